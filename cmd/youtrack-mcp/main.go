@@ -2,6 +2,7 @@ package main
 
 import (
 	"fmt"
+	"os"
 
 	"github.com/mkozhukh/youtrack/internal/mcp"
 
@@ -23,6 +24,21 @@ func main() {
 
 	rootCmd.Flags().BoolVar(&useHTTP, "http", false, "Use StreamableHTTP transport instead of stdio")
 
+	doctorCmd := &cobra.Command{
+		Use:   "doctor",
+		Short: "Check the configuration without starting the server",
+		Long: `Loads the configuration, tests the YouTrack connection and the api key, checks that the
+log, tracker and timer files can be written, verifies the default project and lists the
+tools that will be registered once the blacklist and tools.allow_destructive are applied.
+Exits with an error when any check fails.`,
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return doctor()
+		},
+	}
+	rootCmd.AddCommand(doctorCmd)
+
 	if err := rootCmd.Execute(); err != nil {
 		log.Fatal(err)
 	}
@@ -43,6 +59,11 @@ func run(useHTTP bool) error {
 		return fmt.Errorf("failed to register tools: %w", err)
 	}
 
+	if err := s.WatchConfig(""); err != nil {
+		log.Warn("Config hot reload disabled", "error", err)
+	}
+	watchDebugSignal(s.GetAppLogger())
+
 	if useHTTP {
 		log.Info("Starting server with StreamableHTTP transport")
 		if err := s.ServeHTTP(); err != nil {
@@ -58,6 +79,18 @@ func run(useHTTP bool) error {
 	return nil
 }
 
+func doctor() error {
+	// Keep the server start-up messages out of the report
+	log.SetLevel(log.WarnLevel)
+
+	report := mcp.Doctor("")
+	report.Print(os.Stdout)
+	if report.Failed() {
+		return fmt.Errorf("configuration check failed")
+	}
+	return nil
+}
+
 func logToolCall(toolName string, args map[string]any) {
 	log.Info("Tool call", "tool", toolName, "args", args)
 }
@@ -0,0 +1,26 @@
+//go:build !windows
+
+package main
+
+import (
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/mkozhukh/youtrack/internal/mcp/logging"
+
+	"github.com/charmbracelet/log"
+)
+
+// watchDebugSignal toggles the REST body capture on SIGUSR1
+func watchDebugSignal(appLogger *logging.AppLogger) {
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGUSR1)
+	go func() {
+		for range signals {
+			enabled := !appLogger.Debug()
+			appLogger.SetDebug(enabled)
+			log.Info("REST body capture toggled by SIGUSR1", "enabled", appLogger.Debug(), "file", appLogger.DebugLogPath())
+		}
+	}()
+}
@@ -0,0 +1,8 @@
+//go:build windows
+
+package main
+
+import "github.com/mkozhukh/youtrack/internal/mcp/logging"
+
+// watchDebugSignal does nothing on Windows, which has no SIGUSR1; use logging.debug instead
+func watchDebugSignal(appLogger *logging.AppLogger) {}
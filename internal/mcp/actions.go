@@ -0,0 +1,69 @@
+package mcp
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+
+	"github.com/mkozhukh/youtrack/internal/mcp/audit"
+)
+
+// mutatingTools are the tools that change YouTrack, the timer or the file store, their calls are kept
+// in the session action log read by get_recent_actions
+var mutatingTools = map[string]bool{
+	"create_issue":        true,
+	"create_epic":         true,
+	"update_issue":        true,
+	"batch_update_issues": true,
+	"delete_issue":        true,
+	"apply_command":       true,
+	"add_comment":         true,
+	"tag_issue":           true,
+	"untag_issue":         true,
+	"vote_issue":          true,
+	"unvote_issue":        true,
+	"create_issue_link":   true,
+	"link_pull_request":   true,
+	"upload_attachment":   true,
+	"delete_attachment":   true,
+	"delete_stored_file":  true,
+	"add_worklog":         true,
+	"start_timer":         true,
+	"stop_timer":          true,
+	// posts the summary with post_back
+	"summarize_issue_thread": true,
+	// marks the notifications read with mark_read
+	"get_notifications": true,
+}
+
+// withActionLog wraps a mutating tool handler to record each call, with its arguments and
+// the first line of its result, in the action log of the session
+func (s *MCPServer) withActionLog(toolName string, next server.ToolHandlerFunc) server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		result, err := next(ctx, request)
+
+		action := audit.Action{
+			Time: time.Now(),
+			Tool: toolName,
+			Args: make(map[string]any),
+		}
+		for name, value := range request.GetArguments() {
+			action.Args[name] = value
+		}
+		switch {
+		case err != nil:
+			action.Failed = true
+			action.Result = err.Error()
+		case result != nil:
+			action.Failed = result.IsError
+			action.Result = resultText(result)
+		}
+		action.Result, _, _ = strings.Cut(strings.TrimSpace(action.Result), "\n")
+		s.actionLog.Record(ctx, action)
+
+		return result, err
+	}
+}
@@ -0,0 +1,115 @@
+package audit
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"sync"
+	"time"
+
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// DefaultSize is the number of actions kept per session
+const DefaultSize = 100
+
+// Action is a mutating tool call made in an MCP session
+type Action struct {
+	Time   time.Time      `json:"time"`
+	Tool   string         `json:"tool"`
+	Args   map[string]any `json:"args,omitempty"`
+	Result string         `json:"result,omitempty"`
+	Failed bool           `json:"failed,omitempty"`
+}
+
+// APIKeyProvider provides the effective API key for a context
+type APIKeyProvider interface {
+	GetEffectiveAPIKey(ctx context.Context) string
+}
+
+// Log keeps the recent actions of each MCP session in memory, the oldest are dropped
+// once a session has more than size actions
+type Log struct {
+	mu          sync.Mutex
+	size        int
+	actions     map[string][]Action // session key -> actions, oldest first
+	keyProvider APIKeyProvider
+}
+
+// NewLog creates an action log keeping size actions per session
+func NewLog(size int, keyProvider APIKeyProvider) *Log {
+	if size <= 0 {
+		size = DefaultSize
+	}
+	return &Log{
+		size:        size,
+		actions:     make(map[string][]Action),
+		keyProvider: keyProvider,
+	}
+}
+
+// Record adds an action to the log of the current session
+func (l *Log) Record(ctx context.Context, action Action) {
+	key := l.sessionKey(ctx)
+	if key == "" {
+		return
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	actions := append(l.actions[key], action)
+	if len(actions) > l.size {
+		actions = append([]Action(nil), actions[len(actions)-l.size:]...)
+	}
+	l.actions[key] = actions
+}
+
+// Recent returns the last limit actions of the current session, most recent first
+func (l *Log) Recent(ctx context.Context, limit int) []Action {
+	key := l.sessionKey(ctx)
+	if key == "" {
+		return nil
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	actions := l.actions[key]
+	if limit <= 0 || limit > len(actions) {
+		limit = len(actions)
+	}
+	recent := make([]Action, 0, limit)
+	for i := len(actions) - 1; i >= len(actions)-limit; i-- {
+		recent = append(recent, actions[i])
+	}
+	return recent
+}
+
+// ForgetSession drops the actions of an ended MCP session
+func (l *Log) ForgetSession(sessionID string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	delete(l.actions, sessionKey(sessionID))
+}
+
+// sessionKey identifies the current MCP session, falling back to the user
+// when the transport has no sessions
+func (l *Log) sessionKey(ctx context.Context) string {
+	if session := server.ClientSessionFromContext(ctx); session != nil && session.SessionID() != "" {
+		return sessionKey(session.SessionID())
+	}
+	if l.keyProvider == nil {
+		return ""
+	}
+	if apiKey := l.keyProvider.GetEffectiveAPIKey(ctx); apiKey != "" {
+		hash := sha256.Sum256([]byte(apiKey))
+		return hex.EncodeToString(hash[:])
+	}
+	return ""
+}
+
+// sessionKey returns the log key of an MCP session
+func sessionKey(sessionID string) string {
+	return "session:" + sessionID
+}
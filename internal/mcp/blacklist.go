@@ -0,0 +1,121 @@
+package mcp
+
+import (
+	"fmt"
+	"path"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// Tool categories a tools.blacklist entry can name with @: read tools only read, write
+// tools change YouTrack or the timer, admin tools delete data or manage the server
+const (
+	categoryRead  = "read"
+	categoryWrite = "write"
+	categoryAdmin = "admin"
+)
+
+// adminTools are the tools of the admin category
+var adminTools = map[string]bool{
+	"delete_issue":       true,
+	"delete_attachment":  true,
+	"delete_stored_file": true,
+	"drop_cache":         true,
+	"refresh_cache":      true,
+	"get_server_stats":   true,
+}
+
+// validateBlacklist checks the tools.blacklist entries: tool names, glob patterns such as
+// "upload_*", regular expressions between slashes such as "/^(tag|untag)_issue$/", and
+// categories such as "@write"
+func validateBlacklist(entries []string) error {
+	for _, entry := range entries {
+		switch {
+		case strings.HasPrefix(entry, "@"):
+			if category := entry[1:]; category != categoryRead && category != categoryWrite && category != categoryAdmin {
+				return fmt.Errorf("tools.blacklist: unknown category %q, use @%s, @%s or @%s", entry, categoryRead, categoryWrite, categoryAdmin)
+			}
+		case isRegexEntry(entry):
+			if _, err := regexp.Compile(entry[1 : len(entry)-1]); err != nil {
+				return fmt.Errorf("tools.blacklist: invalid regular expression %q: %w", entry, err)
+			}
+		default:
+			if _, err := path.Match(entry, ""); err != nil {
+				return fmt.Errorf("tools.blacklist: invalid pattern %q: %w", entry, err)
+			}
+		}
+	}
+	return nil
+}
+
+// isRegexEntry reports whether a blacklist entry is a regular expression, written between slashes
+func isRegexEntry(entry string) bool {
+	return len(entry) > 2 && strings.HasPrefix(entry, "/") && strings.HasSuffix(entry, "/")
+}
+
+// toolCategories returns the categories of a tool: read or write, and admin
+func toolCategories(name string, macros []MacroConfig) []string {
+	categories := []string{categoryRead}
+	if mutatingTools[name] || adminTools[name] || isCommandMacro(name, macros) {
+		categories[0] = categoryWrite
+	}
+	if adminTools[name] {
+		categories = append(categories, categoryAdmin)
+	}
+	return categories
+}
+
+// isCommandMacro reports whether a tool is a macro applying a command
+func isCommandMacro(name string, macros []MacroConfig) bool {
+	for _, m := range macros {
+		if m.Name == name {
+			return m.kind() == "command"
+		}
+	}
+	return false
+}
+
+// matchesBlacklist reports whether a blacklist entry matches a tool name
+func (s *MCPServer) matchesBlacklist(entry, name string) bool {
+	switch {
+	case strings.HasPrefix(entry, "@"):
+		return slices.Contains(toolCategories(name, s.config.Macros), entry[1:])
+	case isRegexEntry(entry):
+		matched, _ := regexp.MatchString(entry[1:len(entry)-1], name)
+		return matched
+	default:
+		// A plain name is a pattern matching only itself
+		matched, _ := path.Match(entry, name)
+		return matched
+	}
+}
+
+// inBlacklist reports whether any entry of a blacklist matches a tool name
+func (s *MCPServer) inBlacklist(blacklist []string, name string) bool {
+	for _, entry := range blacklist {
+		if s.matchesBlacklist(entry, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchesAnyTool reports whether a blacklist entry matches a known tool: registered,
+// blacklisted or skipped for another reason
+func (s *MCPServer) matchesAnyTool(entry string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, tool := range s.tools {
+		if s.matchesBlacklist(entry, tool.Tool.Name) {
+			return true
+		}
+	}
+	for name := range s.skippedTools {
+		if s.matchesBlacklist(entry, name) {
+			return true
+		}
+	}
+	return false
+}
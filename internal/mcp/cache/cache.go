@@ -1,6 +1,7 @@
 package cache
 
 import (
+	"strings"
 	"sync"
 	"time"
 
@@ -18,21 +19,69 @@ func (e *entry) isExpired() bool {
 	return time.Now().After(e.expiration)
 }
 
-// ProjectCache provides per-project caching for metadata
+// ProjectCache provides per-project caching for metadata. It is safe for concurrent use.
 type ProjectCache struct {
-	mu           sync.RWMutex
-	ttl          time.Duration
-	customFields map[string]*entry // projectID -> custom fields
-	users        map[string]*entry // projectID -> users
+	mu            sync.RWMutex
+	ttl           time.Duration
+	generation    uint64            // changed by every drop, see storeSince
+	customFields  map[string]*entry // projectID -> custom fields
+	projectFields map[string]*entry // projectID -> custom fields with their types
+	users         map[string]*entry // projectID -> users
+	allowedValues map[string]*entry // projectID/fieldName -> allowed values
+	linkTypes     *entry            // instance-wide link types
+	projects      map[string]*entry // user key -> all projects visible to the user
+
+	// lookups counts the hits and misses by kind, the map is not changed after creation
+	lookups map[string]*lookupCounter
 }
 
 // NewProjectCache creates a new cache with the specified TTL
 func NewProjectCache(ttl time.Duration) *ProjectCache {
-	return &ProjectCache{
-		ttl:          ttl,
-		customFields: make(map[string]*entry),
-		users:        make(map[string]*entry),
+	c := &ProjectCache{
+		ttl:           ttl,
+		customFields:  make(map[string]*entry),
+		projectFields: make(map[string]*entry),
+		users:         make(map[string]*entry),
+		allowedValues: make(map[string]*entry),
+		projects:      make(map[string]*entry),
+		lookups:       make(map[string]*lookupCounter),
+	}
+	for _, kind := range cacheKinds {
+		c.lookups[kind] = &lookupCounter{}
 	}
+	return c
+}
+
+// SetTTL changes the TTL of the entries cached from now on, the cached ones keep their expiration
+func (c *ProjectCache) SetTTL(ttl time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.ttl = ttl
+}
+
+// currentGeneration returns the generation to pass to the set methods of a fetch started now
+func (c *ProjectCache) currentGeneration() uint64 {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.generation
+}
+
+// storeSince stores a value fetched since generation, unless the cache was dropped meanwhile:
+// a fetch that was in flight during a drop would store the data the drop meant to discard
+func (c *ProjectCache) storeSince(generation uint64, store func(e *entry)) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.generation != generation {
+		return
+	}
+	store(&entry{expiration: time.Now().Add(c.ttl)})
+}
+
+// allowedValuesKey builds the cache key for a project field, with the project ID as the
+// other maps are keyed by
+func allowedValuesKey(projectID, fieldName string) string {
+	return projectID + "/" + strings.ToLower(fieldName)
 }
 
 // GetCustomFields retrieves cached custom fields for a project
@@ -42,7 +91,9 @@ func (c *ProjectCache) GetCustomFields(projectID string) []*youtrack.CustomField
 	defer c.mu.RUnlock()
 
 	e, ok := c.customFields[projectID]
-	if !ok || e.isExpired() {
+	hit := ok && !e.isExpired()
+	c.lookups[kindCustomFields].count(hit)
+	if !hit {
 		return nil
 	}
 
@@ -51,13 +102,44 @@ func (c *ProjectCache) GetCustomFields(projectID string) []*youtrack.CustomField
 
 // SetCustomFields stores custom fields for a project
 func (c *ProjectCache) SetCustomFields(projectID string, fields []*youtrack.CustomField) {
-	c.mu.Lock()
-	defer c.mu.Unlock()
+	c.setCustomFields(c.currentGeneration(), projectID, fields)
+}
+
+// setCustomFields stores custom fields for a project fetched since generation
+func (c *ProjectCache) setCustomFields(generation uint64, projectID string, fields []*youtrack.CustomField) {
+	c.storeSince(generation, func(e *entry) {
+		e.value = fields
+		c.customFields[projectID] = e
+	})
+}
+
+// GetProjectFields retrieves the cached custom fields of a project with their types and bundles
+// Returns nil if not cached or expired
+func (c *ProjectCache) GetProjectFields(projectID string) []*youtrack.ProjectCustomField {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
 
-	c.customFields[projectID] = &entry{
-		value:      fields,
-		expiration: time.Now().Add(c.ttl),
+	e, ok := c.projectFields[projectID]
+	hit := ok && !e.isExpired()
+	c.lookups[kindProjectFields].count(hit)
+	if !hit {
+		return nil
 	}
+
+	return e.value.([]*youtrack.ProjectCustomField)
+}
+
+// SetProjectFields stores the custom fields of a project with their types and bundles
+func (c *ProjectCache) SetProjectFields(projectID string, fields []*youtrack.ProjectCustomField) {
+	c.setProjectFields(c.currentGeneration(), projectID, fields)
+}
+
+// setProjectFields stores the custom fields of a project with their types fetched since generation
+func (c *ProjectCache) setProjectFields(generation uint64, projectID string, fields []*youtrack.ProjectCustomField) {
+	c.storeSince(generation, func(e *entry) {
+		e.value = fields
+		c.projectFields[projectID] = e
+	})
 }
 
 // GetUsers retrieves cached users for a project
@@ -67,7 +149,9 @@ func (c *ProjectCache) GetUsers(projectID string) []*youtrack.User {
 	defer c.mu.RUnlock()
 
 	e, ok := c.users[projectID]
-	if !ok || e.isExpired() {
+	hit := ok && !e.isExpired()
+	c.lookups[kindUsers].count(hit)
+	if !hit {
 		return nil
 	}
 
@@ -76,13 +160,101 @@ func (c *ProjectCache) GetUsers(projectID string) []*youtrack.User {
 
 // SetUsers stores users for a project
 func (c *ProjectCache) SetUsers(projectID string, users []*youtrack.User) {
-	c.mu.Lock()
-	defer c.mu.Unlock()
+	c.setUsers(c.currentGeneration(), projectID, users)
+}
+
+// setUsers stores users for a project fetched since generation
+func (c *ProjectCache) setUsers(generation uint64, projectID string, users []*youtrack.User) {
+	c.storeSince(generation, func(e *entry) {
+		e.value = users
+		c.users[projectID] = e
+	})
+}
 
-	c.users[projectID] = &entry{
-		value:      users,
-		expiration: time.Now().Add(c.ttl),
+// GetAllowedValues retrieves cached allowed values for a project field
+// Returns nil if not cached or expired
+func (c *ProjectCache) GetAllowedValues(projectID, fieldName string) []youtrack.AllowedValue {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	e, ok := c.allowedValues[allowedValuesKey(projectID, fieldName)]
+	hit := ok && !e.isExpired()
+	c.lookups[kindAllowedValues].count(hit)
+	if !hit {
+		return nil
 	}
+
+	return e.value.([]youtrack.AllowedValue)
+}
+
+// SetAllowedValues stores allowed values for a project field
+func (c *ProjectCache) SetAllowedValues(projectID, fieldName string, values []youtrack.AllowedValue) {
+	c.setAllowedValues(c.currentGeneration(), projectID, fieldName, values)
+}
+
+// setAllowedValues stores allowed values for a project field fetched since generation
+func (c *ProjectCache) setAllowedValues(generation uint64, projectID, fieldName string, values []youtrack.AllowedValue) {
+	c.storeSince(generation, func(e *entry) {
+		e.value = values
+		c.allowedValues[allowedValuesKey(projectID, fieldName)] = e
+	})
+}
+
+// GetLinkTypes retrieves cached link types
+// Returns nil if not cached or expired
+func (c *ProjectCache) GetLinkTypes() []*youtrack.LinkType {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	hit := c.linkTypes != nil && !c.linkTypes.isExpired()
+	c.lookups[kindLinkTypes].count(hit)
+	if !hit {
+		return nil
+	}
+
+	return c.linkTypes.value.([]*youtrack.LinkType)
+}
+
+// SetLinkTypes stores the instance link types
+func (c *ProjectCache) SetLinkTypes(linkTypes []*youtrack.LinkType) {
+	c.setLinkTypes(c.currentGeneration(), linkTypes)
+}
+
+// setLinkTypes stores the instance link types fetched since generation
+func (c *ProjectCache) setLinkTypes(generation uint64, linkTypes []*youtrack.LinkType) {
+	c.storeSince(generation, func(e *entry) {
+		e.value = linkTypes
+		c.linkTypes = e
+	})
+}
+
+// GetProjects retrieves the cached project list of a user, see CachedClient.SetUserKey
+// Returns nil if not cached or expired
+func (c *ProjectCache) GetProjects(user string) []*youtrack.Project {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	e, ok := c.projects[user]
+	hit := ok && !e.isExpired()
+	c.lookups[kindProjects].count(hit)
+	if !hit {
+		return nil
+	}
+
+	return e.value.([]*youtrack.Project)
+}
+
+// SetProjects stores the project list of a user
+func (c *ProjectCache) SetProjects(user string, projects []*youtrack.Project) {
+	c.setProjects(c.currentGeneration(), user, projects)
+}
+
+// setProjects stores the project list of a user fetched since generation
+func (c *ProjectCache) setProjects(generation uint64, user string, projects []*youtrack.Project) {
+	c.storeSince(generation, func(e *entry) {
+		e.value = projects
+		c.projects[user] = e
+	})
 }
 
 // DropProject removes all cached data for a specific project
@@ -90,8 +262,15 @@ func (c *ProjectCache) DropProject(projectID string) {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
+	c.generation++
 	delete(c.customFields, projectID)
+	delete(c.projectFields, projectID)
 	delete(c.users, projectID)
+	for key := range c.allowedValues {
+		if strings.HasPrefix(key, projectID+"/") {
+			delete(c.allowedValues, key)
+		}
+	}
 }
 
 // DropAll clears all cached data
@@ -99,6 +278,11 @@ func (c *ProjectCache) DropAll() {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
+	c.generation++
 	c.customFields = make(map[string]*entry)
+	c.projectFields = make(map[string]*entry)
 	c.users = make(map[string]*entry)
+	c.allowedValues = make(map[string]*entry)
+	c.linkTypes = nil
+	c.projects = make(map[string]*entry)
 }
@@ -2,6 +2,10 @@ package cache
 
 import (
 	"context"
+	"errors"
+	"fmt"
+
+	"golang.org/x/sync/singleflight"
 
 	"github.com/mkozhukh/youtrack/pkg/youtrack"
 )
@@ -12,20 +16,26 @@ type ProjectClient interface {
 	GetProjectByName(ctx context.Context, name string) (*youtrack.Project, error)
 	ListProjects(ctx context.Context, skip, top int) ([]*youtrack.Project, error)
 	GetProjectCustomFields(ctx context.Context, projectID string) ([]*youtrack.CustomField, error)
+	ListProjectCustomFields(ctx context.Context, projectID string) ([]*youtrack.ProjectCustomField, error)
 	GetCustomFieldAllowedValues(ctx context.Context, projectID string, fieldName string) ([]youtrack.AllowedValue, error)
 	GetAvailableLinkTypes(ctx context.Context) ([]*youtrack.LinkType, error)
+	GetProjectStats(ctx context.Context, projectID string, opts youtrack.ProjectStatsOptions) (*youtrack.ProjectStats, error)
 }
 
 // UserClient defines the interface for user-related operations
 type UserClient interface {
 	GetCurrentUser(ctx context.Context) (*youtrack.User, error)
 	GetProjectUsers(ctx context.Context, projectID string, skip, top int) ([]*youtrack.User, error)
+	GetAssigneeLoad(ctx context.Context, projectID string, opts youtrack.AssigneeLoadOptions) ([]*youtrack.AssigneeLoad, error)
 }
 
-// CachedClient wraps a client with caching functionality
+// CachedClient wraps a client with caching functionality. Concurrent misses of the same
+// entry share one upstream request.
 type CachedClient struct {
 	delegate ProjectAndUserClient
 	cache    *ProjectCache
+	flights  singleflight.Group
+	userKey  func(ctx context.Context) string
 }
 
 // ProjectAndUserClient combines ProjectClient and UserClient interfaces
@@ -39,6 +49,32 @@ func NewCachedClient(delegate ProjectAndUserClient, cache *ProjectCache) *Cached
 	return &CachedClient{
 		delegate: delegate,
 		cache:    cache,
+		userKey:  func(ctx context.Context) string { return "" },
+	}
+}
+
+// SetUserKey sets how the user of a call is told apart, e.g. by a hash of its API key: fetches
+// are only joined between calls of the same user, and the data that depends on who asks,
+// the project list, is cached per user. All calls share them by default.
+func (c *CachedClient) SetUserKey(fn func(ctx context.Context) string) {
+	c.userKey = fn
+}
+
+// load fetches a value on a cache miss, joining the fetch of the same key already in flight
+// for the same user, as it runs with the API key of the call that started it.
+// The fetch stores its result with the cache generation it started at.
+func (c *CachedClient) load(ctx context.Context, key string, fetch func(ctx context.Context, generation uint64) (interface{}, error)) (interface{}, error) {
+	key = c.userKey(ctx) + "/" + key
+	for {
+		value, err, shared := c.flights.Do(key, func() (interface{}, error) {
+			return fetch(ctx, c.cache.currentGeneration())
+		})
+		// A shared fetch fails when the call that started it is cancelled, the other
+		// calls retry with their own context
+		if err != nil && shared && ctx.Err() == nil && (errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded)) {
+			continue
+		}
+		return value, err
 	}
 }
 
@@ -57,6 +93,44 @@ func (c *CachedClient) ListProjects(ctx context.Context, skip, top int) ([]*yout
 	return c.delegate.ListProjects(ctx, skip, top)
 }
 
+// ListAllProjects returns all projects, using cache when available
+func (c *CachedClient) ListAllProjects(ctx context.Context) ([]*youtrack.Project, error) {
+	// Check cache first, the list depends on the permissions of the user
+	user := c.userKey(ctx)
+	if projects := c.cache.GetProjects(user); projects != nil {
+		return projects, nil
+	}
+
+	value, err := c.load(ctx, "projects", func(ctx context.Context, generation uint64) (interface{}, error) {
+		// Fetch all projects with pagination
+		var allProjects []*youtrack.Project
+		skip := 0
+		top := 100
+
+		for {
+			projects, err := c.delegate.ListProjects(ctx, skip, top)
+			if err != nil {
+				return nil, err
+			}
+
+			allProjects = append(allProjects, projects...)
+
+			if len(projects) < top {
+				break
+			}
+			skip += top
+		}
+
+		// Store in cache
+		c.cache.setProjects(generation, user, allProjects)
+		return allProjects, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return value.([]*youtrack.Project), nil
+}
+
 // GetProjectCustomFields returns cached custom fields or fetches from API
 func (c *CachedClient) GetProjectCustomFields(ctx context.Context, projectID string) ([]*youtrack.CustomField, error) {
 	// Check cache first
@@ -64,25 +138,113 @@ func (c *CachedClient) GetProjectCustomFields(ctx context.Context, projectID str
 		return cached, nil
 	}
 
-	// Fetch from API
-	fields, err := c.delegate.GetProjectCustomFields(ctx, projectID)
+	value, err := c.load(ctx, "fields:"+projectID, func(ctx context.Context, generation uint64) (interface{}, error) {
+		// Fetch from API
+		fields, err := c.delegate.GetProjectCustomFields(ctx, projectID)
+		if err != nil {
+			return nil, err
+		}
+
+		// Store in cache
+		c.cache.setCustomFields(generation, projectID, fields)
+		return fields, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return value.([]*youtrack.CustomField), nil
+}
+
+// ListProjectCustomFields returns the cached custom fields of a project with their types and
+// bundles, or fetches them from API
+func (c *CachedClient) ListProjectCustomFields(ctx context.Context, projectID string) ([]*youtrack.ProjectCustomField, error) {
+	// Check cache first
+	if cached := c.cache.GetProjectFields(projectID); cached != nil {
+		return cached, nil
+	}
+
+	value, err := c.load(ctx, "field-types:"+projectID, func(ctx context.Context, generation uint64) (interface{}, error) {
+		// Fetch from API
+		fields, err := c.delegate.ListProjectCustomFields(ctx, projectID)
+		if err != nil {
+			return nil, err
+		}
+
+		// Store in cache
+		c.cache.setProjectFields(generation, projectID, fields)
+		return fields, nil
+	})
 	if err != nil {
 		return nil, err
 	}
+	return value.([]*youtrack.ProjectCustomField), nil
+}
 
-	// Store in cache
-	c.cache.SetCustomFields(projectID, fields)
-	return fields, nil
+// NewCustomFieldPatch creates a custom field patch builder from the cached fields of a project
+func (c *CachedClient) NewCustomFieldPatch(ctx context.Context, projectID string) (*youtrack.CustomFieldPatch, error) {
+	fields, err := c.ListProjectCustomFields(ctx, projectID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get project custom fields: %w", err)
+	}
+	return youtrack.NewCustomFieldPatch(fields), nil
 }
 
-// GetCustomFieldAllowedValues delegates to the underlying client (no caching)
+// GetCustomFieldAllowedValues returns cached allowed values or fetches from API
 func (c *CachedClient) GetCustomFieldAllowedValues(ctx context.Context, projectID string, fieldName string) ([]youtrack.AllowedValue, error) {
-	return c.delegate.GetCustomFieldAllowedValues(ctx, projectID, fieldName)
+	// Check cache first
+	if cached := c.cache.GetAllowedValues(projectID, fieldName); cached != nil {
+		return cached, nil
+	}
+
+	value, err := c.load(ctx, "values:"+allowedValuesKey(projectID, fieldName), func(ctx context.Context, generation uint64) (interface{}, error) {
+		// Fetch from API
+		values, err := c.delegate.GetCustomFieldAllowedValues(ctx, projectID, fieldName)
+		if err != nil {
+			return nil, err
+		}
+
+		// Store in cache
+		c.cache.setAllowedValues(generation, projectID, fieldName, values)
+		return values, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return value.([]youtrack.AllowedValue), nil
+}
+
+// GetProjectStats delegates to the underlying client (no caching, the counts change all the time)
+func (c *CachedClient) GetProjectStats(ctx context.Context, projectID string, opts youtrack.ProjectStatsOptions) (*youtrack.ProjectStats, error) {
+	return c.delegate.GetProjectStats(ctx, projectID, opts)
+}
+
+// GetAssigneeLoad delegates to the underlying client (no caching, the workload changes all the time)
+func (c *CachedClient) GetAssigneeLoad(ctx context.Context, projectID string, opts youtrack.AssigneeLoadOptions) ([]*youtrack.AssigneeLoad, error) {
+	return c.delegate.GetAssigneeLoad(ctx, projectID, opts)
 }
 
-// GetAvailableLinkTypes delegates to the underlying client (no caching)
+// GetAvailableLinkTypes returns cached link types or fetches from API
 func (c *CachedClient) GetAvailableLinkTypes(ctx context.Context) ([]*youtrack.LinkType, error) {
-	return c.delegate.GetAvailableLinkTypes(ctx)
+	// Check cache first
+	if cached := c.cache.GetLinkTypes(); cached != nil {
+		return cached, nil
+	}
+
+	value, err := c.load(ctx, "linktypes", func(ctx context.Context, generation uint64) (interface{}, error) {
+		// Fetch from API
+		linkTypes, err := c.delegate.GetAvailableLinkTypes(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		// Store in cache
+		c.cache.setLinkTypes(generation, linkTypes)
+		return linkTypes, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return value.([]*youtrack.LinkType), nil
 }
 
 // GetCurrentUser delegates to the underlying client (no caching)
@@ -93,55 +255,58 @@ func (c *CachedClient) GetCurrentUser(ctx context.Context) (*youtrack.User, erro
 // GetProjectUsers returns cached users or fetches all pages from API
 func (c *CachedClient) GetProjectUsers(ctx context.Context, projectID string, skip, top int) ([]*youtrack.User, error) {
 	// Check cache first
-	cachedUsers := c.cache.GetUsers(projectID)
-	if cachedUsers != nil {
-		// Return requested slice from cached data
-		if skip >= len(cachedUsers) {
-			return []*youtrack.User{}, nil
-		}
-		end := skip + top
-		if end > len(cachedUsers) {
-			end = len(cachedUsers)
-		}
-		return cachedUsers[skip:end], nil
+	if cachedUsers := c.cache.GetUsers(projectID); cachedUsers != nil {
+		return pageUsers(cachedUsers, skip, top), nil
 	}
 
-	// Fetch all users from API (paginated)
-	var allUsers []*youtrack.User
-	fetchSkip := 0
-	fetchTop := 100
+	value, err := c.load(ctx, "users:"+projectID, func(ctx context.Context, generation uint64) (interface{}, error) {
+		// Fetch all users from API (paginated)
+		var allUsers []*youtrack.User
+		fetchSkip := 0
+		fetchTop := 100
 
-	for {
-		users, err := c.delegate.GetProjectUsers(ctx, projectID, fetchSkip, fetchTop)
-		if err != nil {
-			return nil, err
-		}
+		for {
+			users, err := c.delegate.GetProjectUsers(ctx, projectID, fetchSkip, fetchTop)
+			if err != nil {
+				return nil, err
+			}
 
-		if len(users) == 0 {
-			break
-		}
+			if len(users) == 0 {
+				break
+			}
 
-		allUsers = append(allUsers, users...)
+			allUsers = append(allUsers, users...)
 
-		if len(users) < fetchTop {
-			break
+			if len(users) < fetchTop {
+				break
+			}
+
+			fetchSkip += len(users)
 		}
 
-		fetchSkip += len(users)
+		// Store complete list in cache
+		c.cache.setUsers(generation, projectID, allUsers)
+		return allUsers, nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	// Store complete list in cache
-	c.cache.SetUsers(projectID, allUsers)
-
 	// Return requested slice
-	if skip >= len(allUsers) {
-		return []*youtrack.User{}, nil
+	return pageUsers(value.([]*youtrack.User), skip, top), nil
+}
+
+// pageUsers returns a page of the cached users. The page is capped at its length, so a
+// caller appending to it cannot overwrite the cached list shared with other calls.
+func pageUsers(users []*youtrack.User, skip, top int) []*youtrack.User {
+	if skip >= len(users) {
+		return []*youtrack.User{}
 	}
 	end := skip + top
-	if end > len(allUsers) {
-		end = len(allUsers)
+	if end > len(users) {
+		end = len(users)
 	}
-	return allUsers[skip:end], nil
+	return users[skip:end:end]
 }
 
 // Cache returns the underlying cache for management operations
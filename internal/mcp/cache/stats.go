@@ -0,0 +1,90 @@
+package cache
+
+import "sync/atomic"
+
+// Kinds of cached data, as reported in the stats
+const (
+	kindCustomFields  = "custom_fields"
+	kindProjectFields = "field_types"
+	kindAllowedValues = "allowed_values"
+	kindUsers         = "users"
+	kindLinkTypes     = "link_types"
+	kindProjects      = "projects"
+)
+
+// cacheKinds lists the kinds in the order of the stats
+var cacheKinds = []string{kindCustomFields, kindProjectFields, kindAllowedValues, kindUsers, kindLinkTypes, kindProjects}
+
+// Stats are the lookups of the cache since the server started
+type Stats struct {
+	Hits     int64       `json:"hits"`
+	Misses   int64       `json:"misses"`
+	HitRatio float64     `json:"hit_ratio"`
+	Kinds    []KindStats `json:"kinds"`
+}
+
+// KindStats are the lookups and the cached entries of one kind of data
+type KindStats struct {
+	Kind     string  `json:"kind"`
+	Entries  int     `json:"entries"`
+	Hits     int64   `json:"hits"`
+	Misses   int64   `json:"misses"`
+	HitRatio float64 `json:"hit_ratio"`
+}
+
+// lookupCounter counts the hits and misses of a kind
+type lookupCounter struct {
+	hits   atomic.Int64
+	misses atomic.Int64
+}
+
+// count records a lookup, a hit when the entry was found
+func (c *lookupCounter) count(hit bool) {
+	if hit {
+		c.hits.Add(1)
+	} else {
+		c.misses.Add(1)
+	}
+}
+
+// hitRatio returns the share of lookups that were hits, 0 without lookups
+func hitRatio(hits, misses int64) float64 {
+	if hits+misses == 0 {
+		return 0
+	}
+	return float64(hits) / float64(hits+misses)
+}
+
+// Stats returns the hits, misses and entries of the cache; expired entries are counted
+// until they are replaced
+func (c *ProjectCache) Stats() Stats {
+	c.mu.RLock()
+	entries := map[string]int{
+		kindCustomFields:  len(c.customFields),
+		kindProjectFields: len(c.projectFields),
+		kindAllowedValues: len(c.allowedValues),
+		kindUsers:         len(c.users),
+		kindProjects:      len(c.projects),
+	}
+	if c.linkTypes != nil {
+		entries[kindLinkTypes] = 1
+	}
+	c.mu.RUnlock()
+
+	var stats Stats
+	for _, kind := range cacheKinds {
+		counter := c.lookups[kind]
+		kindStats := KindStats{
+			Kind:    kind,
+			Entries: entries[kind],
+			Hits:    counter.hits.Load(),
+			Misses:  counter.misses.Load(),
+		}
+		kindStats.HitRatio = hitRatio(kindStats.Hits, kindStats.Misses)
+		stats.Hits += kindStats.Hits
+		stats.Misses += kindStats.Misses
+		stats.Kinds = append(stats.Kinds, kindStats)
+	}
+	stats.HitRatio = hitRatio(stats.Hits, stats.Misses)
+	return stats
+}
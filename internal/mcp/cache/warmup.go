@@ -0,0 +1,51 @@
+package cache
+
+import (
+	"context"
+	"time"
+
+	"github.com/charmbracelet/log"
+)
+
+// WarmUp pre-fetches custom fields, allowed values, link types and project users
+// into the cache, so the first tool calls of a session don't pay for resolver lookups.
+// Failures are logged and skipped; warm-up never blocks server startup.
+func (c *CachedClient) WarmUp(ctx context.Context, projectIDs []string) {
+	start := time.Now()
+
+	if _, err := c.GetAvailableLinkTypes(ctx); err != nil {
+		log.Warn("Cache warm-up: failed to fetch link types", "error", err)
+	}
+
+	for _, projectID := range projectIDs {
+		if ctx.Err() != nil {
+			return
+		}
+		c.warmProject(ctx, projectID)
+	}
+
+	log.Info("Cache warm-up completed", "projects", projectIDs, "duration", time.Since(start))
+}
+
+// warmProject pre-fetches the metadata of a single project
+func (c *CachedClient) warmProject(ctx context.Context, projectID string) {
+	fields, err := c.GetProjectCustomFields(ctx, projectID)
+	if err != nil {
+		log.Warn("Cache warm-up: failed to fetch custom fields", "project", projectID, "error", err)
+		return
+	}
+
+	// Fields without a bundle (text, date, period) return an error here, which is expected
+	warmed := 0
+	for _, field := range fields {
+		if _, err := c.GetCustomFieldAllowedValues(ctx, projectID, field.Name); err == nil {
+			warmed++
+		}
+	}
+
+	if _, err := c.GetProjectUsers(ctx, projectID, 0, 1); err != nil {
+		log.Warn("Cache warm-up: failed to fetch project users", "project", projectID, "error", err)
+	}
+
+	log.Info("Cache warmed for project", "project", projectID, "fields", len(fields), "bundles", warmed)
+}
@@ -0,0 +1,45 @@
+package mcp
+
+import (
+	"context"
+	"time"
+
+	"github.com/charmbracelet/log"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// withCallLog wraps a tool handler to log each completed call with the same fields in text
+// and JSON output: tool, key (API key hash), duration_ms and, for failed calls, error
+func (s *MCPServer) withCallLog(toolName string, next server.ToolHandlerFunc) server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		start := time.Now()
+		result, err := next(ctx, request)
+
+		fields := []interface{}{
+			"tool", toolName,
+			"key", s.ytClient.GetKeyHash(ctx),
+			"duration_ms", time.Since(start).Milliseconds(),
+		}
+		s.appLogger.CountToolCall(toolName, err != nil || (result != nil && result.IsError))
+		switch {
+		case err != nil:
+			log.Warn("Tool call failed", append(fields, "error", err.Error())...)
+		case result != nil && result.IsError:
+			log.Warn("Tool call failed", append(fields, "error", resultText(result))...)
+		default:
+			log.Info("Tool call completed", fields...)
+		}
+		return result, err
+	}
+}
+
+// resultText returns the text content of a tool result
+func resultText(result *mcp.CallToolResult) string {
+	for _, content := range result.Content {
+		if text, ok := content.(mcp.TextContent); ok {
+			return text.Text
+		}
+	}
+	return ""
+}
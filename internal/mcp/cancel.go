@@ -0,0 +1,108 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/charmbracelet/log"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// callIDMetaKey is the request meta key used to pass the JSON-RPC request ID to tool handlers
+const callIDMetaKey = "youtrack/callId"
+
+// CallCanceller tracks in-flight tool calls so that a client's notifications/cancelled
+// aborts the matching call and every YouTrack request made on its behalf
+type CallCanceller struct {
+	mu    sync.Mutex
+	calls map[string]context.CancelFunc
+}
+
+// NewCallCanceller creates a new call canceller
+func NewCallCanceller() *CallCanceller {
+	return &CallCanceller{
+		calls: make(map[string]context.CancelFunc),
+	}
+}
+
+// Register attaches the canceller hooks, middleware and notification handler to the server options
+func (c *CallCanceller) Register(hooks *server.Hooks) server.ServerOption {
+	hooks.AddBeforeCallTool(c.beforeCallTool)
+	return server.WithToolHandlerMiddleware(c.middleware)
+}
+
+// beforeCallTool stamps the request ID into the request meta so the middleware can find it
+func (c *CallCanceller) beforeCallTool(ctx context.Context, id any, request *mcp.CallToolRequest) {
+	if id == nil {
+		return
+	}
+	if request.Params.Meta == nil {
+		request.Params.Meta = &mcp.Meta{}
+	}
+	if request.Params.Meta.AdditionalFields == nil {
+		request.Params.Meta.AdditionalFields = make(map[string]any)
+	}
+	request.Params.Meta.AdditionalFields[callIDMetaKey] = callKey(id)
+}
+
+// middleware runs each tool handler with a cancellable context registered under its request ID
+func (c *CallCanceller) middleware(next server.ToolHandlerFunc) server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		var key string
+		if request.Params.Meta != nil {
+			key, _ = request.Params.Meta.AdditionalFields[callIDMetaKey].(string)
+		}
+		if key == "" {
+			return next(ctx, request)
+		}
+
+		ctx, cancel := context.WithCancel(ctx)
+		c.mu.Lock()
+		c.calls[key] = cancel
+		c.mu.Unlock()
+
+		defer func() {
+			c.mu.Lock()
+			delete(c.calls, key)
+			c.mu.Unlock()
+			cancel()
+		}()
+
+		return next(ctx, request)
+	}
+}
+
+// HandleCancelled handles the notifications/cancelled notification sent by clients
+func (c *CallCanceller) HandleCancelled(ctx context.Context, notification mcp.JSONRPCNotification) {
+	id, ok := notification.Params.AdditionalFields["requestId"]
+	if !ok || id == nil {
+		return
+	}
+
+	key := callKey(id)
+	c.mu.Lock()
+	cancel, found := c.calls[key]
+	c.mu.Unlock()
+
+	if found {
+		log.Info("Tool call cancelled by client", "request_id", key, "reason", notification.Params.AdditionalFields["reason"])
+		cancel()
+	}
+}
+
+// callKey normalizes a JSON-RPC request ID so numeric IDs match regardless of their decoded type
+func callKey(id any) string {
+	switch v := id.(type) {
+	case mcp.RequestId:
+		return callKey(v.Value())
+	case float64:
+		if v == float64(int64(v)) {
+			return fmt.Sprintf("%d", int64(v))
+		}
+		return fmt.Sprintf("%g", v)
+	default:
+		return fmt.Sprintf("%v", v)
+	}
+}
@@ -3,7 +3,9 @@ package mcp
 import (
 	"context"
 	"fmt"
+	"io"
 	"strings"
+	"time"
 
 	"github.com/mkozhukh/youtrack/internal/mcp/logging"
 	"github.com/mkozhukh/youtrack/pkg/youtrack"
@@ -13,7 +15,7 @@ import (
 
 // YouTrackClient wraps the YouTrack client with configuration and context handling
 type YouTrackClient struct {
-	client     *youtrack.Client
+	client     youtrack.API
 	config     YouTrackConfig
 	defaultCtx *youtrack.YouTrackContext
 	appLogger  *logging.AppLogger
@@ -36,10 +38,23 @@ func NewYouTrackClient(config YouTrackConfig, appLogger *logging.AppLogger) (*Yo
 	if appLogger != nil && config.APIKey != "" {
 		keyHash := logging.HashAPIKey(config.APIKey)
 		client.SetLogger(appLogger.NewRESTLoggerWithContext(keyHash))
+	} else if appLogger != nil {
+		// Count the calls for the server stats all the same
+		client.SetLogger(appLogger.NewRESTCounter())
 	}
 
-	// Note: The client timeout is set in the NewClient constructor
-	// We would need to modify the client constructor to support custom timeouts
+	// Tune connection pooling and protocol for the many small API calls
+	transport := youtrack.DefaultTransportConfig()
+	if config.MaxIdleConnsPerHost > 0 {
+		transport.MaxIdleConnsPerHost = config.MaxIdleConnsPerHost
+	}
+	transport.EnableHTTP2 = config.HTTP2
+	client.SetTransportConfig(transport)
+
+	// Apply the configured request timeout
+	if config.Timeout > 0 {
+		client.SetTimeout(time.Duration(config.Timeout) * time.Second)
+	}
 
 	// Create default context
 	defaultCtx := youtrack.NewYouTrackContext(context.Background(), config.APIKey)
@@ -182,7 +197,7 @@ func (c *YouTrackClient) GetDefaultContext() *youtrack.YouTrackContext {
 }
 
 // GetClient returns the underlying YouTrack client
-func (c *YouTrackClient) GetClient() *youtrack.Client {
+func (c *YouTrackClient) GetClient() youtrack.API {
 	return c.client
 }
 
@@ -194,6 +209,12 @@ func (c *YouTrackClient) GetIssue(ctx context.Context, issueID string) (*youtrac
 	return c.client.GetIssue(ytCtx, issueID)
 }
 
+// GetIssuesByIDs fetches several issues by readable ID
+func (c *YouTrackClient) GetIssuesByIDs(ctx context.Context, ids []string) ([]*youtrack.Issue, error) {
+	ytCtx := c.WithContext(ctx)
+	return c.client.GetIssuesByIDs(ytCtx, ids)
+}
+
 // SearchIssues searches for issues with optional parameters
 func (c *YouTrackClient) SearchIssues(ctx context.Context, query string, skip, top int) ([]*youtrack.Issue, error) {
 	ytCtx := c.WithContext(ctx)
@@ -218,6 +239,18 @@ func (c *YouTrackClient) CreateIssue(ctx context.Context, req *youtrack.CreateIs
 	return c.client.CreateIssue(ytCtx, req)
 }
 
+// CreateIssueTree creates a parent issue and its subtasks, rolled back when a step fails
+func (c *YouTrackClient) CreateIssueTree(ctx context.Context, parent *youtrack.CreateIssueRequest, subtasks []*youtrack.CreateIssueRequest) (*youtrack.IssueTree, error) {
+	ytCtx := c.WithContext(ctx)
+
+	// Use default project if not specified
+	if parent.Project.ID == "" && c.config.DefaultProject != "" {
+		parent.Project = youtrack.ProjectRef{ID: c.config.DefaultProject}
+	}
+
+	return c.client.CreateIssueTree(ytCtx, parent, subtasks)
+}
+
 // UpdateIssue updates an existing issue
 func (c *YouTrackClient) UpdateIssue(ctx context.Context, issueID string, req *youtrack.UpdateIssueRequest) (*youtrack.Issue, error) {
 	ytCtx := c.WithContext(ctx)
@@ -236,6 +269,24 @@ func (c *YouTrackClient) UpdateIssueAssigneeByProject(ctx context.Context, issue
 	return c.client.UpdateIssueAssigneeByProject(ytCtx, issueID, projectID, username)
 }
 
+// ExecuteBatch runs the operations of a batch with bounded concurrency and retries
+func (c *YouTrackClient) ExecuteBatch(ctx context.Context, ops []youtrack.BatchOperation, opts youtrack.BatchOptions) *youtrack.BatchReport {
+	ytCtx := c.WithContext(ctx)
+	return youtrack.NewBatchExecutor(c.client, opts).Execute(ytCtx, ops)
+}
+
+// NewCustomFieldPatch creates a custom field patch builder for a project
+func (c *YouTrackClient) NewCustomFieldPatch(ctx context.Context, projectID string) (*youtrack.CustomFieldPatch, error) {
+	ytCtx := c.WithContext(ctx)
+	return c.client.NewCustomFieldPatch(ytCtx, projectID)
+}
+
+// ResolveVisibility builds a limited visibility from group names and user logins
+func (c *YouTrackClient) ResolveVisibility(ctx context.Context, groupNames, userLogins []string) (*youtrack.Visibility, error) {
+	ytCtx := c.WithContext(ctx)
+	return c.client.ResolveVisibility(ytCtx, groupNames, userLogins)
+}
+
 // DeleteIssue deletes an issue
 func (c *YouTrackClient) DeleteIssue(ctx context.Context, issueID string) error {
 	ytCtx := c.WithContext(ctx)
@@ -256,6 +307,12 @@ func (c *YouTrackClient) AddIssueComment(ctx context.Context, issueID string, co
 	return c.client.AddIssueComment(ytCtx, issueID, comment)
 }
 
+// ReplyToIssueComment adds a comment replying to another comment of an issue
+func (c *YouTrackClient) ReplyToIssueComment(ctx context.Context, issueID, commentID string, comment string) (*youtrack.IssueComment, error) {
+	ytCtx := c.WithContext(ctx)
+	return c.client.ReplyToIssueComment(ytCtx, issueID, commentID, comment)
+}
+
 // Tag Management Methods
 
 // AddIssueTag adds a tag to an issue by tag ID
@@ -316,12 +373,30 @@ func (c *YouTrackClient) GetProjectCustomFields(ctx context.Context, projectID s
 	return c.client.GetProjectCustomFields(ytCtx, projectID)
 }
 
+// ListProjectCustomFields returns the custom fields of a project with their types and bundles
+func (c *YouTrackClient) ListProjectCustomFields(ctx context.Context, projectID string) ([]*youtrack.ProjectCustomField, error) {
+	ytCtx := c.WithContext(ctx)
+	return c.client.ListProjectCustomFields(ytCtx, projectID)
+}
+
 // GetCustomFieldAllowedValues returns the allowed values for a custom field in a project
 func (c *YouTrackClient) GetCustomFieldAllowedValues(ctx context.Context, projectID string, fieldName string) ([]youtrack.AllowedValue, error) {
 	ytCtx := c.WithContext(ctx)
 	return c.client.GetCustomFieldAllowedValues(ytCtx, projectID, fieldName)
 }
 
+// GetProjectStats computes issue statistics of a project with count-only queries
+func (c *YouTrackClient) GetProjectStats(ctx context.Context, projectID string, opts youtrack.ProjectStatsOptions) (*youtrack.ProjectStats, error) {
+	ytCtx := c.WithContext(ctx)
+	return c.client.GetProjectStats(ytCtx, projectID, opts)
+}
+
+// GetAssigneeLoad returns the project members with their open issue counts, least loaded first
+func (c *YouTrackClient) GetAssigneeLoad(ctx context.Context, projectID string, opts youtrack.AssigneeLoadOptions) ([]*youtrack.AssigneeLoad, error) {
+	ytCtx := c.WithContext(ctx)
+	return c.client.GetAssigneeLoad(ytCtx, projectID, opts)
+}
+
 // GetAvailableLinkTypes returns all available link types
 func (c *YouTrackClient) GetAvailableLinkTypes(ctx context.Context) ([]*youtrack.LinkType, error) {
 	ytCtx := c.WithContext(ctx)
@@ -352,6 +427,90 @@ func (c *YouTrackClient) SearchIssuesSorted(ctx context.Context, query string, s
 	return c.client.SearchIssuesSorted(ytCtx, query, skip, top, sortBy, sortOrder)
 }
 
+// SearchIssuesProjected searches for issues with sorting, fetching only the projected fields
+func (c *YouTrackClient) SearchIssuesProjected(ctx context.Context, query string, skip, top int, sortBy, sortOrder string, projection *youtrack.IssueProjection) ([]*youtrack.Issue, error) {
+	ytCtx := c.WithContext(ctx)
+
+	// Use default max results if top is 0
+	if top == 0 {
+		top = c.config.MaxResults
+	}
+
+	return c.client.SearchIssuesProjected(ytCtx, query, skip, top, sortBy, sortOrder, projection)
+}
+
+// GetIssueProjected retrieves an issue, fetching only the projected fields
+func (c *YouTrackClient) GetIssueProjected(ctx context.Context, issueID string, projection *youtrack.IssueProjection) (*youtrack.Issue, error) {
+	ytCtx := c.WithContext(ctx)
+	return c.client.GetIssueProjected(ytCtx, issueID, projection)
+}
+
+// ForEachIssue iterates over all issues matching the query without loading them all into memory
+func (c *YouTrackClient) ForEachIssue(ctx context.Context, query string, pageSize int, fn func(issue *youtrack.Issue) error) error {
+	ytCtx := c.WithContext(ctx)
+	return c.client.ForEachIssue(ytCtx, query, pageSize, fn)
+}
+
+// GetSearchSuggestions returns query completion suggestions
+func (c *YouTrackClient) GetSearchSuggestions(ctx context.Context, query string, caret int) (*youtrack.SearchAssist, error) {
+	ytCtx := c.WithContext(ctx)
+	return c.client.GetSearchSuggestions(ytCtx, query, caret)
+}
+
+// GetIssueVcsChanges returns the commits linked to an issue
+func (c *YouTrackClient) GetIssueVcsChanges(ctx context.Context, issueID string) ([]*youtrack.VcsChange, error) {
+	ytCtx := c.WithContext(ctx)
+	return c.client.GetIssueVcsChanges(ytCtx, issueID)
+}
+
+// GetIssueVoters returns the votes of an issue
+func (c *YouTrackClient) GetIssueVoters(ctx context.Context, issueID string) (*youtrack.IssueVoters, error) {
+	ytCtx := c.WithContext(ctx)
+	return c.client.GetIssueVoters(ytCtx, issueID)
+}
+
+// VoteIssue votes for an issue as the current user
+func (c *YouTrackClient) VoteIssue(ctx context.Context, issueID string) error {
+	ytCtx := c.WithContext(ctx)
+	return c.client.VoteIssue(ytCtx, issueID)
+}
+
+// UnvoteIssue removes the current user's vote from an issue
+func (c *YouTrackClient) UnvoteIssue(ctx context.Context, issueID string) error {
+	ytCtx := c.WithContext(ctx)
+	return c.client.UnvoteIssue(ytCtx, issueID)
+}
+
+// GetNotifications returns the current user's notifications, most recent first
+func (c *YouTrackClient) GetNotifications(ctx context.Context, skip, top int, unreadOnly bool) ([]*youtrack.Notification, error) {
+	ytCtx := c.WithContext(ctx)
+	return c.client.GetNotifications(ytCtx, skip, top, unreadOnly)
+}
+
+// CountUnreadNotifications counts the current user's unread notifications
+func (c *YouTrackClient) CountUnreadNotifications(ctx context.Context) (int, error) {
+	ytCtx := c.WithContext(ctx)
+	return c.client.CountUnreadNotifications(ytCtx)
+}
+
+// MarkNotificationRead marks a notification read or unread
+func (c *YouTrackClient) MarkNotificationRead(ctx context.Context, notificationID string, read bool) error {
+	ytCtx := c.WithContext(ctx)
+	return c.client.MarkNotificationRead(ytCtx, notificationID, read)
+}
+
+// FindSimilarIssues searches for issues with summaries similar to text
+func (c *YouTrackClient) FindSimilarIssues(ctx context.Context, text string, opts youtrack.SimilarIssuesOptions) ([]*youtrack.SimilarIssue, error) {
+	ytCtx := c.WithContext(ctx)
+	return c.client.FindSimilarIssues(ytCtx, text, opts)
+}
+
+// GetIssueActivities returns the activity stream of an issue
+func (c *YouTrackClient) GetIssueActivities(ctx context.Context, issueID string) ([]*youtrack.ActivityItem, error) {
+	ytCtx := c.WithContext(ctx)
+	return c.client.GetIssueActivities(ytCtx, issueID)
+}
+
 // GetIssueLinks returns the links for an issue
 func (c *YouTrackClient) GetIssueLinks(ctx context.Context, issueID string) ([]*youtrack.IssueLink, error) {
 	ytCtx := c.WithContext(ctx)
@@ -412,6 +571,18 @@ func (c *YouTrackClient) GetProjectUsers(ctx context.Context, projectID string,
 	return c.client.GetProjectUsers(ytCtx, projectID, skip, top)
 }
 
+// AddIssueAttachmentFromReader streams content as an attachment to an issue
+func (c *YouTrackClient) AddIssueAttachmentFromReader(ctx context.Context, issueID string, content io.Reader, size int64, filename string, progress youtrack.UploadProgress) (*youtrack.Attachment, error) {
+	ytCtx := c.WithContext(ctx)
+	return c.client.AddIssueAttachmentFromReader(ytCtx, issueID, content, size, filename, progress)
+}
+
+// DeleteIssueAttachment deletes an attachment from an issue
+func (c *YouTrackClient) DeleteIssueAttachment(ctx context.Context, issueID string, attachmentID string) error {
+	ytCtx := c.WithContext(ctx)
+	return c.client.DeleteIssueAttachment(ytCtx, issueID, attachmentID)
+}
+
 // AddIssueAttachmentFromBytes uploads content as an attachment to an issue
 func (c *YouTrackClient) AddIssueAttachmentFromBytes(ctx context.Context, issueID string, content []byte, filename string) (*youtrack.Attachment, error) {
 	ytCtx := c.WithContext(ctx)
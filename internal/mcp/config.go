@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/mkozhukh/youtrack/internal/mcp/logging"
+	"github.com/mkozhukh/youtrack/pkg/youtrack"
 
 	"github.com/charmbracelet/log"
 	"github.com/knadh/koanf/parsers/toml"
@@ -27,30 +28,96 @@ type fileConfig struct {
 		CallLogPath      string `koanf:"call_log_path"`
 		RESTErrorLogPath string `koanf:"rest_error_log_path"`
 		ToolErrorLogPath string `koanf:"tool_error_log_path"`
+		Debug            bool   `koanf:"debug"`
+		DebugLogPath     string `koanf:"debug_log_path"`
+		MaxSizeMB        int    `koanf:"max_size_mb"`
+		MaxFiles         int    `koanf:"max_files"`
+		MaxAgeDays       int    `koanf:"max_age_days"`
+		Compress         bool   `koanf:"compress"`
+		Format           string `koanf:"format"`
 	} `koanf:"logging"`
 	Tools struct {
-		Blacklist []string `koanf:"blacklist"`
+		Blacklist        []string `koanf:"blacklist"`
+		AllowDestructive bool     `koanf:"allow_destructive"`
 	} `koanf:"tools"`
 	YouTrack struct {
-		BaseURL        string `koanf:"base_url"`
-		APIKey         string `koanf:"api_key"`
-		HubURL         string `koanf:"hub_url"`
-		DefaultProject string `koanf:"default_project"`
-		Timeout        int    `koanf:"timeout"`
-		MaxResults     int    `koanf:"max_results"`
+		BaseURL             string `koanf:"base_url"`
+		APIKey              string `koanf:"api_key"`
+		HubURL              string `koanf:"hub_url"`
+		DefaultProject      string `koanf:"default_project"`
+		Timeout             int    `koanf:"timeout"`
+		MaxResults          int    `koanf:"max_results"`
+		MaxIdleConnsPerHost int    `koanf:"max_idle_conns_per_host"`
+		HTTP2               bool   `koanf:"http2"`
 	} `koanf:"youtrack"`
 	Cache struct {
-		TTLSeconds int `koanf:"ttl_seconds"`
+		TTLSeconds int  `koanf:"ttl_seconds"`
+		WarmUp     bool `koanf:"warmup"`
 	} `koanf:"cache"`
 	Tracker struct {
 		FilePath string `koanf:"file_path"`
 	} `koanf:"tracker"`
 	FileServer struct {
-		Enabled       bool   `koanf:"enabled"`
-		BaseURL       string `koanf:"base_url"`
-		TTLSeconds    int    `koanf:"ttl_seconds"`
-		MaxFileSizeMB int    `koanf:"max_file_size_mb"`
+		Enabled        bool   `koanf:"enabled"`
+		BaseURL        string `koanf:"base_url"`
+		TTLSeconds     int    `koanf:"ttl_seconds"`
+		MaxFileSizeMB  int    `koanf:"max_file_size_mb"`
+		MaxStoreSizeMB int    `koanf:"max_store_size_mb"`
 	} `koanf:"fileserver"`
+	Attachments struct {
+		InlineImageMaxKB int64 `koanf:"inline_image_max_kb"`
+	} `koanf:"attachments"`
+	Workflow struct {
+		ReviewState  string            `koanf:"review_state"`
+		ReviewStates map[string]string `koanf:"review_states"`
+	} `koanf:"workflow"`
+	Timer struct {
+		StateFile         string `koanf:"state_file"`
+		MaxSessionMinutes int    `koanf:"max_session_minutes"`
+		RoundMinutes      int    `koanf:"round_minutes"`
+	} `koanf:"timer"`
+	Duplicates struct {
+		Check    bool    `koanf:"check"`
+		MinScore float64 `koanf:"min_score"`
+		Days     int     `koanf:"days"`
+	} `koanf:"duplicates"`
+	Macros   []MacroConfig `koanf:"macros"`
+	Auth     AuthConfig    `koanf:"auth"`
+	Sampling struct {
+		Enabled   bool `koanf:"enabled"`
+		MaxTokens int  `koanf:"max_tokens"`
+	} `koanf:"sampling"`
+	SLA struct {
+		PriorityField   string `koanf:"priority_field"`
+		slaTargetConfig `koanf:",squash"`
+		Priorities      map[string]slaTargetConfig `koanf:"priorities"`
+	} `koanf:"sla"`
+}
+
+// slaTargetConfig holds the hours allowed to respond to and to resolve an issue, 0 for no target
+type slaTargetConfig struct {
+	FirstResponseHours float64 `koanf:"first_response_hours"`
+	ResolutionHours    float64 `koanf:"resolution_hours"`
+}
+
+// target converts the configured hours to an SLA target
+func (c slaTargetConfig) target() youtrack.SLATarget {
+	return youtrack.SLATarget{
+		FirstResponse: time.Duration(c.FirstResponseHours * float64(time.Hour)),
+		Resolution:    time.Duration(c.ResolutionHours * float64(time.Hour)),
+	}
+}
+
+// ConfigPath returns the config file LoadConfig reads: configPath, "config.toml" when empty,
+// or the YOUTRACK_CONFIG_PATH env var when set
+func ConfigPath(configPath string) string {
+	if configPath == "" {
+		configPath = "config.toml"
+	}
+	if envPath := os.Getenv("YOUTRACK_CONFIG_PATH"); envPath != "" {
+		configPath = envPath
+	}
+	return configPath
 }
 
 // LoadConfig loads ServerConfig from a TOML file and environment variables.
@@ -59,24 +126,47 @@ func LoadConfig(configPath string) (ServerConfig, error) {
 	k := koanf.New(".")
 
 	defaults := map[string]any{
-		"server.port":                 3204,
-		"server.name":                 "YouTrack MCP Server",
-		"logging.enabled":             false,
-		"logging.call_log_path":       "calls.log",
-		"logging.rest_error_log_path": "rest_errors.log",
-		"logging.tool_error_log_path": "tool_errors.log",
-		"youtrack.base_url":           "",
-		"youtrack.api_key":            "",
-		"youtrack.hub_url":            "",
-		"youtrack.default_project":    "",
-		"youtrack.timeout":            30,
-		"youtrack.max_results":        10,
-		"cache.ttl_seconds":           300,
-		"tracker.file_path":           "projects.json",
-		"fileserver.enabled":          false,
-		"fileserver.base_url":         "",
-		"fileserver.ttl_seconds":      1800,
-		"fileserver.max_file_size_mb": 50,
+		"server.port":                      3204,
+		"server.name":                      "YouTrack MCP Server",
+		"logging.enabled":                  false,
+		"logging.call_log_path":            "calls.log",
+		"logging.rest_error_log_path":      "rest_errors.log",
+		"logging.tool_error_log_path":      "tool_errors.log",
+		"logging.debug":                    false,
+		"logging.debug_log_path":           "debug.log",
+		"logging.max_size_mb":              100,
+		"logging.max_files":                5,
+		"logging.max_age_days":             0,
+		"logging.compress":                 false,
+		"logging.format":                   logging.FormatText,
+		"youtrack.base_url":                "",
+		"youtrack.api_key":                 "",
+		"youtrack.hub_url":                 "",
+		"youtrack.default_project":         "",
+		"youtrack.timeout":                 30,
+		"youtrack.max_results":             10,
+		"youtrack.max_idle_conns_per_host": 20,
+		"youtrack.http2":                   true,
+		"cache.ttl_seconds":                300,
+		"cache.warmup":                     false,
+		"tracker.file_path":                "projects.json",
+		"fileserver.enabled":               false,
+		"fileserver.base_url":              "",
+		"fileserver.ttl_seconds":           1800,
+		"fileserver.max_file_size_mb":      50,
+		"fileserver.max_store_size_mb":     500,
+		"attachments.inline_image_max_kb":  1024,
+		"workflow.review_state":            "",
+		"timer.state_file":                 "",
+		"timer.max_session_minutes":        0,
+		"timer.round_minutes":              0,
+		"duplicates.check":                 false,
+		"duplicates.min_score":             0.6,
+		"duplicates.days":                  30,
+		"sampling.enabled":                 false,
+		"sampling.max_tokens":              1000,
+		"tools.allow_destructive":          true,
+		"auth.default_profile":             ProfileAdmin,
 	}
 
 	if err := k.Load(confmap.Provider(defaults, "."), nil); err != nil {
@@ -89,13 +179,7 @@ func LoadConfig(configPath string) (ServerConfig, error) {
 		return ServerConfig{}, fmt.Errorf("error loading env vars: %w", err)
 	}
 
-	if configPath == "" {
-		configPath = "config.toml"
-	}
-	if envPath := os.Getenv("YOUTRACK_CONFIG_PATH"); envPath != "" {
-		configPath = envPath
-	}
-
+	configPath = ConfigPath(configPath)
 	if _, err := os.Stat(configPath); err == nil {
 		if err := k.Load(file.Provider(configPath), toml.Parser()); err != nil {
 			return ServerConfig{}, fmt.Errorf("error loading config file: %w", err)
@@ -109,35 +193,104 @@ func LoadConfig(configPath string) (ServerConfig, error) {
 		return ServerConfig{}, fmt.Errorf("error unmarshaling config: %w", err)
 	}
 
+	if fc.Logging.Format != logging.FormatText && fc.Logging.Format != logging.FormatJSON {
+		return ServerConfig{}, fmt.Errorf("logging.format must be '%s' or '%s', got '%s'", logging.FormatText, logging.FormatJSON, fc.Logging.Format)
+	}
+
+	if fc.Duplicates.MinScore < 0 || fc.Duplicates.MinScore > 1 {
+		return ServerConfig{}, fmt.Errorf("duplicates.min_score must be between 0 and 1, got %v", fc.Duplicates.MinScore)
+	}
+
+	if err := validateBlacklist(fc.Tools.Blacklist); err != nil {
+		return ServerConfig{}, err
+	}
+
+	if err := validateAuth(fc.Auth); err != nil {
+		return ServerConfig{}, err
+	}
+
+	if err := validateMacros(fc.Macros); err != nil {
+		return ServerConfig{}, err
+	}
+
+	if fc.Sampling.MaxTokens <= 0 {
+		return ServerConfig{}, fmt.Errorf("sampling.max_tokens must be positive, got %d", fc.Sampling.MaxTokens)
+	}
+
+	slaPolicy := youtrack.SLAPolicy{
+		PriorityField: fc.SLA.PriorityField,
+		Default:       fc.SLA.target(),
+		Priorities:    make(map[string]youtrack.SLATarget, len(fc.SLA.Priorities)),
+	}
+	for name, target := range fc.SLA.Priorities {
+		slaPolicy.Priorities[name] = target.target()
+	}
+
 	return ServerConfig{
 		Name: fc.Server.Name,
 		Port: fc.Server.Port,
 		YouTrack: YouTrackConfig{
-			BaseURL:        fc.YouTrack.BaseURL,
-			APIKey:         fc.YouTrack.APIKey,
-			HubURL:         fc.YouTrack.HubURL,
-			DefaultProject: fc.YouTrack.DefaultProject,
-			Timeout:        fc.YouTrack.Timeout,
-			MaxResults:     fc.YouTrack.MaxResults,
+			BaseURL:             fc.YouTrack.BaseURL,
+			APIKey:              fc.YouTrack.APIKey,
+			HubURL:              fc.YouTrack.HubURL,
+			DefaultProject:      fc.YouTrack.DefaultProject,
+			Timeout:             fc.YouTrack.Timeout,
+			MaxResults:          fc.YouTrack.MaxResults,
+			MaxIdleConnsPerHost: fc.YouTrack.MaxIdleConnsPerHost,
+			HTTP2:               fc.YouTrack.HTTP2,
 		},
 		Cache: CacheConfig{
-			TTL: time.Duration(fc.Cache.TTLSeconds) * time.Second,
+			TTL:    time.Duration(fc.Cache.TTLSeconds) * time.Second,
+			WarmUp: fc.Cache.WarmUp,
 		},
 		Tracker: TrackerConfig{
 			FilePath: fc.Tracker.FilePath,
 		},
 		FileServer: FileServerConfig{
-			Enabled:       fc.FileServer.Enabled,
-			BaseURL:       fc.FileServer.BaseURL,
-			TTLSeconds:    fc.FileServer.TTLSeconds,
-			MaxFileSizeMB: fc.FileServer.MaxFileSizeMB,
+			Enabled:        fc.FileServer.Enabled,
+			BaseURL:        fc.FileServer.BaseURL,
+			TTLSeconds:     fc.FileServer.TTLSeconds,
+			MaxFileSizeMB:  fc.FileServer.MaxFileSizeMB,
+			MaxStoreSizeMB: fc.FileServer.MaxStoreSizeMB,
+		},
+		Attachments: AttachmentsConfig{
+			InlineImageMaxKB: fc.Attachments.InlineImageMaxKB,
 		},
 		Logging: logging.LogConfig{
 			Enabled:          fc.Logging.Enabled,
 			CallLogPath:      fc.Logging.CallLogPath,
 			RESTErrorLogPath: fc.Logging.RESTErrorLogPath,
 			ToolErrorLogPath: fc.Logging.ToolErrorLogPath,
+			Debug:            fc.Logging.Debug,
+			DebugLogPath:     fc.Logging.DebugLogPath,
+			MaxSizeMB:        fc.Logging.MaxSizeMB,
+			MaxFiles:         fc.Logging.MaxFiles,
+			MaxAgeDays:       fc.Logging.MaxAgeDays,
+			Compress:         fc.Logging.Compress,
+			Format:           fc.Logging.Format,
+		},
+		Workflow: WorkflowConfig{
+			ReviewState:  fc.Workflow.ReviewState,
+			ReviewStates: fc.Workflow.ReviewStates,
+		},
+		Timer: TimerConfig{
+			StateFile:         fc.Timer.StateFile,
+			MaxSessionMinutes: fc.Timer.MaxSessionMinutes,
+			RoundMinutes:      fc.Timer.RoundMinutes,
+		},
+		Duplicates: DuplicatesConfig{
+			Check:    fc.Duplicates.Check,
+			MinScore: fc.Duplicates.MinScore,
+			Days:     fc.Duplicates.Days,
+		},
+		Sampling: SamplingConfig{
+			Enabled:   fc.Sampling.Enabled,
+			MaxTokens: fc.Sampling.MaxTokens,
 		},
-		ToolBlacklist: fc.Tools.Blacklist,
+		Macros:           fc.Macros,
+		Auth:             fc.Auth,
+		SLA:              slaPolicy,
+		ToolBlacklist:    fc.Tools.Blacklist,
+		AllowDestructive: fc.Tools.AllowDestructive,
 	}, nil
 }
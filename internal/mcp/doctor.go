@@ -0,0 +1,275 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/mkozhukh/youtrack/internal/timer"
+	"github.com/mkozhukh/youtrack/pkg/youtrack"
+)
+
+// Doctor check statuses
+const (
+	CheckOK   = "ok"
+	CheckWarn = "warn"
+	CheckFail = "fail"
+)
+
+// DoctorCheck is the outcome of one doctor check
+type DoctorCheck struct {
+	Name    string
+	Status  string
+	Message string
+}
+
+// DoctorReport is the outcome of Doctor
+type DoctorReport struct {
+	ConfigPath string
+	Checks     []*DoctorCheck
+	// Tools are the tools the server registers, Skipped the left out ones with the reason
+	Tools   []string
+	Skipped map[string]string
+}
+
+// Failed reports whether any check failed
+func (r *DoctorReport) Failed() bool {
+	for _, check := range r.Checks {
+		if check.Status == CheckFail {
+			return true
+		}
+	}
+	return false
+}
+
+// add appends a check to the report
+func (r *DoctorReport) add(name, status, format string, a ...interface{}) {
+	r.Checks = append(r.Checks, &DoctorCheck{Name: name, Status: status, Message: fmt.Sprintf(format, a...)})
+}
+
+// Print writes the report: the checks, then the registered and skipped tools
+func (r *DoctorReport) Print(w io.Writer) {
+	for _, check := range r.Checks {
+		fmt.Fprintf(w, "%-4s  %-16s %s\n", check.Status, check.Name, check.Message)
+	}
+
+	if len(r.Tools) == 0 && len(r.Skipped) == 0 {
+		return
+	}
+	fmt.Fprintf(w, "\nTools registered (%d):\n", len(r.Tools))
+	for _, name := range r.Tools {
+		fmt.Fprintf(w, "  %s\n", name)
+	}
+	if len(r.Skipped) > 0 {
+		names := make([]string, 0, len(r.Skipped))
+		for name := range r.Skipped {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		fmt.Fprintf(w, "\nTools skipped (%d):\n", len(names))
+		for _, name := range names {
+			fmt.Fprintf(w, "  %s: %s\n", name, r.Skipped[name])
+		}
+	}
+}
+
+// Doctor checks the server configuration without starting the server: it loads the config,
+// tests the YouTrack connection, the write access to the log, tracker and timer files, the
+// default project and lists the tools that would be registered
+func Doctor(configPath string) *DoctorReport {
+	report := &DoctorReport{ConfigPath: ConfigPath(configPath)}
+
+	if _, err := os.Stat(report.ConfigPath); err != nil {
+		report.add("config file", CheckWarn, "%s not found, using defaults and environment variables", report.ConfigPath)
+	} else {
+		report.add("config file", CheckOK, "%s", report.ConfigPath)
+	}
+
+	cfg, err := LoadConfig(configPath)
+	if err != nil {
+		report.add("config file", CheckFail, "cannot be loaded: %v", err)
+		return report
+	}
+
+	if err := validateConfig(cfg.YouTrack); err != nil {
+		report.add("youtrack", CheckFail, "%v", err)
+	} else {
+		doctorYouTrack(report, cfg)
+	}
+
+	if cfg.Logging.Enabled {
+		for _, path := range []string{cfg.Logging.CallLogPath, cfg.Logging.RESTErrorLogPath, cfg.Logging.ToolErrorLogPath} {
+			doctorWritable(report, "log file", path, false)
+		}
+	}
+	if cfg.Tracker.FilePath != "" {
+		doctorWritable(report, "tracker file", cfg.Tracker.FilePath, false)
+	}
+	doctorWritable(report, "timer file", timer.NewStore(cfg.Timer.StateFile).Path(), true)
+	if cfg.FileServer.Enabled {
+		if err := checkWritableDir(os.TempDir()); err != nil {
+			report.add("file server", CheckFail, "cannot store files in %s: %v", os.TempDir(), err)
+		} else {
+			report.add("file server", CheckOK, "stores files in %s", os.TempDir())
+		}
+	}
+
+	doctorTools(report, cfg)
+	return report
+}
+
+// doctorYouTrack checks the connection, the api key, the default project and the hub
+func doctorYouTrack(report *DoctorReport, cfg ServerConfig) {
+	if cfg.YouTrack.APIKey == "" {
+		report.add("api key", CheckWarn, "youtrack.api_key is not set, clients must send an Authorization header (HTTP transport only); connection checks skipped")
+		return
+	}
+
+	client := youtrack.NewClient(cfg.YouTrack.BaseURL)
+	if cfg.YouTrack.HubURL != "" {
+		client.SetHubURL(cfg.YouTrack.HubURL)
+	}
+	ctx := youtrack.NewYouTrackContext(context.Background(), cfg.YouTrack.APIKey)
+
+	user, err := client.GetCurrentUser(ctx)
+	if err != nil {
+		if apiErr, ok := err.(*youtrack.APIError); ok && apiErr.StatusCode == 401 {
+			report.add("api key", CheckFail, "rejected by %s, the key is invalid, expired or revoked", cfg.YouTrack.BaseURL)
+		} else {
+			report.add("connection", CheckFail, "cannot reach %s: %v", cfg.YouTrack.BaseURL, err)
+		}
+		return
+	}
+	report.add("api key", CheckOK, "authenticated at %s as %s (%s)", cfg.YouTrack.BaseURL, user.FullName, user.Login)
+
+	if caps := client.Capabilities(ctx); caps.Version == "" {
+		report.add("version", CheckWarn, "the YouTrack version is unknown, version-dependent features are assumed available")
+	} else if !caps.Reactions {
+		report.add("version", CheckWarn, "YouTrack %s, comment reactions need %s or later", caps.Version, youtrack.RequiredVersion(youtrack.FeatureReactions))
+	} else {
+		report.add("version", CheckOK, "YouTrack %s", caps.Version)
+	}
+
+	projectID := cfg.YouTrack.DefaultProject
+	if projectID == "" {
+		report.add("default project", CheckWarn, "youtrack.default_project is not set, tools need project_id or set_default_project")
+	} else if _, err := client.GetProject(ctx, projectID); err != nil {
+		report.add("default project", CheckFail, "cannot access %s: %v", projectID, err)
+		projectID = ""
+	} else {
+		report.add("default project", CheckOK, "%s is accessible", projectID)
+		if perms, err := client.GetPermissions(ctx); err != nil {
+			report.add("permissions", CheckWarn, "cannot read the permissions of the api key: %v", err)
+		} else if missing := perms.Missing(projectID, youtrack.WorkPermissions...); len(missing) > 0 {
+			report.add("permissions", CheckWarn, "the api key lacks %s in project %s", youtrack.PermissionNames(missing), projectID)
+		} else {
+			report.add("permissions", CheckOK, "issues can be read, created, updated, commented and timed in %s", projectID)
+		}
+	}
+
+	switch {
+	case cfg.YouTrack.HubURL == "":
+		report.add("hub", CheckWarn, "youtrack.hub_url is not set, get_project_users and suggest_assignee list the users of the Assignee field instead of project teams")
+	case projectID == "":
+		report.add("hub", CheckWarn, "not checked, it needs an accessible default project")
+	default:
+		if _, err := client.GetProjectTeam(ctx, projectID, 0, 1); err != nil {
+			report.add("hub", CheckFail, "cannot list the %s team at %s: %v", projectID, cfg.YouTrack.HubURL, err)
+		} else {
+			report.add("hub", CheckOK, "%s answers", cfg.YouTrack.HubURL)
+		}
+	}
+}
+
+// doctorWritable checks that a file the server writes can be written; mkdirs tells whether
+// the server creates its missing directories
+func doctorWritable(report *DoctorReport, name, path string, mkdirs bool) {
+	if err := checkWritable(path, mkdirs); err != nil {
+		report.add(name, CheckFail, "%s is not writable: %v", path, err)
+		return
+	}
+	report.add(name, CheckOK, "%s is writable", path)
+}
+
+// checkWritable checks that a file can be appended to or, when it does not exist yet, created,
+// without changing it
+func checkWritable(path string, mkdirs bool) error {
+	if _, err := os.Stat(path); err == nil {
+		f, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND, 0)
+		if err != nil {
+			return err
+		}
+		return f.Close()
+	}
+
+	dir := filepath.Dir(path)
+	if !mkdirs {
+		if _, err := os.Stat(dir); err != nil {
+			return fmt.Errorf("directory %s does not exist", dir)
+		}
+		return checkWritableDir(dir)
+	}
+
+	// Missing directories are created, so the nearest existing one must be writable
+	for {
+		if _, err := os.Stat(dir); err == nil {
+			return checkWritableDir(dir)
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return fmt.Errorf("no existing parent directory")
+		}
+		dir = parent
+	}
+}
+
+// checkWritableDir checks that files can be created in a directory
+func checkWritableDir(dir string) error {
+	f, err := os.CreateTemp(dir, ".youtrack-mcp-doctor-*")
+	if err != nil {
+		return err
+	}
+	f.Close()
+	return os.Remove(f.Name())
+}
+
+// doctorTools lists the tools the server registers with the configuration and flags the
+// blacklist entries (names, patterns or categories) that match no tool
+func doctorTools(report *DoctorReport, cfg ServerConfig) {
+	// Tools do not depend on the connection, so the server is built offline and without side effects
+	offline := cfg
+	offline.YouTrack = YouTrackConfig{BaseURL: "http://localhost", DefaultProject: cfg.YouTrack.DefaultProject}
+	offline.Logging.Enabled = false
+	offline.Cache.WarmUp = false
+
+	s, err := NewMCPServer(offline, nil)
+	if err != nil {
+		report.add("tools", CheckFail, "cannot build the server: %v", err)
+		return
+	}
+	if s.fileStore != nil {
+		defer s.fileStore.Close()
+	}
+	if err := s.RegisterTools(); err != nil {
+		report.add("tools", CheckFail, "cannot register the tools: %v", err)
+		return
+	}
+
+	report.Tools, report.Skipped = s.Tools()
+	sort.Strings(report.Tools)
+	report.add("tools", CheckOK, "%d registered, %d skipped", len(report.Tools), len(report.Skipped))
+
+	var unknown []string
+	for _, entry := range cfg.ToolBlacklist {
+		if !s.matchesAnyTool(entry) {
+			unknown = append(unknown, entry)
+		}
+	}
+	if len(unknown) > 0 {
+		report.add("blacklist", CheckWarn, "no tool matches %s, check tools.blacklist for typos", strings.Join(unknown, ", "))
+	}
+}
@@ -1,108 +1,165 @@
 package filestore
 
 import (
+	"bytes"
 	"crypto/rand"
+	"errors"
 	"fmt"
 	"io"
 	"mime"
 	"os"
 	"path/filepath"
+	"sort"
 	"sync"
 	"time"
 )
 
+// ErrTooLarge is returned when a file exceeds the store's size limit
+var ErrTooLarge = errors.New("file too large")
+
 type entry struct {
+	owner       string // hash of the API key that stored the file
 	path        string
 	filename    string
 	contentType string
+	size        int64
+	createdAt   time.Time
 	expiresAt   time.Time
 }
 
-// Store manages temporary files with TTL-based expiry.
+// FileInfo describes a stored file
+type FileInfo struct {
+	ID          string
+	Filename    string
+	ContentType string
+	Size        int64
+	CreatedAt   time.Time
+	ExpiresAt   time.Time
+}
+
+// Store manages temporary files with TTL-based expiry. Each file belongs to the owner that
+// stored it, a hash of its API key: other owners can neither see, download nor delete it.
 type Store struct {
-	mu      sync.RWMutex
-	entries map[string]*entry
-	tempDir string
-	ttl     time.Duration
-	maxSize int64
-	done    chan struct{}
+	mu        sync.RWMutex
+	entries   map[string]*entry
+	tempDir   string
+	ttl       time.Duration
+	maxSize   int64
+	maxTotal  int64
+	totalSize int64
+	done      chan struct{}
 }
 
 // NewStore creates a new file store. It creates a temp directory and starts a
 // background goroutine that cleans up expired entries every 60 seconds.
-func NewStore(ttl time.Duration, maxSizeMB int) (*Store, error) {
+// maxSizeMB limits a single file and maxTotalMB all stored files together; when
+// a new file exceeds the total, the oldest files are evicted. Zero or less means no limit.
+func NewStore(ttl time.Duration, maxSizeMB, maxTotalMB int) (*Store, error) {
 	dir, err := os.MkdirTemp("", "youtrack-filestore-*")
 	if err != nil {
 		return nil, fmt.Errorf("failed to create temp dir: %w", err)
 	}
 
 	s := &Store{
-		entries: make(map[string]*entry),
-		tempDir: dir,
-		ttl:     ttl,
-		maxSize: int64(maxSizeMB) * 1024 * 1024,
-		done:    make(chan struct{}),
+		entries:  make(map[string]*entry),
+		tempDir:  dir,
+		ttl:      ttl,
+		maxSize:  int64(maxSizeMB) * 1024 * 1024,
+		maxTotal: int64(maxTotalMB) * 1024 * 1024,
+		done:     make(chan struct{}),
 	}
 
 	go s.cleanupLoop()
 	return s, nil
 }
 
-// Put stores data as a temporary file and returns a unique file ID.
-func (s *Store) Put(data []byte, filename string) (string, error) {
-	if int64(len(data)) > s.maxSize {
-		return "", fmt.Errorf("file too large: %d bytes (max %d bytes)", len(data), s.maxSize)
-	}
+// Put stores data as a temporary file of the owner and returns a unique file ID.
+func (s *Store) Put(owner string, data []byte, filename string) (string, error) {
+	return s.PutReader(owner, bytes.NewReader(data), filename)
+}
 
+// PutReader streams r into a temporary file of the owner and returns a unique file ID.
+// The content is never held in memory, so large files only cost disk space.
+func (s *Store) PutReader(owner string, r io.Reader, filename string) (string, error) {
 	id := newUUID()
 	path := filepath.Join(s.tempDir, id)
 
-	if err := os.WriteFile(path, data, 0600); err != nil {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_EXCL, 0600)
+	if err != nil {
+		return "", fmt.Errorf("failed to create temp file: %w", err)
+	}
+
+	// Read one byte past the limit to detect oversized files
+	limit := s.maxSize
+	if s.maxTotal > 0 && (limit <= 0 || s.maxTotal < limit) {
+		limit = s.maxTotal
+	}
+	src := r
+	if limit > 0 {
+		src = io.LimitReader(r, limit+1)
+	}
+	written, err := io.Copy(f, src)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(path)
 		return "", fmt.Errorf("failed to write temp file: %w", err)
 	}
+	if limit > 0 && written > limit {
+		os.Remove(path)
+		return "", fmt.Errorf("%w (max %d bytes)", ErrTooLarge, limit)
+	}
 
 	ct := mime.TypeByExtension(filepath.Ext(filename))
 	if ct == "" {
 		ct = "application/octet-stream"
 	}
 
+	now := time.Now()
 	s.mu.Lock()
+	s.evictFor(written)
 	s.entries[id] = &entry{
+		owner:       owner,
 		path:        path,
 		filename:    filename,
 		contentType: ct,
-		expiresAt:   time.Now().Add(s.ttl),
+		size:        written,
+		createdAt:   now,
+		expiresAt:   now.Add(s.ttl),
 	}
+	s.totalSize += written
 	s.mu.Unlock()
 
 	return id, nil
 }
 
-// PutFromFile stores a file from a given path and returns a unique file ID.
-func (s *Store) PutFromFile(srcPath, filename string) (string, error) {
+// PutFromFile stores a file of the owner from a given path and returns a unique file ID.
+func (s *Store) PutFromFile(owner, srcPath, filename string) (string, error) {
 	info, err := os.Stat(srcPath)
 	if err != nil {
 		return "", fmt.Errorf("failed to stat file: %w", err)
 	}
-	if info.Size() > s.maxSize {
-		return "", fmt.Errorf("file too large: %d bytes (max %d bytes)", info.Size(), s.maxSize)
+	if s.maxSize > 0 && info.Size() > s.maxSize {
+		return "", fmt.Errorf("%w: %d bytes (max %d bytes)", ErrTooLarge, info.Size(), s.maxSize)
 	}
 
-	data, err := os.ReadFile(srcPath)
+	src, err := os.Open(srcPath)
 	if err != nil {
 		return "", fmt.Errorf("failed to read file: %w", err)
 	}
+	defer src.Close()
 
-	return s.Put(data, filename)
+	return s.PutReader(owner, src, filename)
 }
 
-// Get returns the file path, original filename, and content type for a stored file.
-func (s *Store) Get(fileID string) (filePath, filename, contentType string, err error) {
+// Get returns the file path, original filename, and content type for a stored file of the owner.
+func (s *Store) Get(owner, fileID string) (filePath, filename, contentType string, err error) {
 	s.mu.RLock()
 	e, ok := s.entries[fileID]
 	s.mu.RUnlock()
 
-	if !ok {
+	if !ok || e.owner != owner {
 		return "", "", "", fmt.Errorf("file not found: %s", fileID)
 	}
 
@@ -114,6 +171,67 @@ func (s *Store) Get(fileID string) (filePath, filename, contentType string, err
 	return e.path, e.filename, e.contentType, nil
 }
 
+// List returns the stored files of the owner that have not expired, oldest first.
+func (s *Store) List(owner string) []FileInfo {
+	now := time.Now()
+	s.mu.RLock()
+	files := make([]FileInfo, 0, len(s.entries))
+	for id, e := range s.entries {
+		if e.owner != owner || now.After(e.expiresAt) {
+			continue
+		}
+		files = append(files, FileInfo{
+			ID:          id,
+			Filename:    e.filename,
+			ContentType: e.contentType,
+			Size:        e.size,
+			CreatedAt:   e.createdAt,
+			ExpiresAt:   e.expiresAt,
+		})
+	}
+	s.mu.RUnlock()
+
+	sort.Slice(files, func(i, j int) bool { return files[i].CreatedAt.Before(files[j].CreatedAt) })
+	return files
+}
+
+// Delete removes a stored file of the owner before it expires.
+func (s *Store) Delete(owner, fileID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if e, ok := s.entries[fileID]; !ok || e.owner != owner {
+		return fmt.Errorf("file not found: %s", fileID)
+	}
+	s.removeLocked(fileID)
+	return nil
+}
+
+// TotalSize returns the size of all stored files in bytes.
+func (s *Store) TotalSize() int64 {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.totalSize
+}
+
+// evictFor removes the oldest files until size more bytes fit in the total limit.
+// The caller must hold the lock.
+func (s *Store) evictFor(size int64) {
+	if s.maxTotal <= 0 {
+		return
+	}
+	for s.totalSize+size > s.maxTotal && len(s.entries) > 0 {
+		var oldestID string
+		var oldest time.Time
+		for id, e := range s.entries {
+			if oldestID == "" || e.createdAt.Before(oldest) {
+				oldestID, oldest = id, e.createdAt
+			}
+		}
+		s.removeLocked(oldestID)
+	}
+}
+
 // Close stops the cleanup goroutine and removes the temp directory.
 func (s *Store) Close() {
 	close(s.done)
@@ -141,8 +259,7 @@ func (s *Store) cleanup() {
 
 	for id, e := range s.entries {
 		if now.After(e.expiresAt) {
-			os.Remove(e.path)
-			delete(s.entries, id)
+			s.removeLocked(id)
 		}
 	}
 }
@@ -150,10 +267,15 @@ func (s *Store) cleanup() {
 func (s *Store) remove(fileID string) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
+	s.removeLocked(fileID)
+}
 
+// removeLocked deletes a file and its entry; the caller must hold the lock.
+func (s *Store) removeLocked(fileID string) {
 	if e, ok := s.entries[fileID]; ok {
 		os.Remove(e.path)
 		delete(s.entries, fileID)
+		s.totalSize -= e.size
 	}
 }
 
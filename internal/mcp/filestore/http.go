@@ -2,15 +2,17 @@ package filestore
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
-	"io"
+	"mime/multipart"
 	"net/http"
 	"strings"
 )
 
-// ServeFile returns an http.HandlerFunc that serves stored files by ID.
+// ServeFile returns an http.HandlerFunc that serves stored files by ID, to the owner
+// of the file only.
 // Expected path: GET /mcpfiles/{id}
-func ServeFile(store *Store) http.HandlerFunc {
+func ServeFile(store *Store, owner func(r *http.Request) string) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		if r.Method != http.MethodGet {
 			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
@@ -23,7 +25,7 @@ func ServeFile(store *Store) http.HandlerFunc {
 			return
 		}
 
-		filePath, filename, contentType, err := store.Get(id)
+		filePath, filename, contentType, err := store.Get(owner(r), id)
 		if err != nil {
 			http.Error(w, err.Error(), http.StatusNotFound)
 			return
@@ -35,9 +37,10 @@ func ServeFile(store *Store) http.HandlerFunc {
 	}
 }
 
-// UploadFile returns an http.HandlerFunc that accepts multipart uploads.
+// UploadFile returns an http.HandlerFunc that accepts multipart uploads, stored as files
+// of the owner of the request.
 // Expected path: POST /mcpfiles
-func UploadFile(store *Store) http.HandlerFunc {
+func UploadFile(store *Store, owner func(r *http.Request) string) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		if r.Method != http.MethodPost {
 			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
@@ -45,24 +48,26 @@ func UploadFile(store *Store) http.HandlerFunc {
 		}
 
 		// Limit request body to store max size + overhead
-		r.Body = http.MaxBytesReader(w, r.Body, store.maxSize+1024*1024)
-
-		file, header, err := r.FormFile("file")
-		if err != nil {
-			http.Error(w, fmt.Sprintf("failed to read file: %v", err), http.StatusBadRequest)
-			return
+		if store.maxSize > 0 {
+			r.Body = http.MaxBytesReader(w, r.Body, store.maxSize+1024*1024)
 		}
-		defer file.Close()
 
-		data, err := io.ReadAll(file)
+		// Stream the "file" part straight to disk instead of buffering the form
+		part, err := filePart(r)
 		if err != nil {
-			http.Error(w, fmt.Sprintf("failed to read file data: %v", err), http.StatusBadRequest)
+			http.Error(w, fmt.Sprintf("failed to read file: %v", err), http.StatusBadRequest)
 			return
 		}
+		defer part.Close()
 
-		fileID, err := store.Put(data, header.Filename)
+		fileID, err := store.PutReader(owner(r), part, part.FileName())
 		if err != nil {
-			http.Error(w, err.Error(), http.StatusRequestEntityTooLarge)
+			status := http.StatusBadRequest
+			var maxBytesErr *http.MaxBytesError
+			if errors.Is(err, ErrTooLarge) || errors.As(err, &maxBytesErr) {
+				status = http.StatusRequestEntityTooLarge
+			}
+			http.Error(w, err.Error(), status)
 			return
 		}
 
@@ -72,3 +77,21 @@ func UploadFile(store *Store) http.HandlerFunc {
 		})
 	}
 }
+
+// filePart returns the "file" part of a multipart request
+func filePart(r *http.Request) (*multipart.Part, error) {
+	reader, err := r.MultipartReader()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		part, err := reader.NextPart()
+		if err != nil {
+			return nil, fmt.Errorf("no file part: %w", err)
+		}
+		if part.FormName() == "file" && part.FileName() != "" {
+			return part, nil
+		}
+		part.Close()
+	}
+}
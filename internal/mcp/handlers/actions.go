@@ -0,0 +1,92 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/mkozhukh/youtrack/internal/mcp/audit"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// defaultActionsLimit is the number of actions get_recent_actions returns by default
+const defaultActionsLimit = 10
+
+// maxActionValueLength is the length argument values and results are cut to in the listing
+const maxActionValueLength = 120
+
+// ActionHandlers manages the session action log MCP operations
+type ActionHandlers struct {
+	actions    ActionLog
+	toolLogger func(string, map[string]interface{})
+}
+
+// ActionLog defines the interface for reading the actions of the current session
+type ActionLog interface {
+	Recent(ctx context.Context, limit int) []audit.Action
+}
+
+// NewActionHandlers creates a new instance of ActionHandlers
+func NewActionHandlers(actions ActionLog, toolLogger func(string, map[string]interface{})) *ActionHandlers {
+	return &ActionHandlers{
+		actions:    actions,
+		toolLogger: toolLogger,
+	}
+}
+
+// GetRecentActionsHandler handles the get_recent_actions tool call
+func (h *ActionHandlers) GetRecentActionsHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	limit := defaultActionsLimit
+	if maxArg, ok := request.GetArguments()["max_results"].(float64); ok && maxArg > 0 {
+		limit = int(maxArg)
+	}
+
+	if h.toolLogger != nil {
+		h.toolLogger("get_recent_actions", map[string]interface{}{
+			"max_results": limit,
+		})
+	}
+
+	return mcp.NewToolResultText(formatActions(h.actions.Recent(ctx, limit))), nil
+}
+
+// formatActions formats the actions, one per line with the arguments and the result
+func formatActions(actions []audit.Action) string {
+	if len(actions) == 0 {
+		return "No changes made in this session yet\n"
+	}
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("Recent actions in this session (%d, most recent first):\n", len(actions)))
+	for _, action := range actions {
+		status := "ok"
+		if action.Failed {
+			status = "failed"
+		}
+		sb.WriteString(fmt.Sprintf("- [%s] %s (%s)\n", action.Time.Format("15:04:05"), action.Tool, status))
+
+		names := make([]string, 0, len(action.Args))
+		for name := range action.Args {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			sb.WriteString(fmt.Sprintf("  %s: %s\n", name, truncateActionValue(fmt.Sprint(action.Args[name]))))
+		}
+		if action.Result != "" {
+			sb.WriteString(fmt.Sprintf("  Result: %s\n", truncateActionValue(action.Result)))
+		}
+	}
+	return sb.String()
+}
+
+// truncateActionValue shortens a value to a single line of maxActionValueLength characters
+func truncateActionValue(value string) string {
+	value = strings.Join(strings.Fields(value), " ")
+	if runes := []rune(value); len(runes) > maxActionValueLength {
+		return string(runes[:maxActionValueLength]) + "..."
+	}
+	return value
+}
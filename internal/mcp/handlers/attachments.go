@@ -4,13 +4,16 @@ import (
 	"context"
 	"encoding/base64"
 	"fmt"
+	"io"
 	"os"
 	"strings"
+	"time"
 
 	"github.com/mkozhukh/youtrack/internal/mcp/filestore"
 	"github.com/mkozhukh/youtrack/pkg/youtrack"
 
 	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
 )
 
 // AttachmentHandlers manages attachment-related MCP operations
@@ -20,6 +23,7 @@ type AttachmentHandlers struct {
 	errorHandler *ErrorHandler
 	fileStore    *filestore.Store
 	fileBaseURL  string
+	maxImageSize int64 // largest image returned as image content, 0 disables it
 }
 
 // AttachmentClient defines the interface for YouTrack client operations needed for attachment management
@@ -28,25 +32,30 @@ type AttachmentClient interface {
 	GetIssueAttachmentContent(ctx context.Context, issueID string, attachmentID string) ([]byte, error)
 	DownloadByURL(ctx context.Context, rawURL string) ([]byte, error)
 	AddIssueAttachmentFromBytes(ctx context.Context, issueID string, content []byte, filename string) (*youtrack.Attachment, error)
+	AddIssueAttachmentFromReader(ctx context.Context, issueID string, content io.Reader, size int64, filename string, progress youtrack.UploadProgress) (*youtrack.Attachment, error)
+	DeleteIssueAttachment(ctx context.Context, issueID string, attachmentID string) error
+	GetKeyHash(ctx context.Context) string
 }
 
 // NewAttachmentHandlers creates a new instance of AttachmentHandlers
-func NewAttachmentHandlers(ytClient AttachmentClient, toolLogger func(string, map[string]interface{})) *AttachmentHandlers {
+func NewAttachmentHandlers(ytClient AttachmentClient, toolLogger func(string, map[string]interface{}), maxImageSize int64) *AttachmentHandlers {
 	return &AttachmentHandlers{
 		ytClient:     ytClient,
 		toolLogger:   toolLogger,
 		errorHandler: NewErrorHandler(),
+		maxImageSize: maxImageSize,
 	}
 }
 
 // NewAttachmentHandlersWithFileStore creates AttachmentHandlers with file server support
-func NewAttachmentHandlersWithFileStore(ytClient AttachmentClient, toolLogger func(string, map[string]interface{}), store *filestore.Store, baseURL string) *AttachmentHandlers {
+func NewAttachmentHandlersWithFileStore(ytClient AttachmentClient, toolLogger func(string, map[string]interface{}), store *filestore.Store, baseURL string, maxImageSize int64) *AttachmentHandlers {
 	return &AttachmentHandlers{
 		ytClient:     ytClient,
 		toolLogger:   toolLogger,
 		errorHandler: NewErrorHandler(),
 		fileStore:    store,
 		fileBaseURL:  baseURL,
+		maxImageSize: maxImageSize,
 	}
 }
 
@@ -67,9 +76,12 @@ func (h *AttachmentHandlers) GetIssueAttachmentsHandler(ctx context.Context, req
 		return h.errorHandler.FormatValidationError("issue_id", err), nil
 	}
 
+	includeImages := request.GetBool("include_images", false)
+
 	if h.toolLogger != nil {
 		h.toolLogger("get_issue_attachments", map[string]interface{}{
-			"issue_id": issueID,
+			"issue_id":       issueID,
+			"include_images": includeImages,
 		})
 	}
 
@@ -92,13 +104,51 @@ func (h *AttachmentHandlers) GetIssueAttachmentsHandler(ctx context.Context, req
 		if att.MimeType != "" {
 			sb.WriteString(fmt.Sprintf("  Type: %s\n", att.MimeType))
 		}
+		if att.ImageDimensions != nil {
+			sb.WriteString(fmt.Sprintf("  Dimensions: %dx%d\n", att.ImageDimensions.Width, att.ImageDimensions.Height))
+		}
+		if att.ThumbnailURL != "" {
+			sb.WriteString(fmt.Sprintf("  Thumbnail: %s\n", att.ThumbnailURL))
+		}
 		if att.Author != nil {
 			sb.WriteString(fmt.Sprintf("  Author: %s\n", att.Author.Login))
 		}
 		sb.WriteString(fmt.Sprintf("  Created: %s\n", att.Created.Format("2006-01-02 15:04:05")))
 	}
 
-	return mcp.NewToolResultText(sb.String()), nil
+	result := mcp.NewToolResultText(sb.String())
+	if includeImages {
+		for _, att := range attachments {
+			h.appendImage(ctx, result, att)
+		}
+	}
+	return result, nil
+}
+
+// appendImage adds an image attachment to the result as image content, when images
+// are enabled and it is not larger than the configured limit. Skipped images are noted as text.
+func (h *AttachmentHandlers) appendImage(ctx context.Context, result *mcp.CallToolResult, att *youtrack.Attachment) {
+	if !att.IsImage() {
+		return
+	}
+	if h.maxImageSize <= 0 {
+		result.Content = append(result.Content, mcp.NewTextContent(fmt.Sprintf("Image %s not included: inline images are disabled", att.Name)))
+		return
+	}
+	if att.Size > h.maxImageSize {
+		result.Content = append(result.Content, mcp.NewTextContent(fmt.Sprintf("Image %s not included: %d bytes exceeds the %d bytes limit", att.Name, att.Size, h.maxImageSize)))
+		return
+	}
+
+	data, err := h.ytClient.DownloadByURL(ctx, att.URL)
+	if err != nil {
+		result.Content = append(result.Content, mcp.NewTextContent(fmt.Sprintf("Image %s not included: %v", att.Name, err)))
+		return
+	}
+	result.Content = append(result.Content,
+		mcp.NewTextContent(fmt.Sprintf("Image %s (ID: %s):", att.Name, att.ID)),
+		mcp.NewImageContent(base64.StdEncoding.EncodeToString(data), att.MimeType),
+	)
 }
 
 // GetIssueAttachmentContentHandler handles the get_issue_attachment_content tool call.
@@ -115,18 +165,37 @@ func (h *AttachmentHandlers) GetIssueAttachmentContentHandler(ctx context.Contex
 		return h.errorHandler.FormatValidationError("attachment_id", err), nil
 	}
 
+	includeImages := request.GetBool("include_images", false)
+
 	if h.toolLogger != nil {
 		h.toolLogger("get_issue_attachment_content", map[string]interface{}{
-			"issue_id":      issueID,
-			"attachment_id": attachmentID,
+			"issue_id":       issueID,
+			"attachment_id":  attachmentID,
+			"include_images": includeImages,
 		})
 	}
 
+	var result *mcp.CallToolResult
 	if h.fileStore != nil {
-		return h.getAttachmentContentViaFileStore(ctx, issueID, attachmentID)
+		result, err = h.getAttachmentContentViaFileStore(ctx, issueID, attachmentID)
+	} else {
+		result, err = h.getAttachmentContentURL(ctx, issueID, attachmentID)
+	}
+	if err != nil || result.IsError || !includeImages {
+		return result, err
 	}
 
-	return h.getAttachmentContentURL(ctx, issueID, attachmentID)
+	// Return the image itself as well
+	attachments, err := h.ytClient.GetIssueAttachments(ctx, issueID)
+	if err != nil {
+		return result, nil
+	}
+	for _, att := range attachments {
+		if att.ID == attachmentID {
+			h.appendImage(ctx, result, att)
+		}
+	}
+	return result, nil
 }
 
 // getAttachmentContentViaFileStore downloads content from YT, stores in filestore, returns local URL
@@ -155,7 +224,7 @@ func (h *AttachmentHandlers) getAttachmentContentViaFileStore(ctx context.Contex
 	}
 
 	// Store in file store
-	fileID, err := h.fileStore.Put(data, att.Name)
+	fileID, err := h.fileStore.Put(h.ytClient.GetKeyHash(ctx), data, att.Name)
 	if err != nil {
 		return mcp.NewToolResultError(fmt.Sprintf("Failed to store file: %v", err)), nil
 	}
@@ -228,17 +297,24 @@ func (h *AttachmentHandlers) uploadAttachmentFromFileStore(ctx context.Context,
 		})
 	}
 
-	filePath, _, _, err := h.fileStore.Get(fileID)
+	filePath, _, _, err := h.fileStore.Get(h.ytClient.GetKeyHash(ctx), fileID)
 	if err != nil {
 		return mcp.NewToolResultError(fmt.Sprintf("Failed to retrieve file from store: %v", err)), nil
 	}
 
-	content, err := os.ReadFile(filePath)
+	// Stream the stored file to YouTrack, so its size is not limited by memory
+	file, err := os.Open(filePath)
 	if err != nil {
 		return mcp.NewToolResultError(fmt.Sprintf("Failed to read stored file: %v", err)), nil
 	}
+	defer file.Close()
 
-	attachment, err := h.ytClient.AddIssueAttachmentFromBytes(ctx, issueID, content, filename)
+	info, err := file.Stat()
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to read stored file: %v", err)), nil
+	}
+
+	attachment, err := h.ytClient.AddIssueAttachmentFromReader(ctx, issueID, file, info.Size(), filename, uploadProgress(ctx, request))
 	if err != nil {
 		return h.errorHandler.HandleError(err, "uploading attachment"), nil
 	}
@@ -296,3 +372,115 @@ func (h *AttachmentHandlers) uploadAttachmentFromBase64(ctx context.Context, req
 
 	return mcp.NewToolResultText(response), nil
 }
+
+// uploadProgressStep is the number of bytes between progress notifications
+const uploadProgressStep = 1024 * 1024
+
+// uploadProgress returns a callback sending MCP progress notifications for an upload,
+// or nil when the client did not ask for progress
+func uploadProgress(ctx context.Context, request mcp.CallToolRequest) youtrack.UploadProgress {
+	if request.Params.Meta == nil || request.Params.Meta.ProgressToken == nil {
+		return nil
+	}
+	srv := server.ServerFromContext(ctx)
+	if srv == nil {
+		return nil
+	}
+
+	token := request.Params.Meta.ProgressToken
+	var reported int64
+	return func(sent, total int64) {
+		if sent-reported < uploadProgressStep && sent != total {
+			return
+		}
+		reported = sent
+		params := map[string]any{
+			"progressToken": token,
+			"progress":      sent,
+			"message":       "Uploading attachment",
+		}
+		if total >= 0 {
+			params["total"] = total
+		}
+		_ = srv.SendNotificationToClient(ctx, "notifications/progress", params)
+	}
+}
+
+// DeleteAttachmentHandler handles the delete_attachment tool call
+func (h *AttachmentHandlers) DeleteAttachmentHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	issueID, err := request.RequireString("issue_id")
+	if err != nil {
+		return h.errorHandler.FormatValidationError("issue_id", err), nil
+	}
+
+	attachmentID, err := request.RequireString("attachment_id")
+	if err != nil {
+		return h.errorHandler.FormatValidationError("attachment_id", err), nil
+	}
+
+	if h.toolLogger != nil {
+		h.toolLogger("delete_attachment", map[string]interface{}{
+			"issue_id":      issueID,
+			"attachment_id": attachmentID,
+		})
+	}
+
+	err = h.ytClient.DeleteIssueAttachment(ctx, issueID, attachmentID)
+	if err != nil {
+		return h.errorHandler.HandleError(err, "deleting attachment"), nil
+	}
+
+	return mcp.NewToolResultText(fmt.Sprintf("Attachment %s deleted from issue %s.", attachmentID, issueID)), nil
+}
+
+// ListStoredFilesHandler handles the list_stored_files tool call
+func (h *AttachmentHandlers) ListStoredFilesHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	if h.fileStore == nil {
+		return mcp.NewToolResultError("The file server is not enabled"), nil
+	}
+
+	if h.toolLogger != nil {
+		h.toolLogger("list_stored_files", map[string]interface{}{})
+	}
+
+	files := h.fileStore.List(h.ytClient.GetKeyHash(ctx))
+	if len(files) == 0 {
+		return mcp.NewToolResultText("The file store is empty."), nil
+	}
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("Stored files (%d, %d bytes in total):\n\n", len(files), h.fileStore.TotalSize()))
+	now := time.Now()
+	for _, f := range files {
+		sb.WriteString(fmt.Sprintf("- %s (ID: %s)\n", f.Filename, f.ID))
+		sb.WriteString(fmt.Sprintf("  Size: %d bytes, Type: %s\n", f.Size, f.ContentType))
+		sb.WriteString(fmt.Sprintf("  Stored: %s, expires in %s\n", f.CreatedAt.Format("2006-01-02 15:04:05"), f.ExpiresAt.Sub(now).Round(time.Second)))
+		sb.WriteString(fmt.Sprintf("  URL: %s/mcpfiles/%s\n", h.fileBaseURL, f.ID))
+	}
+
+	return mcp.NewToolResultText(sb.String()), nil
+}
+
+// DeleteStoredFileHandler handles the delete_stored_file tool call
+func (h *AttachmentHandlers) DeleteStoredFileHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	if h.fileStore == nil {
+		return mcp.NewToolResultError("The file server is not enabled"), nil
+	}
+
+	fileID, err := request.RequireString("file_id")
+	if err != nil {
+		return h.errorHandler.FormatValidationError("file_id", err), nil
+	}
+
+	if h.toolLogger != nil {
+		h.toolLogger("delete_stored_file", map[string]interface{}{
+			"file_id": fileID,
+		})
+	}
+
+	if err := h.fileStore.Delete(h.ytClient.GetKeyHash(ctx), fileID); err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	return mcp.NewToolResultText(fmt.Sprintf("Stored file %s deleted.", fileID)), nil
+}
@@ -0,0 +1,194 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"github.com/mkozhukh/youtrack/pkg/youtrack"
+)
+
+// maxBatchOperations limits the operations of one batch_update_issues call
+const maxBatchOperations = 50
+
+// batchLine is the outcome of one operation of a batch_update_issues call
+type batchLine struct {
+	opType  string
+	issueID string
+	err     string
+	notes   []string
+}
+
+// BatchUpdateIssuesHandler handles the batch_update_issues tool call
+func (h *IssueHandlers) BatchUpdateIssuesHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	rawOps, _ := request.GetArguments()["operations"].([]interface{})
+	if len(rawOps) == 0 {
+		return mcp.NewToolResultError("operations is required: a list of update or comment operations"), nil
+	}
+	if len(rawOps) > maxBatchOperations {
+		return mcp.NewToolResultError(fmt.Sprintf("Too many operations: %d, at most %d per call", len(rawOps), maxBatchOperations)), nil
+	}
+
+	// Log the tool call
+	if h.toolLogger != nil {
+		h.toolLogger("batch_update_issues", map[string]interface{}{
+			"operations": len(rawOps),
+		})
+	}
+
+	// Operations that cannot be prepared fail without running, positions maps the others
+	lines := make([]batchLine, len(rawOps))
+	var ops []youtrack.BatchOperation
+	var positions []int
+	for i, raw := range rawOps {
+		op, line, err := h.prepareBatchOperation(ctx, raw)
+		lines[i] = line
+		if err != nil {
+			lines[i].err = err.Error()
+			continue
+		}
+		ops = append(ops, op)
+		positions = append(positions, i)
+	}
+
+	if len(ops) > 0 {
+		report := h.ytClient.ExecuteBatch(ctx, ops, youtrack.BatchOptions{})
+		for j, result := range report.Results {
+			if !result.Success {
+				lines[positions[j]].err = result.Error
+			}
+		}
+	}
+
+	failed := 0
+	for _, line := range lines {
+		if line.err != "" {
+			failed++
+		}
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "Batch: %d succeeded, %d failed\n\n", len(lines)-failed, failed)
+	for i, line := range lines {
+		issueID := line.issueID
+		if issueID == "" {
+			issueID = "(no issue)"
+		}
+		if line.err != "" {
+			fmt.Fprintf(&b, "%d. ❌ %s %s: %s\n", i+1, line.opType, issueID, line.err)
+		} else {
+			fmt.Fprintf(&b, "%d. ✅ %s %s\n", i+1, line.opType, issueID)
+		}
+		for _, note := range line.notes {
+			fmt.Fprintf(&b, "   ⚠️ %s\n", note)
+		}
+	}
+
+	if failed == len(lines) {
+		return mcp.NewToolResultError(b.String()), nil
+	}
+	return mcp.NewToolResultText(b.String()), nil
+}
+
+// prepareBatchOperation validates an operation of the call and builds it; update values are
+// resolved like update_issue does, corrections are returned as notes
+func (h *IssueHandlers) prepareBatchOperation(ctx context.Context, raw interface{}) (youtrack.BatchOperation, batchLine, error) {
+	var line batchLine
+	args, ok := raw.(map[string]interface{})
+	if !ok {
+		return youtrack.BatchOperation{}, line, fmt.Errorf("operation must be an object")
+	}
+
+	line.opType, _ = args["type"].(string)
+	line.issueID, _ = args["issue_id"].(string)
+	if line.issueID == "" {
+		return youtrack.BatchOperation{}, line, fmt.Errorf("issue_id is required")
+	}
+
+	switch youtrack.BatchOperationType(line.opType) {
+	case youtrack.BatchComment:
+		text, _ := args["text"].(string)
+		if strings.TrimSpace(text) == "" {
+			return youtrack.BatchOperation{}, line, fmt.Errorf("text is required for a comment")
+		}
+		return youtrack.BatchOperation{Type: youtrack.BatchComment, IssueID: line.issueID, Comment: text}, line, nil
+
+	case youtrack.BatchUpdate:
+		req, notes, err := h.buildBatchUpdate(ctx, line.issueID, args)
+		line.notes = notes
+		if err != nil {
+			return youtrack.BatchOperation{}, line, err
+		}
+		return youtrack.BatchOperation{Type: youtrack.BatchUpdate, IssueID: line.issueID, Update: req}, line, nil
+
+	default:
+		return youtrack.BatchOperation{}, line, fmt.Errorf("unknown operation type '%s', use update or comment", line.opType)
+	}
+}
+
+// buildBatchUpdate builds the update request of an update operation
+func (h *IssueHandlers) buildBatchUpdate(ctx context.Context, issueID string, args map[string]interface{}) (*youtrack.UpdateIssueRequest, []string, error) {
+	summary, _ := args["summary"].(string)
+	description, _ := args["description"].(string)
+	state, _ := args["state"].(string)
+	assignee, _ := args["assignee"].(string)
+	if summary == "" && description == "" && state == "" && assignee == "" {
+		return nil, nil, fmt.Errorf("nothing to update, set summary, description, state or assignee")
+	}
+
+	req := &youtrack.UpdateIssueRequest{}
+	if summary != "" {
+		req.Summary = &summary
+	}
+	if description != "" {
+		req.Description = &description
+	}
+	if state == "" && assignee == "" {
+		return req, nil, nil
+	}
+
+	// The project comes from the readable ID of the issue, issue_id may be a database ID
+	issue, err := h.ytClient.GetIssue(ctx, issueID)
+	if err != nil {
+		return nil, nil, youtrack.TranslateError(err)
+	}
+	projectID := extractProjectFromIssueID(issue.ID)
+	if projectID == "" {
+		return nil, nil, fmt.Errorf("could not find the project of issue %s", issueID)
+	}
+
+	// Fields are built with the types the project uses
+	var notes []string
+	patch, err := h.ytClient.NewCustomFieldPatch(ctx, projectID)
+	if err != nil {
+		return nil, nil, youtrack.TranslateError(err)
+	}
+	if state != "" {
+		resolved, err := h.resolver.ResolveEnumMatch(ctx, projectID, "State", state)
+		if err != nil {
+			return nil, nil, youtrack.TranslateError(err)
+		}
+		if note := resolved.Note(); note != "" {
+			notes = append(notes, "State: "+note)
+		}
+		patch.Set("State", resolved.Value)
+	}
+	if assignee != "" {
+		resolved, err := h.resolver.ResolveUserMatch(ctx, projectID, assignee)
+		if err != nil {
+			return nil, notes, youtrack.TranslateError(err)
+		}
+		if note := resolved.Note(); note != "" {
+			notes = append(notes, "Assignee: "+note)
+		}
+		patch.Set("Assignee", resolved.Value)
+	}
+
+	req.Fields, err = patch.Fields()
+	if err != nil {
+		return nil, notes, err
+	}
+	return req, notes, nil
+}
@@ -13,16 +13,23 @@ type CacheManager interface {
 	DropAll()
 }
 
+// CacheWarmer defines the interface for re-fetching project metadata into the cache
+type CacheWarmer interface {
+	WarmUp(ctx context.Context, projectIDs []string)
+}
+
 // CacheHandlers manages cache-related MCP operations
 type CacheHandlers struct {
 	cache      CacheManager
+	warmer     CacheWarmer
 	toolLogger func(string, map[string]interface{})
 }
 
 // NewCacheHandlers creates a new instance of CacheHandlers
-func NewCacheHandlers(cache CacheManager, toolLogger func(string, map[string]interface{})) *CacheHandlers {
+func NewCacheHandlers(cache CacheManager, warmer CacheWarmer, toolLogger func(string, map[string]interface{})) *CacheHandlers {
 	return &CacheHandlers{
 		cache:      cache,
+		warmer:     warmer,
 		toolLogger: toolLogger,
 	}
 }
@@ -46,3 +53,28 @@ func (h *CacheHandlers) DropCacheHandler(ctx context.Context, request mcp.CallTo
 	h.cache.DropAll()
 	return mcp.NewToolResultText("Cache dropped for all projects."), nil
 }
+
+// RefreshCacheHandler handles the refresh_cache tool call
+func (h *CacheHandlers) RefreshCacheHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	args := request.GetArguments()
+	projectID, _ := args["project_id"].(string)
+
+	if h.toolLogger != nil {
+		h.toolLogger("refresh_cache", map[string]interface{}{
+			"project_id": projectID,
+		})
+	}
+
+	if projectID == "" {
+		h.cache.DropAll()
+		return mcp.NewToolResultText("Cache dropped for all projects. Metadata will be re-fetched on next use."), nil
+	}
+
+	// Drop and immediately re-fetch, so the next resolver lookups see the new configuration
+	h.cache.DropProject(projectID)
+	if h.warmer != nil {
+		h.warmer.WarmUp(ctx, []string{projectID})
+	}
+
+	return mcp.NewToolResultText(fmt.Sprintf("Cache refreshed for project '%s': custom fields, allowed values and users re-fetched.", projectID)), nil
+}
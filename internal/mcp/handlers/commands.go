@@ -4,7 +4,7 @@ import (
 	"context"
 	"fmt"
 
-	"github.com/mkozhukh/youtrack/internal/mcp/resolver"
+	"github.com/mkozhukh/youtrack/internal/resolver"
 	"github.com/mkozhukh/youtrack/pkg/youtrack"
 
 	"github.com/mark3labs/mcp-go/mcp"
@@ -21,16 +21,15 @@ type CommandHandlers struct {
 // CommandClient defines the interface for YouTrack client operations needed for command execution
 type CommandClient interface {
 	ApplyCommand(ctx context.Context, issueID string, command string) error
-	// Resolver support
-	GetProjectUsers(ctx context.Context, projectID string, skip, top int) ([]*youtrack.User, error)
-	GetCustomFieldAllowedValues(ctx context.Context, projectID string, fieldName string) ([]youtrack.AllowedValue, error)
+	GetIssue(ctx context.Context, issueID string) (*youtrack.Issue, error)
 }
 
 // NewCommandHandlers creates a new instance of CommandHandlers
-func NewCommandHandlers(ytClient CommandClient, toolLogger func(string, map[string]interface{})) *CommandHandlers {
+// resolverClient backs user and enum value resolution; pass a cached client to avoid repeated lookups
+func NewCommandHandlers(ytClient CommandClient, resolverClient resolver.ResolverClient, toolLogger func(string, map[string]interface{})) *CommandHandlers {
 	return &CommandHandlers{
 		ytClient:     ytClient,
-		resolver:     resolver.NewResolver(ytClient),
+		resolver:     resolver.NewResolver(resolverClient),
 		toolLogger:   toolLogger,
 		errorHandler: NewErrorHandler(),
 	}
@@ -48,10 +47,14 @@ func (h *CommandHandlers) ApplyCommandHandler(ctx context.Context, request mcp.C
 		return h.errorHandler.FormatValidationError("command", err), nil
 	}
 
-	// Extract project ID from issue ID
-	projectID := extractProjectFromIssueID(issueID)
+	// The project comes from the readable ID of the issue, issue_id may be a database ID
+	issue, err := h.ytClient.GetIssue(ctx, issueID)
+	if err != nil {
+		return h.errorHandler.HandleError(err, "finding issue"), nil
+	}
+	projectID := extractProjectFromIssueID(issue.ID)
 	if projectID == "" {
-		return mcp.NewToolResultError("Could not extract project ID from issue ID. Issue ID should be in format PROJECT-123"), nil
+		return mcp.NewToolResultError("Could not find the project of issue " + issueID), nil
 	}
 
 	// Try to resolve field values in the command using smart matching
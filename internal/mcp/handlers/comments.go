@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/mkozhukh/youtrack/internal/resolver"
 	"github.com/mkozhukh/youtrack/pkg/youtrack"
 
 	"github.com/mark3labs/mcp-go/mcp"
@@ -13,6 +14,7 @@ import (
 // CommentHandlers manages comment-related MCP operations
 type CommentHandlers struct {
 	ytClient     CommentClient
+	resolver     *resolver.Resolver
 	toolLogger   func(string, map[string]interface{})
 	errorHandler *ErrorHandler
 }
@@ -20,13 +22,15 @@ type CommentHandlers struct {
 // CommentClient defines the interface for YouTrack client operations needed for comment management
 type CommentClient interface {
 	AddIssueComment(ctx context.Context, issueID string, comment string) (*youtrack.IssueComment, error)
+	ReplyToIssueComment(ctx context.Context, issueID, commentID string, comment string) (*youtrack.IssueComment, error)
 	GetIssue(ctx context.Context, issueID string) (*youtrack.Issue, error)
 }
 
 // NewCommentHandlers creates a new instance of CommentHandlers
-func NewCommentHandlers(ytClient CommentClient, toolLogger func(string, map[string]interface{})) *CommentHandlers {
+func NewCommentHandlers(ytClient CommentClient, resolverClient resolver.ResolverClient, toolLogger func(string, map[string]interface{})) *CommentHandlers {
 	return &CommentHandlers{
 		ytClient:     ytClient,
+		resolver:     resolver.NewResolver(resolverClient),
 		toolLogger:   toolLogger,
 		errorHandler: NewErrorHandler(),
 	}
@@ -50,11 +54,14 @@ func (h *CommentHandlers) AddCommentHandler(ctx context.Context, request mcp.Cal
 		return h.errorHandler.FormatValidationError("comment", err), nil
 	}
 
+	replyTo := request.GetString("reply_to", "")
+
 	// Log the tool call
 	if h.toolLogger != nil {
 		h.toolLogger("add_comment", map[string]interface{}{
 			"issue_id": issueID,
 			"comment":  commentText,
+			"reply_to": replyTo,
 		})
 	}
 
@@ -64,8 +71,23 @@ func (h *CommentHandlers) AddCommentHandler(ctx context.Context, request mcp.Cal
 		return h.errorHandler.HandleError(err, "finding issue"), nil
 	}
 
-	// Add the comment to the issue
-	comment, err := h.ytClient.AddIssueComment(ctx, issueID, commentText)
+	// Mentions of names are rewritten to logins; a failed user lookup keeps them as written
+	var notes []string
+	mentions, err := h.resolver.ResolveMentions(ctx, extractProjectFromIssueID(issue.ID), commentText)
+	if err != nil {
+		notes = append(notes, fmt.Sprintf("Mentions left as written: %v", err))
+	} else {
+		commentText = mentions.Text
+		notes = append(append(notes, mentions.Notes...), mentions.Warnings...)
+	}
+
+	// Add the comment to the issue, in the thread of the replied comment
+	var comment *youtrack.IssueComment
+	if replyTo != "" {
+		comment, err = h.ytClient.ReplyToIssueComment(ctx, issueID, replyTo, commentText)
+	} else {
+		comment, err = h.ytClient.AddIssueComment(ctx, issueID, commentText)
+	}
 	if err != nil {
 		return h.errorHandler.HandleError(err, "adding comment to issue"), nil
 	}
@@ -78,9 +100,15 @@ func (h *CommentHandlers) AddCommentHandler(ctx context.Context, request mcp.Cal
 		details += fmt.Sprintf("Author: %s\n", comment.Author.Login)
 	}
 	details += fmt.Sprintf("Created: %s\n", comment.Created.Format("2006-01-02 15:04:05"))
+	if comment.ReplyTo != nil {
+		details += fmt.Sprintf("Reply to: %s\n", comment.ReplyTo.ID)
+	}
 	details += fmt.Sprintf("\nComment text:\n%s", comment.Text)
 
 	response := h.formatSuccessResult("Comment added successfully!", details)
+	for _, note := range notes {
+		response += fmt.Sprintf("⚠️ %s\n", note)
+	}
 	return mcp.NewToolResultText(response), nil
 }
 
@@ -0,0 +1,60 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/mkozhukh/youtrack/pkg/youtrack"
+)
+
+// maxDuplicateCandidates is the number of likely duplicates create_issue reports
+const maxDuplicateCandidates = 5
+
+// SetDuplicateCheck makes create_issue look for open issues of the project with a similar
+// summary, created in the last window (0 for any time), and refuse to create the issue when
+// one scores minScore or more, unless called with force
+func (h *IssueHandlers) SetDuplicateCheck(minScore float64, window time.Duration) {
+	h.duplicateCheck = true
+	h.duplicateMinScore = minScore
+	h.duplicateWindow = window
+}
+
+// findDuplicates returns the open issues of the project similar enough to the summary to be
+// likely duplicates, best first
+func (h *IssueHandlers) findDuplicates(ctx context.Context, projectID, summary string) ([]*youtrack.SimilarIssue, error) {
+	opts := youtrack.SimilarIssuesOptions{
+		Project:        projectID,
+		UnresolvedOnly: true,
+		Limit:          maxDuplicateCandidates,
+	}
+	if h.duplicateWindow > 0 {
+		opts.CreatedSince = time.Now().Add(-h.duplicateWindow)
+	}
+
+	similar, err := h.ytClient.FindSimilarIssues(ctx, summary, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	var duplicates []*youtrack.SimilarIssue
+	for _, candidate := range similar {
+		if candidate.Score >= h.duplicateMinScore {
+			duplicates = append(duplicates, candidate)
+		}
+	}
+	return duplicates, nil
+}
+
+// formatDuplicateWarning explains why the issue was not created and lists the candidates
+func formatDuplicateWarning(summary string, duplicates []*youtrack.SimilarIssue) string {
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("⚠️  Issue not created: %d open issue(s) look like duplicates of '%s':\n", len(duplicates), summary))
+	for _, d := range duplicates {
+		sb.WriteString(fmt.Sprintf("- %s: %s (similarity %.0f%%, created %s)\n",
+			d.Issue.ID, d.Issue.Summary, d.Score*100, d.Issue.Created.Format("2006-01-02")))
+	}
+	sb.WriteString("\nCheck these issues first, e.g. add a comment to an existing one. To create the issue anyway, call create_issue again with force: true.\n")
+	return sb.String()
+}
@@ -0,0 +1,133 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"github.com/mkozhukh/youtrack/internal/resolver"
+	"github.com/mkozhukh/youtrack/pkg/youtrack"
+)
+
+// maxEpicSubtasks limits the subtasks of one create_epic call
+const maxEpicSubtasks = 50
+
+// CreateEpicHandler handles the create_epic tool call
+func (h *IssueHandlers) CreateEpicHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	// Extract parameters
+	projectID, err := request.RequireString("project_id")
+	if err != nil {
+		return h.errorHandler.FormatValidationError("project_id", err), nil
+	}
+
+	summary, err := request.RequireString("summary")
+	if err != nil {
+		return h.errorHandler.FormatValidationError("summary", err), nil
+	}
+	if err := h.errorHandler.ValidateRequiredParameter(summary, "summary"); err != nil {
+		return h.errorHandler.FormatValidationError("summary", err), nil
+	}
+
+	args := request.GetArguments()
+	description, _ := args["description"].(string)
+	epicType, _ := args["type"].(string)
+
+	rawSubtasks, _ := args["subtasks"].([]interface{})
+	if len(rawSubtasks) == 0 {
+		return mcp.NewToolResultError("subtasks is required: a list of subtasks with a summary"), nil
+	}
+	if len(rawSubtasks) > maxEpicSubtasks {
+		return mcp.NewToolResultError(fmt.Sprintf("Too many subtasks: %d, at most %d per call", len(rawSubtasks), maxEpicSubtasks)), nil
+	}
+
+	subtasks := make([]*youtrack.CreateIssueRequest, 0, len(rawSubtasks))
+	for i, raw := range rawSubtasks {
+		item, _ := raw.(map[string]interface{})
+		subSummary, _ := item["summary"].(string)
+		if strings.TrimSpace(subSummary) == "" {
+			return mcp.NewToolResultError(fmt.Sprintf("Subtask %d has no summary", i+1)), nil
+		}
+		subDescription, _ := item["description"].(string)
+		subtasks = append(subtasks, &youtrack.CreateIssueRequest{Summary: subSummary, Description: subDescription})
+	}
+
+	// Track project usage
+	if h.projectTracker != nil {
+		h.projectTracker.TrackProject(ctx, projectID)
+	}
+
+	// Log the tool call
+	if h.toolLogger != nil {
+		h.toolLogger("create_epic", map[string]interface{}{
+			"project_id":  projectID,
+			"summary":     summary,
+			"description": description,
+			"type":        epicType,
+			"subtasks":    len(subtasks),
+		})
+	}
+
+	parent := &youtrack.CreateIssueRequest{
+		Project:     youtrack.ProjectRef{ID: projectID},
+		Summary:     summary,
+		Description: description,
+	}
+
+	// Corrections made by fuzzy matching, reported back to the caller
+	var notes []string
+	if epicType != "" {
+		resolvedType, err := h.resolver.ResolveEnumMatch(ctx, projectID, "Type", epicType)
+		if err != nil {
+			if resolveErr, ok := err.(*resolver.ResolveError); ok {
+				return mcp.NewToolResultError(resolveErr.Error()), nil
+			}
+			return h.errorHandler.HandleError(err, "resolving type value"), nil
+		}
+		if note := resolvedType.Note(); note != "" {
+			notes = append(notes, "Type: "+note)
+		}
+
+		patch, err := h.ytClient.NewCustomFieldPatch(ctx, projectID)
+		if err != nil {
+			return h.errorHandler.HandleError(err, "retrieving project fields"), nil
+		}
+		parent.Fields, err = patch.Set("Type", resolvedType.Value).Fields()
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+	}
+
+	tree, err := h.ytClient.CreateIssueTree(ctx, parent, subtasks)
+	if err != nil {
+		// Issues the rollback could not delete are named, they have to be removed by hand
+		var rollbackErr *youtrack.RollbackError
+		if errors.As(err, &rollbackErr) {
+			return mcp.NewToolResultError(fmt.Sprintf("Error during creating epic: %s\nThe issues named above were left in YouTrack and have to be deleted by hand.", err)), nil
+		}
+		return h.errorHandler.HandleError(err, "creating epic"), nil
+	}
+
+	response := h.formatCreatedTree(tree)
+	for _, note := range notes {
+		response += fmt.Sprintf("⚠️ %s\n", note)
+	}
+	return mcp.NewToolResultText(response), nil
+}
+
+// formatCreatedTree formats the created epic with its subtasks
+func (h *IssueHandlers) formatCreatedTree(tree *youtrack.IssueTree) string {
+	var details strings.Builder
+	fmt.Fprintf(&details, "Epic: %s %s\n", tree.Parent.ID, tree.Parent.Summary)
+	fmt.Fprintf(&details, "Subtasks (%d):\n", len(tree.Subtasks))
+	for i, subtask := range tree.Subtasks {
+		branch := "├─"
+		if i == len(tree.Subtasks)-1 {
+			branch = "└─"
+		}
+		fmt.Fprintf(&details, "  %s %s %s\n", branch, subtask.ID, subtask.Summary)
+	}
+	return h.formatSuccessResult("Epic created successfully!", details.String())
+}
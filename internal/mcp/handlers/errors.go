@@ -2,6 +2,7 @@ package handlers
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strings"
 
@@ -51,6 +52,21 @@ func (e *ErrorHandler) HandleError(err error, operation string) *mcp.CallToolRes
 		e.logger.LogToolError(e.keyHash, e.toolName, e.params, err.Error())
 	}
 
+	// Check if the request was aborted by the caller or timed out
+	if errors.Is(err, context.Canceled) {
+		return mcp.NewToolResultError(fmt.Sprintf("Request cancelled during %s.", operation))
+	}
+	if errors.Is(err, context.DeadlineExceeded) {
+		return mcp.NewToolResultError(fmt.Sprintf("Request timed out during %s. Try narrowing the query or retry later.", operation))
+	}
+
+	// A refused request names the permission it needs
+	err = youtrack.TranslateError(err)
+	var permErr *youtrack.PermissionError
+	if errors.As(err, &permErr) {
+		return mcp.NewToolResultError(fmt.Sprintf("Permission denied during %s: %s.", operation, permErr.Error()))
+	}
+
 	// Check if it's a YouTrack API error
 	if apiErr, ok := err.(*youtrack.APIError); ok {
 		return e.handleAPIError(apiErr, operation)
@@ -0,0 +1,63 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/mkozhukh/youtrack/pkg/youtrack"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// GetFieldHistoryHandler handles the get_field_history tool call
+func (h *IssueHandlers) GetFieldHistoryHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	issueID, err := request.RequireString("issue_id")
+	if err != nil {
+		return h.errorHandler.FormatValidationError("issue_id", err), nil
+	}
+
+	field, err := request.RequireString("field")
+	if err != nil {
+		return h.errorHandler.FormatValidationError("field", err), nil
+	}
+
+	if h.toolLogger != nil {
+		h.toolLogger("get_field_history", map[string]interface{}{
+			"issue_id": issueID,
+			"field":    field,
+		})
+	}
+
+	activities, err := h.ytClient.GetIssueActivities(ctx, issueID)
+	if err != nil {
+		return h.errorHandler.HandleError(err, "retrieving issue activities"), nil
+	}
+
+	return mcp.NewToolResultText(formatFieldHistory(issueID, field, youtrack.FieldHistory(activities, field))), nil
+}
+
+// formatFieldHistory lists the changes of a field, one per line: "date author: from → to"
+func formatFieldHistory(issueID, field string, changes []*youtrack.FieldChange) string {
+	if len(changes) == 0 {
+		return fmt.Sprintf("No changes of field '%s' found in the history of %s. Check the field name with get_project_info.\n", field, issueID)
+	}
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("📜 History of %s on %s (%d changes, oldest first):\n", changes[0].Field, issueID, len(changes)))
+	for _, change := range changes {
+		author := "unknown"
+		if change.Author != nil {
+			author = change.Author.Login
+		}
+		from, to := "(none)", "(none)"
+		if len(change.From) > 0 {
+			from = truncateActivityValue(strings.Join(change.From, ", "))
+		}
+		if len(change.To) > 0 {
+			to = truncateActivityValue(strings.Join(change.To, ", "))
+		}
+		sb.WriteString(fmt.Sprintf("- %s %s: %s → %s\n", change.Timestamp.Format("2006-01-02 15:04"), author, from, to))
+	}
+	return sb.String()
+}
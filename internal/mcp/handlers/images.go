@@ -0,0 +1,156 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"path"
+	"regexp"
+	"strings"
+
+	"github.com/mkozhukh/youtrack/internal/mcp/filestore"
+	"github.com/mkozhukh/youtrack/internal/mcp/tools"
+	"github.com/mkozhukh/youtrack/pkg/youtrack"
+
+	"github.com/charmbracelet/log"
+)
+
+// markdownImagePattern matches markdown images, ![alt](target "title"), with an optional
+// YouTrack size suffix such as {width=70%}
+var markdownImagePattern = regexp.MustCompile(`!\[([^\]]*)\]\(\s*<?([^)\s>]+)>?(?:\s+"[^"]*")?\s*\)(?:\{[^}]*\})?`)
+
+// imageRef is a markdown image found in an issue text
+type imageRef struct {
+	target string
+	source string
+}
+
+// SetImageSources enables the resolution of images embedded in descriptions and comments by
+// get_issue_details. Attachments are linked on youtrackURL, or copied to the file store and
+// linked on fileBaseURL when store is set.
+func (h *IssueHandlers) SetImageSources(youtrackURL string, store *filestore.Store, fileBaseURL string) {
+	h.youtrackURL = strings.TrimRight(youtrackURL, "/")
+	h.fileStore = store
+	h.fileBaseURL = fileBaseURL
+}
+
+// findImageRefs returns the markdown images of the description and comments, in order
+func findImageRefs(description string, comments []*youtrack.IssueComment) []imageRef {
+	var refs []imageRef
+	add := func(text, source string) {
+		for _, match := range markdownImagePattern.FindAllStringSubmatch(text, -1) {
+			refs = append(refs, imageRef{target: match[2], source: source})
+		}
+	}
+	add(description, "description")
+	for _, comment := range comments {
+		add(comment.Text, "comment "+comment.ID)
+	}
+	return refs
+}
+
+// embeddedImages resolves the images embedded in the description and comments: an image
+// naming an attachment gets a URL the client can open, external images keep theirs.
+// Resolution is best effort, unresolved images are left out.
+func (h *IssueHandlers) embeddedImages(ctx context.Context, issueID, description string, comments []*youtrack.IssueComment) []tools.ImageOutput {
+	refs := findImageRefs(description, comments)
+	if len(refs) == 0 {
+		return nil
+	}
+
+	var attachments []*youtrack.Attachment
+	for _, ref := range refs {
+		if !isExternalURL(ref.target) {
+			var err error
+			if attachments, err = h.ytClient.GetIssueAttachments(ctx, issueID); err != nil {
+				log.Warn("Failed to retrieve attachments for embedded images", "issue_id", issueID, "error", err)
+			}
+			break
+		}
+	}
+
+	var images []tools.ImageOutput
+	stored := make(map[string]string)
+	for _, ref := range refs {
+		if isExternalURL(ref.target) {
+			images = append(images, tools.ImageOutput{Name: path.Base(ref.target), Source: ref.source, URL: ref.target})
+			continue
+		}
+
+		att := findAttachment(attachments, ref.target)
+		if att == nil {
+			continue
+		}
+		imageURL, ok := stored[att.ID]
+		if !ok {
+			imageURL = h.imageURL(ctx, att)
+			stored[att.ID] = imageURL
+		}
+		images = append(images, tools.ImageOutput{
+			Name:         att.Name,
+			Source:       ref.source,
+			URL:          imageURL,
+			AttachmentID: att.ID,
+			MimeType:     att.MimeType,
+		})
+	}
+	return images
+}
+
+// imageURL returns the URL of an attachment: a file store copy when the file server is
+// enabled, else the signed YouTrack URL
+func (h *IssueHandlers) imageURL(ctx context.Context, att *youtrack.Attachment) string {
+	youtrackURL := att.URL
+	if !isExternalURL(youtrackURL) {
+		youtrackURL = h.youtrackURL + youtrackURL
+	}
+	if h.fileStore == nil {
+		return youtrackURL
+	}
+
+	data, err := h.ytClient.DownloadByURL(ctx, att.URL)
+	if err == nil {
+		var fileID string
+		if fileID, err = h.fileStore.Put(h.ytClient.GetKeyHash(ctx), data, att.Name); err == nil {
+			return fmt.Sprintf("%s/mcpfiles/%s", h.fileBaseURL, fileID)
+		}
+	}
+	log.Warn("Failed to store embedded image, linking YouTrack instead", "attachment", att.Name, "error", err)
+	return youtrackURL
+}
+
+// formatEmbeddedImages lists the resolved images for the text response
+func formatEmbeddedImages(images []tools.ImageOutput) string {
+	if len(images) == 0 {
+		return ""
+	}
+	response := fmt.Sprintf("\n🖼️  Embedded images (%d):\n", len(images))
+	for _, image := range images {
+		response += fmt.Sprintf("   %s (%s): %s\n", image.Name, image.Source, image.URL)
+	}
+	return response
+}
+
+// findAttachment finds the attachment an image target names, by file name
+func findAttachment(attachments []*youtrack.Attachment, target string) *youtrack.Attachment {
+	name := path.Base(target)
+	if unescaped, err := url.PathUnescape(name); err == nil {
+		name = unescaped
+	}
+	for _, att := range attachments {
+		if att.Name == name {
+			return att
+		}
+	}
+	for _, att := range attachments {
+		if strings.EqualFold(att.Name, name) {
+			return att
+		}
+	}
+	return nil
+}
+
+// isExternalURL reports whether an image target is an absolute URL
+func isExternalURL(target string) bool {
+	return strings.HasPrefix(target, "http://") || strings.HasPrefix(target, "https://")
+}
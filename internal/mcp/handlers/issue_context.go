@@ -0,0 +1,296 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/mkozhukh/youtrack/pkg/youtrack"
+
+	"github.com/charmbracelet/log"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+const (
+	defaultContextComments   = 5
+	defaultContextActivities = 10
+)
+
+// issueContext holds everything fetched for get_issue_context
+type issueContext struct {
+	issue        *youtrack.Issue
+	comments     []*youtrack.IssueComment
+	customFields []*youtrack.CustomFieldValue
+	links        []*youtrack.IssueLink
+	activities   []*youtrack.ActivityItem
+	missing      []string // parts that could not be fetched
+}
+
+// GetIssueContextHandler handles the get_issue_context tool call.
+// It fetches the issue, its comments, custom fields, links and activities concurrently
+// and renders them as one markdown document.
+func (h *IssueHandlers) GetIssueContextHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	issueID, err := request.RequireString("issue_id")
+	if err != nil {
+		return h.errorHandler.FormatValidationError("issue_id", err), nil
+	}
+
+	args := request.GetArguments()
+	maxComments := defaultContextComments
+	if v, ok := args["comments"].(float64); ok && v >= 0 {
+		maxComments = int(v)
+	}
+	maxActivities := defaultContextActivities
+	if v, ok := args["activities"].(float64); ok && v >= 0 {
+		maxActivities = int(v)
+	}
+
+	if h.toolLogger != nil {
+		h.toolLogger("get_issue_context", map[string]interface{}{
+			"issue_id":   issueID,
+			"comments":   maxComments,
+			"activities": maxActivities,
+		})
+	}
+
+	issue, err := h.ytClient.GetIssue(ctx, issueID)
+	if err != nil {
+		return h.errorHandler.HandleError(err, "retrieving issue"), nil
+	}
+	issue.Description = issue.MarkdownDescription()
+
+	ic := h.fetchIssueContext(ctx, issueID, maxComments > 0, maxActivities > 0)
+	ic.issue = issue
+
+	return mcp.NewToolResultText(formatIssueContext(ic, maxComments, maxActivities)), nil
+}
+
+// fetchIssueContext fetches the secondary parts of the issue context in parallel.
+// Failures are logged and recorded as missing, so the rest of the document is still returned.
+func (h *IssueHandlers) fetchIssueContext(ctx context.Context, issueID string, withComments, withActivities bool) *issueContext {
+	ic := &issueContext{}
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+
+	fetch := func(part string, fn func() error) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := fn(); err != nil {
+				log.Warn("Failed to retrieve issue context part", "issue_id", issueID, "part", part, "error", err)
+				mu.Lock()
+				ic.missing = append(ic.missing, part)
+				mu.Unlock()
+			}
+		}()
+	}
+
+	fetch("custom fields", func() (err error) {
+		ic.customFields, err = h.ytClient.GetIssueCustomFields(ctx, issueID)
+		return err
+	})
+	fetch("links", func() (err error) {
+		ic.links, err = h.ytClient.GetIssueLinks(ctx, issueID)
+		return err
+	})
+	if withComments {
+		fetch("comments", func() (err error) {
+			ic.comments, err = h.ytClient.GetIssueComments(ctx, issueID)
+			return err
+		})
+	}
+	if withActivities {
+		fetch("activities", func() (err error) {
+			ic.activities, err = h.ytClient.GetIssueActivities(ctx, issueID)
+			return err
+		})
+	}
+
+	wg.Wait()
+	return ic
+}
+
+// formatIssueContext renders the issue context as a compact markdown document
+func formatIssueContext(ic *issueContext, maxComments, maxActivities int) string {
+	issue := ic.issue
+	var sb strings.Builder
+
+	sb.WriteString(fmt.Sprintf("# %s: %s\n\n", issue.ID, issue.Summary))
+
+	meta := []string{}
+	if issue.State != "" {
+		meta = append(meta, "**State:** "+issue.State)
+	}
+	assignee := "Unassigned"
+	if issue.Assignee != nil {
+		assignee = issue.Assignee.Login
+	}
+	meta = append(meta, "**Assignee:** "+assignee)
+	if issue.Reporter != nil {
+		meta = append(meta, "**Reporter:** "+issue.Reporter.Login)
+	}
+	meta = append(meta, "**Created:** "+issue.Created.Format("2006-01-02"), "**Updated:** "+issue.Updated.Format("2006-01-02"))
+	if issue.Resolved != nil {
+		meta = append(meta, "**Resolved:** "+issue.Resolved.Format("2006-01-02"))
+	}
+	sb.WriteString(strings.Join(meta, " | ") + "\n")
+
+	if len(issue.Tags) > 0 {
+		tags := make([]string, 0, len(issue.Tags))
+		for _, tag := range issue.Tags {
+			tags = append(tags, tag.Name)
+		}
+		sb.WriteString("**Tags:** " + strings.Join(tags, ", ") + "\n")
+	}
+	if issue.Visibility.IsLimited() {
+		sb.WriteString(fmt.Sprintf("**Visible to:** %s\n", issue.Visibility))
+	}
+
+	// Only fields with a value, the empty ones are noise in a summary
+	var fields []string
+	for _, field := range ic.customFields {
+		if value := fieldValue(field); value != "" {
+			fields = append(fields, fmt.Sprintf("%s: %s", field.Name, value))
+		}
+	}
+	if len(fields) > 0 {
+		sb.WriteString("**Fields:** " + strings.Join(fields, "; ") + "\n")
+	}
+
+	if strings.TrimSpace(issue.Description) != "" {
+		sb.WriteString("\n## Description\n\n")
+		sb.WriteString(strings.TrimSpace(issue.Description) + "\n")
+	}
+
+	if len(ic.links) > 0 {
+		var lines []string
+		for _, link := range ic.links {
+			typeName := "Link"
+			if link.LinkType != nil {
+				typeName = link.LinkType.Name
+			}
+			for _, linked := range link.Issues {
+				lines = append(lines, fmt.Sprintf("- %s (%s): %s %s", typeName, strings.ToLower(link.Direction), linked.ID, linked.Summary))
+			}
+		}
+		if len(lines) > 0 {
+			sb.WriteString("\n## Links\n\n")
+			sb.WriteString(strings.Join(lines, "\n") + "\n")
+		}
+	}
+
+	if maxActivities > 0 && len(ic.activities) > 0 {
+		activities := ic.activities
+		if len(activities) > maxActivities {
+			activities = activities[len(activities)-maxActivities:]
+		}
+		sb.WriteString(fmt.Sprintf("\n## Recent activity (%d of %d)\n\n", len(activities), len(ic.activities)))
+		for _, activity := range activities {
+			sb.WriteString("- " + formatActivityLine(activity) + "\n")
+		}
+	}
+
+	if maxComments > 0 && len(ic.comments) > 0 {
+		comments := ic.comments
+		if len(comments) > maxComments {
+			comments = comments[len(comments)-maxComments:]
+		}
+		sb.WriteString(fmt.Sprintf("\n## Comments (last %d of %d)\n", len(comments), len(ic.comments)))
+
+		// Replies name the author of the comment they answer, which may be older than the last ones
+		authors := make(map[string]string, len(ic.comments))
+		for _, comment := range ic.comments {
+			authors[comment.ID] = "unknown"
+			if comment.Author != nil {
+				authors[comment.ID] = comment.Author.Login
+			}
+		}
+		for _, comment := range comments {
+			header := fmt.Sprintf("**%s**, %s", authors[comment.ID], comment.Created.Format("2006-01-02 15:04"))
+			if comment.ReplyTo != nil {
+				if author, ok := authors[comment.ReplyTo.ID]; ok {
+					header += fmt.Sprintf(", replying to **%s**", author)
+				}
+			}
+			sb.WriteString(fmt.Sprintf("\n%s:\n%s\n", header, strings.TrimSpace(comment.Text)))
+		}
+	}
+
+	if len(ic.missing) > 0 {
+		sb.WriteString(fmt.Sprintf("\n_Could not retrieve: %s_\n", strings.Join(ic.missing, ", ")))
+	}
+
+	return sb.String()
+}
+
+// formatActivityLine renders an activity as "date author: change"
+func formatActivityLine(activity *youtrack.ActivityItem) string {
+	author := "unknown"
+	if activity.Author != nil {
+		author = activity.Author.Login
+	}
+	line := fmt.Sprintf("%s %s: ", activity.Timestamp.Format("2006-01-02 15:04"), author)
+
+	switch activity.Category.ID {
+	case "IssueCreatedCategory":
+		return line + "created the issue"
+	case "CommentCategory":
+		return line + "commented"
+	case "AttachmentsCategory", "AttachmentCategory":
+		return line + "changed attachments"
+	case "WorkItemCategory":
+		return line + "logged work"
+	}
+
+	name := activity.Category.ID
+	if activity.Field != nil {
+		name = activity.Field.Name
+		if name == "" {
+			name = activity.Field.ID
+		}
+	}
+
+	removed := activityValues(activity.Removed, activity.RemovedValues)
+	added := activityValues(activity.Added, activity.AddedValues)
+	switch {
+	case removed != "" && added != "":
+		return line + fmt.Sprintf("%s %s → %s", name, removed, added)
+	case added != "":
+		return line + fmt.Sprintf("%s + %s", name, added)
+	case removed != "":
+		return line + fmt.Sprintf("%s − %s", name, removed)
+	default:
+		return line + fmt.Sprintf("changed %s", name)
+	}
+}
+
+// activityValues joins the display values of an activity's added or removed values
+func activityValues(single *youtrack.FieldValue, values []*youtrack.FieldValue) string {
+	if single != nil {
+		values = append([]*youtrack.FieldValue{single}, values...)
+	}
+	parts := make([]string, 0, len(values))
+	for _, v := range values {
+		text := v.Name
+		for _, candidate := range []string{v.Text, v.FullName, v.Login, v.Markdown} {
+			if text != "" {
+				break
+			}
+			text = candidate
+		}
+		if text != "" {
+			parts = append(parts, truncateActivityValue(text))
+		}
+	}
+	return strings.Join(parts, ", ")
+}
+
+// truncateActivityValue shortens long values such as descriptions to one line
+func truncateActivityValue(text string) string {
+	text = strings.Join(strings.Fields(text), " ")
+	if len([]rune(text)) > 80 {
+		return string([]rune(text)[:77]) + "..."
+	}
+	return text
+}
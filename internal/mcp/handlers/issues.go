@@ -6,7 +6,9 @@ import (
 	"strings"
 	"time"
 
-	"github.com/mkozhukh/youtrack/internal/mcp/resolver"
+	"github.com/mkozhukh/youtrack/internal/mcp/filestore"
+	"github.com/mkozhukh/youtrack/internal/mcp/tools"
+	"github.com/mkozhukh/youtrack/internal/resolver"
 	"github.com/mkozhukh/youtrack/pkg/youtrack"
 
 	"github.com/charmbracelet/log"
@@ -16,33 +18,62 @@ import (
 // IssueHandlers contains all the handlers for issue-related tools
 type IssueHandlers struct {
 	ytClient       YouTrackClientInterface
+	fieldClient    FieldClient
 	resolver       *resolver.Resolver
 	toolLogger     func(string, map[string]interface{})
 	errorHandler   *ErrorHandler
 	projectTracker ProjectTracker
+
+	// Embedded image resolution, see SetImageSources
+	youtrackURL string
+	fileStore   *filestore.Store
+	fileBaseURL string
+
+	// Duplicate check of create_issue, see SetDuplicateCheck
+	duplicateCheck    bool
+	duplicateMinScore float64
+	duplicateWindow   time.Duration
 }
 
 // YouTrackClientInterface defines the interface for YouTrack client operations
 type YouTrackClientInterface interface {
 	SearchIssues(ctx context.Context, query string, skip, top int) ([]*youtrack.Issue, error)
 	SearchIssuesSorted(ctx context.Context, query string, skip, top int, sortBy, sortOrder string) ([]*youtrack.Issue, error)
+	SearchIssuesProjected(ctx context.Context, query string, skip, top int, sortBy, sortOrder string, projection *youtrack.IssueProjection) ([]*youtrack.Issue, error)
 	GetIssue(ctx context.Context, issueID string) (*youtrack.Issue, error)
+	GetIssueProjected(ctx context.Context, issueID string, projection *youtrack.IssueProjection) (*youtrack.Issue, error)
 	GetIssueComments(ctx context.Context, issueID string) ([]*youtrack.IssueComment, error)
 	GetIssueCustomFields(ctx context.Context, issueID string) ([]*youtrack.CustomFieldValue, error)
+	GetIssueLinks(ctx context.Context, issueID string) ([]*youtrack.IssueLink, error)
+	GetIssueActivities(ctx context.Context, issueID string) ([]*youtrack.ActivityItem, error)
 	CreateIssue(ctx context.Context, req *youtrack.CreateIssueRequest) (*youtrack.Issue, error)
+	CreateIssueTree(ctx context.Context, parent *youtrack.CreateIssueRequest, subtasks []*youtrack.CreateIssueRequest) (*youtrack.IssueTree, error)
 	UpdateIssue(ctx context.Context, issueID string, req *youtrack.UpdateIssueRequest) (*youtrack.Issue, error)
 	UpdateIssueAssigneeByProject(ctx context.Context, issueID string, projectID string, username string) (*youtrack.Issue, error)
+	NewCustomFieldPatch(ctx context.Context, projectID string) (*youtrack.CustomFieldPatch, error)
+	ResolveVisibility(ctx context.Context, groupNames, userLogins []string) (*youtrack.Visibility, error)
 	DeleteIssue(ctx context.Context, issueID string) error
-	// Resolver support
-	GetProjectUsers(ctx context.Context, projectID string, skip, top int) ([]*youtrack.User, error)
-	GetCustomFieldAllowedValues(ctx context.Context, projectID string, fieldName string) ([]youtrack.AllowedValue, error)
+	GetIssueAttachments(ctx context.Context, issueID string) ([]*youtrack.Attachment, error)
+	DownloadByURL(ctx context.Context, rawURL string) ([]byte, error)
+	FindSimilarIssues(ctx context.Context, text string, opts youtrack.SimilarIssuesOptions) ([]*youtrack.SimilarIssue, error)
+	ExecuteBatch(ctx context.Context, ops []youtrack.BatchOperation, opts youtrack.BatchOptions) *youtrack.BatchReport
+	GetKeyHash(ctx context.Context) string
+}
+
+// FieldClient resolves field values and builds the custom field patches of updates
+type FieldClient interface {
+	resolver.ResolverClient
+	NewCustomFieldPatch(ctx context.Context, projectID string) (*youtrack.CustomFieldPatch, error)
 }
 
 // NewIssueHandlers creates a new instance of IssueHandlers
-func NewIssueHandlers(ytClient YouTrackClientInterface, toolLogger func(string, map[string]interface{}), projectTracker ProjectTracker) *IssueHandlers {
+// fieldClient backs user and enum value resolution and the field types of updates; pass a
+// cached client to avoid repeated lookups
+func NewIssueHandlers(ytClient YouTrackClientInterface, fieldClient FieldClient, toolLogger func(string, map[string]interface{}), projectTracker ProjectTracker) *IssueHandlers {
 	return &IssueHandlers{
 		ytClient:       ytClient,
-		resolver:       resolver.NewResolver(ytClient),
+		fieldClient:    fieldClient,
+		resolver:       resolver.NewResolver(fieldClient),
 		toolLogger:     toolLogger,
 		errorHandler:   NewErrorHandler(),
 		projectTracker: projectTracker,
@@ -63,6 +94,11 @@ func (h *IssueHandlers) GetIssueListHandler(ctx context.Context, request mcp.Cal
 	sortBy, _ := args["sort_by"].(string)
 	sortOrder, _ := args["sort_order"].(string)
 
+	projection, _, err := issueProjection(request)
+	if err != nil {
+		return h.errorHandler.FormatValidationError("fields", err), nil
+	}
+
 	// Convert max_results to int and validate
 	maxResultsInt := int(maxResults)
 	if maxResults > 0 {
@@ -94,12 +130,16 @@ func (h *IssueHandlers) GetIssueListHandler(ctx context.Context, request mcp.Cal
 			"max_results":     maxResultsInt,
 			"sort_by":         sortBy,
 			"sort_order":      sortOrder,
+			"fields":          request.GetStringSlice("fields", nil),
 		})
 	}
 
-	// Search for issues — use sorted search if sort_by is provided
+	// Search for issues — use sorted search if sort_by is provided, and fetch only the
+	// requested fields if any
 	var issues []*youtrack.Issue
-	if hasSortParam {
+	if projection != nil {
+		issues, err = h.ytClient.SearchIssuesProjected(ctx, optimizedQuery, 0, maxResultsInt, sortBy, sortOrder, projection)
+	} else if hasSortParam {
 		issues, err = h.ytClient.SearchIssuesSorted(ctx, optimizedQuery, 0, maxResultsInt, sortBy, sortOrder)
 	} else {
 		issues, err = h.ytClient.SearchIssues(ctx, optimizedQuery, 0, maxResultsInt)
@@ -109,8 +149,18 @@ func (h *IssueHandlers) GetIssueListHandler(ctx context.Context, request mcp.Cal
 	}
 
 	// Format the response
+	output := tools.IssueListOutput{Issues: make([]tools.IssueOutput, 0, len(issues)), Total: len(issues)}
+	if projection != nil {
+		for _, issue := range issues {
+			output.Issues = append(output.Issues, projectedIssueOutput(issue, projection))
+		}
+		return mcp.NewToolResultStructured(output, h.formatProjectedIssueList(issues, projection)), nil
+	}
+	for _, issue := range issues {
+		output.Issues = append(output.Issues, issueOutput(issue))
+	}
 	response := h.formatIssueList(issues)
-	return mcp.NewToolResultText(response), nil
+	return mcp.NewToolResultStructured(output, response), nil
 }
 
 // GetIssueDetailsHandler handles the get_issue_details tool call
@@ -121,19 +171,36 @@ func (h *IssueHandlers) GetIssueDetailsHandler(ctx context.Context, request mcp.
 		return h.errorHandler.FormatValidationError("issue_id", err), nil
 	}
 
+	projection, extras, err := issueProjection(request)
+	if err != nil {
+		return h.errorHandler.FormatValidationError("fields", err), nil
+	}
+	rawDescription := request.GetBool("raw_description", false)
+
 	// Log the tool call
 	if h.toolLogger != nil {
 		h.toolLogger("get_issue_details", map[string]interface{}{
-			"issue_id": issueID,
+			"issue_id":        issueID,
+			"fields":          request.GetStringSlice("fields", nil),
+			"raw_description": rawDescription,
 		})
 	}
 
+	if projection != nil {
+		return h.getProjectedIssueDetails(ctx, issueID, projection, extras, rawDescription)
+	}
+
 	// Get the issue details
 	issue, err := h.ytClient.GetIssue(ctx, issueID)
 	if err != nil {
 		return h.errorHandler.HandleError(err, "retrieving issue details"), nil
 	}
 
+	// Legacy HTML and wiki descriptions are returned as Markdown
+	if !rawDescription {
+		issue.Description = issue.MarkdownDescription()
+	}
+
 	// Get the issue comments
 	comments, err := h.ytClient.GetIssueComments(ctx, issueID)
 	if err != nil {
@@ -146,9 +213,47 @@ func (h *IssueHandlers) GetIssueDetailsHandler(ctx context.Context, request mcp.
 		log.Warn("Failed to retrieve custom fields", "issue_id", issueID, "error", err)
 	}
 
+	// Resolve the images embedded in the description and comments
+	images := h.embeddedImages(ctx, issueID, issue.Description, comments)
+
 	// Format the response
-	response := h.formatIssueDetails(issue, comments, customFields)
-	return mcp.NewToolResultText(response), nil
+	response := h.formatIssueDetails(issue, comments, customFields) + formatEmbeddedImages(images)
+	output := issueDetailsOutput(issue, comments, customFields)
+	output.Images = images
+	return mcp.NewToolResultStructured(output, response), nil
+}
+
+// getProjectedIssueDetails returns the requested fields of an issue. Comments and embedded images
+// are only fetched when selected.
+func (h *IssueHandlers) getProjectedIssueDetails(ctx context.Context, issueID string, projection *youtrack.IssueProjection, extras map[string]bool, rawDescription bool) (*mcp.CallToolResult, error) {
+	issue, err := h.ytClient.GetIssueProjected(ctx, issueID, projection)
+	if err != nil {
+		return h.errorHandler.HandleError(err, "retrieving issue details"), nil
+	}
+	if !rawDescription {
+		issue.Description = issue.MarkdownDescription()
+	}
+
+	var comments []*youtrack.IssueComment
+	if extras["comments"] || extras["images"] {
+		if comments, err = h.ytClient.GetIssueComments(ctx, issueID); err != nil {
+			return h.errorHandler.HandleError(err, "retrieving issue comments"), nil
+		}
+	}
+
+	var images []tools.ImageOutput
+	if extras["images"] {
+		// Images of the description are only found when it was fetched
+		images = h.embeddedImages(ctx, issueID, issue.Description, comments)
+	}
+
+	if !extras["comments"] {
+		comments = nil
+	}
+	response := h.formatProjectedIssueDetails(issue, projection, comments, extras["comments"]) + formatEmbeddedImages(images)
+	output := projectedIssueDetailsOutput(issue, projection, comments)
+	output.Images = images
+	return mcp.NewToolResultStructured(output, response), nil
 }
 
 // CreateIssueHandler handles the create_issue tool call
@@ -171,6 +276,13 @@ func (h *IssueHandlers) CreateIssueHandler(ctx context.Context, request mcp.Call
 
 	args := request.GetArguments()
 	description, _ := args["description"].(string)
+	visibility, _ := args["visibility"].(string)
+	force := request.GetBool("force", false)
+	if visibility != "" {
+		if err := validateVisibility(visibility); err != nil {
+			return h.errorHandler.FormatValidationError("visibility", err), nil
+		}
+	}
 
 	// Track project usage
 	if h.projectTracker != nil {
@@ -183,9 +295,21 @@ func (h *IssueHandlers) CreateIssueHandler(ctx context.Context, request mcp.Call
 			"project_id":  projectID,
 			"summary":     summary,
 			"description": description,
+			"visibility":  visibility,
+			"force":       force,
 		})
 	}
 
+	// Refuse likely duplicates unless forced; the check is skipped when the search fails
+	if h.duplicateCheck && !force {
+		duplicates, err := h.findDuplicates(ctx, projectID, summary)
+		if err != nil {
+			log.Warn("Duplicate check failed, creating the issue", "project_id", projectID, "error", err)
+		} else if len(duplicates) > 0 {
+			return mcp.NewToolResultError(formatDuplicateWarning(summary, duplicates)), nil
+		}
+	}
+
 	// Create the issue request
 	createReq := &youtrack.CreateIssueRequest{
 		Project:     youtrack.ProjectRef{ID: projectID},
@@ -193,6 +317,13 @@ func (h *IssueHandlers) CreateIssueHandler(ctx context.Context, request mcp.Call
 		Description: description,
 	}
 
+	if visibility != "" {
+		createReq.Visibility, err = h.resolveVisibility(ctx, visibility)
+		if err != nil {
+			return h.errorHandler.HandleError(err, "resolving visibility"), nil
+		}
+	}
+
 	// Create the issue
 	issue, err := h.ytClient.CreateIssue(ctx, createReq)
 	if err != nil {
@@ -217,6 +348,12 @@ func (h *IssueHandlers) UpdateIssueHandler(ctx context.Context, request mcp.Call
 	assignee, _ := args["assignee"].(string)
 	summary, _ := args["summary"].(string)
 	description, _ := args["description"].(string)
+	visibility, _ := args["visibility"].(string)
+	if visibility != "" {
+		if err := validateVisibility(visibility); err != nil {
+			return h.errorHandler.FormatValidationError("visibility", err), nil
+		}
+	}
 
 	// Log the tool call
 	if h.toolLogger != nil {
@@ -226,20 +363,20 @@ func (h *IssueHandlers) UpdateIssueHandler(ctx context.Context, request mcp.Call
 			"assignee":    assignee,
 			"summary":     summary,
 			"description": description,
+			"visibility":  visibility,
 		})
 	}
 
-	// Extract project ID from the issue ID (assuming format like PROJECT-123)
-	projectID := extractProjectFromIssueID(issueID)
-	if projectID == "" {
-		return mcp.NewToolResultError("Could not extract project ID from issue ID. Issue ID should be in format PROJECT-123"), nil
-	}
-
-	// Start with getting the current issue
+	// Start with getting the current issue, its readable ID names the project even when
+	// issue_id is a database ID
 	currentIssue, err := h.ytClient.GetIssue(ctx, issueID)
 	if err != nil {
 		return h.errorHandler.HandleError(err, "retrieving current issue"), nil
 	}
+	projectID := extractProjectFromIssueID(currentIssue.ID)
+	if projectID == "" {
+		return mcp.NewToolResultError("Could not find the project of issue " + issueID), nil
+	}
 
 	// Create the update request
 	updateReq := &youtrack.UpdateIssueRequest{}
@@ -255,21 +392,41 @@ func (h *IssueHandlers) UpdateIssueHandler(ctx context.Context, request mcp.Call
 		hasUpdates = true
 	}
 
+	if visibility != "" {
+		updateReq.Visibility, err = h.resolveVisibility(ctx, visibility)
+		if err != nil {
+			return h.errorHandler.HandleError(err, "resolving visibility"), nil
+		}
+		hasUpdates = true
+	}
+
+	// Corrections made by fuzzy matching, reported back to the caller
+	var notes []string
+
 	// Resolve and validate state if provided
 	if state != "" {
-		resolvedState, err := h.resolver.ResolveEnumValue(ctx, projectID, "State", state)
+		resolvedState, err := h.resolver.ResolveEnumMatch(ctx, projectID, "State", state)
 		if err != nil {
 			if resolveErr, ok := err.(*resolver.ResolveError); ok {
 				return mcp.NewToolResultError(resolveErr.Error()), nil
 			}
 			return h.errorHandler.HandleError(err, "resolving state value"), nil
 		}
+		if note := resolvedState.Note(); note != "" {
+			notes = append(notes, "State: "+note)
+		}
 
-		updateReq.Fields = append(updateReq.Fields, youtrack.CustomField{
-			Name:  "State",
-			Type:  "StateIssueCustomField",
-			Value: youtrack.SingleValue{Value: resolvedState},
-		})
+		// Build the field with the type the project uses for State
+		patch, err := h.fieldClient.NewCustomFieldPatch(ctx, projectID)
+		if err != nil {
+			return h.errorHandler.HandleError(err, "retrieving project fields"), nil
+		}
+		fields, err := patch.Set("State", resolvedState.Value).Fields()
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
+		updateReq.Fields = append(updateReq.Fields, fields...)
 		hasUpdates = true
 	}
 
@@ -286,15 +443,18 @@ func (h *IssueHandlers) UpdateIssueHandler(ctx context.Context, request mcp.Call
 	// Handle assignee update separately if provided
 	if assignee != "" {
 		// Resolve assignee using smart matching
-		resolvedAssignee, err := h.resolver.ResolveUser(ctx, projectID, assignee)
+		resolvedAssignee, err := h.resolver.ResolveUserMatch(ctx, projectID, assignee)
 		if err != nil {
 			if resolveErr, ok := err.(*resolver.ResolveError); ok {
 				return mcp.NewToolResultError(resolveErr.Error()), nil
 			}
 			return h.errorHandler.HandleError(err, "resolving assignee"), nil
 		}
+		if note := resolvedAssignee.Note(); note != "" {
+			notes = append(notes, "Assignee: "+note)
+		}
 
-		updatedIssue, err = h.ytClient.UpdateIssueAssigneeByProject(ctx, issueID, projectID, resolvedAssignee)
+		updatedIssue, err = h.ytClient.UpdateIssueAssigneeByProject(ctx, issueID, projectID, resolvedAssignee.Value)
 		if err != nil {
 			return h.errorHandler.HandleError(err, "updating issue assignee"), nil
 		}
@@ -307,9 +467,30 @@ func (h *IssueHandlers) UpdateIssueHandler(ctx context.Context, request mcp.Call
 
 	// Format the response
 	response := h.formatUpdatedIssue(updatedIssue)
+	for _, note := range notes {
+		response += fmt.Sprintf("⚠️ %s\n", note)
+	}
 	return mcp.NewToolResultText(response), nil
 }
 
+// resolveVisibility converts a visibility spec ("Developers, user:john" or "public") to a Visibility
+func (h *IssueHandlers) resolveVisibility(ctx context.Context, spec string) (*youtrack.Visibility, error) {
+	groups, users, public := youtrack.ParseVisibility(spec)
+	if public {
+		return youtrack.NewUnlimitedVisibility(), nil
+	}
+	return h.ytClient.ResolveVisibility(ctx, groups, users)
+}
+
+// validateVisibility rejects a visibility spec that names no group or user, e.g. " , "
+func validateVisibility(spec string) error {
+	groups, users, public := youtrack.ParseVisibility(spec)
+	if !public && len(groups) == 0 && len(users) == 0 {
+		return fmt.Errorf("expected 'public' or group names and user:login entries, got '%s'", spec)
+	}
+	return nil
+}
+
 // Helper functions for query optimization and formatting
 
 // buildOptimizedQuery creates an optimized query with smart defaults
@@ -429,6 +610,14 @@ func (h *IssueHandlers) formatIssueDetails(issue *youtrack.Issue, comments []*yo
 		response += fmt.Sprintf("✅ Resolved: %s\n", issue.Resolved.Format("2006-01-02 15:04:05"))
 	}
 
+	if issue.Visibility.IsLimited() {
+		response += fmt.Sprintf("🔒 Visible to: %s\n", issue.Visibility)
+	}
+
+	if issue.Votes > 0 {
+		response += fmt.Sprintf("👍 Votes: %d\n", issue.Votes)
+	}
+
 	if len(issue.Tags) > 0 {
 		response += "🏷️  Tags: "
 		for i, tag := range issue.Tags {
@@ -443,21 +632,18 @@ func (h *IssueHandlers) formatIssueDetails(issue *youtrack.Issue, comments []*yo
 	if len(customFields) > 0 {
 		response += "\n📌 Custom Fields:\n"
 		for _, field := range customFields {
-			response += fmt.Sprintf("   %s: %v\n", field.Name, formatFieldValue(field.Value))
+			value := fieldValue(field)
+			if value == "" {
+				value = "<empty>"
+			}
+			response += fmt.Sprintf("   %s: %s\n", field.Name, value)
 		}
 	}
 
 	if len(comments) > 0 {
 		response += fmt.Sprintf("\n💬 Comments (%d):\n", len(comments))
 		response += "━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━\n"
-		for i, comment := range comments {
-			author := "Unknown"
-			if comment.Author != nil {
-				author = comment.Author.Login
-			}
-			response += fmt.Sprintf("%d. 👤 %s (%s)\n", i+1, author, comment.Created.Format("2006-01-02 15:04:05"))
-			response += fmt.Sprintf("   📝 %s\n\n", comment.Text)
-		}
+		response += formatCommentThreads(comments)
 	}
 
 	// Add footer with metadata
@@ -467,6 +653,36 @@ func (h *IssueHandlers) formatIssueDetails(issue *youtrack.Issue, comments []*yo
 	return response + footer
 }
 
+// formatCommentThreads formats comments by thread, replies indented under the comment they answer,
+// each with its reaction counts
+func formatCommentThreads(comments []*youtrack.IssueComment) string {
+	var sb strings.Builder
+	i := 0
+	youtrack.WalkCommentThreads(youtrack.BuildCommentThreads(comments), func(comment *youtrack.IssueComment, depth int) {
+		i++
+		author := "Unknown"
+		if comment.Author != nil {
+			author = comment.Author.Login
+		}
+		indent := strings.Repeat("   ", depth)
+		marker := ""
+		if depth > 0 {
+			marker = "↳ "
+		}
+		sb.WriteString(fmt.Sprintf("%s%s%d. 👤 %s (%s) [%s]\n", indent, marker, i, author, comment.Created.Format("2006-01-02 15:04:05"), comment.ID))
+		sb.WriteString(fmt.Sprintf("%s   📝 %s\n", indent, comment.Text))
+		if counts := youtrack.CountReactions(comment.Reactions); len(counts) > 0 {
+			parts := make([]string, 0, len(counts))
+			for _, c := range counts {
+				parts = append(parts, fmt.Sprintf("%s x%d", c.Reaction, c.Count))
+			}
+			sb.WriteString(fmt.Sprintf("%s   🙌 Reactions: %s\n", indent, strings.Join(parts, ", ")))
+		}
+		sb.WriteString("\n")
+	})
+	return sb.String()
+}
+
 func (h *IssueHandlers) formatCreatedIssue(issue *youtrack.Issue) string {
 	details := fmt.Sprintf("Issue ID: %s\n", issue.ID)
 	details += fmt.Sprintf("Summary: %s\n", issue.Summary)
@@ -516,38 +732,40 @@ func (h *IssueHandlers) DeleteIssueHandler(ctx context.Context, request mcp.Call
 	return mcp.NewToolResultText(fmt.Sprintf("Issue %s deleted successfully.", issueID)), nil
 }
 
-// formatFieldValue converts a custom field value to a display string
-func formatFieldValue(value interface{}) string {
-	if value == nil {
-		return "<empty>"
-	}
-	switch v := value.(type) {
+// fieldValue returns the display value of a custom field, with the login of users next to
+// their name, e.g. "John Smith (john)", since the login is what update_issue takes back
+func fieldValue(field *youtrack.CustomFieldValue) string {
+	switch v := field.Value.(type) {
 	case map[string]interface{}:
-		if name, ok := v["name"].(string); ok {
-			return name
-		}
-		if login, ok := v["login"].(string); ok {
-			return login
+		if user, ok := userValue(v); ok {
+			return user
 		}
-		return fmt.Sprintf("%v", v)
 	case []interface{}:
-		var names []string
+		values := make([]string, 0, len(v))
 		for _, item := range v {
-			if m, ok := item.(map[string]interface{}); ok {
-				if name, ok := m["name"].(string); ok {
-					names = append(names, name)
-					continue
-				}
-			}
-			names = append(names, fmt.Sprintf("%v", item))
+			values = append(values, fieldValue(&youtrack.CustomFieldValue{Value: item}))
 		}
-		return strings.Join(names, ", ")
-	default:
-		return fmt.Sprintf("%v", v)
+		return strings.Join(values, ", ")
+	}
+	return field.String()
+}
+
+// userValue formats a user value as "Full Name (login)", or the login alone when the
+// name is missing; false for values that are not users
+func userValue(v map[string]interface{}) (string, bool) {
+	login, _ := v["login"].(string)
+	if login == "" {
+		return "", false
+	}
+	if name, _ := v["fullName"].(string); name != "" && name != login {
+		return fmt.Sprintf("%s (%s)", name, login), true
 	}
+	return login, true
 }
 
-// extractProjectFromIssueID extracts the project ID from an issue ID
+// extractProjectFromIssueID extracts the project short name from a readable issue ID such as
+// PROJECT-123. A database ID ("2-123") has no project prefix, pass the idReadable of the
+// fetched issue instead.
 // Assumes format like "PROJECT-123" -> "PROJECT"
 func extractProjectFromIssueID(issueID string) string {
 	for i, char := range issueID {
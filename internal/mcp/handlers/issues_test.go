@@ -0,0 +1,108 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"github.com/mkozhukh/youtrack/pkg/youtrack"
+)
+
+// fakeIssueClient serves one issue, known by its readable and its database ID, and records
+// the projects the handlers look up; methods it does not implement panic
+type fakeIssueClient struct {
+	YouTrackClientInterface
+	issue    *youtrack.Issue
+	projects []string
+}
+
+func (c *fakeIssueClient) GetIssue(ctx context.Context, issueID string) (*youtrack.Issue, error) {
+	if issueID != c.issue.ID && issueID != c.issue.InternalID {
+		return nil, &youtrack.APIError{StatusCode: 404, Message: "Not Found"}
+	}
+	return c.issue, nil
+}
+
+func (c *fakeIssueClient) UpdateIssue(ctx context.Context, issueID string, req *youtrack.UpdateIssueRequest) (*youtrack.Issue, error) {
+	return c.GetIssue(ctx, issueID)
+}
+
+func (c *fakeIssueClient) UpdateIssueAssigneeByProject(ctx context.Context, issueID, projectID, username string) (*youtrack.Issue, error) {
+	c.projects = append(c.projects, projectID)
+	return c.GetIssue(ctx, issueID)
+}
+
+func (c *fakeIssueClient) GetProjectUsers(ctx context.Context, projectID string, skip, top int) ([]*youtrack.User, error) {
+	c.projects = append(c.projects, projectID)
+	if projectID != "PRJ" || skip > 0 {
+		return nil, nil
+	}
+	return []*youtrack.User{{ID: "1-1", Login: "alice", FullName: "Alice Smith"}}, nil
+}
+
+func (c *fakeIssueClient) GetCustomFieldAllowedValues(ctx context.Context, projectID, fieldName string) ([]youtrack.AllowedValue, error) {
+	c.projects = append(c.projects, projectID)
+	if projectID != "PRJ" {
+		return nil, fmt.Errorf("project %s not found", projectID)
+	}
+	return []youtrack.AllowedValue{{ID: "s-1", Name: "Open"}, {ID: "s-2", Name: "Fixed"}}, nil
+}
+
+func (c *fakeIssueClient) NewCustomFieldPatch(ctx context.Context, projectID string) (*youtrack.CustomFieldPatch, error) {
+	c.projects = append(c.projects, projectID)
+	return youtrack.NewCustomFieldPatch([]*youtrack.ProjectCustomField{
+		{Type: "StateProjectCustomField", Field: &youtrack.CustomFieldDefinition{Name: "State", FieldType: &youtrack.FieldType{ID: "state[1]"}}},
+	}), nil
+}
+
+func TestUpdateIssueHandler_DatabaseID(t *testing.T) {
+	client := &fakeIssueClient{issue: &youtrack.Issue{ID: "PRJ-123", InternalID: "2-123", Summary: "Fix login"}}
+	h := NewIssueHandlers(client, client, nil, nil)
+
+	request := mcp.CallToolRequest{}
+	request.Params.Name = "update_issue"
+	request.Params.Arguments = map[string]any{"issue_id": "2-123", "state": "fixed", "assignee": "alice"}
+
+	result, err := h.UpdateIssueHandler(context.Background(), request)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if result.IsError {
+		t.Fatalf("Expected success, got %+v", result.Content)
+	}
+	if len(client.projects) == 0 {
+		t.Fatal("Expected the state and assignee to be resolved in the project")
+	}
+	for _, projectID := range client.projects {
+		if projectID != "PRJ" {
+			t.Errorf("Expected lookups in PRJ, got %v", client.projects)
+			break
+		}
+	}
+}
+
+func TestPrepareBatchOperation_DatabaseID(t *testing.T) {
+	client := &fakeIssueClient{issue: &youtrack.Issue{ID: "PRJ-123", InternalID: "2-123", Summary: "Fix login"}}
+	h := NewIssueHandlers(client, client, nil, nil)
+
+	op, _, err := h.prepareBatchOperation(context.Background(), map[string]interface{}{
+		"type": "update", "issue_id": "2-123", "state": "fixed",
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if op.IssueID != "2-123" || op.Update == nil || len(op.Update.Fields) != 1 {
+		t.Errorf("Expected the state update of 2-123, got %+v", op)
+	}
+	if len(client.projects) == 0 {
+		t.Fatal("Expected the state to be resolved in the project")
+	}
+	for _, projectID := range client.projects {
+		if projectID != "PRJ" {
+			t.Errorf("Expected lookups in PRJ, got %v", client.projects)
+			break
+		}
+	}
+}
@@ -21,6 +21,7 @@ type LinkHandlers struct {
 type LinkClient interface {
 	GetIssueLinks(ctx context.Context, issueID string) ([]*youtrack.IssueLink, error)
 	CreateIssueLink(ctx context.Context, sourceID, targetID, linkType string) error
+	GetIssueVcsChanges(ctx context.Context, issueID string) ([]*youtrack.VcsChange, error)
 }
 
 // NewLinkHandlers creates a new instance of LinkHandlers
@@ -72,6 +73,44 @@ func (h *LinkHandlers) GetIssueLinksHandler(ctx context.Context, request mcp.Cal
 	return mcp.NewToolResultText(sb.String()), nil
 }
 
+// GetIssueCommitsHandler handles the get_issue_commits tool call
+func (h *LinkHandlers) GetIssueCommitsHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	issueID, err := request.RequireString("issue_id")
+	if err != nil {
+		return h.errorHandler.FormatValidationError("issue_id", err), nil
+	}
+
+	if h.toolLogger != nil {
+		h.toolLogger("get_issue_commits", map[string]interface{}{
+			"issue_id": issueID,
+		})
+	}
+
+	changes, err := h.ytClient.GetIssueVcsChanges(ctx, issueID)
+	if err != nil {
+		return h.errorHandler.HandleError(err, "retrieving issue commits"), nil
+	}
+
+	if len(changes) == 0 {
+		return mcp.NewToolResultText(fmt.Sprintf("No commits found for issue %s.", issueID)), nil
+	}
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("Commits for %s:\n\n", issueID))
+
+	for _, change := range changes {
+		sb.WriteString(fmt.Sprintf("- %s by %s on %s\n", change.ShortVersion(), change.AuthorName(), change.Date.Format("2006-01-02 15:04")))
+		if message := strings.TrimSpace(change.Text); message != "" {
+			sb.WriteString(fmt.Sprintf("  %s\n", strings.ReplaceAll(message, "\n", "\n  ")))
+		}
+		for _, url := range change.URLs {
+			sb.WriteString(fmt.Sprintf("  %s\n", url))
+		}
+	}
+
+	return mcp.NewToolResultText(sb.String()), nil
+}
+
 // CreateIssueLinkHandler handles the create_issue_link tool call
 func (h *LinkHandlers) CreateIssueLinkHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 	sourceID, err := request.RequireString("source_issue_id")
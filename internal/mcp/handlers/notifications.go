@@ -0,0 +1,115 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/mkozhukh/youtrack/pkg/youtrack"
+
+	"github.com/charmbracelet/log"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// defaultNotificationsLimit is the number of notifications get_notifications looks at by default
+const defaultNotificationsLimit = 20
+
+// NotificationHandlers manages notification MCP operations
+type NotificationHandlers struct {
+	ytClient     NotificationClient
+	toolLogger   func(string, map[string]interface{})
+	errorHandler *ErrorHandler
+}
+
+// NotificationClient defines the interface for YouTrack client operations needed for notifications
+type NotificationClient interface {
+	GetNotifications(ctx context.Context, skip, top int, unreadOnly bool) ([]*youtrack.Notification, error)
+	CountUnreadNotifications(ctx context.Context) (int, error)
+	MarkNotificationRead(ctx context.Context, notificationID string, read bool) error
+}
+
+// NewNotificationHandlers creates a new instance of NotificationHandlers
+func NewNotificationHandlers(ytClient NotificationClient, toolLogger func(string, map[string]interface{})) *NotificationHandlers {
+	return &NotificationHandlers{
+		ytClient:     ytClient,
+		toolLogger:   toolLogger,
+		errorHandler: NewErrorHandler(),
+	}
+}
+
+// GetNotificationsHandler handles the get_notifications tool call
+func (h *NotificationHandlers) GetNotificationsHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	unreadOnly := request.GetBool("unread_only", true)
+	markRead := request.GetBool("mark_read", false)
+	limit := defaultNotificationsLimit
+	if maxArg, ok := request.GetArguments()["max_results"].(float64); ok && maxArg > 0 {
+		limit = int(maxArg)
+	}
+
+	if h.toolLogger != nil {
+		h.toolLogger("get_notifications", map[string]interface{}{
+			"unread_only": unreadOnly,
+			"max_results": limit,
+			"mark_read":   markRead,
+		})
+	}
+
+	notifications, err := h.ytClient.GetNotifications(ctx, 0, limit, unreadOnly)
+	if err != nil {
+		return h.errorHandler.HandleError(err, "retrieving notifications"), nil
+	}
+
+	var sb strings.Builder
+	// The unread count is informative only (non-critical)
+	if unread, err := h.ytClient.CountUnreadNotifications(ctx); err == nil {
+		sb.WriteString(fmt.Sprintf("Unread notifications: %d\n", unread))
+	}
+	sb.WriteString(formatNotifications(notifications))
+
+	if markRead {
+		marked := 0
+		for _, n := range notifications {
+			if n.Read {
+				continue
+			}
+			if err := h.ytClient.MarkNotificationRead(ctx, n.ID, true); err != nil {
+				log.Warn("Failed to mark notification read", "notification_id", n.ID, "error", err)
+				continue
+			}
+			marked++
+		}
+		sb.WriteString(fmt.Sprintf("\nMarked %d notifications read\n", marked))
+	}
+
+	return mcp.NewToolResultText(sb.String()), nil
+}
+
+// formatNotifications formats notifications, one line per notification with its reasons and changes
+func formatNotifications(notifications []*youtrack.Notification) string {
+	if len(notifications) == 0 {
+		return "No notifications found\n"
+	}
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("\nNotifications (%d):\n", len(notifications)))
+	for _, n := range notifications {
+		status := ""
+		if !n.Read {
+			status = " (unread)"
+		}
+		issue := n.IssueID
+		if issue == "" {
+			issue = "unknown issue"
+		} else if n.IssueSummary != "" {
+			issue += ": " + n.IssueSummary
+		}
+		sb.WriteString(fmt.Sprintf("- [%s] %s%s\n", n.Updated.Format("2006-01-02 15:04"), issue, status))
+		if len(n.Reasons) > 0 {
+			sb.WriteString(fmt.Sprintf("  Reason: %s\n", strings.Join(n.Reasons, ", ")))
+		}
+		if len(n.Changes) > 0 {
+			sb.WriteString(fmt.Sprintf("  Changed: %s\n", strings.Join(n.Changes, ", ")))
+		}
+	}
+	return sb.String()
+}
@@ -0,0 +1,70 @@
+package handlers
+
+import (
+	"time"
+
+	"github.com/mkozhukh/youtrack/internal/mcp/tools"
+	"github.com/mkozhukh/youtrack/pkg/youtrack"
+)
+
+// issueOutput converts an issue to its structured result
+func issueOutput(issue *youtrack.Issue) tools.IssueOutput {
+	output := tools.IssueOutput{
+		ID:         issue.ID,
+		InternalID: issue.InternalID,
+		Summary:    issue.Summary,
+		State:      issue.State,
+		Votes:      issue.Votes,
+		Created:    issue.Created.Format(time.RFC3339),
+		Updated:    issue.Updated.Format(time.RFC3339),
+	}
+	if issue.Assignee != nil {
+		output.Assignee = issue.Assignee.Login
+	}
+	if issue.Reporter != nil {
+		output.Reporter = issue.Reporter.Login
+	}
+	if issue.Resolved != nil {
+		output.Resolved = issue.Resolved.Format(time.RFC3339)
+	}
+	for _, tag := range issue.Tags {
+		output.Tags = append(output.Tags, tag.Name)
+	}
+	return output
+}
+
+// issueDetailsOutput converts an issue with its comments and custom fields to the structured result
+func issueDetailsOutput(issue *youtrack.Issue, comments []*youtrack.IssueComment, customFields []*youtrack.CustomFieldValue) tools.IssueDetailsOutput {
+	output := tools.IssueDetailsOutput{
+		IssueOutput: issueOutput(issue),
+		Description: issue.Description,
+		Comments:    make([]tools.CommentOutput, 0, len(comments)),
+	}
+	if issue.Visibility.IsLimited() {
+		output.VisibleTo = issue.Visibility.String()
+	}
+	for _, field := range customFields {
+		output.CustomFields = append(output.CustomFields, tools.FieldValueOutput{Name: field.Name, Value: fieldValue(field)})
+	}
+	for _, comment := range comments {
+		c := tools.CommentOutput{ID: comment.ID, Created: comment.Created.Format(time.RFC3339), Text: comment.Text}
+		if comment.Author != nil {
+			c.Author = comment.Author.Login
+		}
+		if comment.ReplyTo != nil {
+			c.ReplyTo = comment.ReplyTo.ID
+		}
+		for _, r := range comment.Reactions {
+			reaction := tools.ReactionOutput{Reaction: r.Reaction}
+			if r.Author != nil {
+				reaction.Author = r.Author.Login
+			}
+			c.Reactions = append(c.Reactions, reaction)
+		}
+		for _, count := range youtrack.CountReactions(comment.Reactions) {
+			c.ReactionCounts = append(c.ReactionCounts, tools.ReactionCountOutput{Reaction: count.Reaction, Count: count.Count})
+		}
+		output.Comments = append(output.Comments, c)
+	}
+	return output
+}
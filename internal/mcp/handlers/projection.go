@@ -0,0 +1,225 @@
+package handlers
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"github.com/mkozhukh/youtrack/internal/mcp/tools"
+	"github.com/mkozhukh/youtrack/pkg/youtrack"
+)
+
+// detailsOnlyFields are the fields argument values of get_issue_details that are not issue
+// attributes: they select the parts of the response fetched separately
+var detailsOnlyFields = []string{"comments", "images"}
+
+// issueProjection reads the fields argument of an issue tool. It returns a nil projection when
+// no fields are given, and the details-only fields that were selected.
+func issueProjection(request mcp.CallToolRequest) (*youtrack.IssueProjection, map[string]bool, error) {
+	paths := request.GetStringSlice("fields", nil)
+	if len(paths) == 0 {
+		return nil, nil, nil
+	}
+
+	extras := make(map[string]bool)
+	var attributes []string
+	for _, path := range paths {
+		if name := strings.ToLower(strings.TrimSpace(path)); isDetailsOnlyField(name) {
+			extras[name] = true
+			continue
+		}
+		attributes = append(attributes, path)
+	}
+
+	projection, err := youtrack.NewIssueProjection(attributes)
+	if err != nil {
+		return nil, nil, err
+	}
+	return projection, extras, nil
+}
+
+func isDetailsOnlyField(name string) bool {
+	for _, field := range detailsOnlyFields {
+		if field == name {
+			return true
+		}
+	}
+	return false
+}
+
+// projectedIssueOutput converts an issue to its structured result, keeping only the
+// attributes of the projection
+func projectedIssueOutput(issue *youtrack.Issue, projection *youtrack.IssueProjection) tools.IssueOutput {
+	full := issueOutput(issue)
+	output := tools.IssueOutput{ID: full.ID}
+	if projection.Includes("internal_id") {
+		output.InternalID = full.InternalID
+	}
+	if projection.Includes("summary") {
+		output.Summary = full.Summary
+	}
+	if projection.Includes("state") {
+		output.State = full.State
+	}
+	if projection.Includes("assignee.login") {
+		output.Assignee = full.Assignee
+	}
+	if projection.Includes("assignee.full_name") && issue.Assignee != nil {
+		output.AssigneeName = issue.Assignee.FullName
+	}
+	if projection.Includes("reporter.login") {
+		output.Reporter = full.Reporter
+	}
+	if projection.Includes("reporter.full_name") && issue.Reporter != nil {
+		output.ReporterName = issue.Reporter.FullName
+	}
+	if projection.Includes("created") {
+		output.Created = full.Created
+	}
+	if projection.Includes("updated") {
+		output.Updated = full.Updated
+	}
+	if projection.Includes("resolved") {
+		output.Resolved = full.Resolved
+	}
+	if projection.Includes("tags") {
+		output.Tags = full.Tags
+	}
+	if projection.Includes("votes") {
+		output.Votes = full.Votes
+	}
+	for _, field := range projectedCustomFields(issue, projection) {
+		output.CustomFields = append(output.CustomFields, tools.FieldValueOutput{Name: field.Name, Value: fieldValue(field)})
+	}
+	return output
+}
+
+// projectedCustomFields returns the custom fields of an issue the projection selects by name
+func projectedCustomFields(issue *youtrack.Issue, projection *youtrack.IssueProjection) []*youtrack.CustomFieldValue {
+	if !projection.Includes("custom_fields") {
+		return nil
+	}
+	var fields []*youtrack.CustomFieldValue
+	for _, field := range issue.CustomFields {
+		if projection.IncludesCustomField(field.Name) {
+			fields = append(fields, field)
+		}
+	}
+	return fields
+}
+
+// formatProjectedIssue renders the attributes of the projection, one per line
+func formatProjectedIssue(issue *youtrack.Issue, projection *youtrack.IssueProjection, indent string) string {
+	output := projectedIssueOutput(issue, projection)
+	var response string
+	line := func(label, value string) {
+		response += fmt.Sprintf("%s%s: %s\n", indent, label, value)
+	}
+
+	if output.InternalID != "" {
+		line("🆔 Internal ID", output.InternalID)
+	}
+	if projection.Includes("summary") {
+		line("📝 Summary", output.Summary)
+	}
+	if projection.Includes("description") {
+		line("📄 Description", issue.Description)
+	}
+	if projection.Includes("state") {
+		line("🚦 State", valueOr(output.State, "<empty>"))
+	}
+	if projection.Includes("assignee") {
+		line("👤 Assignee", formatProjectedUser(output.Assignee, output.AssigneeName, projection.Includes("assignee.login"), "Unassigned"))
+	}
+	if projection.Includes("reporter") {
+		line("📩 Reporter", formatProjectedUser(output.Reporter, output.ReporterName, projection.Includes("reporter.login"), "Unknown"))
+	}
+	if projection.Includes("created") {
+		line("📅 Created", issue.Created.Format("2006-01-02 15:04:05"))
+	}
+	if projection.Includes("updated") {
+		line("🔄 Updated", issue.Updated.Format("2006-01-02 15:04:05"))
+	}
+	if projection.Includes("resolved") && issue.Resolved != nil {
+		line("✅ Resolved", issue.Resolved.Format("2006-01-02 15:04:05"))
+	}
+	if projection.Includes("visibility") && issue.Visibility.IsLimited() {
+		line("🔒 Visible to", issue.Visibility.String())
+	}
+	if projection.Includes("votes") {
+		line("👍 Votes", fmt.Sprintf("%d", output.Votes))
+	}
+	if projection.Includes("tags") && len(output.Tags) > 0 {
+		line("🏷️  Tags", strings.Join(output.Tags, ", "))
+	}
+	for _, field := range output.CustomFields {
+		line("📌 "+field.Name, valueOr(field.Value, "<empty>"))
+	}
+	return response
+}
+
+// formatProjectedUser renders a user of a projected issue with the selected attributes
+func formatProjectedUser(login, fullName string, withLogin bool, none string) string {
+	switch {
+	case login == "" && fullName == "":
+		return none
+	case withLogin && fullName != "":
+		return fmt.Sprintf("%s (%s)", fullName, login)
+	case withLogin:
+		return login
+	default:
+		return fullName
+	}
+}
+
+// valueOr returns value, or fallback when value is empty
+func valueOr(value, fallback string) string {
+	if value == "" {
+		return fallback
+	}
+	return value
+}
+
+// formatProjectedIssueList renders issues with the attributes of the projection
+func (h *IssueHandlers) formatProjectedIssueList(issues []*youtrack.Issue, projection *youtrack.IssueProjection) string {
+	if len(issues) == 0 {
+		return h.formatEmptyResult("No issues found", "Try adjusting your query or search criteria")
+	}
+
+	response := fmt.Sprintf("📋 Issues Found: %d (fields: %s)\n\n", len(issues), strings.Join(projection.Paths(), ", "))
+	for i, issue := range issues {
+		response += fmt.Sprintf("%d. 🎫 %s\n", i+1, issue.ID)
+		response += formatProjectedIssue(issue, projection, "   ")
+		response += "\n"
+	}
+	return response
+}
+
+// formatProjectedIssueDetails renders an issue with the attributes of the projection, and its
+// comments when selected
+func (h *IssueHandlers) formatProjectedIssueDetails(issue *youtrack.Issue, projection *youtrack.IssueProjection, comments []*youtrack.IssueComment, withComments bool) string {
+	response := fmt.Sprintf("🎫 Issue Details: %s\n", issue.ID)
+	response += formatProjectedIssue(issue, projection, "")
+
+	if withComments {
+		response += fmt.Sprintf("\n💬 Comments (%d):\n", len(comments))
+		response += formatCommentThreads(comments)
+	}
+	return response
+}
+
+// projectedIssueDetailsOutput converts an issue to the structured result of get_issue_details,
+// keeping only the attributes of the projection
+func projectedIssueDetailsOutput(issue *youtrack.Issue, projection *youtrack.IssueProjection, comments []*youtrack.IssueComment) tools.IssueDetailsOutput {
+	// The comments and reactions are converted as in the full result
+	output := issueDetailsOutput(issue, comments, nil)
+	output.IssueOutput = projectedIssueOutput(issue, projection)
+	if !projection.Includes("description") {
+		output.Description = ""
+	}
+	if !projection.Includes("visibility") {
+		output.VisibleTo = ""
+	}
+	return output
+}
@@ -2,9 +2,12 @@ package handlers
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strings"
 
+	"github.com/mkozhukh/youtrack/internal/mcp/tools"
+	"github.com/mkozhukh/youtrack/internal/resolver"
 	"github.com/mkozhukh/youtrack/pkg/youtrack"
 
 	"github.com/mark3labs/mcp-go/mcp"
@@ -26,6 +29,8 @@ type ProjectClient interface {
 	GetProjectCustomFields(ctx context.Context, projectID string) ([]*youtrack.CustomField, error)
 	GetCustomFieldAllowedValues(ctx context.Context, projectID string, fieldName string) ([]youtrack.AllowedValue, error)
 	GetAvailableLinkTypes(ctx context.Context) ([]*youtrack.LinkType, error)
+	ListAllProjects(ctx context.Context) ([]*youtrack.Project, error)
+	GetProjectStats(ctx context.Context, projectID string, opts youtrack.ProjectStatsOptions) (*youtrack.ProjectStats, error)
 }
 
 // NewProjectHandlers creates a new instance of ProjectHandlers
@@ -69,6 +74,12 @@ func (h *ProjectHandlers) GetProjectInfoHandler(ctx context.Context, request mcp
 	}
 
 	// Build response
+	output := tools.ProjectInfoOutput{
+		ShortName:    project.ShortName,
+		Name:         project.Name,
+		Description:  project.Description,
+		CustomFields: make([]tools.ProjectFieldOutput, 0, len(fields)),
+	}
 	var sb strings.Builder
 	sb.WriteString(fmt.Sprintf("Project: %s (%s)\n", project.Name, project.ShortName))
 	if project.Description != "" {
@@ -78,6 +89,7 @@ func (h *ProjectHandlers) GetProjectInfoHandler(ctx context.Context, request mcp
 
 	for _, field := range fields {
 		sb.WriteString(fmt.Sprintf("- %s (type: %s)\n", field.Name, field.Type))
+		fieldOutput := tools.ProjectFieldOutput{Name: field.Name, Type: field.Type}
 
 		// Try to get allowed values for this field
 		values, err := h.ytClient.GetCustomFieldAllowedValues(ctx, projectID, field.Name)
@@ -87,7 +99,9 @@ func (h *ProjectHandlers) GetProjectInfoHandler(ctx context.Context, request mcp
 				valueNames = append(valueNames, v.Name)
 			}
 			sb.WriteString(fmt.Sprintf("  Allowed values: %s\n", strings.Join(valueNames, ", ")))
+			fieldOutput.AllowedValues = valueNames
 		}
+		output.CustomFields = append(output.CustomFields, fieldOutput)
 	}
 
 	// Get link types
@@ -96,10 +110,45 @@ func (h *ProjectHandlers) GetProjectInfoHandler(ctx context.Context, request mcp
 		sb.WriteString("\n## Link Types\n\n")
 		for _, lt := range linkTypes {
 			sb.WriteString(fmt.Sprintf("- %s\n", lt.Name))
+			output.LinkTypes = append(output.LinkTypes, lt.Name)
 		}
 	}
 
-	return mcp.NewToolResultText(sb.String()), nil
+	return mcp.NewToolResultStructured(output, sb.String()), nil
+}
+
+// SetDefaultProjectHandler handles the set_default_project tool call
+func (h *ProjectHandlers) SetDefaultProjectHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	query, err := request.RequireString("project")
+	if err != nil {
+		return h.errorHandler.FormatValidationError("project", err), nil
+	}
+
+	if h.toolLogger != nil {
+		h.toolLogger("set_default_project", map[string]interface{}{
+			"project": query,
+		})
+	}
+
+	if h.projectTracker == nil {
+		return mcp.NewToolResultError("Project tracking is not available"), nil
+	}
+
+	project, err := resolver.ResolveProject(ctx, h.ytClient, query)
+	if err != nil {
+		var resolveErr *resolver.ResolveError
+		if errors.As(err, &resolveErr) {
+			return mcp.NewToolResultError(resolveErr.Error()), nil
+		}
+		return h.errorHandler.HandleError(err, "resolving project"), nil
+	}
+
+	h.projectTracker.PinProject(ctx, project.ShortName)
+	h.projectTracker.TrackProject(ctx, project.ShortName)
+
+	response := fmt.Sprintf("Default project for this session: %s\n", resolver.FormatProjectForDisplay(project))
+	response += "Tools that need a project use it when project_id is omitted."
+	return mcp.NewToolResultText(response), nil
 }
 
 // ListProjectsHandler handles the list_projects tool call
@@ -148,3 +197,66 @@ func (h *ProjectHandlers) ListProjectsHandler(ctx context.Context, request mcp.C
 
 	return mcp.NewToolResultText(sb.String()), nil
 }
+
+// GetProjectStatsHandler handles the get_project_stats tool call
+func (h *ProjectHandlers) GetProjectStatsHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	projectID, err := request.RequireString("project_id")
+	if err != nil {
+		return h.errorHandler.FormatValidationError("project_id", err), nil
+	}
+
+	args := request.GetArguments()
+	opts := youtrack.ProjectStatsOptions{}
+	if v, ok := args["days"].(float64); ok {
+		opts.Days = int(v)
+	}
+	if v, ok := args["top_assignees"].(float64); ok {
+		opts.TopAssignees = int(v)
+	}
+
+	if h.toolLogger != nil {
+		h.toolLogger("get_project_stats", map[string]interface{}{
+			"project_id":    projectID,
+			"days":          opts.Days,
+			"top_assignees": opts.TopAssignees,
+		})
+	}
+
+	if h.projectTracker != nil {
+		h.projectTracker.TrackProject(ctx, projectID)
+	}
+
+	stats, err := h.ytClient.GetProjectStats(ctx, projectID, opts)
+	if err != nil {
+		return h.errorHandler.HandleError(err, "computing project statistics"), nil
+	}
+
+	return mcp.NewToolResultText(formatProjectStats(stats)), nil
+}
+
+// formatProjectStats renders project statistics as a compact markdown summary
+func formatProjectStats(stats *youtrack.ProjectStats) string {
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("# %s statistics\n\n", stats.Project))
+	sb.WriteString(fmt.Sprintf("- Open: %d\n", stats.Open))
+	sb.WriteString(fmt.Sprintf("- Resolved: %d\n", stats.Resolved))
+	sb.WriteString(fmt.Sprintf("- Last %d days: %d created, %d resolved\n", stats.Days, stats.Created, stats.ResolvedRecently))
+
+	writeCounts := func(title string, counts []youtrack.ValueCount) {
+		if len(counts) == 0 {
+			return
+		}
+		sb.WriteString(fmt.Sprintf("\n## %s\n\n", title))
+		for _, c := range counts {
+			sb.WriteString(fmt.Sprintf("- %s: %d\n", c.Value, c.Count))
+		}
+	}
+	writeCounts("By state", stats.ByState)
+	writeCounts("Open by priority", stats.ByPriority)
+	writeCounts("Top assignees (open issues)", stats.TopAssignees)
+
+	if len(stats.Missing) > 0 {
+		sb.WriteString(fmt.Sprintf("\n_Not available: %s_\n", strings.Join(stats.Missing, ", ")))
+	}
+	return sb.String()
+}
@@ -0,0 +1,142 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/mkozhukh/youtrack/internal/resolver"
+	"github.com/mkozhukh/youtrack/pkg/youtrack"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// PullRequestHandlers manages pull request related MCP operations
+type PullRequestHandlers struct {
+	ytClient     PullRequestClient
+	resolver     *resolver.Resolver
+	reviewState  string
+	reviewStates map[string]string
+	toolLogger   func(string, map[string]interface{})
+	errorHandler *ErrorHandler
+}
+
+// PullRequestClient defines the interface for YouTrack client operations needed for linking pull requests
+type PullRequestClient interface {
+	AddIssueComment(ctx context.Context, issueID string, comment string) (*youtrack.IssueComment, error)
+	UpdateIssue(ctx context.Context, issueID string, req *youtrack.UpdateIssueRequest) (*youtrack.Issue, error)
+	NewCustomFieldPatch(ctx context.Context, projectID string) (*youtrack.CustomFieldPatch, error)
+	GetIssue(ctx context.Context, issueID string) (*youtrack.Issue, error)
+}
+
+// NewPullRequestHandlers creates a new instance of PullRequestHandlers.
+// reviewState is the state set on linked issues, reviewStates overrides it per project short name.
+func NewPullRequestHandlers(ytClient PullRequestClient, resolverClient resolver.ResolverClient, reviewState string, reviewStates map[string]string, toolLogger func(string, map[string]interface{})) *PullRequestHandlers {
+	return &PullRequestHandlers{
+		ytClient:     ytClient,
+		resolver:     resolver.NewResolver(resolverClient),
+		reviewState:  reviewState,
+		reviewStates: reviewStates,
+		toolLogger:   toolLogger,
+		errorHandler: NewErrorHandler(),
+	}
+}
+
+// LinkPullRequestHandler handles the link_pull_request tool call
+func (h *PullRequestHandlers) LinkPullRequestHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	issueID, err := request.RequireString("issue_id")
+	if err != nil {
+		return h.errorHandler.FormatValidationError("issue_id", err), nil
+	}
+
+	prURL, err := request.RequireString("pr_url")
+	if err != nil {
+		return h.errorHandler.FormatValidationError("pr_url", err), nil
+	}
+
+	args := request.GetArguments()
+	state, _ := args["state"].(string)
+
+	if h.toolLogger != nil {
+		h.toolLogger("link_pull_request", map[string]interface{}{
+			"issue_id": issueID,
+			"pr_url":   prURL,
+			"state":    state,
+		})
+	}
+
+	pr, err := youtrack.ParsePullRequestURL(prURL)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	// The project comes from the readable ID of the issue, issue_id may be a database ID
+	issue, err := h.ytClient.GetIssue(ctx, issueID)
+	if err != nil {
+		return h.errorHandler.HandleError(err, "finding issue"), nil
+	}
+	projectID := extractProjectFromIssueID(issue.ID)
+	if projectID == "" {
+		return mcp.NewToolResultError("Could not find the project of issue " + issueID), nil
+	}
+
+	if state == "" {
+		state = h.reviewStateFor(projectID)
+	}
+	if strings.EqualFold(state, "none") {
+		state = ""
+	}
+
+	// Resolve the state first, so an unknown state fails before the comment is posted
+	var stateFields []youtrack.CustomField
+	var note string
+	if state != "" {
+		resolvedState, err := h.resolver.ResolveEnumMatch(ctx, projectID, "State", state)
+		if err != nil {
+			if resolveErr, ok := err.(*resolver.ResolveError); ok {
+				return mcp.NewToolResultError(resolveErr.Error()), nil
+			}
+			return h.errorHandler.HandleError(err, "resolving state value"), nil
+		}
+		state = resolvedState.Value
+		note = resolvedState.Note()
+
+		patch, err := h.ytClient.NewCustomFieldPatch(ctx, projectID)
+		if err != nil {
+			return h.errorHandler.HandleError(err, "retrieving project fields"), nil
+		}
+		stateFields, err = patch.Set("State", state).Fields()
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+	}
+
+	if _, err := h.ytClient.AddIssueComment(ctx, issueID, pr.Comment()); err != nil {
+		return h.errorHandler.HandleError(err, "adding pull request comment"), nil
+	}
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("Linked %s to %s: %s\n", pr.Reference(), issueID, pr.URL))
+
+	if stateFields != nil {
+		if _, err := h.ytClient.UpdateIssue(ctx, issueID, &youtrack.UpdateIssueRequest{Fields: stateFields}); err != nil {
+			return h.errorHandler.HandleError(err, "updating issue state (the pull request comment was posted)"), nil
+		}
+		sb.WriteString(fmt.Sprintf("State: %s\n", state))
+		if note != "" {
+			sb.WriteString(fmt.Sprintf("⚠️ State: %s\n", note))
+		}
+	}
+
+	return mcp.NewToolResultText(sb.String()), nil
+}
+
+// reviewStateFor returns the configured review state for a project
+func (h *PullRequestHandlers) reviewStateFor(projectID string) string {
+	for project, state := range h.reviewStates {
+		if strings.EqualFold(project, projectID) {
+			return state
+		}
+	}
+	return h.reviewState
+}
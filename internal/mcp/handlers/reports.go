@@ -0,0 +1,180 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/mkozhukh/youtrack/pkg/youtrack"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// ReportHandlers manages report-related MCP operations
+type ReportHandlers struct {
+	ytClient     ReportClient
+	toolLogger   func(string, map[string]interface{})
+	errorHandler *ErrorHandler
+	slaPolicy    youtrack.SLAPolicy
+}
+
+// ReportClient defines the interface for YouTrack client operations needed for reports
+type ReportClient interface {
+	ForEachIssue(ctx context.Context, query string, pageSize int, fn func(issue *youtrack.Issue) error) error
+	GetIssueComments(ctx context.Context, issueID string) ([]*youtrack.IssueComment, error)
+}
+
+// NewReportHandlers creates a new instance of ReportHandlers
+// that check issues against slaPolicy in check_sla
+func NewReportHandlers(ytClient ReportClient, toolLogger func(string, map[string]interface{}), slaPolicy youtrack.SLAPolicy) *ReportHandlers {
+	return &ReportHandlers{
+		ytClient:     ytClient,
+		toolLogger:   toolLogger,
+		errorHandler: NewErrorHandler(),
+		slaPolicy:    slaPolicy,
+	}
+}
+
+// GenerateReleaseNotesHandler handles the generate_release_notes tool call
+func (h *ReportHandlers) GenerateReleaseNotesHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	projectID, err := request.RequireString("project_id")
+	if err != nil {
+		return h.errorHandler.FormatValidationError("project_id", err), nil
+	}
+
+	args := request.GetArguments()
+	query, _ := args["query"].(string)
+	groupBy, _ := args["group_by"].(string)
+	order, _ := args["order"].(string)
+	title, _ := args["title"].(string)
+
+	if query == "" {
+		query = "#Resolved"
+	}
+	if order == "" {
+		order = "Feature,Bug,Task"
+	}
+
+	if h.toolLogger != nil {
+		h.toolLogger("generate_release_notes", map[string]interface{}{
+			"project_id": projectID,
+			"query":      query,
+			"group_by":   groupBy,
+		})
+	}
+
+	searchQuery := fmt.Sprintf("project: %s %s", projectID, query)
+
+	var issues []*youtrack.Issue
+	err = h.ytClient.ForEachIssue(ctx, searchQuery, youtrack.DefaultPageSize, func(issue *youtrack.Issue) error {
+		issues = append(issues, issue)
+		return nil
+	})
+	if err != nil {
+		return h.errorHandler.HandleError(err, "searching issues"), nil
+	}
+
+	var groupOrder []string
+	for _, name := range strings.Split(order, ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			groupOrder = append(groupOrder, name)
+		}
+	}
+
+	notes := youtrack.BuildReleaseNotes(title, issues, groupBy, groupOrder)
+	return mcp.NewToolResultText(notes.Markdown()), nil
+}
+
+// CheckSLAHandler handles the check_sla tool call
+func (h *ReportHandlers) CheckSLAHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	projectID, err := request.RequireString("project_id")
+	if err != nil {
+		return h.errorHandler.FormatValidationError("project_id", err), nil
+	}
+
+	args := request.GetArguments()
+	query, _ := args["query"].(string)
+	breachedOnly := request.GetBool("breached_only", false)
+	limit := 50
+	if l, ok := args["limit"].(float64); ok && l > 0 {
+		limit = min(int(l), 200)
+	}
+
+	if query == "" {
+		query = "#Unresolved"
+	}
+
+	if h.toolLogger != nil {
+		h.toolLogger("check_sla", map[string]interface{}{
+			"project_id":    projectID,
+			"query":         query,
+			"breached_only": breachedOnly,
+			"limit":         limit,
+		})
+	}
+
+	if h.slaPolicy.IsEmpty() {
+		return mcp.NewToolResultError("No SLA policy is configured. Add an [sla] section to the server config."), nil
+	}
+
+	searchQuery := fmt.Sprintf("project: %s %s sort by: created asc", projectID, query)
+
+	var issues []*youtrack.Issue
+	err = h.ytClient.ForEachIssue(ctx, searchQuery, youtrack.DefaultPageSize, func(issue *youtrack.Issue) error {
+		issues = append(issues, issue)
+		if len(issues) == limit {
+			return youtrack.ErrStopIteration
+		}
+		return nil
+	})
+	if err != nil {
+		return h.errorHandler.HandleError(err, "searching issues"), nil
+	}
+
+	now := time.Now()
+	breached := 0
+	var results []*youtrack.SLAResult
+	for _, issue := range issues {
+		comments, err := h.ytClient.GetIssueComments(ctx, issue.ID)
+		if err != nil {
+			return h.errorHandler.HandleError(err, fmt.Sprintf("retrieving comments of %s", issue.ID)), nil
+		}
+		result := youtrack.EvaluateSLA(issue, comments, h.slaPolicy, now)
+		if result.Breached() {
+			breached++
+		} else if breachedOnly {
+			continue
+		}
+		results = append(results, result)
+	}
+
+	response := fmt.Sprintf("SLA check of %d issue(s) in %s matching '%s': %d breached.\n", len(issues), projectID, query, breached)
+	if len(issues) == limit {
+		response += fmt.Sprintf("Only the %d oldest issues were checked, narrow the query or raise the limit to check more.\n", limit)
+	}
+	for _, result := range results {
+		priority := result.Priority
+		if priority == "" {
+			priority = "no priority"
+		}
+		marker := ""
+		if result.Breached() {
+			marker = " (BREACHED)"
+		}
+		response += fmt.Sprintf("\n- %s [%s] %s%s\n", result.IssueID, priority, result.Summary, marker)
+		response += fmt.Sprintf("  - Created: %s\n", result.Created.Format("2006-01-02 15:04"))
+		response += fmt.Sprintf("  - First response: %s\n", formatSLACheck(result.FirstResponse))
+		response += fmt.Sprintf("  - Resolution: %s\n", formatSLACheck(result.Resolution))
+	}
+
+	return mcp.NewToolResultText(response), nil
+}
+
+// formatSLACheck describes an SLA outcome with the elapsed and target times
+func formatSLACheck(check youtrack.SLACheck) string {
+	if check.Status == youtrack.SLANoTarget {
+		return "no target"
+	}
+	return fmt.Sprintf("%s, %s of %s", check.Status, formatDuration(check.ElapsedMinutes), formatDuration(check.TargetMinutes))
+}
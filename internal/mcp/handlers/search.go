@@ -0,0 +1,227 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/mkozhukh/youtrack/internal/resolver"
+	"github.com/mkozhukh/youtrack/pkg/youtrack"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// SearchHandlers manages search-related MCP operations
+type SearchHandlers struct {
+	ytClient      SearchClient
+	projectLister resolver.ProjectLister
+	toolLogger    func(string, map[string]interface{})
+	errorHandler  *ErrorHandler
+}
+
+// SearchClient defines the interface for YouTrack client operations needed for search assistance
+type SearchClient interface {
+	GetSearchSuggestions(ctx context.Context, query string, caret int) (*youtrack.SearchAssist, error)
+	GetIssuesByIDs(ctx context.Context, ids []string) ([]*youtrack.Issue, error)
+	GetIssue(ctx context.Context, issueID string) (*youtrack.Issue, error)
+	FindSimilarIssues(ctx context.Context, text string, opts youtrack.SimilarIssuesOptions) ([]*youtrack.SimilarIssue, error)
+}
+
+// NewSearchHandlers creates a new instance of SearchHandlers
+// projectLister provides the known project prefixes; pass a cached client to avoid repeated lookups
+func NewSearchHandlers(ytClient SearchClient, projectLister resolver.ProjectLister, toolLogger func(string, map[string]interface{})) *SearchHandlers {
+	return &SearchHandlers{
+		ytClient:      ytClient,
+		projectLister: projectLister,
+		toolLogger:    toolLogger,
+		errorHandler:  NewErrorHandler(),
+	}
+}
+
+// SuggestQueryCompletionsHandler handles the suggest_query_completions tool call
+func (h *SearchHandlers) SuggestQueryCompletionsHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	args := request.GetArguments()
+	query, _ := args["query"].(string)
+	caret := utf8.RuneCountInString(query)
+	if caretArg, ok := args["caret"].(float64); ok && caretArg >= 0 {
+		caret = int(caretArg)
+	}
+	maxResults := 20
+	if maxArg, ok := args["max_results"].(float64); ok && maxArg > 0 {
+		maxResults = int(maxArg)
+	}
+
+	if h.toolLogger != nil {
+		h.toolLogger("suggest_query_completions", map[string]interface{}{
+			"query":       query,
+			"caret":       caret,
+			"max_results": maxResults,
+		})
+	}
+
+	assist, err := h.ytClient.GetSearchSuggestions(ctx, query, caret)
+	if err != nil {
+		return h.errorHandler.HandleError(err, "retrieving query suggestions"), nil
+	}
+
+	return mcp.NewToolResultText(formatSearchSuggestions(query, assist, maxResults)), nil
+}
+
+// formatSearchSuggestions formats search assist suggestions as a readable list
+func formatSearchSuggestions(query string, assist *youtrack.SearchAssist, maxResults int) string {
+	if assist == nil || len(assist.Suggestions) == 0 {
+		return fmt.Sprintf("No completions found for query '%s'.", query)
+	}
+
+	suggestions := assist.Suggestions
+	if len(suggestions) > maxResults {
+		suggestions = suggestions[:maxResults]
+	}
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("Completions for query '%s' (%d of %d):\n\n", query, len(suggestions), len(assist.Suggestions)))
+	for _, s := range suggestions {
+		sb.WriteString(fmt.Sprintf("- %s", s.Option))
+		if s.Description != "" {
+			sb.WriteString(fmt.Sprintf(" (%s)", s.Description))
+		}
+		sb.WriteString(fmt.Sprintf("\n  Query: %s\n", s.Apply(query)))
+	}
+
+	return sb.String()
+}
+
+// ExtractIssueIDsHandler handles the extract_issue_ids tool call
+func (h *SearchHandlers) ExtractIssueIDsHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	text, err := request.RequireString("text")
+	if err != nil {
+		return h.errorHandler.FormatValidationError("text", err), nil
+	}
+
+	args := request.GetArguments()
+	projects, _ := args["projects"].(string)
+
+	if h.toolLogger != nil {
+		h.toolLogger("extract_issue_ids", map[string]interface{}{
+			"text_length": len(text),
+			"projects":    projects,
+		})
+	}
+
+	// Limit matches to the given projects, or to all projects known to YouTrack
+	var prefixes []string
+	for _, project := range strings.Split(projects, ",") {
+		if project = strings.TrimSpace(project); project != "" {
+			prefixes = append(prefixes, project)
+		}
+	}
+	if len(prefixes) == 0 {
+		allProjects, err := h.projectLister.ListAllProjects(ctx)
+		if err != nil {
+			return h.errorHandler.HandleError(err, "listing projects"), nil
+		}
+		for _, project := range allProjects {
+			prefixes = append(prefixes, project.ShortName)
+		}
+	}
+
+	ids := youtrack.ExtractIssueIDs(text, prefixes)
+	if len(ids) == 0 {
+		return mcp.NewToolResultText("No issue IDs found in the text."), nil
+	}
+
+	issues, err := h.ytClient.GetIssuesByIDs(ctx, ids)
+	if err != nil {
+		return h.errorHandler.HandleError(err, "retrieving referenced issues"), nil
+	}
+
+	found := make(map[string]*youtrack.Issue, len(issues))
+	for _, issue := range issues {
+		found[strings.ToUpper(issue.ID)] = issue
+	}
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("Found %d issue IDs:\n\n", len(ids)))
+	for _, id := range ids {
+		issue, ok := found[strings.ToUpper(id)]
+		if !ok {
+			sb.WriteString(fmt.Sprintf("- %s: not found or not accessible\n", id))
+			continue
+		}
+
+		state := issue.State
+		if state == "" {
+			state = "no state"
+		}
+		sb.WriteString(fmt.Sprintf("- %s [%s]: %s\n", issue.ID, state, issue.Summary))
+	}
+
+	return mcp.NewToolResultText(sb.String()), nil
+}
+
+// FindSimilarIssuesHandler handles the find_similar_issues tool call
+func (h *SearchHandlers) FindSimilarIssuesHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	args := request.GetArguments()
+	issueID, _ := args["issue_id"].(string)
+	text, _ := args["text"].(string)
+	projectID, _ := args["project_id"].(string)
+	unresolvedOnly := request.GetBool("unresolved_only", false)
+
+	if strings.TrimSpace(issueID) == "" && strings.TrimSpace(text) == "" {
+		return h.errorHandler.FormatValidationError("text", fmt.Errorf("provide issue_id or text")), nil
+	}
+
+	opts := youtrack.SimilarIssuesOptions{
+		Project:        projectID,
+		ExcludeID:      issueID,
+		UnresolvedOnly: unresolvedOnly,
+	}
+	if maxArg, ok := args["max_results"].(float64); ok && maxArg > 0 {
+		opts.Limit = int(maxArg)
+	}
+
+	if h.toolLogger != nil {
+		h.toolLogger("find_similar_issues", map[string]interface{}{
+			"issue_id":        issueID,
+			"text":            text,
+			"project_id":      projectID,
+			"unresolved_only": unresolvedOnly,
+			"max_results":     opts.Limit,
+		})
+	}
+
+	// Search for the summary of the given issue, unless a text is given
+	if text == "" {
+		issue, err := h.ytClient.GetIssue(ctx, issueID)
+		if err != nil {
+			return h.errorHandler.HandleError(err, "retrieving issue"), nil
+		}
+		text = issue.Summary
+	}
+
+	similar, err := h.ytClient.FindSimilarIssues(ctx, text, opts)
+	if err != nil {
+		return h.errorHandler.HandleError(err, "searching similar issues"), nil
+	}
+
+	if len(similar) == 0 {
+		return mcp.NewToolResultText(fmt.Sprintf("No similar issues found for \"%s\".", text)), nil
+	}
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("Issues similar to \"%s\" (%d, best first):\n\n", text, len(similar)))
+	for _, s := range similar {
+		state := s.Issue.State
+		if s.Issue.Resolved != nil && state == "" {
+			state = "Resolved"
+		}
+		if state != "" {
+			state = " [" + state + "]"
+		}
+		sb.WriteString(fmt.Sprintf("- %s: %s%s (score %.0f%%, matched: %s)\n", s.Issue.ID, s.Issue.Summary, state, s.Score*100, strings.Join(s.Keywords, ", ")))
+	}
+	sb.WriteString("\nCandidates share summary keywords; read them with get_issue_details before treating one as a duplicate.")
+
+	return mcp.NewToolResultText(sb.String()), nil
+}
@@ -0,0 +1,102 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/mkozhukh/youtrack/internal/mcp/cache"
+	"github.com/mkozhukh/youtrack/internal/mcp/logging"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// CallStatsSource defines the interface for reading the tool and REST call counts
+type CallStatsSource interface {
+	CallStats() logging.CallStats
+}
+
+// CacheStatsSource defines the interface for reading the cache lookups
+type CacheStatsSource interface {
+	Stats() cache.Stats
+}
+
+// ServerStats are the usage counters of the server since it started
+type ServerStats struct {
+	StartedAt     time.Time         `json:"started_at"`
+	UptimeSeconds int64             `json:"uptime_seconds"`
+	Calls         logging.CallStats `json:"calls"`
+	Cache         cache.Stats       `json:"cache"`
+}
+
+// StatsHandlers manages the server stats MCP operations
+type StatsHandlers struct {
+	calls      CallStatsSource
+	cache      CacheStatsSource
+	toolLogger func(string, map[string]interface{})
+	startTime  time.Time
+}
+
+// NewStatsHandlers creates a new instance of StatsHandlers
+func NewStatsHandlers(calls CallStatsSource, cache CacheStatsSource, toolLogger func(string, map[string]interface{}), startTime time.Time) *StatsHandlers {
+	return &StatsHandlers{
+		calls:      calls,
+		cache:      cache,
+		toolLogger: toolLogger,
+		startTime:  startTime,
+	}
+}
+
+// collect reads the current counters
+func (h *StatsHandlers) collect() *ServerStats {
+	return &ServerStats{
+		StartedAt:     h.startTime,
+		UptimeSeconds: int64(time.Since(h.startTime).Seconds()),
+		Calls:         h.calls.CallStats(),
+		Cache:         h.cache.Stats(),
+	}
+}
+
+// GetServerStatsHandler handles the get_server_stats tool call
+func (h *StatsHandlers) GetServerStatsHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	if h.toolLogger != nil {
+		h.toolLogger("get_server_stats", map[string]interface{}{})
+	}
+
+	return mcp.NewToolResultText(formatServerStats(h.collect())), nil
+}
+
+// StatsHTTPHandler serves the server stats as JSON
+func (h *StatsHandlers) StatsHTTPHandler(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(h.collect()); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+// formatServerStats formats the stats as text, the tools by number of calls
+func formatServerStats(stats *ServerStats) string {
+	var sb strings.Builder
+	uptime := time.Duration(stats.UptimeSeconds) * time.Second
+	sb.WriteString(fmt.Sprintf("📊 Server stats (up %s, since %s)\n\n", uptime, stats.StartedAt.Format("2006-01-02 15:04:05")))
+
+	calls := stats.Calls
+	sb.WriteString(fmt.Sprintf("🔧 Tool calls: %d (%d failed)\n", calls.ToolCalls, calls.ToolErrors))
+	for _, tool := range calls.Tools {
+		sb.WriteString(fmt.Sprintf("   %s: %d", tool.Name, tool.Calls))
+		if tool.Errors > 0 {
+			sb.WriteString(fmt.Sprintf(" (%d failed)", tool.Errors))
+		}
+		sb.WriteString("\n")
+	}
+	sb.WriteString(fmt.Sprintf("🌐 REST calls: %d (%d error responses)\n\n", calls.RESTCalls, calls.RESTErrors))
+
+	sb.WriteString(fmt.Sprintf("💾 Cache: %d hits, %d misses (%.0f%% hit ratio)\n", stats.Cache.Hits, stats.Cache.Misses, stats.Cache.HitRatio*100))
+	for _, kind := range stats.Cache.Kinds {
+		sb.WriteString(fmt.Sprintf("   %s: %d entries, %d hits, %d misses (%.0f%%)\n", kind.Kind, kind.Entries, kind.Hits, kind.Misses, kind.HitRatio*100))
+	}
+	return sb.String()
+}
@@ -0,0 +1,187 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/mkozhukh/youtrack/pkg/youtrack"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// maxThreadChars bounds the issue thread sent for summarization, older comments are left out beyond it
+const maxThreadChars = 60000
+
+// summarizePrompt is the system prompt of the summarization requests
+const summarizePrompt = "You summarize YouTrack issue discussions for the team. Write a concise summary in Markdown: " +
+	"the problem, the decisions taken, the open questions and the next steps, naming who is responsible when it is known. " +
+	"Only use the information of the thread."
+
+// SummaryHandlers manages the tools summarizing issues with the client model, through MCP sampling
+type SummaryHandlers struct {
+	ytClient     SummaryClient
+	toolLogger   func(string, map[string]interface{})
+	errorHandler *ErrorHandler
+	maxTokens    int
+}
+
+// SummaryClient defines the interface for YouTrack client operations needed for summaries
+type SummaryClient interface {
+	GetIssue(ctx context.Context, issueID string) (*youtrack.Issue, error)
+	GetIssueComments(ctx context.Context, issueID string) ([]*youtrack.IssueComment, error)
+	AddIssueComment(ctx context.Context, issueID string, comment string) (*youtrack.IssueComment, error)
+}
+
+// NewSummaryHandlers creates a new instance of SummaryHandlers; maxTokens limits the length of a summary
+func NewSummaryHandlers(ytClient SummaryClient, toolLogger func(string, map[string]interface{}), maxTokens int) *SummaryHandlers {
+	return &SummaryHandlers{
+		ytClient:     ytClient,
+		toolLogger:   toolLogger,
+		errorHandler: NewErrorHandler(),
+		maxTokens:    maxTokens,
+	}
+}
+
+// SummarizeIssueThreadHandler handles summarizing the thread of an issue
+func (h *SummaryHandlers) SummarizeIssueThreadHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	issueID, err := request.RequireString("issue_id")
+	if err != nil {
+		return h.errorHandler.FormatValidationError("issue_id", err), nil
+	}
+	focus := request.GetString("focus", "")
+	postBack := request.GetBool("post_back", false)
+
+	// Log the tool call
+	if h.toolLogger != nil {
+		h.toolLogger("summarize_issue_thread", map[string]interface{}{
+			"issue_id":  issueID,
+			"focus":     focus,
+			"post_back": postBack,
+		})
+	}
+
+	srv := server.ServerFromContext(ctx)
+	if srv == nil || !clientSupportsSampling(ctx) {
+		return mcp.NewToolResultError("The MCP client does not support sampling. Read the thread with get_issue_details and summarize it instead."), nil
+	}
+
+	issue, err := h.ytClient.GetIssue(ctx, issueID)
+	if err != nil {
+		return h.errorHandler.HandleError(err, "retrieving issue"), nil
+	}
+	issue.Description = issue.MarkdownDescription()
+	comments, err := h.ytClient.GetIssueComments(ctx, issueID)
+	if err != nil {
+		return h.errorHandler.HandleError(err, "retrieving comments"), nil
+	}
+	if len(comments) == 0 && strings.TrimSpace(issue.Description) == "" {
+		return mcp.NewToolResultError(fmt.Sprintf("Issue %s has no description or comments to summarize.", issueID)), nil
+	}
+
+	systemPrompt := summarizePrompt
+	if focus != "" {
+		systemPrompt += " Focus on: " + focus + "."
+	}
+
+	result, err := srv.RequestSampling(ctx, mcp.CreateMessageRequest{
+		CreateMessageParams: mcp.CreateMessageParams{
+			Messages: []mcp.SamplingMessage{{
+				Role:    mcp.RoleUser,
+				Content: mcp.NewTextContent(formatThread(issue, comments)),
+			}},
+			SystemPrompt: systemPrompt,
+			MaxTokens:    h.maxTokens,
+		},
+	})
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("The summary was not generated: %v", err)), nil
+	}
+	summary := samplingText(result.Content)
+	if summary == "" {
+		return mcp.NewToolResultError("The client model returned no text summary."), nil
+	}
+
+	response := fmt.Sprintf("📝 Summary of %s: %s\n", issueID, issue.Summary)
+	if result.Model != "" {
+		response += fmt.Sprintf("🤖 Model: %s\n", result.Model)
+	}
+	response += "\n" + summary + "\n"
+
+	if postBack {
+		comment, err := h.ytClient.AddIssueComment(ctx, issueID, "**Thread summary**\n\n"+summary)
+		if err != nil {
+			return h.errorHandler.HandleError(err, "posting the summary"), nil
+		}
+		response += fmt.Sprintf("\n✅ Summary added to %s as comment %s\n", issueID, comment.ID)
+	}
+
+	return mcp.NewToolResultText(response), nil
+}
+
+// clientSupportsSampling reports whether the client of the session declared the sampling capability
+func clientSupportsSampling(ctx context.Context) bool {
+	session, ok := server.ClientSessionFromContext(ctx).(server.SessionWithClientInfo)
+	if !ok {
+		// The capabilities are unknown, let the request tell
+		return true
+	}
+	return session.GetClientCapabilities().Sampling != nil
+}
+
+// formatThread writes the issue and its comments as the text to summarize. When the thread is
+// too long, the oldest comments are left out.
+func formatThread(issue *youtrack.Issue, comments []*youtrack.IssueComment) string {
+	header := fmt.Sprintf("Issue %s: %s\n", issue.ID, issue.Summary)
+	if issue.Description != "" {
+		header += fmt.Sprintf("\nDescription:\n%s\n", issue.Description)
+	}
+
+	// Keep the most recent comments that fit
+	budget := maxThreadChars - len(header)
+	first := len(comments)
+	entries := make([]string, len(comments))
+	for i := len(comments) - 1; i >= 0; i-- {
+		comment := comments[i]
+		author := "unknown"
+		if comment.Author != nil {
+			author = comment.Author.Login
+		}
+		entries[i] = fmt.Sprintf("\n[%s] %s:\n%s\n", comment.Created.Format("2006-01-02 15:04"), author, comment.Text)
+		if len(entries[i]) > budget {
+			break
+		}
+		budget -= len(entries[i])
+		first = i
+	}
+
+	thread := header
+	if len(comments) > 0 {
+		thread += fmt.Sprintf("\nComments (%d):\n", len(comments))
+	}
+	if first > 0 {
+		thread += fmt.Sprintf("(%d older comments left out)\n", first)
+	}
+	for _, entry := range entries[first:] {
+		thread += entry
+	}
+	return thread
+}
+
+// samplingText returns the text of a sampling result, empty when it is not text
+func samplingText(content any) string {
+	switch c := content.(type) {
+	case mcp.TextContent:
+		return strings.TrimSpace(c.Text)
+	case *mcp.TextContent:
+		return strings.TrimSpace(c.Text)
+	case map[string]any:
+		// Results received over a transport are decoded as maps
+		if c["type"] == "text" {
+			text, _ := c["text"].(string)
+			return strings.TrimSpace(text)
+		}
+	}
+	return ""
+}
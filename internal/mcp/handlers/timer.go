@@ -0,0 +1,160 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/mkozhukh/youtrack/internal/timer"
+	"github.com/mkozhukh/youtrack/pkg/youtrack"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// StartTimerHandler handles the start_timer tool call
+func (h *WorklogHandlers) StartTimerHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	issueID, err := request.RequireString("issue_id")
+	if err != nil {
+		return h.errorHandler.FormatValidationError("issue_id", err), nil
+	}
+
+	if h.toolLogger != nil {
+		h.toolLogger("start_timer", map[string]interface{}{
+			"issue_id": issueID,
+		})
+	}
+
+	timers := h.timers(ctx)
+	current, err := timers.Current()
+	if err != nil {
+		return h.errorHandler.HandleError(err, "reading timer state"), nil
+	}
+	if current != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("A timer is already running for %s (started %s, %s ago). Stop it with stop_timer first.",
+			current.IssueID, current.Started.Format("2006-01-02 15:04"), formatDuration(timer.Minutes(current.Elapsed(time.Now()))))), nil
+	}
+
+	// Check the issue exists, so the worklog can be created when the timer stops
+	issue, err := h.ytClient.GetIssue(ctx, issueID)
+	if err != nil {
+		return h.errorHandler.HandleError(err, "retrieving issue"), nil
+	}
+
+	t, err := timers.Start(issue.ID, issue.Summary, time.Now())
+	if err != nil {
+		return h.errorHandler.HandleError(err, "starting timer"), nil
+	}
+
+	return mcp.NewToolResultText(fmt.Sprintf("Timer started for %s: %s\n\n- Started: %s\n",
+		t.IssueID, t.Summary, t.Started.Format("2006-01-02 15:04"))), nil
+}
+
+// StopTimerHandler handles the stop_timer tool call.
+// It logs the elapsed time as a worklog, rounded and capped by the timer rules, unless
+// discard is set. A duration replaces the elapsed time; with continue_timer, the rest
+// of the session keeps running.
+func (h *WorklogHandlers) StopTimerHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	args := request.GetArguments()
+	text, _ := args["text"].(string)
+	workType, _ := args["work_type"].(string)
+	durationStr, _ := args["duration"].(string)
+	discard := request.GetBool("discard", false)
+	continueTimer := request.GetBool("continue_timer", false)
+
+	if h.toolLogger != nil {
+		h.toolLogger("stop_timer", map[string]interface{}{
+			"text":           text,
+			"work_type":      workType,
+			"duration":       durationStr,
+			"discard":        discard,
+			"continue_timer": continueTimer,
+		})
+	}
+
+	if continueTimer && durationStr == "" {
+		return mcp.NewToolResultError("continue_timer requires a duration, the time to log."), nil
+	}
+	if discard && (durationStr != "" || continueTimer) {
+		return mcp.NewToolResultError("discard cannot be combined with duration or continue_timer."), nil
+	}
+
+	timers := h.timers(ctx)
+	t, err := timers.Current()
+	if err != nil {
+		return h.errorHandler.HandleError(err, "reading timer state"), nil
+	}
+	if t == nil {
+		return mcp.NewToolResultError("No timer is running. Start one with start_timer."), nil
+	}
+
+	now := time.Now()
+	elapsed := t.Elapsed(now)
+	minutes, capped := h.timerRules.Minutes(elapsed)
+	var rest *timer.Timer
+	if durationStr != "" {
+		minutes, err = youtrack.ParseDuration(durationStr)
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Invalid duration '%s': %v", durationStr, err)), nil
+		}
+		if continueTimer {
+			if rest, err = t.Split(minutes, now); err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Cannot continue the timer: %s is not less than the elapsed %s.",
+					formatDuration(minutes), formatDuration(timer.Minutes(elapsed)))), nil
+			}
+		}
+		capped = false
+	}
+
+	if _, err := timers.Stop(); errors.Is(err, timer.ErrNotRunning) {
+		return mcp.NewToolResultError("No timer is running. Start one with start_timer."), nil
+	} else if err != nil {
+		return h.errorHandler.HandleError(err, "stopping timer"), nil
+	}
+
+	if discard {
+		return mcp.NewToolResultText(fmt.Sprintf("Timer for %s discarded after %s, no worklog added.", t.IssueID, formatDuration(timer.Minutes(elapsed)))), nil
+	}
+
+	started := t.Started.UnixMilli()
+	req := &youtrack.CreateWorklogRequest{
+		Duration:    youtrack.DurationValue{Minutes: minutes},
+		Description: text,
+		Date:        &started,
+	}
+	if workType != "" {
+		req.Type = &youtrack.WorkTypeRequest{Name: workType}
+	}
+
+	workItem, err := h.ytClient.AddIssueWorklog(ctx, t.IssueID, req)
+	if err != nil {
+		// Keep the timer running, so the time is not lost and the call can be retried
+		if restoreErr := timers.Restore(t); restoreErr != nil {
+			return h.errorHandler.HandleError(fmt.Errorf("%w (the timer could not be restored: %v)", err, restoreErr), "adding worklog"), nil
+		}
+		return h.errorHandler.HandleError(err, "adding worklog (the timer is still running)"), nil
+	}
+
+	response := "Timer stopped, worklog added.\n\n"
+	response += fmt.Sprintf("- Issue: %s\n", t.IssueID)
+	response += fmt.Sprintf("- Started: %s\n", t.Started.Format("2006-01-02 15:04"))
+	response += fmt.Sprintf("- Duration: %s\n", formatDuration(workItem.Duration.Minutes))
+	if workItem.Description != "" {
+		response += fmt.Sprintf("- Description: %s\n", workItem.Description)
+	}
+	if workItem.Type != nil {
+		response += fmt.Sprintf("- Type: %s\n", workItem.Type.Name)
+	}
+	if capped {
+		response += fmt.Sprintf("\nThe timer ran %s, only the session cap was logged. Add a worklog for the rest if it was actually worked.\n", formatDuration(timer.Minutes(elapsed)))
+	}
+
+	if rest != nil {
+		if err := timers.Restore(rest); err != nil {
+			return h.errorHandler.HandleError(err, "continuing timer (the worklog was added)"), nil
+		}
+		response += fmt.Sprintf("\nTimer still running for %s with the remaining %s.\n", t.IssueID, formatDuration(timer.Minutes(elapsed)-minutes))
+	}
+
+	return mcp.NewToolResultText(response), nil
+}
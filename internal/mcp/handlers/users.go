@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/mkozhukh/youtrack/internal/mcp/tools"
 	"github.com/mkozhukh/youtrack/pkg/youtrack"
 
 	"github.com/mark3labs/mcp-go/mcp"
@@ -22,12 +23,15 @@ type UserHandlers struct {
 type UserClient interface {
 	GetCurrentUser(ctx context.Context) (*youtrack.User, error)
 	GetProjectUsers(ctx context.Context, projectID string, skip, top int) ([]*youtrack.User, error)
+	GetAssigneeLoad(ctx context.Context, projectID string, opts youtrack.AssigneeLoadOptions) ([]*youtrack.AssigneeLoad, error)
 }
 
 // ProjectTracker defines the interface for tracking project usage
 type ProjectTracker interface {
 	TrackProject(ctx context.Context, projectID string)
 	GetLastProject(ctx context.Context) string
+	PinProject(ctx context.Context, projectID string)
+	DefaultProject(ctx context.Context) string
 }
 
 // NewUserHandlers creates a new instance of UserHandlers
@@ -58,17 +62,28 @@ func (h *UserHandlers) GetCurrentUserHandler(ctx context.Context, request mcp.Ca
 	response += fmt.Sprintf("- Full Name: %s\n", user.FullName)
 	response += fmt.Sprintf("- Email: %s\n", user.Email)
 
+	output := tools.CurrentUserOutput{
+		ID:             user.ID,
+		Login:          user.Login,
+		FullName:       user.FullName,
+		Email:          user.Email,
+		DefaultProject: h.defaultProject,
+	}
+
 	if h.defaultProject != "" {
 		response += fmt.Sprintf("- Default Project: %s\n", h.defaultProject)
 	}
 
 	if h.projectTracker != nil {
-		if lastProject := h.projectTracker.GetLastProject(ctx); lastProject != "" {
-			response += fmt.Sprintf("- Last Used Project: %s\n", lastProject)
+		if sessionProject := h.projectTracker.DefaultProject(ctx); sessionProject != "" {
+			response += fmt.Sprintf("- Session Project: %s\n", sessionProject)
+			output.SessionProject = sessionProject
 		}
 	}
 
-	return mcp.NewToolResultText(response), nil
+	response += fmt.Sprintf("\nTip: use 'Assignee: me' or 'Assignee: %s' in queries to find issues assigned to this user.\n", user.Login)
+
+	return mcp.NewToolResultStructured(output, response), nil
 }
 
 // GetProjectUsersHandler handles the get_project_users tool call
@@ -119,7 +134,11 @@ func (h *UserHandlers) GetProjectUsersHandler(ctx context.Context, request mcp.C
 
 	response := fmt.Sprintf("Project Members (%d):\n\n", len(allUsers))
 	for _, user := range allUsers {
-		response += fmt.Sprintf("- %s (%s)\n", user.FullName, user.Login)
+		response += fmt.Sprintf("- %s (%s)", user.FullName, user.Login)
+		if user.Banned {
+			response += " [banned, cannot be assigned]"
+		}
+		response += "\n"
 		if user.Email != "" {
 			response += fmt.Sprintf("  Email: %s\n", user.Email)
 		}
@@ -127,3 +146,68 @@ func (h *UserHandlers) GetProjectUsersHandler(ctx context.Context, request mcp.C
 
 	return mcp.NewToolResultText(response), nil
 }
+
+// SuggestAssigneeHandler handles the suggest_assignee tool call
+func (h *UserHandlers) SuggestAssigneeHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	projectID, err := request.RequireString("project_id")
+	if err != nil {
+		return h.errorHandler.FormatValidationError("project_id", err), nil
+	}
+
+	args := request.GetArguments()
+	opts := youtrack.AssigneeLoadOptions{}
+	opts.IssueType, _ = args["issue_type"].(string)
+	opts.TypeField, _ = args["type_field"].(string)
+	if days, ok := args["worklog_days"].(float64); ok && days > 0 {
+		opts.WorklogDays = int(days)
+	}
+	limit := 10
+	if l, ok := args["limit"].(float64); ok && l > 0 {
+		limit = int(l)
+	}
+
+	if h.toolLogger != nil {
+		h.toolLogger("suggest_assignee", map[string]interface{}{
+			"project_id":   projectID,
+			"issue_type":   opts.IssueType,
+			"type_field":   opts.TypeField,
+			"worklog_days": opts.WorklogDays,
+			"limit":        limit,
+		})
+	}
+
+	// Track project usage
+	if h.projectTracker != nil {
+		h.projectTracker.TrackProject(ctx, projectID)
+	}
+
+	loads, err := h.ytClient.GetAssigneeLoad(ctx, projectID, opts)
+	if err != nil {
+		return h.errorHandler.HandleError(err, "computing assignee load"), nil
+	}
+	if len(loads) == 0 {
+		return mcp.NewToolResultText(fmt.Sprintf("No users found in project '%s'.", projectID)), nil
+	}
+
+	response := fmt.Sprintf("Project members of %s by current load, least loaded first", projectID)
+	if opts.IssueType != "" {
+		response += fmt.Sprintf(" (ordered by open %s issues, then all open issues)", opts.IssueType)
+	}
+	response += ":\n\n"
+	for i, load := range loads[:min(limit, len(loads))] {
+		response += fmt.Sprintf("%d. %s (%s): %d open issue(s)", i+1, load.User.FullName, load.User.Login, load.OpenIssues)
+		if opts.IssueType != "" {
+			response += fmt.Sprintf(", %d open %s", load.OpenOfType, opts.IssueType)
+		}
+		if opts.WorklogDays > 0 {
+			response += fmt.Sprintf(", %s logged in the last %d days", formatDuration(load.LoggedMinutes), opts.WorklogDays)
+		}
+		response += "\n"
+	}
+	if len(loads) > limit {
+		response += fmt.Sprintf("\n%d more member(s) not listed.\n", len(loads)-limit)
+	}
+	response += fmt.Sprintf("\nSuggested assignee: %s\n", loads[0].User.Login)
+
+	return mcp.NewToolResultText(response), nil
+}
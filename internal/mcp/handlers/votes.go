@@ -0,0 +1,128 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/mkozhukh/youtrack/pkg/youtrack"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// VoteHandlers manages issue voting MCP operations
+type VoteHandlers struct {
+	ytClient     VoteClient
+	toolLogger   func(string, map[string]interface{})
+	errorHandler *ErrorHandler
+}
+
+// VoteClient defines the interface for YouTrack client operations needed for voting
+type VoteClient interface {
+	GetIssueVoters(ctx context.Context, issueID string) (*youtrack.IssueVoters, error)
+	VoteIssue(ctx context.Context, issueID string) error
+	UnvoteIssue(ctx context.Context, issueID string) error
+}
+
+// NewVoteHandlers creates a new instance of VoteHandlers
+func NewVoteHandlers(ytClient VoteClient, toolLogger func(string, map[string]interface{})) *VoteHandlers {
+	return &VoteHandlers{
+		ytClient:     ytClient,
+		toolLogger:   toolLogger,
+		errorHandler: NewErrorHandler(),
+	}
+}
+
+// GetIssueVotersHandler handles the get_issue_voters tool call
+func (h *VoteHandlers) GetIssueVotersHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	issueID, err := request.RequireString("issue_id")
+	if err != nil {
+		return h.errorHandler.FormatValidationError("issue_id", err), nil
+	}
+
+	if h.toolLogger != nil {
+		h.toolLogger("get_issue_voters", map[string]interface{}{
+			"issue_id": issueID,
+		})
+	}
+
+	voters, err := h.ytClient.GetIssueVoters(ctx, issueID)
+	if err != nil {
+		return h.errorHandler.HandleError(err, "retrieving issue voters"), nil
+	}
+
+	return mcp.NewToolResultText(formatVoters(issueID, voters)), nil
+}
+
+// VoteIssueHandler handles the vote_issue tool call
+func (h *VoteHandlers) VoteIssueHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return h.setVote(ctx, request, "vote_issue", true)
+}
+
+// UnvoteIssueHandler handles the unvote_issue tool call
+func (h *VoteHandlers) UnvoteIssueHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return h.setVote(ctx, request, "unvote_issue", false)
+}
+
+// setVote adds or removes the current user's vote and reports the resulting vote count
+func (h *VoteHandlers) setVote(ctx context.Context, request mcp.CallToolRequest, toolName string, vote bool) (*mcp.CallToolResult, error) {
+	issueID, err := request.RequireString("issue_id")
+	if err != nil {
+		return h.errorHandler.FormatValidationError("issue_id", err), nil
+	}
+
+	if h.toolLogger != nil {
+		h.toolLogger(toolName, map[string]interface{}{
+			"issue_id": issueID,
+		})
+	}
+
+	if vote {
+		err = h.ytClient.VoteIssue(ctx, issueID)
+	} else {
+		err = h.ytClient.UnvoteIssue(ctx, issueID)
+	}
+	if err != nil {
+		return h.errorHandler.HandleError(err, "changing vote"), nil
+	}
+
+	action := "Voted for"
+	if !vote {
+		action = "Removed vote from"
+	}
+	response := fmt.Sprintf("%s %s\n", action, issueID)
+
+	// Report the new count (non-critical)
+	if voters, err := h.ytClient.GetIssueVoters(ctx, issueID); err == nil {
+		response += fmt.Sprintf("Votes: %d\n", voters.Votes)
+	}
+
+	return mcp.NewToolResultText(response), nil
+}
+
+// formatVoters formats the votes of an issue
+func formatVoters(issueID string, voters *youtrack.IssueVoters) string {
+	response := fmt.Sprintf("Votes for %s: %d\n", issueID, voters.Votes)
+	if voters.HasVote {
+		response += "The current user voted for this issue.\n"
+	}
+
+	if len(voters.Voters) > 0 {
+		response += "\nVoters:\n"
+		for _, user := range voters.Voters {
+			response += fmt.Sprintf("- %s (%s)\n", user.FullName, user.Login)
+		}
+	}
+
+	if len(voters.DuplicateVotes) > 0 {
+		response += "\nVotes for duplicates:\n"
+		for _, vote := range voters.DuplicateVotes {
+			login := "unknown"
+			if vote.User != nil {
+				login = vote.User.Login
+			}
+			response += fmt.Sprintf("- %s via %s\n", login, vote.IssueID)
+		}
+	}
+
+	return response
+}
@@ -6,6 +6,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/mkozhukh/youtrack/internal/timer"
 	"github.com/mkozhukh/youtrack/pkg/youtrack"
 
 	"github.com/mark3labs/mcp-go/mcp"
@@ -16,6 +17,8 @@ type WorklogHandlers struct {
 	ytClient     WorklogClient
 	toolLogger   func(string, map[string]interface{})
 	errorHandler *ErrorHandler
+	timers       func(ctx context.Context) *timer.Store
+	timerRules   timer.Rules
 }
 
 // WorklogClient defines the interface for YouTrack client operations needed for worklog management
@@ -24,14 +27,20 @@ type WorklogClient interface {
 	AddIssueWorklog(ctx context.Context, issueID string, req *youtrack.CreateWorklogRequest) (*youtrack.WorkItem, error)
 	GetUserWorklogs(ctx context.Context, userID string, projectID string, startDate, endDate string, skip, top int) ([]*youtrack.WorkItem, error)
 	GetCurrentUser(ctx context.Context) (*youtrack.User, error)
+	GetUserByLogin(ctx context.Context, login string) (*youtrack.User, error)
+	GetIssue(ctx context.Context, issueID string) (*youtrack.Issue, error)
 }
 
 // NewWorklogHandlers creates a new instance of WorklogHandlers
-func NewWorklogHandlers(ytClient WorklogClient, toolLogger func(string, map[string]interface{})) *WorklogHandlers {
+// that keep the start_timer/stop_timer state in the store timers returns for the caller
+// and log time by timerRules
+func NewWorklogHandlers(ytClient WorklogClient, toolLogger func(string, map[string]interface{}), timers func(ctx context.Context) *timer.Store, timerRules timer.Rules) *WorklogHandlers {
 	return &WorklogHandlers{
 		ytClient:     ytClient,
 		toolLogger:   toolLogger,
 		errorHandler: NewErrorHandler(),
+		timers:       timers,
+		timerRules:   timerRules,
 	}
 }
 
@@ -55,6 +64,8 @@ func (h *WorklogHandlers) AddWorklogHandler(ctx context.Context, request mcp.Cal
 	text, _ := args["text"].(string)
 	dateStr, _ := args["date"].(string)
 	workType, _ := args["work_type"].(string)
+	author, _ := args["author"].(string)
+	author = strings.TrimSpace(author)
 
 	if h.toolLogger != nil {
 		h.toolLogger("add_worklog", map[string]interface{}{
@@ -63,6 +74,7 @@ func (h *WorklogHandlers) AddWorklogHandler(ctx context.Context, request mcp.Cal
 			"text":      text,
 			"date":      dateStr,
 			"work_type": workType,
+			"author":    author,
 		})
 	}
 
@@ -86,6 +98,15 @@ func (h *WorklogHandlers) AddWorklogHandler(ctx context.Context, request mcp.Cal
 		req.Type = &youtrack.WorkTypeRequest{Name: workType}
 	}
 
+	// Log the work on behalf of another user
+	if author != "" {
+		user, err := h.ytClient.GetUserByLogin(ctx, author)
+		if err != nil {
+			return h.errorHandler.HandleError(err, fmt.Sprintf("finding author '%s'", author)), nil
+		}
+		req.Author = &youtrack.UserRef{ID: user.ID}
+	}
+
 	workItem, err := h.ytClient.AddIssueWorklog(ctx, issueID, req)
 	if err != nil {
 		return h.errorHandler.HandleError(err, "adding worklog"), nil
@@ -0,0 +1,141 @@
+package mcp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/charmbracelet/log"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+
+	"github.com/mkozhukh/youtrack/internal/resolver"
+	"github.com/mkozhukh/youtrack/pkg/youtrack"
+)
+
+// issueIDArgs are the tool arguments that hold an issue ID
+var issueIDArgs = []string{"issue_id", "source_issue_id", "target_issue_id"}
+
+// issueIDListArgs are the tool arguments that hold a list of objects with an issue_id each,
+// e.g. the operations of batch_update_issues
+var issueIDListArgs = []string{"operations"}
+
+// toolIssueIDArgs returns the issue ID arguments the tool accepts, and its list arguments
+// whose items hold an issue_id
+func toolIssueIDArgs(tool mcp.Tool) (names, lists []string) {
+	for _, name := range issueIDArgs {
+		if _, ok := tool.InputSchema.Properties[name]; ok {
+			names = append(names, name)
+		}
+	}
+	for _, name := range issueIDListArgs {
+		if itemIssueIDProperty(tool, name) != nil {
+			lists = append(lists, name)
+		}
+	}
+	return names, lists
+}
+
+// itemIssueIDProperty returns the schema of the issue_id of the items of a list argument,
+// nil when the tool has no such argument
+func itemIssueIDProperty(tool mcp.Tool, list string) map[string]any {
+	prop, _ := tool.InputSchema.Properties[list].(map[string]any)
+	items, _ := prop["items"].(map[string]any)
+	properties, _ := items["properties"].(map[string]any)
+	issueID, _ := properties["issue_id"].(map[string]any)
+	return issueID
+}
+
+// withIssueNumbers documents that the issue ID arguments accept a bare number
+func withIssueNumbers(tool mcp.Tool, names, lists []string) mcp.Tool {
+	const note = " (a bare number like 123 refers to an issue of the session project)"
+	for _, name := range names {
+		if prop, ok := tool.InputSchema.Properties[name].(map[string]any); ok {
+			description, _ := prop["description"].(string)
+			prop["description"] = description + note
+		}
+	}
+	for _, list := range lists {
+		prop := itemIssueIDProperty(tool, list)
+		description, _ := prop["description"].(string)
+		prop["description"] = description + note
+	}
+	return tool
+}
+
+// withIssueIDNormalization wraps a tool handler so that the issue ID arguments, and the
+// issue_id of the items of its list arguments, accept a bare number ("123"), completed with
+// the session project: the one pinned by set_default_project, the last used one, or the
+// configured default project
+func (s *MCPServer) withIssueIDNormalization(names, lists []string, next server.ToolHandlerFunc) server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		args := request.GetArguments()
+
+		// Full IDs are kept as they are, the project is only looked up for bare numbers
+		var projectID string
+		normalize := func(id string) (string, error) {
+			normalized, err := youtrack.NormalizeIssueID(id, "")
+			if err == nil {
+				return normalized, nil
+			}
+			if projectID == "" {
+				if projectID, err = s.issueProject(ctx); err != nil {
+					return "", err
+				}
+			}
+			return youtrack.NormalizeIssueID(id, projectID)
+		}
+
+		for _, name := range names {
+			id, _ := args[name].(string)
+			if id == "" {
+				continue
+			}
+			normalized, err := normalize(id)
+			if err != nil {
+				return mcp.NewToolResultError(name + ": " + err.Error()), nil
+			}
+			args[name] = normalized
+		}
+		for _, list := range lists {
+			items, _ := args[list].([]interface{})
+			for i, raw := range items {
+				item, _ := raw.(map[string]interface{})
+				id, _ := item["issue_id"].(string)
+				if id == "" {
+					continue
+				}
+				normalized, err := normalize(id)
+				if err != nil {
+					return mcp.NewToolResultError(fmt.Sprintf("%s[%d].issue_id: %v", list, i, err)), nil
+				}
+				item["issue_id"] = normalized
+			}
+		}
+		return next(ctx, request)
+	}
+}
+
+// issueProject returns the short name of the session project that bare issue numbers
+// belong to, empty when there is none. The configured default project may be a name.
+func (s *MCPServer) issueProject(ctx context.Context) (string, error) {
+	query := s.projectTracker.DefaultProject(ctx)
+	if query == "" {
+		query = s.config.YouTrack.DefaultProject
+	}
+	if query == "" {
+		return "", nil
+	}
+
+	project, err := resolver.ResolveProject(ctx, s.cachedClient, query)
+	if err != nil {
+		var resolveErr *resolver.ResolveError
+		if errors.As(err, &resolveErr) {
+			return "", err
+		}
+		// The project list is unavailable - keep the value as given
+		log.Debug("Project resolution skipped", "project", query, "error", err)
+		return query, nil
+	}
+	return project.ShortName, nil
+}
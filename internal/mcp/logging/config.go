@@ -13,6 +13,29 @@ type LogConfig struct {
 
 	// ToolErrorLogPath is the path to the tool error log file
 	ToolErrorLogPath string
+
+	// Debug captures the full REST request and response bodies to DebugLogPath; it can be
+	// toggled at runtime with AppLogger.SetDebug and does not depend on Enabled
+	Debug bool
+
+	// DebugLogPath is the path to the REST body capture log file
+	DebugLogPath string
+
+	// MaxSizeMB rotates a log file once it reaches the size, 0 never rotates
+	MaxSizeMB int
+
+	// MaxFiles is the number of rotated files kept per log, 0 keeps all
+	MaxFiles int
+
+	// MaxAgeDays removes rotated files older than the number of days, 0 keeps them regardless of age
+	MaxAgeDays int
+
+	// Compress gzips the rotated files
+	Compress bool
+
+	// Format is the console log format, FormatText or FormatJSON; it applies whether
+	// the log files are enabled or not
+	Format string
 }
 
 // DefaultLogConfig returns the default logging configuration
@@ -22,5 +45,9 @@ func DefaultLogConfig() LogConfig {
 		CallLogPath:      "calls.log",
 		RESTErrorLogPath: "rest_errors.log",
 		ToolErrorLogPath: "tool_errors.log",
+		DebugLogPath:     "debug.log",
+		MaxSizeMB:        100,
+		MaxFiles:         5,
+		Format:           FormatText,
 	}
 }
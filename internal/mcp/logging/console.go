@@ -0,0 +1,27 @@
+package logging
+
+import (
+	"fmt"
+
+	"github.com/charmbracelet/log"
+)
+
+// Console log formats
+const (
+	FormatText = "text"
+	FormatJSON = "json"
+)
+
+// SetConsoleFormat switches the console (stderr) log output between text and JSON;
+// an empty format means text
+func SetConsoleFormat(format string) error {
+	switch format {
+	case "", FormatText:
+		log.SetFormatter(log.TextFormatter)
+	case FormatJSON:
+		log.SetFormatter(log.JSONFormatter)
+	default:
+		return fmt.Errorf("unknown log format '%s', use '%s' or '%s'", format, FormatText, FormatJSON)
+	}
+	return nil
+}
@@ -5,11 +5,17 @@ import (
 	"crypto/sha256"
 	"encoding/hex"
 	"encoding/json"
+	"fmt"
+	"io"
 	"os"
 	"sync"
+	"sync/atomic"
 	"time"
 
+	"github.com/mkozhukh/youtrack/pkg/youtrack"
+
 	"github.com/charmbracelet/log"
+	"gopkg.in/natefinch/lumberjack.v2"
 )
 
 // contextKey is a custom type for context keys to avoid collisions
@@ -52,76 +58,182 @@ type RESTLogger interface {
 type AppLogger struct {
 	config LogConfig
 
-	callLogFile      *os.File
-	restErrorLogFile *os.File
-	toolErrorLogFile *os.File
+	// The log files are rotated by size when config.MaxSizeMB is set
+	callLogFile      io.WriteCloser
+	restErrorLogFile io.WriteCloser
+	toolErrorLogFile io.WriteCloser
 
 	callMu      sync.Mutex
 	restErrorMu sync.Mutex
 	toolErrorMu sync.Mutex
+
+	// debug tells whether REST bodies are captured to debugLogFile, opened with debugConfig
+	debug        atomic.Bool
+	debugConfig  LogConfig
+	debugLogFile io.WriteCloser
+	debugMu      sync.Mutex
+
+	// counter counts the calls for the server stats, also while logging is disabled
+	counter callCounter
 }
 
+// debugBodyLimit is the largest body written to the debug log, longer ones are truncated
+const debugBodyLimit = 64 * 1024
+
 // NewAppLogger creates a new AppLogger with the given configuration
 func NewAppLogger(config LogConfig) (*AppLogger, error) {
+	logger := &AppLogger{config: config, debugConfig: config}
+	logger.callLogFile, logger.restErrorLogFile, logger.toolErrorLogFile = openLogFiles(config)
+	logger.setDebug(config.Debug)
+	return logger, nil
+}
+
+// openLogFiles opens the log files of a configuration, nil for the ones that are not logged
+func openLogFiles(config LogConfig) (callLog, restErrorLog, toolErrorLog io.WriteCloser) {
 	if !config.Enabled {
-		return &AppLogger{config: config}, nil
+		return nil, nil, nil
 	}
+	return openLogFile(config, config.CallLogPath, "call"),
+		openLogFile(config, config.RESTErrorLogPath, "REST error"),
+		openLogFile(config, config.ToolErrorLogPath, "tool error")
+}
 
-	logger := &AppLogger{config: config}
+// openLogFile opens a log file for appending, or returns nil when the path is empty or the file cannot be opened.
+// With config.MaxSizeMB set the file is rotated once it reaches the size, keeping config.MaxFiles
+// old files for at most config.MaxAgeDays.
+func openLogFile(config LogConfig, path, name string) io.WriteCloser {
+	if path == "" {
+		return nil
+	}
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0600)
+	if err != nil {
+		log.Warn("Failed to open "+name+" log file, "+name+" logging disabled", "path", path, "error", err)
+		return nil
+	}
+	if config.MaxSizeMB <= 0 {
+		return f
+	}
 
-	// Open call log file
-	if config.CallLogPath != "" {
-		f, err := os.OpenFile(config.CallLogPath, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0600)
-		if err != nil {
-			log.Warn("Failed to open call log file, call logging disabled", "path", config.CallLogPath, "error", err)
-		} else {
-			logger.callLogFile = f
-		}
+	// The file opens fine, the rotating writer reopens it on the first write
+	f.Close()
+	return &lumberjack.Logger{
+		Filename:   path,
+		MaxSize:    config.MaxSizeMB,
+		MaxBackups: config.MaxFiles,
+		MaxAge:     config.MaxAgeDays,
+		Compress:   config.Compress,
+		LocalTime:  true,
 	}
+}
 
-	// Open REST error log file
-	if config.RESTErrorLogPath != "" {
-		f, err := os.OpenFile(config.RESTErrorLogPath, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0600)
-		if err != nil {
-			log.Warn("Failed to open REST error log file, REST error logging disabled", "path", config.RESTErrorLogPath, "error", err)
-		} else {
-			logger.restErrorLogFile = f
+// Reconfigure applies a new configuration: the log files are reopened, so logging can be
+// enabled, disabled or moved to other files while the server runs
+func (l *AppLogger) Reconfigure(config LogConfig) {
+	callLog, restErrorLog, toolErrorLog := openLogFiles(config)
+
+	l.callMu.Lock()
+	l.restErrorMu.Lock()
+	l.toolErrorMu.Lock()
+	old := []io.WriteCloser{l.callLogFile, l.restErrorLogFile, l.toolErrorLogFile}
+	l.config = config
+	l.callLogFile, l.restErrorLogFile, l.toolErrorLogFile = callLog, restErrorLog, toolErrorLog
+	l.toolErrorMu.Unlock()
+	l.restErrorMu.Unlock()
+	l.callMu.Unlock()
+
+	for _, f := range old {
+		if f != nil {
+			f.Close()
 		}
 	}
 
-	// Open tool error log file
-	if config.ToolErrorLogPath != "" {
-		f, err := os.OpenFile(config.ToolErrorLogPath, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0600)
-		if err != nil {
-			log.Warn("Failed to open tool error log file, tool error logging disabled", "path", config.ToolErrorLogPath, "error", err)
-		} else {
-			logger.toolErrorLogFile = f
-		}
+	l.debugMu.Lock()
+	l.debugConfig = config
+	l.setDebug(config.Debug)
+	l.debugMu.Unlock()
+}
+
+// SetDebug switches the capture of REST request and response bodies to the debug log
+func (l *AppLogger) SetDebug(enabled bool) {
+	l.debugMu.Lock()
+	defer l.debugMu.Unlock()
+	l.setDebug(enabled)
+}
+
+// setDebug (re)opens or closes the debug log; debugMu must be held
+func (l *AppLogger) setDebug(enabled bool) {
+	if l.debugLogFile != nil {
+		l.debugLogFile.Close()
+		l.debugLogFile = nil
 	}
+	if enabled {
+		l.debugLogFile = openLogFile(l.debugConfig, l.debugConfig.DebugLogPath, "debug")
+	}
+	l.debug.Store(l.debugLogFile != nil)
+}
 
-	return logger, nil
+// Debug reports whether REST bodies are captured
+func (l *AppLogger) Debug() bool {
+	return l.debug.Load()
 }
 
-// Close closes all log files
-func (l *AppLogger) Close() error {
-	if l.callLogFile != nil {
-		l.callLogFile.Close()
+// DebugLogPath returns the file REST bodies are captured to
+func (l *AppLogger) DebugLogPath() string {
+	l.debugMu.Lock()
+	defer l.debugMu.Unlock()
+	return l.debugConfig.DebugLogPath
+}
+
+// LogRESTBodies logs a captured REST exchange to the debug log
+func (l *AppLogger) LogRESTBodies(keyHash string, exchange *youtrack.RESTExchange) {
+	entry := map[string]interface{}{
+		"t":                time.Now().UTC().Format(time.RFC3339),
+		"key":              keyHash,
+		"method":           exchange.Method,
+		"url":              exchange.URL,
+		"ms":               exchange.Duration.Milliseconds(),
+		"request_headers":  exchange.RequestHeader,
+		"request_body":     debugBody(exchange.RequestBody),
+		"status":           exchange.StatusCode,
+		"response_headers": exchange.ResponseHeader,
+		"response_body":    debugBody(exchange.ResponseBody),
 	}
-	if l.restErrorLogFile != nil {
-		l.restErrorLogFile.Close()
+	if exchange.Err != nil {
+		entry["error"] = exchange.Err.Error()
 	}
-	if l.toolErrorLogFile != nil {
-		l.toolErrorLogFile.Close()
+
+	l.debugMu.Lock()
+	defer l.debugMu.Unlock()
+
+	if l.debugLogFile == nil {
+		return
 	}
+	data, err := json.Marshal(entry)
+	if err != nil {
+		log.Error("Failed to marshal debug log entry", "error", err)
+		return
+	}
+	if _, err := l.debugLogFile.Write(append(data, '\n')); err != nil {
+		log.Error("Failed to write to debug log", "error", err)
+	}
+}
+
+// debugBody returns a body as text for the debug log, truncated to debugBodyLimit
+func debugBody(body []byte) string {
+	if len(body) <= debugBodyLimit {
+		return string(body)
+	}
+	return fmt.Sprintf("%s... (truncated, %d bytes)", body[:debugBodyLimit], len(body))
+}
+
+// Close closes all log files
+func (l *AppLogger) Close() error {
+	l.Reconfigure(LogConfig{})
 	return nil
 }
 
 // LogToolCall logs a tool invocation to calls.log
 func (l *AppLogger) LogToolCall(keyHash, toolName string) {
-	if !l.config.Enabled || l.callLogFile == nil {
-		return
-	}
-
 	entry := map[string]interface{}{
 		"t":    time.Now().UTC().Format(time.RFC3339),
 		"type": "tool",
@@ -134,9 +246,7 @@ func (l *AppLogger) LogToolCall(keyHash, toolName string) {
 
 // LogRESTCall logs a REST API call to calls.log
 func (l *AppLogger) LogRESTCall(keyHash, method, path string, duration time.Duration) {
-	if !l.config.Enabled || l.callLogFile == nil {
-		return
-	}
+	l.counter.restCall()
 
 	entry := map[string]interface{}{
 		"t":      time.Now().UTC().Format(time.RFC3339),
@@ -152,9 +262,7 @@ func (l *AppLogger) LogRESTCall(keyHash, method, path string, duration time.Dura
 
 // LogRESTError logs a REST API error to rest_errors.log
 func (l *AppLogger) LogRESTError(keyHash, method, path string, params interface{}, statusCode int, errMsg string) {
-	if !l.config.Enabled || l.restErrorLogFile == nil {
-		return
-	}
+	l.counter.restError()
 
 	entry := map[string]interface{}{
 		"t":      time.Now().UTC().Format(time.RFC3339),
@@ -171,10 +279,6 @@ func (l *AppLogger) LogRESTError(keyHash, method, path string, params interface{
 
 // LogToolError logs a tool error to tool_errors.log
 func (l *AppLogger) LogToolError(keyHash, toolName string, params map[string]interface{}, errMsg string) {
-	if !l.config.Enabled || l.toolErrorLogFile == nil {
-		return
-	}
-
 	entry := map[string]interface{}{
 		"t":      time.Now().UTC().Format(time.RFC3339),
 		"key":    keyHash,
@@ -190,12 +294,17 @@ func (l *AppLogger) writeCallLog(entry map[string]interface{}) {
 	l.callMu.Lock()
 	defer l.callMu.Unlock()
 
+	// The file is nil when logging is disabled
+	if l.callLogFile == nil {
+		return
+	}
+
 	data, err := json.Marshal(entry)
 	if err != nil {
 		log.Error("Failed to marshal call log entry", "error", err)
 		return
 	}
-	if _, err := l.callLogFile.WriteString(string(data) + "\n"); err != nil {
+	if _, err := l.callLogFile.Write(append(data, '\n')); err != nil {
 		log.Error("Failed to write to call log", "error", err)
 	}
 }
@@ -204,12 +313,17 @@ func (l *AppLogger) writeRESTErrorLog(entry map[string]interface{}) {
 	l.restErrorMu.Lock()
 	defer l.restErrorMu.Unlock()
 
+	// The file is nil when logging is disabled
+	if l.restErrorLogFile == nil {
+		return
+	}
+
 	data, err := json.Marshal(entry)
 	if err != nil {
 		log.Error("Failed to marshal REST error log entry", "error", err)
 		return
 	}
-	if _, err := l.restErrorLogFile.WriteString(string(data) + "\n"); err != nil {
+	if _, err := l.restErrorLogFile.Write(append(data, '\n')); err != nil {
 		log.Error("Failed to write to REST error log", "error", err)
 	}
 }
@@ -218,12 +332,17 @@ func (l *AppLogger) writeToolErrorLog(entry map[string]interface{}) {
 	l.toolErrorMu.Lock()
 	defer l.toolErrorMu.Unlock()
 
+	// The file is nil when logging is disabled
+	if l.toolErrorLogFile == nil {
+		return
+	}
+
 	data, err := json.Marshal(entry)
 	if err != nil {
 		log.Error("Failed to marshal tool error log entry", "error", err)
 		return
 	}
-	if _, err := l.toolErrorLogFile.WriteString(string(data) + "\n"); err != nil {
+	if _, err := l.toolErrorLogFile.Write(append(data, '\n')); err != nil {
 		log.Error("Failed to write to tool error log", "error", err)
 	}
 }
@@ -249,6 +368,18 @@ func (r *RESTLoggerWithContext) LogRESTCall(method, path string, duration time.D
 	}
 }
 
+// CaptureBodies reports whether REST bodies are captured to the debug log
+func (r *RESTLoggerWithContext) CaptureBodies() bool {
+	return r.logger != nil && r.logger.Debug()
+}
+
+// LogRESTBodies logs a captured REST exchange with the keyHash
+func (r *RESTLoggerWithContext) LogRESTBodies(exchange *youtrack.RESTExchange) {
+	if r.logger != nil {
+		r.logger.LogRESTBodies(r.keyHash, exchange)
+	}
+}
+
 // LogRESTError logs a REST error with the keyHash
 func (r *RESTLoggerWithContext) LogRESTError(method, path string, body interface{}, statusCode int, errMsg string) {
 	if r.logger != nil {
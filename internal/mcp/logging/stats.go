@@ -0,0 +1,111 @@
+package logging
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// CallStats are the tool and REST call counts since the server started
+type CallStats struct {
+	ToolCalls  int64        `json:"tool_calls"`
+	ToolErrors int64        `json:"tool_errors"`
+	RESTCalls  int64        `json:"rest_calls"`
+	RESTErrors int64        `json:"rest_errors"`
+	Tools      []ToolCounts `json:"tools"`
+}
+
+// ToolCounts are the call and error counts of a tool
+type ToolCounts struct {
+	Name   string `json:"name"`
+	Calls  int64  `json:"calls"`
+	Errors int64  `json:"errors"`
+}
+
+// callCounter counts the calls logged by an AppLogger, whether or not they are written to a file
+type callCounter struct {
+	mu         sync.Mutex
+	tools      map[string]*ToolCounts
+	restCalls  int64
+	restErrors int64
+}
+
+func (c *callCounter) toolCall(toolName string, failed bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.tools == nil {
+		c.tools = make(map[string]*ToolCounts)
+	}
+	counts, ok := c.tools[toolName]
+	if !ok {
+		counts = &ToolCounts{Name: toolName}
+		c.tools[toolName] = counts
+	}
+	counts.Calls++
+	if failed {
+		counts.Errors++
+	}
+}
+
+func (c *callCounter) restCall() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.restCalls++
+}
+
+func (c *callCounter) restError() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.restErrors++
+}
+
+// CountToolCall counts a completed tool call for the stats, failed when it returned an error
+func (l *AppLogger) CountToolCall(toolName string, failed bool) {
+	l.counter.toolCall(toolName, failed)
+}
+
+// CallStats returns the call counts, the tools sorted by the number of calls
+func (l *AppLogger) CallStats() CallStats {
+	l.counter.mu.Lock()
+	defer l.counter.mu.Unlock()
+
+	stats := CallStats{
+		RESTCalls:  l.counter.restCalls,
+		RESTErrors: l.counter.restErrors,
+		Tools:      make([]ToolCounts, 0, len(l.counter.tools)),
+	}
+	for _, counts := range l.counter.tools {
+		stats.ToolCalls += counts.Calls
+		stats.ToolErrors += counts.Errors
+		stats.Tools = append(stats.Tools, *counts)
+	}
+	sort.Slice(stats.Tools, func(i, j int) bool {
+		if stats.Tools[i].Calls != stats.Tools[j].Calls {
+			return stats.Tools[i].Calls > stats.Tools[j].Calls
+		}
+		return stats.Tools[i].Name < stats.Tools[j].Name
+	})
+	return stats
+}
+
+// RESTCounter counts REST calls without logging them, for the client of per-request auth
+// mode, which has no API key of its own to log the calls with
+type RESTCounter struct {
+	logger *AppLogger
+}
+
+// NewRESTCounter creates a REST logger that only counts the calls for the stats
+func (l *AppLogger) NewRESTCounter() *RESTCounter {
+	return &RESTCounter{logger: l}
+}
+
+// LogRESTCall counts a REST call
+func (r *RESTCounter) LogRESTCall(method, path string, duration time.Duration) {
+	r.logger.counter.restCall()
+}
+
+// LogRESTError counts a failed REST call
+func (r *RESTCounter) LogRESTError(method, path string, body interface{}, statusCode int, errMsg string) {
+	r.logger.counter.restError()
+}
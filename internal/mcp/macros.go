@@ -0,0 +1,220 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/charmbracelet/log"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// macroNamePattern is the form of macro tool and parameter names
+var macroNamePattern = regexp.MustCompile(`^[a-z][a-z0-9_]*$`)
+
+// macroPlaceholder matches the {{name}} placeholders of macro templates
+var macroPlaceholder = regexp.MustCompile(`\{\{\s*([a-z][a-z0-9_]*)\s*\}\}`)
+
+// macroBuiltinParams are the arguments every macro tool of a kind has: query macros search a
+// project like get_issue_list, command macros apply to an issue like apply_command
+var macroBuiltinParams = map[string][]string{
+	"query":   {"project_id", "max_results"},
+	"command": {"issue_id"},
+}
+
+// kind returns "query" or "command"
+func (m MacroConfig) kind() string {
+	if m.Command != "" {
+		return "command"
+	}
+	return "query"
+}
+
+// template returns the query or command of the macro
+func (m MacroConfig) template() string {
+	if m.Command != "" {
+		return m.Command
+	}
+	return m.Query
+}
+
+// validateMacros checks the macro definitions: valid unique names, either a query or a
+// command, and placeholders that name a parameter
+func validateMacros(macros []MacroConfig) error {
+	names := make(map[string]bool)
+	for i, m := range macros {
+		if !macroNamePattern.MatchString(m.Name) {
+			return fmt.Errorf("macros[%d]: name %q must be lower case letters, digits and underscores", i, m.Name)
+		}
+		if names[m.Name] {
+			return fmt.Errorf("macros[%d]: duplicate macro name %q", i, m.Name)
+		}
+		names[m.Name] = true
+
+		if (m.Query == "") == (m.Command == "") {
+			return fmt.Errorf("macro %s: set either query or command", m.Name)
+		}
+
+		params := make(map[string]bool)
+		for _, name := range macroBuiltinParams[m.kind()] {
+			params[name] = true
+		}
+		for _, p := range m.Params {
+			if !macroNamePattern.MatchString(p.Name) {
+				return fmt.Errorf("macro %s: parameter name %q must be lower case letters, digits and underscores", m.Name, p.Name)
+			}
+			if params[p.Name] {
+				return fmt.Errorf("macro %s: parameter %q is defined twice or is a built-in argument", m.Name, p.Name)
+			}
+			params[p.Name] = true
+		}
+
+		for _, match := range macroPlaceholder.FindAllStringSubmatch(m.template(), -1) {
+			if !params[match[1]] {
+				return fmt.Errorf("macro %s: placeholder {{%s}} names no parameter", m.Name, match[1])
+			}
+		}
+	}
+	return nil
+}
+
+// registerMacros registers the macro tools of the config. A macro named like a built-in
+// tool is skipped.
+func (s *MCPServer) registerMacros() {
+	for _, m := range s.config.Macros {
+		if s.hasTool(m.Name) {
+			log.Warn("Macro named like a built-in tool, skipping", "tool", m.Name)
+			s.skipTool(m.Name, "a macro of the config has the name of this built-in tool, the macro is skipped")
+			continue
+		}
+
+		handler := s.macroHandler(m)
+		if m.kind() == "command" {
+			handler = s.withActionLog(m.Name, handler)
+		}
+		s.addTool(macroTool(m), handler)
+	}
+}
+
+// hasTool reports whether a tool is already registered, blacklisted or not
+func (s *MCPServer) hasTool(name string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, tool := range s.tools {
+		if tool.Tool.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// macroTool builds the MCP tool definition of a macro
+func macroTool(m MacroConfig) mcp.Tool {
+	description := m.Description
+	if description == "" {
+		description = fmt.Sprintf("Run the YouTrack %s: %s", m.kind(), m.template())
+	}
+
+	opts := []mcp.ToolOption{mcp.WithDescription(description)}
+	if m.kind() == "command" {
+		opts = append(opts, mcp.WithString("issue_id",
+			mcp.Required(),
+			mcp.Description("Issue ID to apply the command to"),
+		))
+	} else {
+		opts = append(opts,
+			mcp.WithString("project_id",
+				mcp.Required(),
+				mcp.Description("Project ID to search issues in"),
+			),
+			mcp.WithNumber("max_results",
+				mcp.Description("Maximum number of results to return (optional, defaults to config value)"),
+			),
+		)
+	}
+	for _, p := range m.Params {
+		propOpts := []mcp.PropertyOption{mcp.Description(p.Description)}
+		if p.Required {
+			propOpts = append(propOpts, mcp.Required())
+		} else if p.Default != "" {
+			propOpts = append(propOpts, mcp.DefaultString(p.Default))
+		}
+		opts = append(opts, mcp.WithString(p.Name, propOpts...))
+	}
+	return mcp.NewTool(m.Name, opts...)
+}
+
+// macroHandler fills the template of a macro with the call arguments and runs it through
+// get_issue_list or apply_command
+func (s *MCPServer) macroHandler(m MacroConfig) server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		args := request.GetArguments()
+		values := make(map[string]string)
+		for _, name := range macroBuiltinParams[m.kind()] {
+			if value, ok := args[name].(string); ok {
+				values[name] = value
+			}
+		}
+		for _, p := range m.Params {
+			value, _ := args[p.Name].(string)
+			if value == "" {
+				if p.Required {
+					return mcp.NewToolResultError(fmt.Sprintf("Invalid %s: parameter is required", p.Name)), nil
+				}
+				value = p.Default
+			}
+			values[p.Name] = value
+		}
+		if m.kind() == "command" {
+			for name, value := range values {
+				quoted, err := commandValue(value)
+				if err != nil {
+					return mcp.NewToolResultError(fmt.Sprintf("Invalid %s: %v", name, err)), nil
+				}
+				values[name] = quoted
+			}
+		}
+		rendered := renderMacro(m.template(), values)
+
+		delegated := request
+		if m.kind() == "command" {
+			delegated.Params.Arguments = map[string]any{
+				"issue_id": args["issue_id"],
+				"command":  rendered,
+			}
+			return s.commandHandlers.ApplyCommandHandler(ctx, delegated)
+		}
+		delegated.Params.Arguments = map[string]any{
+			"project_id":  args["project_id"],
+			"max_results": args["max_results"],
+			"query":       rendered,
+		}
+		return s.issueHandlers.GetIssueListHandler(ctx, delegated)
+	}
+}
+
+// commandValue makes an argument a single value of a YouTrack command: a value of several
+// words is wrapped in braces, so that it cannot add commands of its own
+func commandValue(value string) (string, error) {
+	if strings.ContainsAny(value, "{}") {
+		return "", fmt.Errorf("braces are not allowed in command values")
+	}
+	value = strings.Join(strings.Fields(value), " ")
+	if strings.Contains(value, " ") {
+		return "{" + value + "}", nil
+	}
+	return value, nil
+}
+
+// renderMacro replaces the placeholders of a template with their values; the spaces left by
+// empty values are collapsed
+func renderMacro(template string, values map[string]string) string {
+	rendered := macroPlaceholder.ReplaceAllStringFunc(template, func(placeholder string) string {
+		name := macroPlaceholder.FindStringSubmatch(placeholder)[1]
+		return values[name]
+	})
+	return strings.Join(strings.Fields(rendered), " ")
+}
@@ -0,0 +1,159 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"regexp"
+	"slices"
+
+	"github.com/mkozhukh/youtrack/internal/mcp/logging"
+
+	"github.com/charmbracelet/log"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// Permission profiles of the API keys: read-only keys call the @read tools, contributor
+// keys the @read and @write tools but not the @admin ones, admin keys every tool
+const (
+	ProfileReadOnly    = "read-only"
+	ProfileContributor = "contributor"
+	ProfileAdmin       = "admin"
+)
+
+// keyHashPattern is the form of a key hash, as logged in the key field of tool calls
+var keyHashPattern = regexp.MustCompile(`^[0-9a-f]{12}$`)
+
+// Permissions restricts the tools each API key may call to its permission profile. Calls
+// outside the profile are refused before the handler runs and tools/list hides them.
+type Permissions struct {
+	defaultProfile string
+	profiles       map[string]string // profile by key hash
+	apiKey         string            // configured key, used by calls without a token
+	macros         []MacroConfig
+}
+
+// NewPermissions creates the permission checks of the configured keys; apiKey is the key of
+// calls that carry no Authorization token
+func NewPermissions(config AuthConfig, apiKey string, macros []MacroConfig) *Permissions {
+	p := &Permissions{
+		defaultProfile: config.DefaultProfile,
+		profiles:       make(map[string]string, len(config.Keys)),
+		apiKey:         apiKey,
+		macros:         macros,
+	}
+	if p.defaultProfile == "" {
+		p.defaultProfile = ProfileAdmin
+	}
+	for _, key := range config.Keys {
+		hash := key.KeyHash
+		if key.Key != "" {
+			hash = logging.HashAPIKey(key.Key)
+		}
+		p.profiles[hash] = key.Profile
+	}
+	return p
+}
+
+// Register attaches the permission middleware and the tools/list filter to the server options
+func (p *Permissions) Register() []server.ServerOption {
+	return []server.ServerOption{
+		server.WithToolHandlerMiddleware(p.middleware),
+		server.WithToolFilter(p.filterTools),
+	}
+}
+
+// validateAuth checks the profiles of the config and that each key is given by value or hash
+func validateAuth(config AuthConfig) error {
+	if config.DefaultProfile != "" && !isProfile(config.DefaultProfile) {
+		return fmt.Errorf("auth.default_profile: unknown profile %q, use %s, %s or %s", config.DefaultProfile, ProfileReadOnly, ProfileContributor, ProfileAdmin)
+	}
+	for i, key := range config.Keys {
+		name := key.Name
+		if name == "" {
+			name = fmt.Sprintf("auth.keys[%d]", i)
+		}
+		if !isProfile(key.Profile) {
+			return fmt.Errorf("%s: unknown profile %q, use %s, %s or %s", name, key.Profile, ProfileReadOnly, ProfileContributor, ProfileAdmin)
+		}
+		if (key.Key == "") == (key.KeyHash == "") {
+			return fmt.Errorf("%s: set either key or key_hash", name)
+		}
+		if key.KeyHash != "" && !keyHashPattern.MatchString(key.KeyHash) {
+			return fmt.Errorf("%s: key_hash must be the 12 hex characters logged as the key of tool calls", name)
+		}
+	}
+	return nil
+}
+
+// isProfile reports whether a name is a permission profile
+func isProfile(name string) bool {
+	return name == ProfileReadOnly || name == ProfileContributor || name == ProfileAdmin
+}
+
+// profile returns the permission profile and the key hash of the API key of a call
+func (p *Permissions) profile(ctx context.Context) (string, string) {
+	key := GetAuthToken(ctx)
+	if key == "" {
+		key = p.apiKey
+	}
+	hash := logging.HashAPIKey(key)
+	if profile, ok := p.profiles[hash]; ok {
+		return profile, hash
+	}
+	return p.defaultProfile, hash
+}
+
+// allows reports whether a profile may call a tool
+func (p *Permissions) allows(profile, toolName string) bool {
+	categories := toolCategories(toolName, p.macros)
+	switch profile {
+	case ProfileReadOnly:
+		return !slices.Contains(categories, categoryWrite) && !slices.Contains(categories, categoryAdmin)
+	case ProfileContributor:
+		return !slices.Contains(categories, categoryAdmin)
+	default:
+		return true
+	}
+}
+
+// middleware refuses the tool calls the profile of the key does not allow
+func (p *Permissions) middleware(next server.ToolHandlerFunc) server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		profile, hash := p.profile(ctx)
+		if !p.allows(profile, request.Params.Name) {
+			log.Warn("Tool call refused by the permission profile", "tool", request.Params.Name, "key", hash, "profile", profile)
+			return mcp.NewToolResultError(fmt.Sprintf("Tool %s is not allowed for the %s permission profile of this API key.", request.Params.Name, profile)), nil
+		}
+		return next(ctx, request)
+	}
+}
+
+// RequireTool wraps an HTTP handler so that it serves only the keys whose profile allows a tool
+func (p *Permissions) RequireTool(toolName string, next http.HandlerFunc) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		profile, hash := p.profile(r.Context())
+		if !p.allows(profile, toolName) {
+			log.Warn("HTTP request refused by the permission profile", "path", r.URL.Path, "key", hash, "profile", profile)
+			http.Error(w, fmt.Sprintf("not allowed for the %s permission profile of this API key", profile), http.StatusForbidden)
+			return
+		}
+		next(w, r)
+	})
+}
+
+// filterTools leaves out of tools/list the tools the profile of the key does not allow
+func (p *Permissions) filterTools(ctx context.Context, tools []mcp.Tool) []mcp.Tool {
+	profile, _ := p.profile(ctx)
+	if profile == ProfileAdmin {
+		return tools
+	}
+	allowed := make([]mcp.Tool, 0, len(tools))
+	for _, tool := range tools {
+		if p.allows(profile, tool.Name) {
+			allowed = append(allowed, tool)
+		}
+	}
+	return allowed
+}
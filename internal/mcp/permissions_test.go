@@ -0,0 +1,37 @@
+package mcp
+
+import "testing"
+
+func TestPermissions_Allows(t *testing.T) {
+	macros := []MacroConfig{
+		{Name: "my_bugs", Query: "Type: Bug for: me"},
+		{Name: "escalate", Command: "Priority Critical"},
+	}
+	p := NewPermissions(AuthConfig{}, "", macros)
+
+	tests := []struct {
+		profile  string
+		tool     string
+		expected bool
+	}{
+		{profile: ProfileReadOnly, tool: "get_issue", expected: true},
+		{profile: ProfileReadOnly, tool: "my_bugs", expected: true},
+		{profile: ProfileReadOnly, tool: "update_issue", expected: false},
+		{profile: ProfileReadOnly, tool: "escalate", expected: false},
+		// mark_read changes the notifications on the server
+		{profile: ProfileReadOnly, tool: "get_notifications", expected: false},
+		{profile: ProfileReadOnly, tool: "summarize_issue_thread", expected: false},
+		{profile: ProfileContributor, tool: "get_notifications", expected: true},
+		{profile: ProfileContributor, tool: "update_issue", expected: true},
+		{profile: ProfileContributor, tool: "delete_issue", expected: false},
+		{profile: ProfileAdmin, tool: "delete_issue", expected: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.profile+" "+tt.tool, func(t *testing.T) {
+			if allowed := p.allows(tt.profile, tt.tool); allowed != tt.expected {
+				t.Errorf("Expected allowed %v, got %v", tt.expected, allowed)
+			}
+		})
+	}
+}
@@ -0,0 +1,98 @@
+package mcp
+
+import (
+	"context"
+	"errors"
+	"slices"
+
+	"github.com/charmbracelet/log"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+
+	"github.com/mkozhukh/youtrack/internal/resolver"
+)
+
+// projectArg is the tool argument that holds a project reference
+const projectArg = "project_id"
+
+// hasProjectArg reports whether the tool accepts a project_id argument
+func hasProjectArg(tool mcp.Tool) bool {
+	_, ok := tool.InputSchema.Properties[projectArg]
+	return ok
+}
+
+// isProjectRequired reports whether the tool declares project_id as required
+func isProjectRequired(tool mcp.Tool) bool {
+	return slices.Contains(tool.InputSchema.Required, projectArg)
+}
+
+// withOptionalProject makes the required project_id argument optional, as the session project
+// is used when it is omitted
+func withOptionalProject(tool mcp.Tool) mcp.Tool {
+	tool.InputSchema.Required = slices.DeleteFunc(slices.Clone(tool.InputSchema.Required), func(name string) bool {
+		return name == projectArg
+	})
+	if prop, ok := tool.InputSchema.Properties[projectArg].(map[string]any); ok {
+		description, _ := prop["description"].(string)
+		prop["description"] = description + " (optional when the session has a project, see set_default_project)"
+	}
+	return tool
+}
+
+// withSessionProject wraps a tool handler so that an omitted project_id is filled with the
+// session project: the one pinned by set_default_project, the last used one, or the
+// configured default project
+func (s *MCPServer) withSessionProject(next server.ToolHandlerFunc) server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		args := request.GetArguments()
+		if query, _ := args[projectArg].(string); query != "" {
+			return next(ctx, request)
+		}
+
+		projectID := s.projectTracker.DefaultProject(ctx)
+		if projectID == "" {
+			projectID = s.config.YouTrack.DefaultProject
+		}
+		if projectID == "" {
+			return mcp.NewToolResultError("project_id is required: no project has been used in this session yet. Pass project_id or call set_default_project."), nil
+		}
+
+		if args == nil {
+			args = make(map[string]any)
+			request.Params.Arguments = args
+		}
+		args[projectArg] = projectID
+		log.Debug("Session project used", "project", projectID)
+		return next(ctx, request)
+	}
+}
+
+// withProjectResolution wraps a tool handler so that project_id accepts a project name
+// ("Mobile App") as well as a short name or ID; the argument is replaced with the
+// project short name before the handler runs
+func (s *MCPServer) withProjectResolution(next server.ToolHandlerFunc) server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		args := request.GetArguments()
+		query, _ := args[projectArg].(string)
+		if query == "" {
+			return next(ctx, request)
+		}
+
+		project, err := resolver.ResolveProject(ctx, s.cachedClient, query)
+		if err != nil {
+			var resolveErr *resolver.ResolveError
+			if errors.As(err, &resolveErr) {
+				return mcp.NewToolResultError(resolveErr.Error()), nil
+			}
+			// The project list is unavailable - let the API validate the value
+			log.Debug("Project resolution skipped", "project", query, "error", err)
+			return next(ctx, request)
+		}
+
+		if project.ShortName != query {
+			log.Debug("Project resolved", "query", query, "project", project.ShortName)
+		}
+		args[projectArg] = project.ShortName
+		return next(ctx, request)
+	}
+}
@@ -0,0 +1,128 @@
+package mcp
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/mkozhukh/youtrack/internal/mcp/logging"
+
+	"github.com/charmbracelet/log"
+	"github.com/knadh/koanf/providers/file"
+)
+
+// WatchConfig watches the configuration file and applies its changes with ReloadConfig.
+// The file is reloaded with the environment variables, like on startup; a file that
+// cannot be loaded leaves the current settings in place.
+func (s *MCPServer) WatchConfig(configPath string) error {
+	configPath = ConfigPath(configPath)
+	if _, err := os.Stat(configPath); err != nil {
+		log.Info("Config file not found, hot reload disabled", "path", configPath)
+		return nil
+	}
+
+	return file.Provider(configPath).Watch(func(event interface{}, err error) {
+		if err != nil {
+			log.Warn("Config watch failed, hot reload stopped", "error", err)
+			return
+		}
+
+		config, err := LoadConfig(configPath)
+		if err != nil {
+			log.Warn("Config reload failed, keeping the current settings", "error", err)
+			return
+		}
+		s.ReloadConfig(config)
+	})
+}
+
+// ReloadConfig applies the settings that can change while the server runs: the tool
+// blacklist, the logging settings and the cache TTL. It logs and returns a summary of
+// the changes; other changed settings are only reported, they need a restart.
+func (s *MCPServer) ReloadConfig(config ServerConfig) []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var changes []string
+
+	if added, removed := s.applyBlacklist(config.ToolBlacklist); len(added) > 0 || len(removed) > 0 {
+		if len(removed) > 0 {
+			changes = append(changes, "tools removed: "+strings.Join(removed, ", "))
+		}
+		if len(added) > 0 {
+			changes = append(changes, "tools added: "+strings.Join(added, ", "))
+		}
+	}
+	s.config.ToolBlacklist = config.ToolBlacklist
+
+	if config.Logging.Format != s.config.Logging.Format {
+		if err := logging.SetConsoleFormat(config.Logging.Format); err != nil {
+			log.Warn("Log format not applied", "error", err)
+		} else {
+			changes = append(changes, "log format "+config.Logging.Format)
+		}
+	}
+	files := config.Logging
+	files.Format = s.config.Logging.Format
+	if files != s.config.Logging {
+		s.appLogger.Reconfigure(config.Logging)
+		if config.Logging.Enabled {
+			changes = append(changes, fmt.Sprintf("logging enabled (calls: %s, REST errors: %s, tool errors: %s)",
+				config.Logging.CallLogPath, config.Logging.RESTErrorLogPath, config.Logging.ToolErrorLogPath))
+		} else {
+			changes = append(changes, "logging disabled")
+		}
+		if config.Logging.Debug {
+			changes = append(changes, "REST body capture enabled ("+config.Logging.DebugLogPath+")")
+		} else if s.config.Logging.Debug {
+			changes = append(changes, "REST body capture disabled")
+		}
+	}
+	s.config.Logging = config.Logging
+
+	ttl := config.Cache.TTL
+	if ttl == 0 {
+		ttl = 5 * time.Minute
+	}
+	if config.Cache.TTL != s.config.Cache.TTL {
+		s.projectCache.SetTTL(ttl)
+		changes = append(changes, fmt.Sprintf("cache TTL %s", ttl))
+		s.config.Cache.TTL = config.Cache.TTL
+	}
+
+	// Whatever differs beyond the reloadable settings needs a restart
+	current, next := s.config, config
+	next.ToolBlacklist, next.Logging, next.Cache.TTL = current.ToolBlacklist, current.Logging, current.Cache.TTL
+	if !reflect.DeepEqual(current, next) {
+		log.Warn("Config changes besides tools.blacklist, logging and cache.ttl_seconds need a server restart")
+	}
+
+	if len(changes) == 0 {
+		log.Info("Config reloaded, nothing to apply")
+	} else {
+		log.Info("Config reloaded", "changes", strings.Join(changes, "; "))
+	}
+	return changes
+}
+
+// applyBlacklist registers the tools no longer blacklisted and removes the newly blacklisted ones,
+// returning their names; s.mu must be held
+func (s *MCPServer) applyBlacklist(blacklist []string) (added, removed []string) {
+	for _, tool := range s.tools {
+		was := s.isBlacklisted(tool.Tool.Name)
+		is := s.inBlacklist(blacklist, tool.Tool.Name)
+		switch {
+		case was && !is:
+			s.server.AddTool(tool.Tool, tool.Handler)
+			added = append(added, tool.Tool.Name)
+		case !was && is:
+			removed = append(removed, tool.Tool.Name)
+		}
+	}
+	if len(removed) > 0 {
+		s.server.DeleteTools(removed...)
+	}
+	return added, removed
+}
@@ -1,16 +1,22 @@
 package mcp
 
 import (
+	"context"
 	"fmt"
 	"net/http"
+	"strings"
+	"sync"
 	"time"
 
+	"github.com/mkozhukh/youtrack/internal/mcp/audit"
 	"github.com/mkozhukh/youtrack/internal/mcp/cache"
 	"github.com/mkozhukh/youtrack/internal/mcp/filestore"
 	"github.com/mkozhukh/youtrack/internal/mcp/handlers"
 	"github.com/mkozhukh/youtrack/internal/mcp/logging"
 	"github.com/mkozhukh/youtrack/internal/mcp/tools"
 	"github.com/mkozhukh/youtrack/internal/mcp/tracker"
+	"github.com/mkozhukh/youtrack/internal/timer"
+	"github.com/mkozhukh/youtrack/pkg/youtrack"
 
 	"github.com/charmbracelet/log"
 	"github.com/mark3labs/mcp-go/mcp"
@@ -25,11 +31,15 @@ type YouTrackConfig struct {
 	DefaultProject string `koanf:"default_project"`
 	Timeout        int    `koanf:"timeout"`
 	MaxResults     int    `koanf:"max_results"`
+	// Connection tuning for the HTTP client
+	MaxIdleConnsPerHost int  `koanf:"max_idle_conns_per_host"`
+	HTTP2               bool `koanf:"http2"`
 }
 
 // CacheConfig holds cache-specific configuration
 type CacheConfig struct {
-	TTL time.Duration
+	TTL    time.Duration
+	WarmUp bool
 }
 
 // TrackerConfig holds project tracker configuration
@@ -39,10 +49,74 @@ type TrackerConfig struct {
 
 // FileServerConfig holds the file server configuration
 type FileServerConfig struct {
-	Enabled       bool   `koanf:"enabled"`
-	BaseURL       string `koanf:"base_url"`
-	TTLSeconds    int    `koanf:"ttl_seconds"`
-	MaxFileSizeMB int    `koanf:"max_file_size_mb"`
+	Enabled        bool   `koanf:"enabled"`
+	BaseURL        string `koanf:"base_url"`
+	TTLSeconds     int    `koanf:"ttl_seconds"`
+	MaxFileSizeMB  int    `koanf:"max_file_size_mb"`
+	MaxStoreSizeMB int    `koanf:"max_store_size_mb"`
+}
+
+// AttachmentsConfig holds the attachment tools configuration
+type AttachmentsConfig struct {
+	InlineImageMaxKB int64 // largest image returned as image content by the attachment tools, 0 disables it
+}
+
+// WorkflowConfig holds issue states used by workflow tools
+type WorkflowConfig struct {
+	ReviewState  string            // State set when a pull request is linked (e.g. "In Review")
+	ReviewStates map[string]string // Per-project overrides of ReviewState, by short name
+}
+
+// TimerConfig holds the worklog timer configuration
+type TimerConfig struct {
+	StateFile         string // timer state file, empty for the one shared with the yt CLI (~/.config/yt/timer.json)
+	MaxSessionMinutes int    // cap of the time logged by stop_timer, 0 uses 8 hours, -1 disables it
+	RoundMinutes      int    // stop_timer rounds to the nearest multiple (e.g. 5 or 15), 0 to whole minutes
+}
+
+// DuplicatesConfig holds the duplicate check of create_issue
+type DuplicatesConfig struct {
+	Check    bool    // look for similar open issues before creating one
+	MinScore float64 // similarity from 0 to 1 from which an issue is reported as a likely duplicate
+	Days     int     // only issues created in the last days are compared, 0 for all
+}
+
+// SamplingConfig holds the tools asking the client model for completions through MCP sampling
+type SamplingConfig struct {
+	Enabled   bool // register the sampling tools (summarize_issue_thread)
+	MaxTokens int  // length limit of a completion
+}
+
+// MacroConfig defines a macro tool: a YouTrack search or command with {{name}} placeholders
+// filled from the tool arguments
+type MacroConfig struct {
+	Name        string       `koanf:"name"`
+	Description string       `koanf:"description"`
+	Query       string       `koanf:"query"`   // search run in a project, as get_issue_list
+	Command     string       `koanf:"command"` // command applied to an issue, as apply_command
+	Params      []MacroParam `koanf:"params"`
+}
+
+// MacroParam is an argument of a macro tool
+type MacroParam struct {
+	Name        string `koanf:"name"`
+	Description string `koanf:"description"`
+	Required    bool   `koanf:"required"`
+	Default     string `koanf:"default"`
+}
+
+// AuthConfig holds the permission profiles of the API keys
+type AuthConfig struct {
+	DefaultProfile string       `koanf:"default_profile"` // profile of the keys not listed, admin by default
+	Keys           []KeyProfile `koanf:"keys"`
+}
+
+// KeyProfile assigns a permission profile to an API key, given by value or by its hash
+type KeyProfile struct {
+	Name    string `koanf:"name"`
+	Key     string `koanf:"key"`
+	KeyHash string `koanf:"key_hash"`
+	Profile string `koanf:"profile"`
 }
 
 // ServerConfig holds the MCP server configuration
@@ -53,50 +127,91 @@ type ServerConfig struct {
 	Cache         CacheConfig
 	Tracker       TrackerConfig
 	FileServer    FileServerConfig
+	Attachments   AttachmentsConfig
 	Logging       logging.LogConfig
+	Workflow      WorkflowConfig
+	Timer         TimerConfig
+	Duplicates    DuplicatesConfig
+	Sampling      SamplingConfig
+	Macros        []MacroConfig // tools defined in the config, registered after the built-in ones
+	Auth          AuthConfig
+	SLA           youtrack.SLAPolicy // targets checked by check_sla, empty when not configured
 	ToolBlacklist []string
+	// AllowDestructive registers tools that delete data (delete_issue, delete_attachment)
+	AllowDestructive bool
 }
 
 // MCPServer wraps the MCP server with YouTrack-specific functionality
 type MCPServer struct {
-	server             *server.MCPServer
-	config             ServerConfig
-	ytClient           *YouTrackClient
-	cachedClient       *cache.CachedClient
-	appLogger          *logging.AppLogger
-	toolLogger         func(string, map[string]interface{})
-	fileStore          *filestore.Store
-	issueHandlers      *handlers.IssueHandlers
-	tagHandlers        *handlers.TagHandlers
-	commentHandlers    *handlers.CommentHandlers
-	healthHandlers     *handlers.HealthHandlers
-	projectHandlers    *handlers.ProjectHandlers
-	userHandlers       *handlers.UserHandlers
-	linkHandlers       *handlers.LinkHandlers
-	attachmentHandlers *handlers.AttachmentHandlers
-	commandHandlers    *handlers.CommandHandlers
-	worklogHandlers    *handlers.WorklogHandlers
-	cacheHandlers      *handlers.CacheHandlers
-	startTime          time.Time
+	server               *server.MCPServer
+	config               ServerConfig
+	ytClient             *YouTrackClient
+	cachedClient         *cache.CachedClient
+	appLogger            *logging.AppLogger
+	toolLogger           func(string, map[string]interface{})
+	fileStore            *filestore.Store
+	issueHandlers        *handlers.IssueHandlers
+	tagHandlers          *handlers.TagHandlers
+	voteHandlers         *handlers.VoteHandlers
+	notificationHandlers *handlers.NotificationHandlers
+	commentHandlers      *handlers.CommentHandlers
+	healthHandlers       *handlers.HealthHandlers
+	projectHandlers      *handlers.ProjectHandlers
+	userHandlers         *handlers.UserHandlers
+	linkHandlers         *handlers.LinkHandlers
+	attachmentHandlers   *handlers.AttachmentHandlers
+	commandHandlers      *handlers.CommandHandlers
+	worklogHandlers      *handlers.WorklogHandlers
+	cacheHandlers        *handlers.CacheHandlers
+	statsHandlers        *handlers.StatsHandlers
+	searchHandlers       *handlers.SearchHandlers
+	prHandlers           *handlers.PullRequestHandlers
+	reportHandlers       *handlers.ReportHandlers
+	actionHandlers       *handlers.ActionHandlers
+	summaryHandlers      *handlers.SummaryHandlers
+	projectTracker       *tracker.ContextProjectTracker
+	actionLog            *audit.Log
+	projectCache         *cache.ProjectCache
+	permissions          *Permissions
+	startTime            time.Time
+
+	// mu guards the settings ReloadConfig changes and the tool lists
+	mu sync.Mutex
+	// tools are the tools that pass the destructive check, including the blacklisted ones,
+	// so that a reload can register them; skippedTools are the others with the reason
+	tools        []server.ServerTool
+	skippedTools map[string]string
 }
 
 // NewMCPServer creates a new MCP server instance with YouTrack integration
 func NewMCPServer(config ServerConfig, toolLogger func(string, map[string]interface{})) (*MCPServer, error) {
-	// Create the underlying MCP server
-	s := server.NewMCPServer(
-		config.Name,
-		"1.0.0",
-		server.WithToolCapabilities(false),
-	)
-
-	// Create app logger
-	var appLogger *logging.AppLogger
+	if err := logging.SetConsoleFormat(config.Logging.Format); err != nil {
+		return nil, err
+	}
+
+	// Create the underlying MCP server, propagating client cancellation to tool calls
+	canceller := NewCallCanceller()
+	hooks := &server.Hooks{}
+	options := []server.ServerOption{
+		server.WithToolCapabilities(true),
+		server.WithHooks(hooks),
+		canceller.Register(hooks),
+	}
+	// Restrict the tools of each API key to its permission profile
+	permissions := NewPermissions(config.Auth, config.YouTrack.APIKey, config.Macros)
+	options = append(options, permissions.Register()...)
+	s := server.NewMCPServer(config.Name, "1.0.0", options...)
+	s.AddNotificationHandler("notifications/cancelled", canceller.HandleCancelled)
+	if config.Sampling.Enabled {
+		s.EnableSampling()
+	}
+
+	// Create app logger, it writes nothing while logging is disabled and is reconfigured on reload
+	appLogger, err := logging.NewAppLogger(config.Logging)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create app logger: %w", err)
+	}
 	if config.Logging.Enabled {
-		var err error
-		appLogger, err = logging.NewAppLogger(config.Logging)
-		if err != nil {
-			return nil, fmt.Errorf("failed to create app logger: %w", err)
-		}
 		log.Info("Structured logging enabled",
 			"call_log", config.Logging.CallLogPath,
 			"rest_error_log", config.Logging.RESTErrorLogPath,
@@ -129,40 +244,73 @@ func NewMCPServer(config ServerConfig, toolLogger func(string, map[string]interf
 	}
 	projectCache := cache.NewProjectCache(cacheTTL)
 	cachedClient := cache.NewCachedClient(ytClient, projectCache)
+	cachedClient.SetUserKey(ytClient.GetKeyHash)
 
 	log.Info("Cache initialized", "ttl", cacheTTL)
 
 	// Create project tracker
 	projectTracker := tracker.NewProjectTracker(config.Tracker.FilePath)
 	contextTracker := tracker.NewContextProjectTracker(projectTracker, ytClient)
+	hooks.AddOnUnregisterSession(func(ctx context.Context, session server.ClientSession) {
+		contextTracker.ForgetSession(session.SessionID())
+	})
+
+	// Create the action log of the sessions, read by get_recent_actions
+	actionLog := audit.NewLog(audit.DefaultSize, ytClient)
+	hooks.AddOnUnregisterSession(func(ctx context.Context, session server.ClientSession) {
+		actionLog.ForgetSession(session.SessionID())
+	})
 
 	if config.Tracker.FilePath != "" {
 		log.Info("Project tracker initialized", "file", config.Tracker.FilePath)
 	}
 
+	// Warm up the cache in the background for the default and recently tracked projects
+	if config.Cache.WarmUp {
+		if config.YouTrack.APIKey == "" {
+			log.Info("Cache warm-up skipped: no api_key configured (per-request auth mode)")
+		} else {
+			projects := warmUpProjects(config.YouTrack.DefaultProject, projectTracker.Projects())
+			go cachedClient.WarmUp(context.Background(), projects)
+		}
+	}
+
 	// Create file store if enabled
 	var store *filestore.Store
 	if config.FileServer.Enabled {
 		ttl := time.Duration(config.FileServer.TTLSeconds) * time.Second
 		maxSize := config.FileServer.MaxFileSizeMB
-		if maxSize == 0 {
-			maxSize = 50
-		}
-		store, err = filestore.NewStore(ttl, maxSize)
+		maxStore := config.FileServer.MaxStoreSizeMB
+		store, err = filestore.NewStore(ttl, maxSize, maxStore)
 		if err != nil {
 			return nil, fmt.Errorf("failed to create file store: %w", err)
 		}
-		log.Info("File server enabled", "ttl", ttl, "max_size_mb", maxSize)
+		log.Info("File server enabled", "ttl", ttl, "max_size_mb", maxSize, "max_store_mb", maxStore)
+	}
+
+	fileBaseURL := config.FileServer.BaseURL
+	if fileBaseURL == "" {
+		fileBaseURL = fmt.Sprintf("http://localhost:%d", config.Port)
 	}
 
-	// Create issue handlers
-	issueHandlers := handlers.NewIssueHandlers(ytClient, wrappedToolLogger, contextTracker)
+	// Create issue handlers, resolving field values through the cached client
+	issueHandlers := handlers.NewIssueHandlers(ytClient, cachedClient, wrappedToolLogger, contextTracker)
+	issueHandlers.SetImageSources(config.YouTrack.BaseURL, store, fileBaseURL)
+	if config.Duplicates.Check {
+		issueHandlers.SetDuplicateCheck(config.Duplicates.MinScore, time.Duration(config.Duplicates.Days)*24*time.Hour)
+	}
 
 	// Create tag handlers
 	tagHandlers := handlers.NewTagHandlers(ytClient, wrappedToolLogger)
 
-	// Create comment handlers
-	commentHandlers := handlers.NewCommentHandlers(ytClient, wrappedToolLogger)
+	// Create vote handlers
+	voteHandlers := handlers.NewVoteHandlers(ytClient, wrappedToolLogger)
+
+	// Create notification handlers
+	notificationHandlers := handlers.NewNotificationHandlers(ytClient, wrappedToolLogger)
+
+	// Create comment handlers, resolving mentions through the cached client
+	commentHandlers := handlers.NewCommentHandlers(ytClient, cachedClient, wrappedToolLogger)
 
 	// Create health handlers
 	startTime := time.Now()
@@ -180,64 +328,131 @@ func NewMCPServer(config ServerConfig, toolLogger func(string, map[string]interf
 	// Create attachment handlers
 	var attachmentHandlers *handlers.AttachmentHandlers
 	if store != nil {
-		fileBaseURL := config.FileServer.BaseURL
-		if fileBaseURL == "" {
-			fileBaseURL = fmt.Sprintf("http://localhost:%d", config.Port)
-		}
-		attachmentHandlers = handlers.NewAttachmentHandlersWithFileStore(ytClient, wrappedToolLogger, store, fileBaseURL)
+		attachmentHandlers = handlers.NewAttachmentHandlersWithFileStore(ytClient, wrappedToolLogger, store, fileBaseURL, config.Attachments.InlineImageMaxKB*1024)
 	} else {
-		attachmentHandlers = handlers.NewAttachmentHandlers(ytClient, wrappedToolLogger)
+		attachmentHandlers = handlers.NewAttachmentHandlers(ytClient, wrappedToolLogger, config.Attachments.InlineImageMaxKB*1024)
 	}
 
 	// Create command handlers
-	commandHandlers := handlers.NewCommandHandlers(ytClient, wrappedToolLogger)
-
-	// Create worklog handlers
-	worklogHandlers := handlers.NewWorklogHandlers(ytClient, wrappedToolLogger)
+	commandHandlers := handlers.NewCommandHandlers(ytClient, cachedClient, wrappedToolLogger)
+
+	// Create worklog handlers. The configured key shares the timer of the yt CLI, each
+	// per-request key has its own.
+	timers := timer.NewStore(config.Timer.StateFile)
+	timerStore := func(ctx context.Context) *timer.Store {
+		if token := GetAuthToken(ctx); token != "" && token != config.YouTrack.APIKey {
+			return timers.ForKey(logging.HashAPIKey(token))
+		}
+		return timers
+	}
+	worklogHandlers := handlers.NewWorklogHandlers(ytClient, wrappedToolLogger, timerStore, timer.Rules{
+		MaxSession:   time.Duration(config.Timer.MaxSessionMinutes) * time.Minute,
+		RoundMinutes: config.Timer.RoundMinutes,
+	})
 
 	// Create cache handlers
-	cacheHandlers := handlers.NewCacheHandlers(projectCache, wrappedToolLogger)
+	cacheHandlers := handlers.NewCacheHandlers(projectCache, cachedClient, wrappedToolLogger)
+
+	// Create stats handlers, reading the counters of the app logger and the cache
+	statsHandlers := handlers.NewStatsHandlers(appLogger, projectCache, wrappedToolLogger, startTime)
+
+	// Create search handlers
+	searchHandlers := handlers.NewSearchHandlers(ytClient, cachedClient, wrappedToolLogger)
+
+	// Create pull request handlers
+	prHandlers := handlers.NewPullRequestHandlers(ytClient, cachedClient, config.Workflow.ReviewState, config.Workflow.ReviewStates, wrappedToolLogger)
+
+	// Create report handlers
+	reportHandlers := handlers.NewReportHandlers(ytClient, wrappedToolLogger, config.SLA)
+
+	// Create action handlers
+	actionHandlers := handlers.NewActionHandlers(actionLog, wrappedToolLogger)
+
+	// Create summary handlers
+	summaryHandlers := handlers.NewSummaryHandlers(ytClient, wrappedToolLogger, config.Sampling.MaxTokens)
 
 	return &MCPServer{
-		server:             s,
-		config:             config,
-		ytClient:           ytClient,
-		cachedClient:       cachedClient,
-		appLogger:          appLogger,
-		toolLogger:         toolLogger,
-		fileStore:          store,
-		issueHandlers:      issueHandlers,
-		tagHandlers:        tagHandlers,
-		commentHandlers:    commentHandlers,
-		healthHandlers:     healthHandlers,
-		projectHandlers:    projectHandlers,
-		userHandlers:       userHandlers,
-		linkHandlers:       linkHandlers,
-		attachmentHandlers: attachmentHandlers,
-		commandHandlers:    commandHandlers,
-		worklogHandlers:    worklogHandlers,
-		cacheHandlers:      cacheHandlers,
-		startTime:          startTime,
+		server:               s,
+		config:               config,
+		ytClient:             ytClient,
+		cachedClient:         cachedClient,
+		appLogger:            appLogger,
+		toolLogger:           toolLogger,
+		fileStore:            store,
+		issueHandlers:        issueHandlers,
+		tagHandlers:          tagHandlers,
+		voteHandlers:         voteHandlers,
+		notificationHandlers: notificationHandlers,
+		commentHandlers:      commentHandlers,
+		healthHandlers:       healthHandlers,
+		projectHandlers:      projectHandlers,
+		userHandlers:         userHandlers,
+		linkHandlers:         linkHandlers,
+		attachmentHandlers:   attachmentHandlers,
+		commandHandlers:      commandHandlers,
+		worklogHandlers:      worklogHandlers,
+		cacheHandlers:        cacheHandlers,
+		statsHandlers:        statsHandlers,
+		searchHandlers:       searchHandlers,
+		prHandlers:           prHandlers,
+		reportHandlers:       reportHandlers,
+		actionHandlers:       actionHandlers,
+		summaryHandlers:      summaryHandlers,
+		projectTracker:       contextTracker,
+		actionLog:            actionLog,
+		projectCache:         projectCache,
+		permissions:          permissions,
+		startTime:            startTime,
 	}, nil
 }
 
+// warmUpProjects returns the default project followed by the tracked projects, without duplicates
+func warmUpProjects(defaultProject string, tracked []string) []string {
+	var projects []string
+	seen := make(map[string]bool)
+	for _, projectID := range append([]string{defaultProject}, tracked...) {
+		if projectID == "" || seen[strings.ToUpper(projectID)] {
+			continue
+		}
+		seen[strings.ToUpper(projectID)] = true
+		projects = append(projects, projectID)
+	}
+	return projects
+}
+
 // GetAppLogger returns the app logger
 func (s *MCPServer) GetAppLogger() *logging.AppLogger {
 	return s.appLogger
 }
 
-// isBlacklisted checks if a tool name is in the blacklist
+// isBlacklisted checks if a tool name matches an entry of the blacklist
 func (s *MCPServer) isBlacklisted(toolName string) bool {
-	for _, name := range s.config.ToolBlacklist {
-		if name == toolName {
-			return true
-		}
-	}
-	return false
+	return s.inBlacklist(s.config.ToolBlacklist, toolName)
 }
 
 // addTool registers a tool if it's not blacklisted
 func (s *MCPServer) addTool(tool mcp.Tool, handler server.ToolHandlerFunc) {
+	if hasProjectArg(tool) {
+		handler = s.withProjectResolution(handler)
+		if isProjectRequired(tool) {
+			tool = withOptionalProject(tool)
+			handler = s.withSessionProject(handler)
+		}
+	}
+	if names, lists := toolIssueIDArgs(tool); len(names) > 0 || len(lists) > 0 {
+		tool = withIssueNumbers(tool, names, lists)
+		handler = s.withIssueIDNormalization(names, lists, handler)
+	}
+	if mutatingTools[tool.Name] {
+		handler = s.withActionLog(tool.Name, handler)
+	}
+	handler = s.withCallLog(tool.Name, handler)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// Blacklisted tools are kept to be registered when a reload removes them from the blacklist
+	s.tools = append(s.tools, server.ServerTool{Tool: tool, Handler: handler})
 	if s.isBlacklisted(tool.Name) {
 		log.Info("Tool blacklisted, skipping", "tool", tool.Name)
 		return
@@ -245,6 +460,48 @@ func (s *MCPServer) addTool(tool mcp.Tool, handler server.ToolHandlerFunc) {
 	s.server.AddTool(tool, handler)
 }
 
+// skipTool records a tool left out of the registration
+func (s *MCPServer) skipTool(name, reason string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.skippedTools == nil {
+		s.skippedTools = make(map[string]string)
+	}
+	s.skippedTools[name] = reason
+}
+
+// Tools returns the names of the registered tools and the reasons of the skipped ones,
+// after RegisterTools
+func (s *MCPServer) Tools() ([]string, map[string]string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var registered []string
+	skipped := make(map[string]string, len(s.skippedTools))
+	for name, reason := range s.skippedTools {
+		skipped[name] = reason
+	}
+	for _, tool := range s.tools {
+		if s.isBlacklisted(tool.Tool.Name) {
+			skipped[tool.Tool.Name] = "blacklisted in tools.blacklist"
+		} else {
+			registered = append(registered, tool.Tool.Name)
+		}
+	}
+	return registered, skipped
+}
+
+// addDestructiveTool registers a tool that deletes data, unless destructive tools are disabled
+func (s *MCPServer) addDestructiveTool(tool mcp.Tool, handler server.ToolHandlerFunc) {
+	if !s.config.AllowDestructive {
+		log.Info("Destructive tools disabled, skipping", "tool", tool.Name)
+		s.skipTool(tool.Name, "destructive tools are disabled by tools.allow_destructive")
+		return
+	}
+	s.addTool(tool, handler)
+}
+
 // RegisterTools registers all YouTrack-related tools with the MCP server
 func (s *MCPServer) RegisterTools() error {
 	// Resolve file server base URL for embedding in tool descriptions
@@ -256,37 +513,62 @@ func (s *MCPServer) RegisterTools() error {
 	// Register issue management tools
 	s.addTool(tools.GetIssueListTool(), s.issueHandlers.GetIssueListHandler)
 	s.addTool(tools.GetIssueDetailsTool(), s.issueHandlers.GetIssueDetailsHandler)
+	s.addTool(tools.GetIssueContextTool(), s.issueHandlers.GetIssueContextHandler)
+	s.addTool(tools.GetFieldHistoryTool(), s.issueHandlers.GetFieldHistoryHandler)
 	s.addTool(tools.CreateIssueTool(), s.issueHandlers.CreateIssueHandler)
+	s.addTool(tools.CreateEpicTool(), s.issueHandlers.CreateEpicHandler)
 	s.addTool(tools.UpdateIssueTool(), s.issueHandlers.UpdateIssueHandler)
-	s.addTool(tools.DeleteIssueTool(), s.issueHandlers.DeleteIssueHandler)
+	s.addTool(tools.BatchUpdateIssuesTool(), s.issueHandlers.BatchUpdateIssuesHandler)
+	s.addDestructiveTool(tools.DeleteIssueTool(), s.issueHandlers.DeleteIssueHandler)
+
+	// Register search tools
+	s.addTool(tools.SuggestQueryCompletionsTool(), s.searchHandlers.SuggestQueryCompletionsHandler)
+	s.addTool(tools.ExtractIssueIDsTool(), s.searchHandlers.ExtractIssueIDsHandler)
+	s.addTool(tools.FindSimilarIssuesTool(), s.searchHandlers.FindSimilarIssuesHandler)
 
 	// Register tag management tools
 	s.addTool(tools.TagIssueTool(), s.tagHandlers.TagIssueHandler)
 	s.addTool(tools.UntagIssueTool(), s.tagHandlers.UntagIssueHandler)
 	s.addTool(tools.SearchTagsTool(), s.tagHandlers.SearchTagsHandler)
 
+	// Register voting tools
+	s.addTool(tools.GetIssueVotersTool(), s.voteHandlers.GetIssueVotersHandler)
+	s.addTool(tools.VoteIssueTool(), s.voteHandlers.VoteIssueHandler)
+	s.addTool(tools.UnvoteIssueTool(), s.voteHandlers.UnvoteIssueHandler)
+
+	// Register notification tools
+	s.addTool(tools.GetNotificationsTool(), s.notificationHandlers.GetNotificationsHandler)
+
 	// Register comment management tools
 	s.addTool(tools.AddCommentTool(), s.commentHandlers.AddCommentHandler)
 
 	// Register project management tools
 	s.addTool(tools.GetProjectInfoTool(), s.projectHandlers.GetProjectInfoHandler)
 	s.addTool(tools.ListProjectsTool(), s.projectHandlers.ListProjectsHandler)
+	s.addTool(tools.GetProjectStatsTool(), s.projectHandlers.GetProjectStatsHandler)
+	s.addTool(tools.SetDefaultProjectTool(), s.projectHandlers.SetDefaultProjectHandler)
 
 	// Register user management tools
 	s.addTool(tools.GetCurrentUserTool(), s.userHandlers.GetCurrentUserHandler)
 	s.addTool(tools.GetProjectUsersTool(), s.userHandlers.GetProjectUsersHandler)
+	s.addTool(tools.SuggestAssigneeTool(), s.userHandlers.SuggestAssigneeHandler)
 
 	// Register link management tools
 	s.addTool(tools.GetIssueLinksTool(), s.linkHandlers.GetIssueLinksHandler)
 	s.addTool(tools.CreateIssueLinkTool(), s.linkHandlers.CreateIssueLinkHandler)
+	s.addTool(tools.GetIssueCommitsTool(), s.linkHandlers.GetIssueCommitsHandler)
+	s.addTool(tools.LinkPullRequestTool(), s.prHandlers.LinkPullRequestHandler)
 
 	// Register attachment management tools
 	s.addTool(tools.GetIssueAttachmentsTool(), s.attachmentHandlers.GetIssueAttachmentsHandler)
 	s.addTool(tools.GetIssueAttachmentContentTool(fileBaseURL), s.attachmentHandlers.GetIssueAttachmentContentHandler)
+	s.addDestructiveTool(tools.DeleteAttachmentTool(), s.attachmentHandlers.DeleteAttachmentHandler)
 
 	if fileBaseURL != "" {
 		// File server mode: upload via file_id, description includes full URL
 		s.addTool(tools.UploadAttachmentTool(fileBaseURL), s.attachmentHandlers.UploadAttachmentHandler)
+		s.addTool(tools.ListStoredFilesTool(), s.attachmentHandlers.ListStoredFilesHandler)
+		s.addTool(tools.DeleteStoredFileTool(), s.attachmentHandlers.DeleteStoredFileHandler)
 	} else {
 		// No file server: register base64 upload tool
 		s.addTool(tools.UploadAttachmentBase64Tool(), s.attachmentHandlers.UploadAttachmentHandler)
@@ -299,9 +581,30 @@ func (s *MCPServer) RegisterTools() error {
 	s.addTool(tools.AddWorklogTool(), s.worklogHandlers.AddWorklogHandler)
 	s.addTool(tools.GetIssueWorklogsTool(), s.worklogHandlers.GetIssueWorklogsHandler)
 	s.addTool(tools.GetUserWorklogsTool(), s.worklogHandlers.GetUserWorklogsHandler)
+	s.addTool(tools.StartTimerTool(), s.worklogHandlers.StartTimerHandler)
+	s.addTool(tools.StopTimerTool(), s.worklogHandlers.StopTimerHandler)
+
+	// Register report tools
+	s.addTool(tools.GenerateReleaseNotesTool(), s.reportHandlers.GenerateReleaseNotesHandler)
+	s.addTool(tools.CheckSLATool(), s.reportHandlers.CheckSLAHandler)
+
+	// Register sampling tools, the client model does the work
+	if s.config.Sampling.Enabled {
+		s.addTool(tools.SummarizeIssueThreadTool(), s.summaryHandlers.SummarizeIssueThreadHandler)
+	} else {
+		s.skipTool("summarize_issue_thread", "sampling tools are disabled by sampling.enabled")
+	}
+
+	// Register session tools
+	s.addTool(tools.GetRecentActionsTool(), s.actionHandlers.GetRecentActionsHandler)
 
 	// Register cache management tools
 	s.addTool(tools.DropCacheTool(), s.cacheHandlers.DropCacheHandler)
+	s.addTool(tools.RefreshCacheTool(), s.cacheHandlers.RefreshCacheHandler)
+	s.addTool(tools.GetServerStatsTool(), s.statsHandlers.GetServerStatsHandler)
+
+	// Register the macro tools of the config
+	s.registerMacros()
 
 	return nil
 }
@@ -348,10 +651,15 @@ func (s *MCPServer) ServeHTTP() error {
 	// Add health endpoint
 	http.HandleFunc("/health", s.healthHandlers.HealthCheckHTTPHandler)
 
+	// Add stats endpoint, for the keys whose profile allows get_server_stats
+	http.Handle("/stats", AuthMiddleware(s.permissions.RequireTool("get_server_stats", s.statsHandlers.StatsHTTPHandler)))
+
 	// Add file server routes if enabled
 	if s.fileStore != nil {
-		http.HandleFunc("/mcpfiles/", filestore.ServeFile(s.fileStore))
-		http.HandleFunc("/mcpfiles", filestore.UploadFile(s.fileStore))
+		// Files belong to the API key that stored them, the one of the request or the configured one
+		owner := func(r *http.Request) string { return s.ytClient.GetKeyHash(r.Context()) }
+		http.Handle("/mcpfiles/", AuthMiddleware(filestore.ServeFile(s.fileStore, owner)))
+		http.Handle("/mcpfiles", AuthMiddleware(filestore.UploadFile(s.fileStore, owner)))
 		log.Info("File server routes registered on MCP port")
 	}
 
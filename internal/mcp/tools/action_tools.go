@@ -0,0 +1,15 @@
+package tools
+
+import (
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// GetRecentActionsTool returns the MCP tool definition for listing the actions of the session
+func GetRecentActionsTool() mcp.Tool {
+	return mcp.NewTool("get_recent_actions",
+		mcp.WithDescription("List the last changes made through this MCP session (issues created or updated, comments, tags, links, worklogs, commands...) with their arguments and results, most recent first. Use it to recall what was already done instead of re-querying YouTrack."),
+		mcp.WithNumber("max_results",
+			mcp.Description("Maximum number of actions to return (optional, defaults to 10)"),
+		),
+	)
+}
@@ -9,11 +9,12 @@ import (
 // GetIssueAttachmentsTool returns the MCP tool definition for listing issue attachments
 func GetIssueAttachmentsTool() mcp.Tool {
 	return mcp.NewTool("get_issue_attachments",
-		mcp.WithDescription("List all attachments for a specific issue with metadata (name, size, mime type)"),
+		mcp.WithDescription("List all attachments for a specific issue with metadata (name, size, mime type, image dimensions and thumbnail URL)"),
 		mcp.WithString("issue_id",
 			mcp.Required(),
 			mcp.Description("Issue ID to retrieve attachments for"),
 		),
+		includeImagesParam(),
 	)
 }
 
@@ -37,6 +38,7 @@ func GetIssueAttachmentContentTool(fileBaseURL string) mcp.Tool {
 				mcp.Required(),
 				mcp.Description("Attachment ID to download"),
 			),
+			includeImagesParam(),
 		)
 	}
 
@@ -50,6 +52,14 @@ func GetIssueAttachmentContentTool(fileBaseURL string) mcp.Tool {
 			mcp.Required(),
 			mcp.Description("Attachment ID to download"),
 		),
+		includeImagesParam(),
+	)
+}
+
+// includeImagesParam is the option to return image attachments as image content
+func includeImagesParam() mcp.ToolOption {
+	return mcp.WithBoolean("include_images",
+		mcp.Description("Also return image attachments (screenshots) as image content, if they are within the server's size limit (optional, defaults to false)"),
 	)
 }
 
@@ -98,3 +108,36 @@ func UploadAttachmentBase64Tool() mcp.Tool {
 		),
 	)
 }
+
+// DeleteAttachmentTool returns the MCP tool definition for deleting an issue attachment
+func DeleteAttachmentTool() mcp.Tool {
+	return mcp.NewTool("delete_attachment",
+		mcp.WithDescription("Delete an attachment from an issue. The file is removed permanently; use get_issue_attachments to find the attachment ID"),
+		mcp.WithString("issue_id",
+			mcp.Required(),
+			mcp.Description("Issue ID the attachment belongs to"),
+		),
+		mcp.WithString("attachment_id",
+			mcp.Required(),
+			mcp.Description("Attachment ID to delete"),
+		),
+	)
+}
+
+// ListStoredFilesTool returns the MCP tool definition for listing files held by the file server
+func ListStoredFilesTool() mcp.Tool {
+	return mcp.NewTool("list_stored_files",
+		mcp.WithDescription("List your temporary files held by the file server (uploads waiting to be attached and downloaded attachments), with their size and expiry"),
+	)
+}
+
+// DeleteStoredFileTool returns the MCP tool definition for removing a file from the file server
+func DeleteStoredFileTool() mcp.Tool {
+	return mcp.NewTool("delete_stored_file",
+		mcp.WithDescription("Delete one of your temporary files from the file server before it expires. Does not affect YouTrack attachments"),
+		mcp.WithString("file_id",
+			mcp.Required(),
+			mcp.Description("File ID from list_stored_files or the file server upload response"),
+		),
+	)
+}
@@ -7,9 +7,19 @@ import (
 // DropCacheTool returns the MCP tool definition for dropping cached project metadata
 func DropCacheTool() mcp.Tool {
 	return mcp.NewTool("drop_cache",
-		mcp.WithDescription("Drop cached project metadata (custom fields, users). Use to force refresh of cached data."),
+		mcp.WithDescription("Drop cached project metadata (custom fields, allowed values, users, link types). Use to force refresh of cached data."),
 		mcp.WithString("project_id",
 			mcp.Description("Project ID to drop cache for. If empty, drops cache for all projects."),
 		),
 	)
 }
+
+// RefreshCacheTool returns the MCP tool definition for refreshing cached project metadata
+func RefreshCacheTool() mcp.Tool {
+	return mcp.NewTool("refresh_cache",
+		mcp.WithDescription("Refresh cached project metadata (custom fields, allowed values, users). Use when project configuration changed and field values fail to resolve."),
+		mcp.WithString("project_id",
+			mcp.Description("Project ID to refresh. The cache is dropped and re-fetched immediately. If empty, drops cache for all projects."),
+		),
+	)
+}
@@ -7,7 +7,7 @@ import (
 // AddCommentTool returns the MCP tool definition for adding comments to issues
 func AddCommentTool() mcp.Tool {
 	return mcp.NewTool("add_comment",
-		mcp.WithDescription("Add a comment to an issue in YouTrack"),
+		mcp.WithDescription("Add a comment to an issue in YouTrack. @mentions may use a name, e.g. @john or @smith: they are resolved against the project users and rewritten to @login, with a warning for the ones no single user matches"),
 		mcp.WithString("issue_id",
 			mcp.Required(),
 			mcp.Description("Issue ID to add the comment to"),
@@ -16,5 +16,8 @@ func AddCommentTool() mcp.Tool {
 			mcp.Required(),
 			mcp.Description("Comment text to add to the issue"),
 		),
+		mcp.WithString("reply_to",
+			mcp.Description("ID of a comment of the issue to reply to, placing the comment in its thread (optional)"),
+		),
 	)
 }
@@ -4,6 +4,9 @@ import (
 	"github.com/mark3labs/mcp-go/mcp"
 )
 
+// issueFieldsHelp lists the values of the fields argument of the issue tools
+const issueFieldsHelp = "Fields: id, internal_id, summary, description, state, assignee, assignee.login, assignee.full_name, reporter, reporter.login, reporter.full_name, created, updated, resolved, tags, votes, visible_to, custom_fields; any other name selects that custom field (e.g. Priority)."
+
 // GetIssueListTool returns the MCP tool definition for listing issues
 func GetIssueListTool() mcp.Tool {
 	return mcp.NewTool("get_issue_list",
@@ -24,6 +27,11 @@ func GetIssueListTool() mcp.Tool {
 		mcp.WithString("sort_order",
 			mcp.Description("Sort order: 'asc' or 'desc' (optional, defaults to 'desc')"),
 		),
+		mcp.WithArray("fields",
+			mcp.Description("Return only these fields, e.g. [\"summary\", \"state\", \"assignee.login\"]. "+issueFieldsHelp+" (optional, all default fields when omitted)"),
+			mcp.WithStringItems(),
+		),
+		mcp.WithOutputSchema[IssueListOutput](),
 	)
 }
 
@@ -35,6 +43,14 @@ func GetIssueDetailsTool() mcp.Tool {
 			mcp.Required(),
 			mcp.Description("Issue ID to retrieve details for"),
 		),
+		mcp.WithArray("fields",
+			mcp.Description("Return only these fields, e.g. [\"summary\", \"description\", \"comments\"]. "+issueFieldsHelp+" Also: comments, images (optional, all details when omitted)"),
+			mcp.WithStringItems(),
+		),
+		mcp.WithBoolean("raw_description",
+			mcp.Description("Return the description as stored; by default legacy HTML and wiki descriptions are converted to Markdown (optional)"),
+		),
+		mcp.WithOutputSchema[IssueDetailsOutput](),
 	)
 }
 
@@ -53,6 +69,44 @@ func CreateIssueTool() mcp.Tool {
 		mcp.WithString("description",
 			mcp.Description("Issue description (optional)"),
 		),
+		mcp.WithString("visibility",
+			mcp.Description("Limit who can see the issue: comma-separated group names and users prefixed with 'user:' (e.g. 'Developers, user:john') (optional)"),
+		),
+		mcp.WithBoolean("force",
+			mcp.Description("Create the issue even when open issues with a similar summary exist (optional, defaults to false). Only set it after checking the reported candidates"),
+		),
+	)
+}
+
+// GetIssueContextTool returns the MCP tool definition for the aggregated issue context
+func GetIssueContextTool() mcp.Tool {
+	return mcp.NewTool("get_issue_context",
+		mcp.WithDescription("Get everything needed to understand an issue in one call: details, custom fields, description, links, recent activity and the latest comments, as one compact markdown document. Prefer this over calling get_issue_details, get_issue_links and the activity tools separately"),
+		mcp.WithString("issue_id",
+			mcp.Required(),
+			mcp.Description("Issue ID to summarize"),
+		),
+		mcp.WithNumber("comments",
+			mcp.Description("Number of latest comments to include (optional, defaults to 5, 0 to skip comments)"),
+		),
+		mcp.WithNumber("activities",
+			mcp.Description("Number of latest activity entries to include (optional, defaults to 10, 0 to skip activity)"),
+		),
+	)
+}
+
+// GetFieldHistoryTool returns the MCP tool definition for the change history of one issue field
+func GetFieldHistoryTool() mcp.Tool {
+	return mcp.NewTool("get_field_history",
+		mcp.WithDescription("Get the changes of one field of an issue (e.g. State, Assignee, Priority, summary) with the time, the author and the old and new values, oldest first. Use it to answer questions like \"when did this go to In Progress\" in one call"),
+		mcp.WithString("issue_id",
+			mcp.Required(),
+			mcp.Description("Issue ID"),
+		),
+		mcp.WithString("field",
+			mcp.Required(),
+			mcp.Description("Field name, matched case-insensitively (e.g. State)"),
+		),
 	)
 }
 
@@ -87,5 +141,65 @@ func UpdateIssueTool() mcp.Tool {
 		mcp.WithString("description",
 			mcp.Description("New description for the issue (optional)"),
 		),
+		mcp.WithString("visibility",
+			mcp.Description("Limit who can see the issue: comma-separated group names and users prefixed with 'user:' (e.g. 'Developers, user:john'), or 'public' to remove restrictions (optional)"),
+		),
+	)
+}
+
+// BatchUpdateIssuesTool returns the MCP tool definition for updating and commenting on several issues
+func BatchUpdateIssuesTool() mcp.Tool {
+	return mcp.NewTool("batch_update_issues",
+		mcp.WithDescription("Update and comment on several issues in one call. The operations run a few at a time and are retried when YouTrack is briefly unavailable; a failed operation does not stop the others. Returns the outcome of every operation"),
+		mcp.WithArray("operations",
+			mcp.Required(),
+			mcp.Description("Operations to run, up to 50. Each has a type ('update' or 'comment') and an issue_id; an update sets any of summary, description, state and assignee (resolved like update_issue), a comment has a text"),
+			mcp.Items(map[string]any{
+				"type": "object",
+				"properties": map[string]any{
+					"type":        map[string]any{"type": "string", "enum": []string{"update", "comment"}, "description": "Operation type"},
+					"issue_id":    map[string]any{"type": "string", "description": "Issue ID, e.g. PRJ-123"},
+					"summary":     map[string]any{"type": "string", "description": "New summary (update)"},
+					"description": map[string]any{"type": "string", "description": "New description (update)"},
+					"state":       map[string]any{"type": "string", "description": "New state (update)"},
+					"assignee":    map[string]any{"type": "string", "description": "New assignee login or name (update)"},
+					"text":        map[string]any{"type": "string", "description": "Comment text (comment)"},
+				},
+				"required": []string{"type", "issue_id"},
+			}),
+		),
+	)
+}
+
+// CreateEpicTool returns the MCP tool definition for creating an epic with its subtasks
+func CreateEpicTool() mcp.Tool {
+	return mcp.NewTool("create_epic",
+		mcp.WithDescription("Create an epic and its subtasks in one call: the parent issue, then each subtask linked to it as 'subtask of'. If any step fails, the issues already created are deleted again, so no partial plan is left. Returns the created tree"),
+		mcp.WithString("project_id",
+			mcp.Required(),
+			mcp.Description("Project ID where the issues should be created"),
+		),
+		mcp.WithString("summary",
+			mcp.Required(),
+			mcp.Description("Epic summary/title"),
+		),
+		mcp.WithString("description",
+			mcp.Description("Epic description (optional)"),
+		),
+		mcp.WithString("type",
+			mcp.Description("Value of the Type field of the epic, e.g. 'Epic' (optional, the project default when omitted)"),
+		),
+		mcp.WithArray("subtasks",
+			mcp.Required(),
+			mcp.Description("Subtasks to create under the epic, in order, up to 50"),
+			mcp.Items(map[string]any{
+				"type": "object",
+				"properties": map[string]any{
+					"summary":     map[string]any{"type": "string", "description": "Subtask summary/title"},
+					"description": map[string]any{"type": "string", "description": "Subtask description"},
+				},
+				"required": []string{"summary"},
+			}),
+		),
 	)
 }
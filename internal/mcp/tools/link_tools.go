@@ -15,6 +15,17 @@ func GetIssueLinksTool() mcp.Tool {
 	)
 }
 
+// GetIssueCommitsTool returns the MCP tool definition for getting the commits linked to an issue
+func GetIssueCommitsTool() mcp.Tool {
+	return mcp.NewTool("get_issue_commits",
+		mcp.WithDescription("Get the VCS commits linked to a specific issue, oldest first"),
+		mcp.WithString("issue_id",
+			mcp.Required(),
+			mcp.Description("Issue ID to retrieve commits for"),
+		),
+	)
+}
+
 // CreateIssueLinkTool returns the MCP tool definition for creating an issue link
 func CreateIssueLinkTool() mcp.Tool {
 	return mcp.NewTool("create_issue_link",
@@ -0,0 +1,21 @@
+package tools
+
+import (
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// GetNotificationsTool returns the MCP tool definition for reading the current user's notifications
+func GetNotificationsTool() mcp.Tool {
+	return mcp.NewTool("get_notifications",
+		mcp.WithDescription("Get the current user's recent YouTrack notifications: changes to issues the user is mentioned in or subscribed to by a tag or saved search, with the reason and the changed fields. Useful to triage what needs attention."),
+		mcp.WithBoolean("unread_only",
+			mcp.Description("Only return unread notifications (optional, defaults to true)"),
+		),
+		mcp.WithNumber("max_results",
+			mcp.Description("Maximum number of notifications to look at, most recent first (optional, defaults to 20)"),
+		),
+		mcp.WithBoolean("mark_read",
+			mcp.Description("Mark the returned notifications read (optional, defaults to false)"),
+		),
+	)
+}
@@ -0,0 +1,102 @@
+package tools
+
+// Structured results of the tools that declare an output schema. The handlers return
+// them as structuredContent next to the text rendering, which stays for clients
+// that only read text content. Times are RFC 3339.
+
+// IssueOutput is an issue in structured results
+type IssueOutput struct {
+	ID           string             `json:"id"`
+	InternalID   string             `json:"internal_id,omitempty" jsonschema_description:"Database ID, e.g. 2-123"`
+	Summary      string             `json:"summary,omitempty"`
+	State        string             `json:"state,omitempty"`
+	Assignee     string             `json:"assignee,omitempty" jsonschema_description:"Assignee login"`
+	AssigneeName string             `json:"assignee_name,omitempty" jsonschema_description:"Assignee full name, with the assignee.full_name field"`
+	Reporter     string             `json:"reporter,omitempty" jsonschema_description:"Reporter login"`
+	ReporterName string             `json:"reporter_name,omitempty" jsonschema_description:"Reporter full name, with the reporter.full_name field"`
+	Created      string             `json:"created,omitempty"`
+	Updated      string             `json:"updated,omitempty"`
+	Resolved     string             `json:"resolved,omitempty"`
+	Tags         []string           `json:"tags,omitempty"`
+	Votes        int                `json:"votes,omitempty"`
+	CustomFields []FieldValueOutput `json:"custom_fields,omitempty"`
+}
+
+// IssueListOutput is the structured result of get_issue_list
+type IssueListOutput struct {
+	Issues []IssueOutput `json:"issues"`
+	Total  int           `json:"total"`
+}
+
+// IssueDetailsOutput is the structured result of get_issue_details
+type IssueDetailsOutput struct {
+	IssueOutput
+	Description string          `json:"description,omitempty"`
+	VisibleTo   string          `json:"visible_to,omitempty" jsonschema_description:"Groups and users the issue is limited to"`
+	Comments    []CommentOutput `json:"comments,omitempty"`
+	Images      []ImageOutput   `json:"images,omitempty" jsonschema_description:"Images embedded in the description and comments"`
+}
+
+// ImageOutput is an image embedded in an issue description or comment
+type ImageOutput struct {
+	Name         string `json:"name"`
+	Source       string `json:"source" jsonschema_description:"Where the image is embedded: description, or comment <id>"`
+	URL          string `json:"url" jsonschema_description:"URL to display the image"`
+	AttachmentID string `json:"attachment_id,omitempty" jsonschema_description:"Set when the image is an issue attachment"`
+	MimeType     string `json:"mime_type,omitempty"`
+}
+
+// FieldValueOutput is a custom field value formatted for display
+type FieldValueOutput struct {
+	Name  string `json:"name"`
+	Value string `json:"value" jsonschema_description:"Display value, empty when the field is not set"`
+}
+
+// CommentOutput is an issue comment in structured results
+type CommentOutput struct {
+	ID             string                `json:"id"`
+	Author         string                `json:"author,omitempty"`
+	Created        string                `json:"created"`
+	Text           string                `json:"text"`
+	ReplyTo        string                `json:"reply_to,omitempty" jsonschema_description:"ID of the comment this one replies to, in its thread"`
+	Reactions      []ReactionOutput      `json:"reactions,omitempty"`
+	ReactionCounts []ReactionCountOutput `json:"reaction_counts,omitempty" jsonschema_description:"Reactions counted by kind, most frequent first"`
+}
+
+// ReactionCountOutput is the number of reactions of one kind to a comment
+type ReactionCountOutput struct {
+	Reaction string `json:"reaction"`
+	Count    int    `json:"count"`
+}
+
+// ReactionOutput is a reaction of a user to a comment
+type ReactionOutput struct {
+	Reaction string `json:"reaction" jsonschema_description:"Reaction name, e.g. thumbs-up"`
+	Author   string `json:"author,omitempty" jsonschema_description:"Login of the user who reacted"`
+}
+
+// CurrentUserOutput is the structured result of get_current_user
+type CurrentUserOutput struct {
+	ID             string `json:"id"`
+	Login          string `json:"login"`
+	FullName       string `json:"full_name,omitempty"`
+	Email          string `json:"email,omitempty"`
+	DefaultProject string `json:"default_project,omitempty" jsonschema_description:"Project from the server configuration"`
+	SessionProject string `json:"session_project,omitempty" jsonschema_description:"Project used when project_id is omitted"`
+}
+
+// ProjectInfoOutput is the structured result of get_project_info
+type ProjectInfoOutput struct {
+	ShortName    string               `json:"short_name"`
+	Name         string               `json:"name"`
+	Description  string               `json:"description,omitempty"`
+	CustomFields []ProjectFieldOutput `json:"custom_fields"`
+	LinkTypes    []string             `json:"link_types,omitempty"`
+}
+
+// ProjectFieldOutput is a project custom field with its allowed values
+type ProjectFieldOutput struct {
+	Name          string   `json:"name"`
+	Type          string   `json:"type"`
+	AllowedValues []string `json:"allowed_values,omitempty"`
+}
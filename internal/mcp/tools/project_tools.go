@@ -12,6 +12,18 @@ func GetProjectInfoTool() mcp.Tool {
 			mcp.Required(),
 			mcp.Description("Project ID (short name) to retrieve info for"),
 		),
+		mcp.WithOutputSchema[ProjectInfoOutput](),
+	)
+}
+
+// SetDefaultProjectTool returns the MCP tool definition for pinning the session project
+func SetDefaultProjectTool() mcp.Tool {
+	return mcp.NewTool("set_default_project",
+		mcp.WithDescription("Pin the project used by this session: tools that need a project use it when project_id is omitted"),
+		mcp.WithString("project",
+			mcp.Required(),
+			mcp.Description("Project short name, ID or name to use as the session default"),
+		),
 	)
 }
 
@@ -24,3 +36,20 @@ func ListProjectsTool() mcp.Tool {
 		),
 	)
 }
+
+// GetProjectStatsTool returns the MCP tool definition for project issue statistics
+func GetProjectStatsTool() mcp.Tool {
+	return mcp.NewTool("get_project_stats",
+		mcp.WithDescription("Get issue statistics of a project: open and resolved counts, issues by state and open issues by priority, issues created and resolved in the last days, and the assignees with the most open issues. Uses count-only queries, cheap enough for status summaries"),
+		mcp.WithString("project_id",
+			mcp.Required(),
+			mcp.Description("Project ID (short name) or project name"),
+		),
+		mcp.WithNumber("days",
+			mcp.Description("Period in days of the created/resolved counts (optional, default 30)"),
+		),
+		mcp.WithNumber("top_assignees",
+			mcp.Description("Number of assignees to list (optional, default 5)"),
+		),
+	)
+}
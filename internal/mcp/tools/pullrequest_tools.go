@@ -0,0 +1,23 @@
+package tools
+
+import (
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// LinkPullRequestTool returns the MCP tool definition for linking a pull request to an issue
+func LinkPullRequestTool() mcp.Tool {
+	return mcp.NewTool("link_pull_request",
+		mcp.WithDescription("Link a GitHub, GitLab or Bitbucket pull request to an issue: posts a comment with the PR link and moves the issue to the configured review state"),
+		mcp.WithString("issue_id",
+			mcp.Required(),
+			mcp.Description("Issue ID to link the pull request to"),
+		),
+		mcp.WithString("pr_url",
+			mcp.Required(),
+			mcp.Description("Pull request or merge request URL"),
+		),
+		mcp.WithString("state",
+			mcp.Description("State to move the issue to (optional, defaults to the configured review state; 'none' keeps the current state)"),
+		),
+	)
+}
@@ -0,0 +1,48 @@
+package tools
+
+import (
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// GenerateReleaseNotesTool returns the MCP tool definition for generating release notes
+func GenerateReleaseNotesTool() mcp.Tool {
+	return mcp.NewTool("generate_release_notes",
+		mcp.WithDescription("Generate Markdown release notes from the issues matching a query, grouped by a custom field (Type by default)"),
+		mcp.WithString("project_id",
+			mcp.Required(),
+			mcp.Description("Project ID (short name) or project name to generate release notes for"),
+		),
+		mcp.WithString("query",
+			mcp.Description("YouTrack search query selecting the issues, e.g. 'Fix versions: 2024.2 #Resolved' (optional, defaults to '#Resolved')"),
+		),
+		mcp.WithString("group_by",
+			mcp.Description("Custom field to group issues by (optional, defaults to 'Type')"),
+		),
+		mcp.WithString("order",
+			mcp.Description("Comma-separated group order; other groups follow alphabetically (optional, defaults to 'Feature,Bug,Task')"),
+		),
+		mcp.WithString("title",
+			mcp.Description("Title of the release notes (optional)"),
+		),
+	)
+}
+
+// CheckSLATool returns the MCP tool definition for checking issues against the SLA policy
+func CheckSLATool() mcp.Tool {
+	return mcp.NewTool("check_sla",
+		mcp.WithDescription("Check issues against the configured SLA policy: time to the first response (first comment by someone other than the reporter) and to the resolution, with targets per priority. Flags breached issues"),
+		mcp.WithString("project_id",
+			mcp.Required(),
+			mcp.Description("Project ID (short name) or project name to check"),
+		),
+		mcp.WithString("query",
+			mcp.Description("YouTrack search query selecting the issues, e.g. 'created: {last month}' (optional, defaults to '#Unresolved')"),
+		),
+		mcp.WithBoolean("breached_only",
+			mcp.Description("List only the issues with a breached target (optional, defaults to false)"),
+		),
+		mcp.WithNumber("limit",
+			mcp.Description("Maximum number of issues to check, oldest first (optional, defaults to 50, max 200)"),
+		),
+	)
+}
@@ -0,0 +1,57 @@
+package tools
+
+import (
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// SuggestQueryCompletionsTool returns the MCP tool definition for YouTrack query autocompletion
+func SuggestQueryCompletionsTool() mcp.Tool {
+	return mcp.NewTool("suggest_query_completions",
+		mcp.WithDescription("Suggest completions for a partial YouTrack search query (field names, values, keywords). Use it to build valid queries for get_issue_list"),
+		mcp.WithString("query",
+			mcp.Description("Partial YouTrack query to complete, e.g. 'State: In' (optional, empty returns top-level suggestions)"),
+		),
+		mcp.WithNumber("caret",
+			mcp.Description("Cursor position in the query, in characters (optional, defaults to the end of the query)"),
+		),
+		mcp.WithNumber("max_results",
+			mcp.Description("Maximum number of suggestions to return (optional, defaults to 20)"),
+		),
+	)
+}
+
+// ExtractIssueIDsTool returns the MCP tool definition for finding issue references in free text
+func ExtractIssueIDsTool() mcp.Tool {
+	return mcp.NewTool("extract_issue_ids",
+		mcp.WithDescription("Find issue IDs (e.g. PROJ-123) mentioned in free text such as commit messages or PR descriptions, and return the referenced issues with summaries and states. Useful for changelogs"),
+		mcp.WithString("text",
+			mcp.Required(),
+			mcp.Description("Text to scan for issue IDs"),
+		),
+		mcp.WithString("projects",
+			mcp.Description("Comma-separated project short names to match (optional, defaults to all accessible projects)"),
+		),
+	)
+}
+
+// FindSimilarIssuesTool returns the MCP tool definition for finding likely duplicates
+func FindSimilarIssuesTool() mcp.Tool {
+	return mcp.NewTool("find_similar_issues",
+		mcp.WithDescription("Find issues that may duplicate an existing issue or a planned one, by searching for summary keywords across projects and ranking the results by keyword overlap. Call it before create_issue to avoid duplicates"),
+		mcp.WithString("issue_id",
+			mcp.Description("Issue to find duplicates of; its summary is searched for and it is left out of the results (optional if text is given)"),
+		),
+		mcp.WithString("text",
+			mcp.Description("Summary or short description to search for, e.g. the summary of an issue about to be created (optional if issue_id is given)"),
+		),
+		mcp.WithString("project_id",
+			mcp.Description("Only search this project (short name or name); all projects by default"),
+		),
+		mcp.WithBoolean("unresolved_only",
+			mcp.Description("Only return unresolved issues (optional, defaults to false)"),
+		),
+		mcp.WithNumber("max_results",
+			mcp.Description("Maximum number of candidates to return (optional, defaults to 10)"),
+		),
+	)
+}
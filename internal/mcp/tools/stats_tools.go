@@ -0,0 +1,12 @@
+package tools
+
+import (
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// GetServerStatsTool returns the MCP tool definition for reading the server usage counters
+func GetServerStatsTool() mcp.Tool {
+	return mcp.NewTool("get_server_stats",
+		mcp.WithDescription("Get the usage counters of the MCP server since it started: uptime, tool calls and failures by tool, YouTrack REST calls and error responses, and the cache hits, misses and hit ratio by kind of metadata."),
+	)
+}
@@ -0,0 +1,22 @@
+package tools
+
+import (
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// SummarizeIssueThreadTool returns the MCP tool definition for summarizing the discussion of an issue
+func SummarizeIssueThreadTool() mcp.Tool {
+	return mcp.NewTool("summarize_issue_thread",
+		mcp.WithDescription("Summarize the description and comment thread of an issue with the client model, through MCP sampling. The client may ask the user to approve the request. With post_back, the summary is added to the issue as a comment."),
+		mcp.WithString("issue_id",
+			mcp.Required(),
+			mcp.Description("Issue ID to summarize"),
+		),
+		mcp.WithString("focus",
+			mcp.Description("What the summary should focus on, e.g. 'decisions and open questions' (default: the problem, decisions, open questions and next steps)"),
+		),
+		mcp.WithBoolean("post_back",
+			mcp.Description("Add the summary to the issue as a comment (default: false)"),
+		),
+	)
+}
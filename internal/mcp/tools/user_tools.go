@@ -7,7 +7,8 @@ import (
 // GetCurrentUserTool returns the MCP tool definition for getting the current user
 func GetCurrentUserTool() mcp.Tool {
 	return mcp.NewTool("get_current_user",
-		mcp.WithDescription("Get the authenticated user's profile information including default project"),
+		mcp.WithDescription("Get the authenticated user's login, full name, email and default project. Use the login (or 'me') to build queries such as 'Assignee: me' or 'Reporter: <login>'"),
+		mcp.WithOutputSchema[CurrentUserOutput](),
 	)
 }
 
@@ -21,3 +22,26 @@ func GetProjectUsersTool() mcp.Tool {
 		),
 	)
 }
+
+// SuggestAssigneeTool returns the MCP tool definition for suggesting the least loaded assignee
+func SuggestAssigneeTool() mcp.Tool {
+	return mcp.NewTool("suggest_assignee",
+		mcp.WithDescription("Suggest who to assign a new issue to: list project members sorted by their current open issues (of the given type first), optionally by recently logged time, least loaded first. Use it to distribute work evenly when triaging"),
+		mcp.WithString("project_id",
+			mcp.Required(),
+			mcp.Description("Project ID (short name) to suggest an assignee in"),
+		),
+		mcp.WithString("issue_type",
+			mcp.Description("Type of the issue to assign, e.g. 'Bug'; members are ordered by their open issues of this type first (optional)"),
+		),
+		mcp.WithString("type_field",
+			mcp.Description("Custom field holding the issue type (optional, defaults to 'Type')"),
+		),
+		mcp.WithNumber("worklog_days",
+			mcp.Description("Also consider the time members logged in the project in the last N days (optional, slower)"),
+		),
+		mcp.WithNumber("limit",
+			mcp.Description("Maximum number of members listed (optional, defaults to 10)"),
+		),
+	)
+}
@@ -0,0 +1,38 @@
+package tools
+
+import (
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// GetIssueVotersTool returns the MCP tool definition for reading the votes of an issue
+func GetIssueVotersTool() mcp.Tool {
+	return mcp.NewTool("get_issue_voters",
+		mcp.WithDescription("Get the vote count of an issue and the users who voted for it or for one of its duplicates. Useful to gauge demand for feature requests."),
+		mcp.WithString("issue_id",
+			mcp.Required(),
+			mcp.Description("Issue ID to get the votes for"),
+		),
+	)
+}
+
+// VoteIssueTool returns the MCP tool definition for voting for an issue
+func VoteIssueTool() mcp.Tool {
+	return mcp.NewTool("vote_issue",
+		mcp.WithDescription("Vote for an issue as the current user"),
+		mcp.WithString("issue_id",
+			mcp.Required(),
+			mcp.Description("Issue ID to vote for"),
+		),
+	)
+}
+
+// UnvoteIssueTool returns the MCP tool definition for removing a vote from an issue
+func UnvoteIssueTool() mcp.Tool {
+	return mcp.NewTool("unvote_issue",
+		mcp.WithDescription("Remove the current user's vote from an issue"),
+		mcp.WithString("issue_id",
+			mcp.Required(),
+			mcp.Description("Issue ID to remove the vote from"),
+		),
+	)
+}
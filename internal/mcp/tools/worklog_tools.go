@@ -25,6 +25,9 @@ func AddWorklogTool() mcp.Tool {
 		mcp.WithString("work_type",
 			mcp.Description("Type of work (e.g., 'Development', 'Testing', 'Documentation') (optional)"),
 		),
+		mcp.WithString("author",
+			mcp.Description("Login of the user to log the work for (optional, defaults to the token owner). Logging work for another user needs the 'Update Work Item' permission."),
+		),
 	)
 }
 
@@ -57,3 +60,36 @@ func GetUserWorklogsTool() mcp.Tool {
 		),
 	)
 }
+
+// StartTimerTool returns the MCP tool definition for starting the worklog timer
+func StartTimerTool() mcp.Tool {
+	return mcp.NewTool("start_timer",
+		mcp.WithDescription("Start a timer to track work on an issue; stop_timer logs the elapsed time as a worklog. Only one timer runs at a time, and it is shared with the yt CLI ('yt timer')"),
+		mcp.WithString("issue_id",
+			mcp.Required(),
+			mcp.Description("Issue ID to track work on"),
+		),
+	)
+}
+
+// StopTimerTool returns the MCP tool definition for stopping the worklog timer
+func StopTimerTool() mcp.Tool {
+	return mcp.NewTool("stop_timer",
+		mcp.WithDescription("Stop the running timer and log the elapsed time as a worklog on its issue. The time is rounded and capped by the server's timer rules (whole minutes and 8 hours by default)"),
+		mcp.WithString("text",
+			mcp.Description("Description of the work performed (optional)"),
+		),
+		mcp.WithString("work_type",
+			mcp.Description("Type of work (e.g., 'Development', 'Testing', 'Documentation') (optional)"),
+		),
+		mcp.WithString("duration",
+			mcp.Description("Log this time instead of the elapsed time, e.g. when the timer kept running during a break (e.g., '45m', '1h 30m') (optional)"),
+		),
+		mcp.WithBoolean("continue_timer",
+			mcp.Description("Split the session: log duration and keep the timer running with the rest (optional, requires duration)"),
+		),
+		mcp.WithBoolean("discard",
+			mcp.Description("Stop the timer without logging any work (optional, default false)"),
+		),
+	)
+}
@@ -6,9 +6,11 @@ import (
 	"encoding/hex"
 	"encoding/json"
 	"os"
+	"sort"
 	"sync"
 
 	"github.com/charmbracelet/log"
+	"github.com/mark3labs/mcp-go/server"
 )
 
 // APIKeyProvider provides the effective API key for a context
@@ -17,9 +19,11 @@ type APIKeyProvider interface {
 }
 
 // ProjectTracker tracks the last used project per user (identified by auth key hash)
+// and the projects pinned for MCP sessions
 type ProjectTracker struct {
 	mu       sync.RWMutex
 	projects map[string]string // keyHash -> projectID
+	pinned   map[string]string // session key -> projectID, kept in memory only
 	filePath string
 }
 
@@ -27,6 +31,7 @@ type ProjectTracker struct {
 func NewProjectTracker(filePath string) *ProjectTracker {
 	pt := &ProjectTracker{
 		projects: make(map[string]string),
+		pinned:   make(map[string]string),
 		filePath: filePath,
 	}
 	pt.load()
@@ -46,6 +51,23 @@ func (pt *ProjectTracker) GetLastProject(keyHash string) string {
 	return pt.projects[keyHash]
 }
 
+// Projects returns the distinct projects tracked for all users
+func (pt *ProjectTracker) Projects() []string {
+	pt.mu.RLock()
+	defer pt.mu.RUnlock()
+
+	seen := make(map[string]bool)
+	var projects []string
+	for _, projectID := range pt.projects {
+		if projectID != "" && !seen[projectID] {
+			seen[projectID] = true
+			projects = append(projects, projectID)
+		}
+	}
+	sort.Strings(projects)
+	return projects
+}
+
 // SetLastProject sets the last used project for the given key hash
 func (pt *ProjectTracker) SetLastProject(keyHash, projectID string) {
 	pt.mu.Lock()
@@ -59,6 +81,27 @@ func (pt *ProjectTracker) SetLastProject(keyHash, projectID string) {
 	pt.save()
 }
 
+// PinProject pins the project for the given session key
+func (pt *ProjectTracker) PinProject(sessionKey, projectID string) {
+	pt.mu.Lock()
+	defer pt.mu.Unlock()
+	pt.pinned[sessionKey] = projectID
+}
+
+// PinnedProject returns the project pinned for the given session key
+func (pt *ProjectTracker) PinnedProject(sessionKey string) string {
+	pt.mu.RLock()
+	defer pt.mu.RUnlock()
+	return pt.pinned[sessionKey]
+}
+
+// ForgetSession drops the project pinned for the given session key
+func (pt *ProjectTracker) ForgetSession(sessionKey string) {
+	pt.mu.Lock()
+	defer pt.mu.Unlock()
+	delete(pt.pinned, sessionKey)
+}
+
 // load reads the tracker state from file
 func (pt *ProjectTracker) load() {
 	if pt.filePath == "" {
@@ -126,3 +169,44 @@ func (ct *ContextProjectTracker) GetLastProject(ctx context.Context) string {
 	}
 	return ct.tracker.GetLastProject(HashKey(apiKey))
 }
+
+// PinProject pins the project for the current MCP session, it takes precedence
+// over the last used project until the session ends
+func (ct *ContextProjectTracker) PinProject(ctx context.Context, projectID string) {
+	if key := ct.sessionKey(ctx); key != "" {
+		ct.tracker.PinProject(key, projectID)
+	}
+}
+
+// DefaultProject returns the project to use when a tool call omits project_id:
+// the project pinned for the session, or else the user's last used project
+func (ct *ContextProjectTracker) DefaultProject(ctx context.Context) string {
+	if key := ct.sessionKey(ctx); key != "" {
+		if projectID := ct.tracker.PinnedProject(key); projectID != "" {
+			return projectID
+		}
+	}
+	return ct.GetLastProject(ctx)
+}
+
+// ForgetSession drops the project pinned for an ended MCP session
+func (ct *ContextProjectTracker) ForgetSession(sessionID string) {
+	ct.tracker.ForgetSession(pinKey(sessionID))
+}
+
+// sessionKey identifies the current MCP session, falling back to the user
+// when the transport has no sessions
+func (ct *ContextProjectTracker) sessionKey(ctx context.Context) string {
+	if session := server.ClientSessionFromContext(ctx); session != nil && session.SessionID() != "" {
+		return pinKey(session.SessionID())
+	}
+	if apiKey := ct.keyProvider.GetEffectiveAPIKey(ctx); apiKey != "" {
+		return HashKey(apiKey)
+	}
+	return ""
+}
+
+// pinKey returns the pinned-project key of an MCP session
+func pinKey(sessionID string) string {
+	return "session:" + sessionID
+}
@@ -11,7 +11,8 @@ import (
 // EnumMatch represents a matched enum value with match details
 type EnumMatch struct {
 	Value     youtrack.AllowedValue
-	MatchType string // "exact", "exact_case_insensitive", "partial", "prefix"
+	MatchType string // "exact", "exact_case_insensitive", "partial", "prefix", "fuzzy"
+	Score     float64
 }
 
 // ResolveEnumValue resolves an enum field value query to a specific value
@@ -20,13 +21,26 @@ type EnumMatch struct {
 // - Case-insensitive exact match
 // - Prefix match
 // - Partial (substring) match
+// - Fuzzy match for close misspellings (e.g. "in-progress", "Fixd")
+//
+// Archived values (e.g. old versions of a version bundle) are only matched by exact name.
 //
 // Returns: (resolvedValue, error)
 // If single match found, returns the exact value name
 // If no match or multiple matches, returns a ResolveError with helpful context
 func (r *Resolver) ResolveEnumValue(ctx context.Context, projectID, fieldName, query string) (string, error) {
+	resolution, err := r.ResolveEnumMatch(ctx, projectID, fieldName, query)
+	if err != nil {
+		return "", err
+	}
+	return resolution.Value, nil
+}
+
+// ResolveEnumMatch resolves an enum field value like ResolveEnumValue,
+// but also reports how the value was matched so callers can surface fuzzy corrections
+func (r *Resolver) ResolveEnumMatch(ctx context.Context, projectID, fieldName, query string) (*Resolution, error) {
 	if query == "" {
-		return "", &ResolveError{
+		return nil, &ResolveError{
 			Field:   fieldName,
 			Query:   query,
 			Message: fmt.Sprintf("%s value cannot be empty", fieldName),
@@ -36,39 +50,55 @@ func (r *Resolver) ResolveEnumValue(ctx context.Context, projectID, fieldName, q
 	query = strings.TrimSpace(query)
 
 	// Fetch allowed values for this field
+	unvalidated := &Resolution{Value: query, Display: query, Query: query, MatchType: "unvalidated"}
 	allowedValues, err := r.client.GetCustomFieldAllowedValues(ctx, projectID, fieldName)
 	if err != nil {
 		// Field might not exist or not be an enum - return the original value
 		// The API will handle validation
-		return query, nil
+		return unvalidated, nil
 	}
 
 	if len(allowedValues) == 0 {
 		// No allowed values defined - return original value
-		return query, nil
+		return unvalidated, nil
 	}
 
+	return MatchEnumValue(fieldName, query, allowedValues)
+}
+
+// MatchEnumValue resolves a field value query against already fetched allowed values,
+// using the same rules as ResolveEnumValue
+func MatchEnumValue(fieldName, query string, allowedValues []youtrack.AllowedValue) (*Resolution, error) {
+	query = strings.TrimSpace(query)
+
 	// Try to find matches
-	matches := r.findEnumMatches(allowedValues, query)
+	matches := findEnumMatches(allowedValues, query)
 
 	// Handle results
 	switch len(matches) {
 	case 0:
 		// No matches - provide helpful error with available values
-		return "", r.noEnumMatchError(fieldName, query, allowedValues)
+		return nil, noEnumMatchError(fieldName, query, allowedValues)
 
 	case 1:
 		// Single match - success
-		return matches[0].Value.Name, nil
+		match := matches[0]
+		return &Resolution{
+			Value:     match.Value.Name,
+			Display:   match.Value.Name,
+			Query:     query,
+			MatchType: match.MatchType,
+			Score:     match.Score,
+		}, nil
 
 	default:
 		// Multiple matches - provide candidates
-		return "", r.multipleEnumMatchError(fieldName, query, matches)
+		return nil, multipleEnumMatchError(fieldName, query, matches)
 	}
 }
 
 // findEnumMatches finds all enum values matching the query
-func (r *Resolver) findEnumMatches(values []youtrack.AllowedValue, query string) []EnumMatch {
+func findEnumMatches(values []youtrack.AllowedValue, query string) []EnumMatch {
 	var exactMatches []EnumMatch
 	var prefixMatches []EnumMatch
 	var partialMatches []EnumMatch
@@ -78,6 +108,11 @@ func (r *Resolver) findEnumMatches(values []youtrack.AllowedValue, query string)
 	for _, value := range values {
 		normalizedValue := normalizeString(value.Name)
 
+		// Archived values can only be picked explicitly
+		if value.Archived && normalizedValue != normalizedQuery {
+			continue
+		}
+
 		// Exact match (case-sensitive)
 		if value.Name == query {
 			exactMatches = append(exactMatches, EnumMatch{Value: value, MatchType: "exact"})
@@ -120,15 +155,31 @@ func (r *Resolver) findEnumMatches(values []youtrack.AllowedValue, query string)
 	if len(prefixMatches) > 0 {
 		return prefixMatches
 	}
+	if len(partialMatches) > 0 {
+		return partialMatches
+	}
+
+	// Fall back to fuzzy matching for close misspellings
+	scores := make([]float64, len(values))
+	for i, value := range values {
+		if !value.Archived {
+			scores[i] = similarity(value.Name, query)
+		}
+	}
+	if best, score := bestFuzzy(scores); best >= 0 {
+		return []EnumMatch{{Value: values[best], MatchType: "fuzzy", Score: score}}
+	}
 
-	return partialMatches
+	return nil
 }
 
 // noEnumMatchError creates an error for no enum value match
-func (r *Resolver) noEnumMatchError(fieldName, query string, values []youtrack.AllowedValue) *ResolveError {
+func noEnumMatchError(fieldName, query string, values []youtrack.AllowedValue) *ResolveError {
 	var candidates []string
 	for _, v := range values {
-		candidates = append(candidates, v.Name)
+		if !v.Archived {
+			candidates = append(candidates, formatAllowedValue(v))
+		}
 	}
 
 	return &ResolveError{
@@ -141,10 +192,10 @@ func (r *Resolver) noEnumMatchError(fieldName, query string, values []youtrack.A
 }
 
 // multipleEnumMatchError creates an error for multiple enum value matches
-func (r *Resolver) multipleEnumMatchError(fieldName, query string, matches []EnumMatch) *ResolveError {
+func multipleEnumMatchError(fieldName, query string, matches []EnumMatch) *ResolveError {
 	var candidates []string
 	for _, m := range matches {
-		candidates = append(candidates, fmt.Sprintf("%s (%s)", m.Value.Name, m.MatchType))
+		candidates = append(candidates, fmt.Sprintf("%s - %s", formatAllowedValue(m.Value), m.MatchType))
 	}
 
 	return &ResolveError{
@@ -156,6 +207,18 @@ func (r *Resolver) multipleEnumMatchError(fieldName, query string, matches []Enu
 	}
 }
 
+// formatAllowedValue formats a value for candidate lists, marking released and archived versions
+func formatAllowedValue(value youtrack.AllowedValue) string {
+	switch {
+	case value.Archived:
+		return value.Name + " (archived)"
+	case value.Released:
+		return value.Name + " (released)"
+	default:
+		return value.Name
+	}
+}
+
 // Common field names that are typically enums
 var CommonEnumFields = []string{
 	"State",
@@ -0,0 +1,152 @@
+package resolver
+
+import (
+	"strings"
+	"unicode"
+)
+
+// FuzzyThreshold is the minimum similarity score for a fuzzy match to be accepted
+const FuzzyThreshold = 0.85
+
+// fuzzyMargin is how much better the best candidate must score than the runner-up
+// for a fuzzy match to be considered unambiguous
+const fuzzyMargin = 0.05
+
+// looseString normalizes a string for fuzzy comparison:
+// lowercase, separators ('-', '_', '.') turned into spaces, whitespace collapsed
+func looseString(s string) string {
+	s = strings.Map(func(r rune) rune {
+		switch r {
+		case '-', '_', '.':
+			return ' '
+		}
+		return unicode.ToLower(r)
+	}, s)
+	return strings.Join(strings.Fields(s), " ")
+}
+
+// similarity scores how close two strings are, from 0 (unrelated) to 1 (equal after normalization).
+// It takes the best of Jaro-Winkler (good for typos near the end and short strings)
+// and normalized Levenshtein distance (good for transpositions inside longer strings).
+func similarity(a, b string) float64 {
+	a, b = looseString(a), looseString(b)
+	if a == "" || b == "" {
+		return 0
+	}
+	if a == b || strings.ReplaceAll(a, " ", "") == strings.ReplaceAll(b, " ", "") {
+		return 1
+	}
+
+	ra, rb := []rune(a), []rune(b)
+	maxLen := len(ra)
+	if len(rb) > maxLen {
+		maxLen = len(rb)
+	}
+	lev := 1 - float64(levenshtein(ra, rb))/float64(maxLen)
+
+	jw := jaroWinkler(ra, rb)
+	if jw > lev {
+		return jw
+	}
+	return lev
+}
+
+// levenshtein returns the edit distance between two rune slices
+func levenshtein(a, b []rune) int {
+	prev := make([]int, len(b)+1)
+	curr := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(a); i++ {
+		curr[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+
+	return prev[len(b)]
+}
+
+// jaroWinkler returns the Jaro-Winkler similarity of two rune slices
+func jaroWinkler(a, b []rune) float64 {
+	if len(a) == 0 || len(b) == 0 {
+		return 0
+	}
+
+	matchDistance := max(len(a), len(b))/2 - 1
+	if matchDistance < 0 {
+		matchDistance = 0
+	}
+
+	aMatches := make([]bool, len(a))
+	bMatches := make([]bool, len(b))
+	matches := 0
+	for i := range a {
+		start := max(0, i-matchDistance)
+		end := min(len(b), i+matchDistance+1)
+		for j := start; j < end; j++ {
+			if bMatches[j] || a[i] != b[j] {
+				continue
+			}
+			aMatches[i], bMatches[j] = true, true
+			matches++
+			break
+		}
+	}
+	if matches == 0 {
+		return 0
+	}
+
+	transpositions := 0
+	k := 0
+	for i := range a {
+		if !aMatches[i] {
+			continue
+		}
+		for !bMatches[k] {
+			k++
+		}
+		if a[i] != b[k] {
+			transpositions++
+		}
+		k++
+	}
+
+	m := float64(matches)
+	jaro := (m/float64(len(a)) + m/float64(len(b)) + (m-float64(transpositions)/2)/m) / 3
+
+	// Winkler boost for a common prefix of up to 4 characters
+	prefix := 0
+	for i := 0; i < min(4, len(a), len(b)) && a[i] == b[i]; i++ {
+		prefix++
+	}
+
+	return jaro + float64(prefix)*0.1*(1-jaro)
+}
+
+// bestFuzzy picks the index of the single best-scoring candidate above FuzzyThreshold.
+// Returns -1 if no candidate is close enough or the best one isn't clearly ahead.
+func bestFuzzy(scores []float64) (int, float64) {
+	best, runnerUp := -1, 0.0
+	bestScore := 0.0
+	for i, score := range scores {
+		if score > bestScore {
+			runnerUp = bestScore
+			best, bestScore = i, score
+		} else if score > runnerUp {
+			runnerUp = score
+		}
+	}
+
+	if best < 0 || bestScore < FuzzyThreshold || bestScore-runnerUp < fuzzyMargin {
+		return -1, 0
+	}
+	return best, bestScore
+}
@@ -0,0 +1,132 @@
+package resolver
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/mkozhukh/youtrack/pkg/youtrack"
+)
+
+// mentionPattern matches an @name at the start of the text or after a space or punctuation,
+// so that e-mail addresses are not taken for mentions
+var mentionPattern = regexp.MustCompile(`(^|[\s(\[{,;:!?"'])@([\p{L}\p{N}_][\p{L}\p{N}._-]*)`)
+
+// MentionResult is a comment text with its @mentions resolved to user logins
+type MentionResult struct {
+	Text     string   // Text with the resolved mentions written as @login
+	Notes    []string // Mentions rewritten to another login, e.g. "@john → @jsmith (John Smith)"
+	Warnings []string // Mentions left as written, as no single user matches them
+}
+
+// HasMentions reports whether a text may contain @mentions
+func HasMentions(text string) bool {
+	return mentionPattern.MatchString(text)
+}
+
+// ResolveMentions resolves the @mentions of a comment text against the users of a project.
+// The users are only fetched when the text has mentions.
+func (r *Resolver) ResolveMentions(ctx context.Context, projectID, text string) (*MentionResult, error) {
+	if !HasMentions(text) {
+		return &MentionResult{Text: text}, nil
+	}
+
+	users, err := r.fetchAllProjectUsers(ctx, projectID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch project users: %w", err)
+	}
+	return MatchMentions(projectID, text, users), nil
+}
+
+// MatchMentions resolves the @mentions of a text against already fetched project users, using
+// the same rules as ResolveUserMatch. A mention of a login is kept; any other mention matching
+// a single user is rewritten to @login. Mentions in code blocks and code spans are left alone.
+func MatchMentions(projectID, text string, users []*youtrack.User) *MentionResult {
+	result := &MentionResult{}
+	seen := make(map[string]string)
+
+	resolve := func(name string) string {
+		if login, ok := seen[name]; ok {
+			return login
+		}
+		login := matchMention(projectID, name, users, result)
+		seen[name] = login
+		return login
+	}
+
+	var sb strings.Builder
+	inFence := false
+	for _, line := range strings.SplitAfter(text, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, "```") || strings.HasPrefix(trimmed, "~~~") {
+			inFence = !inFence
+			sb.WriteString(line)
+			continue
+		}
+		if inFence {
+			sb.WriteString(line)
+			continue
+		}
+
+		// Odd parts between backticks are code spans
+		for i, part := range strings.Split(line, "`") {
+			if i > 0 {
+				sb.WriteString("`")
+			}
+			if i%2 == 1 {
+				sb.WriteString(part)
+				continue
+			}
+			sb.WriteString(rewriteMentions(part, resolve))
+		}
+	}
+
+	result.Text = sb.String()
+	return result
+}
+
+// rewriteMentions replaces the name of each mention of a text with the login resolve returns
+func rewriteMentions(text string, resolve func(name string) string) string {
+	var sb strings.Builder
+	last := 0
+	for _, m := range mentionPattern.FindAllStringSubmatchIndex(text, -1) {
+		// A sentence may end right after the mention
+		start := m[4]
+		name := strings.TrimRight(text[start:m[5]], ".-")
+		sb.WriteString(text[last:start])
+		sb.WriteString(resolve(name))
+		last = start + len(name)
+	}
+	sb.WriteString(text[last:])
+	return sb.String()
+}
+
+// matchMention returns the login a mention refers to, recording a note when it was rewritten
+// and a warning when it is left as written
+func matchMention(projectID, name string, users []*youtrack.User, result *MentionResult) string {
+	for _, user := range youtrack.ActiveUsers(users) {
+		if user.Login == name {
+			return name
+		}
+	}
+
+	resolution, err := MatchUser(projectID, name, users)
+	if err != nil {
+		message := err.Error()
+		if resolveErr, ok := err.(*ResolveError); ok {
+			message = resolveErr.Message
+		}
+		result.Warnings = append(result.Warnings, fmt.Sprintf("@%s left as written: %s", name, message))
+		return name
+	}
+
+	note := fmt.Sprintf("@%s → @%s", name, resolution.Display)
+	if resolution.Corrected() {
+		note += fmt.Sprintf(" (closest match, %.0f%% similar)", resolution.Score*100)
+	}
+	if resolution.Value != name {
+		result.Notes = append(result.Notes, note)
+	}
+	return resolution.Value
+}
@@ -0,0 +1,164 @@
+package resolver
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/mkozhukh/youtrack/pkg/youtrack"
+)
+
+// ProjectLister provides the list of all projects visible to the user
+type ProjectLister interface {
+	ListAllProjects(ctx context.Context) ([]*youtrack.Project, error)
+}
+
+// ProjectMatch represents a project match result
+type ProjectMatch struct {
+	Project   *youtrack.Project
+	MatchType string // "exact_short_name", "exact_id", "exact_name", "prefix", "partial", "fuzzy"
+	Score     float64
+}
+
+// ResolveProject resolves a project query to a specific project.
+// The query can be a short name ("MOB"), a database ID ("0-1") or a
+// human-readable name ("Mobile App"), matched in this order:
+// - Exact short name, ID or name (case-insensitive)
+// - Prefix of the name or short name
+// - Partial (substring) match of the name
+// - Fuzzy match for close misspellings
+//
+// If no match or multiple matches, returns a ResolveError with candidates
+func ResolveProject(ctx context.Context, lister ProjectLister, query string) (*youtrack.Project, error) {
+	if strings.TrimSpace(query) == "" {
+		return nil, &ResolveError{
+			Field:   "project",
+			Query:   query,
+			Message: "empty project query",
+		}
+	}
+
+	projects, err := lister.ListAllProjects(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list projects: %w", err)
+	}
+
+	return MatchProject(projects, query)
+}
+
+// MatchProject resolves a project query against an already fetched project list,
+// using the same rules as ResolveProject
+func MatchProject(projects []*youtrack.Project, query string) (*youtrack.Project, error) {
+	matches := findProjectMatches(projects, query)
+
+	switch len(matches) {
+	case 0:
+		return nil, noProjectMatchError(query, projects)
+	case 1:
+		return matches[0].Project, nil
+	default:
+		return nil, multipleProjectMatchError(query, matches)
+	}
+}
+
+// findProjectMatches finds all projects matching the query
+func findProjectMatches(projects []*youtrack.Project, query string) []ProjectMatch {
+	var exactMatches []ProjectMatch
+	var prefixMatches []ProjectMatch
+	var partialMatches []ProjectMatch
+
+	normalizedQuery := normalizeString(query)
+
+	for _, project := range projects {
+		// Exact short name or ID always wins, they are unique
+		if equalsNormalized(project.ShortName, query) {
+			return []ProjectMatch{{Project: project, MatchType: "exact_short_name"}}
+		}
+		if project.ID == query {
+			return []ProjectMatch{{Project: project, MatchType: "exact_id"}}
+		}
+
+		normalizedName := normalizeString(project.Name)
+
+		if normalizedName == normalizedQuery {
+			exactMatches = append(exactMatches, ProjectMatch{Project: project, MatchType: "exact_name"})
+			continue
+		}
+
+		if strings.HasPrefix(normalizedName, normalizedQuery) || strings.HasPrefix(normalizeString(project.ShortName), normalizedQuery) {
+			prefixMatches = append(prefixMatches, ProjectMatch{Project: project, MatchType: "prefix"})
+			continue
+		}
+
+		if strings.Contains(normalizedName, normalizedQuery) {
+			partialMatches = append(partialMatches, ProjectMatch{Project: project, MatchType: "partial"})
+		}
+	}
+
+	// Return matches by priority: exact > prefix > partial
+	if len(exactMatches) > 0 {
+		return exactMatches
+	}
+	if len(prefixMatches) > 0 {
+		return prefixMatches
+	}
+	if len(partialMatches) > 0 {
+		return partialMatches
+	}
+
+	// Fall back to fuzzy matching against name and short name
+	scores := make([]float64, len(projects))
+	for i, project := range projects {
+		scores[i] = max(similarity(project.Name, query), similarity(project.ShortName, query))
+	}
+	if best, score := bestFuzzy(scores); best >= 0 {
+		return []ProjectMatch{{Project: projects[best], MatchType: "fuzzy", Score: score}}
+	}
+
+	return nil
+}
+
+// FormatProjectForDisplay formats a project for display in messages
+func FormatProjectForDisplay(project *youtrack.Project) string {
+	if project.Name != "" && project.Name != project.ShortName {
+		return fmt.Sprintf("%s (%s)", project.ShortName, project.Name)
+	}
+	return project.ShortName
+}
+
+// noProjectMatchError creates an error for no project match
+func noProjectMatchError(query string, projects []*youtrack.Project) *ResolveError {
+	const maxCandidates = 20
+	var candidates []string
+	for i, project := range projects {
+		if i >= maxCandidates {
+			candidates = append(candidates, fmt.Sprintf("... and %d more", len(projects)-maxCandidates))
+			break
+		}
+		candidates = append(candidates, FormatProjectForDisplay(project))
+	}
+
+	return &ResolveError{
+		Field:      "project",
+		Query:      query,
+		Message:    fmt.Sprintf("no project found matching '%s'", query),
+		Candidates: candidates,
+		Suggestion: "Use the project short name or its full name.",
+	}
+}
+
+// multipleProjectMatchError creates an error for multiple project matches
+func multipleProjectMatchError(query string, matches []ProjectMatch) *ResolveError {
+	var candidates []string
+	for _, m := range matches {
+		candidates = append(candidates, fmt.Sprintf("%s - %s", FormatProjectForDisplay(m.Project), m.MatchType))
+	}
+
+	return &ResolveError{
+		Field:      "project",
+		Query:      query,
+		Message:    fmt.Sprintf("multiple projects match '%s' - please be more specific", query),
+		Candidates: candidates,
+		Suggestion: "Provide the project short name to avoid ambiguity.",
+	}
+}
@@ -35,6 +35,28 @@ func (e *ResolveError) Error() string {
 	return sb.String()
 }
 
+// Resolution describes how a query was resolved to a value
+type Resolution struct {
+	Value     string  // Resolved value: enum value name or user login
+	Display   string  // Human-readable form of the resolved value
+	Query     string  // Original query
+	MatchType string  // How the value was matched, e.g. "exact", "prefix", "fuzzy"
+	Score     float64 // Similarity score, set for fuzzy matches
+}
+
+// Corrected reports whether the query was resolved by fuzzy matching, i.e. it was likely misspelled
+func (r *Resolution) Corrected() bool {
+	return r.MatchType == "fuzzy"
+}
+
+// Note returns a message describing a fuzzy correction, or an empty string for direct matches
+func (r *Resolution) Note() string {
+	if !r.Corrected() {
+		return ""
+	}
+	return fmt.Sprintf("'%s' was interpreted as '%s' (closest match, %.0f%% similar)", r.Query, r.Display, r.Score*100)
+}
+
 // Resolver provides smart resolution for users and enum fields
 type Resolver struct {
 	client ResolverClient
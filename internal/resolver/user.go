@@ -11,7 +11,8 @@ import (
 // UserMatch represents a matched user with match details
 type UserMatch struct {
 	User      *youtrack.User
-	MatchType string // "exact_login", "exact_email", "partial_login", "partial_name", "partial_email"
+	MatchType string // "exact_login", "exact_email", "partial_login", "partial_name", "partial_email", "fuzzy"
+	Score     float64
 }
 
 // ResolveUser resolves a user query to a specific user login
@@ -20,29 +21,46 @@ type UserMatch struct {
 // - Exact login match
 // - Exact email match
 // - Partial login/name/email match
+// - Fuzzy match for close misspellings (e.g. "Jon Smtih")
 //
 // Returns: (userLogin, error)
 // If single match found, returns the login
 // If no match or multiple matches, returns a ResolveError with helpful context
 func (r *Resolver) ResolveUser(ctx context.Context, projectID, query string) (string, error) {
+	resolution, err := r.ResolveUserMatch(ctx, projectID, query)
+	if err != nil {
+		return "", err
+	}
+	return resolution.Value, nil
+}
+
+// ResolveUserMatch resolves a user like ResolveUser,
+// but also reports how the user was matched so callers can surface fuzzy corrections
+func (r *Resolver) ResolveUserMatch(ctx context.Context, projectID, query string) (*Resolution, error) {
 	if query == "" {
-		return "", &ResolveError{
+		return nil, &ResolveError{
 			Field:   "user",
 			Query:   query,
 			Message: "user query cannot be empty",
 		}
 	}
 
-	query = strings.TrimSpace(query)
-
 	// Fetch all project users
 	allUsers, err := r.fetchAllProjectUsers(ctx, projectID)
 	if err != nil {
-		return "", fmt.Errorf("failed to fetch project users: %w", err)
+		return nil, fmt.Errorf("failed to fetch project users: %w", err)
 	}
 
+	return MatchUser(projectID, query, allUsers)
+}
+
+// MatchUser resolves a user query against already fetched project users,
+// using the same rules as ResolveUserMatch
+func MatchUser(projectID, query string, allUsers []*youtrack.User) (*Resolution, error) {
+	query = strings.TrimSpace(query)
+
 	if len(allUsers) == 0 {
-		return "", &ResolveError{
+		return nil, &ResolveError{
 			Field:      "user",
 			Query:      query,
 			Message:    fmt.Sprintf("no users found in project '%s'", projectID),
@@ -50,23 +68,51 @@ func (r *Resolver) ResolveUser(ctx context.Context, projectID, query string) (st
 		}
 	}
 
-	// Try to find matches
-	matches := r.findUserMatches(allUsers, query)
+	// Try to find matches, banned users cannot be assigned
+	activeUsers := youtrack.ActiveUsers(allUsers)
+	matches := findUserMatches(activeUsers, query)
 
 	// Handle results
 	switch len(matches) {
 	case 0:
+		// A banned user matching the query gets a clearer error than no match at all
+		if banned := findUserMatches(bannedUsers(allUsers), query); len(banned) > 0 {
+			return nil, &ResolveError{
+				Field:      "user",
+				Query:      query,
+				Message:    fmt.Sprintf("user %s is banned (deactivated) and cannot be assigned", FormatUserForDisplay(banned[0].User)),
+				Suggestion: "Pick an active user of the project.",
+			}
+		}
 		// No matches - provide helpful error with available users
-		return "", r.noUserMatchError(query, allUsers, projectID)
+		return nil, noUserMatchError(query, activeUsers, projectID)
 
 	case 1:
 		// Single match - success
-		return matches[0].User.Login, nil
+		match := matches[0]
+		return &Resolution{
+			Value:     match.User.Login,
+			Display:   FormatUserForDisplay(match.User),
+			Query:     query,
+			MatchType: match.MatchType,
+			Score:     match.Score,
+		}, nil
 
 	default:
 		// Multiple matches - provide candidates
-		return "", r.multipleUserMatchError(query, matches)
+		return nil, multipleUserMatchError(query, matches)
+	}
+}
+
+// bannedUsers returns the banned users of a list
+func bannedUsers(users []*youtrack.User) []*youtrack.User {
+	var banned []*youtrack.User
+	for _, user := range users {
+		if user.Banned {
+			banned = append(banned, user)
+		}
 	}
+	return banned
 }
 
 // fetchAllProjectUsers fetches all users from a project with pagination
@@ -98,7 +144,7 @@ func (r *Resolver) fetchAllProjectUsers(ctx context.Context, projectID string) (
 }
 
 // findUserMatches finds all users matching the query
-func (r *Resolver) findUserMatches(users []*youtrack.User, query string) []UserMatch {
+func findUserMatches(users []*youtrack.User, query string) []UserMatch {
 	var exactMatches []UserMatch
 	var partialMatches []UserMatch
 
@@ -156,12 +202,25 @@ func (r *Resolver) findUserMatches(users []*youtrack.User, query string) []UserM
 	if len(exactMatches) > 0 {
 		return exactMatches
 	}
+	if len(partialMatches) > 0 {
+		return partialMatches
+	}
+
+	// Fall back to fuzzy matching against login, full name and email name
+	scores := make([]float64, len(users))
+	for i, user := range users {
+		emailName, _, _ := strings.Cut(user.Email, "@")
+		scores[i] = max(similarity(user.Login, query), similarity(user.FullName, query), similarity(emailName, query))
+	}
+	if best, score := bestFuzzy(scores); best >= 0 {
+		return []UserMatch{{User: users[best], MatchType: "fuzzy", Score: score}}
+	}
 
-	return partialMatches
+	return nil
 }
 
 // noUserMatchError creates an error for no user match
-func (r *Resolver) noUserMatchError(query string, users []*youtrack.User, projectID string) *ResolveError {
+func noUserMatchError(query string, users []*youtrack.User, projectID string) *ResolveError {
 	// Show some available users as suggestions
 	var candidates []string
 	maxCandidates := 5
@@ -188,7 +247,7 @@ func (r *Resolver) noUserMatchError(query string, users []*youtrack.User, projec
 }
 
 // multipleUserMatchError creates an error for multiple user matches
-func (r *Resolver) multipleUserMatchError(query string, matches []UserMatch) *ResolveError {
+func multipleUserMatchError(query string, matches []UserMatch) *ResolveError {
 	var candidates []string
 	for _, m := range matches {
 		if m.User.FullName != "" {
@@ -0,0 +1,222 @@
+// Package timer keeps the state of the worklog timer shared by the yt CLI and the MCP server.
+// A single timer can run at a time; its state is a small JSON file, so a timer started in
+// one tool can be stopped from the other. The users of an MCP server with per-request
+// keys each have their own timer, see Store.ForKey.
+package timer
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+var (
+	// ErrRunning is returned when starting a timer while another one is running
+	ErrRunning = errors.New("a timer is already running")
+	// ErrNotRunning is returned when stopping a timer while none is running
+	ErrNotRunning = errors.New("no timer is running")
+	// ErrSplitTooLong is returned when splitting a session at or after the elapsed time
+	ErrSplitTooLong = errors.New("the logged time is not less than the elapsed time")
+)
+
+// Timer is a running worklog timer
+type Timer struct {
+	IssueID string    `json:"issueId"`
+	Summary string    `json:"summary,omitempty"`
+	Started time.Time `json:"started"`
+}
+
+// Elapsed returns the time the timer has been running at now
+func (t *Timer) Elapsed(now time.Time) time.Duration {
+	if now.Before(t.Started) {
+		return 0
+	}
+	return now.Sub(t.Started)
+}
+
+// Split splits the session after the logged minutes and returns the rest of it, to keep
+// running with Store.Restore. The rest keeps running as if started after the logged time.
+// It fails with ErrSplitTooLong unless the logged time is less than the elapsed time at now.
+func (t *Timer) Split(minutes int, now time.Time) (*Timer, error) {
+	logged := time.Duration(minutes) * time.Minute
+	if logged >= t.Elapsed(now) {
+		return nil, ErrSplitTooLong
+	}
+
+	rest := *t
+	rest.Started = t.Started.Add(logged)
+	return &rest, nil
+}
+
+// DefaultMaxSession caps a session when Rules.MaxSession is zero: a timer running longer
+// was most likely forgotten, not worked on
+const DefaultMaxSession = 8 * time.Hour
+
+// Minutes converts an elapsed time to worklog minutes, rounded up to whole minutes.
+// Anything shorter than a minute counts as one, as YouTrack rejects empty work items.
+func Minutes(elapsed time.Duration) int {
+	minutes := int((elapsed + time.Minute - 1) / time.Minute)
+	if minutes < 1 {
+		return 1
+	}
+	return minutes
+}
+
+// Rules turn the elapsed time of a session into the logged time
+type Rules struct {
+	// MaxSession caps the logged time, zero means DefaultMaxSession and a negative value no cap
+	MaxSession time.Duration
+	// RoundMinutes rounds the logged time to the nearest multiple, e.g. 5 or 15;
+	// 0 or 1 rounds up to whole minutes
+	RoundMinutes int
+}
+
+// Minutes returns the worklog minutes for an elapsed time, and whether the session cap cut it short
+func (r Rules) Minutes(elapsed time.Duration) (int, bool) {
+	minutes := Minutes(elapsed)
+	if r.RoundMinutes > 1 {
+		step := time.Duration(r.RoundMinutes) * time.Minute
+		minutes = int((elapsed+step/2)/step) * r.RoundMinutes
+		// Short sessions still log one step rather than nothing
+		if minutes < r.RoundMinutes {
+			minutes = r.RoundMinutes
+		}
+	}
+
+	maxSession := r.MaxSession
+	if maxSession == 0 {
+		maxSession = DefaultMaxSession
+	}
+	if maxSession > 0 {
+		if limit := Minutes(maxSession); minutes > limit {
+			return limit, true
+		}
+	}
+	return minutes, false
+}
+
+// Store persists the timer state in a JSON file
+type Store struct {
+	path string
+}
+
+// NewStore creates a store for the state file at path, or the default path if empty
+func NewStore(path string) *Store {
+	if path == "" {
+		path = DefaultPath()
+	}
+	return &Store{path: path}
+}
+
+// DefaultPath returns the default state file, next to the yt configuration (~/.config/yt/timer.json)
+func DefaultPath() string {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return filepath.Join(os.TempDir(), "yt-timer.json")
+	}
+	return filepath.Join(homeDir, ".config", "yt", "timer.json")
+}
+
+// ForKey returns the store of the timer of one user of a shared server, identified by key,
+// e.g. a hash of its API key: timer.json keeps the state of key "ab12" in timer-ab12.json
+func (s *Store) ForKey(key string) *Store {
+	ext := filepath.Ext(s.path)
+	return &Store{path: strings.TrimSuffix(s.path, ext) + "-" + key + ext}
+}
+
+// Path returns the state file path
+func (s *Store) Path() string {
+	return s.path
+}
+
+// Current returns the running timer, or nil if none is running
+func (s *Store) Current() (*Timer, error) {
+	data, err := os.ReadFile(s.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read timer state: %w", err)
+	}
+
+	var t Timer
+	if err := json.Unmarshal(data, &t); err != nil {
+		return nil, fmt.Errorf("failed to parse timer state %s: %w", s.path, err)
+	}
+	return &t, nil
+}
+
+// Start starts a timer for the issue. It fails with ErrRunning, returning the running
+// timer, if one is already running.
+func (s *Store) Start(issueID, summary string, now time.Time) (*Timer, error) {
+	current, err := s.Current()
+	if err != nil {
+		return nil, err
+	}
+	if current != nil {
+		return current, ErrRunning
+	}
+
+	t := &Timer{IssueID: issueID, Summary: summary, Started: now}
+	if err := s.save(t); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+// Stop stops the running timer and returns it, or fails with ErrNotRunning
+func (s *Store) Stop() (*Timer, error) {
+	current, err := s.Current()
+	if err != nil {
+		return nil, err
+	}
+	if current == nil {
+		return nil, ErrNotRunning
+	}
+
+	if err := os.Remove(s.path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("failed to clear timer state: %w", err)
+	}
+	return current, nil
+}
+
+// Restore puts a stopped timer back, e.g. when its worklog could not be created,
+// or with a later start time to keep a split session running
+func (s *Store) Restore(t *Timer) error {
+	return s.save(t)
+}
+
+// save writes the state file through a temporary file, so readers never see a partial state
+func (s *Store) save(t *Timer) error {
+	data, err := json.MarshalIndent(t, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal timer state: %w", err)
+	}
+
+	dir := filepath.Dir(s.path)
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return fmt.Errorf("failed to create timer state directory: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(dir, ".timer-*.json")
+	if err != nil {
+		return fmt.Errorf("failed to write timer state: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write timer state: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write timer state: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), s.path); err != nil {
+		return fmt.Errorf("failed to write timer state: %w", err)
+	}
+	return nil
+}
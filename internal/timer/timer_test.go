@@ -0,0 +1,49 @@
+package timer
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestTimer_Split(t *testing.T) {
+	started := time.Date(2024, 1, 15, 9, 0, 0, 0, time.UTC)
+	now := started.Add(90 * time.Minute)
+	timer := &Timer{IssueID: "PRJ-1", Summary: "Fix login", Started: started}
+
+	tests := []struct {
+		name     string
+		minutes  int
+		expected time.Time
+		err      error
+	}{
+		{name: "Part of the session", minutes: 60, expected: started.Add(60 * time.Minute)},
+		{name: "Almost all of it", minutes: 89, expected: started.Add(89 * time.Minute)},
+		{name: "All of it", minutes: 90, err: ErrSplitTooLong},
+		{name: "More than elapsed", minutes: 120, err: ErrSplitTooLong},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rest, err := timer.Split(tt.minutes, now)
+			if tt.err != nil {
+				if !errors.Is(err, tt.err) {
+					t.Fatalf("Expected error %v, got %v", tt.err, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if !rest.Started.Equal(tt.expected) {
+				t.Errorf("Expected the rest to start at %s, got %s", tt.expected, rest.Started)
+			}
+			if rest.IssueID != timer.IssueID || rest.Summary != timer.Summary {
+				t.Errorf("Expected the rest of %s, got %+v", timer.IssueID, rest)
+			}
+			if !timer.Started.Equal(started) {
+				t.Errorf("Expected the split timer to keep its start, got %s", timer.Started)
+			}
+		})
+	}
+}
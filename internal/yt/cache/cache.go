@@ -0,0 +1,142 @@
+package cache
+
+import (
+	"crypto/cipher"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/mkozhukh/youtrack/internal/yt/config"
+)
+
+// DefaultTTL is used when the configuration doesn't set a cache TTL
+const DefaultTTL = 10 * time.Minute
+
+// globalScope is the directory used for entries that don't belong to a project
+const globalScope = "_global"
+
+// Store is a file-based cache of project metadata (users, custom fields) for the CLI.
+// Entries are stored as JSON files under <dir>/<project>/<kind>.json, encrypted when
+// cache.encrypt is set.
+type Store struct {
+	dir string
+	ttl time.Duration
+
+	// aead encrypts the files when encryption is enabled
+	aead      cipher.AEAD
+	keySource string
+	// keyErr is the error of loading the key, returned when the cache is read or written
+	keyErr error
+}
+
+// New creates a store rooted at dir with the given TTL
+func New(dir string, ttl time.Duration) *Store {
+	if ttl <= 0 {
+		ttl = DefaultTTL
+	}
+	return &Store{
+		dir: dir,
+		ttl: ttl,
+	}
+}
+
+// Open returns the store configured for the CLI
+func Open(cfg *config.Config) *Store {
+	dir := cfg.Cache.Dir
+	if dir == "" {
+		dir = DefaultDir()
+	}
+	store := New(dir, time.Duration(cfg.Cache.TTLSeconds)*time.Second)
+	if cfg.Cache.Encrypt {
+		store.keySource = KeySource(cfg)
+		store.aead, store.keyErr = newCipher(cfg)
+	}
+	return store
+}
+
+// DefaultDir returns the default cache directory (~/.cache/yt on Linux)
+func DefaultDir() string {
+	cacheDir, err := os.UserCacheDir()
+	if err != nil {
+		return filepath.Join(os.TempDir(), "yt-cache")
+	}
+	return filepath.Join(cacheDir, "yt")
+}
+
+// Dir returns the cache directory
+func (s *Store) Dir() string {
+	return s.dir
+}
+
+// path returns the file path for a cache entry
+func (s *Store) path(projectID, kind string) string {
+	return filepath.Join(s.projectDir(projectID), kind+".json")
+}
+
+// projectDir returns the directory that holds a project's entries
+func (s *Store) projectDir(projectID string) string {
+	if projectID == "" {
+		return filepath.Join(s.dir, globalScope)
+	}
+	return filepath.Join(s.dir, strings.ToUpper(projectID))
+}
+
+// Get loads a cached entry into v. Returns false if the entry is missing, expired, or unreadable.
+func (s *Store) Get(projectID, kind string, v interface{}) bool {
+	path := s.path(projectID, kind)
+
+	info, err := os.Stat(path)
+	if err != nil || time.Since(info.ModTime()) > s.ttl {
+		return false
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return false
+	}
+	if data, err = s.Unseal(data); err != nil {
+		return false
+	}
+
+	return json.Unmarshal(data, v) == nil
+}
+
+// Set stores v as a cache entry
+func (s *Store) Set(projectID, kind string, v interface{}) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Errorf("failed to marshal cache entry: %w", err)
+	}
+	if data, err = s.Seal(data); err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(s.projectDir(projectID), 0700); err != nil {
+		return fmt.Errorf("failed to create cache directory: %w", err)
+	}
+
+	if err := os.WriteFile(s.path(projectID, kind), data, 0600); err != nil {
+		return fmt.Errorf("failed to write cache entry: %w", err)
+	}
+
+	return nil
+}
+
+// ClearProject removes all cached entries for a project
+func (s *Store) ClearProject(projectID string) error {
+	if err := os.RemoveAll(s.projectDir(projectID)); err != nil {
+		return fmt.Errorf("failed to clear cache for project %s: %w", projectID, err)
+	}
+	return nil
+}
+
+// Clear removes all cached entries
+func (s *Store) Clear() error {
+	if err := os.RemoveAll(s.dir); err != nil {
+		return fmt.Errorf("failed to clear cache: %w", err)
+	}
+	return nil
+}
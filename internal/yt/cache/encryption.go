@@ -0,0 +1,149 @@
+package cache
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/mkozhukh/youtrack/internal/yt/config"
+)
+
+// sealedPrefix starts the files written encrypted, followed by the nonce and the ciphertext
+var sealedPrefix = []byte("yt-sealed-v1\n")
+
+// DefaultKeyFile returns the default encryption key file, next to the yt configuration
+// (~/.config/yt/cache.key), so a copy of the cache directory cannot be read without it
+func DefaultKeyFile() string {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return filepath.Join(os.TempDir(), "yt-cache.key")
+	}
+	return filepath.Join(homeDir, ".config", "yt", "cache.key")
+}
+
+// KeySource describes where the encryption key of the configuration comes from
+func KeySource(cfg *config.Config) string {
+	if cfg.Cache.Key != "" {
+		return "YT_CACHE_KEY"
+	}
+	if cfg.Cache.KeyFile != "" {
+		return cfg.Cache.KeyFile
+	}
+	return DefaultKeyFile()
+}
+
+// newCipher creates the AES-GCM cipher of the configured key: the YT_CACHE_KEY secret, or the
+// key file, created with a random key when missing. Any secret is accepted, it is hashed to
+// a 256-bit key.
+func newCipher(cfg *config.Config) (cipher.AEAD, error) {
+	secret := []byte(cfg.Cache.Key)
+	if len(secret) == 0 {
+		var err error
+		if secret, err = readKeyFile(KeySource(cfg)); err != nil {
+			return nil, err
+		}
+	}
+
+	key := sha256.Sum256(secret)
+	block, err := aes.NewCipher(key[:])
+	if err != nil {
+		return nil, fmt.Errorf("failed to create the cache cipher: %w", err)
+	}
+	return cipher.NewGCM(block)
+}
+
+// readKeyFile reads the key file, generating it when missing
+func readKeyFile(path string) ([]byte, error) {
+	data, err := os.ReadFile(path)
+	if err == nil {
+		if secret := bytes.TrimSpace(data); len(secret) > 0 {
+			return secret, nil
+		}
+		return nil, fmt.Errorf("cache key file %s is empty", path)
+	}
+	if !errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("failed to read the cache key: %w", err)
+	}
+
+	raw := make([]byte, 32)
+	if _, err := rand.Read(raw); err != nil {
+		return nil, fmt.Errorf("failed to generate the cache key: %w", err)
+	}
+	secret := []byte(base64.StdEncoding.EncodeToString(raw))
+
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return nil, fmt.Errorf("failed to create the cache key directory: %w", err)
+	}
+	// O_EXCL keeps the key of a concurrent command that created it first
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+	if errors.Is(err, os.ErrExist) {
+		return readKeyFile(path)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to create the cache key: %w", err)
+	}
+	if _, err := f.Write(append(secret, '\n')); err != nil {
+		f.Close()
+		return nil, fmt.Errorf("failed to write the cache key: %w", err)
+	}
+	if err := f.Close(); err != nil {
+		return nil, fmt.Errorf("failed to write the cache key: %w", err)
+	}
+	return secret, nil
+}
+
+// Seal encrypts data to be written in the cache directory when encryption is enabled, and
+// returns it unchanged otherwise
+func (s *Store) Seal(data []byte) ([]byte, error) {
+	if s.keyErr != nil {
+		return nil, s.keyErr
+	}
+	if s.aead == nil {
+		return data, nil
+	}
+
+	nonce := make([]byte, s.aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, fmt.Errorf("failed to encrypt cache data: %w", err)
+	}
+	sealed := append([]byte{}, sealedPrefix...)
+	sealed = append(sealed, nonce...)
+	return s.aead.Seal(sealed, nonce, data, nil), nil
+}
+
+// Unseal decrypts data read from the cache directory. Data written before encryption was
+// enabled is returned as it is, and is encrypted when written again.
+func (s *Store) Unseal(data []byte) ([]byte, error) {
+	if !bytes.HasPrefix(data, sealedPrefix) {
+		return data, nil
+	}
+	if s.keyErr != nil {
+		return nil, s.keyErr
+	}
+	if s.aead == nil {
+		return nil, fmt.Errorf("the cache is encrypted, set cache.encrypt to read it")
+	}
+
+	data = data[len(sealedPrefix):]
+	size := s.aead.NonceSize()
+	if len(data) < size {
+		return nil, fmt.Errorf("encrypted cache data is truncated")
+	}
+	plain, err := s.aead.Open(nil, data[:size], data[size:], nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt cache data, the key from %s does not match: %w", s.keySource, err)
+	}
+	return plain, nil
+}
+
+// Encrypted reports whether the store encrypts what it writes
+func (s *Store) Encrypted() bool {
+	return s.aead != nil
+}
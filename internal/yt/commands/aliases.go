@@ -0,0 +1,163 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/charmbracelet/log"
+
+	"github.com/mkozhukh/youtrack/internal/yt/config"
+)
+
+// valueFlags are the global flags that take a separate value, skipped when looking for the command
+var valueFlags = map[string]bool{"-c": true, "--config": true, "-o": true, "--output": true, "--theme": true}
+
+// expandAlias replaces an alias of the [aliases] config section with its command line, e.g.
+// "yt mine -o json" with "yt tickets list -u me -q '#Unresolved' -o json". Aliases do not
+// shadow the built-in commands and are expanded once, an alias cannot call another one.
+func expandAlias(args []string) ([]string, error) {
+	index := commandIndex(args)
+	if index < 0 {
+		return args, nil
+	}
+	name := args[index]
+	if isBuiltinCommand(name) {
+		return args, nil
+	}
+
+	cfg, err := config.LoadFile(configFlag(args))
+	if err != nil || len(cfg.Aliases) == 0 {
+		// The command fails as unknown, with the config error when there is one
+		return args, nil
+	}
+	alias, ok := cfg.Aliases[name]
+	if !ok {
+		return args, nil
+	}
+
+	words, err := splitCommandLine(alias)
+	if err != nil {
+		return nil, fmt.Errorf("invalid alias '%s': %w", name, err)
+	}
+	if len(words) == 0 {
+		return nil, fmt.Errorf("alias '%s' is empty", name)
+	}
+	log.Debug("Alias expanded", "alias", name, "command", alias)
+
+	expanded := make([]string, 0, len(args)+len(words))
+	expanded = append(expanded, args[:index]...)
+	expanded = append(expanded, words...)
+	return append(expanded, args[index+1:]...), nil
+}
+
+// commandIndex returns the index of the first argument that is not a global flag, or -1
+func commandIndex(args []string) int {
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		switch {
+		case arg == "--":
+			return -1
+		case valueFlags[arg]:
+			i++ // skip the flag value
+		case strings.HasPrefix(arg, "-"):
+			continue
+		default:
+			return i
+		}
+	}
+	return -1
+}
+
+// configFlag returns the value of the --config flag, or "" for the default config file
+func configFlag(args []string) string {
+	for i, arg := range args {
+		if (arg == "-c" || arg == "--config") && i+1 < len(args) {
+			return args[i+1]
+		}
+		if value, ok := strings.CutPrefix(arg, "--config="); ok {
+			return value
+		}
+	}
+	return ""
+}
+
+// isBuiltinCommand reports whether name is a command or command alias of yt
+func isBuiltinCommand(name string) bool {
+	if name == "help" || strings.HasPrefix(name, "__") {
+		return true
+	}
+	for _, cmd := range rootCmd.Commands() {
+		if cmd.Name() == name || cmd.HasAlias(name) {
+			return true
+		}
+	}
+	return false
+}
+
+// splitCommandLine splits a command line into words like a POSIX shell: words are separated
+// by spaces, single quotes keep their content as is, double quotes and backslashes escape
+func splitCommandLine(line string) ([]string, error) {
+	var words []string
+	var word strings.Builder
+	inWord := false
+	var quote rune
+
+	runes := []rune(line)
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+		switch {
+		case quote == '\'':
+			if r == '\'' {
+				quote = 0
+			} else {
+				word.WriteRune(r)
+			}
+		case quote == '"':
+			switch {
+			case r == '"':
+				quote = 0
+			case r == '\\' && i+1 < len(runes) && strings.ContainsRune(`"\$`+"`", runes[i+1]):
+				i++
+				word.WriteRune(runes[i])
+			default:
+				word.WriteRune(r)
+			}
+		case r == '\'' || r == '"':
+			quote = r
+			inWord = true
+		case r == '\\':
+			if i+1 < len(runes) {
+				i++
+				word.WriteRune(runes[i])
+			}
+			inWord = true
+		case r == ' ' || r == '\t' || r == '\n':
+			if inWord {
+				words = append(words, word.String())
+				word.Reset()
+				inWord = false
+			}
+		default:
+			word.WriteRune(r)
+			inWord = true
+		}
+	}
+	if quote != 0 {
+		return nil, fmt.Errorf("unterminated %c quote", quote)
+	}
+	if inWord {
+		words = append(words, word.String())
+	}
+	return words, nil
+}
+
+// osArgs returns the command line arguments, with an alias expanded
+func osArgs() []string {
+	args, err := expandAlias(os.Args[1:])
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Error:", err)
+		os.Exit(1)
+	}
+	return args
+}
@@ -0,0 +1,231 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/log"
+	"github.com/spf13/cobra"
+
+	"github.com/mkozhukh/youtrack/internal/yt/cache"
+	"github.com/mkozhukh/youtrack/internal/yt/config"
+	"github.com/mkozhukh/youtrack/internal/yt/projects"
+	"github.com/mkozhukh/youtrack/internal/yt/theme"
+	"github.com/mkozhukh/youtrack/pkg/youtrack"
+)
+
+// Separators of the git log format: fields and records
+const (
+	gitFieldSeparator  = "\x1f"
+	gitRecordSeparator = "\x1e"
+)
+
+var (
+	annotateRange    string
+	annotateNoMerges bool
+)
+
+// AnnotatedCommit is a commit with the tickets its message names
+type AnnotatedCommit struct {
+	Hash    string   `json:"hash"`
+	Short   string   `json:"short"`
+	Author  string   `json:"author"`
+	Date    string   `json:"date"`
+	Subject string   `json:"subject"`
+	Tickets []string `json:"tickets"`
+}
+
+// AnnotatedTicket is a ticket named by commits of the range
+type AnnotatedTicket struct {
+	ID       string   `json:"id"`
+	Found    bool     `json:"found"`
+	Summary  string   `json:"summary,omitempty"`
+	State    string   `json:"state,omitempty"`
+	Resolved bool     `json:"resolved"`
+	Commits  []string `json:"commits"`
+}
+
+// Annotation is the commit list of a range annotated with the tickets
+type Annotation struct {
+	Range   string             `json:"range"`
+	Commits []*AnnotatedCommit `json:"commits"`
+	Tickets []*AnnotatedTicket `json:"tickets"`
+}
+
+// IssueIDs returns the tickets found in YouTrack, in order of first mention, for --output ids
+func (a *Annotation) IssueIDs() []string {
+	var ids []string
+	for _, ticket := range a.Tickets {
+		if ticket.Found {
+			ids = append(ids, ticket.ID)
+		}
+	}
+	return ids
+}
+
+// annotateCmd represents the annotate command
+var annotateCmd = &cobra.Command{
+	Use:   "annotate",
+	Short: "Annotates the commits of a range with the tickets they name",
+	Long: `Scans the git log of a range for ticket IDs, fetches the summaries and states of the
+tickets, and prints the commit list with the tickets under each commit, followed by the
+tickets of the range. Only IDs of existing YouTrack projects are picked, in any case
+("prj-12" names PRJ-12), so that "UTF-8" or IDs of other trackers are skipped.
+
+Without --range, the commits since the latest tag are annotated.`,
+	Example: `  yt annotate --range v1.2..HEAD
+  yt annotate --range v1.2..v1.3 --no-merges -o ids | yt tickets tag - released-1.3`,
+	Args: cobra.NoArgs,
+	RunE: annotateCommits,
+}
+
+func init() {
+	annotateCmd.Flags().StringVar(&annotateRange, "range", "", "Git revision range, e.g. v1.2..HEAD (defaults to the commits since the latest tag)")
+	annotateCmd.Flags().BoolVar(&annotateNoMerges, "no-merges", false, "Skip merge commits")
+}
+
+func annotateCommits(cmd *cobra.Command, args []string) error {
+	// Load configuration
+	cfg, err := config.Load(cfgFile, cmd.Flags())
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+
+	// Validate configuration
+	if err := cfg.Validate(); err != nil {
+		return fmt.Errorf("invalid configuration: %w", err)
+	}
+
+	revRange := annotateRange
+	if revRange == "" {
+		tag, err := runGit("describe", "--tags", "--abbrev=0")
+		if err != nil {
+			return fmt.Errorf("no tag to start from, use --range: %w", err)
+		}
+		revRange = tag + "..HEAD"
+	}
+
+	gitArgs := []string{"log", "--date=short", "--format=%H%x1f%h%x1f%an%x1f%ad%x1f%s%x1f%b%x1e"}
+	if annotateNoMerges {
+		gitArgs = append(gitArgs, "--no-merges")
+	}
+	out, err := runGit(append(gitArgs, revRange, "--")...)
+	if err != nil {
+		return err
+	}
+
+	// Create client and context
+	client := cfg.NewClient()
+	ctx := youtrack.NewYouTrackContext(context.Background(), cfg.Server.Token)
+
+	// Ticket IDs are matched against the project short names, IDs of other trackers are skipped
+	var prefixes []string
+	if list, err := projects.List(cache.Open(cfg), client, ctx); err != nil {
+		log.Warn("Failed to list projects, only upper-case ticket IDs are picked", "error", err)
+	} else {
+		for _, project := range list {
+			prefixes = append(prefixes, project.ShortName)
+		}
+	}
+
+	annotation := &Annotation{Range: revRange, Commits: []*AnnotatedCommit{}, Tickets: []*AnnotatedTicket{}}
+	tickets := make(map[string]*AnnotatedTicket)
+	var ids []string
+	for _, record := range strings.Split(out, gitRecordSeparator) {
+		fields := strings.Split(strings.TrimLeft(record, "\n"), gitFieldSeparator)
+		if len(fields) < 6 {
+			continue
+		}
+		commit := &AnnotatedCommit{Hash: fields[0], Short: fields[1], Author: fields[2], Date: fields[3], Subject: fields[4]}
+		commit.Tickets = youtrack.ExtractIssueIDs(fields[4]+"\n"+fields[5], prefixes)
+		if commit.Tickets == nil {
+			commit.Tickets = []string{}
+		}
+		for _, id := range commit.Tickets {
+			ticket, ok := tickets[id]
+			if !ok {
+				ticket = &AnnotatedTicket{ID: id}
+				tickets[id] = ticket
+				ids = append(ids, id)
+				annotation.Tickets = append(annotation.Tickets, ticket)
+			}
+			ticket.Commits = append(ticket.Commits, commit.Short)
+		}
+		annotation.Commits = append(annotation.Commits, commit)
+	}
+
+	log.Info("Fetching tickets", "range", revRange, "commits", len(annotation.Commits), "tickets", len(ids))
+	issues, err := client.GetIssuesByIDs(ctx, ids)
+	if err != nil {
+		return fmt.Errorf("failed to fetch tickets: %w", err)
+	}
+	for _, issue := range issues {
+		if ticket, ok := tickets[issue.ID]; ok {
+			ticket.Found = true
+			ticket.Summary = issue.Summary
+			ticket.State = issue.State
+			ticket.Resolved = issue.Resolved != nil
+		}
+	}
+
+	return outputResult(annotation, formatAnnotation)
+}
+
+func formatAnnotation(data interface{}) error {
+	annotation := data.(*Annotation)
+	if len(annotation.Commits) == 0 {
+		fmt.Printf("No commits in %s.\n", annotation.Range)
+		return nil
+	}
+
+	th := theme.Current()
+	hashStyle, ticketStyle, dimStyle := th.Code, th.Accent, th.Cell
+
+	tickets := make(map[string]*AnnotatedTicket, len(annotation.Tickets))
+	for _, ticket := range annotation.Tickets {
+		tickets[ticket.ID] = ticket
+	}
+
+	withTickets := 0
+	for _, commit := range annotation.Commits {
+		fmt.Printf("%s %s\n", hashStyle.Render(commit.Short), commit.Subject)
+		if len(commit.Tickets) == 0 {
+			fmt.Printf("        %s\n", dimStyle.Render("(no ticket)"))
+			continue
+		}
+		withTickets++
+		for _, id := range commit.Tickets {
+			fmt.Printf("        %s %s\n", ticketStyle.Render(id), describeAnnotatedTicket(tickets[id]))
+		}
+	}
+
+	if len(annotation.Tickets) > 0 {
+		fmt.Printf("\nTickets:\n")
+		for _, ticket := range annotation.Tickets {
+			fmt.Printf("  %s %s (%d commit(s))\n", ticketStyle.Render(ticket.ID), describeAnnotatedTicket(ticket), len(ticket.Commits))
+		}
+	}
+
+	resolved := 0
+	for _, ticket := range annotation.Tickets {
+		if ticket.Resolved {
+			resolved++
+		}
+	}
+	fmt.Printf("\n%d commit(s) in %s, %d naming tickets; %d ticket(s), %d resolved\n",
+		len(annotation.Commits), annotation.Range, withTickets, len(annotation.Tickets), resolved)
+	return nil
+}
+
+// describeAnnotatedTicket formats the state and summary of a ticket
+func describeAnnotatedTicket(ticket *AnnotatedTicket) string {
+	if !ticket.Found {
+		return "(not found in YouTrack)"
+	}
+	state := ticket.State
+	if state == "" {
+		state = "-"
+	}
+	return fmt.Sprintf("[%s] %s", state, ticket.Summary)
+}
@@ -0,0 +1,231 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/log"
+	"github.com/spf13/cobra"
+
+	"github.com/mkozhukh/youtrack/internal/yt/cache"
+	"github.com/mkozhukh/youtrack/internal/yt/config"
+	"github.com/mkozhukh/youtrack/internal/yt/projects"
+	"github.com/mkozhukh/youtrack/internal/yt/theme"
+	"github.com/mkozhukh/youtrack/pkg/youtrack"
+)
+
+const (
+	// boardMinColumnWidth and boardMaxColumnWidth bound the width of a board column
+	boardMinColumnWidth = 24
+	boardMaxColumnWidth = 40
+	// boardDefaultWidth is the board width when the output is not a terminal
+	boardDefaultWidth = 120
+)
+
+var (
+	boardField     string
+	boardQuery     string
+	boardLimit     int
+	boardHideEmpty bool
+)
+
+// Board is the issues of a project grouped in columns by the values of a field
+type Board struct {
+	Project string         `json:"project"`
+	Field   string         `json:"field"`
+	Columns []*BoardColumn `json:"columns"`
+}
+
+// IssueIDs returns the IDs of the cards, column by column, for --output ids
+func (b *Board) IssueIDs() []string {
+	var ids []string
+	for _, column := range b.Columns {
+		for _, card := range column.Cards {
+			ids = append(ids, card.ID)
+		}
+	}
+	return ids
+}
+
+// BoardColumn is the issues with one value of the board field
+type BoardColumn struct {
+	Value   string       `json:"value"`
+	Cards   []*BoardCard `json:"cards"`
+	HasMore bool         `json:"hasMore,omitempty"`
+}
+
+// BoardCard is an issue shown on the board
+type BoardCard struct {
+	ID       string `json:"id"`
+	Summary  string `json:"summary"`
+	Assignee string `json:"assignee,omitempty"`
+}
+
+// boardCmd represents the board command
+var boardCmd = &cobra.Command{
+	Use:   "board [project]",
+	Short: "Shows the issues of a project as a Kanban board",
+	Long: `Shows the issues of a project as a Kanban board: one column per value of a field
+(State by default), in the field's bundle order, with a card per issue showing its ID,
+summary and assignee. Each column is loaded with one query and shows at most --limit
+cards. The columns wrap to fit the terminal width.`,
+	Example: `  yt board PRJ
+  yt board PRJ --field Priority --query "#Unresolved"`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: showBoard,
+}
+
+func init() {
+	boardCmd.Flags().StringVarP(&boardField, "field", "f", "State", "Custom field whose values are the columns")
+	boardCmd.Flags().StringVarP(&boardQuery, "query", "q", "", "YouTrack search query narrowing down the issues")
+	boardCmd.Flags().IntVarP(&boardLimit, "limit", "l", 10, "Maximum number of cards per column")
+	boardCmd.Flags().BoolVar(&boardHideEmpty, "hide-empty", false, "Leave out the columns without issues")
+}
+
+func showBoard(cmd *cobra.Command, args []string) error {
+	if boardLimit < 1 {
+		return fmt.Errorf("--limit must be at least 1")
+	}
+
+	// Load configuration
+	cfg, err := config.Load(cfgFile, cmd.Flags())
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+
+	// Validate configuration
+	if err := cfg.Validate(); err != nil {
+		return fmt.Errorf("invalid configuration: %w", err)
+	}
+
+	// Determine project ID to use
+	projectID := cfg.Defaults.Project
+	if len(args) > 0 {
+		projectID = args[0]
+	}
+	if projectID == "" {
+		return fmt.Errorf("project ID is required (pass it as argument or set default project in config)")
+	}
+
+	// Create client and context
+	client := cfg.NewClient()
+	ctx := youtrack.NewYouTrackContext(context.Background(), cfg.Server.Token)
+
+	// Resolve the project by short name or name
+	project, err := projects.Resolve(cache.Open(cfg), client, ctx, projectID)
+	if err != nil {
+		return err
+	}
+
+	values, err := client.GetCustomFieldAllowedValues(ctx, project.ShortName, boardField)
+	if err != nil {
+		log.Error("Failed to get field values", "field", boardField, "error", err)
+		return fmt.Errorf("failed to get the values of %s: %w", boardField, err)
+	}
+
+	board := &Board{Project: project.ShortName, Field: boardField}
+	for _, value := range values {
+		query := strings.TrimSpace(fmt.Sprintf("project: %s {%s}: {%s} %s", project.ShortName, boardField, value.Name, boardQuery))
+		log.Info("Loading board column", "query", query)
+
+		// One more issue than shown tells whether the column has more
+		issues, err := client.SearchIssues(ctx, query, 0, boardLimit+1)
+		if err != nil {
+			log.Error("Failed to search issues", "error", err)
+			return fmt.Errorf("failed to load the %s column: %w", value.Name, err)
+		}
+		if len(issues) == 0 && boardHideEmpty {
+			continue
+		}
+
+		column := &BoardColumn{Value: value.Name, Cards: []*BoardCard{}}
+		if len(issues) > boardLimit {
+			column.HasMore = true
+			issues = issues[:boardLimit]
+		}
+		for _, issue := range issues {
+			card := &BoardCard{ID: issue.ID, Summary: issue.Summary}
+			if issue.Assignee != nil {
+				card.Assignee = issue.Assignee.Login
+			}
+			column.Cards = append(column.Cards, card)
+		}
+		board.Columns = append(board.Columns, column)
+	}
+
+	return outputResult(board, func(data interface{}) error {
+		return formatBoard(data.(*Board))
+	})
+}
+
+// formatBoard renders the board columns side by side, wrapping them into rows that fit the terminal
+func formatBoard(board *Board) error {
+	if len(board.Columns) == 0 {
+		fmt.Printf("No issues in %s.\n", board.Project)
+		return nil
+	}
+
+	width := boardDefaultWidth
+	if w := theme.TerminalWidth(); w > 0 {
+		width = w
+	}
+
+	// Each column has a border of one character on each side and a gap of one
+	perRow := max(1, min(len(board.Columns), width/(boardMinColumnWidth+3)))
+	columnWidth := min(boardMaxColumnWidth, max(boardMinColumnWidth, width/perRow-3))
+
+	th := theme.Current()
+	headerStyle := th.Header
+	idStyle := th.Accent
+	summaryStyle := th.Cell
+	assigneeStyle := th.Muted
+	cardStyle := lipgloss.NewStyle().
+		Width(columnWidth-2).
+		Border(lipgloss.NormalBorder(), false, false, true, false).
+		BorderForeground(th.Separator.GetForeground())
+	columnStyle := lipgloss.NewStyle().
+		Width(columnWidth).
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(th.Border.GetForeground()).
+		Padding(0, 1).
+		MarginRight(1)
+
+	var rendered []string
+	for _, column := range board.Columns {
+		count := fmt.Sprintf("%d", len(column.Cards))
+		if column.HasMore {
+			count += "+"
+		}
+		parts := []string{headerStyle.Render(fmt.Sprintf("%s (%s)", column.Value, count))}
+
+		for _, card := range column.Cards {
+			lines := []string{idStyle.Render(card.ID), summaryStyle.Render(truncate(card.Summary, 2*(columnWidth-2)))}
+			if card.Assignee != "" {
+				lines = append(lines, assigneeStyle.Render("@"+card.Assignee))
+			}
+			parts = append(parts, cardStyle.Render(strings.Join(lines, "\n")))
+		}
+		if column.HasMore {
+			parts = append(parts, assigneeStyle.Render("..."))
+		}
+		rendered = append(rendered, columnStyle.Render(strings.Join(parts, "\n")))
+	}
+
+	fmt.Printf("%s board by %s\n", board.Project, board.Field)
+	for start := 0; start < len(rendered); start += perRow {
+		end := min(start+perRow, len(rendered))
+		fmt.Println(lipgloss.JoinHorizontal(lipgloss.Top, rendered[start:end]...))
+	}
+	return nil
+}
+
+// truncate shortens text to at most n characters, marking the cut with an ellipsis
+func truncate(text string, n int) string {
+	runes := []rune(text)
+	if len(runes) <= n {
+		return text
+	}
+	return string(runes[:n-3]) + "..."
+}
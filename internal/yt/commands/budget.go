@@ -0,0 +1,260 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/log"
+	"github.com/spf13/cobra"
+
+	"github.com/mkozhukh/youtrack/internal/yt/cache"
+	"github.com/mkozhukh/youtrack/internal/yt/config"
+	"github.com/mkozhukh/youtrack/internal/yt/projects"
+	"github.com/mkozhukh/youtrack/internal/yt/theme"
+	"github.com/mkozhukh/youtrack/pkg/youtrack"
+)
+
+// defaultBudgetWarnAt is the percentage of a budget past which the report warns when the
+// config sets no threshold
+const defaultBudgetWarnAt = 80
+
+// Budget statuses
+const (
+	budgetOK       = "ok"
+	budgetWarning  = "warning"
+	budgetOver     = "over"
+	budgetNoBudget = "no budget"
+)
+
+// BudgetReport is the cost of the time logged in a project compared with its budget
+type BudgetReport struct {
+	Project  string        `json:"project"`
+	Since    string        `json:"since"`
+	Until    string        `json:"until"`
+	Currency string        `json:"currency,omitempty"`
+	Users    []*BudgetUser `json:"users"`
+	Minutes  int           `json:"minutes"`
+	Cost     float64       `json:"cost"`
+	// UnratedMinutes is the time of users without an hourly rate, not priced
+	UnratedMinutes int      `json:"unratedMinutes"`
+	Budget         float64  `json:"budget"`
+	Remaining      float64  `json:"remaining"`
+	UsedPercent    float64  `json:"usedPercent"`
+	Status         string   `json:"status"`
+	Warnings       []string `json:"warnings,omitempty"`
+}
+
+// BudgetUser is the time a user logged in the project and its cost
+type BudgetUser struct {
+	Login   string  `json:"login"`
+	Name    string  `json:"name"`
+	Minutes int     `json:"minutes"`
+	Rate    float64 `json:"rate"`
+	Rated   bool    `json:"rated"`
+	Cost    float64 `json:"cost"`
+}
+
+func reportBudget(cmd *cobra.Command, args []string) error {
+	// Load configuration
+	cfg, err := config.Load(cfgFile, cmd.Flags())
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+
+	// Validate configuration
+	if err := cfg.Validate(); err != nil {
+		return fmt.Errorf("invalid configuration: %w", err)
+	}
+
+	// Determine project ID to use
+	projectID := reportProject
+	if projectID == "" {
+		projectID = cfg.Defaults.Project
+	}
+	if projectID == "" {
+		return fmt.Errorf("project ID is required (use --project flag or set default project in config)")
+	}
+
+	// Create client and context
+	client := cfg.NewClient()
+	ctx := youtrack.NewYouTrackContext(context.Background(), cfg.Server.Token)
+	store := cache.Open(cfg)
+
+	// Resolve the project by short name or name
+	project, err := projects.Resolve(store, client, ctx, projectID)
+	if err != nil {
+		return err
+	}
+	projectID = project.ShortName
+	budget := cfg.ProjectBudget(projectID)
+
+	// Default to the budget start, or the current month
+	now := time.Now()
+	since, until := budgetSince, budgetUntil
+	if since == "" {
+		since = budget.Start
+	}
+	if since == "" {
+		since = time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.Local).Format("2006-01-02")
+	}
+	if until == "" {
+		until = now.Format("2006-01-02")
+	}
+	if _, err := parseDate(since); err != nil {
+		return fmt.Errorf("invalid start date format: %s (use YYYY-MM-DD)", since)
+	}
+	if _, err := parseDate(until); err != nil {
+		return fmt.Errorf("invalid end date format: %s (use YYYY-MM-DD)", until)
+	}
+
+	users, err := cachedProjectUsers(store, client, ctx, projectID)
+	if err != nil {
+		return err
+	}
+
+	log.Info("Collecting team worklogs", "project", projectID, "users", len(users), "since", since, "until", until)
+	workItems, err := fetchTeamWorklogs(client, ctx, users, projectID, since, until, budgetWorkers)
+	if err != nil {
+		return err
+	}
+
+	report := buildBudgetReport(cfg, projectID, users, workItems)
+	report.Since, report.Until = since, until
+	return outputResult(report, formatBudgetReport)
+}
+
+// buildBudgetReport prices the users' work items and compares the total with the project budget
+func buildBudgetReport(cfg *config.Config, projectID string, users []*youtrack.User, workItems map[string][]*youtrack.WorkItem) *BudgetReport {
+	budget := cfg.ProjectBudget(projectID)
+	report := &BudgetReport{Project: projectID, Currency: cfg.Budget.Currency, Budget: budget.Amount, Users: []*BudgetUser{}}
+
+	var unrated []string
+	for _, user := range users {
+		minutes := 0
+		for _, item := range workItems[user.ID] {
+			minutes += item.Duration.Minutes
+		}
+		if minutes == 0 {
+			continue
+		}
+
+		row := &BudgetUser{Login: user.Login, Name: user.FullName, Minutes: minutes}
+		row.Rate, row.Rated = cfg.HourlyRate(projectID, user.Login)
+		if row.Rated {
+			row.Cost = float64(minutes) / 60 * row.Rate
+			report.Cost += row.Cost
+		} else {
+			report.UnratedMinutes += minutes
+			unrated = append(unrated, user.Login)
+		}
+		report.Minutes += minutes
+		report.Users = append(report.Users, row)
+	}
+	sort.SliceStable(report.Users, func(i, j int) bool {
+		return report.Users[i].Cost > report.Users[j].Cost
+	})
+
+	if report.Budget <= 0 {
+		report.Status = budgetNoBudget
+		report.Warnings = append(report.Warnings, fmt.Sprintf("No budget configured for %s (set project.%s.budget.amount)", projectID, projectID))
+	} else {
+		report.Remaining = report.Budget - report.Cost
+		report.UsedPercent = report.Cost / report.Budget * 100
+		report.Status = budgetOK
+
+		warnAt := budget.WarnAt
+		if len(warnAt) == 0 {
+			warnAt = cfg.Budget.WarnAt
+		}
+		if len(warnAt) == 0 {
+			warnAt = []float64{defaultBudgetWarnAt}
+		}
+		passed := 0.0
+		for _, threshold := range warnAt {
+			if report.UsedPercent >= threshold && threshold > passed {
+				passed = threshold
+			}
+		}
+
+		switch {
+		case report.Cost > report.Budget:
+			report.Status = budgetOver
+			report.Warnings = append(report.Warnings, fmt.Sprintf("Over budget by %s (%.0f%% used)",
+				formatMoney(-report.Remaining, report.Currency), report.UsedPercent))
+		case passed > 0:
+			report.Status = budgetWarning
+			report.Warnings = append(report.Warnings, fmt.Sprintf("%.0f%% of the budget used, past the %g%% threshold", report.UsedPercent, passed))
+		}
+	}
+
+	if report.UnratedMinutes > 0 {
+		report.Warnings = append(report.Warnings, fmt.Sprintf("%s logged by users without an hourly rate is not priced: %s",
+			formatDuration(report.UnratedMinutes), strings.Join(unrated, ", ")))
+	}
+	return report
+}
+
+// formatMoney formats an amount with two decimals and the currency, when set
+func formatMoney(amount float64, currency string) string {
+	if currency == "" {
+		return fmt.Sprintf("%.2f", amount)
+	}
+	return fmt.Sprintf("%.2f %s", amount, currency)
+}
+
+func formatBudgetReport(data interface{}) error {
+	report := data.(*BudgetReport)
+
+	th := theme.Current()
+	warnStyle := th.Warn
+	if report.Status == budgetOver {
+		warnStyle = th.Error
+	}
+
+	fmt.Printf("%s\n\n", th.Header.Render(fmt.Sprintf("Budget of %s, %s to %s", report.Project, report.Since, report.Until)))
+
+	if len(report.Users) == 0 {
+		fmt.Println("No time logged in the period.")
+	} else {
+		t := th.Table("USER", "TIME", "RATE", "COST").
+			Style(func(row, col int) lipgloss.Style {
+				if row == len(report.Users) {
+					return th.Header
+				}
+				return th.Cell
+			})
+
+		for _, user := range report.Users {
+			name := user.Name
+			if name == "" {
+				name = user.Login
+			}
+			rate, cost := "-", "-"
+			if user.Rated {
+				rate = formatMoney(user.Rate, "") + "/h"
+				cost = formatMoney(user.Cost, report.Currency)
+			}
+			t.Row(name, formatDuration(user.Minutes), rate, cost)
+		}
+		t.Row("TOTAL", formatDuration(report.Minutes), "", formatMoney(report.Cost, report.Currency))
+		fmt.Println(t)
+	}
+
+	if report.Budget > 0 {
+		fmt.Printf("\nBudget:    %s\n", formatMoney(report.Budget, report.Currency))
+		fmt.Printf("Spent:     %s (%.1f%%)\n", formatMoney(report.Cost, report.Currency), report.UsedPercent)
+		fmt.Printf("Remaining: %s\n", formatMoney(report.Remaining, report.Currency))
+	}
+
+	if len(report.Warnings) > 0 {
+		fmt.Println()
+		for _, warning := range report.Warnings {
+			fmt.Println(warnStyle.Render("! " + warning))
+		}
+	}
+	return nil
+}
@@ -0,0 +1,68 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/charmbracelet/log"
+	"github.com/spf13/cobra"
+
+	"github.com/mkozhukh/youtrack/internal/yt/cache"
+	"github.com/mkozhukh/youtrack/internal/yt/config"
+)
+
+// Cache entry kinds
+const (
+	cacheKindUsers        = "users"
+	cacheKindCustomFields = "custom_fields"
+)
+
+var cacheProject string
+
+// cacheCmd represents the cache command
+var cacheCmd = &cobra.Command{
+	Use:   "cache",
+	Short: "Manage the local metadata cache",
+	Long:  `Manage the local cache of project metadata (custom fields, users) used to speed up commands.`,
+}
+
+// clearCacheCmd represents the cache clear command
+var clearCacheCmd = &cobra.Command{
+	Use:   "clear",
+	Short: "Drops cached project metadata",
+	Long: `Drops cached project metadata (custom fields, users), optionally for a single project.
+Use it when project configuration changed and commands show stale values.`,
+	Args: cobra.NoArgs,
+	RunE: clearCache,
+}
+
+func init() {
+	cacheCmd.AddCommand(clearCacheCmd)
+
+	clearCacheCmd.Flags().StringVarP(&cacheProject, "project", "p", "", "Only drop the cache for this project")
+}
+
+func clearCache(cmd *cobra.Command, args []string) error {
+	// Load configuration
+	cfg, err := config.Load(cfgFile, cmd.Flags())
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+
+	store := cache.Open(cfg)
+
+	if cacheProject != "" {
+		log.Info("Clearing project cache", "project", cacheProject, "dir", store.Dir())
+		if err := store.ClearProject(cacheProject); err != nil {
+			return err
+		}
+		fmt.Printf("Cache cleared for project %s.\n", cacheProject)
+		return nil
+	}
+
+	log.Info("Clearing cache", "dir", store.Dir())
+	if err := store.Clear(); err != nil {
+		return err
+	}
+	fmt.Println("Cache cleared for all projects.")
+	return nil
+}
@@ -0,0 +1,84 @@
+package commands
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/mkozhukh/youtrack/pkg/youtrack"
+)
+
+// calendarEvent is an all-day event for an issue due date
+type calendarEvent struct {
+	Issue *youtrack.Issue
+	Date  time.Time
+}
+
+// writeCalendar writes the events as an iCalendar (RFC 5545) document
+func writeCalendar(w io.Writer, serverURL string, project *youtrack.Project, events []calendarEvent) error {
+	host := serverURL
+	if u, err := url.Parse(serverURL); err == nil && u.Host != "" {
+		host = u.Host
+	}
+	stamp := time.Now().UTC().Format("20060102T150405Z")
+
+	bw := bufio.NewWriter(w)
+	writeLine := func(line string) {
+		bw.WriteString(foldICSLine(line))
+		bw.WriteString("\r\n")
+	}
+
+	writeLine("BEGIN:VCALENDAR")
+	writeLine("VERSION:2.0")
+	writeLine("PRODID:-//yt//YouTrack CLI//EN")
+	writeLine("CALSCALE:GREGORIAN")
+	writeLine("X-WR-CALNAME:" + escapeICSText(project.Name+" due dates"))
+
+	for _, event := range events {
+		issue := event.Issue
+		writeLine("BEGIN:VEVENT")
+		writeLine(fmt.Sprintf("UID:%s@%s", issue.ID, host))
+		writeLine("DTSTAMP:" + stamp)
+		writeLine("DTSTART;VALUE=DATE:" + event.Date.Format("20060102"))
+		writeLine("DTEND;VALUE=DATE:" + event.Date.AddDate(0, 0, 1).Format("20060102"))
+		writeLine("SUMMARY:" + escapeICSText(fmt.Sprintf("%s %s", issue.ID, issue.Summary)))
+		if issue.State != "" {
+			writeLine("DESCRIPTION:" + escapeICSText("State: "+issue.State))
+		}
+		writeLine(fmt.Sprintf("URL:%s/issue/%s", strings.TrimRight(serverURL, "/"), issue.ID))
+		writeLine("END:VEVENT")
+	}
+
+	writeLine("END:VCALENDAR")
+	return bw.Flush()
+}
+
+// escapeICSText escapes a TEXT property value
+func escapeICSText(s string) string {
+	return strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`).Replace(s)
+}
+
+// foldICSLine folds a content line into chunks of at most 75 octets,
+// continuation lines start with a space. UTF-8 sequences are kept intact.
+func foldICSLine(line string) string {
+	const limit = 75
+	if len(line) <= limit {
+		return line
+	}
+
+	var sb strings.Builder
+	width := 0
+	for _, r := range line {
+		size := len(string(r))
+		if width+size > limit {
+			sb.WriteString("\r\n ")
+			width = 1
+		}
+		sb.WriteRune(r)
+		width += size
+	}
+	return sb.String()
+}
@@ -0,0 +1,488 @@
+package commands
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"syscall"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/log"
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+
+	"github.com/mkozhukh/youtrack/internal/timer"
+	"github.com/mkozhukh/youtrack/internal/yt/cache"
+	"github.com/mkozhukh/youtrack/internal/yt/config"
+	"github.com/mkozhukh/youtrack/internal/yt/journal"
+	"github.com/mkozhukh/youtrack/internal/yt/projects"
+	"github.com/mkozhukh/youtrack/internal/yt/theme"
+	"github.com/mkozhukh/youtrack/pkg/youtrack"
+)
+
+// Doctor check outcomes
+const (
+	checkOK   = "ok"
+	checkWarn = "warn"
+	checkFail = "fail"
+)
+
+var (
+	configReveal   bool
+	configListKeys bool
+)
+
+// DoctorCheck is the outcome of one configuration check
+type DoctorCheck struct {
+	Name    string `json:"name"`
+	Status  string `json:"status"`
+	Message string `json:"message"`
+}
+
+// configCmd represents the config command
+var configCmd = &cobra.Command{
+	Use:   "config",
+	Short: "Manage the yt configuration",
+	Long:  `Create, edit, show and check the yt configuration file (~/.config/yt/config.toml by default).`,
+}
+
+// initConfigCmd represents the config init command
+var initConfigCmd = &cobra.Command{
+	Use:   "init",
+	Short: "Creates the configuration with an interactive wizard",
+	Long: `Asks for the YouTrack URL, a permanent token, the Hub URL and the default project,
+checks the connection and the project, and saves them to the configuration file. Current
+values are offered as defaults, so the wizard also updates an existing configuration;
+other settings in the file are kept.`,
+	Args: cobra.NoArgs,
+	RunE: initConfig,
+}
+
+// setConfigCmd represents the config set command
+var setConfigCmd = &cobra.Command{
+	Use:   "set <key> <value>",
+	Short: "Sets a configuration value",
+	Long: `Sets a key of the configuration file, keeping the other settings. Keys are dotted
+paths such as defaults.project or timer.round_minutes; values are converted to the key's type.`,
+	Example: `  yt config set defaults.project PRJ
+  yt config set timer.round_minutes 15
+  yt config set sla.priorities.Critical.first_response_hours 1`,
+	Args: cobra.ExactArgs(2),
+	RunE: setConfig,
+}
+
+// getConfigCmd represents the config get command
+var getConfigCmd = &cobra.Command{
+	Use:   "get [key]",
+	Short: "Shows configuration values",
+	Long: `Shows the effective value of a key, or all values when no key is given, after the
+file, the YT_* environment variables and flags are combined. The token is masked unless
+--reveal is given.`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: getConfig,
+}
+
+// doctorConfigCmd represents the config doctor command
+var doctorConfigCmd = &cobra.Command{
+	Use:   "doctor",
+	Short: "Checks the configuration against the server",
+	Long: `Checks that the configuration works: the token is valid, the user ID matches it, the
+Hub URL answers, the default project is accessible with time tracking enabled, and the
+timer and worklog settings are sane, and no changes wait in the offline journal. Fails when any check fails.`,
+	Args: cobra.NoArgs,
+	RunE: doctorConfig,
+}
+
+func init() {
+	configCmd.AddCommand(initConfigCmd)
+	configCmd.AddCommand(setConfigCmd)
+	configCmd.AddCommand(getConfigCmd)
+	configCmd.AddCommand(doctorConfigCmd)
+
+	getConfigCmd.Flags().BoolVar(&configReveal, "reveal", false, "Show the token instead of masking it")
+	getConfigCmd.Flags().BoolVar(&configListKeys, "keys", false, "List the known configuration keys")
+}
+
+func initConfig(cmd *cobra.Command, args []string) error {
+	configPath := cfgFile
+	if configPath == "" {
+		configPath = config.GetConfigPath()
+	}
+
+	current, err := config.LoadFile(configPath)
+	if err != nil {
+		return err
+	}
+
+	reader := bufio.NewReader(os.Stdin)
+	fmt.Printf("Configuring %s (press Enter to keep the value in brackets)\n\n", configPath)
+
+	url, err := promptValue(reader, "YouTrack URL (e.g., https://youtrack.example.com)", current.Server.URL)
+	if err != nil {
+		return err
+	}
+	url = strings.TrimRight(url, "/")
+	if url == "" {
+		return fmt.Errorf("YouTrack URL cannot be empty")
+	}
+	if !strings.HasPrefix(url, "http://") && !strings.HasPrefix(url, "https://") {
+		return fmt.Errorf("YouTrack URL must start with http:// or https://")
+	}
+
+	fmt.Print("Permanent token")
+	if current.Server.Token != "" {
+		fmt.Print(" [keep current]")
+	}
+	fmt.Print(": ")
+	tokenBytes, err := term.ReadPassword(int(syscall.Stdin))
+	if err != nil {
+		return fmt.Errorf("failed to read token: %w", err)
+	}
+	fmt.Println() // Add newline after password input
+	token := strings.TrimSpace(string(tokenBytes))
+	if token == "" {
+		token = current.Server.Token
+	}
+	if token == "" {
+		return fmt.Errorf("permanent token cannot be empty")
+	}
+
+	// Test the connection
+	fmt.Println("Testing connection...")
+	client := youtrack.NewClient(url)
+	ctx := youtrack.NewYouTrackContext(context.Background(), token)
+	user, err := client.GetUser(ctx, "me")
+	if err != nil {
+		log.Error("Failed to connect to YouTrack", "error", err)
+		return fmt.Errorf("failed to verify connection: %w", err)
+	}
+	fmt.Printf("Authenticated as %s (%s)\n", user.FullName, user.Login)
+
+	hubURL, err := promptValue(reader, "Hub URL, needed for project teams (optional, e.g. "+url+"/hub)", current.Server.HubURL)
+	if err != nil {
+		return err
+	}
+	hubURL = strings.TrimRight(hubURL, "/")
+
+	project, err := promptValue(reader, "Default project (optional)", current.Defaults.Project)
+	if err != nil {
+		return err
+	}
+	if project != "" {
+		cfg := &config.Config{Server: config.ServerConfig{URL: url, Token: token}, Cache: current.Cache}
+		resolved, err := projects.Resolve(cache.Open(cfg), client, ctx, project)
+		if err != nil {
+			return err
+		}
+		if _, err := client.GetProject(ctx, resolved.ShortName); err != nil {
+			return fmt.Errorf("cannot access project %s: %w", resolved.ShortName, err)
+		}
+		project = resolved.ShortName
+	}
+
+	values := map[string]string{
+		"server.url":       url,
+		"server.token":     token,
+		"server.hub_url":   hubURL,
+		"defaults.project": project,
+		"defaults.user_id": user.ID,
+	}
+	if err := config.SetValues(configPath, values); err != nil {
+		return fmt.Errorf("failed to save configuration: %w", err)
+	}
+
+	fmt.Printf("\nConfiguration saved to: %s\n", configPath)
+	fmt.Println("Run 'yt config doctor' to check it.")
+	return nil
+}
+
+// promptValue asks for a value, returning the current one when the answer is empty
+func promptValue(reader *bufio.Reader, question, current string) (string, error) {
+	if current != "" {
+		question += fmt.Sprintf(" [%s]", current)
+	}
+	fmt.Print(question + ": ")
+	answer, err := reader.ReadString('\n')
+	if err != nil && answer == "" {
+		return "", fmt.Errorf("failed to read input: %w", err)
+	}
+	if answer = strings.TrimSpace(answer); answer != "" {
+		return answer, nil
+	}
+	return current, nil
+}
+
+func setConfig(cmd *cobra.Command, args []string) error {
+	configPath := cfgFile
+	if configPath == "" {
+		configPath = config.GetConfigPath()
+	}
+
+	if err := config.SetValue(configPath, args[0], args[1]); err != nil {
+		return err
+	}
+	log.Info("Configuration updated", "key", args[0], "path", configPath)
+	fmt.Printf("%s updated in %s\n", args[0], configPath)
+	return nil
+}
+
+func getConfig(cmd *cobra.Command, args []string) error {
+	if configListKeys {
+		return outputResult(config.Keys(), func(data interface{}) error {
+			for _, key := range data.([]string) {
+				fmt.Println(key)
+			}
+			return nil
+		})
+	}
+
+	// Load configuration
+	if _, err := config.Load(cfgFile, cmd.Flags()); err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+
+	if len(args) == 1 {
+		value, ok := config.Get(args[0])
+		if !ok {
+			return fmt.Errorf("%s is not set", args[0])
+		}
+		if _, isMap := value.(map[string]interface{}); !isMap {
+			value = shownConfigValue(args[0], value)
+		}
+		return outputResult(value, func(data interface{}) error {
+			if section, ok := data.(map[string]interface{}); ok {
+				return printConfigValues(flattenConfig(args[0], section))
+			}
+			fmt.Println(data)
+			return nil
+		})
+	}
+
+	values := config.All()
+	for key, value := range values {
+		values[key] = shownConfigValue(key, value)
+	}
+	return outputResult(values, func(data interface{}) error {
+		return printConfigValues(data.(map[string]interface{}))
+	})
+}
+
+// shownConfigValue masks secret values unless --reveal is given
+func shownConfigValue(key string, value interface{}) interface{} {
+	text := fmt.Sprint(value)
+	if !config.IsSecret(key) || configReveal || text == "" {
+		return value
+	}
+	if len(text) <= 8 {
+		return "****"
+	}
+	return text[:4] + "****" + text[len(text)-4:]
+}
+
+// flattenConfig flattens a config section to dotted keys, masking secrets
+func flattenConfig(prefix string, section map[string]interface{}) map[string]interface{} {
+	values := make(map[string]interface{})
+	for name, value := range section {
+		key := prefix + "." + name
+		if nested, ok := value.(map[string]interface{}); ok {
+			for k, v := range flattenConfig(key, nested) {
+				values[k] = v
+			}
+			continue
+		}
+		values[key] = shownConfigValue(key, value)
+	}
+	return values
+}
+
+// printConfigValues prints key = value lines sorted by key
+func printConfigValues(values map[string]interface{}) error {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		fmt.Printf("%s = %v\n", key, values[key])
+	}
+	return nil
+}
+
+func doctorConfig(cmd *cobra.Command, args []string) error {
+	var checks []*DoctorCheck
+	add := func(name, status, format string, a ...interface{}) {
+		checks = append(checks, &DoctorCheck{Name: name, Status: status, Message: fmt.Sprintf(format, a...)})
+	}
+
+	configPath := cfgFile
+	if configPath == "" {
+		configPath = config.GetConfigPath()
+	}
+	if _, err := os.Stat(configPath); err != nil {
+		add("config file", checkWarn, "%s not found, using environment variables only (run 'yt config init')", configPath)
+	} else {
+		add("config file", checkOK, "%s", configPath)
+	}
+
+	// Load configuration
+	cfg, err := config.Load(cfgFile, cmd.Flags())
+	if err != nil {
+		add("config file", checkFail, "cannot be loaded: %v", err)
+		return reportDoctor(checks)
+	}
+	if err := cfg.Validate(); err != nil {
+		add("server", checkFail, "%v", err)
+		return reportDoctor(checks)
+	}
+
+	client := cfg.NewClient()
+	ctx := youtrack.NewYouTrackContext(context.Background(), cfg.Server.Token)
+
+	user, err := client.GetUser(ctx, "me")
+	if err != nil {
+		if apiErr, ok := err.(*youtrack.APIError); ok && apiErr.StatusCode == 401 {
+			add("token", checkFail, "rejected by %s, the token is invalid, expired or revoked", cfg.Server.URL)
+		} else {
+			add("token", checkFail, "cannot reach %s: %v", cfg.Server.URL, err)
+		}
+		return reportDoctor(checks)
+	}
+	add("token", checkOK, "authenticated as %s (%s)", user.FullName, user.Login)
+
+	switch cfg.Defaults.UserID {
+	case "":
+		add("user id", checkWarn, "defaults.user_id is not set, commands defaulting to the current user look it up each time")
+	case user.ID:
+		add("user id", checkOK, "matches the token")
+	default:
+		add("user id", checkWarn, "defaults.user_id %s does not match the token's user %s", cfg.Defaults.UserID, user.ID)
+	}
+
+	if cfg.Defaults.Project == "" {
+		add("default project", checkWarn, "defaults.project is not set, commands need --project")
+	} else {
+		doctorProject(client, ctx, cfg, add)
+	}
+
+	timerOK := true
+	if cfg.Timer.RoundMinutes < 0 {
+		add("timer", checkFail, "timer.round_minutes must not be negative")
+		timerOK = false
+	}
+	if cfg.Timer.MaxSessionMinutes < -1 {
+		add("timer", checkFail, "timer.max_session_minutes must be -1 (no cap), 0 (8 hours) or a number of minutes")
+		timerOK = false
+	}
+	if timerOK {
+		rules := timerRules(cfg)
+		limit := "8h"
+		if rules.MaxSession < 0 {
+			limit = "none"
+		} else if rules.MaxSession > 0 {
+			limit = formatDuration(cfg.Timer.MaxSessionMinutes)
+		}
+		add("timer", checkOK, "rounding to %d minute(s), session cap %s", max(1, cfg.Timer.RoundMinutes), limit)
+	}
+	if t, err := timer.NewStore("").Current(); err != nil {
+		add("timer", checkWarn, "state file is unreadable: %v", err)
+	} else if t != nil {
+		add("timer", checkOK, "running for %s since %s", t.IssueID, t.Started.Format("2006-01-02 15:04"))
+	}
+
+	if cfg.Worklogs.DailyTargetMinutes < 0 {
+		add("worklogs", checkFail, "worklogs.daily_target_minutes must not be negative")
+	}
+
+	if cfg.Cache.Encrypt {
+		if _, err := cache.Open(cfg).Seal(nil); err != nil {
+			add("cache", checkFail, "encryption key is unusable: %v", err)
+		} else {
+			add("cache", checkOK, "encrypted with the key from %s", cache.KeySource(cfg))
+		}
+	}
+
+	if ops, err := journal.NewStore(cfg.Offline.Journal, cache.Open(cfg)).List(); err != nil {
+		add("offline", checkWarn, "journal is unreadable: %v", err)
+	} else if len(ops) > 0 {
+		add("offline", checkWarn, "%d change(s) queued since %s, run 'yt sync' to send them", len(ops), ops[0].Queued.Local().Format("2006-01-02 15:04"))
+	}
+
+	return reportDoctor(checks)
+}
+
+// doctorProject checks access to the default project, its team and its time tracking settings
+func doctorProject(client *youtrack.Client, ctx *youtrack.YouTrackContext, cfg *config.Config, add func(name, status, format string, a ...interface{})) {
+	project, err := projects.Resolve(cache.Open(cfg), client, ctx, cfg.Defaults.Project)
+	if err != nil {
+		add("default project", checkFail, "%v", err)
+		return
+	}
+	if _, err := client.GetProject(ctx, project.ShortName); err != nil {
+		add("default project", checkFail, "cannot access %s: %v", project.ShortName, err)
+		return
+	}
+	add("default project", checkOK, "%s is accessible", project.ShortName)
+
+	if perms, err := client.GetPermissions(ctx); err != nil {
+		add("permissions", checkWarn, "cannot read the permissions of the token: %v", err)
+	} else if missing := perms.Missing(project.ShortName, youtrack.WorkPermissions...); len(missing) > 0 {
+		add("permissions", checkWarn, "your token lacks %s in project %s", youtrack.PermissionNames(missing), project.ShortName)
+	} else {
+		add("permissions", checkOK, "issues can be read, created, updated, commented and timed in %s", project.ShortName)
+	}
+
+	if cfg.Server.HubURL == "" {
+		add("hub", checkWarn, "server.hub_url is not set, users are listed from the Assignee field instead of the project team")
+	} else if _, err := client.GetProjectTeam(ctx, project.ShortName, 0, 1); err != nil {
+		add("hub", checkFail, "cannot list the %s team at %s: %v", project.ShortName, cfg.Server.HubURL, err)
+	} else {
+		add("hub", checkOK, "%s answers", cfg.Server.HubURL)
+	}
+
+	settings, err := client.GetTimeTrackingSettings(ctx, project.ShortName)
+	switch {
+	case err != nil:
+		add("time tracking", checkWarn, "cannot read the settings of %s (project admin access is needed): %v", project.ShortName, err)
+	case !settings.Enabled:
+		add("time tracking", checkWarn, "disabled in %s, worklogs and the timer cannot log time there", project.ShortName)
+	default:
+		var types []string
+		for _, workType := range settings.WorkItemTypes {
+			types = append(types, workType.Name)
+		}
+		message := fmt.Sprintf("enabled in %s", project.ShortName)
+		if len(types) > 0 {
+			message += ", work types: " + strings.Join(types, ", ")
+		}
+		add("time tracking", checkOK, "%s", message)
+	}
+}
+
+// reportDoctor prints the checks and fails when any of them failed
+func reportDoctor(checks []*DoctorCheck) error {
+	if err := outputResult(checks, func(data interface{}) error {
+		th := theme.Current()
+		styles := map[string]lipgloss.Style{
+			checkOK:   th.OK,
+			checkWarn: th.Warn,
+			checkFail: th.Error,
+		}
+		nameStyle := th.Header.Width(16)
+		for _, check := range data.([]*DoctorCheck) {
+			fmt.Printf("%s %s %s\n", styles[check.Status].Render(fmt.Sprintf("%-4s", check.Status)), nameStyle.Render(check.Name), check.Message)
+		}
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	for _, check := range checks {
+		if check.Status == checkFail {
+			return fmt.Errorf("configuration check failed")
+		}
+	}
+	return nil
+}
@@ -0,0 +1,284 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/log"
+	"github.com/spf13/cobra"
+
+	"github.com/mkozhukh/youtrack/internal/yt/cache"
+	"github.com/mkozhukh/youtrack/internal/yt/config"
+	"github.com/mkozhukh/youtrack/internal/yt/projects"
+	"github.com/mkozhukh/youtrack/internal/yt/theme"
+	"github.com/mkozhukh/youtrack/pkg/youtrack"
+)
+
+// defaultCycleTimeDays is the period of the cycle time report when --since is not given
+const defaultCycleTimeDays = 30
+
+// leadTimeRow is the name of the lead time row of the cycle time table
+const leadTimeRow = "(lead time)"
+
+// CycleTimeReport is the time the issues resolved in a period spent in each state
+type CycleTimeReport struct {
+	Project    string            `json:"project"`
+	Since      string            `json:"since"`
+	Query      string            `json:"query,omitempty"`
+	StateField string            `json:"stateField"`
+	States     []*TimeStats      `json:"states"`
+	LeadTime   *TimeStats        `json:"leadTime"`
+	Issues     []*IssueCycleTime `json:"issues"`
+}
+
+// TimeStats are the percentiles of the time the issues spent in a state, in minutes
+type TimeStats struct {
+	State         string `json:"state,omitempty"`
+	Issues        int    `json:"issues"`
+	MedianMinutes int    `json:"medianMinutes"`
+	P75Minutes    int    `json:"p75Minutes"`
+	P90Minutes    int    `json:"p90Minutes"`
+	MaxMinutes    int    `json:"maxMinutes"`
+}
+
+// IssueCycleTime is the time one issue spent in each state, in minutes
+type IssueCycleTime struct {
+	IssueID         string         `json:"issueId"`
+	Summary         string         `json:"summary"`
+	LeadTimeMinutes int            `json:"leadTimeMinutes"`
+	States          map[string]int `json:"states"`
+}
+
+// IssueIDs returns the IDs of the reported issues, for --output ids
+func (r *CycleTimeReport) IssueIDs() []string {
+	ids := make([]string, len(r.Issues))
+	for i, issue := range r.Issues {
+		ids[i] = issue.IssueID
+	}
+	return ids
+}
+
+func reportCycleTime(cmd *cobra.Command, args []string) error {
+	since := cycleTimeSince
+	if since == "" {
+		since = time.Now().AddDate(0, 0, -defaultCycleTimeDays).Format("2006-01-02")
+	}
+	if _, err := parseDate(since); err != nil {
+		return fmt.Errorf("invalid start date format: %s (use YYYY-MM-DD)", since)
+	}
+
+	// Load configuration
+	cfg, err := config.Load(cfgFile, cmd.Flags())
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+
+	// Validate configuration
+	if err := cfg.Validate(); err != nil {
+		return fmt.Errorf("invalid configuration: %w", err)
+	}
+
+	// Determine project ID to use
+	projectID := reportProject
+	if projectID == "" {
+		projectID = cfg.Defaults.Project
+	}
+	if projectID == "" {
+		return fmt.Errorf("project ID is required (use --project flag or set default project in config)")
+	}
+
+	// Create client and context
+	client := cfg.NewClient()
+	ctx := youtrack.NewYouTrackContext(context.Background(), cfg.Server.Token)
+
+	// Resolve the project by short name or name
+	project, err := projects.Resolve(cache.Open(cfg), client, ctx, projectID)
+	if err != nil {
+		return err
+	}
+
+	searchQuery := fmt.Sprintf("project: %s resolved date: %s .. Today %s sort by: resolved date asc", project.ShortName, since, cycleTimeQuery)
+	log.Info("Searching resolved issues", "query", searchQuery)
+
+	var issues []*youtrack.Issue
+	err = client.ForEachIssue(ctx, searchQuery, youtrack.DefaultPageSize, func(issue *youtrack.Issue) error {
+		issues = append(issues, issue)
+		return nil
+	})
+	if err != nil {
+		log.Error("Failed to search issues", "error", err)
+		return fmt.Errorf("failed to search issues: %w", err)
+	}
+
+	activities, err := fetchIssuesActivities(client, ctx, issues, cycleTimeWorkers)
+	if err != nil {
+		return err
+	}
+
+	report := buildCycleTimeReport(issues, activities, cycleTimeStateField, time.Now())
+	report.Project, report.Since, report.Query = project.ShortName, since, cycleTimeQuery
+
+	return outputResult(report, func(data interface{}) error {
+		return formatCycleTimeReport(data.(*CycleTimeReport))
+	})
+}
+
+// buildCycleTimeReport computes the time in state of every issue and the percentiles per
+// state. States are listed in the order the issues first entered them.
+func buildCycleTimeReport(issues []*youtrack.Issue, activities map[string][]*youtrack.ActivityItem, stateField string, now time.Time) *CycleTimeReport {
+	report := &CycleTimeReport{StateField: stateField}
+
+	var (
+		states    []string
+		perState  = make(map[string][]time.Duration)
+		leadTimes []time.Duration
+	)
+	for _, issue := range issues {
+		durations := youtrack.TimeInState(issue, activities[issue.ID], stateField, now)
+		detail := &IssueCycleTime{IssueID: issue.ID, Summary: issue.Summary, States: make(map[string]int, len(durations))}
+
+		// Follow the history of the issue, so states appear in workflow order
+		for _, state := range issueStates(issue, activities[issue.ID], stateField) {
+			duration, ok := durations[state]
+			if !ok {
+				continue
+			}
+			if _, seen := perState[state]; !seen {
+				states = append(states, state)
+			}
+			perState[state] = append(perState[state], duration)
+			detail.States[state] = int(duration.Minutes())
+		}
+
+		if lead, ok := youtrack.LeadTime(issue); ok {
+			leadTimes = append(leadTimes, lead)
+			detail.LeadTimeMinutes = int(lead.Minutes())
+		}
+		report.Issues = append(report.Issues, detail)
+	}
+
+	for _, state := range states {
+		stats := timeStats(perState[state])
+		stats.State = state
+		report.States = append(report.States, stats)
+	}
+	report.LeadTime = timeStats(leadTimes)
+	return report
+}
+
+// issueStates returns the states of an issue in the order it entered them, without repeats
+func issueStates(issue *youtrack.Issue, activities []*youtrack.ActivityItem, stateField string) []string {
+	changes := youtrack.FieldHistory(activities, stateField)
+	var list []string
+	if len(changes) > 0 {
+		list = append(list, changes[0].From...)
+		for _, change := range changes {
+			list = append(list, change.To...)
+		}
+	} else {
+		list = append(list, issue.FieldValue(stateField))
+	}
+
+	var states []string
+	seen := make(map[string]bool)
+	for _, state := range list {
+		if state != "" && !seen[state] {
+			seen[state] = true
+			states = append(states, state)
+		}
+	}
+	return states
+}
+
+// timeStats returns the percentiles of durations in minutes
+func timeStats(durations []time.Duration) *TimeStats {
+	minutes := func(p float64) int {
+		return int(youtrack.DurationPercentile(durations, p).Minutes())
+	}
+	return &TimeStats{
+		Issues:        len(durations),
+		MedianMinutes: minutes(50),
+		P75Minutes:    minutes(75),
+		P90Minutes:    minutes(90),
+		MaxMinutes:    minutes(100),
+	}
+}
+
+// fetchIssuesActivities fetches the activity streams of the issues concurrently, with at most
+// workers fetches in flight, by issue ID
+func fetchIssuesActivities(client *youtrack.Client, ctx *youtrack.YouTrackContext, issues []*youtrack.Issue, workers int) (map[string][]*youtrack.ActivityItem, error) {
+	if workers < 1 {
+		workers = 1
+	}
+
+	result := make(map[string][]*youtrack.ActivityItem, len(issues))
+	var (
+		mu       sync.Mutex
+		wg       sync.WaitGroup
+		firstErr error
+	)
+	sem := make(chan struct{}, workers)
+
+	for _, issue := range issues {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(issueID string) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			activities, err := client.GetIssueActivities(ctx, issueID)
+
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				log.Error("Failed to fetch activities", "ticketID", issueID, "error", err)
+				if firstErr == nil {
+					firstErr = fmt.Errorf("failed to fetch activities of %s: %w", issueID, err)
+				}
+				return
+			}
+			result[issueID] = activities
+		}(issue.ID)
+	}
+	wg.Wait()
+
+	if firstErr != nil {
+		return nil, firstErr
+	}
+	return result, nil
+}
+
+// formatCycleTimeReport prints the percentiles of the time in each state, then the lead time
+func formatCycleTimeReport(report *CycleTimeReport) error {
+	if len(report.Issues) == 0 {
+		fmt.Printf("No issues of %s resolved since %s.\n", report.Project, report.Since)
+		return nil
+	}
+
+	th := theme.Current()
+	leadRow := len(report.States)
+	t := th.Table("STATE", "ISSUES", "MEDIAN", "P75", "P90", "MAX").
+		Style(func(row, col int) lipgloss.Style {
+			if row == leadRow {
+				return th.Accent
+			}
+			return th.Cell
+		})
+
+	row := func(name string, stats *TimeStats) {
+		t.Row(name, strconv.Itoa(stats.Issues), formatDuration(stats.MedianMinutes), formatDuration(stats.P75Minutes),
+			formatDuration(stats.P90Minutes), formatDuration(stats.MaxMinutes))
+	}
+	for _, stats := range report.States {
+		row(stats.State, stats)
+	}
+	row(leadTimeRow, report.LeadTime)
+
+	fmt.Println(t)
+	fmt.Printf("%d issue(s) of %s resolved since %s, time by %s\n", len(report.Issues), report.Project, report.Since, report.StateField)
+	return nil
+}
@@ -0,0 +1,209 @@
+package commands
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/log"
+	"github.com/spf13/cobra"
+
+	"github.com/mkozhukh/youtrack/internal/yt/cache"
+	"github.com/mkozhukh/youtrack/internal/yt/config"
+	"github.com/mkozhukh/youtrack/internal/yt/projects"
+	"github.com/mkozhukh/youtrack/pkg/youtrack"
+)
+
+// ganttGroupAssignee groups the chart by the assignee of the issues
+const ganttGroupAssignee = "assignee"
+
+// ganttTask is the bar of an issue on the chart; an issue without a start date is a milestone
+// on its due date
+type ganttTask struct {
+	Issue *youtrack.Issue
+	Start time.Time
+	Due   time.Time
+	// Milestone is set when the issue has no start date, or one after its due date
+	Milestone bool
+}
+
+func exportGantt(cmd *cobra.Command, args []string) error {
+	// Load configuration
+	cfg, err := config.Load(cfgFile, cmd.Flags())
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+
+	// Validate configuration
+	if err := cfg.Validate(); err != nil {
+		return fmt.Errorf("invalid configuration: %w", err)
+	}
+
+	// Determine project ID to use
+	projectID := reportProject
+	if projectID == "" {
+		projectID = cfg.Defaults.Project
+	}
+	if projectID == "" {
+		return fmt.Errorf("project ID is required (use --project flag or set default project in config)")
+	}
+
+	// Create client and context
+	client := cfg.NewClient()
+	ctx := youtrack.NewYouTrackContext(context.Background(), cfg.Server.Token)
+
+	// Resolve the project by short name or name
+	project, err := projects.Resolve(cache.Open(cfg), client, ctx, projectID)
+	if err != nil {
+		return err
+	}
+
+	searchQuery := strings.TrimSpace(fmt.Sprintf("project: %s has: {%s} %s", project.ShortName, ganttDueField, ganttQuery))
+	log.Info("Collecting scheduled issues", "query", searchQuery)
+
+	var tasks []ganttTask
+	err = client.ForEachIssue(ctx, searchQuery, youtrack.DefaultPageSize, func(issue *youtrack.Issue) error {
+		due, ok := issue.DateField(ganttDueField)
+		if !ok {
+			return nil
+		}
+		task := ganttTask{Issue: issue, Due: due, Start: due, Milestone: true}
+		if start, ok := issue.DateField(ganttStartField); ok && !start.After(due) {
+			task.Start, task.Milestone = start, false
+		}
+		tasks = append(tasks, task)
+		return nil
+	})
+	if err != nil {
+		log.Error("Failed to search issues", "error", err)
+		return fmt.Errorf("failed to search issues: %w", err)
+	}
+
+	out := os.Stdout
+	if ganttOut != "" {
+		file, err := os.Create(ganttOut)
+		if err != nil {
+			return fmt.Errorf("failed to create output file: %w", err)
+		}
+		defer file.Close()
+		out = file
+	}
+
+	title := ganttTitle
+	if title == "" {
+		title = project.Name + " schedule"
+	}
+	if err := writeGantt(out, title, tasks, ganttGroupBy, time.Now()); err != nil {
+		return fmt.Errorf("failed to write chart: %w", err)
+	}
+
+	if ganttOut != "" {
+		fmt.Printf("Exported %d tasks to %s\n", len(tasks), ganttOut)
+	}
+	return nil
+}
+
+// writeGantt writes the tasks as a Mermaid gantt chart with a section per group, the groups
+// sorted by name with issues without a value last. Resolved issues are marked done,
+// unresolved ones past their due date critical, and the ones under way active.
+func writeGantt(w io.Writer, title string, tasks []ganttTask, groupBy string, now time.Time) error {
+	sections := make(map[string][]ganttTask)
+	for _, task := range tasks {
+		group := ganttGroup(task.Issue, groupBy)
+		sections[group] = append(sections[group], task)
+	}
+
+	names := make([]string, 0, len(sections))
+	for name := range sections {
+		if name != "" {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	if _, ok := sections[""]; ok {
+		names = append(names, "")
+	}
+
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+
+	bw := bufio.NewWriter(w)
+	fmt.Fprintln(bw, "gantt")
+	fmt.Fprintf(bw, "    title %s\n", ganttText(title))
+	fmt.Fprintln(bw, "    dateFormat YYYY-MM-DD")
+	io.WriteString(bw, "    axisFormat %b %d\n")
+
+	for _, name := range names {
+		section := name
+		if section == "" {
+			section = "(none)"
+			if groupBy == ganttGroupAssignee {
+				section = "Unassigned"
+			}
+		}
+		fmt.Fprintf(bw, "\n    section %s\n", ganttText(section))
+
+		group := sections[name]
+		sort.SliceStable(group, func(i, j int) bool {
+			if !group[i].Start.Equal(group[j].Start) {
+				return group[i].Start.Before(group[j].Start)
+			}
+			return group[i].Due.Before(group[j].Due)
+		})
+
+		for _, task := range group {
+			var tags []string
+			switch {
+			case task.Issue.Resolved != nil:
+				tags = append(tags, "done")
+			case task.Due.Before(today):
+				tags = append(tags, "crit")
+			case !task.Start.After(today):
+				tags = append(tags, "active")
+			}
+			if task.Milestone {
+				tags = append(tags, "milestone")
+			}
+			tags = append(tags, ganttTaskID(task.Issue.ID), task.Start.Format("2006-01-02"))
+			if task.Milestone {
+				tags = append(tags, "0d")
+			} else {
+				// Mermaid ends a bar at the start of its end date, the due date is included
+				tags = append(tags, task.Due.AddDate(0, 0, 1).Format("2006-01-02"))
+			}
+
+			label := ganttText(fmt.Sprintf("%s %s", task.Issue.ID, task.Issue.Summary))
+			fmt.Fprintf(bw, "    %s :%s\n", label, strings.Join(tags, ", "))
+		}
+	}
+	return bw.Flush()
+}
+
+// ganttGroup returns the section of an issue: its assignee, or the value of a custom field
+func ganttGroup(issue *youtrack.Issue, groupBy string) string {
+	if strings.EqualFold(groupBy, ganttGroupAssignee) {
+		if issue.Assignee == nil {
+			return ""
+		}
+		if issue.Assignee.FullName != "" {
+			return issue.Assignee.FullName
+		}
+		return issue.Assignee.Login
+	}
+	return issue.FieldValue(groupBy)
+}
+
+// ganttTaskID turns an issue ID into a task ID, e.g. PRJ-12 into prj_12
+func ganttTaskID(issueID string) string {
+	return strings.ToLower(strings.ReplaceAll(issueID, "-", "_"))
+}
+
+// ganttText removes the characters that end a title, section or task name in Mermaid
+func ganttText(s string) string {
+	s = strings.NewReplacer(":", " ", ";", " ", "#", " ", "\r", " ", "\n", " ").Replace(s)
+	return strings.Join(strings.Fields(s), " ")
+}
@@ -0,0 +1,432 @@
+package commands
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"text/template"
+
+	"github.com/charmbracelet/log"
+	"github.com/spf13/cobra"
+
+	"github.com/mkozhukh/youtrack/internal/yt/config"
+	"github.com/mkozhukh/youtrack/internal/yt/projects"
+	"github.com/mkozhukh/youtrack/pkg/youtrack"
+)
+
+// Defaults of the [git] config section
+const (
+	defaultBranchTemplate = "feature/{{.ID}}-{{.Slug}}"
+	defaultCommitTemplate = "{{.ID}} {{.Subject}}"
+	defaultSlugLength     = 40
+)
+
+// commitMsgHookScript is the hook installed by `yt git commit-msg-hook --install`
+const commitMsgHookScript = `#!/bin/sh
+# Installed by 'yt git commit-msg-hook --install': adds the ticket ID of the branch to the message
+exec yt git commit-msg-hook "$1"
+`
+
+var (
+	gitBranchBase  string
+	gitBranchPrint bool
+	gitHookInstall bool
+	gitHookForce   bool
+	gitHookRequire bool
+)
+
+// GitBranch is the branch created or switched to for a ticket
+type GitBranch struct {
+	TicketID string `json:"ticketId"`
+	Branch   string `json:"branch"`
+	Created  bool   `json:"created"`
+	Switched bool   `json:"switched"`
+}
+
+// IssueIDs returns the ticket of the branch, for --output ids
+func (b *GitBranch) IssueIDs() []string {
+	return []string{b.TicketID}
+}
+
+// branchNameData are the fields of the branch name template
+type branchNameData struct {
+	ID       string
+	Project  string
+	Number   string
+	Summary  string
+	Slug     string
+	Type     string
+	Assignee string
+}
+
+// commitSubjectData are the fields of the commit subject template
+type commitSubjectData struct {
+	ID      string
+	Subject string
+}
+
+// gitCmd represents the git command
+var gitCmd = &cobra.Command{
+	Use:   "git",
+	Short: "Git helpers: branches named after tickets and ticket IDs in commit messages",
+	Long: `Git helpers run in the current repository: a branch named after a ticket, and a
+commit-msg hook adding the ticket ID of the branch to commit messages.`,
+}
+
+// gitBranchCmd represents the git branch command
+var gitBranchCmd = &cobra.Command{
+	Use:   "branch <ticket_id>",
+	Short: "Creates a branch named after a ticket",
+	Long: `Creates a branch named after a ticket and switches to it, or switches to it when it exists.
+The name comes from the git.branch_template config key, a Go template with the fields ID,
+Project, Number, Summary, Slug (the summary in lower case with dashes, at most
+git.slug_length characters), Type (the Type field, slugified) and Assignee (login). The
+default is "feature/{{.ID}}-{{.Slug}}", e.g. feature/PRJ-123-fix-login-timeout.`,
+	Example: `  yt git branch PRJ-123
+  yt git branch 123 --base origin/main
+  yt config set git.branch_template "{{.Type}}/{{.ID}}-{{.Slug}}"`,
+	Args: cobra.ExactArgs(1),
+	RunE: createGitBranch,
+}
+
+// gitCommitMsgHookCmd represents the git commit-msg-hook command
+var gitCommitMsgHookCmd = &cobra.Command{
+	Use:   "commit-msg-hook [message_file]",
+	Short: "Adds the ticket ID of the branch to a commit message (git commit-msg hook)",
+	Long: `Runs as the commit-msg hook of git: when the commit message names no ticket, the ticket ID
+found in the branch name is added to its subject line, following the git.commit_template config
+key (default "{{.ID}} {{.Subject}}"). With git.require_ticket set (or --require), a commit
+naming no ticket on a branch naming none is rejected. Merge, fixup and squash commits are left
+as they are. No request is sent to YouTrack.
+
+Install it in the current repository with --install.`,
+	Example: `  yt git commit-msg-hook --install
+  yt git commit-msg-hook .git/COMMIT_EDITMSG`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runCommitMsgHook,
+}
+
+func init() {
+	gitCmd.AddCommand(gitBranchCmd)
+	gitCmd.AddCommand(gitCommitMsgHookCmd)
+
+	gitBranchCmd.Flags().StringVar(&gitBranchBase, "base", "", "Start the new branch at this commit or branch (defaults to HEAD)")
+	gitBranchCmd.Flags().BoolVar(&gitBranchPrint, "print", false, "Only print the branch name, without running git")
+
+	gitCommitMsgHookCmd.Flags().BoolVar(&gitHookInstall, "install", false, "Install the hook in the current repository")
+	gitCommitMsgHookCmd.Flags().BoolVar(&gitHookForce, "force", false, "Replace an existing commit-msg hook when installing")
+	gitCommitMsgHookCmd.Flags().BoolVar(&gitHookRequire, "require", false, "Reject commits naming no ticket, like git.require_ticket")
+}
+
+func createGitBranch(cmd *cobra.Command, args []string) error {
+	// Load configuration
+	cfg, err := config.Load(cfgFile, cmd.Flags())
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+
+	// Validate configuration
+	if err := cfg.Validate(); err != nil {
+		return fmt.Errorf("invalid configuration: %w", err)
+	}
+
+	// Normalize the ticket ID, a bare number gets the default project
+	ticketID, err := projects.IssueID(cfg, args[0])
+	if err != nil {
+		return err
+	}
+
+	// Create client and context
+	client := cfg.NewClient()
+	ctx := youtrack.NewYouTrackContext(context.Background(), cfg.Server.Token)
+
+	issue, err := client.GetIssue(ctx, ticketID)
+	if err != nil {
+		return fmt.Errorf("failed to get ticket %s: %w", ticketID, err)
+	}
+
+	name, err := branchName(cfg.Git, issue)
+	if err != nil {
+		return err
+	}
+	if _, err := runGit("check-ref-format", "--branch", name); err != nil {
+		return fmt.Errorf("%q is not a valid branch name, check git.branch_template: %w", name, err)
+	}
+
+	result := &GitBranch{TicketID: issue.ID, Branch: name}
+	if gitBranchPrint {
+		return outputResult(result, func(data interface{}) error {
+			fmt.Println(data.(*GitBranch).Branch)
+			return nil
+		})
+	}
+
+	if _, err := runGit("rev-parse", "--verify", "--quiet", "refs/heads/"+name); err == nil {
+		if gitBranchBase != "" {
+			return fmt.Errorf("branch %s already exists, --base only applies to a new branch", name)
+		}
+		log.Info("Switching to the existing branch", "branch", name)
+		if _, err := runGit("switch", name); err != nil {
+			return err
+		}
+	} else {
+		gitArgs := []string{"switch", "-c", name}
+		if gitBranchBase != "" {
+			gitArgs = append(gitArgs, gitBranchBase)
+		}
+		log.Info("Creating branch", "branch", name, "base", gitBranchBase)
+		if _, err := runGit(gitArgs...); err != nil {
+			return err
+		}
+		result.Created = true
+	}
+	result.Switched = true
+
+	return outputResult(result, func(data interface{}) error {
+		branch := data.(*GitBranch)
+		if branch.Created {
+			fmt.Printf("Switched to a new branch %s for %s\n", branch.Branch, branch.TicketID)
+		} else {
+			fmt.Printf("Switched to the existing branch %s for %s\n", branch.Branch, branch.TicketID)
+		}
+		return nil
+	})
+}
+
+// branchName renders the branch name template for an issue
+func branchName(cfg config.GitConfig, issue *youtrack.Issue) (string, error) {
+	text := cfg.BranchTemplate
+	if text == "" {
+		text = defaultBranchTemplate
+	}
+	slugLength := cfg.SlugLength
+	if slugLength <= 0 {
+		slugLength = defaultSlugLength
+	}
+
+	project, number, _ := strings.Cut(issue.ID, "-")
+	data := branchNameData{
+		ID:      issue.ID,
+		Project: project,
+		Number:  number,
+		Summary: issue.Summary,
+		Slug:    slugify(issue.Summary, slugLength),
+		Type:    slugify(issue.FieldValue("Type"), slugLength),
+	}
+	if issue.Assignee != nil {
+		data.Assignee = issue.Assignee.Login
+	}
+
+	name, err := renderGitTemplate("git.branch_template", text, data)
+	if err != nil {
+		return "", err
+	}
+	// Empty fields leave separators behind, e.g. "/PRJ-1-" without a type and a summary
+	name = strings.Trim(name, "-/")
+	if name == "" {
+		return "", fmt.Errorf("git.branch_template %q gives an empty branch name for %s", text, issue.ID)
+	}
+	return name, nil
+}
+
+// slugify converts a text to lower-case ASCII words joined by dashes, cut at a word boundary
+// to at most maxLength characters
+func slugify(text string, maxLength int) string {
+	var words []string
+	var word strings.Builder
+	for _, r := range strings.ToLower(text) {
+		if r < 128 && (r >= 'a' && r <= 'z' || r >= '0' && r <= '9') {
+			word.WriteRune(r)
+			continue
+		}
+		if word.Len() > 0 {
+			words = append(words, word.String())
+			word.Reset()
+		}
+	}
+	if word.Len() > 0 {
+		words = append(words, word.String())
+	}
+
+	slug := ""
+	for _, w := range words {
+		next := w
+		if slug != "" {
+			next = slug + "-" + w
+		}
+		if len(next) > maxLength {
+			if slug == "" {
+				slug = w[:maxLength]
+			}
+			break
+		}
+		slug = next
+	}
+	return slug
+}
+
+// renderGitTemplate executes a template of the [git] config section
+func renderGitTemplate(key, text string, data interface{}) (string, error) {
+	t, err := template.New(key).Option("missingkey=error").Parse(text)
+	if err != nil {
+		return "", fmt.Errorf("invalid %s: %w", key, err)
+	}
+	var sb strings.Builder
+	if err := t.Execute(&sb, data); err != nil {
+		return "", fmt.Errorf("invalid %s: %w", key, err)
+	}
+	return strings.TrimSpace(sb.String()), nil
+}
+
+func runCommitMsgHook(cmd *cobra.Command, args []string) error {
+	if gitHookInstall {
+		if len(args) > 0 {
+			return fmt.Errorf("--install takes no message file")
+		}
+		return installCommitMsgHook()
+	}
+	if len(args) == 0 {
+		return fmt.Errorf("the commit message file is required (git passes it to the hook)")
+	}
+
+	// Load configuration, the hook does not contact YouTrack
+	cfg, err := config.Load(cfgFile, cmd.Flags())
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+
+	data, err := os.ReadFile(args[0])
+	if err != nil {
+		return fmt.Errorf("failed to read the commit message: %w", err)
+	}
+
+	branch, err := runGit("rev-parse", "--abbrev-ref", "HEAD")
+	if err != nil || branch == "HEAD" {
+		// No branch yet, or a detached HEAD
+		branch = ""
+	}
+
+	message, err := injectTicketID(cfg, string(data), branch)
+	if err != nil {
+		// The hook output is shown by git, without the usage
+		cmd.SilenceUsage = true
+		return err
+	}
+	if message == string(data) {
+		return nil
+	}
+	if err := os.WriteFile(args[0], []byte(message), 0644); err != nil {
+		return fmt.Errorf("failed to write the commit message: %w", err)
+	}
+	return nil
+}
+
+// injectTicketID adds the ticket ID of the branch to the subject of a commit message naming no
+// ticket, or rejects the message when a ticket is required and the branch names none
+func injectTicketID(cfg *config.Config, message, branch string) (string, error) {
+	lines := strings.Split(message, "\n")
+	subject := -1
+	var text []string
+	for i, line := range lines {
+		// Lines starting with # are comments git strips
+		if strings.HasPrefix(line, "#") {
+			continue
+		}
+		if subject < 0 && strings.TrimSpace(line) != "" {
+			subject = i
+		}
+		text = append(text, line)
+	}
+	if subject < 0 {
+		// An empty message, git aborts the commit
+		return message, nil
+	}
+
+	for _, prefix := range []string{"Merge ", "fixup! ", "squash! ", "amend! "} {
+		if strings.HasPrefix(lines[subject], prefix) {
+			return message, nil
+		}
+	}
+	if len(messageIssueIDs(strings.Join(text, "\n"), cfg.Defaults.Project)) > 0 {
+		return message, nil
+	}
+
+	ids := messageIssueIDs(branch, cfg.Defaults.Project)
+	if len(ids) == 0 {
+		if cfg.Git.RequireTicket || gitHookRequire {
+			if branch == "" {
+				return "", fmt.Errorf("the commit message names no ticket: add its ID, e.g. \"PRJ-123 %s\"", lines[subject])
+			}
+			return "", fmt.Errorf("the commit message names no ticket and neither does the branch %s: add its ID, e.g. \"PRJ-123 %s\"", branch, lines[subject])
+		}
+		return message, nil
+	}
+
+	commitTemplate := cfg.Git.CommitTemplate
+	if commitTemplate == "" {
+		commitTemplate = defaultCommitTemplate
+	}
+	line, err := renderGitTemplate("git.commit_template", commitTemplate, commitSubjectData{ID: ids[0], Subject: strings.TrimSpace(lines[subject])})
+	if err != nil {
+		return "", err
+	}
+	log.Info("Adding the ticket ID of the branch to the commit message", "ticketID", ids[0], "branch", branch)
+	lines[subject] = line
+	return strings.Join(lines, "\n"), nil
+}
+
+// messageIssueIDs finds ticket IDs in a commit message or a branch name: upper-case IDs of any
+// project, or IDs of the default project in any case (e.g. "feature/prj-12-fix")
+func messageIssueIDs(text, defaultProject string) []string {
+	if ids := youtrack.ExtractIssueIDs(text, nil); len(ids) > 0 {
+		return ids
+	}
+	if defaultProject == "" {
+		return nil
+	}
+	return youtrack.ExtractIssueIDs(text, []string{strings.ToUpper(defaultProject)})
+}
+
+// installCommitMsgHook writes the commit-msg hook of the current repository
+func installCommitMsgHook() error {
+	path, err := runGit("rev-parse", "--git-path", "hooks/commit-msg")
+	if err != nil {
+		return err
+	}
+	if _, err := os.Stat(path); err == nil && !gitHookForce {
+		return fmt.Errorf("%s already exists, use --force to replace it", path)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create the hooks directory: %w", err)
+	}
+	if err := os.WriteFile(path, []byte(commitMsgHookScript), 0755); err != nil {
+		return fmt.Errorf("failed to write the hook: %w", err)
+	}
+	fmt.Printf("Installed the commit-msg hook in %s\n", path)
+	return nil
+}
+
+// runGit runs git in the current directory and returns its trimmed output; the error carries
+// what git printed on stderr
+func runGit(args ...string) (string, error) {
+	var stdout, stderr bytes.Buffer
+	c := exec.Command("git", args...)
+	c.Stdout, c.Stderr = &stdout, &stderr
+	if err := c.Run(); err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			if msg := strings.TrimSpace(stderr.String()); msg != "" {
+				return "", fmt.Errorf("git %s: %s", args[0], msg)
+			}
+			return "", fmt.Errorf("git %s: %w", args[0], err)
+		}
+		return "", fmt.Errorf("failed to run git: %w", err)
+	}
+	return strings.TrimSpace(stdout.String()), nil
+}
@@ -0,0 +1,153 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/charmbracelet/log"
+	"github.com/spf13/cobra"
+
+	"github.com/mkozhukh/youtrack/internal/yt/config"
+	"github.com/mkozhukh/youtrack/internal/yt/theme"
+	"github.com/mkozhukh/youtrack/pkg/youtrack"
+)
+
+// groupsCmd represents the groups command
+var groupsCmd = &cobra.Command{
+	Use:   "groups",
+	Short: "Manage user groups",
+	Long:  `List YouTrack user groups and their members.`,
+	RunE:  listGroups, // Default to list when no subcommand is given
+}
+
+// listGroupsCmd represents the list command
+var listGroupsCmd = &cobra.Command{
+	Use:   "list",
+	Short: "Lists all user groups",
+	Long:  `Lists all user groups with their member counts.`,
+	RunE:  listGroups,
+}
+
+// groupMembersCmd represents the members command
+var groupMembersCmd = &cobra.Command{
+	Use:   "members <group>",
+	Short: "Lists the members of a group",
+	Long: `Lists the members of a group, including members of its subgroups.
+The group can be specified by name or ID. Requires hub_url in the config.`,
+	Args: cobra.ExactArgs(1),
+	RunE: listGroupMembers,
+}
+
+func init() {
+	groupsCmd.AddCommand(listGroupsCmd)
+	groupsCmd.AddCommand(groupMembersCmd)
+}
+
+func listGroups(cmd *cobra.Command, args []string) error {
+	client, ctx, err := newGroupsClient(cmd)
+	if err != nil {
+		return err
+	}
+
+	groups, err := client.ListGroups(ctx)
+	if err != nil {
+		log.Error("Failed to fetch groups", "error", err)
+		return fmt.Errorf("failed to fetch groups: %w", err)
+	}
+
+	// Output results
+	return outputResult(groups, func(data interface{}) error {
+		return formatGroupsList(data.([]*youtrack.UserGroup))
+	})
+}
+
+func listGroupMembers(cmd *cobra.Command, args []string) error {
+	client, ctx, err := newGroupsClient(cmd)
+	if err != nil {
+		return err
+	}
+
+	group, err := client.FindGroup(ctx, args[0])
+	if err != nil {
+		return err
+	}
+
+	members, err := fetchAllGroupMembers(client, ctx, group.RingID)
+	if err != nil {
+		log.Error("Failed to fetch group members", "error", err)
+		return fmt.Errorf("failed to fetch group members: %w", err)
+	}
+
+	// Output results
+	return outputResult(members, func(data interface{}) error {
+		users := data.([]*youtrack.User)
+		if len(users) == 0 {
+			fmt.Printf("No members in group %s\n", group.Name)
+			return nil
+		}
+		return formatUsersList(users)
+	})
+}
+
+// newGroupsClient loads the configuration and creates the client and context
+func newGroupsClient(cmd *cobra.Command) (*youtrack.Client, *youtrack.YouTrackContext, error) {
+	// Load configuration
+	cfg, err := config.Load(cfgFile, cmd.Flags())
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to load configuration: %w", err)
+	}
+
+	// Validate configuration
+	if err := cfg.Validate(); err != nil {
+		return nil, nil, fmt.Errorf("invalid configuration: %w", err)
+	}
+
+	// Create client and context
+	client := cfg.NewClient()
+	ctx := youtrack.NewYouTrackContext(context.Background(), cfg.Server.Token)
+
+	return client, ctx, nil
+}
+
+// fetchAllGroupMembers retrieves all members of a group
+func fetchAllGroupMembers(client *youtrack.Client, ctx *youtrack.YouTrackContext, ringID string) ([]*youtrack.User, error) {
+	var allUsers []*youtrack.User
+	skip := 0
+	top := 100
+
+	for {
+		users, err := client.GetGroupMembers(ctx, ringID, skip, top)
+		if err != nil {
+			return nil, err
+		}
+
+		allUsers = append(allUsers, users...)
+
+		// If we got fewer users than requested, we've reached the end
+		if len(users) < top {
+			break
+		}
+
+		skip += top
+	}
+
+	return allUsers, nil
+}
+
+// formatGroupsList formats groups list for text output
+func formatGroupsList(groups []*youtrack.UserGroup) error {
+	if len(groups) == 0 {
+		fmt.Println("No groups found")
+		return nil
+	}
+
+	t := theme.Current().Table("NAME", "MEMBERS", "ID")
+
+	for _, group := range groups {
+		t.Row(group.Name, strconv.Itoa(group.UsersCount), group.ID)
+	}
+
+	fmt.Println(t)
+	return nil
+}
@@ -0,0 +1,151 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/log"
+	"github.com/spf13/cobra"
+
+	"github.com/mkozhukh/youtrack/internal/yt/config"
+	"github.com/mkozhukh/youtrack/internal/yt/theme"
+	"github.com/mkozhukh/youtrack/pkg/youtrack"
+)
+
+var (
+	inboxAll      bool
+	inboxLimit    int
+	inboxMarkRead bool
+	inboxMentions bool
+)
+
+// Inbox is the notification inbox for output
+type Inbox struct {
+	Unread        int                      `json:"unread"`
+	Notifications []*youtrack.Notification `json:"notifications"`
+	Marked        int                      `json:"marked,omitempty"`
+}
+
+// IssueIDs returns the tickets of the notifications, without duplicates, for --output ids
+func (i *Inbox) IssueIDs() []string {
+	var ids []string
+	seen := make(map[string]bool)
+	for _, n := range i.Notifications {
+		if n.IssueID != "" && !seen[n.IssueID] {
+			seen[n.IssueID] = true
+			ids = append(ids, n.IssueID)
+		}
+	}
+	return ids
+}
+
+// inboxCmd represents the inbox command
+var inboxCmd = &cobra.Command{
+	Use:   "inbox",
+	Short: "Shows your YouTrack notifications",
+	Long: `Shows your recent YouTrack notifications: changes to tickets you are mentioned in or
+subscribed to by a tag or a saved search, with the reason and the changed fields.
+Only unread notifications are shown, use --all to include read ones and --mark-read
+to mark the shown notifications read.`,
+	Args: cobra.NoArgs,
+	RunE: showInbox,
+}
+
+func init() {
+	inboxCmd.Flags().BoolVarP(&inboxAll, "all", "a", false, "Include read notifications")
+	inboxCmd.Flags().IntVarP(&inboxLimit, "limit", "n", 20, "Number of recent notifications to look at")
+	inboxCmd.Flags().BoolVar(&inboxMarkRead, "mark-read", false, "Mark the shown notifications read")
+	inboxCmd.Flags().BoolVar(&inboxMentions, "mentions", false, "Only show notifications for mentions")
+}
+
+func showInbox(cmd *cobra.Command, args []string) error {
+	if inboxLimit <= 0 {
+		return fmt.Errorf("--limit must be positive")
+	}
+
+	// Load configuration
+	cfg, err := config.Load(cfgFile, cmd.Flags())
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+
+	// Validate configuration
+	if err := cfg.Validate(); err != nil {
+		return fmt.Errorf("invalid configuration: %w", err)
+	}
+
+	// Create client and context
+	client := cfg.NewClient()
+	ctx := youtrack.NewYouTrackContext(context.Background(), cfg.Server.Token)
+
+	notifications, err := client.GetNotifications(ctx, 0, inboxLimit, !inboxAll)
+	if err != nil {
+		log.Error("Failed to fetch notifications", "error", err)
+		return fmt.Errorf("failed to fetch notifications: %w", err)
+	}
+	if inboxMentions {
+		var mentions []*youtrack.Notification
+		for _, n := range notifications {
+			if n.IsMention() {
+				mentions = append(mentions, n)
+			}
+		}
+		notifications = mentions
+	}
+
+	unread, err := client.CountUnreadNotifications(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to count unread notifications: %w", err)
+	}
+	inbox := &Inbox{Unread: unread, Notifications: notifications}
+
+	if inboxMarkRead {
+		for _, n := range notifications {
+			if n.Read {
+				continue
+			}
+			if err := client.MarkNotificationRead(ctx, n.ID, true); err != nil {
+				log.Warn("Failed to mark notification read", "id", n.ID, "error", err)
+				continue
+			}
+			inbox.Marked++
+		}
+		inbox.Unread -= inbox.Marked
+	}
+
+	// Content is the email rendering, too large for the output
+	for _, n := range notifications {
+		n.Content = ""
+	}
+
+	return outputResult(inbox, func(data interface{}) error {
+		return formatInbox(data.(*Inbox))
+	})
+}
+
+// formatInbox formats the notification inbox for text output
+func formatInbox(inbox *Inbox) error {
+	fmt.Printf("Unread notifications: %d\n", inbox.Unread)
+	if len(inbox.Notifications) == 0 {
+		fmt.Println("No notifications found")
+		return nil
+	}
+
+	t := theme.Current().Table("", "UPDATED", "TICKET", "SUMMARY", "REASON", "CHANGED").Flex(3)
+
+	for _, n := range inbox.Notifications {
+		marker := "•"
+		if n.Read {
+			marker = ""
+		}
+		t.Row(marker, n.Updated.Format("2006-01-02 15:04"), n.IssueID, n.IssueSummary,
+			strings.Join(n.Reasons, ", "), strings.Join(n.Changes, ", "))
+	}
+
+	fmt.Println(t)
+	if inbox.Marked > 0 {
+		fmt.Printf("Marked %d notifications read\n", inbox.Marked)
+	}
+	return nil
+}
@@ -0,0 +1,289 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/log"
+	"github.com/spf13/cobra"
+
+	"github.com/mkozhukh/youtrack/internal/yt/cache"
+	"github.com/mkozhukh/youtrack/internal/yt/config"
+	"github.com/mkozhukh/youtrack/internal/yt/index"
+	"github.com/mkozhukh/youtrack/internal/yt/projects"
+	"github.com/mkozhukh/youtrack/internal/yt/theme"
+	"github.com/mkozhukh/youtrack/pkg/youtrack"
+)
+
+var (
+	indexProject string
+	indexFull    bool
+	findProject  string
+	findLimit    int
+)
+
+// IndexBuild is the outcome of building or refreshing a project's index
+type IndexBuild struct {
+	Project   string    `json:"project"`
+	Full      bool      `json:"full"`
+	Fetched   int       `json:"fetched"`
+	Total     int       `json:"total"`
+	Refreshed time.Time `json:"refreshed"`
+	Path      string    `json:"path"`
+}
+
+// FindResult lists the indexed issues matching the search terms
+type FindResult struct {
+	Terms   string       `json:"terms"`
+	Indexes []*IndexInfo `json:"indexes"`
+	Hits    []*index.Hit `json:"hits"`
+	Total   int          `json:"total"`
+}
+
+// IndexInfo describes a searched index
+type IndexInfo struct {
+	Project   string    `json:"project"`
+	Issues    int       `json:"issues"`
+	Refreshed time.Time `json:"refreshed"`
+}
+
+// IssueIDs returns the matching tickets, for --output ids
+func (r *FindResult) IssueIDs() []string {
+	ids := make([]string, len(r.Hits))
+	for i, hit := range r.Hits {
+		ids[i] = hit.Document.ID
+	}
+	return ids
+}
+
+// indexCmd represents the index command
+var indexCmd = &cobra.Command{
+	Use:   "index",
+	Short: "Manage the local full-text index searched by 'yt find'",
+	Long: `Manage the local full-text index of project issues: summaries, descriptions, tags,
+states and assignees, searched offline by 'yt find'.`,
+}
+
+// buildIndexCmd represents the index build command
+var buildIndexCmd = &cobra.Command{
+	Use:   "build",
+	Short: "Snapshots the issues of a project into the local index",
+	Long: `Snapshots the issues of a project into the local index. The first build fetches every
+issue; later builds only fetch the issues updated since the last one. Use --full to rebuild
+the index from scratch, which also drops deleted issues and issues moved to another project.`,
+	Args: cobra.NoArgs,
+	RunE: buildIndex,
+}
+
+// findCmd represents the find command
+var findCmd = &cobra.Command{
+	Use:   "find <terms...>",
+	Short: "Searches the local index of issues",
+	Long: `Searches the issues in the local index, without contacting YouTrack. An issue matches
+when it contains every term, in its ID, summary, tags, description, state or assignee; a term
+also matches the words it starts with ("auth" matches "authentication"). Matches in the
+summary rank first. Build the index with 'yt index build' and refresh it the same way.`,
+	Example: `  yt index build --project PRJ
+  yt find login timeout
+  yt find -p PRJ crash -o ids | yt tickets tag - triage`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: findIssues,
+}
+
+func init() {
+	indexCmd.AddCommand(buildIndexCmd)
+
+	buildIndexCmd.Flags().StringVarP(&indexProject, "project", "p", "", "The project short name or name (uses default from config if not provided)")
+	buildIndexCmd.Flags().BoolVar(&indexFull, "full", false, "Rebuild the index from scratch instead of fetching the updated issues")
+
+	findCmd.Flags().StringVarP(&findProject, "project", "p", "", "Only search the index of this project (searches every indexed project if not provided)")
+	findCmd.Flags().IntVarP(&findLimit, "limit", "n", 20, "Maximum number of issues to show")
+}
+
+func buildIndex(cmd *cobra.Command, args []string) error {
+	// Load configuration
+	cfg, err := config.Load(cfgFile, cmd.Flags())
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+
+	// Validate configuration
+	if err := cfg.Validate(); err != nil {
+		return fmt.Errorf("invalid configuration: %w", err)
+	}
+
+	// Determine project ID to use
+	projectID := indexProject
+	if projectID == "" {
+		projectID = cfg.Defaults.Project
+	}
+	if projectID == "" {
+		return fmt.Errorf("project ID is required (use --project flag or set default project in config)")
+	}
+
+	// Create client and context
+	client := cfg.NewClient()
+	ctx := youtrack.NewYouTrackContext(context.Background(), cfg.Server.Token)
+
+	// Resolve the project by short name or name
+	metadata := cache.Open(cfg)
+	project, err := projects.Resolve(metadata, client, ctx, projectID)
+	if err != nil {
+		return err
+	}
+
+	store := index.NewStore(metadata)
+	idx, err := store.Load(project.ShortName)
+	if err != nil {
+		return err
+	}
+
+	// Refresh from the day of the latest indexed update: the query has a day granularity, and
+	// the issues of that day are fetched again
+	full := indexFull || idx == nil || idx.LastUpdated().IsZero()
+	searchQuery := "project: " + project.ShortName
+	if full {
+		idx = index.New(project.ShortName)
+	} else {
+		searchQuery += " updated: " + idx.LastUpdated().Local().Format("2006-01-02") + " .. *"
+	}
+	log.Info("Indexing issues", "query", searchQuery, "full", full)
+
+	build := &IndexBuild{Project: project.ShortName, Full: full, Path: store.Path(project.ShortName)}
+	err = client.ForEachIssue(ctx, searchQuery, youtrack.DefaultPageSize, func(issue *youtrack.Issue) error {
+		idx.Put(indexDocument(issue))
+		build.Fetched++
+		return nil
+	})
+	if err != nil {
+		log.Error("Failed to search issues", "error", err)
+		return fmt.Errorf("failed to search issues: %w", err)
+	}
+
+	idx.Refreshed = time.Now()
+	if err := store.Save(idx); err != nil {
+		return err
+	}
+	build.Total = len(idx.Documents)
+	build.Refreshed = idx.Refreshed
+
+	return outputResult(build, formatIndexBuild)
+}
+
+// indexDocument converts an issue to its indexed text
+func indexDocument(issue *youtrack.Issue) *index.Document {
+	doc := &index.Document{
+		ID:          issue.ID,
+		Summary:     issue.Summary,
+		Description: issue.Description,
+		State:       issue.State,
+		Updated:     issue.Updated.Time,
+		Resolved:    issue.Resolved != nil,
+	}
+	if issue.Assignee != nil {
+		doc.Assignee = strings.TrimSpace(issue.Assignee.Login + " " + issue.Assignee.FullName)
+	}
+	for _, tag := range issue.Tags {
+		doc.Tags = append(doc.Tags, tag.Name)
+	}
+	return doc
+}
+
+func formatIndexBuild(data interface{}) error {
+	build := data.(*IndexBuild)
+	if build.Full {
+		fmt.Printf("Indexed %d issue(s) of %s.\n", build.Total, build.Project)
+	} else {
+		fmt.Printf("Refreshed the index of %s: %d updated issue(s), %d in total.\n", build.Project, build.Fetched, build.Total)
+	}
+	return nil
+}
+
+func findIssues(cmd *cobra.Command, args []string) error {
+	if findLimit <= 0 {
+		return fmt.Errorf("--limit must be positive")
+	}
+
+	// Load configuration
+	cfg, err := config.Load(cfgFile, cmd.Flags())
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+
+	terms := strings.Join(args, " ")
+	if len(index.Tokenize(terms)) == 0 {
+		return fmt.Errorf("no words to search for in %q", terms)
+	}
+
+	store := index.NewStore(cache.Open(cfg))
+	projectIDs := []string{findProject}
+	if findProject == "" {
+		if projectIDs, err = store.Projects(); err != nil {
+			return err
+		}
+		if len(projectIDs) == 0 {
+			return fmt.Errorf("no issues are indexed yet (run 'yt index build --project <PROJECT>')")
+		}
+	}
+
+	result := &FindResult{Terms: terms, Hits: []*index.Hit{}}
+	for _, projectID := range projectIDs {
+		idx, err := store.Load(projectID)
+		if err != nil {
+			return err
+		}
+		if idx == nil {
+			return fmt.Errorf("project %s is not indexed (run 'yt index build --project %s')", strings.ToUpper(projectID), projectID)
+		}
+		result.Indexes = append(result.Indexes, &IndexInfo{Project: idx.Project, Issues: len(idx.Documents), Refreshed: idx.Refreshed})
+		result.Hits = append(result.Hits, idx.Search(terms)...)
+	}
+
+	index.SortHits(result.Hits)
+	result.Total = len(result.Hits)
+	if len(result.Hits) > findLimit {
+		result.Hits = result.Hits[:findLimit]
+	}
+
+	return outputResult(result, formatFindResult)
+}
+
+func formatFindResult(data interface{}) error {
+	result := data.(*FindResult)
+
+	if len(result.Hits) == 0 {
+		fmt.Printf("No indexed issues match %q.\n", result.Terms)
+	} else {
+		t := theme.Current().Table("ISSUE", "STATE", "SUMMARY", "UPDATED").Flex(2)
+
+		for _, hit := range result.Hits {
+			summary := hit.Document.Summary
+			if hit.Snippet != "" {
+				summary += "\n  " + hit.Snippet
+			}
+			state := hit.Document.State
+			if state == "" {
+				state = "-"
+			}
+			t.Row(hit.Document.ID, state, summary, hit.Document.Updated.Local().Format("2006-01-02"))
+		}
+		fmt.Println(t)
+
+		if result.Total > len(result.Hits) {
+			fmt.Printf("%d of %d matching issue(s), use --limit to see more\n", len(result.Hits), result.Total)
+		} else {
+			fmt.Printf("%d matching issue(s)\n", result.Total)
+		}
+	}
+
+	// Searching an old snapshot misses recent changes
+	for _, info := range result.Indexes {
+		if time.Since(info.Refreshed) > 24*time.Hour {
+			fmt.Printf("The index of %s was refreshed %s, run 'yt index build --project %s' to update it\n",
+				info.Project, info.Refreshed.Local().Format("2006-01-02 15:04"), info.Project)
+		}
+	}
+	return nil
+}
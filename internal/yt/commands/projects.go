@@ -7,12 +7,13 @@ import (
 	"net/url"
 	"strings"
 
-	"github.com/charmbracelet/lipgloss"
-	"github.com/charmbracelet/lipgloss/table"
 	"github.com/charmbracelet/log"
 	"github.com/spf13/cobra"
 
+	"github.com/mkozhukh/youtrack/internal/yt/cache"
 	"github.com/mkozhukh/youtrack/internal/yt/config"
+	"github.com/mkozhukh/youtrack/internal/yt/projects"
+	"github.com/mkozhukh/youtrack/internal/yt/theme"
 	"github.com/mkozhukh/youtrack/pkg/youtrack"
 )
 
@@ -68,7 +69,7 @@ func listProjects(cmd *cobra.Command, args []string) error {
 	}
 
 	// Create client and context
-	client := youtrack.NewClient(cfg.Server.URL)
+	client := cfg.NewClient()
 	ctx := youtrack.NewYouTrackContext(context.Background(), cfg.Server.Token)
 
 	// Fetch projects
@@ -104,8 +105,16 @@ func describeProject(cmd *cobra.Command, args []string) error {
 	}
 
 	// Create client and context
-	client := youtrack.NewClient(cfg.Server.URL)
+	client := cfg.NewClient()
 	ctx := youtrack.NewYouTrackContext(context.Background(), cfg.Server.Token)
+	store := cache.Open(cfg)
+
+	// Resolve the project by short name or name
+	resolved, err := projects.Resolve(store, client, ctx, projectID)
+	if err != nil {
+		return err
+	}
+	projectID = resolved.ShortName
 
 	// Fetch project details
 	project, err := client.GetProject(ctx, projectID)
@@ -117,12 +126,17 @@ func describeProject(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to fetch project: %w", err)
 	}
 
-	// Fetch project custom fields
-	customFields, err := fetchProjectCustomFields(client, ctx, projectID)
-	if err != nil {
-		log.Warn("Failed to fetch custom fields", "error", err)
-		// Don't fail the command if we can't get custom fields
-		customFields = nil
+	// Fetch project custom fields, using the local cache when fresh
+	var customFields interface{}
+	if !store.Get(projectID, cacheKindCustomFields, &customFields) {
+		customFields, err = fetchProjectCustomFields(client, ctx, projectID)
+		if err != nil {
+			log.Warn("Failed to fetch custom fields", "error", err)
+			// Don't fail the command if we can't get custom fields
+			customFields = nil
+		} else if err := store.Set(projectID, cacheKindCustomFields, customFields); err != nil {
+			log.Warn("Failed to cache custom fields", "error", err)
+		}
 	}
 
 	// Create a detailed project structure
@@ -208,25 +222,14 @@ func formatProjectsList(projects []*youtrack.Project) error {
 		return nil
 	}
 
-	cellStyle := lipgloss.NewStyle().Padding(0, 1).Foreground(lipgloss.Color("246"))
-	t := table.New().
-		Border(lipgloss.NormalBorder()).
-		BorderStyle(lipgloss.NewStyle().Foreground(lipgloss.Color("99"))).
-		StyleFunc(func(row, col int) lipgloss.Style {
-			return cellStyle
-		}).
-		Headers("ID", "NAME", "SHORT NAME", "DESCRIPTION")
+	t := theme.Current().Table("ID", "NAME", "SHORT NAME", "DESCRIPTION").Flex(3)
 
 	for _, project := range projects {
-		description := project.Description
-		if len(description) > 50 {
-			description = description[:47] + "..."
-		}
 		t.Row(
 			project.ID,
 			project.Name,
 			project.ShortName,
-			description,
+			project.Description,
 		)
 	}
 
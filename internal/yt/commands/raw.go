@@ -0,0 +1,177 @@
+package commands
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/mkozhukh/youtrack/internal/yt/config"
+	"github.com/mkozhukh/youtrack/internal/yt/extract"
+	"github.com/mkozhukh/youtrack/pkg/youtrack"
+)
+
+var (
+	rawData   string
+	rawQuery  []string
+	rawHeader []string
+)
+
+// rawCmd represents the raw command
+var rawCmd = &cobra.Command{
+	Use:   "raw <method> <path>",
+	Short: "Sends a request to any endpoint of the YouTrack REST API",
+	Long: `Sends a request to the YouTrack REST API with the configured server and token and prints
+the response, for the endpoints yt has no command for. A path without a leading slash is
+relative to /api/, so "issues/PRJ-1" is /api/issues/PRJ-1. JSON responses are indented;
+--jsonpath and --template select values of them.
+
+The body is given with --data, either as a JSON document, as @file.json to read it from a
+file, or as @- to read it from stdin.`,
+	Example: `  yt raw GET issues/PRJ-1 --query fields=idReadable,summary
+  yt raw POST issues/PRJ-1/comments --data '{"text":"Deployed"}'
+  yt raw POST admin/projects/0-1/customFields --data @field.json
+  yt raw GET issues/PRJ-1/attachments/7-1 -H 'Accept: */*' > file.bin
+  yt raw GET admin/customFieldSettings/customFields --query fields=name --jsonpath '$[*].name'`,
+	Args: cobra.ExactArgs(2),
+	RunE: sendRawRequest,
+}
+
+func init() {
+	rawCmd.Flags().StringVarP(&rawData, "data", "d", "", "JSON body, @file.json to read it from a file, or @- to read it from stdin")
+	rawCmd.Flags().StringArrayVar(&rawQuery, "query", nil, "Query parameter as key=value (repeatable)")
+	rawCmd.Flags().StringArrayVarP(&rawHeader, "header", "H", nil, "Request header as 'Name: value' (repeatable)")
+}
+
+func sendRawRequest(cmd *cobra.Command, args []string) error {
+	// Load configuration
+	cfg, err := config.Load(cfgFile, cmd.Flags())
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+
+	// Validate configuration
+	if err := cfg.Validate(); err != nil {
+		return fmt.Errorf("invalid configuration: %w", err)
+	}
+
+	method := strings.ToUpper(args[0])
+	path, query, err := parseRawPath(args[1], rawQuery)
+	if err != nil {
+		return err
+	}
+
+	header := make(http.Header)
+	for _, line := range rawHeader {
+		name, value, ok := strings.Cut(line, ":")
+		if !ok || strings.TrimSpace(name) == "" {
+			return fmt.Errorf("invalid header: %s (use 'Name: value')", line)
+		}
+		header.Add(strings.TrimSpace(name), strings.TrimSpace(value))
+	}
+
+	var body interface{}
+	if rawData != "" {
+		data, err := readRawData(rawData)
+		if err != nil {
+			return err
+		}
+		body = json.RawMessage(data)
+	}
+
+	// Create client and context
+	client := cfg.NewClient()
+	ctx := youtrack.NewYouTrackContext(context.Background(), cfg.Server.Token)
+
+	resp, err := client.Do(ctx, method, path, body, &youtrack.RequestOptions{Query: query, Header: header})
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read response: %w", err)
+	}
+	return printRawResponse(data)
+}
+
+// parseRawPath resolves a path relative to /api/ and merges the query of the path with the
+// key=value parameters
+func parseRawPath(rawPath string, params []string) (string, url.Values, error) {
+	path, rawQuery, _ := strings.Cut(rawPath, "?")
+	if !strings.HasPrefix(path, "/") {
+		path = "/api/" + path
+	}
+
+	query, err := url.ParseQuery(rawQuery)
+	if err != nil {
+		return "", nil, fmt.Errorf("invalid query in path: %w", err)
+	}
+	for _, param := range params {
+		key, value, ok := strings.Cut(param, "=")
+		if !ok || key == "" {
+			return "", nil, fmt.Errorf("invalid query parameter: %s (use key=value)", param)
+		}
+		query.Add(key, value)
+	}
+	if len(query) == 0 {
+		query = nil
+	}
+	return path, query, nil
+}
+
+// readRawData reads the request body from the flag, a file or stdin, and checks it is JSON
+func readRawData(value string) ([]byte, error) {
+	data := []byte(value)
+	if name, ok := strings.CutPrefix(value, "@"); ok {
+		var err error
+		if name == "-" {
+			data, err = io.ReadAll(os.Stdin)
+		} else {
+			data, err = os.ReadFile(name)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read request body: %w", err)
+		}
+	}
+	if !json.Valid(data) {
+		return nil, fmt.Errorf("request body is not valid JSON")
+	}
+	return data, nil
+}
+
+// printRawResponse prints a JSON response indented, or the values selected by --jsonpath or
+// --template, and any other response as is
+func printRawResponse(data []byte) error {
+	if len(bytes.TrimSpace(data)) == 0 {
+		return nil
+	}
+	if !json.Valid(data) {
+		_, err := os.Stdout.Write(data)
+		return err
+	}
+
+	if outputTmpl != "" || outputJSONPath != "" {
+		var value interface{}
+		if err := json.Unmarshal(data, &value); err != nil {
+			return fmt.Errorf("failed to decode response: %w", err)
+		}
+		return extract.Print(os.Stdout, outputTmpl, outputJSONPath, value)
+	}
+
+	var indented bytes.Buffer
+	if err := json.Indent(&indented, data, "", "  "); err != nil {
+		return fmt.Errorf("failed to format response: %w", err)
+	}
+	indented.WriteByte('\n')
+	_, err := indented.WriteTo(os.Stdout)
+	return err
+}
@@ -0,0 +1,620 @@
+package commands
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/log"
+	"github.com/spf13/cobra"
+
+	"github.com/mkozhukh/youtrack/internal/yt/cache"
+	"github.com/mkozhukh/youtrack/internal/yt/config"
+	"github.com/mkozhukh/youtrack/internal/yt/projects"
+	"github.com/mkozhukh/youtrack/internal/yt/theme"
+	"github.com/mkozhukh/youtrack/pkg/youtrack"
+)
+
+var (
+	reportProject string
+
+	// Changelog command flags
+	changelogQuery   string
+	changelogGroupBy string
+	changelogOrder   string
+	changelogTitle   string
+
+	// Calendar command flags
+	calendarQuery string
+	calendarField string
+	calendarOut   string
+
+	// Gantt command flags
+	ganttQuery      string
+	ganttStartField string
+	ganttDueField   string
+	ganttGroupBy    string
+	ganttTitle      string
+	ganttOut        string
+
+	// Team time command flags
+	teamTimeSince     string
+	teamTimeUntil     string
+	teamTimeTypeField string
+	teamTimeOut       string
+	teamTimeWorkers   int
+
+	// Fields command flags
+	fieldsNames string
+	fieldsQuery string
+
+	// Stale command flags
+	staleQuery   string
+	staleDays    int
+	staleComment string
+	staleTag     string
+
+	// Cycle time command flags
+	cycleTimeQuery      string
+	cycleTimeSince      string
+	cycleTimeStateField string
+	cycleTimeWorkers    int
+
+	// Budget command flags
+	budgetSince   string
+	budgetUntil   string
+	budgetWorkers int
+
+	// SLA command flags
+	slaQuery        string
+	slaBreachedOnly bool
+	slaWorkers      int
+)
+
+// reportCmd represents the report command
+var reportCmd = &cobra.Command{
+	Use:   "report",
+	Short: "Generate reports",
+	Long:  `Generate reports such as changelogs from YouTrack issues.`,
+}
+
+// changelogCmd represents the report changelog command
+var changelogCmd = &cobra.Command{
+	Use:   "changelog",
+	Short: "Generates release notes in Markdown",
+	Long: `Generates release notes in Markdown from the issues matching a query,
+grouped by a custom field (Type by default).`,
+	Example: `  yt report changelog --project PRJ --query "Fix versions: 2024.2 #Resolved"`,
+	RunE:    generateChangelog,
+}
+
+// calendarCmd represents the report calendar command
+var calendarCmd = &cobra.Command{
+	Use:   "calendar",
+	Short: "Exports due dates as an iCalendar (.ics) file",
+	Long: `Exports the issues with a due date as all-day calendar events in iCalendar format,
+for import into calendar applications.`,
+	Example: `  yt report calendar --project PRJ --out due.ics`,
+	RunE:    exportCalendar,
+}
+
+// ganttCmd represents the report gantt command
+var ganttCmd = &cobra.Command{
+	Use:   "gantt",
+	Short: "Exports the schedule as a Mermaid gantt chart",
+	Long: `Exports the issues with a due date as a Mermaid gantt chart, a bar from the start date to
+the due date per issue, with a section per assignee or per value of a custom field such as
+Subsystem. Issues without a start date are milestones on their due date. Resolved issues
+are marked done, overdue ones critical and the ones under way active.`,
+	Example: `  yt report gantt --project PRJ --out plan.mmd
+  yt report gantt --project PRJ --group-by Subsystem --query "Fix versions: 2.0"`,
+	Args: cobra.NoArgs,
+	RunE: exportGantt,
+}
+
+// teamTimeCmd represents the report team-time command
+var teamTimeCmd = &cobra.Command{
+	Use:   "team-time",
+	Short: "Exports the time logged by the project team as CSV",
+	Long: `Exports the time logged in a project by every project user, aggregated per user and
+issue type, as CSV for payroll and invoicing systems. Worklogs of the users are fetched
+concurrently. The period defaults to the current month.`,
+	Example: `  yt report team-time --project PRJ --since 2025-01-01 --until 2025-01-31 --out january.csv`,
+	RunE:    exportTeamTime,
+}
+
+// fieldsReportCmd represents the report fields command
+var fieldsReportCmd = &cobra.Command{
+	Use:   "fields",
+	Short: "Shows the distribution of custom field values",
+	Long: `Shows how the issues matching a query (unresolved by default) are distributed over the
+values of one or more custom fields, as a table with a histogram. Useful for backlog
+health checks. Counts come from count-only queries, no issues are fetched.`,
+	Example: `  yt report fields --project PRJ --field State,Priority`,
+	RunE:    reportFieldDistribution,
+}
+
+// staleCmd represents the report stale command
+var staleCmd = &cobra.Command{
+	Use:   "stale",
+	Short: "Lists unresolved issues not updated for a while",
+	Long: `Lists the unresolved issues that were not updated for a number of days, oldest first.
+Optionally posts a nudge comment on each of them and/or tags them (with "stale" when
+--tag is given without a value), reporting the outcome per issue. Commenting or tagging
+updates the issues, so they are not reported as stale again right away.`,
+	Example: `  yt report stale --project PRJ --days 30
+  yt report stale --project PRJ --days 60 --comment "Is this still relevant?" --tag`,
+	RunE: reportStaleIssues,
+}
+
+// slaCmd represents the report sla command
+var slaCmd = &cobra.Command{
+	Use:   "sla",
+	Short: "Checks issues against the SLA policy",
+	Long: `Checks the issues matching a query against the SLA policy of the [sla] config section:
+the time to the first response (the first comment by someone other than the reporter) and
+to the resolution, with targets per priority. Lists every issue with the outcome of both
+targets and flags breaches. Times are calendar time, working hours are not taken into account.`,
+	Example: `  yt report sla --project PRJ
+  yt report sla --project PRJ --query "created: {last month}" --breached`,
+	RunE: reportSLA,
+}
+
+// budgetCmd represents the report budget command
+var budgetCmd = &cobra.Command{
+	Use:   "budget",
+	Short: "Compares the cost of the time logged in a project with its budget",
+	Long: `Prices the time logged in a project with the hourly rates of the [budget] config section
+and compares the cost with the budget of the [project.PRJ.budget] section. A user's rate is
+their rate in the project, else their global rate, else the project rate, else the global
+rate; time of users without a rate is reported apart and not priced.
+
+The report warns when the cost passes a threshold of budget.warn_at (80% by default) and
+when it exceeds the budget.`,
+	Example: `  yt report budget --project PRJ
+  yt report budget --project PRJ --since 2025-01-01 --until 2025-03-31`,
+	Args: cobra.NoArgs,
+	RunE: reportBudget,
+}
+
+// cycleTimeCmd represents the report cycle-time command
+var cycleTimeCmd = &cobra.Command{
+	Use:   "cycle-time",
+	Short: "Shows the time issues spent in each state",
+	Long: `Shows how long the issues resolved since a date spent in each state, computed from
+their activity streams: the median, 75th and 90th percentiles and the maximum per state,
+and the same for the lead time from creation to resolution. With --output json, the time
+in state of every issue is included, for engineering metrics dashboards.`,
+	Example: `  yt report cycle-time --project PRJ --since 2025-01-01
+  yt report cycle-time --project PRJ --query "Type: Bug" -o json`,
+	RunE: reportCycleTime,
+}
+
+func init() {
+	reportCmd.AddCommand(changelogCmd)
+	reportCmd.AddCommand(calendarCmd)
+	reportCmd.AddCommand(ganttCmd)
+	reportCmd.AddCommand(teamTimeCmd)
+	reportCmd.AddCommand(fieldsReportCmd)
+	reportCmd.AddCommand(staleCmd)
+	reportCmd.AddCommand(slaCmd)
+	reportCmd.AddCommand(cycleTimeCmd)
+	reportCmd.AddCommand(budgetCmd)
+
+	changelogCmd.Flags().StringVarP(&reportProject, "project", "p", "", "The project short name or name (uses default from config if not provided)")
+	changelogCmd.Flags().StringVarP(&changelogQuery, "query", "q", "#Resolved", "YouTrack search query selecting the issues")
+	changelogCmd.Flags().StringVar(&changelogGroupBy, "group-by", "Type", "Custom field to group issues by")
+	changelogCmd.Flags().StringVar(&changelogOrder, "order", "Feature,Bug,Task", "Comma-separated group order; other groups follow alphabetically")
+	changelogCmd.Flags().StringVar(&changelogTitle, "title", "", "Title of the release notes")
+
+	calendarCmd.Flags().StringVarP(&reportProject, "project", "p", "", "The project short name or name (uses default from config if not provided)")
+	calendarCmd.Flags().StringVarP(&calendarQuery, "query", "q", "#Unresolved", "YouTrack search query selecting the issues")
+	calendarCmd.Flags().StringVar(&calendarField, "field", "Due Date", "Date custom field holding the due date")
+	calendarCmd.Flags().StringVar(&calendarOut, "out", "", "Output file (prints to stdout if not provided)")
+
+	ganttCmd.Flags().StringVarP(&reportProject, "project", "p", "", "The project short name or name (uses default from config if not provided)")
+	ganttCmd.Flags().StringVarP(&ganttQuery, "query", "q", "#Unresolved", "YouTrack search query selecting the issues")
+	ganttCmd.Flags().StringVar(&ganttStartField, "start-field", "Start Date", "Date custom field holding the start date")
+	ganttCmd.Flags().StringVar(&ganttDueField, "due-field", "Due Date", "Date custom field holding the due date")
+	ganttCmd.Flags().StringVar(&ganttGroupBy, "group-by", ganttGroupAssignee, "Group the issues by assignee or by a custom field, e.g. Subsystem")
+	ganttCmd.Flags().StringVar(&ganttTitle, "title", "", "Title of the chart (defaults to the project name)")
+	ganttCmd.Flags().StringVar(&ganttOut, "out", "", "Output file (prints to stdout if not provided)")
+
+	teamTimeCmd.Flags().StringVarP(&reportProject, "project", "p", "", "The project short name or name (uses default from config if not provided)")
+	teamTimeCmd.Flags().StringVar(&teamTimeSince, "since", "", "Start date in YYYY-MM-DD format (defaults to the first day of the month)")
+	teamTimeCmd.Flags().StringVar(&teamTimeUntil, "until", "", "End date in YYYY-MM-DD format (defaults to today)")
+	teamTimeCmd.Flags().StringVar(&teamTimeTypeField, "type-field", "Type", "Custom field holding the issue type")
+	teamTimeCmd.Flags().StringVar(&teamTimeOut, "out", "", "Output file (prints to stdout if not provided)")
+	teamTimeCmd.Flags().IntVar(&teamTimeWorkers, "concurrency", 4, "Number of users whose worklogs are fetched at the same time")
+
+	fieldsReportCmd.Flags().StringVarP(&reportProject, "project", "p", "", "The project short name or name (uses default from config if not provided)")
+	fieldsReportCmd.Flags().StringVarP(&fieldsNames, "field", "f", "State,Priority", "Comma-separated custom fields to report on")
+	fieldsReportCmd.Flags().StringVarP(&fieldsQuery, "query", "q", "#Unresolved", "YouTrack search query selecting the issues")
+
+	staleCmd.Flags().StringVarP(&reportProject, "project", "p", "", "The project short name or name (uses default from config if not provided)")
+	staleCmd.Flags().IntVar(&staleDays, "days", 30, "Days without updates after which an issue is stale")
+	staleCmd.Flags().StringVarP(&staleQuery, "query", "q", "", "Additional YouTrack search query narrowing down the issues")
+	staleCmd.Flags().StringVar(&staleComment, "comment", "", "Post this comment on every stale issue")
+	staleCmd.Flags().StringVar(&staleTag, "tag", "", "Tag every stale issue (\"stale\" when given without a value)")
+	staleCmd.Flags().Lookup("tag").NoOptDefVal = "stale"
+
+	slaCmd.Flags().StringVarP(&reportProject, "project", "p", "", "The project short name or name (uses default from config if not provided)")
+	slaCmd.Flags().StringVarP(&slaQuery, "query", "q", "#Unresolved", "YouTrack search query selecting the issues")
+	slaCmd.Flags().BoolVar(&slaBreachedOnly, "breached", false, "List only the issues with a breached target")
+	slaCmd.Flags().IntVar(&slaWorkers, "concurrency", 4, "Number of issues whose comments are fetched at the same time")
+
+	cycleTimeCmd.Flags().StringVarP(&reportProject, "project", "p", "", "The project short name or name (uses default from config if not provided)")
+	cycleTimeCmd.Flags().StringVar(&cycleTimeSince, "since", "", "Report the issues resolved since this date, YYYY-MM-DD (defaults to 30 days ago)")
+	cycleTimeCmd.Flags().StringVarP(&cycleTimeQuery, "query", "q", "", "Additional YouTrack search query narrowing down the issues")
+	cycleTimeCmd.Flags().StringVar(&cycleTimeStateField, "state-field", "State", "Custom field holding the state")
+	cycleTimeCmd.Flags().IntVar(&cycleTimeWorkers, "concurrency", 4, "Number of issues whose activities are fetched at the same time")
+
+	budgetCmd.Flags().StringVarP(&reportProject, "project", "p", "", "The project short name or name (uses default from config if not provided)")
+	budgetCmd.Flags().StringVar(&budgetSince, "since", "", "Start date in YYYY-MM-DD format (defaults to the budget start, or the first day of the month)")
+	budgetCmd.Flags().StringVar(&budgetUntil, "until", "", "End date in YYYY-MM-DD format (defaults to today)")
+	budgetCmd.Flags().IntVar(&budgetWorkers, "concurrency", 4, "Number of users whose worklogs are fetched at the same time")
+}
+
+func generateChangelog(cmd *cobra.Command, args []string) error {
+	// Load configuration
+	cfg, err := config.Load(cfgFile, cmd.Flags())
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+
+	// Validate configuration
+	if err := cfg.Validate(); err != nil {
+		return fmt.Errorf("invalid configuration: %w", err)
+	}
+
+	// Determine project ID to use
+	projectID := reportProject
+	if projectID == "" {
+		projectID = cfg.Defaults.Project
+	}
+	if projectID == "" {
+		return fmt.Errorf("project ID is required (use --project flag or set default project in config)")
+	}
+
+	// Create client and context
+	client := cfg.NewClient()
+	ctx := youtrack.NewYouTrackContext(context.Background(), cfg.Server.Token)
+
+	// Resolve the project by short name or name
+	project, err := projects.Resolve(cache.Open(cfg), client, ctx, projectID)
+	if err != nil {
+		return err
+	}
+
+	searchQuery := strings.TrimSpace(fmt.Sprintf("project: %s %s", project.ShortName, changelogQuery))
+	log.Info("Collecting changelog issues", "query", searchQuery)
+
+	var issues []*youtrack.Issue
+	err = client.ForEachIssue(ctx, searchQuery, youtrack.DefaultPageSize, func(issue *youtrack.Issue) error {
+		issues = append(issues, issue)
+		return nil
+	})
+	if err != nil {
+		log.Error("Failed to search issues", "error", err)
+		return fmt.Errorf("failed to search issues: %w", err)
+	}
+
+	notes := youtrack.BuildReleaseNotes(changelogTitle, issues, changelogGroupBy, splitList(changelogOrder))
+
+	// Output results
+	return outputResult(notes, func(data interface{}) error {
+		fmt.Print(data.(*youtrack.ReleaseNotes).Markdown())
+		return nil
+	})
+}
+
+func exportCalendar(cmd *cobra.Command, args []string) error {
+	// Load configuration
+	cfg, err := config.Load(cfgFile, cmd.Flags())
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+
+	// Validate configuration
+	if err := cfg.Validate(); err != nil {
+		return fmt.Errorf("invalid configuration: %w", err)
+	}
+
+	// Determine project ID to use
+	projectID := reportProject
+	if projectID == "" {
+		projectID = cfg.Defaults.Project
+	}
+	if projectID == "" {
+		return fmt.Errorf("project ID is required (use --project flag or set default project in config)")
+	}
+
+	// Create client and context
+	client := cfg.NewClient()
+	ctx := youtrack.NewYouTrackContext(context.Background(), cfg.Server.Token)
+
+	// Resolve the project by short name or name
+	project, err := projects.Resolve(cache.Open(cfg), client, ctx, projectID)
+	if err != nil {
+		return err
+	}
+
+	searchQuery := strings.TrimSpace(fmt.Sprintf("project: %s has: {%s} %s", project.ShortName, calendarField, calendarQuery))
+	log.Info("Collecting issues with due dates", "query", searchQuery)
+
+	var events []calendarEvent
+	err = client.ForEachIssue(ctx, searchQuery, youtrack.DefaultPageSize, func(issue *youtrack.Issue) error {
+		if due, ok := issue.DateField(calendarField); ok {
+			events = append(events, calendarEvent{Issue: issue, Date: due})
+		}
+		return nil
+	})
+	if err != nil {
+		log.Error("Failed to search issues", "error", err)
+		return fmt.Errorf("failed to search issues: %w", err)
+	}
+
+	out := os.Stdout
+	if calendarOut != "" {
+		file, err := os.Create(calendarOut)
+		if err != nil {
+			return fmt.Errorf("failed to create output file: %w", err)
+		}
+		defer file.Close()
+		out = file
+	}
+
+	if err := writeCalendar(out, cfg.Server.URL, project, events); err != nil {
+		return fmt.Errorf("failed to write calendar: %w", err)
+	}
+
+	if calendarOut != "" {
+		fmt.Printf("Exported %d events to %s\n", len(events), calendarOut)
+	}
+	return nil
+}
+
+func exportTeamTime(cmd *cobra.Command, args []string) error {
+	// Default to the current month
+	now := time.Now()
+	since, until := teamTimeSince, teamTimeUntil
+	if since == "" {
+		since = time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.Local).Format("2006-01-02")
+	}
+	if until == "" {
+		until = now.Format("2006-01-02")
+	}
+	if _, err := parseDate(since); err != nil {
+		return fmt.Errorf("invalid start date format: %s (use YYYY-MM-DD)", since)
+	}
+	if _, err := parseDate(until); err != nil {
+		return fmt.Errorf("invalid end date format: %s (use YYYY-MM-DD)", until)
+	}
+
+	// Load configuration
+	cfg, err := config.Load(cfgFile, cmd.Flags())
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+
+	// Validate configuration
+	if err := cfg.Validate(); err != nil {
+		return fmt.Errorf("invalid configuration: %w", err)
+	}
+
+	// Determine project ID to use
+	projectID := reportProject
+	if projectID == "" {
+		projectID = cfg.Defaults.Project
+	}
+	if projectID == "" {
+		return fmt.Errorf("project ID is required (use --project flag or set default project in config)")
+	}
+
+	// Create client and context
+	client := cfg.NewClient()
+	ctx := youtrack.NewYouTrackContext(context.Background(), cfg.Server.Token)
+	store := cache.Open(cfg)
+
+	// Resolve the project by short name or name
+	project, err := projects.Resolve(store, client, ctx, projectID)
+	if err != nil {
+		return err
+	}
+	projectID = project.ShortName
+
+	users, err := cachedProjectUsers(store, client, ctx, projectID)
+	if err != nil {
+		return err
+	}
+
+	log.Info("Collecting team worklogs", "project", projectID, "users", len(users), "since", since, "until", until)
+
+	workItems, err := fetchTeamWorklogs(client, ctx, users, projectID, since, until, teamTimeWorkers)
+	if err != nil {
+		return err
+	}
+
+	// Look up the type of every issue time was logged on
+	seen := make(map[string]bool)
+	var issueIDs []string
+	for _, items := range workItems {
+		for _, item := range items {
+			if item.Issue != nil && !seen[item.Issue.ID] {
+				seen[item.Issue.ID] = true
+				issueIDs = append(issueIDs, item.Issue.ID)
+			}
+		}
+	}
+	sort.Strings(issueIDs)
+	issueTypes, err := fetchIssueTypes(client, ctx, issueIDs, teamTimeTypeField)
+	if err != nil {
+		log.Error("Failed to fetch issue types", "error", err)
+		return err
+	}
+
+	report := buildTeamTimeReport(users, workItems, issueTypes)
+	report.Project, report.Since, report.Until = projectID, since, until
+
+	out := os.Stdout
+	if teamTimeOut != "" {
+		file, err := os.Create(teamTimeOut)
+		if err != nil {
+			return fmt.Errorf("failed to create output file: %w", err)
+		}
+		defer file.Close()
+		out = file
+	}
+
+	if output == "json" {
+		encoder := json.NewEncoder(out)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(report); err != nil {
+			return err
+		}
+	} else if err := writeTeamTimeCSV(out, report); err != nil {
+		return fmt.Errorf("failed to write report: %w", err)
+	}
+
+	if teamTimeOut != "" {
+		fmt.Printf("Exported %d rows for %d users (%s) to %s\n", len(report.Rows), len(report.Users), formatDuration(report.TotalMinutes), teamTimeOut)
+	}
+	return nil
+}
+
+// cachedProjectUsers fetches all project users, using the local cache when fresh
+func cachedProjectUsers(store *cache.Store, client *youtrack.Client, ctx *youtrack.YouTrackContext, projectID string) ([]*youtrack.User, error) {
+	var users []*youtrack.User
+	if store.Get(projectID, cacheKindUsers, &users) {
+		return users, nil
+	}
+
+	users, err := fetchAllProjectUsers(client, ctx, projectID)
+	if err != nil {
+		log.Error("Failed to fetch project users", "error", err)
+		return nil, fmt.Errorf("failed to fetch project users: %w", err)
+	}
+	if err := store.Set(projectID, cacheKindUsers, users); err != nil {
+		log.Warn("Failed to cache project users", "error", err)
+	}
+	return users, nil
+}
+
+func reportFieldDistribution(cmd *cobra.Command, args []string) error {
+	fields := splitList(fieldsNames)
+	if len(fields) == 0 {
+		return fmt.Errorf("at least one field is required (use --field flag)")
+	}
+
+	// Load configuration
+	cfg, err := config.Load(cfgFile, cmd.Flags())
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+
+	// Validate configuration
+	if err := cfg.Validate(); err != nil {
+		return fmt.Errorf("invalid configuration: %w", err)
+	}
+
+	// Determine project ID to use
+	projectID := reportProject
+	if projectID == "" {
+		projectID = cfg.Defaults.Project
+	}
+	if projectID == "" {
+		return fmt.Errorf("project ID is required (use --project flag or set default project in config)")
+	}
+
+	// Create client and context
+	client := cfg.NewClient()
+	ctx := youtrack.NewYouTrackContext(context.Background(), cfg.Server.Token)
+
+	// Resolve the project by short name or name
+	project, err := projects.Resolve(cache.Open(cfg), client, ctx, projectID)
+	if err != nil {
+		return err
+	}
+
+	var distributions []*youtrack.FieldDistribution
+	for _, field := range fields {
+		log.Info("Counting field values", "project", project.ShortName, "field", field, "query", fieldsQuery)
+		dist, err := client.GetFieldDistribution(ctx, project.ShortName, field, fieldsQuery)
+		if err != nil {
+			log.Error("Failed to count field values", "field", field, "error", err)
+			return fmt.Errorf("failed to count values of %s: %w", field, err)
+		}
+		distributions = append(distributions, dist)
+	}
+
+	// Output results
+	return outputResult(distributions, func(data interface{}) error {
+		return formatFieldDistributions(data.([]*youtrack.FieldDistribution))
+	})
+}
+
+// splitList splits a comma-separated list, dropping empty entries
+func splitList(s string) []string {
+	var items []string
+	for _, item := range strings.Split(s, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// formatFieldDistributions prints a table with a histogram bar per field
+func formatFieldDistributions(distributions []*youtrack.FieldDistribution) error {
+	const barWidth = 30
+
+	th := theme.Current()
+	for i, dist := range distributions {
+		if i > 0 {
+			fmt.Println()
+		}
+		fmt.Printf("%s\n", th.Header.Render(fmt.Sprintf("%s (%d issues)", dist.Field, dist.Total)))
+
+		largest := 0
+		for _, v := range dist.Values {
+			largest = max(largest, v.Count)
+		}
+
+		t := th.Table("VALUE", "COUNT", "%", "").
+			Style(func(row, col int) lipgloss.Style {
+				if col == 3 {
+					return th.Accent
+				}
+				return th.Cell
+			})
+
+		for _, v := range dist.Values {
+			// Skip the empty bucket when no issue lacks a value
+			if v.Value == "" && v.Count == 0 {
+				continue
+			}
+			name := v.Value
+			if name == "" {
+				name = "(no value)"
+			}
+
+			percent, bar := 0.0, ""
+			if dist.Total > 0 {
+				percent = float64(v.Count) * 100 / float64(dist.Total)
+			}
+			if largest > 0 {
+				bar = strings.Repeat("█", (v.Count*barWidth+largest-1)/largest)
+			}
+			t.Row(name, strconv.Itoa(v.Count), fmt.Sprintf("%.1f", percent), bar)
+		}
+
+		fmt.Println(t)
+	}
+	return nil
+}
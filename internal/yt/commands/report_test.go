@@ -0,0 +1,36 @@
+package commands
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestReportChangelog_DefaultQuery(t *testing.T) {
+	var queries []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/issues" {
+			http.NotFound(w, r)
+			return
+		}
+		queries = append(queries, r.URL.Query().Get("query"))
+		w.Write([]byte(`[]`))
+	}))
+	defer server.Close()
+
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("YT_SERVER_URL", server.URL)
+	t.Setenv("YT_SERVER_TOKEN", "token")
+	t.Setenv("YT_DEFAULTS_PROJECT", "PRJ")
+	t.Setenv("YT_CACHE_DIR", t.TempDir())
+
+	// The other report commands register --query with other defaults after changelog
+	rootCmd.SetArgs([]string{"report", "changelog"})
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if len(queries) != 1 || queries[0] != "project: PRJ #Resolved" {
+		t.Errorf("Expected query 'project: PRJ #Resolved', got %q", queries)
+	}
+}
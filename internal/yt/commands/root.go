@@ -2,6 +2,7 @@ package commands
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
 
@@ -9,12 +10,22 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/mkozhukh/youtrack/internal/yt/commands/tickets"
+	"github.com/mkozhukh/youtrack/internal/yt/config"
+	"github.com/mkozhukh/youtrack/internal/yt/extract"
+	"github.com/mkozhukh/youtrack/internal/yt/ids"
+	"github.com/mkozhukh/youtrack/internal/yt/theme"
+	"github.com/mkozhukh/youtrack/pkg/youtrack"
 )
 
 var (
-	cfgFile string
-	verbose bool
-	output  string
+	cfgFile        string
+	verbose        bool
+	trace          bool
+	output         string
+	outputTmpl     string
+	outputJSONPath string
+	themeName      string
+	wide           bool
 )
 
 // rootCmd represents the base command when called without any subcommands
@@ -24,40 +35,110 @@ var rootCmd = &cobra.Command{
 	Long: `yt is a command-line interface (CLI) tool for interacting with a remote 
 YouTrack instance. It allows users to perform common YouTrack operations 
 directly from their terminal.`,
-	PersistentPreRun: func(cmd *cobra.Command, args []string) {
-		// Set log level based on verbose flag
-		if verbose {
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		// Set log level based on the verbosity flags, trace also prints each HTTP request
+		switch {
+		case trace:
+			log.SetLevel(log.DebugLevel)
+		case verbose:
 			log.SetLevel(log.InfoLevel)
-		} else {
+		default:
 			log.SetLevel(log.WarnLevel)
 		}
+		// Tables fit the terminal unless --wide keeps their columns whole
+		if !wide {
+			theme.SetWidth(theme.TerminalWidth())
+		}
+		return selectTheme()
 	},
 }
 
+// selectTheme sets the theme of the text output from the --theme flag, the environment or
+// the output.theme setting. A config file that fails to load is reported by the command, an
+// unknown configured theme only warned about, so that `yt config set` can still fix it.
+func selectTheme() error {
+	configured := os.Getenv("YT_OUTPUT_THEME")
+	if configured == "" {
+		if cfg, err := config.LoadFile(cfgFile); err == nil {
+			configured = cfg.Output.Theme
+		}
+	}
+	err := theme.Select(themeName, configured)
+	if err != nil && themeName == "" {
+		log.Warn("Ignoring the output.theme setting", "error", err)
+		return theme.Select("", "")
+	}
+	return err
+}
+
 // Execute adds all child commands to the root command and sets flags appropriately.
+// An alias of the [aliases] config section is expanded first.
 func Execute() {
+	rootCmd.SetArgs(osArgs())
+	translateErrors(rootCmd)
 	err := rootCmd.Execute()
+	var exitErr *tickets.ExitCodeError
+	if errors.As(err, &exitErr) {
+		os.Exit(exitErr.Code)
+	}
 	if err != nil {
 		os.Exit(1)
 	}
 }
 
+// translateErrors wraps the commands so that a refused request names the permission it needs,
+// e.g. "your token lacks 'Update Issue' in project PRJ", instead of the raw API error
+func translateErrors(cmd *cobra.Command) {
+	if run := cmd.RunE; run != nil {
+		cmd.RunE = func(cmd *cobra.Command, args []string) error {
+			return youtrack.TranslateError(run(cmd, args))
+		}
+	}
+	for _, sub := range cmd.Commands() {
+		translateErrors(sub)
+	}
+}
+
 func init() {
 	// Add subcommands
 	rootCmd.AddCommand(loginCmd)
+	rootCmd.AddCommand(configCmd)
 	rootCmd.AddCommand(projectsCmd)
 	rootCmd.AddCommand(tickets.TicketsCmd)
+	rootCmd.AddCommand(tickets.LinkPRCmd)
+	rootCmd.AddCommand(tickets.SyncCmd)
 	rootCmd.AddCommand(usersCmd)
+	rootCmd.AddCommand(groupsCmd)
+	rootCmd.AddCommand(inboxCmd)
+	rootCmd.AddCommand(reportCmd)
+	rootCmd.AddCommand(boardCmd)
+	rootCmd.AddCommand(worklogsCmd)
+	rootCmd.AddCommand(timerCmd)
+	rootCmd.AddCommand(cacheCmd)
+	rootCmd.AddCommand(indexCmd)
+	rootCmd.AddCommand(findCmd)
+	rootCmd.AddCommand(gitCmd)
+	rootCmd.AddCommand(annotateCmd)
+	rootCmd.AddCommand(rawCmd)
 	rootCmd.AddCommand(completionCmd)
 
 	// Global flags
 	rootCmd.PersistentFlags().StringVarP(&cfgFile, "config", "c", "", "config file (default is $HOME/.config/yt/config.toml)")
 	rootCmd.PersistentFlags().BoolVarP(&verbose, "verbose", "v", false, "enable verbose output")
-	rootCmd.PersistentFlags().StringVarP(&output, "output", "o", "text", "output format (text, json)")
+	rootCmd.PersistentFlags().BoolVar(&trace, "trace", false, "enable debug output with each HTTP request line and response status")
+	rootCmd.PersistentFlags().StringVarP(&output, "output", "o", "text", "output format (text, json, ids: ticket IDs one per line, for commands listing tickets)")
+	rootCmd.PersistentFlags().StringVar(&outputTmpl, "template", "", "print the result with a Go template, once per item of a list (e.g. '{{.ID}} {{.Summary}}')")
+	rootCmd.PersistentFlags().StringVar(&themeName, "theme", "", "color theme of the text output (dark, light, plain; default from output.theme, plain when NO_COLOR is set)")
+	rootCmd.PersistentFlags().BoolVar(&wide, "wide", false, "show table columns whole instead of fitting tables to the terminal width")
+	rootCmd.PersistentFlags().StringVar(&outputJSONPath, "jsonpath", "", "print the values a JSONPath expression selects in the json output (e.g. '$[*].idReadable')")
 }
 
 // Helper function to output in the requested format
 func outputResult(data interface{}, formatAsText func(interface{}) error) error {
+	if outputTmpl != "" || outputJSONPath != "" {
+		return extract.Print(os.Stdout, outputTmpl, outputJSONPath, data)
+	}
+
 	switch output {
 	case "json":
 		encoder := json.NewEncoder(os.Stdout)
@@ -65,6 +146,8 @@ func outputResult(data interface{}, formatAsText func(interface{}) error) error
 		return encoder.Encode(data)
 	case "text":
 		return formatAsText(data)
+	case ids.Format:
+		return ids.Print(os.Stdout, data)
 	default:
 		return fmt.Errorf("unsupported output format: %s", output)
 	}
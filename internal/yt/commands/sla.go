@@ -0,0 +1,211 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/log"
+	"github.com/spf13/cobra"
+
+	"github.com/mkozhukh/youtrack/internal/yt/cache"
+	"github.com/mkozhukh/youtrack/internal/yt/config"
+	"github.com/mkozhukh/youtrack/internal/yt/projects"
+	"github.com/mkozhukh/youtrack/internal/yt/theme"
+	"github.com/mkozhukh/youtrack/pkg/youtrack"
+)
+
+// SLAReport is the SLA evaluation of the issues matching a query
+type SLAReport struct {
+	Project  string                `json:"project"`
+	Query    string                `json:"query"`
+	Checked  int                   `json:"checked"`
+	Breached int                   `json:"breached"`
+	Issues   []*youtrack.SLAResult `json:"issues"`
+}
+
+// IssueIDs returns the IDs of the reported issues, for --output ids
+func (r *SLAReport) IssueIDs() []string {
+	ids := make([]string, len(r.Issues))
+	for i, result := range r.Issues {
+		ids[i] = result.IssueID
+	}
+	return ids
+}
+
+func reportSLA(cmd *cobra.Command, args []string) error {
+	// Load configuration
+	cfg, err := config.Load(cfgFile, cmd.Flags())
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+
+	// Validate configuration
+	if err := cfg.Validate(); err != nil {
+		return fmt.Errorf("invalid configuration: %w", err)
+	}
+
+	policy := slaPolicy(cfg)
+	if policy.IsEmpty() {
+		return fmt.Errorf("no SLA policy configured (add an [sla] section to the config)")
+	}
+
+	// Determine project ID to use
+	projectID := reportProject
+	if projectID == "" {
+		projectID = cfg.Defaults.Project
+	}
+	if projectID == "" {
+		return fmt.Errorf("project ID is required (use --project flag or set default project in config)")
+	}
+
+	// Create client and context
+	client := cfg.NewClient()
+	ctx := youtrack.NewYouTrackContext(context.Background(), cfg.Server.Token)
+
+	// Resolve the project by short name or name
+	project, err := projects.Resolve(cache.Open(cfg), client, ctx, projectID)
+	if err != nil {
+		return err
+	}
+
+	searchQuery := fmt.Sprintf("project: %s %s sort by: created asc", project.ShortName, slaQuery)
+	log.Info("Searching issues", "query", searchQuery)
+
+	var issues []*youtrack.Issue
+	err = client.ForEachIssue(ctx, searchQuery, youtrack.DefaultPageSize, func(issue *youtrack.Issue) error {
+		issues = append(issues, issue)
+		return nil
+	})
+	if err != nil {
+		log.Error("Failed to search issues", "error", err)
+		return fmt.Errorf("failed to search issues: %w", err)
+	}
+
+	comments, err := fetchIssuesComments(client, ctx, issues, slaWorkers)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	report := &SLAReport{Project: project.ShortName, Query: slaQuery, Checked: len(issues)}
+	for _, issue := range issues {
+		result := youtrack.EvaluateSLA(issue, comments[issue.ID], policy, now)
+		if result.Breached() {
+			report.Breached++
+		} else if slaBreachedOnly {
+			continue
+		}
+		report.Issues = append(report.Issues, result)
+	}
+
+	return outputResult(report, func(data interface{}) error {
+		return formatSLAReport(data.(*SLAReport))
+	})
+}
+
+// slaPolicy returns the SLA policy from the config
+func slaPolicy(cfg *config.Config) youtrack.SLAPolicy {
+	policy := youtrack.SLAPolicy{
+		PriorityField: cfg.SLA.PriorityField,
+		Default:       slaTarget(cfg.SLA.SLATarget),
+		Priorities:    make(map[string]youtrack.SLATarget, len(cfg.SLA.Priorities)),
+	}
+	for name, target := range cfg.SLA.Priorities {
+		policy.Priorities[name] = slaTarget(target)
+	}
+	return policy
+}
+
+// slaTarget converts the configured hours to an SLA target
+func slaTarget(target config.SLATarget) youtrack.SLATarget {
+	return youtrack.SLATarget{
+		FirstResponse: time.Duration(target.FirstResponseHours * float64(time.Hour)),
+		Resolution:    time.Duration(target.ResolutionHours * float64(time.Hour)),
+	}
+}
+
+// fetchIssuesComments fetches the comments of the issues concurrently, with at most
+// workers fetches in flight, by issue ID
+func fetchIssuesComments(client *youtrack.Client, ctx *youtrack.YouTrackContext, issues []*youtrack.Issue, workers int) (map[string][]*youtrack.IssueComment, error) {
+	if workers < 1 {
+		workers = 1
+	}
+
+	result := make(map[string][]*youtrack.IssueComment, len(issues))
+	var (
+		mu       sync.Mutex
+		wg       sync.WaitGroup
+		firstErr error
+	)
+	sem := make(chan struct{}, workers)
+
+	for _, issue := range issues {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(issueID string) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			comments, err := client.GetIssueComments(ctx, issueID)
+
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				log.Error("Failed to fetch comments", "ticketID", issueID, "error", err)
+				if firstErr == nil {
+					firstErr = fmt.Errorf("failed to fetch comments of %s: %w", issueID, err)
+				}
+				return
+			}
+			result[issueID] = comments
+		}(issue.ID)
+	}
+	wg.Wait()
+
+	if firstErr != nil {
+		return nil, firstErr
+	}
+	return result, nil
+}
+
+// formatSLAReport prints the SLA outcome of every issue, breaches highlighted
+func formatSLAReport(report *SLAReport) error {
+	if len(report.Issues) == 0 {
+		fmt.Printf("No SLA breaches among %d issue(s) of %s.\n", report.Checked, report.Project)
+		return nil
+	}
+
+	th := theme.Current()
+	t := th.Table("ISSUE", "SUMMARY", "PRIORITY", "CREATED", "FIRST RESPONSE", "RESOLUTION").
+		Flex(1).
+		Style(func(row, col int) lipgloss.Style {
+			if report.Issues[row].Breached() {
+				return th.Error
+			}
+			return th.Cell
+		})
+
+	for _, result := range report.Issues {
+		priority := result.Priority
+		if priority == "" {
+			priority = "-"
+		}
+		t.Row(result.IssueID, result.Summary, priority, result.Created.Format("2006-01-02"),
+			formatSLACheck(result.FirstResponse), formatSLACheck(result.Resolution))
+	}
+
+	fmt.Println(t)
+	fmt.Printf("%d of %d issue(s) breach the SLA\n", report.Breached, report.Checked)
+	return nil
+}
+
+// formatSLACheck formats an SLA outcome as the status with the elapsed and target times
+func formatSLACheck(check youtrack.SLACheck) string {
+	if check.Status == youtrack.SLANoTarget {
+		return "-"
+	}
+	return fmt.Sprintf("%s %s / %s", check.Status, formatDuration(check.ElapsedMinutes), formatDuration(check.TargetMinutes))
+}
@@ -0,0 +1,219 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/log"
+	"github.com/spf13/cobra"
+
+	"github.com/mkozhukh/youtrack/internal/yt/cache"
+	"github.com/mkozhukh/youtrack/internal/yt/config"
+	"github.com/mkozhukh/youtrack/internal/yt/projects"
+	"github.com/mkozhukh/youtrack/internal/yt/theme"
+	"github.com/mkozhukh/youtrack/pkg/youtrack"
+)
+
+// StaleIssue is an issue not updated for a while, with the outcome of the nudge
+type StaleIssue struct {
+	ID           string    `json:"id"`
+	Summary      string    `json:"summary"`
+	Assignee     string    `json:"assignee,omitempty"`
+	Updated      time.Time `json:"updated"`
+	DaysIdle     int       `json:"daysIdle"`
+	Commented    bool      `json:"commented,omitempty"`
+	Tagged       bool      `json:"tagged,omitempty"`
+	CommentError string    `json:"commentError,omitempty"`
+	TagError     string    `json:"tagError,omitempty"`
+}
+
+// StaleReport lists the stale issues of a project
+type StaleReport struct {
+	Project string        `json:"project"`
+	Days    int           `json:"days"`
+	Comment string        `json:"comment,omitempty"`
+	Tag     string        `json:"tag,omitempty"`
+	Issues  []*StaleIssue `json:"issues"`
+}
+
+// IssueIDs returns the IDs of the stale issues, for --output ids
+func (r *StaleReport) IssueIDs() []string {
+	ids := make([]string, len(r.Issues))
+	for i, issue := range r.Issues {
+		ids[i] = issue.ID
+	}
+	return ids
+}
+
+func reportStaleIssues(cmd *cobra.Command, args []string) error {
+	if staleDays < 1 {
+		return fmt.Errorf("--days must be at least 1")
+	}
+
+	// Load configuration
+	cfg, err := config.Load(cfgFile, cmd.Flags())
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+
+	// Validate configuration
+	if err := cfg.Validate(); err != nil {
+		return fmt.Errorf("invalid configuration: %w", err)
+	}
+
+	// Determine project ID to use
+	projectID := reportProject
+	if projectID == "" {
+		projectID = cfg.Defaults.Project
+	}
+	if projectID == "" {
+		return fmt.Errorf("project ID is required (use --project flag or set default project in config)")
+	}
+
+	// Create client and context
+	client := cfg.NewClient()
+	ctx := youtrack.NewYouTrackContext(context.Background(), cfg.Server.Token)
+
+	// Resolve the project by short name or name
+	project, err := projects.Resolve(cache.Open(cfg), client, ctx, projectID)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	cutoff := now.AddDate(0, 0, -staleDays).Format("2006-01-02")
+	searchQuery := strings.TrimSpace(fmt.Sprintf("project: %s #Unresolved updated: * .. %s %s sort by: updated asc", project.ShortName, cutoff, staleQuery))
+	log.Info("Searching stale issues", "query", searchQuery)
+
+	report := &StaleReport{Project: project.ShortName, Days: staleDays, Comment: staleComment, Tag: staleTag}
+	err = client.ForEachIssue(ctx, searchQuery, youtrack.DefaultPageSize, func(issue *youtrack.Issue) error {
+		stale := &StaleIssue{
+			ID:       issue.ID,
+			Summary:  issue.Summary,
+			Updated:  issue.Updated.Time,
+			DaysIdle: int(now.Sub(issue.Updated.Time).Hours() / 24),
+		}
+		if issue.Assignee != nil {
+			stale.Assignee = issue.Assignee.Login
+		}
+		report.Issues = append(report.Issues, stale)
+		return nil
+	})
+	if err != nil {
+		log.Error("Failed to search issues", "error", err)
+		return fmt.Errorf("failed to search issues: %w", err)
+	}
+
+	if len(report.Issues) > 0 && (staleComment != "" || staleTag != "") {
+		nudgeStaleIssues(client, ctx, report)
+	}
+
+	// Output results
+	if err := outputResult(report, func(data interface{}) error {
+		return formatStaleReport(data.(*StaleReport))
+	}); err != nil {
+		return err
+	}
+
+	for _, issue := range report.Issues {
+		if issue.CommentError != "" || issue.TagError != "" {
+			return fmt.Errorf("some stale issues could not be updated")
+		}
+	}
+	return nil
+}
+
+// nudgeStaleIssues comments on and/or tags every stale issue, recording the outcome per issue
+func nudgeStaleIssues(client *youtrack.Client, ctx *youtrack.YouTrackContext, report *StaleReport) {
+	tagID := ""
+	var tagErr error
+	if report.Tag != "" {
+		tagID, tagErr = client.EnsureTag(ctx, report.Tag, "")
+		if tagErr != nil {
+			log.Error("Failed to get or create tag", "tag", report.Tag, "error", tagErr)
+		}
+	}
+
+	for _, issue := range report.Issues {
+		if report.Comment != "" {
+			if _, err := client.AddIssueComment(ctx, issue.ID, report.Comment); err != nil {
+				log.Error("Failed to comment on stale issue", "ticketID", issue.ID, "error", err)
+				issue.CommentError = err.Error()
+			} else {
+				issue.Commented = true
+			}
+		}
+
+		if report.Tag != "" {
+			switch {
+			case tagErr != nil:
+				issue.TagError = tagErr.Error()
+			default:
+				if err := client.AddIssueTag(ctx, issue.ID, tagID); err != nil {
+					log.Error("Failed to tag stale issue", "ticketID", issue.ID, "error", err)
+					issue.TagError = err.Error()
+				} else {
+					issue.Tagged = true
+				}
+			}
+		}
+	}
+}
+
+// formatStaleReport prints the stale issues, with the nudge outcome when one was requested
+func formatStaleReport(report *StaleReport) error {
+	if len(report.Issues) == 0 {
+		fmt.Printf("No unresolved issues in %s without updates for %d days.\n", report.Project, report.Days)
+		return nil
+	}
+
+	headers := []string{"ISSUE", "SUMMARY", "ASSIGNEE", "UPDATED", "IDLE"}
+	if report.Comment != "" {
+		headers = append(headers, "COMMENT")
+	}
+	if report.Tag != "" {
+		headers = append(headers, "TAG")
+	}
+
+	t := theme.Current().Table(headers...).Flex(1)
+
+	failed := 0
+	for _, issue := range report.Issues {
+		assignee := issue.Assignee
+		if assignee == "" {
+			assignee = "-"
+		}
+
+		row := []string{issue.ID, issue.Summary, assignee, issue.Updated.Format("2006-01-02"), fmt.Sprintf("%dd", issue.DaysIdle)}
+		if report.Comment != "" {
+			row = append(row, nudgeOutcome(issue.Commented, issue.CommentError))
+		}
+		if report.Tag != "" {
+			row = append(row, nudgeOutcome(issue.Tagged, issue.TagError))
+		}
+		if issue.CommentError != "" || issue.TagError != "" {
+			failed++
+		}
+		t.Row(row...)
+	}
+
+	fmt.Println(t)
+	fmt.Printf("%d stale issue(s) in %s (no updates for %d days)\n", len(report.Issues), report.Project, report.Days)
+	if failed > 0 {
+		fmt.Printf("%d issue(s) could not be updated, see the errors above\n", failed)
+	}
+	return nil
+}
+
+// nudgeOutcome formats the outcome of a comment or tag
+func nudgeOutcome(done bool, errText string) string {
+	if done {
+		return "ok"
+	}
+	if errText != "" {
+		return "failed: " + errText
+	}
+	return "-"
+}
@@ -0,0 +1,175 @@
+package commands
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/charmbracelet/log"
+
+	"github.com/mkozhukh/youtrack/pkg/youtrack"
+)
+
+// issueTypeChunk is the number of issues whose type is looked up in one search
+const issueTypeChunk = 50
+
+// TeamTimeReport is the time logged in a project per user and issue type
+type TeamTimeReport struct {
+	Project      string          `json:"project"`
+	Since        string          `json:"since"`
+	Until        string          `json:"until"`
+	Rows         []*TeamTimeRow  `json:"rows"`
+	Users        []*TeamTimeUser `json:"users"`
+	TotalMinutes int             `json:"totalMinutes"`
+}
+
+// TeamTimeRow is the time a user logged on issues of one type
+type TeamTimeRow struct {
+	Login     string `json:"login"`
+	Name      string `json:"name"`
+	Email     string `json:"email,omitempty"`
+	IssueType string `json:"issueType"`
+	Minutes   int    `json:"minutes"`
+}
+
+// TeamTimeUser is the total time a user logged
+type TeamTimeUser struct {
+	Login   string `json:"login"`
+	Name    string `json:"name"`
+	Minutes int    `json:"minutes"`
+}
+
+// fetchTeamWorklogs fetches the work items of all users concurrently, with at most
+// workers fetches in flight. Each user's work items are fetched page by page.
+func fetchTeamWorklogs(client *youtrack.Client, ctx *youtrack.YouTrackContext, users []*youtrack.User, projectID, since, until string, workers int) (map[string][]*youtrack.WorkItem, error) {
+	if workers < 1 {
+		workers = 1
+	}
+
+	result := make(map[string][]*youtrack.WorkItem, len(users))
+	var (
+		mu       sync.Mutex
+		wg       sync.WaitGroup
+		firstErr error
+	)
+	sem := make(chan struct{}, workers)
+
+	for _, user := range users {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(user *youtrack.User) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			items, err := fetchAllUserWorklogs(client, ctx, user.ID, projectID, since, until)
+
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				log.Error("Failed to fetch user worklogs", "user", user.Login, "error", err)
+				if firstErr == nil {
+					firstErr = fmt.Errorf("failed to fetch worklogs of %s: %w", user.Login, err)
+				}
+				return
+			}
+			result[user.ID] = items
+		}(user)
+	}
+	wg.Wait()
+
+	if firstErr != nil {
+		return nil, firstErr
+	}
+	return result, nil
+}
+
+// fetchIssueTypes looks up the value of the type field of the issues, by issue ID
+func fetchIssueTypes(client *youtrack.Client, ctx *youtrack.YouTrackContext, issueIDs []string, typeField string) (map[string]string, error) {
+	types := make(map[string]string, len(issueIDs))
+	for start := 0; start < len(issueIDs); start += issueTypeChunk {
+		end := min(start+issueTypeChunk, len(issueIDs))
+		query := "issue id: " + strings.Join(issueIDs[start:end], ", ")
+
+		issues, err := client.SearchIssues(ctx, query, 0, end-start)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get issue types: %w", err)
+		}
+		for _, issue := range issues {
+			types[issue.ID] = issue.FieldValue(typeField)
+		}
+	}
+	return types, nil
+}
+
+// buildTeamTimeReport aggregates the users' work items per user and issue type
+func buildTeamTimeReport(users []*youtrack.User, workItems map[string][]*youtrack.WorkItem, issueTypes map[string]string) *TeamTimeReport {
+	report := &TeamTimeReport{}
+
+	for _, user := range users {
+		byType := make(map[string]int)
+		total := 0
+		for _, item := range workItems[user.ID] {
+			issueType := ""
+			if item.Issue != nil {
+				issueType = issueTypes[item.Issue.ID]
+			}
+			if issueType == "" {
+				issueType = "(none)"
+			}
+			byType[issueType] += item.Duration.Minutes
+			total += item.Duration.Minutes
+		}
+		if total == 0 {
+			continue
+		}
+
+		typeNames := make([]string, 0, len(byType))
+		for name := range byType {
+			typeNames = append(typeNames, name)
+		}
+		sort.Strings(typeNames)
+		for _, name := range typeNames {
+			report.Rows = append(report.Rows, &TeamTimeRow{
+				Login:     user.Login,
+				Name:      user.FullName,
+				Email:     user.Email,
+				IssueType: name,
+				Minutes:   byType[name],
+			})
+		}
+
+		report.Users = append(report.Users, &TeamTimeUser{Login: user.Login, Name: user.FullName, Minutes: total})
+		report.TotalMinutes += total
+	}
+
+	sort.SliceStable(report.Users, func(i, j int) bool {
+		return report.Users[i].Minutes > report.Users[j].Minutes
+	})
+	return report
+}
+
+// writeTeamTimeCSV writes one row per user and issue type, with the time in minutes
+// and in decimal hours as payroll and invoicing systems expect
+func writeTeamTimeCSV(w io.Writer, report *TeamTimeReport) error {
+	cw := csv.NewWriter(w)
+	cw.Write([]string{"project", "since", "until", "login", "name", "email", "issue_type", "minutes", "hours"})
+	for _, row := range report.Rows {
+		cw.Write([]string{
+			report.Project,
+			report.Since,
+			report.Until,
+			row.Login,
+			row.Name,
+			row.Email,
+			row.IssueType,
+			strconv.Itoa(row.Minutes),
+			strconv.FormatFloat(float64(row.Minutes)/60, 'f', 2, 64),
+		})
+	}
+	cw.Flush()
+	return cw.Error()
+}
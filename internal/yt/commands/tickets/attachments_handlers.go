@@ -4,11 +4,13 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"path/filepath"
 
 	"github.com/charmbracelet/log"
 	"github.com/spf13/cobra"
 
 	"github.com/mkozhukh/youtrack/internal/yt/config"
+	"github.com/mkozhukh/youtrack/internal/yt/projects"
 	"github.com/mkozhukh/youtrack/pkg/youtrack"
 )
 
@@ -16,11 +18,6 @@ import (
 func listAttachments(cmd *cobra.Command, args []string) error {
 	ticketID := args[0]
 
-	// Validate ticket ID format
-	if !isValidTicketID(ticketID) {
-		return fmt.Errorf("invalid ticket ID format: %s (expected format: PRJ-123)", ticketID)
-	}
-
 	// Load configuration
 	cfg, err := config.Load("", cmd.Flags())
 	if err != nil {
@@ -32,8 +29,14 @@ func listAttachments(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("invalid configuration: %w", err)
 	}
 
+	// Normalize the ticket ID, a bare number gets the default project
+	ticketID, err = projects.IssueID(cfg, ticketID)
+	if err != nil {
+		return err
+	}
+
 	// Create client and context
-	client := youtrack.NewClient(cfg.Server.URL)
+	client := cfg.NewClient()
 	ctx := youtrack.NewYouTrackContext(context.Background(), cfg.Server.Token)
 
 	log.Info("Fetching attachments for ticket", "ticketID", ticketID)
@@ -57,17 +60,19 @@ func addAttachment(cmd *cobra.Command, args []string) error {
 	ticketID := args[0]
 	filePath := args[1]
 
-	// Validate ticket ID format
-	if !isValidTicketID(ticketID) {
-		return fmt.Errorf("invalid ticket ID format: %s (expected format: PRJ-123)", ticketID)
-	}
-
-	// Validate file existence
-	if _, err := os.Stat(filePath); os.IsNotExist(err) {
+	// Open the file; it is streamed to the server, so large files are fine
+	file, err := os.Open(filePath)
+	if os.IsNotExist(err) {
 		return fmt.Errorf("file does not exist: %s", filePath)
 	} else if err != nil {
 		return fmt.Errorf("failed to access file: %w", err)
 	}
+	defer file.Close()
+
+	fileInfo, err := file.Stat()
+	if err != nil {
+		return fmt.Errorf("failed to access file: %w", err)
+	}
 
 	// Load configuration
 	cfg, err := config.Load("", cmd.Flags())
@@ -80,14 +85,20 @@ func addAttachment(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("invalid configuration: %w", err)
 	}
 
+	// Normalize the ticket ID, a bare number gets the default project
+	ticketID, err = projects.IssueID(cfg, ticketID)
+	if err != nil {
+		return err
+	}
+
 	// Create client and context
-	client := youtrack.NewClient(cfg.Server.URL)
+	client := cfg.NewClient()
 	ctx := youtrack.NewYouTrackContext(context.Background(), cfg.Server.Token)
 
 	log.Info("Adding attachment to ticket", "ticketID", ticketID, "filePath", filePath)
 
 	// Add the attachment
-	attachment, err := client.AddIssueAttachment(ctx, ticketID, filePath)
+	attachment, err := client.AddIssueAttachmentFromReader(ctx, ticketID, file, fileInfo.Size(), filepath.Base(filePath), uploadProgress(fileInfo.Size()))
 	if err != nil {
 		if apiErr, ok := err.(*youtrack.APIError); ok && apiErr.StatusCode == 404 {
 			return fmt.Errorf("ticket not found: %s", ticketID)
@@ -99,3 +110,90 @@ func addAttachment(cmd *cobra.Command, args []string) error {
 	// Output results
 	return outputResult(cmd, attachment, formatAttachmentAdded)
 }
+
+// uploadProgress returns a callback printing the upload percentage to stderr,
+// or nil for small files and when stderr is not a terminal
+func uploadProgress(size int64) youtrack.UploadProgress {
+	const minSize = 5 * 1024 * 1024
+	if size < minSize {
+		return nil
+	}
+	if info, err := os.Stderr.Stat(); err != nil || info.Mode()&os.ModeCharDevice == 0 {
+		return nil
+	}
+
+	lastPercent := int64(-1)
+	return func(sent, total int64) {
+		percent := sent * 100 / total
+		if percent == lastPercent {
+			return
+		}
+		lastPercent = percent
+		fmt.Fprintf(os.Stderr, "\rUploading... %d%%", percent)
+		if sent == total {
+			fmt.Fprintln(os.Stderr)
+		}
+	}
+}
+
+// deleteAttachment handles the delete attachment command
+func deleteAttachment(cmd *cobra.Command, args []string) error {
+	ticketID := args[0]
+	attachmentID := args[1]
+
+	// Load configuration
+	cfg, err := config.Load("", cmd.Flags())
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+
+	// Validate configuration
+	if err := cfg.Validate(); err != nil {
+		return fmt.Errorf("invalid configuration: %w", err)
+	}
+
+	// Normalize the ticket ID, a bare number gets the default project
+	ticketID, err = projects.IssueID(cfg, ticketID)
+	if err != nil {
+		return err
+	}
+
+	// Create client and context
+	client := cfg.NewClient()
+	ctx := youtrack.NewYouTrackContext(context.Background(), cfg.Server.Token)
+
+	// Look up the attachment first, so a wrong ID is reported clearly
+	attachments, err := client.GetIssueAttachments(ctx, ticketID)
+	if err != nil {
+		if apiErr, ok := err.(*youtrack.APIError); ok && apiErr.StatusCode == 404 {
+			return fmt.Errorf("ticket not found: %s", ticketID)
+		}
+		return fmt.Errorf("failed to fetch attachments: %w", err)
+	}
+	var attachment *youtrack.Attachment
+	for _, a := range attachments {
+		if a.ID == attachmentID {
+			attachment = a
+			break
+		}
+	}
+	if attachment == nil {
+		return fmt.Errorf("attachment %s not found on ticket %s", attachmentID, ticketID)
+	}
+
+	log.Info("Deleting attachment", "ticketID", ticketID, "attachmentID", attachmentID, "name", attachment.Name)
+
+	if err := client.DeleteIssueAttachment(ctx, ticketID, attachmentID); err != nil {
+		log.Error("Failed to delete attachment", "error", err)
+		return fmt.Errorf("failed to delete attachment: %w", err)
+	}
+
+	summary := &AttachmentDeleteSummary{
+		TicketID:     ticketID,
+		AttachmentID: attachmentID,
+		Name:         attachment.Name,
+	}
+
+	// Output results
+	return outputResult(cmd, summary, formatAttachmentDeleted)
+}
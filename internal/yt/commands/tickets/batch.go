@@ -0,0 +1,214 @@
+package tickets
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/charmbracelet/log"
+	"github.com/spf13/cobra"
+
+	"github.com/mkozhukh/youtrack/internal/yt/config"
+	"github.com/mkozhukh/youtrack/internal/yt/projects"
+	"github.com/mkozhukh/youtrack/pkg/youtrack"
+)
+
+// stdinTicketArg is the ticket ID argument that reads the ticket IDs from stdin
+const stdinTicketArg = "-"
+
+// defaultBatchConcurrency is the number of tickets processed at once in batch mode
+const defaultBatchConcurrency = 4
+
+// batchConcurrency is the --concurrency flag of the commands accepting - as the ticket ID
+var batchConcurrency int
+
+// BatchSummary contains the results of an operation applied to tickets read from stdin
+type BatchSummary struct {
+	Operation string        `json:"operation"`
+	Succeeded int           `json:"succeeded"`
+	Failed    int           `json:"failed"`
+	Results   []BatchResult `json:"results"`
+}
+
+// BatchResult is the result of the operation on one ticket
+type BatchResult struct {
+	TicketID string `json:"ticketId"`
+	Success  bool   `json:"success"`
+	Error    string `json:"error,omitempty"`
+}
+
+// IssueIDs returns the tickets the operation succeeded on, so batches can be chained
+func (s *BatchSummary) IssueIDs() []string {
+	var list []string
+	for _, result := range s.Results {
+		if result.Success {
+			list = append(list, result.TicketID)
+		}
+	}
+	return list
+}
+
+// batchOperation applies an operation to one ticket of a batch
+type batchOperation func(client *youtrack.Client, ctx *youtrack.YouTrackContext, ticketID string) error
+
+// addBatchFlags adds the --concurrency flag to commands accepting - as the ticket ID
+func addBatchFlags(cmds ...*cobra.Command) {
+	for _, cmd := range cmds {
+		cmd.Flags().IntVar(&batchConcurrency, "concurrency", defaultBatchConcurrency, "Number of tickets processed at once when reading ticket IDs from stdin (-)")
+	}
+}
+
+// readTicketIDs reads ticket IDs one per line. Blank lines are skipped and only the first
+// word of a line is used, so lines of a listing can be piped as well.
+func readTicketIDs(r io.Reader) ([]string, error) {
+	var list []string
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 {
+			continue
+		}
+		list = append(list, fields[0])
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read ticket IDs from stdin: %w", err)
+	}
+	return list, nil
+}
+
+// runBatch applies an operation to each ticket ID read from stdin with the batch executor,
+// a few tickets at a time, and outputs a summary. It fails when the operation failed for any ticket.
+func runBatch(cmd *cobra.Command, cfg *config.Config, operation string, apply batchOperation) error {
+	ticketIDs, err := readTicketIDs(os.Stdin)
+	if err != nil {
+		return err
+	}
+	if len(ticketIDs) == 0 {
+		return fmt.Errorf("no ticket IDs on stdin")
+	}
+
+	// Create client and context
+	client := cfg.NewClient()
+	ctx := youtrack.NewYouTrackContext(context.Background(), cfg.Server.Token)
+
+	concurrency := batchConcurrency
+	if concurrency < 1 {
+		concurrency = 1
+	}
+
+	log.Info("Processing tickets from stdin", "operation", operation, "count", len(ticketIDs), "concurrency", concurrency)
+
+	// Results keep the input order, positions maps the operations to them
+	summary := &BatchSummary{Operation: operation, Results: make([]BatchResult, len(ticketIDs))}
+	var ops []youtrack.BatchOperation
+	var positions []int
+	for i, id := range ticketIDs {
+		// Normalize the ticket ID, a bare number gets the default project
+		ticketID, err := projects.IssueID(cfg, id)
+		if err != nil {
+			summary.Results[i] = BatchResult{TicketID: id, Error: err.Error()}
+			continue
+		}
+
+		ops = append(ops, youtrack.BatchOperation{
+			Type:    youtrack.BatchOperationType(operation),
+			IssueID: ticketID,
+			Apply: func(ctx *youtrack.YouTrackContext) error {
+				return apply(client, ctx, ticketID)
+			},
+		})
+		positions = append(positions, i)
+	}
+
+	// Transient failures are retried by the executor
+	report := youtrack.NewBatchExecutor(client, youtrack.BatchOptions{Concurrency: concurrency}).Execute(ctx, ops)
+	for j, result := range report.Results {
+		summary.Results[positions[j]] = BatchResult{TicketID: result.IssueID, Success: result.Success, Error: result.Error}
+	}
+
+	return outputBatchSummary(cmd, summary)
+}
+
+// outputBatchSummary counts and outputs the results of a batch. It fails when the operation
+// failed for any ticket.
+func outputBatchSummary(cmd *cobra.Command, summary *BatchSummary) error {
+	for _, result := range summary.Results {
+		if result.Success {
+			summary.Succeeded++
+		} else {
+			summary.Failed++
+		}
+	}
+
+	if err := outputResult(cmd, summary, formatBatchSummary); err != nil {
+		return err
+	}
+	if summary.Failed > 0 {
+		// The summary already lists the failures
+		cmd.SilenceUsage = true
+		return fmt.Errorf("%s failed for %d of %d tickets", summary.Operation, summary.Failed, len(summary.Results))
+	}
+	return nil
+}
+
+// tagResolver returns the ID of a tag by name
+type tagResolver func(client *youtrack.Client, ctx *youtrack.YouTrackContext, name string) (string, error)
+
+// ensureTagID returns the ID of a tag, creating the tag when missing
+func ensureTagID(client *youtrack.Client, ctx *youtrack.YouTrackContext, name string) (string, error) {
+	return client.EnsureTag(ctx, name, "")
+}
+
+// findTagID returns the ID of an existing tag
+func findTagID(client *youtrack.Client, ctx *youtrack.YouTrackContext, name string) (string, error) {
+	tag, err := client.GetTagByName(ctx, name)
+	if err != nil {
+		return "", err
+	}
+	return tag.ID, nil
+}
+
+// tagIDCache resolves tag names to IDs once, the tickets of a batch share it
+type tagIDCache struct {
+	mu      sync.Mutex
+	ids     map[string]string
+	resolve tagResolver
+}
+
+func newTagIDCache(resolve tagResolver) *tagIDCache {
+	return &tagIDCache{ids: make(map[string]string), resolve: resolve}
+}
+
+// ID returns the ID of a tag. Resolution is serialized, so a missing tag is created only once.
+func (c *tagIDCache) ID(client *youtrack.Client, ctx *youtrack.YouTrackContext, name string) (string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if id, ok := c.ids[name]; ok {
+		return id, nil
+	}
+	id, err := c.resolve(client, ctx, name)
+	if err != nil {
+		return "", err
+	}
+	c.ids[name] = id
+	return id, nil
+}
+
+// tagOperationError returns an error naming the tags that failed, nil if none did
+func tagOperationError(summary *TagOperationSummary) error {
+	if !summary.HasErrors {
+		return nil
+	}
+	var failed []string
+	for _, result := range summary.Results {
+		if !result.Success {
+			failed = append(failed, fmt.Sprintf("%s: %s", result.TagName, result.Error))
+		}
+	}
+	return fmt.Errorf("tags failed: %s", strings.Join(failed, "; "))
+}
@@ -1,6 +1,8 @@
 package tickets
 
 import (
+	"time"
+
 	"github.com/spf13/cobra"
 )
 
@@ -11,11 +13,19 @@ var (
 	query     string
 	limit     int
 
+	// Show command flags
+	showWithCommits  bool
+	showWithComments bool
+	showRaw          bool
+
 	// Create command flags
 	createTitle       string
 	createDescription string
 	createAssignee    string
 	createFields      []string
+	createVisibility  string
+	createTags        []string
+	createNoDefaults  bool
 
 	// Update command flags
 	updateStatus   string
@@ -24,14 +34,41 @@ var (
 
 	// Comment command flags
 	commentMessage string
+	commentYes     bool
+	commentReplyTo string
+
+	// Comment broadcast command flags
+	broadcastQuery    string
+	broadcastLimit    int
+	broadcastInterval time.Duration
+	broadcastYes      bool
 
 	// Worklog command flags
 	worklogDuration    string
 	worklogDescription string
+	worklogAuthor      string
+	worklogDate        string
+	worklogSpread      string
 
 	// Link command flags
 	linkType string
 
+	// Link PR command flags
+	linkPRState   string
+	linkPRNoState bool
+
+	// History command flags
+	historyField string
+
+	// Export activities command flags
+	exportSince string
+	exportOut   string
+
+	// Similar command flags
+	similarText       string
+	similarUnresolved bool
+	similarLimit      int
+
 	// Global output flag from parent
 	output string
 )
@@ -56,58 +93,80 @@ var listTicketsCmd = &cobra.Command{
 var showTicketCmd = &cobra.Command{
 	Use:   "show <ticket_id>",
 	Short: "Shows detailed information for a specific ticket",
-	Long:  `Shows detailed information for a specific ticket including description, assignee, tags, etc.`,
-	Args:  cobra.ExactArgs(1),
-	RunE:  showTicket,
+	Long: `Shows detailed information for a specific ticket including description, assignee, tags, etc.
+Descriptions in HTML or in the legacy YouTrack wiki markup are converted to Markdown; use --raw
+to show them as stored.`,
+	Args: cobra.ExactArgs(1),
+	RunE: showTicket,
+}
+
+// existsTicketCmd represents the exists command
+var existsTicketCmd = &cobra.Command{
+	Use:   "exists <ticket_id>",
+	Short: "Checks that a ticket exists, through the exit status",
+	Long: `Exits with status 0 when the ticket exists and is visible to you, and 2 otherwise, without
+printing anything (use -v to log the outcome). A malformed ticket ID does not exist either.
+Other failures, such as an unreachable server or an invalid token, are reported with exit
+status 1. Meant for shell conditionals and CI checks:
+
+  branch=$(git rev-parse --abbrev-ref HEAD)
+  yt tickets exists "$(echo "$branch" | grep -oE '[A-Z]+-[0-9]+')" || { echo "no ticket in $branch"; exit 1; }`,
+	Args: cobra.ExactArgs(1),
+	RunE: existsTicket,
 }
 
 // createTicketCmd represents the create command
 var createTicketCmd = &cobra.Command{
 	Use:   "create",
 	Short: "Creates a new ticket in a project",
-	Long:  `Creates a new ticket with title, description, assignee, and custom fields.`,
-	RunE:  createTicket,
+	Long: `Creates a new ticket with title, description, assignee, tags and custom fields.
+The [project.<PRJ>.defaults] section of the config sets the type, priority, assignee
+and tags of new tickets of a project; values given on the command line take precedence.`,
+	RunE: createTicket,
 }
 
 // updateTicketCmd represents the update command
 var updateTicketCmd = &cobra.Command{
 	Use:   "update <ticket_id>",
 	Short: "Updates fields of a specific ticket",
-	Long:  `Updates status, assignee, and custom fields of a ticket. Only specified fields are updated (partial updates).`,
-	Args:  cobra.ExactArgs(1),
-	RunE:  updateTicket,
+	Long: `Updates status, assignee, and custom fields of a ticket. Only specified fields are updated (partial updates).
+Pass - as the ticket ID to update the tickets read from stdin, one ID per line.`,
+	Args: cobra.ExactArgs(1),
+	RunE: updateTicket,
 }
 
 // tagTicketCmd represents the tag command
 var tagTicketCmd = &cobra.Command{
 	Use:   "tag <ticket_id> <tag_name...>",
 	Short: "Adds one or more tags to a ticket",
-	Long:  `Adds one or more tags to a ticket. If a tag doesn't exist, it will be created automatically.`,
-	Args:  cobra.MinimumNArgs(2),
-	RunE:  tagTicket,
+	Long: `Adds one or more tags to a ticket. If a tag doesn't exist, it will be created automatically.
+Pass - as the ticket ID to tag the tickets read from stdin, one ID per line.`,
+	Args: cobra.MinimumNArgs(2),
+	RunE: tagTicket,
 }
 
 // untagTicketCmd represents the untag command
 var untagTicketCmd = &cobra.Command{
 	Use:   "untag <ticket_id> <tag_name...>",
 	Short: "Removes one or more tags from a ticket",
-	Long:  `Removes one or more tags from a ticket. Non-existent tags are handled gracefully.`,
-	Args:  cobra.MinimumNArgs(2),
-	RunE:  untagTicket,
+	Long: `Removes one or more tags from a ticket. Non-existent tags are handled gracefully.
+Pass - as the ticket ID to untag the tickets read from stdin, one ID per line.`,
+	Args: cobra.MinimumNArgs(2),
+	RunE: untagTicket,
 }
 
 // commentsCmd represents the comments command
 var commentsCmd = &cobra.Command{
 	Use:   "comments",
 	Short: "Manage comments on a ticket",
-	Long:  `List and add comments to tickets.`,
+	Long:  `List and add comments to tickets, and react to comments.`,
 }
 
 // attachmentsCmd represents the attachments command
 var attachmentsCmd = &cobra.Command{
 	Use:   "attachments",
 	Short: "Manage attachments on a ticket",
-	Long:  `List and add attachments to tickets.`,
+	Long:  `List, add and delete attachments of tickets.`,
 }
 
 // worklogsCmd represents the worklogs command
@@ -137,9 +196,46 @@ var listCommentsCmd = &cobra.Command{
 var addCommentCmd = &cobra.Command{
 	Use:   "add <ticket_id>",
 	Short: "Adds a comment to a ticket",
-	Long:  `Adds a new comment to a ticket with the specified message.`,
-	Args:  cobra.ExactArgs(1),
-	RunE:  addComment,
+	Long: `Adds a new comment to a ticket with the specified message.
+Pass - as the ticket ID to comment on the tickets read from stdin, one ID per line.
+
+In a terminal the comment is shown rendered, with the problems found in its Markdown
+(unclosed code blocks or bold markers, broken links, repeated words, wiki markup), and
+posted after confirmation; use --yes to post without the prompt. When not run in a
+terminal the problems are logged as warnings.
+
+Use --reply-to with a comment ID to answer that comment in its thread.`,
+	Args: cobra.ExactArgs(1),
+	RunE: addComment,
+}
+
+// broadcastCommentCmd represents the comments broadcast command
+var broadcastCommentCmd = &cobra.Command{
+	Use:   "broadcast",
+	Short: "Posts the same comment to every ticket matching a query",
+	Long: `Posts the same comment to every ticket matching a YouTrack search query. The matching
+tickets are listed and the comment is posted after confirmation, one ticket at a time with
+--interval between posts. The command refuses queries matching more than --limit tickets.`,
+	Args: cobra.NoArgs,
+	RunE: broadcastComment,
+}
+
+// reactCommentCmd represents the comments react command
+var reactCommentCmd = &cobra.Command{
+	Use:   "react <ticket_id> <comment_id> <reaction>",
+	Short: "Adds a reaction to a comment",
+	Long:  `Adds your reaction to a comment, e.g. 'thumbs-up', 'heart' or 'eyes', and shows the reactions of the comment.`,
+	Args:  cobra.ExactArgs(3),
+	RunE:  reactComment,
+}
+
+// unreactCommentCmd represents the comments unreact command
+var unreactCommentCmd = &cobra.Command{
+	Use:   "unreact <ticket_id> <comment_id> <reaction>",
+	Short: "Removes your reaction from a comment",
+	Long:  `Removes your reaction of the given kind from a comment and shows the remaining reactions.`,
+	Args:  cobra.ExactArgs(3),
+	RunE:  unreactComment,
 }
 
 // listAttachmentsCmd represents the attachments list command
@@ -160,6 +256,15 @@ var addAttachmentCmd = &cobra.Command{
 	RunE:  addAttachment,
 }
 
+// deleteAttachmentCmd represents the attachments delete command
+var deleteAttachmentCmd = &cobra.Command{
+	Use:   "delete <ticket_id> <attachment_id>",
+	Short: "Deletes an attachment from a ticket",
+	Long:  `Deletes an attachment from a ticket. Use 'attachments list' to find the attachment ID.`,
+	Args:  cobra.ExactArgs(2),
+	RunE:  deleteAttachment,
+}
+
 // listWorklogsCmd represents the worklogs list command
 var listWorklogsCmd = &cobra.Command{
 	Use:   "list <ticket_id>",
@@ -173,9 +278,14 @@ var listWorklogsCmd = &cobra.Command{
 var addWorklogCmd = &cobra.Command{
 	Use:   "add <ticket_id>",
 	Short: "Adds a worklog entry to a ticket",
-	Long:  `Adds a new worklog entry to a ticket with the specified duration and optional description.`,
-	Args:  cobra.ExactArgs(1),
-	RunE:  addWorklog,
+	Long: `Adds a new worklog entry to a ticket with the specified duration and optional description.
+
+The work is logged today, on the day of --date, or split evenly across the working days
+(Monday to Friday) of a --spread range, one work item per day.`,
+	Example: `  yt tickets worklogs add PRJ-12 --duration 2h --date 2025-02-10
+  yt tickets worklogs add PRJ-12 --duration 20h --spread 2025-02-10..2025-02-14`,
+	Args: cobra.ExactArgs(1),
+	RunE: addWorklog,
 }
 
 // addLinkCmd represents the links add command
@@ -187,36 +297,117 @@ var addLinkCmd = &cobra.Command{
 	RunE:  addLink,
 }
 
+// LinkPRCmd represents the link-pr command, registered at the top level as `yt link-pr`
+var LinkPRCmd = &cobra.Command{
+	Use:   "link-pr <ticket_id> <pr_url>",
+	Short: "Links a pull request to a ticket",
+	Long: `Posts a comment with a GitHub, GitLab or Bitbucket pull request link on a ticket and
+moves the ticket to the review state from the config (workflow.review_state).`,
+	Args: cobra.ExactArgs(2),
+	RunE: linkPullRequest,
+}
+
+// similarTicketsCmd represents the similar command
+var similarTicketsCmd = &cobra.Command{
+	Use:   "similar [ticket_id]",
+	Short: "Finds tickets similar to a ticket or text",
+	Long: `Searches all projects (or the one given with --project) for tickets whose summary shares keywords
+with the summary of a ticket or with --text, and ranks them by keyword overlap.
+Use it to find likely duplicates before creating a new ticket.`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: findSimilarTickets,
+}
+
+// voteTicketCmd represents the vote command
+var voteTicketCmd = &cobra.Command{
+	Use:   "vote <ticket_id>",
+	Short: "Votes for a ticket",
+	Long: `Adds your vote to a ticket and shows its votes.
+Pass - as the ticket ID to vote for the tickets read from stdin, one ID per line.`,
+	Args: cobra.ExactArgs(1),
+	RunE: voteTicket,
+}
+
+// unvoteTicketCmd represents the unvote command
+var unvoteTicketCmd = &cobra.Command{
+	Use:   "unvote <ticket_id>",
+	Short: "Removes your vote from a ticket",
+	Long: `Removes your vote from a ticket and shows its votes.
+Pass - as the ticket ID to remove your vote from the tickets read from stdin, one ID per line.`,
+	Args: cobra.ExactArgs(1),
+	RunE: unvoteTicket,
+}
+
+// votersCmd represents the voters command
+var votersCmd = &cobra.Command{
+	Use:   "voters <ticket_id>",
+	Short: "Shows the votes of a ticket",
+	Long:  `Shows the vote count of a ticket and the users who voted for it or for one of its duplicates.`,
+	Args:  cobra.ExactArgs(1),
+	RunE:  listVoters,
+}
+
 // historyCmd represents the history command
 var historyCmd = &cobra.Command{
 	Use:   "history <ticket_id>",
 	Short: "Shows the activity stream for a ticket",
-	Long:  `Shows the activity stream for a ticket including field changes, comments, attachments, and other activities in chronological order.`,
-	Args:  cobra.ExactArgs(1),
-	RunE:  showHistory,
+	Long: `Shows the activity stream for a ticket including field changes, comments, attachments, and other activities in chronological order.
+
+Use --field to show only the changes of one field, with the time, the author and the old and new values,
+e.g. to find when the ticket went to In Progress:
+
+  yt tickets history PROJ-123 --field State`,
+	Args: cobra.ExactArgs(1),
+	RunE: showHistory,
+}
+
+// exportActivitiesCmd represents the export-activities command
+var exportActivitiesCmd = &cobra.Command{
+	Use:   "export-activities",
+	Short: "Exports the activities of a project's tickets as JSON lines",
+	Long: `Exports the activities of a project's tickets, oldest first, one JSON object per line
+(JSONL) for data pipelines. The activities are fetched page by page and written as they
+arrive, so exports of any size run in constant memory.
+
+Each line has the same keys: id, issueId, timestamp (RFC 3339, UTC), category, field,
+author, authorName, added and removed (lists of display values, empty when none).
+
+  yt tickets export-activities --project PRJ --since 2024-01-01 --out activities.jsonl`,
+	Args: cobra.NoArgs,
+	RunE: exportActivities,
 }
 
 func init() {
 	// Add subcommands
 	TicketsCmd.AddCommand(listTicketsCmd)
 	TicketsCmd.AddCommand(showTicketCmd)
+	TicketsCmd.AddCommand(existsTicketCmd)
 	TicketsCmd.AddCommand(createTicketCmd)
 	TicketsCmd.AddCommand(updateTicketCmd)
 	TicketsCmd.AddCommand(tagTicketCmd)
 	TicketsCmd.AddCommand(untagTicketCmd)
+	TicketsCmd.AddCommand(similarTicketsCmd)
+	TicketsCmd.AddCommand(voteTicketCmd)
+	TicketsCmd.AddCommand(unvoteTicketCmd)
+	TicketsCmd.AddCommand(votersCmd)
 	TicketsCmd.AddCommand(commentsCmd)
 	TicketsCmd.AddCommand(attachmentsCmd)
 	TicketsCmd.AddCommand(worklogsCmd)
 	TicketsCmd.AddCommand(linksCmd)
 	TicketsCmd.AddCommand(historyCmd)
+	TicketsCmd.AddCommand(exportActivitiesCmd)
 
 	// Add comments subcommands
 	commentsCmd.AddCommand(listCommentsCmd)
 	commentsCmd.AddCommand(addCommentCmd)
+	commentsCmd.AddCommand(broadcastCommentCmd)
+	commentsCmd.AddCommand(reactCommentCmd)
+	commentsCmd.AddCommand(unreactCommentCmd)
 
 	// Add attachments subcommands
 	attachmentsCmd.AddCommand(listAttachmentsCmd)
 	attachmentsCmd.AddCommand(addAttachmentCmd)
+	attachmentsCmd.AddCommand(deleteAttachmentCmd)
 
 	// Add worklogs subcommands
 	worklogsCmd.AddCommand(listWorklogsCmd)
@@ -226,36 +417,92 @@ func init() {
 	linksCmd.AddCommand(addLinkCmd)
 
 	// Add flags to both tickets and tickets list commands for the alias to work
-	TicketsCmd.Flags().StringVarP(&projectID, "project", "p", "", "The project ID (uses default from config if not provided)")
+	TicketsCmd.Flags().StringVarP(&projectID, "project", "p", "", "The project short name or name (uses default from config if not provided)")
 	TicketsCmd.Flags().StringVarP(&userID, "user", "u", "", "Filter tickets by assignee (defaults to current user from config)")
 	TicketsCmd.Flags().StringVarP(&query, "query", "q", "", "Filter tickets with a YouTrack search query")
 	TicketsCmd.Flags().IntVar(&limit, "limit", 20, "Number of tickets to show")
 
-	listTicketsCmd.Flags().StringVarP(&projectID, "project", "p", "", "The project ID (uses default from config if not provided)")
+	listTicketsCmd.Flags().StringVarP(&projectID, "project", "p", "", "The project short name or name (uses default from config if not provided)")
 	listTicketsCmd.Flags().StringVarP(&userID, "user", "u", "", "Filter tickets by assignee (defaults to current user from config)")
 	listTicketsCmd.Flags().StringVarP(&query, "query", "q", "", "Filter tickets with a YouTrack search query")
 	listTicketsCmd.Flags().IntVar(&limit, "limit", 20, "Number of tickets to show")
 
+	// Complete --query values using YouTrack search assist
+	TicketsCmd.RegisterFlagCompletionFunc("query", completeQuery)
+	listTicketsCmd.RegisterFlagCompletionFunc("query", completeQuery)
+
+	// Add flags for show command
+	showTicketCmd.Flags().BoolVar(&showWithCommits, "with-commits", false, "Also list the VCS commits linked to the ticket")
+	showTicketCmd.Flags().BoolVar(&showWithComments, "with-comments", false, "Also list the comments of the ticket, by thread, with their reaction counts")
+	showTicketCmd.Flags().BoolVar(&showRaw, "raw", false, "Show the description as stored, without converting legacy HTML or wiki markup to Markdown")
+
 	// Add flags for create command
-	createTicketCmd.Flags().StringVarP(&projectID, "project", "p", "", "The project ID (uses default from config if not provided)")
+	createTicketCmd.Flags().StringVarP(&projectID, "project", "p", "", "The project short name or name (uses default from config if not provided)")
 	createTicketCmd.Flags().StringVarP(&createTitle, "title", "t", "", "The title of the new ticket (required)")
 	createTicketCmd.Flags().StringVarP(&createDescription, "description", "d", "", "The description for the ticket")
 	createTicketCmd.Flags().StringVar(&createAssignee, "assignee", "", "Assign the ticket to a user")
-	createTicketCmd.Flags().StringSliceVar(&createFields, "field", []string{}, "Set a custom field (key=value format). Can be specified multiple times")
+	createTicketCmd.Flags().StringArrayVar(&createFields, "field", []string{}, "Set a custom field (key=value format). Repeat the flag or separate values with commas for multi-value fields")
+	createTicketCmd.Flags().StringArrayVar(&createTags, "tag", []string{}, "Add a tag to the ticket, replacing the project default tags. Repeat the flag for several tags")
+	createTicketCmd.Flags().BoolVar(&createNoDefaults, "no-defaults", false, "Ignore the project defaults of the config")
+	createTicketCmd.Flags().StringVar(&createVisibility, "visibility", "", "Limit who can see the ticket: comma-separated groups and users (e.g. 'Developers,user:john')")
 	createTicketCmd.MarkFlagRequired("title")
 
 	// Add flags for update command
-	updateTicketCmd.Flags().StringSliceVar(&updateFields, "field", []string{}, "Set a custom field (key=value format). Can be specified multiple times")
+	updateTicketCmd.Flags().StringArrayVar(&updateFields, "field", []string{}, "Set a custom field (key=value format). Repeat the flag or separate values with commas for multi-value fields")
+
+	// Add flags for history command
+	historyCmd.Flags().StringVar(&historyField, "field", "", "Only show the changes of this field (e.g. State)")
+
+	// Add flags for export-activities command
+	exportActivitiesCmd.Flags().StringVarP(&projectID, "project", "p", "", "The project short name or name (uses default from config if not provided)")
+	exportActivitiesCmd.Flags().StringVar(&exportSince, "since", "", "Only export the activities since this date (YYYY-MM-DD)")
+	exportActivitiesCmd.Flags().StringVarP(&query, "query", "q", "", "Only export the activities of the tickets matching this YouTrack search query")
+	exportActivitiesCmd.Flags().StringVar(&exportOut, "out", "", "Write the export to this file instead of stdout")
+	exportActivitiesCmd.RegisterFlagCompletionFunc("query", completeQuery)
+
+	// Add flags for similar command
+	similarTicketsCmd.Flags().StringVarP(&projectID, "project", "p", "", "Only search this project (short name or name); all projects by default")
+	similarTicketsCmd.Flags().StringVarP(&similarText, "text", "t", "", "Search for this text instead of the ticket summary")
+	similarTicketsCmd.Flags().BoolVar(&similarUnresolved, "unresolved", false, "Only show unresolved tickets")
+	similarTicketsCmd.Flags().IntVar(&similarLimit, "limit", 10, "Number of candidates to show")
 
 	// Add flags for comment add command
 	addCommentCmd.Flags().StringVarP(&commentMessage, "message", "m", "", "The comment message (required)")
+	addCommentCmd.Flags().BoolVarP(&commentYes, "yes", "y", false, "Post without showing the preview and asking for confirmation")
+	addCommentCmd.Flags().StringVar(&commentReplyTo, "reply-to", "", "ID of the comment to reply to")
 	addCommentCmd.MarkFlagRequired("message")
 
+	// Add flags for comment broadcast command
+	broadcastCommentCmd.Flags().StringVarP(&broadcastQuery, "query", "q", "", "The YouTrack search query selecting the tickets (required)")
+	broadcastCommentCmd.Flags().StringVarP(&commentMessage, "message", "m", "", "The comment message (required)")
+	broadcastCommentCmd.Flags().IntVar(&broadcastLimit, "limit", 50, "Maximum number of tickets the comment can be posted to")
+	broadcastCommentCmd.Flags().DurationVar(&broadcastInterval, "interval", time.Second, "Time to wait between two posts")
+	broadcastCommentCmd.Flags().BoolVarP(&broadcastYes, "yes", "y", false, "Post without asking for confirmation")
+	broadcastCommentCmd.MarkFlagRequired("query")
+	broadcastCommentCmd.MarkFlagRequired("message")
+	broadcastCommentCmd.RegisterFlagCompletionFunc("query", completeQuery)
+
+	// Commands accepting - as the ticket ID process the tickets read from stdin
+	addBatchFlags(updateTicketCmd, tagTicketCmd, untagTicketCmd, addCommentCmd, voteTicketCmd, unvoteTicketCmd)
+
 	// Add flags for worklog add command
 	addWorklogCmd.Flags().StringVar(&worklogDuration, "duration", "", "The duration of the work (e.g., '1h 30m') (required)")
 	addWorklogCmd.Flags().StringVar(&worklogDescription, "description", "", "An optional description for the worklog entry")
+	addWorklogCmd.Flags().StringVar(&worklogAuthor, "author", "", "Login of the user to log the work for (needs the 'Update Work Item' permission)")
+	addWorklogCmd.Flags().StringVar(&worklogDate, "date", "", "The day of the work, YYYY-MM-DD (defaults to today)")
+	addWorklogCmd.Flags().StringVar(&worklogSpread, "spread", "", "Split the duration evenly across the working days of a range, e.g. 2025-02-10..2025-02-14")
 	addWorklogCmd.MarkFlagRequired("duration")
+	addWorklogCmd.MarkFlagsMutuallyExclusive("date", "spread")
 
 	// Add flags for link add command
 	addLinkCmd.Flags().StringVar(&linkType, "type", "relates to", "The relationship type (e.g., 'relates to', 'is duplicated by')")
+
+	// Add flags for link-pr command
+	LinkPRCmd.Flags().StringVar(&linkPRState, "state", "", "The state to move the ticket to (overrides workflow.review_state from config)")
+	addOfflineFlags(createTicketCmd, updateTicketCmd, addCommentCmd, addWorklogCmd)
+	SyncCmd.Flags().BoolVar(&syncList, "list", false, "List the queued changes without sending them")
+	SyncCmd.Flags().BoolVar(&syncForce, "force", false, "Apply queued updates even when their ticket changed on the server since")
+	SyncCmd.Flags().StringVar(&syncDiscard, "discard", "", "Remove a queued change by ID without sending it")
+
+	LinkPRCmd.Flags().BoolVar(&linkPRNoState, "no-state", false, "Only post the comment, do not change the ticket state")
 }
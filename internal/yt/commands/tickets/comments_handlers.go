@@ -3,11 +3,16 @@ package tickets
 import (
 	"context"
 	"fmt"
+	"os"
+	"time"
 
 	"github.com/charmbracelet/log"
 	"github.com/spf13/cobra"
+	"golang.org/x/term"
 
 	"github.com/mkozhukh/youtrack/internal/yt/config"
+	"github.com/mkozhukh/youtrack/internal/yt/journal"
+	"github.com/mkozhukh/youtrack/internal/yt/projects"
 	"github.com/mkozhukh/youtrack/pkg/youtrack"
 )
 
@@ -15,11 +20,6 @@ import (
 func listComments(cmd *cobra.Command, args []string) error {
 	ticketID := args[0]
 
-	// Validate ticket ID format
-	if !isValidTicketID(ticketID) {
-		return fmt.Errorf("invalid ticket ID format: %s (expected format: PRJ-123)", ticketID)
-	}
-
 	// Load configuration
 	cfg, err := config.Load("", cmd.Flags())
 	if err != nil {
@@ -31,8 +31,14 @@ func listComments(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("invalid configuration: %w", err)
 	}
 
+	// Normalize the ticket ID, a bare number gets the default project
+	ticketID, err = projects.IssueID(cfg, ticketID)
+	if err != nil {
+		return err
+	}
+
 	// Create client and context
-	client := youtrack.NewClient(cfg.Server.URL)
+	client := cfg.NewClient()
 	ctx := youtrack.NewYouTrackContext(context.Background(), cfg.Server.Token)
 
 	log.Info("Fetching comments for ticket", "ticketID", ticketID)
@@ -55,12 +61,117 @@ func listComments(cmd *cobra.Command, args []string) error {
 func addComment(cmd *cobra.Command, args []string) error {
 	ticketID := args[0]
 
-	// Validate ticket ID format
-	if !isValidTicketID(ticketID) {
-		return fmt.Errorf("invalid ticket ID format: %s (expected format: PRJ-123)", ticketID)
+	// Validate required parameters
+	if commentMessage == "" {
+		return fmt.Errorf("comment message is required (use --message flag)")
+	}
+
+	// Load configuration
+	cfg, err := config.Load("", cmd.Flags())
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+
+	// Validate configuration
+	if err := cfg.Validate(); err != nil {
+		return fmt.Errorf("invalid configuration: %w", err)
+	}
+
+	// Scripts get the problems of the Markdown as warnings, terminals with the preview
+	interactive := !commentYes && ticketID != stdinTicketArg && isInteractive()
+	if !interactive {
+		for _, warning := range youtrack.MarkdownWarnings(commentMessage) {
+			log.Warn("Comment markdown", "problem", warning)
+		}
+	}
+
+	if ticketID == stdinTicketArg {
+		if offlineQueue {
+			return fmt.Errorf("--offline applies to a single ticket, not to ticket IDs read from stdin")
+		}
+		if commentReplyTo != "" {
+			return fmt.Errorf("--reply-to applies to a single ticket, not to ticket IDs read from stdin")
+		}
+		mentions := newMentionCache()
+		return runBatch(cmd, cfg, "comment", func(client *youtrack.Client, ctx *youtrack.YouTrackContext, ticketID string) error {
+			text := mentions.Resolve(client, ctx, ticketID, commentMessage)
+			_, err := addTicketComment(client, ctx, ticketID, text, "")
+			return err
+		})
+	}
+
+	// Normalize the ticket ID, a bare number gets the default project
+	ticketID, err = projects.IssueID(cfg, ticketID)
+	if err != nil {
+		return err
 	}
 
+	// Create client and context
+	client := cfg.NewClient()
+	ctx := youtrack.NewYouTrackContext(context.Background(), cfg.Server.Token)
+
+	// Mentions are resolved before the preview; a queued comment is resolved when it is sent
+	text := commentMessage
+	if !offlineQueue {
+		text = newMentionCache().Resolve(client, ctx, ticketID, commentMessage)
+	}
+
+	// Show the rendered comment and ask before posting
+	if interactive {
+		ok, err := confirmComment(ticketID, text)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			fmt.Fprintln(os.Stderr, "Cancelled, no comment posted.")
+			return nil
+		}
+	}
+
+	op := &journal.Operation{Kind: journal.KindComment, IssueID: ticketID, Text: commentMessage, ReplyTo: commentReplyTo}
+	if queued, err := queueOffline(cmd, cfg, op, nil); queued {
+		return err
+	}
+
+	comment, err := addTicketComment(client, ctx, ticketID, text, commentReplyTo)
+	if err != nil {
+		if queued, queueErr := queueOffline(cmd, cfg, op, err); queued {
+			return queueErr
+		}
+		return err
+	}
+
+	// Output results
+	return outputResult(cmd, comment, formatCommentAdded)
+}
+
+// addTicketComment adds a comment to a ticket, a reply to the comment replyTo when set
+func addTicketComment(client *youtrack.Client, ctx *youtrack.YouTrackContext, ticketID, text, replyTo string) (*youtrack.IssueComment, error) {
+	log.Info("Adding comment to ticket", "ticketID", ticketID, "replyTo", replyTo)
+
+	var comment *youtrack.IssueComment
+	var err error
+	if replyTo != "" {
+		comment, err = client.ReplyToIssueComment(ctx, ticketID, replyTo, text)
+	} else {
+		comment, err = client.AddIssueComment(ctx, ticketID, text)
+	}
+	if err != nil {
+		if apiErr, ok := err.(*youtrack.APIError); ok && apiErr.StatusCode == 404 {
+			return nil, fmt.Errorf("ticket not found: %s", ticketID)
+		}
+		log.Error("Failed to add comment", "ticketID", ticketID, "error", err)
+		return nil, fmt.Errorf("failed to add comment: %w", err)
+	}
+	return comment, nil
+}
+
+// broadcastComment handles the comments broadcast command
+func broadcastComment(cmd *cobra.Command, args []string) error {
 	// Validate required parameters
+	if broadcastQuery == "" {
+		return fmt.Errorf("a search query is required (use --query flag)")
+	}
 	if commentMessage == "" {
 		return fmt.Errorf("comment message is required (use --message flag)")
 	}
@@ -77,21 +188,176 @@ func addComment(cmd *cobra.Command, args []string) error {
 	}
 
 	// Create client and context
-	client := youtrack.NewClient(cfg.Server.URL)
+	client := cfg.NewClient()
 	ctx := youtrack.NewYouTrackContext(context.Background(), cfg.Server.Token)
 
-	log.Info("Adding comment to ticket", "ticketID", ticketID)
+	log.Info("Searching tickets to comment on", "query", broadcastQuery, "limit", broadcastLimit)
+
+	// Find the tickets, one more than the limit to detect a query matching too many
+	var tickets []*youtrack.Issue
+	err = client.ForEachIssue(ctx, broadcastQuery, youtrack.DefaultPageSize, func(issue *youtrack.Issue) error {
+		tickets = append(tickets, issue)
+		if len(tickets) > broadcastLimit {
+			return youtrack.ErrStopIteration
+		}
+		return nil
+	})
+	if err != nil {
+		log.Error("Failed to search tickets", "error", err)
+		return fmt.Errorf("failed to search tickets: %w", err)
+	}
+	if len(tickets) == 0 {
+		fmt.Fprintln(os.Stderr, "No tickets match the query, no comment posted.")
+		return nil
+	}
+	if len(tickets) > broadcastLimit {
+		return fmt.Errorf("the query matches more than %d tickets, narrow it down or raise --limit", broadcastLimit)
+	}
+
+	// Show the affected tickets and ask before posting
+	if !broadcastYes {
+		if !term.IsTerminal(int(os.Stdin.Fd())) {
+			return fmt.Errorf("the comment would be posted to %d tickets, use --yes to confirm without a prompt", len(tickets))
+		}
+		fmt.Fprintf(os.Stderr, "The comment will be posted to %d ticket(s):\n", len(tickets))
+		for _, ticket := range tickets {
+			fmt.Fprintf(os.Stderr, "  %s  %s\n", ticket.ID, ticket.Summary)
+		}
+		ok, err := confirm(fmt.Sprintf("Post the comment to %d ticket(s)?", len(tickets)))
+		if err != nil {
+			return err
+		}
+		if !ok {
+			fmt.Fprintln(os.Stderr, "Cancelled, no comment posted.")
+			return nil
+		}
+	}
+
+	// Post the comments one at a time, throttled by the interval
+	mentions := newMentionCache()
+	summary := &BatchSummary{Operation: "comment", Results: make([]BatchResult, len(tickets))}
+	for i, ticket := range tickets {
+		if i > 0 && broadcastInterval > 0 {
+			time.Sleep(broadcastInterval)
+		}
+		result := BatchResult{TicketID: ticket.ID, Success: true}
+		text := mentions.Resolve(client, ctx, ticket.ID, commentMessage)
+		if _, err := addTicketComment(client, ctx, ticket.ID, text, ""); err != nil {
+			result.Success = false
+			result.Error = err.Error()
+		}
+		summary.Results[i] = result
+	}
+
+	return outputBatchSummary(cmd, summary)
+}
+
+// reactComment handles the comments react command
+func reactComment(cmd *cobra.Command, args []string) error {
+	ticketID, commentID, reaction := args[0], args[1], args[2]
 
-	// Add the comment
-	comment, err := client.AddIssueComment(ctx, ticketID, commentMessage)
+	client, ctx, ticketID, err := newCommentsClient(cmd, ticketID)
 	if err != nil {
+		return err
+	}
+
+	log.Info("Adding reaction to comment", "ticketID", ticketID, "commentID", commentID, "reaction", reaction)
+
+	if _, err := client.AddCommentReaction(ctx, ticketID, commentID, reaction); err != nil {
 		if apiErr, ok := err.(*youtrack.APIError); ok && apiErr.StatusCode == 404 {
-			return fmt.Errorf("ticket not found: %s", ticketID)
+			return fmt.Errorf("comment not found: %s on %s", commentID, ticketID)
 		}
-		log.Error("Failed to add comment", "error", err)
-		return fmt.Errorf("failed to add comment: %w", err)
+		log.Error("Failed to add reaction", "error", err)
+		return fmt.Errorf("failed to add reaction: %w", err)
 	}
 
-	// Output results
-	return outputResult(cmd, comment, formatCommentAdded)
+	return showReactions(cmd, client, ctx, ticketID, commentID)
+}
+
+// unreactComment handles the comments unreact command
+func unreactComment(cmd *cobra.Command, args []string) error {
+	ticketID, commentID, reaction := args[0], args[1], args[2]
+
+	client, ctx, ticketID, err := newCommentsClient(cmd, ticketID)
+	if err != nil {
+		return err
+	}
+
+	me, err := client.GetCurrentUser(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get current user: %w", err)
+	}
+
+	reactions, err := client.GetCommentReactions(ctx, ticketID, commentID)
+	if err != nil {
+		if apiErr, ok := err.(*youtrack.APIError); ok && apiErr.StatusCode == 404 {
+			return fmt.Errorf("comment not found: %s on %s", commentID, ticketID)
+		}
+		return fmt.Errorf("failed to fetch reactions: %w", err)
+	}
+
+	// Find the reaction of the current user
+	var mine *youtrack.Reaction
+	for _, r := range reactions {
+		if r.Reaction == reaction && r.Author != nil && r.Author.ID == me.ID {
+			mine = r
+			break
+		}
+	}
+	if mine == nil {
+		return fmt.Errorf("you have no '%s' reaction on comment %s", reaction, commentID)
+	}
+
+	log.Info("Removing reaction from comment", "ticketID", ticketID, "commentID", commentID, "reaction", reaction)
+
+	if err := client.RemoveCommentReaction(ctx, ticketID, commentID, mine.ID); err != nil {
+		log.Error("Failed to remove reaction", "error", err)
+		return fmt.Errorf("failed to remove reaction: %w", err)
+	}
+
+	return showReactions(cmd, client, ctx, ticketID, commentID)
+}
+
+// showReactions fetches and outputs the reactions of a comment
+func showReactions(cmd *cobra.Command, client *youtrack.Client, ctx *youtrack.YouTrackContext, ticketID, commentID string) error {
+	reactions, err := client.GetCommentReactions(ctx, ticketID, commentID)
+	if err != nil {
+		return fmt.Errorf("failed to fetch reactions: %w", err)
+	}
+
+	summary := &ReactionsSummary{
+		TicketID:  ticketID,
+		CommentID: commentID,
+		Counts:    youtrack.CountReactions(reactions),
+		Reactions: reactions,
+	}
+	if summary.Reactions == nil {
+		summary.Reactions = []*youtrack.Reaction{}
+	}
+
+	return outputResult(cmd, summary, formatReactions)
+}
+
+// newCommentsClient loads the configuration, normalizes the ticket ID and creates a client and context
+func newCommentsClient(cmd *cobra.Command, ticketID string) (*youtrack.Client, *youtrack.YouTrackContext, string, error) {
+	// Load configuration
+	cfg, err := config.Load("", cmd.Flags())
+	if err != nil {
+		return nil, nil, "", fmt.Errorf("failed to load configuration: %w", err)
+	}
+
+	// Validate configuration
+	if err := cfg.Validate(); err != nil {
+		return nil, nil, "", fmt.Errorf("invalid configuration: %w", err)
+	}
+
+	// Normalize the ticket ID, a bare number gets the default project
+	ticketID, err = projects.IssueID(cfg, ticketID)
+	if err != nil {
+		return nil, nil, "", err
+	}
+
+	client := cfg.NewClient()
+	ctx := youtrack.NewYouTrackContext(context.Background(), cfg.Server.Token)
+	return client, ctx, ticketID, nil
 }
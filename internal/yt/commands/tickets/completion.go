@@ -0,0 +1,40 @@
+package tickets
+
+import (
+	"context"
+	"fmt"
+	"unicode/utf8"
+
+	"github.com/spf13/cobra"
+
+	"github.com/mkozhukh/youtrack/internal/yt/config"
+	"github.com/mkozhukh/youtrack/pkg/youtrack"
+)
+
+// completeQuery provides dynamic shell completion for --query values using YouTrack search assist
+func completeQuery(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	cfg, err := config.Load("", cmd.Flags())
+	if err != nil || cfg.Validate() != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	client := cfg.NewClient()
+	ctx := youtrack.NewYouTrackContext(context.Background(), cfg.Server.Token)
+
+	assist, err := client.GetSearchSuggestions(ctx, toComplete, utf8.RuneCountInString(toComplete))
+	if err != nil {
+		cobra.CompDebugln(fmt.Sprintf("search assist failed: %v", err), true)
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	completions := make([]string, 0, len(assist.Suggestions))
+	for _, s := range assist.Suggestions {
+		completion := s.Apply(toComplete)
+		if s.Description != "" {
+			completion = fmt.Sprintf("%s\t%s", completion, s.Description)
+		}
+		completions = append(completions, completion)
+	}
+
+	return completions, cobra.ShellCompDirectiveNoFileComp | cobra.ShellCompDirectiveNoSpace
+}
@@ -2,13 +2,18 @@ package tickets
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"net/http"
 	"strings"
 
 	"github.com/charmbracelet/log"
 	"github.com/spf13/cobra"
 
+	"github.com/mkozhukh/youtrack/internal/yt/cache"
 	"github.com/mkozhukh/youtrack/internal/yt/config"
+	"github.com/mkozhukh/youtrack/internal/yt/journal"
+	"github.com/mkozhukh/youtrack/internal/yt/projects"
 	"github.com/mkozhukh/youtrack/pkg/youtrack"
 )
 
@@ -36,16 +41,36 @@ func listTickets(cmd *cobra.Command, args []string) error {
 	}
 
 	// Create client and context
-	client := youtrack.NewClient(cfg.Server.URL)
+	client := cfg.NewClient()
 	ctx := youtrack.NewYouTrackContext(context.Background(), cfg.Server.Token)
 
+	// Resolve the project by short name or name
+	if projectID != "" {
+		project, err := projects.Resolve(cache.Open(cfg), client, ctx, projectID)
+		if err != nil {
+			return err
+		}
+		projectID = project.ShortName
+	}
+
 	// Build the search query
 	searchQuery := buildSearchQuery(projectID, userID, query)
 
 	log.Info("Searching tickets", "query", searchQuery, "limit", limit)
 
-	// Search for tickets
-	tickets, err := client.SearchIssues(ctx, searchQuery, 0, limit)
+	// Search for tickets, paging through results until the limit is reached
+	pageSize := youtrack.DefaultPageSize
+	if limit > 0 && limit < pageSize {
+		pageSize = limit
+	}
+	tickets := make([]*youtrack.Issue, 0, pageSize)
+	err = client.ForEachIssue(ctx, searchQuery, pageSize, func(issue *youtrack.Issue) error {
+		tickets = append(tickets, issue)
+		if limit > 0 && len(tickets) >= limit {
+			return youtrack.ErrStopIteration
+		}
+		return nil
+	})
 	if err != nil {
 		log.Error("Failed to search tickets", "error", err)
 		return fmt.Errorf("failed to search tickets: %w", err)
@@ -59,11 +84,6 @@ func listTickets(cmd *cobra.Command, args []string) error {
 func showTicket(cmd *cobra.Command, args []string) error {
 	ticketID := args[0]
 
-	// Validate ticket ID format
-	if !isValidTicketID(ticketID) {
-		return fmt.Errorf("invalid ticket ID format: %s (expected format: PRJ-123)", ticketID)
-	}
-
 	// Load configuration
 	cfg, err := config.Load("", cmd.Flags())
 	if err != nil {
@@ -75,8 +95,14 @@ func showTicket(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("invalid configuration: %w", err)
 	}
 
+	// Normalize the ticket ID, a bare number gets the default project
+	ticketID, err = projects.IssueID(cfg, ticketID)
+	if err != nil {
+		return err
+	}
+
 	// Create client and context
-	client := youtrack.NewClient(cfg.Server.URL)
+	client := cfg.NewClient()
 	ctx := youtrack.NewYouTrackContext(context.Background(), cfg.Server.Token)
 
 	log.Info("Fetching ticket details", "ticketID", ticketID)
@@ -88,8 +114,87 @@ func showTicket(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to get ticket %s: %w", ticketID, err)
 	}
 
+	// Legacy HTML and wiki descriptions are shown as Markdown
+	if !showRaw {
+		ticket.Description = ticket.MarkdownDescription()
+	}
+
+	if !showWithCommits && !showWithComments {
+		return outputResult(cmd, ticket, formatTicketDetails)
+	}
+
+	details := &TicketDetails{Issue: ticket, withCommits: showWithCommits, withComments: showWithComments}
+	if showWithCommits {
+		details.Commits, err = client.GetIssueVcsChanges(ctx, ticketID)
+		if err != nil {
+			log.Error("Failed to get ticket commits", "ticketID", ticketID, "error", err)
+			return fmt.Errorf("failed to get commits for ticket %s: %w", ticketID, err)
+		}
+	}
+	if showWithComments {
+		comments, err := client.GetIssueComments(ctx, ticketID)
+		if err != nil {
+			log.Error("Failed to get ticket comments", "ticketID", ticketID, "error", err)
+			return fmt.Errorf("failed to get comments for ticket %s: %w", ticketID, err)
+		}
+		for _, comment := range comments {
+			details.Comments = append(details.Comments, &TicketComment{
+				IssueComment:   comment,
+				ReactionCounts: youtrack.CountReactions(comment.Reactions),
+			})
+		}
+	}
+
 	// Output results
-	return outputResult(cmd, ticket, formatTicketDetails)
+	return outputResult(cmd, details, formatTicketDetailsWithExtras)
+}
+
+// existsTicket handles the exists command, answering through the exit status
+func existsTicket(cmd *cobra.Command, args []string) error {
+	// Load configuration
+	cfg, err := config.Load("", cmd.Flags())
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+
+	// Validate configuration
+	if err := cfg.Validate(); err != nil {
+		return fmt.Errorf("invalid configuration: %w", err)
+	}
+
+	// The answer is the exit status, failures are reported without the usage
+	cmd.SilenceUsage = true
+	notFound := func(reason string) error {
+		log.Info("Ticket does not exist", "ticketID", args[0], "reason", reason)
+		cmd.SilenceErrors = true
+		return &ExitCodeError{Code: 2}
+	}
+
+	// Normalize the ticket ID, a bare number gets the default project
+	ticketID, err := projects.IssueID(cfg, args[0])
+	if err != nil {
+		return notFound(err.Error())
+	}
+
+	// Create client and context
+	client := cfg.NewClient()
+	ctx := youtrack.NewYouTrackContext(context.Background(), cfg.Server.Token)
+
+	projection, err := youtrack.NewIssueProjection([]string{"id"})
+	if err != nil {
+		return err
+	}
+	ticket, err := client.GetIssueProjected(ctx, ticketID, projection)
+	if err != nil {
+		var apiErr *youtrack.APIError
+		if errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound {
+			return notFound("not found or not visible")
+		}
+		return fmt.Errorf("failed to check ticket %s: %w", ticketID, err)
+	}
+
+	log.Info("Ticket exists", "ticketID", ticket.ID)
+	return nil
 }
 
 // createTicket handles the create ticket command
@@ -113,21 +218,92 @@ func createTicket(cmd *cobra.Command, args []string) error {
 		}
 	}
 
-	// Parse custom fields
-	customFields, err := parseCustomFields(createFields)
-	if err != nil {
+	// Parse custom fields, so invalid ones are reported before the ticket is queued
+	if _, err := parseCustomFields(createFields); err != nil {
 		return fmt.Errorf("failed to parse custom fields: %w", err)
 	}
 
+	op := &journal.Operation{
+		Kind:        journal.KindCreate,
+		Project:     projectID,
+		Summary:     createTitle,
+		Description: createDescription,
+		Fields:      createFields,
+		Assignee:    createAssignee,
+		Tags:        createTags,
+		NoDefaults:  createNoDefaults,
+		Visibility:  createVisibility,
+	}
+	if queued, err := queueOffline(cmd, cfg, op, nil); queued {
+		return err
+	}
+
 	// Create client and context
-	client := youtrack.NewClient(cfg.Server.URL)
+	client := cfg.NewClient()
 	ctx := youtrack.NewYouTrackContext(context.Background(), cfg.Server.Token)
 
+	ticket, err := createTicketFrom(cfg, client, ctx, op)
+	if err != nil {
+		if queued, queueErr := queueOffline(cmd, cfg, op, err); queued {
+			return queueErr
+		}
+		return err
+	}
+
+	// Output results
+	return outputResult(cmd, ticket, formatTicketCreated)
+}
+
+// createTicketFrom creates a ticket from the create input, now or when replaying the offline journal
+func createTicketFrom(cfg *config.Config, client *youtrack.Client, ctx *youtrack.YouTrackContext, in *journal.Operation) (*youtrack.Issue, error) {
+	fieldAssignments, err := parseCustomFields(in.Fields)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse custom fields: %w", err)
+	}
+
+	// Resolve the project by short name or name
+	project, err := projects.Resolve(cache.Open(cfg), client, ctx, in.Project)
+	if err != nil {
+		return nil, err
+	}
+	projectID := project.ShortName
+
+	// Explicit values come first: --field, then --assignee, then the project defaults
+	fieldAssignments = withDefaultFields(fieldAssignments, [][2]string{{"Assignee", in.Assignee}})
+	tags := in.Tags
+	if !in.NoDefaults {
+		defaults := cfg.ProjectDefaults(projectID)
+		fieldAssignments = withDefaultFields(fieldAssignments, projectDefaultFields(defaults))
+		if len(tags) == 0 {
+			tags = defaults.Tags
+		}
+	}
+
+	// Build custom fields with the types of the project fields
+	customFields, err := buildCustomFields(client, ctx, projectID, fieldAssignments)
+	if err != nil {
+		return nil, err
+	}
+
+	// Resolve visibility groups and users
+	var visibility *youtrack.Visibility
+	if in.Visibility != "" {
+		groups, users, public := youtrack.ParseVisibility(in.Visibility)
+		if !public && len(groups) == 0 && len(users) == 0 {
+			return nil, fmt.Errorf("invalid visibility '%s': expected 'public' or group names and user:login entries", in.Visibility)
+		}
+		visibility, err = client.ResolveVisibility(ctx, groups, users)
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve visibility: %w", err)
+		}
+	}
+
 	// Build create request
 	req := &youtrack.CreateIssueRequest{
-		Project:     youtrack.ProjectRef{ID: projectID},
-		Summary:     createTitle,
-		Description: createDescription,
+		Project:     youtrack.ProjectRef{ID: project.ShortName},
+		Summary:     in.Summary,
+		Description: in.Description,
+		Visibility:  visibility,
 	}
 
 	// Set custom fields if any
@@ -135,30 +311,37 @@ func createTicket(cmd *cobra.Command, args []string) error {
 		req.Fields = customFields
 	}
 
-	log.Info("Creating ticket", "project", projectID, "title", createTitle)
+	log.Info("Creating ticket", "project", projectID, "title", in.Summary)
 
 	// Create the ticket
 	ticket, err := client.CreateIssue(ctx, req)
 	if err != nil {
 		log.Error("Failed to create ticket", "error", err)
-		return fmt.Errorf("failed to create ticket: %w", err)
+		return nil, fmt.Errorf("failed to create ticket: %w", err)
 	}
 
 	log.Info("Ticket created successfully", "ticketID", ticket.ID)
 
-	// Output results
-	return outputResult(cmd, ticket, formatTicketCreated)
+	// Tag the ticket; a failed tag is reported without failing the creation
+	for _, tagName := range tags {
+		tagID, err := client.EnsureTag(ctx, tagName, "")
+		if err == nil {
+			err = client.AddIssueTag(ctx, ticket.ID, tagID)
+		}
+		if err != nil {
+			log.Warn("Failed to tag ticket", "ticketID", ticket.ID, "tag", tagName, "error", err)
+			continue
+		}
+		ticket.Tags = append(ticket.Tags, &youtrack.IssueTag{ID: tagID, Name: tagName})
+	}
+
+	return ticket, nil
 }
 
 // updateTicket handles the update ticket command
 func updateTicket(cmd *cobra.Command, args []string) error {
 	ticketID := args[0]
 
-	// Validate ticket ID format
-	if !isValidTicketID(ticketID) {
-		return fmt.Errorf("invalid ticket ID format: %s (expected format: PRJ-123)", ticketID)
-	}
-
 	// Load configuration
 	cfg, err := config.Load("", cmd.Flags())
 	if err != nil {
@@ -176,20 +359,62 @@ func updateTicket(cmd *cobra.Command, args []string) error {
 	}
 
 	// Parse custom fields
-	customFields, err := parseCustomFields(updateFields)
+	fieldAssignments, err := parseCustomFields(updateFields)
 	if err != nil {
 		return fmt.Errorf("failed to parse custom fields: %w", err)
 	}
 
+	if ticketID == stdinTicketArg {
+		if offlineQueue {
+			return fmt.Errorf("--offline applies to a single ticket, not to ticket IDs read from stdin")
+		}
+		return runBatch(cmd, cfg, "update", func(client *youtrack.Client, ctx *youtrack.YouTrackContext, ticketID string) error {
+			_, err := applyTicketUpdate(client, ctx, ticketID, fieldAssignments)
+			return err
+		})
+	}
+
+	// Normalize the ticket ID, a bare number gets the default project
+	ticketID, err = projects.IssueID(cfg, ticketID)
+	if err != nil {
+		return err
+	}
+
+	op := &journal.Operation{Kind: journal.KindUpdate, IssueID: ticketID, Fields: updateFields}
+	if queued, err := queueOffline(cmd, cfg, op, nil); queued {
+		return err
+	}
+
 	// Create client and context
-	client := youtrack.NewClient(cfg.Server.URL)
+	client := cfg.NewClient()
 	ctx := youtrack.NewYouTrackContext(context.Background(), cfg.Server.Token)
 
+	summary, err := applyTicketUpdate(client, ctx, ticketID, fieldAssignments)
+	if err != nil {
+		if queued, queueErr := queueOffline(cmd, cfg, op, err); queued {
+			return queueErr
+		}
+		return err
+	}
+
+	// Output results
+	return outputResult(cmd, summary, formatTicketUpdated)
+}
+
+// applyTicketUpdate sets the custom fields of a ticket and describes the change
+func applyTicketUpdate(client *youtrack.Client, ctx *youtrack.YouTrackContext, ticketID string, fieldAssignments []fieldAssignment) (*UpdateSummary, error) {
 	// Get original ticket for comparison
 	originalTicket, err := client.GetIssue(ctx, ticketID)
 	if err != nil {
 		log.Error("Failed to get original ticket", "ticketID", ticketID, "error", err)
-		return fmt.Errorf("failed to get original ticket %s: %w", ticketID, err)
+		return nil, fmt.Errorf("failed to get original ticket %s: %w", ticketID, err)
+	}
+
+	// Build custom fields with the types of the project fields
+	ticketProject, _, _ := strings.Cut(ticketID, "-")
+	customFields, err := buildCustomFields(client, ctx, ticketProject, fieldAssignments)
+	if err != nil {
+		return nil, err
 	}
 
 	// Build update request
@@ -206,7 +431,7 @@ func updateTicket(cmd *cobra.Command, args []string) error {
 	updatedTicket, err := client.UpdateIssue(ctx, ticketID, req)
 	if err != nil {
 		log.Error("Failed to update ticket", "ticketID", ticketID, "error", err)
-		return fmt.Errorf("failed to update ticket %s: %w", ticketID, err)
+		return nil, fmt.Errorf("failed to update ticket %s: %w", ticketID, err)
 	}
 
 	log.Info("Ticket updated successfully", "ticketID", ticketID)
@@ -223,8 +448,7 @@ func updateTicket(cmd *cobra.Command, args []string) error {
 		summary.FieldsChanged = append(summary.FieldsChanged, updateFields...)
 	}
 
-	// Output results
-	return outputResult(cmd, summary, formatTicketUpdated)
+	return summary, nil
 }
 
 // tagTicket handles the tag ticket command
@@ -232,11 +456,6 @@ func tagTicket(cmd *cobra.Command, args []string) error {
 	ticketID := args[0]
 	tagNames := args[1:]
 
-	// Validate ticket ID format
-	if !isValidTicketID(ticketID) {
-		return fmt.Errorf("invalid ticket ID format: %s (expected format: PRJ-123)", ticketID)
-	}
-
 	// Load configuration
 	cfg, err := config.Load("", cmd.Flags())
 	if err != nil {
@@ -248,10 +467,31 @@ func tagTicket(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("invalid configuration: %w", err)
 	}
 
+	if ticketID == stdinTicketArg {
+		tags := newTagIDCache(ensureTagID)
+		return runBatch(cmd, cfg, "tag", func(client *youtrack.Client, ctx *youtrack.YouTrackContext, ticketID string) error {
+			return tagOperationError(addTicketTags(client, ctx, ticketID, tagNames, tags))
+		})
+	}
+
+	// Normalize the ticket ID, a bare number gets the default project
+	ticketID, err = projects.IssueID(cfg, ticketID)
+	if err != nil {
+		return err
+	}
+
 	// Create client and context
-	client := youtrack.NewClient(cfg.Server.URL)
+	client := cfg.NewClient()
 	ctx := youtrack.NewYouTrackContext(context.Background(), cfg.Server.Token)
 
+	summary := addTicketTags(client, ctx, ticketID, tagNames, newTagIDCache(ensureTagID))
+
+	// Output results
+	return outputResult(cmd, summary, formatTagOperationSummary)
+}
+
+// addTicketTags adds tags to a ticket, creating the missing ones
+func addTicketTags(client *youtrack.Client, ctx *youtrack.YouTrackContext, ticketID string, tagNames []string, tags *tagIDCache) *TagOperationSummary {
 	log.Info("Adding tags to ticket", "ticketID", ticketID, "tags", tagNames)
 
 	// Track operation results
@@ -263,7 +503,7 @@ func tagTicket(cmd *cobra.Command, args []string) error {
 
 	// Add each tag
 	for i, tagName := range tagNames {
-		tagID, err := client.EnsureTag(ctx, tagName, "")
+		tagID, err := tags.ID(client, ctx, tagName)
 		if err != nil {
 			summary.Results[i] = TagOperationResult{
 				TagName: tagName,
@@ -289,8 +529,7 @@ func tagTicket(cmd *cobra.Command, args []string) error {
 		summary.Results[i] = result
 	}
 
-	// Output results
-	return outputResult(cmd, summary, formatTagOperationSummary)
+	return summary
 }
 
 // untagTicket handles the untag ticket command
@@ -298,11 +537,6 @@ func untagTicket(cmd *cobra.Command, args []string) error {
 	ticketID := args[0]
 	tagNames := args[1:]
 
-	// Validate ticket ID format
-	if !isValidTicketID(ticketID) {
-		return fmt.Errorf("invalid ticket ID format: %s (expected format: PRJ-123)", ticketID)
-	}
-
 	// Load configuration
 	cfg, err := config.Load("", cmd.Flags())
 	if err != nil {
@@ -314,10 +548,31 @@ func untagTicket(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("invalid configuration: %w", err)
 	}
 
+	if ticketID == stdinTicketArg {
+		tags := newTagIDCache(findTagID)
+		return runBatch(cmd, cfg, "untag", func(client *youtrack.Client, ctx *youtrack.YouTrackContext, ticketID string) error {
+			return tagOperationError(removeTicketTags(client, ctx, ticketID, tagNames, tags))
+		})
+	}
+
+	// Normalize the ticket ID, a bare number gets the default project
+	ticketID, err = projects.IssueID(cfg, ticketID)
+	if err != nil {
+		return err
+	}
+
 	// Create client and context
-	client := youtrack.NewClient(cfg.Server.URL)
+	client := cfg.NewClient()
 	ctx := youtrack.NewYouTrackContext(context.Background(), cfg.Server.Token)
 
+	summary := removeTicketTags(client, ctx, ticketID, tagNames, newTagIDCache(findTagID))
+
+	// Output results
+	return outputResult(cmd, summary, formatTagOperationSummary)
+}
+
+// removeTicketTags removes tags from a ticket
+func removeTicketTags(client *youtrack.Client, ctx *youtrack.YouTrackContext, ticketID string, tagNames []string, tags *tagIDCache) *TagOperationSummary {
 	log.Info("Removing tags from ticket", "ticketID", ticketID, "tags", tagNames)
 
 	// Track operation results
@@ -329,7 +584,7 @@ func untagTicket(cmd *cobra.Command, args []string) error {
 
 	// Remove each tag
 	for i, tagName := range tagNames {
-		tag, err := client.GetTagByName(ctx, tagName)
+		tagID, err := tags.ID(client, ctx, tagName)
 		if err != nil {
 			summary.Results[i] = TagOperationResult{
 				TagName: tagName,
@@ -340,7 +595,7 @@ func untagTicket(cmd *cobra.Command, args []string) error {
 			log.Error("Failed to find tag", "ticketID", ticketID, "tag", tagName, "error", err)
 			continue
 		}
-		err = client.RemoveIssueTag(ctx, ticketID, tag.ID)
+		err = client.RemoveIssueTag(ctx, ticketID, tagID)
 		result := TagOperationResult{
 			TagName: tagName,
 			Success: err == nil,
@@ -355,19 +610,13 @@ func untagTicket(cmd *cobra.Command, args []string) error {
 		summary.Results[i] = result
 	}
 
-	// Output results
-	return outputResult(cmd, summary, formatTagOperationSummary)
+	return summary
 }
 
 // showHistory handles the history command
 func showHistory(cmd *cobra.Command, args []string) error {
 	ticketID := args[0]
 
-	// Validate ticket ID format
-	if !isValidTicketID(ticketID) {
-		return fmt.Errorf("invalid ticket ID format: %s (expected format: PRJ-123)", ticketID)
-	}
-
 	// Load configuration
 	cfg, err := config.Load("", cmd.Flags())
 	if err != nil {
@@ -379,8 +628,14 @@ func showHistory(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("invalid configuration: %w", err)
 	}
 
+	// Normalize the ticket ID, a bare number gets the default project
+	ticketID, err = projects.IssueID(cfg, ticketID)
+	if err != nil {
+		return err
+	}
+
 	// Create client and context
-	client := youtrack.NewClient(cfg.Server.URL)
+	client := cfg.NewClient()
 	ctx := youtrack.NewYouTrackContext(context.Background(), cfg.Server.Token)
 
 	log.Info("Fetching ticket history", "ticketID", ticketID)
@@ -392,6 +647,16 @@ func showHistory(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to get ticket activities for %s: %w", ticketID, err)
 	}
 
+	// Keep only the changes of one field
+	if historyField != "" {
+		summary := &FieldHistorySummary{
+			TicketID: ticketID,
+			Field:    historyField,
+			Changes:  youtrack.FieldHistory(activities, historyField),
+		}
+		return outputResult(cmd, summary, formatFieldHistorySummary)
+	}
+
 	// Create history summary
 	summary := &HistorySummary{
 		TicketID:   ticketID,
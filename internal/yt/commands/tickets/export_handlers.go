@@ -0,0 +1,126 @@
+package tickets
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/charmbracelet/log"
+	"github.com/spf13/cobra"
+
+	"github.com/mkozhukh/youtrack/internal/yt/cache"
+	"github.com/mkozhukh/youtrack/internal/yt/config"
+	"github.com/mkozhukh/youtrack/internal/yt/projects"
+	"github.com/mkozhukh/youtrack/pkg/youtrack"
+)
+
+// ExportedActivity is one line of the activities export. The schema is stable: every key is
+// always present, lists are empty rather than null and times are RFC 3339 in UTC.
+type ExportedActivity struct {
+	ID         string   `json:"id"`
+	IssueID    string   `json:"issueId"`
+	Timestamp  string   `json:"timestamp"`
+	Category   string   `json:"category"`
+	Field      string   `json:"field"`
+	Author     string   `json:"author"`
+	AuthorName string   `json:"authorName"`
+	Added      []string `json:"added"`
+	Removed    []string `json:"removed"`
+}
+
+// exportActivities handles the export-activities command
+func exportActivities(cmd *cobra.Command, args []string) error {
+	// Load configuration
+	cfg, err := config.Load("", cmd.Flags())
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+
+	// Validate configuration
+	if err := cfg.Validate(); err != nil {
+		return fmt.Errorf("invalid configuration: %w", err)
+	}
+
+	// Use default project if not specified
+	if projectID == "" {
+		projectID = cfg.Defaults.Project
+	}
+	if projectID == "" {
+		return fmt.Errorf("project is required (use --project or set a default project)")
+	}
+
+	var since time.Time
+	if exportSince != "" {
+		if since, err = time.Parse("2006-01-02", exportSince); err != nil {
+			return fmt.Errorf("invalid --since date (use YYYY-MM-DD): %w", err)
+		}
+	}
+
+	// Create client and context
+	client := cfg.NewClient()
+	ctx := youtrack.NewYouTrackContext(context.Background(), cfg.Server.Token)
+
+	// Resolve the project by short name or name
+	project, err := projects.Resolve(cache.Open(cfg), client, ctx, projectID)
+	if err != nil {
+		return err
+	}
+
+	issueQuery := buildSearchQuery(project.ShortName, "", query)
+
+	var out io.Writer = os.Stdout
+	if exportOut != "" && exportOut != "-" {
+		file, err := os.Create(exportOut)
+		if err != nil {
+			return fmt.Errorf("failed to create %s: %w", exportOut, err)
+		}
+		defer file.Close()
+		out = file
+	}
+
+	log.Info("Exporting activities", "query", issueQuery, "since", exportSince)
+
+	// Each activity is written as soon as its page arrives
+	encoder := json.NewEncoder(out)
+	count := 0
+	err = client.ForEachActivity(ctx, youtrack.ActivityQuery{IssueQuery: issueQuery, Since: since}, func(activity *youtrack.ActivityItem) error {
+		count++
+		return encoder.Encode(exportedActivity(activity))
+	})
+	if err != nil {
+		log.Error("Failed to export activities", "exported", count, "error", err)
+		return fmt.Errorf("failed to export activities after %d records: %w", count, err)
+	}
+
+	if out != os.Stdout {
+		fmt.Printf("Exported %d activities to %s\n", count, exportOut)
+	}
+	return nil
+}
+
+// exportedActivity converts an activity to its export record
+func exportedActivity(activity *youtrack.ActivityItem) *ExportedActivity {
+	record := &ExportedActivity{
+		ID:        activity.ID,
+		IssueID:   activity.IssueID(),
+		Timestamp: activity.Timestamp.UTC().Format(time.RFC3339),
+		Category:  activity.Category.ID,
+		Field:     activity.FieldName(),
+		Added:     activity.AddedText(),
+		Removed:   activity.RemovedText(),
+	}
+	if activity.Author != nil {
+		record.Author = activity.Author.Login
+		record.AuthorName = activity.Author.FullName
+	}
+	if record.Added == nil {
+		record.Added = []string{}
+	}
+	if record.Removed == nil {
+		record.Removed = []string{}
+	}
+	return record
+}
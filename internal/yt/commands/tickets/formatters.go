@@ -5,9 +5,7 @@ import (
 	"strings"
 	"time"
 
-	"github.com/charmbracelet/lipgloss"
-	"github.com/charmbracelet/lipgloss/table"
-
+	"github.com/mkozhukh/youtrack/internal/yt/theme"
 	"github.com/mkozhukh/youtrack/pkg/youtrack"
 )
 
@@ -20,14 +18,7 @@ func formatTicketsList(data interface{}) error {
 		return nil
 	}
 
-	cellStyle := lipgloss.NewStyle().Padding(0, 1).Foreground(lipgloss.Color("246"))
-	t := table.New().
-		Border(lipgloss.NormalBorder()).
-		BorderStyle(lipgloss.NewStyle().Foreground(lipgloss.Color("99"))).
-		StyleFunc(func(row, col int) lipgloss.Style {
-			return cellStyle
-		}).
-		Headers("ID", "SUMMARY", "ASSIGNEE", "UPDATED", "TAGS")
+	t := theme.Current().Table("ID", "SUMMARY", "ASSIGNEE", "UPDATED", "TAGS").Flex(1)
 
 	for _, ticket := range tickets {
 		assignee := "Unassigned"
@@ -51,15 +42,9 @@ func formatTicketsList(data interface{}) error {
 			tags = "-"
 		}
 
-		// Truncate summary if too long
-		summary := ticket.Summary
-		if len(summary) > 60 {
-			summary = summary[:57] + "..."
-		}
-
 		t.Row(
 			ticket.ID,
-			summary,
+			ticket.Summary,
 			assignee,
 			updated,
 			tags,
@@ -109,6 +94,29 @@ func formatTicketDetails(data interface{}) error {
 		fmt.Printf("Resolved:    %s\n", ticket.Resolved.Time.Format(time.RFC3339))
 	}
 
+	if ticket.Visibility.IsLimited() {
+		fmt.Printf("Visible to:  %s\n", ticket.Visibility)
+	}
+
+	if ticket.Votes > 0 {
+		fmt.Printf("Votes:       %d\n", ticket.Votes)
+	}
+
+	// Display custom fields with a value, dates in local time
+	var fields []*youtrack.CustomFieldValue
+	for _, field := range ticket.CustomFields {
+		if field.Name != "Assignee" && field.String() != "" {
+			fields = append(fields, field)
+		}
+	}
+	if len(fields) > 0 {
+		fmt.Printf("\nFields\n")
+		fmt.Printf("──────\n")
+		for _, field := range fields {
+			fmt.Printf("%-12s %s\n", field.Name+":", field.String())
+		}
+	}
+
 	// Display tags if available
 	if len(ticket.Tags) > 0 {
 		fmt.Printf("\nTags\n")
@@ -121,6 +129,86 @@ func formatTicketDetails(data interface{}) error {
 	return nil
 }
 
+// formatTicketDetailsWithExtras formats ticket details followed by the linked commits and
+// the comments, as requested
+func formatTicketDetailsWithExtras(data interface{}) error {
+	details := data.(*TicketDetails)
+
+	if err := formatTicketDetails(details.Issue); err != nil {
+		return err
+	}
+	if details.withCommits {
+		formatTicketCommits(details.Commits)
+	}
+	if details.withComments {
+		formatTicketComments(details.Comments)
+	}
+	return nil
+}
+
+// formatTicketCommits formats the commits linked to a ticket
+func formatTicketCommits(commits []*youtrack.VcsChange) {
+	fmt.Printf("\nCommits\n")
+	fmt.Printf("───────\n")
+
+	if len(commits) == 0 {
+		fmt.Println("No commits linked")
+		return
+	}
+
+	for _, commit := range commits {
+		// Show only the first line of the commit message
+		message, _, _ := strings.Cut(strings.TrimSpace(commit.Text), "\n")
+		fmt.Printf("- %s %s  %s (%s)\n", commit.ShortVersion(), commit.Date.Format("2006-01-02"), message, commit.AuthorName())
+		for _, url := range commit.URLs {
+			fmt.Printf("  %s\n", url)
+		}
+	}
+}
+
+// formatTicketComments formats the comments of a ticket by thread, with their reaction counts
+func formatTicketComments(comments []*TicketComment) {
+	fmt.Printf("\nComments\n")
+	fmt.Printf("────────\n")
+
+	if len(comments) == 0 {
+		fmt.Println("No comments")
+		return
+	}
+
+	counts := make(map[*youtrack.IssueComment][]youtrack.ReactionCount, len(comments))
+	list := make([]*youtrack.IssueComment, 0, len(comments))
+	for _, comment := range comments {
+		counts[comment.IssueComment] = comment.ReactionCounts
+		list = append(list, comment.IssueComment)
+	}
+
+	// Replies follow the comment they answer, indented by their depth in the thread
+	youtrack.WalkCommentThreads(youtrack.BuildCommentThreads(list), func(comment *youtrack.IssueComment, depth int) {
+		indent := strings.Repeat("  ", depth)
+		author := "Unknown"
+		if comment.Author != nil {
+			author = comment.Author.FullName
+			if author == "" {
+				author = comment.Author.Login
+			}
+		}
+
+		marker := "- "
+		if depth > 0 {
+			marker = "↳ "
+		}
+		header := fmt.Sprintf("%s%s%s, %s (%s)", indent, marker, author, comment.Created.Time.Format("2006-01-02 15:04"), comment.ID)
+		if reactions := formatReactionCounts(counts[comment]); reactions != "" {
+			header += "  [" + reactions + "]"
+		}
+		fmt.Println(header)
+		for _, line := range strings.Split(strings.TrimSpace(comment.Text), "\n") {
+			fmt.Printf("%s  %s\n", indent, line)
+		}
+	})
+}
+
 // formatTicketCreated formats the created ticket for text output
 func formatTicketCreated(data interface{}) error {
 	ticket := data.(*youtrack.Issue)
@@ -128,6 +216,9 @@ func formatTicketCreated(data interface{}) error {
 	fmt.Printf("Ticket created successfully!\n\n")
 	fmt.Printf("ID:      %s\n", ticket.ID)
 	fmt.Printf("Summary: %s\n", ticket.Summary)
+	if ticket.Visibility.IsLimited() {
+		fmt.Printf("Visible: %s\n", ticket.Visibility)
+	}
 
 	if ticket.Description != "" {
 		fmt.Printf("Description: %s\n", ticket.Description)
@@ -141,6 +232,14 @@ func formatTicketCreated(data interface{}) error {
 		fmt.Printf("Assignee: %s\n", assignee)
 	}
 
+	if len(ticket.Tags) > 0 {
+		names := make([]string, len(ticket.Tags))
+		for i, tag := range ticket.Tags {
+			names[i] = tag.Name
+		}
+		fmt.Printf("Tags: %s\n", strings.Join(names, ", "))
+	}
+
 	fmt.Printf("Created: %s\n", ticket.Created.Time.Format(time.RFC3339))
 
 	return nil
@@ -266,6 +365,27 @@ func formatTagOperationSummary(data interface{}) error {
 	return nil
 }
 
+// formatBatchSummary formats the results of an operation applied to tickets read from stdin
+func formatBatchSummary(data interface{}) error {
+	summary := data.(*BatchSummary)
+
+	for _, result := range summary.Results {
+		if result.Success {
+			fmt.Printf("✓ %s\n", result.TicketID)
+		} else {
+			fmt.Printf("✗ %s: %s\n", result.TicketID, result.Error)
+		}
+	}
+
+	fmt.Printf("\nSummary: %s succeeded for %d ticket(s)", summary.Operation, summary.Succeeded)
+	if summary.Failed > 0 {
+		fmt.Printf(", %d failed", summary.Failed)
+	}
+	fmt.Printf("\n")
+
+	return nil
+}
+
 // formatCommentsList formats comments list for text output
 func formatCommentsList(data interface{}) error {
 	comments := data.([]*youtrack.IssueComment)
@@ -275,20 +395,10 @@ func formatCommentsList(data interface{}) error {
 		return nil
 	}
 
-	t := table.New().
-		Border(lipgloss.NormalBorder()).
-		BorderStyle(lipgloss.NewStyle().Foreground(lipgloss.Color("99"))).
-		StyleFunc(func(row, col int) lipgloss.Style {
-			switch {
-			case row == 0:
-				return lipgloss.NewStyle().Foreground(lipgloss.Color("212")).Bold(true)
-			default:
-				return lipgloss.NewStyle().Foreground(lipgloss.Color("246"))
-			}
-		}).
-		Headers("ID", "AUTHOR", "CREATED", "TEXT")
+	t := theme.Current().Table("ID", "AUTHOR", "CREATED", "TEXT", "REACTIONS").Flex(3)
 
-	for _, comment := range comments {
+	// Replies follow the comment they answer, indented by their depth in the thread
+	youtrack.WalkCommentThreads(youtrack.BuildCommentThreads(comments), func(comment *youtrack.IssueComment, depth int) {
 		author := "Unknown"
 		if comment.Author != nil {
 			author = comment.Author.FullName
@@ -300,22 +410,21 @@ func formatCommentsList(data interface{}) error {
 		// Format created time
 		created := comment.Created.Time.Format("2006-01-02 15:04")
 
-		// Truncate text if too long for table display
-		text := comment.Text
-		if len(text) > 60 {
-			text = text[:57] + "..."
-		}
 		// Replace newlines with spaces for table display
-		text = strings.ReplaceAll(text, "\n", " ")
+		text := strings.ReplaceAll(comment.Text, "\n", " ")
 		text = strings.ReplaceAll(text, "\r", " ")
+		if depth > 0 {
+			text = strings.Repeat("  ", depth-1) + "↳ " + text
+		}
 
 		t.Row(
 			comment.ID,
 			author,
 			created,
 			text,
+			formatReactionCounts(youtrack.CountReactions(comment.Reactions)),
 		)
-	}
+	})
 
 	fmt.Println(t)
 	return nil
@@ -337,6 +446,9 @@ func formatCommentAdded(data interface{}) error {
 	}
 
 	fmt.Printf("Created: %s\n", comment.Created.Time.Format(time.RFC3339))
+	if comment.ReplyTo != nil {
+		fmt.Printf("Reply to: %s\n", comment.ReplyTo.ID)
+	}
 	fmt.Printf("Text:    %s\n", comment.Text)
 
 	return nil
@@ -351,18 +463,7 @@ func formatAttachmentsList(data interface{}) error {
 		return nil
 	}
 
-	t := table.New().
-		Border(lipgloss.NormalBorder()).
-		BorderStyle(lipgloss.NewStyle().Foreground(lipgloss.Color("99"))).
-		StyleFunc(func(row, col int) lipgloss.Style {
-			switch {
-			case row == 0:
-				return lipgloss.NewStyle().Foreground(lipgloss.Color("212")).Bold(true)
-			default:
-				return lipgloss.NewStyle().Foreground(lipgloss.Color("246"))
-			}
-		}).
-		Headers("ID", "NAME", "SIZE", "AUTHOR", "CREATED")
+	t := theme.Current().Table("ID", "NAME", "SIZE", "AUTHOR", "CREATED")
 
 	for _, attachment := range attachments {
 		author := "Unknown"
@@ -376,8 +477,11 @@ func formatAttachmentsList(data interface{}) error {
 		// Format created time
 		created := attachment.Created.Time.Format("2006-01-02 15:04")
 
-		// Format file size in a human-readable way
+		// Format file size in a human-readable way, with the dimensions of images
 		size := formatFileSize(attachment.Size)
+		if attachment.ImageDimensions != nil {
+			size += fmt.Sprintf(" (%dx%d)", attachment.ImageDimensions.Width, attachment.ImageDimensions.Height)
+		}
 
 		t.Row(
 			attachment.ID,
@@ -427,18 +531,7 @@ func formatWorklogsList(data interface{}) error {
 		return nil
 	}
 
-	t := table.New().
-		Border(lipgloss.NormalBorder()).
-		BorderStyle(lipgloss.NewStyle().Foreground(lipgloss.Color("99"))).
-		StyleFunc(func(row, col int) lipgloss.Style {
-			switch {
-			case row == 0:
-				return lipgloss.NewStyle().Foreground(lipgloss.Color("212")).Bold(true)
-			default:
-				return lipgloss.NewStyle().Foreground(lipgloss.Color("246"))
-			}
-		}).
-		Headers("ID", "AUTHOR", "DATE", "DURATION", "DESCRIPTION")
+	t := theme.Current().Table("ID", "AUTHOR", "DATE", "DURATION", "DESCRIPTION").Flex(4)
 
 	for _, worklog := range worklogs {
 		author := "Unknown"
@@ -455,13 +548,8 @@ func formatWorklogsList(data interface{}) error {
 		// Format duration
 		duration := formatDuration(worklog.Duration.Minutes)
 
-		// Truncate description if too long for table display
-		description := worklog.Description
-		if len(description) > 50 {
-			description = description[:47] + "..."
-		}
 		// Replace newlines with spaces for table display
-		description = strings.ReplaceAll(description, "\n", " ")
+		description := strings.ReplaceAll(worklog.Description, "\n", " ")
 		description = strings.ReplaceAll(description, "\r", " ")
 		if description == "" {
 			description = "-"
@@ -509,6 +597,18 @@ func formatWorklogAdded(data interface{}) error {
 	return nil
 }
 
+// formatWorklogsAdded formats the work items of a worklog spread across days
+func formatWorklogsAdded(data interface{}) error {
+	worklogs := data.([]*youtrack.WorkItem)
+
+	total := 0
+	for _, worklog := range worklogs {
+		total += worklog.Duration.Minutes
+	}
+	fmt.Printf("%d worklogs added, %s in total.\n\n", len(worklogs), formatDuration(total))
+	return formatWorklogsList(worklogs)
+}
+
 // formatLinkOperationSummary formats the link operation results for text output
 func formatLinkOperationSummary(data interface{}) error {
 	summary := data.(*LinkOperationSummary)
@@ -530,6 +630,161 @@ func formatLinkOperationSummary(data interface{}) error {
 	return nil
 }
 
+// formatAttachmentDeleted formats the attachment deletion for text output
+func formatAttachmentDeleted(data interface{}) error {
+	summary := data.(*AttachmentDeleteSummary)
+
+	fmt.Printf("Deleted attachment %s (%s) from %s\n", summary.Name, summary.AttachmentID, summary.TicketID)
+	return nil
+}
+
+// formatSimilarTickets formats the similar ticket candidates for text output
+func formatSimilarTickets(data interface{}) error {
+	summary := data.(*SimilarTicketsSummary)
+
+	if len(summary.Candidates) == 0 {
+		fmt.Printf("No similar tickets found for \"%s\"\n", summary.Text)
+		return nil
+	}
+
+	fmt.Printf("Tickets similar to \"%s\":\n", summary.Text)
+
+	t := theme.Current().Table("ID", "SCORE", "STATE", "SUMMARY", "MATCHED").Flex(3)
+
+	for _, candidate := range summary.Candidates {
+		state := candidate.Issue.State
+		if state == "" {
+			state = "-"
+		}
+
+		t.Row(
+			candidate.Issue.ID,
+			fmt.Sprintf("%.0f%%", candidate.Score*100),
+			state,
+			candidate.Issue.Summary,
+			strings.Join(candidate.Keywords, ", "),
+		)
+	}
+
+	fmt.Println(t)
+	return nil
+}
+
+// formatReactions formats the reactions to a comment for text output
+func formatReactions(data interface{}) error {
+	summary := data.(*ReactionsSummary)
+
+	if len(summary.Reactions) == 0 {
+		fmt.Printf("No reactions on comment %s\n", summary.CommentID)
+		return nil
+	}
+
+	fmt.Printf("Reactions on comment %s of %s: %s\n", summary.CommentID, summary.TicketID, formatReactionCounts(summary.Counts))
+	for _, count := range summary.Counts {
+		var users []string
+		for _, r := range summary.Reactions {
+			if r.Reaction == count.Reaction {
+				users = append(users, userDisplayName(r.Author))
+			}
+		}
+		fmt.Printf("  %s: %s\n", count.Reaction, strings.Join(users, ", "))
+	}
+	return nil
+}
+
+// formatReactionCounts formats reaction counts as "thumbs-up x2, heart x1"
+func formatReactionCounts(counts []youtrack.ReactionCount) string {
+	parts := make([]string, 0, len(counts))
+	for _, c := range counts {
+		parts = append(parts, fmt.Sprintf("%s x%d", c.Reaction, c.Count))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// formatVoters formats the votes of a ticket for text output
+func formatVoters(data interface{}) error {
+	summary := data.(*VotersSummary)
+
+	fmt.Printf("Votes for %s: %d\n", summary.TicketID, summary.Votes)
+	if summary.HasVote {
+		fmt.Printf("You voted for this ticket.\n")
+	}
+
+	if len(summary.Voters) > 0 {
+		fmt.Printf("\nVoters\n")
+		fmt.Printf("──────\n")
+		for _, user := range summary.Voters {
+			fmt.Printf("- %s\n", userDisplayName(user))
+		}
+	}
+
+	if len(summary.DuplicateVotes) > 0 {
+		fmt.Printf("\nVotes for duplicates\n")
+		fmt.Printf("────────────────────\n")
+		for _, vote := range summary.DuplicateVotes {
+			fmt.Printf("- %s (%s)\n", userDisplayName(vote.User), vote.IssueID)
+		}
+	}
+
+	return nil
+}
+
+// userDisplayName returns the full name of a user, or the login if not set
+func userDisplayName(user *youtrack.User) string {
+	if user == nil {
+		return "Unknown"
+	}
+	if user.FullName != "" {
+		return user.FullName
+	}
+	return user.Login
+}
+
+// formatPullRequestLinked formats the pull request link result for text output
+func formatPullRequestLinked(data interface{}) error {
+	summary := data.(*PullRequestLinkSummary)
+
+	fmt.Printf("Linked %s to %s\n", summary.PullRequest.Reference(), summary.TicketID)
+	fmt.Printf("URL:   %s\n", summary.PullRequest.URL)
+	if summary.State != "" {
+		fmt.Printf("State: %s\n", summary.State)
+	}
+
+	return nil
+}
+
+// formatFieldHistorySummary formats the changes of one field for text output
+func formatFieldHistorySummary(data interface{}) error {
+	summary := data.(*FieldHistorySummary)
+
+	if len(summary.Changes) == 0 {
+		fmt.Printf("No changes of field %s found for ticket: %s\n", summary.Field, summary.TicketID)
+		return nil
+	}
+
+	fmt.Printf("%s History for Ticket: %s\n\n", summary.Changes[0].Field, summary.TicketID)
+	for _, change := range summary.Changes {
+		author := "System"
+		if change.Author != nil {
+			author = change.Author.FullName
+			if author == "" {
+				author = change.Author.Login
+			}
+		}
+		from, to := "(none)", "(none)"
+		if len(change.From) > 0 {
+			from = strings.Join(change.From, ", ")
+		}
+		if len(change.To) > 0 {
+			to = strings.Join(change.To, ", ")
+		}
+		fmt.Printf("%s  %-20s %s -> %s\n", change.Timestamp.Format("2006-01-02 15:04:05"), author, from, to)
+	}
+
+	fmt.Printf("\nTotal changes: %d\n", len(summary.Changes))
+	return nil
+}
+
 // formatHistorySummary formats the activity history for text output
 func formatHistorySummary(data interface{}) error {
 	summary := data.(*HistorySummary)
@@ -8,6 +8,7 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/mkozhukh/youtrack/internal/yt/config"
+	"github.com/mkozhukh/youtrack/internal/yt/projects"
 	"github.com/mkozhukh/youtrack/pkg/youtrack"
 )
 
@@ -16,14 +17,6 @@ func addLink(cmd *cobra.Command, args []string) error {
 	sourceTicketID := args[0]
 	targetTicketID := args[1]
 
-	// Validate ticket ID formats
-	if !isValidTicketID(sourceTicketID) {
-		return fmt.Errorf("invalid source ticket ID format: %s (expected format: PRJ-123)", sourceTicketID)
-	}
-	if !isValidTicketID(targetTicketID) {
-		return fmt.Errorf("invalid target ticket ID format: %s (expected format: PRJ-123)", targetTicketID)
-	}
-
 	// Load configuration
 	cfg, err := config.Load("", cmd.Flags())
 	if err != nil {
@@ -35,8 +28,15 @@ func addLink(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("invalid configuration: %w", err)
 	}
 
+	if sourceTicketID, err = projects.IssueID(cfg, sourceTicketID); err != nil {
+		return fmt.Errorf("invalid source ticket: %w", err)
+	}
+	if targetTicketID, err = projects.IssueID(cfg, targetTicketID); err != nil {
+		return fmt.Errorf("invalid target ticket: %w", err)
+	}
+
 	// Create client and context
-	client := youtrack.NewClient(cfg.Server.URL)
+	client := cfg.NewClient()
 	ctx := youtrack.NewYouTrackContext(context.Background(), cfg.Server.Token)
 
 	log.Info("Creating link between tickets", "source", sourceTicketID, "target", targetTicketID, "type", linkType)
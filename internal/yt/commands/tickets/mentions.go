@@ -0,0 +1,72 @@
+package tickets
+
+import (
+	"strings"
+	"sync"
+
+	"github.com/charmbracelet/log"
+
+	"github.com/mkozhukh/youtrack/internal/resolver"
+	"github.com/mkozhukh/youtrack/pkg/youtrack"
+)
+
+// mentionCache resolves the @mentions of comments, fetching the users of each project once;
+// the tickets of a batch share it
+type mentionCache struct {
+	mu    sync.Mutex
+	users map[string][]*youtrack.User
+}
+
+func newMentionCache() *mentionCache {
+	return &mentionCache{users: make(map[string][]*youtrack.User)}
+}
+
+// Resolve returns the text with its mentions rewritten to the logins of the users of the
+// ticket's project. Rewritten mentions are logged, unresolved ones logged as warnings; when
+// the users cannot be fetched the text is returned as written.
+func (c *mentionCache) Resolve(client *youtrack.Client, ctx *youtrack.YouTrackContext, ticketID, text string) string {
+	if !resolver.HasMentions(text) {
+		return text
+	}
+
+	projectID, _, _ := strings.Cut(ticketID, "-")
+	users, err := c.projectUsers(client, ctx, projectID)
+	if err != nil {
+		log.Warn("Mentions left as written, failed to fetch project users", "ticketID", ticketID, "error", err)
+		return text
+	}
+
+	result := resolver.MatchMentions(projectID, text, users)
+	for _, note := range result.Notes {
+		log.Info("Comment mention", "ticketID", ticketID, "rewritten", note)
+	}
+	for _, warning := range result.Warnings {
+		log.Warn("Comment mention", "ticketID", ticketID, "problem", warning)
+	}
+	return result.Text
+}
+
+// projectUsers returns the users of a project, fetched on first use
+func (c *mentionCache) projectUsers(client *youtrack.Client, ctx *youtrack.YouTrackContext, projectID string) ([]*youtrack.User, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if users, ok := c.users[projectID]; ok {
+		return users, nil
+	}
+
+	var users []*youtrack.User
+	const top = 100
+	for skip := 0; ; skip += top {
+		page, err := client.GetProjectUsers(ctx, projectID, skip, top)
+		if err != nil {
+			return nil, err
+		}
+		users = append(users, page...)
+		if len(page) < top {
+			break
+		}
+	}
+	c.users[projectID] = users
+	return users, nil
+}
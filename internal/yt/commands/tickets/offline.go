@@ -0,0 +1,356 @@
+package tickets
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/log"
+	"github.com/spf13/cobra"
+
+	"github.com/mkozhukh/youtrack/internal/yt/cache"
+	"github.com/mkozhukh/youtrack/internal/yt/config"
+	"github.com/mkozhukh/youtrack/internal/yt/journal"
+	"github.com/mkozhukh/youtrack/pkg/youtrack"
+)
+
+// offlineQueue is the --offline flag of the commands that can be queued
+var offlineQueue bool
+
+// Flags of the sync command
+var (
+	syncList    bool
+	syncForce   bool
+	syncDiscard string
+)
+
+// Statuses of a replayed operation
+const (
+	syncApplied  = "applied"
+	syncConflict = "conflict"
+	syncFailed   = "failed"
+	syncPending  = "pending"
+)
+
+// SyncCmd represents the sync command, registered at the top level as `yt sync`
+var SyncCmd = &cobra.Command{
+	Use:   "sync",
+	Short: "Sends the changes queued while YouTrack was unreachable",
+	Long: `Replays the creates, updates, comments and worklogs queued in the offline journal, in
+the order they were queued. Applied changes leave the journal; conflicts and failures stay
+in it, to be retried, forced or discarded.
+
+An update conflicts when its ticket changed on the server after the update was queued; use
+--force to apply it anyway. A change to a ticket that no longer exists conflicts as well.
+Syncing stops at the first change that finds YouTrack still unreachable.`,
+	Args: cobra.NoArgs,
+	RunE: syncJournal,
+}
+
+// QueuedOperation is the result of a command queued while offline
+type QueuedOperation struct {
+	Operation *journal.Operation `json:"operation"`
+	Pending   int                `json:"pending"`
+	Journal   string             `json:"journal"`
+}
+
+// SyncSummary contains the results of replaying the offline journal
+type SyncSummary struct {
+	Applied   int          `json:"applied"`
+	Conflicts int          `json:"conflicts"`
+	Failed    int          `json:"failed"`
+	Pending   int          `json:"pending"`
+	Results   []SyncResult `json:"results"`
+}
+
+// SyncResult is the result of replaying one queued operation
+type SyncResult struct {
+	ID      string `json:"id"`
+	Kind    string `json:"kind"`
+	Target  string `json:"target"`
+	Status  string `json:"status"`
+	IssueID string `json:"issueId,omitempty"`
+	Message string `json:"message,omitempty"`
+}
+
+// IssueIDs returns the tickets changed or created by the sync
+func (s *SyncSummary) IssueIDs() []string {
+	var list []string
+	for _, result := range s.Results {
+		if result.Status == syncApplied {
+			list = append(list, result.IssueID)
+		}
+	}
+	return list
+}
+
+// addOfflineFlags adds the --offline flag to the commands that can be queued
+func addOfflineFlags(cmds ...*cobra.Command) {
+	for _, cmd := range cmds {
+		cmd.Flags().BoolVar(&offlineQueue, "offline", false, "Queue the change in the offline journal without contacting YouTrack (sent by 'yt sync')")
+	}
+}
+
+// queueOffline queues an operation in the offline journal when --offline is set, or when err
+// shows YouTrack is unreachable and offline.enabled is set in the config. It reports whether
+// the operation was queued, with the error of queuing it.
+func queueOffline(cmd *cobra.Command, cfg *config.Config, op *journal.Operation, err error) (bool, error) {
+	if !offlineQueue && !(cfg.Offline.Enabled && journal.Unreachable(err)) {
+		return false, nil
+	}
+	if err != nil {
+		log.Warn("YouTrack is unreachable, queuing the change", "error", err)
+	}
+
+	store := journal.NewStore(cfg.Offline.Journal, cache.Open(cfg))
+	pending, err := store.Add(op, time.Now())
+	if err != nil {
+		return true, err
+	}
+	return true, outputResult(cmd, &QueuedOperation{Operation: op, Pending: pending, Journal: store.Path()}, formatQueuedOperation)
+}
+
+// syncJournal handles the sync command
+func syncJournal(cmd *cobra.Command, args []string) error {
+	// Load configuration
+	cfg, err := config.Load("", cmd.Flags())
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+
+	store := journal.NewStore(cfg.Offline.Journal, cache.Open(cfg))
+
+	if syncDiscard != "" {
+		removed, err := store.Remove(syncDiscard)
+		if err != nil {
+			return err
+		}
+		if !removed {
+			return fmt.Errorf("no queued change with ID %s (see 'yt sync --list')", syncDiscard)
+		}
+		fmt.Printf("Discarded queued change %s.\n", syncDiscard)
+		return nil
+	}
+
+	ops, err := store.List()
+	if err != nil {
+		return err
+	}
+	if syncList {
+		return outputResult(cmd, ops, formatJournal)
+	}
+	if len(ops) == 0 {
+		return outputResult(cmd, &SyncSummary{Results: []SyncResult{}}, formatSyncSummary)
+	}
+
+	// Validate configuration
+	if err := cfg.Validate(); err != nil {
+		return fmt.Errorf("invalid configuration: %w", err)
+	}
+
+	// Create client and context
+	client := cfg.NewClient()
+	ctx := youtrack.NewYouTrackContext(context.Background(), cfg.Server.Token)
+
+	log.Info("Replaying the offline journal", "journal", store.Path(), "count", len(ops))
+
+	summary := &SyncSummary{}
+	unreachable := false
+	for _, op := range ops {
+		result := SyncResult{ID: op.ID, Kind: op.Kind, Target: op.Target(), Status: syncPending}
+		if !unreachable {
+			var err error
+			result.IssueID, err = replayOperation(cfg, client, ctx, op)
+			switch {
+			case err == nil:
+				result.Status = syncApplied
+				if _, err := store.Remove(op.ID); err != nil {
+					return err
+				}
+			case journal.Unreachable(err):
+				// The rest is kept for the next sync
+				unreachable = true
+				result.Message = "YouTrack is unreachable"
+			case errors.As(err, new(*conflictError)):
+				result.Status = syncConflict
+				result.Message = err.Error()
+			default:
+				result.Status = syncFailed
+				result.Message = youtrack.TranslateError(err).Error()
+			}
+		}
+		summary.Results = append(summary.Results, result)
+	}
+
+	for _, result := range summary.Results {
+		switch result.Status {
+		case syncApplied:
+			summary.Applied++
+		case syncConflict:
+			summary.Conflicts++
+		case syncFailed:
+			summary.Failed++
+		default:
+			summary.Pending++
+		}
+	}
+
+	if err := outputResult(cmd, summary, formatSyncSummary); err != nil {
+		return err
+	}
+	if summary.Conflicts > 0 || summary.Failed > 0 || summary.Pending > 0 {
+		// The summary already lists the changes left in the journal
+		cmd.SilenceUsage = true
+		return fmt.Errorf("%d of %d queued changes were not applied", len(ops)-summary.Applied, len(ops))
+	}
+	return nil
+}
+
+// conflictError is a queued change that no longer fits the ticket on the server
+type conflictError struct {
+	message string
+}
+
+func (e *conflictError) Error() string {
+	return e.message
+}
+
+// replayOperation applies a queued operation, returning the changed or created ticket
+func replayOperation(cfg *config.Config, client *youtrack.Client, ctx *youtrack.YouTrackContext, op *journal.Operation) (string, error) {
+	if op.Kind == journal.KindCreate {
+		ticket, err := createTicketFrom(cfg, client, ctx, op)
+		if err != nil {
+			return "", err
+		}
+		return ticket.ID, nil
+	}
+
+	// The ticket may have been deleted or changed since the operation was queued
+	issue, err := client.GetIssue(ctx, op.IssueID)
+	if err != nil {
+		if apiErr, ok := err.(*youtrack.APIError); ok && apiErr.StatusCode == 404 {
+			return "", &conflictError{fmt.Sprintf("ticket %s no longer exists", op.IssueID)}
+		}
+		return "", err
+	}
+
+	switch op.Kind {
+	case journal.KindUpdate:
+		if issue.Updated.After(op.Queued) && !syncForce {
+			return "", &conflictError{fmt.Sprintf("%s changed on the server at %s, after the update was queued (use --force to apply it anyway)",
+				op.IssueID, issue.Updated.Local().Format("2006-01-02 15:04"))}
+		}
+		fieldAssignments, err := parseCustomFields(op.Fields)
+		if err != nil {
+			return "", fmt.Errorf("failed to parse custom fields: %w", err)
+		}
+		_, err = applyTicketUpdate(client, ctx, op.IssueID, fieldAssignments)
+		return op.IssueID, err
+	case journal.KindComment:
+		text := newMentionCache().Resolve(client, ctx, op.IssueID, op.Text)
+		_, err := addTicketComment(client, ctx, op.IssueID, text, op.ReplyTo)
+		return op.IssueID, err
+	case journal.KindWorklog:
+		days, err := planWorklog(op.Minutes, op.Date, op.Spread)
+		if err != nil {
+			return "", err
+		}
+		// Work logged today is dated with the time it was queued, not the time it was sent
+		if len(days) == 1 && days[0].Date.IsZero() {
+			days[0].Date = op.Queued
+		}
+		_, err = addTicketWorklogs(client, ctx, op.IssueID, op.Author, op.Description, days)
+		return op.IssueID, err
+	default:
+		return "", fmt.Errorf("unknown change kind %q", op.Kind)
+	}
+}
+
+// describeOperation describes a queued operation in one line
+func describeOperation(op *journal.Operation) string {
+	switch op.Kind {
+	case journal.KindCreate:
+		return fmt.Sprintf("create %q in %s", op.Summary, op.Project)
+	case journal.KindUpdate:
+		return fmt.Sprintf("update %s: %s", op.IssueID, strings.Join(op.Fields, ", "))
+	case journal.KindComment:
+		text := strings.Join(strings.Fields(op.Text), " ")
+		if runes := []rune(text); len(runes) > 60 {
+			text = string(runes[:57]) + "..."
+		}
+		if op.ReplyTo != "" {
+			return fmt.Sprintf("reply to comment %s on %s: %s", op.ReplyTo, op.IssueID, text)
+		}
+		return fmt.Sprintf("comment on %s: %s", op.IssueID, text)
+	case journal.KindWorklog:
+		text := fmt.Sprintf("log %s on %s", formatDuration(op.Minutes), op.IssueID)
+		switch {
+		case op.Spread != "":
+			text += " across " + op.Spread
+		case op.Date != "":
+			text += " on " + op.Date
+		}
+		if op.Author != "" {
+			text += " for " + op.Author
+		}
+		return text
+	default:
+		return op.Kind + " " + op.Target()
+	}
+}
+
+func formatQueuedOperation(data interface{}) error {
+	queued := data.(*QueuedOperation)
+	fmt.Printf("Queued offline: %s\n", describeOperation(queued.Operation))
+	fmt.Printf("%d change(s) pending, run 'yt sync' to send them.\n", queued.Pending)
+	return nil
+}
+
+func formatJournal(data interface{}) error {
+	ops := data.([]*journal.Operation)
+	if len(ops) == 0 {
+		fmt.Println("No queued changes.")
+		return nil
+	}
+	for _, op := range ops {
+		fmt.Printf("%3s  %s  %s\n", op.ID, op.Queued.Local().Format("2006-01-02 15:04"), describeOperation(op))
+	}
+	return nil
+}
+
+func formatSyncSummary(data interface{}) error {
+	summary := data.(*SyncSummary)
+	if len(summary.Results) == 0 {
+		fmt.Println("No queued changes.")
+		return nil
+	}
+
+	for _, result := range summary.Results {
+		switch result.Status {
+		case syncApplied:
+			fmt.Printf("✓ %s %s %s\n", result.ID, result.Kind, result.IssueID)
+		case syncPending:
+			fmt.Printf("… %s %s %s: %s\n", result.ID, result.Kind, result.Target, result.Message)
+		default:
+			fmt.Printf("✗ %s %s %s (%s): %s\n", result.ID, result.Kind, result.Target, result.Status, result.Message)
+		}
+	}
+
+	fmt.Printf("\nSummary: %d applied", summary.Applied)
+	if summary.Conflicts > 0 {
+		fmt.Printf(", %d conflict(s)", summary.Conflicts)
+	}
+	if summary.Failed > 0 {
+		fmt.Printf(", %d failed", summary.Failed)
+	}
+	if summary.Pending > 0 {
+		fmt.Printf(", %d still pending", summary.Pending)
+	}
+	fmt.Printf("\n")
+	if summary.Conflicts > 0 || summary.Failed > 0 {
+		fmt.Println("Changes not applied stay queued: retry with 'yt sync', or drop one with 'yt sync --discard <id>'.")
+	}
+	return nil
+}
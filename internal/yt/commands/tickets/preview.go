@@ -0,0 +1,87 @@
+package tickets
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"golang.org/x/term"
+
+	"github.com/mkozhukh/youtrack/internal/yt/theme"
+	"github.com/mkozhukh/youtrack/pkg/youtrack"
+)
+
+var (
+	previewBoldPattern = regexp.MustCompile(`\*\*([^*]+)\*\*`)
+	previewCodePattern = regexp.MustCompile("`([^`]+)`")
+	previewLinkPattern = regexp.MustCompile(`\[([^\]]+)\]\(([^)\s]+)\)`)
+	previewListPattern = regexp.MustCompile(`^(\s*)[-*+]\s+`)
+)
+
+// isInteractive reports whether the command runs in a terminal, where a prompt can be answered
+func isInteractive() bool {
+	return term.IsTerminal(int(os.Stdin.Fd())) && term.IsTerminal(int(os.Stderr.Fd()))
+}
+
+// confirmComment shows the rendered comment with the problems found in its Markdown and asks
+// before posting it
+func confirmComment(ticketID, text string) (bool, error) {
+	th := theme.Current()
+	boxStyle := lipgloss.NewStyle().Border(lipgloss.RoundedBorder()).BorderForeground(th.Border.GetForeground()).Padding(0, 1)
+
+	fmt.Fprintf(os.Stderr, "Comment on %s:\n", ticketID)
+	fmt.Fprintln(os.Stderr, boxStyle.Render(renderMarkdownPreview(text)))
+	for _, warning := range youtrack.MarkdownWarnings(text) {
+		fmt.Fprintln(os.Stderr, th.Warn.Render("Warning: "+warning))
+	}
+	return confirm("Post the comment?")
+}
+
+// renderMarkdownPreview renders the common Markdown of comments for the terminal: headings,
+// lists, quotes, code blocks and spans, bold text and links
+func renderMarkdownPreview(text string) string {
+	th := theme.Current()
+	var lines []string
+	inFence := false
+	for _, line := range strings.Split(strings.TrimRight(text, "\n"), "\n") {
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, "```") {
+			inFence = !inFence
+			continue
+		}
+		if inFence {
+			lines = append(lines, th.Code.Render("  "+line))
+			continue
+		}
+
+		switch {
+		case strings.HasPrefix(trimmed, "#"):
+			heading := strings.TrimSpace(strings.TrimLeft(trimmed, "#"))
+			lines = append(lines, th.Header.Render(renderInline(heading)))
+		case strings.HasPrefix(trimmed, ">"):
+			quote := strings.TrimSpace(strings.TrimPrefix(trimmed, ">"))
+			lines = append(lines, th.Cell.Render("│ "+quote))
+		default:
+			line = previewListPattern.ReplaceAllString(line, "${1}• ")
+			lines = append(lines, renderInline(line))
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// renderInline renders the code spans, bold text and links of a line
+func renderInline(line string) string {
+	th := theme.Current()
+	line = previewCodePattern.ReplaceAllStringFunc(line, func(span string) string {
+		return th.Code.Render(strings.Trim(span, "`"))
+	})
+	line = previewBoldPattern.ReplaceAllStringFunc(line, func(bold string) string {
+		return th.Strong.Render(strings.Trim(bold, "*"))
+	})
+	return previewLinkPattern.ReplaceAllStringFunc(line, func(link string) string {
+		match := previewLinkPattern.FindStringSubmatch(link)
+		return th.Link.Render(match[1]) + " (" + match[2] + ")"
+	})
+}
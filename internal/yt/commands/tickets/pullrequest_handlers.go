@@ -0,0 +1,100 @@
+package tickets
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/log"
+	"github.com/spf13/cobra"
+
+	"github.com/mkozhukh/youtrack/internal/resolver"
+	"github.com/mkozhukh/youtrack/internal/yt/config"
+	"github.com/mkozhukh/youtrack/internal/yt/projects"
+	"github.com/mkozhukh/youtrack/pkg/youtrack"
+)
+
+// linkPullRequest handles the link-pr command
+func linkPullRequest(cmd *cobra.Command, args []string) error {
+	ticketID := args[0]
+
+	pr, err := youtrack.ParsePullRequestURL(args[1])
+	if err != nil {
+		return err
+	}
+
+	// Load configuration
+	cfg, err := config.Load("", cmd.Flags())
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+
+	// Validate configuration
+	if err := cfg.Validate(); err != nil {
+		return fmt.Errorf("invalid configuration: %w", err)
+	}
+
+	// Normalize the ticket ID, a bare number gets the default project
+	ticketID, err = projects.IssueID(cfg, ticketID)
+	if err != nil {
+		return err
+	}
+
+	// Determine the state to move the ticket to
+	ticketProject, _, _ := strings.Cut(ticketID, "-")
+	state := linkPRState
+	if state == "" {
+		state = cfg.Workflow.ReviewStateFor(ticketProject)
+	}
+	if linkPRNoState {
+		state = ""
+	}
+
+	// Create client and context
+	client := cfg.NewClient()
+	ctx := youtrack.NewYouTrackContext(context.Background(), cfg.Server.Token)
+
+	// Build the state field first, so an unknown state fails before the comment is posted
+	var stateFields []youtrack.CustomField
+	if state != "" {
+		allowedStates, err := client.GetCustomFieldAllowedValues(ctx, ticketProject, "State")
+		if err != nil {
+			return fmt.Errorf("failed to get states for project %s: %w", ticketProject, err)
+		}
+		resolution, err := resolver.MatchEnumValue("State", state, allowedStates)
+		if err != nil {
+			return err
+		}
+		state = resolution.Value
+
+		stateFields, err = buildCustomFields(client, ctx, ticketProject, []fieldAssignment{{Name: "State", Values: []string{state}}})
+		if err != nil {
+			return err
+		}
+	}
+
+	log.Info("Linking pull request", "ticketID", ticketID, "pr", pr.Reference())
+
+	comment, err := client.AddIssueComment(ctx, ticketID, pr.Comment())
+	if err != nil {
+		log.Error("Failed to add comment", "ticketID", ticketID, "error", err)
+		return fmt.Errorf("failed to add comment to ticket %s: %w", ticketID, err)
+	}
+
+	if stateFields != nil {
+		if _, err := client.UpdateIssue(ctx, ticketID, &youtrack.UpdateIssueRequest{Fields: stateFields}); err != nil {
+			log.Error("Failed to update ticket state", "ticketID", ticketID, "error", err)
+			return fmt.Errorf("pull request linked, but failed to set state of %s to %s: %w", ticketID, state, err)
+		}
+	}
+
+	summary := &PullRequestLinkSummary{
+		TicketID:    ticketID,
+		PullRequest: pr,
+		Comment:     comment,
+		State:       state,
+	}
+
+	// Output results
+	return outputResult(cmd, summary, formatPullRequestLinked)
+}
@@ -0,0 +1,91 @@
+package tickets
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/log"
+	"github.com/spf13/cobra"
+
+	"github.com/mkozhukh/youtrack/internal/yt/cache"
+	"github.com/mkozhukh/youtrack/internal/yt/config"
+	"github.com/mkozhukh/youtrack/internal/yt/projects"
+	"github.com/mkozhukh/youtrack/pkg/youtrack"
+)
+
+// findSimilarTickets handles the similar command
+func findSimilarTickets(cmd *cobra.Command, args []string) error {
+	if len(args) == 0 && strings.TrimSpace(similarText) == "" {
+		return fmt.Errorf("provide a ticket ID or --text to search for")
+	}
+
+	// Load configuration
+	cfg, err := config.Load("", cmd.Flags())
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+
+	// Validate configuration
+	if err := cfg.Validate(); err != nil {
+		return fmt.Errorf("invalid configuration: %w", err)
+	}
+
+	var ticketID string
+	if len(args) > 0 {
+		if ticketID, err = projects.IssueID(cfg, args[0]); err != nil {
+			return err
+		}
+	}
+
+	// Create client and context
+	client := cfg.NewClient()
+	ctx := youtrack.NewYouTrackContext(context.Background(), cfg.Server.Token)
+
+	summary := &SimilarTicketsSummary{Text: similarText}
+	opts := youtrack.SimilarIssuesOptions{
+		UnresolvedOnly: similarUnresolved,
+		Limit:          similarLimit,
+	}
+
+	// Search for the summary of the given ticket, unless a text is given
+	if ticketID != "" {
+		summary.TicketID = ticketID
+		opts.ExcludeID = ticketID
+		if summary.Text == "" {
+			issue, err := client.GetIssue(ctx, ticketID)
+			if err != nil {
+				if apiErr, ok := err.(*youtrack.APIError); ok && apiErr.StatusCode == 404 {
+					return fmt.Errorf("ticket not found: %s", ticketID)
+				}
+				return fmt.Errorf("failed to fetch ticket: %w", err)
+			}
+			summary.Text = issue.Summary
+		}
+	}
+
+	// Search all projects unless one is given
+	if projectID != "" {
+		project, err := projects.Resolve(cache.Open(cfg), client, ctx, projectID)
+		if err != nil {
+			return err
+		}
+		opts.Project = project.ShortName
+		summary.Project = project.ShortName
+	}
+
+	log.Info("Searching similar tickets", "text", summary.Text, "project", opts.Project)
+
+	similar, err := client.FindSimilarIssues(ctx, summary.Text, opts)
+	if err != nil {
+		log.Error("Failed to search similar tickets", "error", err)
+		return err
+	}
+	summary.Candidates = similar
+	if summary.Candidates == nil {
+		summary.Candidates = []*youtrack.SimilarIssue{}
+	}
+
+	// Output results
+	return outputResult(cmd, summary, formatSimilarTickets)
+}
@@ -12,6 +12,22 @@ type UpdateSummary struct {
 	FieldsChanged   []string
 }
 
+// TicketDetails is a ticket together with its linked commits and its comments, as requested
+type TicketDetails struct {
+	*youtrack.Issue
+	Commits  []*youtrack.VcsChange `json:"commits,omitempty"`
+	Comments []*TicketComment      `json:"comments,omitempty"`
+
+	withCommits  bool
+	withComments bool
+}
+
+// TicketComment is a comment with its reactions counted by kind
+type TicketComment struct {
+	*youtrack.IssueComment
+	ReactionCounts []youtrack.ReactionCount `json:"reactionCounts,omitempty"`
+}
+
 // TagOperationResult represents the result of a single tag operation
 type TagOperationResult struct {
 	TagName string
@@ -36,6 +52,59 @@ type LinkOperationSummary struct {
 	Error          string
 }
 
+// PullRequestLinkSummary contains information about a pull request linked to a ticket
+type PullRequestLinkSummary struct {
+	TicketID    string                 `json:"ticketId"`
+	PullRequest *youtrack.PullRequest  `json:"pullRequest"`
+	Comment     *youtrack.IssueComment `json:"comment"`
+	State       string                 `json:"state,omitempty"` // State the ticket was moved to, if any
+}
+
+// AttachmentDeleteSummary contains information about a deleted attachment
+type AttachmentDeleteSummary struct {
+	TicketID     string `json:"ticketId"`
+	AttachmentID string `json:"attachmentId"`
+	Name         string `json:"name"`
+}
+
+// SimilarTicketsSummary contains the candidates found by the similar command
+type SimilarTicketsSummary struct {
+	TicketID   string                   `json:"ticketId,omitempty"`
+	Text       string                   `json:"text"`
+	Project    string                   `json:"project,omitempty"`
+	Candidates []*youtrack.SimilarIssue `json:"candidates"`
+}
+
+// IssueIDs returns the IDs of the candidates, for --output ids
+func (s *SimilarTicketsSummary) IssueIDs() []string {
+	ids := make([]string, len(s.Candidates))
+	for i, candidate := range s.Candidates {
+		ids[i] = candidate.Issue.ID
+	}
+	return ids
+}
+
+// ReactionsSummary contains the reactions to a comment
+type ReactionsSummary struct {
+	TicketID  string                   `json:"ticketId"`
+	CommentID string                   `json:"commentId"`
+	Counts    []youtrack.ReactionCount `json:"counts"`
+	Reactions []*youtrack.Reaction     `json:"reactions"`
+}
+
+// VotersSummary contains the votes of a ticket
+type VotersSummary struct {
+	TicketID string `json:"ticketId"`
+	*youtrack.IssueVoters
+}
+
+// FieldHistorySummary contains the changes of one field of a ticket
+type FieldHistorySummary struct {
+	TicketID string                  `json:"ticketId"`
+	Field    string                  `json:"field"`
+	Changes  []*youtrack.FieldChange `json:"changes"`
+}
+
 // HistorySummary contains the ticket history information
 type HistorySummary struct {
 	TicketID   string
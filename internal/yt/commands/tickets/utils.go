@@ -1,40 +1,51 @@
 package tickets
 
 import (
+	"bufio"
 	"encoding/json"
 	"fmt"
+	"io"
 	"os"
-	"regexp"
 	"strings"
 
+	"github.com/mkozhukh/youtrack/internal/resolver"
+	"github.com/mkozhukh/youtrack/internal/yt/config"
+	"github.com/mkozhukh/youtrack/internal/yt/extract"
+	"github.com/mkozhukh/youtrack/internal/yt/ids"
 	"github.com/mkozhukh/youtrack/pkg/youtrack"
 
 	"github.com/spf13/cobra"
 )
 
-// isValidTicketID validates the ticket ID format (e.g., "PRJ-123")
-func isValidTicketID(ticketID string) bool {
-	// Pattern: one or more alphanumeric characters, dash, one or more digits
-	pattern := `^[A-Za-z0-9]+-\d+$`
-	matched, err := regexp.MatchString(pattern, ticketID)
-	return err == nil && matched
-}
-
 // getOutputFlag gets the output flag from the command hierarchy
 func getOutputFlag(cmd *cobra.Command) string {
-	// Walk up the command hierarchy to find the output flag
+	if value := getGlobalFlag(cmd, "output"); value != "" {
+		return value
+	}
+	return "text" // default
+}
+
+// getGlobalFlag gets the value of a flag from the command hierarchy, empty when not defined
+func getGlobalFlag(cmd *cobra.Command, name string) string {
+	// Walk up the command hierarchy to find the flag
 	current := cmd
 	for current != nil {
-		if flag := current.Flag("output"); flag != nil {
+		if flag := current.Flag(name); flag != nil {
 			return flag.Value.String()
 		}
 		current = current.Parent()
 	}
-	return "text" // default
+	return ""
 }
 
-// outputResult outputs data in the requested format (text or JSON)
+// outputResult outputs data in the requested format (text, JSON or ticket IDs), or with the
+// --template or --jsonpath given
 func outputResult(cmd *cobra.Command, data interface{}, formatAsText func(interface{}) error) error {
+	tmpl, path := getGlobalFlag(cmd, "template"), getGlobalFlag(cmd, "jsonpath")
+	if tmpl != "" || path != "" {
+		return extract.Print(os.Stdout, tmpl, path, data)
+	}
+
 	outputFlag := getOutputFlag(cmd)
 
 	switch outputFlag {
@@ -42,11 +53,23 @@ func outputResult(cmd *cobra.Command, data interface{}, formatAsText func(interf
 		return outputJSON(data)
 	case "text":
 		return formatAsText(data)
+	case ids.Format:
+		return ids.Print(os.Stdout, data)
 	default:
 		return fmt.Errorf("unsupported output format: %s", outputFlag)
 	}
 }
 
+// ExitCodeError ends a command with an exit status other than 1, without an error message
+// when the command silences errors
+type ExitCodeError struct {
+	Code int
+}
+
+func (e *ExitCodeError) Error() string {
+	return fmt.Sprintf("exit status %d", e.Code)
+}
+
 // outputJSON outputs data as JSON
 func outputJSON(data interface{}) error {
 	encoder := json.NewEncoder(os.Stdout)
@@ -54,13 +77,22 @@ func outputJSON(data interface{}) error {
 	return encoder.Encode(data)
 }
 
-// parseCustomFields parses key=value pairs from the --field flags
-func parseCustomFields(fields []string) ([]youtrack.CustomField, error) {
+// fieldAssignment is a custom field value set with --field, before the field type is known
+type fieldAssignment struct {
+	Name   string
+	Type   string   // Explicit type from "key|type", empty if not given
+	Values []string // One entry per occurrence of the key
+}
+
+// parseCustomFields parses key=value pairs from the --field flags.
+// Repeated keys are collected into a single assignment with several values.
+func parseCustomFields(fields []string) ([]fieldAssignment, error) {
 	if len(fields) == 0 {
 		return nil, nil
 	}
 
-	customFields := make([]youtrack.CustomField, 0, len(fields))
+	assignments := make([]fieldAssignment, 0, len(fields))
+	index := make(map[string]int)
 	for _, field := range fields {
 		parts := strings.SplitN(field, "=", 2)
 		if len(parts) != 2 {
@@ -74,41 +106,138 @@ func parseCustomFields(fields []string) ([]youtrack.CustomField, error) {
 			return nil, fmt.Errorf("empty field key in: %s", field)
 		}
 
+		keyType := ""
 		if strings.Contains(key, "|") {
 			parts := strings.SplitN(key, "|", 2)
-			if len(parts) != 2 {
-				return nil, fmt.Errorf("invalid field format: %s (expected key|value)", field)
+			key = strings.TrimSpace(parts[0])
+			keyType = strings.TrimSpace(parts[1])
+			if key == "" || keyType == "" {
+				return nil, fmt.Errorf("invalid field format: %s (expected key|type=value)", field)
 			}
-			key = parts[0]
-			keyType := parts[1]
-
-			switch keyType {
-			case "SingleEnumIssueCustomField":
-			case "enum":
-				customFields = append(customFields, youtrack.CustomField{
-					Name:  key,
-					Type:  "SingleEnumIssueCustomField",
-					Value: youtrack.SingleValue{Value: value},
-				})
-			default:
-				customFields = append(customFields, youtrack.CustomField{
-					Name:  key,
-					Type:  keyType,
-					Value: value,
-				})
+			if keyType == "enum" {
+				keyType = "SingleEnumIssueCustomField"
 			}
+		}
 
+		lowerKey := strings.ToLower(key)
+		if i, ok := index[lowerKey]; ok {
+			assignments[i].Values = append(assignments[i].Values, value)
+			if keyType != "" {
+				assignments[i].Type = keyType
+			}
 			continue
 		}
 
-		customFields = append(customFields, youtrack.CustomField{
-			Name:  key,
-			Type:  "SimpleIssueCustomField",
-			Value: value,
-		})
+		index[lowerKey] = len(assignments)
+		assignments = append(assignments, fieldAssignment{Name: key, Type: keyType, Values: []string{value}})
 	}
 
-	return customFields, nil
+	return assignments, nil
+}
+
+// withDefaultFields adds the default values to the --field assignments, for the fields not
+// already assigned; defaults maps field names to values and empty values are skipped
+func withDefaultFields(assignments []fieldAssignment, defaults [][2]string) []fieldAssignment {
+	for _, d := range defaults {
+		name, value := d[0], d[1]
+		if value == "" {
+			continue
+		}
+		assigned := false
+		for _, a := range assignments {
+			if strings.EqualFold(a.Name, name) {
+				assigned = true
+				break
+			}
+		}
+		if !assigned {
+			assignments = append(assignments, fieldAssignment{Name: name, Values: []string{value}})
+		}
+	}
+	return assignments
+}
+
+// projectDefaultFields returns the fields the project defaults set, in a stable order
+func projectDefaultFields(defaults config.ProjectDefaults) [][2]string {
+	return [][2]string{
+		{"Type", defaults.Type},
+		{"Priority", defaults.Priority},
+		{"Assignee", defaults.Assignee},
+	}
+}
+
+// buildCustomFields converts --field assignments to custom fields for the API.
+// Types of untyped fields are looked up in the project; multi-value fields accept
+// comma-separated and repeated values, and version values are resolved against
+// the project versions (archived versions only by their exact name).
+func buildCustomFields(client *youtrack.Client, ctx *youtrack.YouTrackContext, projectID string, assignments []fieldAssignment) ([]youtrack.CustomField, error) {
+	if len(assignments) == 0 {
+		return nil, nil
+	}
+
+	// Project field types are only needed for fields without an explicit type
+	patch := youtrack.NewCustomFieldPatch(nil)
+	for _, a := range assignments {
+		if a.Type == "" {
+			var err error
+			if patch, err = client.NewCustomFieldPatch(ctx, projectID); err != nil {
+				return nil, err
+			}
+			break
+		}
+	}
+
+	for _, a := range assignments {
+		if a.Type != "" {
+			values := a.Values
+			if strings.HasPrefix(a.Type, "Multi") {
+				values = splitFieldValues(values)
+			}
+			patch.SetTyped(a.Name, a.Type, values...)
+			continue
+		}
+
+		projectField := patch.ProjectField(a.Name)
+		if projectField == nil {
+			return nil, fmt.Errorf("custom field '%s' not found in project %s", a.Name, projectID)
+		}
+
+		values := a.Values
+		if projectField.IsMultiValue() {
+			values = splitFieldValues(values)
+		}
+
+		if projectField.BundleKind() == "version" && projectField.Bundle != nil {
+			versions, err := client.GetCustomFieldAllowedValues(ctx, projectID, projectField.Field.Name)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get versions for %s: %w", projectField.Field.Name, err)
+			}
+			for i, value := range values {
+				resolution, err := resolver.MatchEnumValue(projectField.Field.Name, value, versions)
+				if err != nil {
+					return nil, err
+				}
+				values[i] = resolution.Value
+			}
+		}
+
+		patch.Set(a.Name, values...)
+	}
+
+	return patch.Fields()
+}
+
+// splitFieldValues splits comma-separated values of multi-value fields
+func splitFieldValues(values []string) []string {
+	var result []string
+	for _, value := range values {
+		for _, part := range strings.Split(value, ",") {
+			if part = strings.TrimSpace(part); part != "" {
+				result = append(result, part)
+			}
+		}
+	}
+	return result
 }
 
 // parseDuration parses a duration string like "1h 30m" or "90m" into minutes
@@ -145,3 +274,14 @@ func formatFileSize(size int64) string {
 	}
 	return fmt.Sprintf("%.1f %cB", float64(size)/float64(div), "KMGTPE"[exp])
 }
+
+// confirm asks a yes/no question on stderr and reads the answer from stdin; only y or yes confirm
+func confirm(question string) (bool, error) {
+	fmt.Fprintf(os.Stderr, "%s [y/N]: ", question)
+	answer, err := bufio.NewReader(os.Stdin).ReadString('\n')
+	if err != nil && err != io.EOF {
+		return false, fmt.Errorf("failed to read input: %w", err)
+	}
+	answer = strings.ToLower(strings.TrimSpace(answer))
+	return answer == "y" || answer == "yes", nil
+}
@@ -0,0 +1,128 @@
+package tickets
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/charmbracelet/log"
+	"github.com/spf13/cobra"
+
+	"github.com/mkozhukh/youtrack/internal/yt/config"
+	"github.com/mkozhukh/youtrack/internal/yt/projects"
+	"github.com/mkozhukh/youtrack/pkg/youtrack"
+)
+
+// voteTicket handles the vote command
+func voteTicket(cmd *cobra.Command, args []string) error {
+	return setTicketVote(cmd, args[0], true)
+}
+
+// unvoteTicket handles the unvote command
+func unvoteTicket(cmd *cobra.Command, args []string) error {
+	return setTicketVote(cmd, args[0], false)
+}
+
+// setTicketVote adds or removes the vote of the current user and shows the resulting votes
+func setTicketVote(cmd *cobra.Command, ticketID string, vote bool) error {
+	if ticketID == stdinTicketArg {
+		return setTicketVotes(cmd, vote)
+	}
+
+	client, ctx, ticketID, err := newVotesClient(cmd, ticketID)
+	if err != nil {
+		return err
+	}
+
+	if err := changeVote(client, ctx, ticketID, vote); err != nil {
+		return err
+	}
+
+	return showVoters(cmd, client, ctx, ticketID)
+}
+
+// setTicketVotes adds or removes the vote of the current user on the tickets read from stdin
+func setTicketVotes(cmd *cobra.Command, vote bool) error {
+	// Load configuration
+	cfg, err := config.Load("", cmd.Flags())
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+
+	// Validate configuration
+	if err := cfg.Validate(); err != nil {
+		return fmt.Errorf("invalid configuration: %w", err)
+	}
+
+	operation := "vote"
+	if !vote {
+		operation = "unvote"
+	}
+	return runBatch(cmd, cfg, operation, func(client *youtrack.Client, ctx *youtrack.YouTrackContext, ticketID string) error {
+		return changeVote(client, ctx, ticketID, vote)
+	})
+}
+
+// changeVote adds or removes the vote of the current user
+func changeVote(client *youtrack.Client, ctx *youtrack.YouTrackContext, ticketID string, vote bool) error {
+	var err error
+	if vote {
+		log.Info("Voting for ticket", "ticketID", ticketID)
+		err = client.VoteIssue(ctx, ticketID)
+	} else {
+		log.Info("Removing vote from ticket", "ticketID", ticketID)
+		err = client.UnvoteIssue(ctx, ticketID)
+	}
+	if err != nil {
+		log.Error("Failed to change vote", "ticketID", ticketID, "error", err)
+		return fmt.Errorf("failed to change vote: %w", err)
+	}
+	return nil
+}
+
+// listVoters handles the voters command
+func listVoters(cmd *cobra.Command, args []string) error {
+	ticketID := args[0]
+
+	client, ctx, ticketID, err := newVotesClient(cmd, ticketID)
+	if err != nil {
+		return err
+	}
+
+	return showVoters(cmd, client, ctx, ticketID)
+}
+
+// showVoters fetches and outputs the votes of a ticket
+func showVoters(cmd *cobra.Command, client *youtrack.Client, ctx *youtrack.YouTrackContext, ticketID string) error {
+	voters, err := client.GetIssueVoters(ctx, ticketID)
+	if err != nil {
+		log.Error("Failed to fetch voters", "ticketID", ticketID, "error", err)
+		return fmt.Errorf("failed to fetch voters: %w", err)
+	}
+
+	return outputResult(cmd, &VotersSummary{TicketID: ticketID, IssueVoters: voters}, formatVoters)
+}
+
+// newVotesClient loads the configuration, normalizes the ticket ID and creates a client for the vote commands
+func newVotesClient(cmd *cobra.Command, ticketID string) (*youtrack.Client, *youtrack.YouTrackContext, string, error) {
+	// Load configuration
+	cfg, err := config.Load("", cmd.Flags())
+	if err != nil {
+		return nil, nil, "", fmt.Errorf("failed to load configuration: %w", err)
+	}
+
+	// Validate configuration
+	if err := cfg.Validate(); err != nil {
+		return nil, nil, "", fmt.Errorf("invalid configuration: %w", err)
+	}
+
+	// Normalize the ticket ID, a bare number gets the default project
+	ticketID, err = projects.IssueID(cfg, ticketID)
+	if err != nil {
+		return nil, nil, "", err
+	}
+
+	// Create client and context
+	client := cfg.NewClient()
+	ctx := youtrack.NewYouTrackContext(context.Background(), cfg.Server.Token)
+	return client, ctx, ticketID, nil
+}
@@ -3,11 +3,15 @@ package tickets
 import (
 	"context"
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/charmbracelet/log"
 	"github.com/spf13/cobra"
 
 	"github.com/mkozhukh/youtrack/internal/yt/config"
+	"github.com/mkozhukh/youtrack/internal/yt/journal"
+	"github.com/mkozhukh/youtrack/internal/yt/projects"
 	"github.com/mkozhukh/youtrack/pkg/youtrack"
 )
 
@@ -15,11 +19,6 @@ import (
 func listWorklogs(cmd *cobra.Command, args []string) error {
 	ticketID := args[0]
 
-	// Validate ticket ID format
-	if !isValidTicketID(ticketID) {
-		return fmt.Errorf("invalid ticket ID format: %s (expected format: PRJ-123)", ticketID)
-	}
-
 	// Load configuration
 	cfg, err := config.Load("", cmd.Flags())
 	if err != nil {
@@ -31,8 +30,14 @@ func listWorklogs(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("invalid configuration: %w", err)
 	}
 
+	// Normalize the ticket ID, a bare number gets the default project
+	ticketID, err = projects.IssueID(cfg, ticketID)
+	if err != nil {
+		return err
+	}
+
 	// Create client and context
-	client := youtrack.NewClient(cfg.Server.URL)
+	client := cfg.NewClient()
 	ctx := youtrack.NewYouTrackContext(context.Background(), cfg.Server.Token)
 
 	log.Info("Fetching worklogs for ticket", "ticketID", ticketID)
@@ -55,11 +60,6 @@ func listWorklogs(cmd *cobra.Command, args []string) error {
 func addWorklog(cmd *cobra.Command, args []string) error {
 	ticketID := args[0]
 
-	// Validate ticket ID format
-	if !isValidTicketID(ticketID) {
-		return fmt.Errorf("invalid ticket ID format: %s (expected format: PRJ-123)", ticketID)
-	}
-
 	// Validate required parameters
 	if worklogDuration == "" {
 		return fmt.Errorf("duration is required (use --duration flag)")
@@ -71,6 +71,11 @@ func addWorklog(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("invalid duration format: %w", err)
 	}
 
+	// Check the days before anything is queued or sent
+	if _, err := planWorklog(durationMinutes, worklogDate, worklogSpread); err != nil {
+		return err
+	}
+
 	// Load configuration
 	cfg, err := config.Load("", cmd.Flags())
 	if err != nil {
@@ -82,28 +87,148 @@ func addWorklog(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("invalid configuration: %w", err)
 	}
 
+	// Normalize the ticket ID, a bare number gets the default project
+	ticketID, err = projects.IssueID(cfg, ticketID)
+	if err != nil {
+		return err
+	}
+
+	op := &journal.Operation{Kind: journal.KindWorklog, IssueID: ticketID, Minutes: durationMinutes, Description: worklogDescription, Author: worklogAuthor,
+		Date: worklogDate, Spread: worklogSpread}
+	if queued, err := queueOffline(cmd, cfg, op, nil); queued {
+		return err
+	}
+
 	// Create client and context
-	client := youtrack.NewClient(cfg.Server.URL)
+	client := cfg.NewClient()
 	ctx := youtrack.NewYouTrackContext(context.Background(), cfg.Server.Token)
 
-	// Create worklog request
-	req := &youtrack.CreateWorklogRequest{
-		Duration:    youtrack.DurationValue{Minutes: durationMinutes},
-		Description: worklogDescription,
+	days, _ := planWorklog(durationMinutes, worklogDate, worklogSpread)
+	worklogs, err := addTicketWorklogs(client, ctx, ticketID, worklogAuthor, worklogDescription, days)
+	if err != nil {
+		// Only a change not sent at all is queued, a partly logged spread is not sent twice
+		if len(worklogs) == 0 {
+			if queued, queueErr := queueOffline(cmd, cfg, op, err); queued {
+				return queueErr
+			}
+		}
+		return err
+	}
+
+	// Output results
+	if len(worklogs) == 1 {
+		return outputResult(cmd, worklogs[0], formatWorklogAdded)
+	}
+	return outputResult(cmd, worklogs, formatWorklogsAdded)
+}
+
+// worklogDay is the share of a worklog logged on one day
+type worklogDay struct {
+	// Date is zero for a worklog logged today
+	Date    time.Time
+	Minutes int
+}
+
+// planWorklog returns the work items of a worklog: one on date (today when empty), or the
+// duration split evenly across the working days of a "from..to" spread. The minutes a split
+// leaves over go to the first days, one each.
+func planWorklog(minutes int, date, spread string) ([]worklogDay, error) {
+	if spread == "" {
+		day := worklogDay{Minutes: minutes}
+		if date != "" {
+			parsed, err := time.Parse("2006-01-02", date)
+			if err != nil {
+				return nil, fmt.Errorf("invalid date '%s', use YYYY-MM-DD", date)
+			}
+			day.Date = parsed
+		}
+		return []worklogDay{day}, nil
+	}
+
+	bounds := strings.SplitN(spread, "..", 2)
+	if len(bounds) != 2 {
+		return nil, fmt.Errorf("invalid spread '%s', use FROM..TO, e.g. 2025-02-10..2025-02-14", spread)
+	}
+	from, err := time.Parse("2006-01-02", strings.TrimSpace(bounds[0]))
+	if err != nil {
+		return nil, fmt.Errorf("invalid spread start '%s', use YYYY-MM-DD", bounds[0])
+	}
+	to, err := time.Parse("2006-01-02", strings.TrimSpace(bounds[1]))
+	if err != nil {
+		return nil, fmt.Errorf("invalid spread end '%s', use YYYY-MM-DD", bounds[1])
+	}
+	if to.Before(from) {
+		return nil, fmt.Errorf("invalid spread '%s': the end is before the start", spread)
+	}
+
+	var workdays []time.Time
+	for d := from; !d.After(to); d = d.AddDate(0, 0, 1) {
+		if d.Weekday() != time.Saturday && d.Weekday() != time.Sunday {
+			workdays = append(workdays, d)
+		}
+	}
+	if len(workdays) == 0 {
+		return nil, fmt.Errorf("no working days in %s", spread)
+	}
+	if minutes < len(workdays) {
+		return nil, fmt.Errorf("%s is too short to spread across %d working days", formatDuration(minutes), len(workdays))
 	}
 
-	log.Info("Adding worklog to ticket", "ticketID", ticketID, "duration", durationMinutes)
+	days := make([]worklogDay, len(workdays))
+	for i, d := range workdays {
+		days[i] = worklogDay{Date: d, Minutes: minutes / len(workdays)}
+		if i < minutes%len(workdays) {
+			days[i].Minutes++
+		}
+	}
+	return days, nil
+}
+
+// addTicketWorklogs adds a work item per planned day, stopping at the first failure. It returns
+// the work items added, with the error when not all of them were.
+func addTicketWorklogs(client *youtrack.Client, ctx *youtrack.YouTrackContext, ticketID, author, description string, days []worklogDay) ([]*youtrack.WorkItem, error) {
+	var worklogs []*youtrack.WorkItem
+	for _, day := range days {
+		req := &youtrack.CreateWorklogRequest{
+			Duration:    youtrack.DurationValue{Minutes: day.Minutes},
+			Description: description,
+		}
+		if !day.Date.IsZero() {
+			date := day.Date.UnixMilli()
+			req.Date = &date
+		}
+
+		worklog, err := addTicketWorklog(client, ctx, ticketID, author, req)
+		if err != nil {
+			if len(worklogs) > 0 {
+				return worklogs, fmt.Errorf("%w (%d of %d work items were added)", err, len(worklogs), len(days))
+			}
+			return nil, err
+		}
+		worklogs = append(worklogs, worklog)
+	}
+	return worklogs, nil
+}
+
+// addTicketWorklog adds a worklog to a ticket, logged for the user with the author login when set
+func addTicketWorklog(client *youtrack.Client, ctx *youtrack.YouTrackContext, ticketID, author string, req *youtrack.CreateWorklogRequest) (*youtrack.WorkItem, error) {
+	log.Info("Adding worklog to ticket", "ticketID", ticketID, "duration", req.Duration.Minutes, "author", author)
+
+	if author != "" {
+		user, err := client.GetUserByLogin(ctx, author)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find author '%s': %w", author, err)
+		}
+		req.Author = &youtrack.UserRef{ID: user.ID}
+	}
 
-	// Add the worklog
 	worklog, err := client.AddIssueWorklog(ctx, ticketID, req)
 	if err != nil {
 		if apiErr, ok := err.(*youtrack.APIError); ok && apiErr.StatusCode == 404 {
-			return fmt.Errorf("ticket not found: %s", ticketID)
+			return nil, fmt.Errorf("ticket not found: %s", ticketID)
 		}
 		log.Error("Failed to add worklog", "error", err)
-		return fmt.Errorf("failed to add worklog: %w", err)
+		return nil, fmt.Errorf("failed to add worklog: %w", err)
 	}
-
-	// Output results
-	return outputResult(cmd, worklog, formatWorklogAdded)
+	return worklog, nil
 }
@@ -0,0 +1,320 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/charmbracelet/log"
+	"github.com/spf13/cobra"
+
+	"github.com/mkozhukh/youtrack/internal/timer"
+	"github.com/mkozhukh/youtrack/internal/yt/config"
+	"github.com/mkozhukh/youtrack/internal/yt/projects"
+	"github.com/mkozhukh/youtrack/pkg/youtrack"
+)
+
+var (
+	timerDescription string
+	timerWorkType    string
+	timerDiscard     bool
+	timerDuration    string
+	timerContinue    bool
+)
+
+// TimerSummary describes the timer state for output
+type TimerSummary struct {
+	Running        bool               `json:"running"`
+	IssueID        string             `json:"issueId,omitempty"`
+	Summary        string             `json:"summary,omitempty"`
+	Started        *time.Time         `json:"started,omitempty"`
+	ElapsedMinutes int                `json:"elapsedMinutes,omitempty"`
+	LoggedMinutes  int                `json:"loggedMinutes,omitempty"`
+	Capped         bool               `json:"capped,omitempty"`
+	Worklog        *youtrack.WorkItem `json:"worklog,omitempty"`
+	Discarded      bool               `json:"discarded,omitempty"`
+	Continued      bool               `json:"continued,omitempty"`
+}
+
+// timerCmd represents the timer command
+var timerCmd = &cobra.Command{
+	Use:   "timer",
+	Short: "Track time on a ticket with a start/stop timer",
+	Long: `Track time on a ticket with a start/stop timer. Stopping the timer logs the
+elapsed time as a worklog. The timer state is kept in ~/.config/yt/timer.json and is
+shared with the MCP server, so a timer can be started in one and stopped in the other.`,
+	RunE: timerStatus, // Default to status when no subcommand is given
+}
+
+// startTimerCmd represents the timer start command
+var startTimerCmd = &cobra.Command{
+	Use:   "start <ticket_id>",
+	Short: "Starts the timer for a ticket",
+	Long:  `Starts the timer for a ticket. Only one timer can run at a time, stop the running one first.`,
+	Args:  cobra.ExactArgs(1),
+	RunE:  startTimer,
+}
+
+// stopTimerCmd represents the timer stop command
+var stopTimerCmd = &cobra.Command{
+	Use:   "stop",
+	Short: "Stops the timer and logs the elapsed time",
+	Long: `Stops the running timer and adds a worklog with the elapsed time. The time is rounded
+and capped by the [timer] rules of the config (whole minutes and 8 hours by default).
+
+Use --duration to log a different time, e.g. after a break the timer kept counting, and
+--continue with it to split the session: the given time is logged and the timer keeps
+running with the rest. Use --discard to stop the timer without logging anything.`,
+	Args: cobra.NoArgs,
+	RunE: stopTimer,
+}
+
+// statusTimerCmd represents the timer status command
+var statusTimerCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Shows the running timer",
+	Args:  cobra.NoArgs,
+	RunE:  timerStatus,
+}
+
+func init() {
+	timerCmd.AddCommand(startTimerCmd)
+	timerCmd.AddCommand(stopTimerCmd)
+	timerCmd.AddCommand(statusTimerCmd)
+
+	stopTimerCmd.Flags().StringVarP(&timerDescription, "description", "d", "", "Worklog description")
+	stopTimerCmd.Flags().StringVarP(&timerWorkType, "type", "t", "", "Work type (e.g. Development)")
+	stopTimerCmd.Flags().BoolVar(&timerDiscard, "discard", false, "Stop the timer without adding a worklog")
+	stopTimerCmd.Flags().StringVar(&timerDuration, "duration", "", "Log this time instead of the elapsed time (e.g. 1h30m)")
+	stopTimerCmd.Flags().BoolVar(&timerContinue, "continue", false, "Keep the timer running with the time not logged (requires --duration)")
+}
+
+func startTimer(cmd *cobra.Command, args []string) error {
+	ticketID := args[0]
+
+	// Load configuration
+	cfg, err := config.Load(cfgFile, cmd.Flags())
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+
+	// Validate configuration
+	if err := cfg.Validate(); err != nil {
+		return fmt.Errorf("invalid configuration: %w", err)
+	}
+
+	// Normalize the ticket ID, a bare number gets the default project
+	ticketID, err = projects.IssueID(cfg, ticketID)
+	if err != nil {
+		return err
+	}
+
+	store := timer.NewStore("")
+	if current, err := store.Current(); err != nil {
+		return err
+	} else if current != nil {
+		return fmt.Errorf("a timer is already running for %s (started %s), stop it first", current.IssueID, current.Started.Format("15:04"))
+	}
+
+	// Create client and context
+	client := cfg.NewClient()
+	ctx := youtrack.NewYouTrackContext(context.Background(), cfg.Server.Token)
+
+	// Check the ticket exists, so the worklog can be created when the timer stops
+	issue, err := client.GetIssue(ctx, ticketID)
+	if err != nil {
+		if apiErr, ok := err.(*youtrack.APIError); ok && apiErr.StatusCode == 404 {
+			return fmt.Errorf("ticket not found: %s", ticketID)
+		}
+		return fmt.Errorf("failed to get ticket: %w", err)
+	}
+
+	log.Info("Starting timer", "ticketID", issue.ID, "state", store.Path())
+
+	t, err := store.Start(issue.ID, issue.Summary, time.Now())
+	if err != nil {
+		return err
+	}
+
+	return outputResult(timerSummary(t, time.Now(), timerRules(cfg)), formatTimerStatus)
+}
+
+func stopTimer(cmd *cobra.Command, args []string) error {
+	if timerContinue && timerDuration == "" {
+		return fmt.Errorf("--continue requires --duration, the time to log")
+	}
+	if timerDiscard && (timerDuration != "" || timerContinue) {
+		return fmt.Errorf("--discard cannot be combined with --duration or --continue")
+	}
+
+	// Load configuration
+	cfg, err := config.Load(cfgFile, cmd.Flags())
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+
+	store := timer.NewStore("")
+	t, err := store.Current()
+	if err != nil {
+		return err
+	}
+	if t == nil {
+		return fmt.Errorf("no timer is running (use 'yt timer start <ticket_id>')")
+	}
+
+	now := time.Now()
+	summary := timerSummary(t, now, timerRules(cfg))
+	summary.Running = false
+
+	var rest *timer.Timer
+	if timerDuration != "" {
+		minutes, err := youtrack.ParseDuration(timerDuration)
+		if err != nil {
+			return fmt.Errorf("invalid duration format: %w", err)
+		}
+		if timerContinue {
+			if rest, err = t.Split(minutes, now); err != nil {
+				return fmt.Errorf("cannot continue: %s is not less than the elapsed %s", formatDuration(minutes), formatDuration(summary.ElapsedMinutes))
+			}
+		}
+		summary.LoggedMinutes = minutes
+		summary.Capped = false
+	}
+
+	if _, err := store.Stop(); err != nil {
+		return err
+	}
+
+	if timerDiscard {
+		summary.Discarded = true
+		return outputResult(summary, formatTimerStopped)
+	}
+
+	worklog, err := addTimerWorklog(cfg, t, summary.LoggedMinutes)
+	if err != nil {
+		// Keep the timer running, the time is not lost if the worklog can be retried
+		if restoreErr := store.Restore(t); restoreErr != nil {
+			log.Warn("Failed to restore the timer", "error", restoreErr)
+		}
+		return err
+	}
+	summary.Worklog = worklog
+
+	if rest != nil {
+		if err := store.Restore(rest); err != nil {
+			return fmt.Errorf("worklog added, but the timer could not be continued: %w", err)
+		}
+		summary.Continued = true
+	}
+
+	return outputResult(summary, formatTimerStopped)
+}
+
+// addTimerWorklog logs minutes of the timer session on its ticket, dated with its start
+func addTimerWorklog(cfg *config.Config, t *timer.Timer, minutes int) (*youtrack.WorkItem, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid configuration: %w", err)
+	}
+
+	client := cfg.NewClient()
+	ctx := youtrack.NewYouTrackContext(context.Background(), cfg.Server.Token)
+
+	started := t.Started.UnixMilli()
+	req := &youtrack.CreateWorklogRequest{
+		Duration:    youtrack.DurationValue{Minutes: minutes},
+		Description: timerDescription,
+		Date:        &started,
+	}
+	if timerWorkType != "" {
+		req.Type = &youtrack.WorkTypeRequest{Name: timerWorkType}
+	}
+
+	log.Info("Adding worklog from timer", "ticketID", t.IssueID, "duration", minutes)
+
+	worklog, err := client.AddIssueWorklog(ctx, t.IssueID, req)
+	if err != nil {
+		log.Error("Failed to add worklog", "error", err)
+		return nil, fmt.Errorf("failed to add worklog to %s, the timer is still running: %w", t.IssueID, err)
+	}
+	return worklog, nil
+}
+
+func timerStatus(cmd *cobra.Command, args []string) error {
+	// Load configuration
+	cfg, err := config.Load(cfgFile, cmd.Flags())
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+
+	t, err := timer.NewStore("").Current()
+	if err != nil {
+		return err
+	}
+	if t == nil {
+		return outputResult(&TimerSummary{}, formatTimerStatus)
+	}
+	return outputResult(timerSummary(t, time.Now(), timerRules(cfg)), formatTimerStatus)
+}
+
+// timerRules returns the rounding and cap rules from the config
+func timerRules(cfg *config.Config) timer.Rules {
+	return timer.Rules{
+		MaxSession:   time.Duration(cfg.Timer.MaxSessionMinutes) * time.Minute,
+		RoundMinutes: cfg.Timer.RoundMinutes,
+	}
+}
+
+// timerSummary describes a running timer at now, with the time stopping it would log
+func timerSummary(t *timer.Timer, now time.Time, rules timer.Rules) *TimerSummary {
+	started := t.Started
+	elapsed := t.Elapsed(now)
+	logged, capped := rules.Minutes(elapsed)
+	return &TimerSummary{
+		Running:        true,
+		IssueID:        t.IssueID,
+		Summary:        t.Summary,
+		Started:        &started,
+		ElapsedMinutes: timer.Minutes(elapsed),
+		LoggedMinutes:  logged,
+		Capped:         capped,
+	}
+}
+
+func formatTimerStatus(data interface{}) error {
+	summary := data.(*TimerSummary)
+	if !summary.Running {
+		fmt.Println("No timer is running.")
+		return nil
+	}
+
+	fmt.Printf("Timer running for %s: %s\n", summary.IssueID, summary.Summary)
+	fmt.Printf("Started: %s\n", summary.Started.Format("2006-01-02 15:04"))
+	fmt.Printf("Elapsed: %s\n", formatDuration(summary.ElapsedMinutes))
+	if summary.Capped {
+		fmt.Printf("Warning: the session is over the cap, stopping logs %s (use 'yt timer stop --duration' to log the actual time)\n", formatDuration(summary.LoggedMinutes))
+	}
+	return nil
+}
+
+func formatTimerStopped(data interface{}) error {
+	summary := data.(*TimerSummary)
+	if summary.Discarded {
+		fmt.Printf("Timer for %s discarded after %s, no worklog added.\n", summary.IssueID, formatDuration(summary.ElapsedMinutes))
+		return nil
+	}
+
+	fmt.Printf("Timer stopped, logged %s on %s.\n", formatDuration(summary.Worklog.Duration.Minutes), summary.IssueID)
+	if summary.Capped {
+		fmt.Printf("Warning: the timer ran %s, only the %s session cap was logged.\n", formatDuration(summary.ElapsedMinutes), formatDuration(summary.LoggedMinutes))
+	}
+	if summary.Continued {
+		fmt.Printf("Timer still running for %s with the remaining time.\n", summary.IssueID)
+	}
+	if summary.Worklog.Description != "" {
+		fmt.Printf("Description: %s\n", summary.Worklog.Description)
+	}
+	if summary.Worklog.Type != nil {
+		fmt.Printf("Type: %s\n", summary.Worklog.Type.Name)
+	}
+	return nil
+}
@@ -7,11 +7,13 @@ import (
 	"time"
 
 	"github.com/charmbracelet/lipgloss"
-	"github.com/charmbracelet/lipgloss/table"
 	"github.com/charmbracelet/log"
 	"github.com/spf13/cobra"
 
+	"github.com/mkozhukh/youtrack/internal/yt/cache"
 	"github.com/mkozhukh/youtrack/internal/yt/config"
+	"github.com/mkozhukh/youtrack/internal/yt/projects"
+	"github.com/mkozhukh/youtrack/internal/yt/theme"
 	"github.com/mkozhukh/youtrack/pkg/youtrack"
 )
 
@@ -53,8 +55,8 @@ func init() {
 	usersCmd.AddCommand(userWorklogsCmd)
 
 	// Add project flag to both users and users list commands
-	usersCmd.Flags().StringVarP(&usersProject, "project", "p", "", "The project ID")
-	listUsersCmd.Flags().StringVarP(&usersProject, "project", "p", "", "The project ID")
+	usersCmd.Flags().StringVarP(&usersProject, "project", "p", "", "The project short name or name")
+	listUsersCmd.Flags().StringVarP(&usersProject, "project", "p", "", "The project short name or name")
 
 	// Add flags for worklogs command
 	userWorklogsCmd.Flags().StringVarP(&worklogsProject, "project", "p", "", "Filter worklogs by project")
@@ -84,17 +86,28 @@ func listUsers(cmd *cobra.Command, args []string) error {
 	}
 
 	// Create client and context
-	client := youtrack.NewClient(cfg.Server.URL)
-	if cfg.Server.HubURL != "" {
-		client.SetHubURL(cfg.Server.HubURL)
-	}
+	client := cfg.NewClient()
 	ctx := youtrack.NewYouTrackContext(context.Background(), cfg.Server.Token)
+	store := cache.Open(cfg)
 
-	// Fetch all project users
-	users, err := fetchAllProjectUsers(client, ctx, projectID)
+	// Resolve the project by short name or name
+	project, err := projects.Resolve(store, client, ctx, projectID)
 	if err != nil {
-		log.Error("Failed to fetch project users", "error", err)
-		return fmt.Errorf("failed to fetch project users: %w", err)
+		return err
+	}
+	projectID = project.ShortName
+
+	// Fetch all project users, using the local cache when fresh
+	var users []*youtrack.User
+	if !store.Get(projectID, cacheKindUsers, &users) {
+		users, err = fetchAllProjectUsers(client, ctx, projectID)
+		if err != nil {
+			log.Error("Failed to fetch project users", "error", err)
+			return fmt.Errorf("failed to fetch project users: %w", err)
+		}
+		if err := store.Set(projectID, cacheKindUsers, users); err != nil {
+			log.Warn("Failed to cache project users", "error", err)
+		}
 	}
 
 	// Output results
@@ -118,14 +131,21 @@ func getUserWorklogs(cmd *cobra.Command, args []string) error {
 	}
 
 	// Create client and context
-	client := youtrack.NewClient(cfg.Server.URL)
-	if cfg.Server.HubURL != "" {
-		client.SetHubURL(cfg.Server.HubURL)
-	}
+	client := cfg.NewClient()
 	ctx := youtrack.NewYouTrackContext(context.Background(), cfg.Server.Token)
 
+	// Resolve the project filter by short name or name
+	projectFilter := worklogsProject
+	if projectFilter != "" {
+		project, err := projects.Resolve(cache.Open(cfg), client, ctx, projectFilter)
+		if err != nil {
+			return err
+		}
+		projectFilter = project.ShortName
+	}
+
 	// Find the user (with partial matching)
-	user, err := findUser(client, ctx, username, worklogsProject, cfg.Defaults.Project)
+	user, err := findUser(client, ctx, username, projectFilter, cfg.Defaults.Project)
 	if err != nil {
 		log.Error("Failed to find user", "error", err)
 		return fmt.Errorf("failed to find user: %w", err)
@@ -144,7 +164,7 @@ func getUserWorklogs(cmd *cobra.Command, args []string) error {
 	}
 
 	// Fetch user worklogs
-	workItems, err := fetchAllUserWorklogs(client, ctx, user.ID, worklogsProject, startDate, endDate)
+	workItems, err := fetchAllUserWorklogs(client, ctx, user.ID, projectFilter, startDate, endDate)
 	if err != nil {
 		log.Error("Failed to fetch user worklogs", "error", err)
 		return fmt.Errorf("failed to fetch user worklogs: %w", err)
@@ -281,32 +301,39 @@ func formatUsersList(users []*youtrack.User) error {
 		return nil
 	}
 
-	t := table.New().
-		Border(lipgloss.NormalBorder()).
-		BorderStyle(lipgloss.NewStyle().Foreground(lipgloss.Color("99"))).
-		StyleFunc(func(row, col int) lipgloss.Style {
-			switch {
-			case row == 0:
-				return lipgloss.NewStyle().Foreground(lipgloss.Color("212")).Bold(true)
-			default:
-				return lipgloss.NewStyle().Foreground(lipgloss.Color("246"))
+	th := theme.Current()
+	t := th.Table("LOGIN", "NAME", "EMAIL", "STATUS").
+		Style(func(row, col int) lipgloss.Style {
+			if users[row].Banned {
+				// Banned users stay listed, but cannot be assigned
+				return th.Disabled
 			}
-		}).
-		Headers("LOGIN", "NAME", "EMAIL")
+			return th.Cell
+		})
 
 	for _, user := range users {
-		t.Row(user.Login, user.FullName, user.Email)
+		t.Row(user.Login, user.FullName, user.Email, userStatus(user))
 	}
 
 	fmt.Println(t)
 	return nil
 }
 
+// userStatus describes whether a user is banned or online
+func userStatus(user *youtrack.User) string {
+	switch {
+	case user.Banned:
+		return "banned"
+	case user.Online:
+		return "online"
+	default:
+		return ""
+	}
+}
+
 // formatUserWorklogs formats user worklogs for text output
 func formatUserWorklogs(user *youtrack.User, workItems []*youtrack.WorkItem) error {
-	headerStyle := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("212")).
-		Bold(true)
+	headerStyle := theme.Current().Header
 
 	fmt.Printf("%s\n", headerStyle.Render(fmt.Sprintf("Worklogs for %s (%s)", user.FullName, user.Login)))
 	fmt.Printf("%s\n\n", headerStyle.Render("=================================="))
@@ -316,18 +343,7 @@ func formatUserWorklogs(user *youtrack.User, workItems []*youtrack.WorkItem) err
 		return nil
 	}
 
-	t := table.New().
-		Border(lipgloss.NormalBorder()).
-		BorderStyle(lipgloss.NewStyle().Foreground(lipgloss.Color("99"))).
-		StyleFunc(func(row, col int) lipgloss.Style {
-			switch {
-			case row == 0:
-				return lipgloss.NewStyle().Foreground(lipgloss.Color("212")).Bold(true)
-			default:
-				return lipgloss.NewStyle().Foreground(lipgloss.Color("246"))
-			}
-		}).
-		Headers("DATE", "DURATION", "ISSUE", "DESCRIPTION")
+	t := theme.Current().Table("DATE", "DURATION", "ISSUE", "DESCRIPTION").Flex(3)
 
 	for _, item := range workItems {
 		// Format duration from minutes to human readable
@@ -339,17 +355,11 @@ func formatUserWorklogs(user *youtrack.User, workItems []*youtrack.WorkItem) err
 			issueID = item.Issue.ID
 		}
 
-		// Truncate description if too long
-		description := item.Description
-		if len(description) > 50 {
-			description = description[:47] + "..."
-		}
-
 		t.Row(
 			item.Date.Format("2006-01-02"),
 			duration,
 			issueID,
-			description,
+			item.Description,
 		)
 	}
 
@@ -0,0 +1,446 @@
+package commands
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/log"
+	"github.com/spf13/cobra"
+
+	"github.com/mkozhukh/youtrack/internal/yt/cache"
+	"github.com/mkozhukh/youtrack/internal/yt/config"
+	"github.com/mkozhukh/youtrack/internal/yt/projects"
+	"github.com/mkozhukh/youtrack/internal/yt/theme"
+	"github.com/mkozhukh/youtrack/pkg/youtrack"
+)
+
+// defaultDailyTarget is the time expected per workday when the config doesn't set one
+const defaultDailyTarget = 8 * 60
+
+var (
+	timesheetUser    string
+	timesheetWeek    string
+	timesheetProject string
+	fillTarget       string
+)
+
+// Timesheet is the work logged by a user in one ISO week
+type Timesheet struct {
+	User         *youtrack.User    `json:"user"`
+	Week         string            `json:"week"`
+	Days         []*TimesheetDay   `json:"days"`
+	Issues       []*TimesheetIssue `json:"issues"`
+	TotalMinutes int               `json:"totalMinutes"`
+}
+
+// TimesheetDay is the total logged on one day of the week
+type TimesheetDay struct {
+	Date    string `json:"date"`
+	Minutes int    `json:"minutes"`
+}
+
+// TimesheetIssue is the time logged on an issue, per day of the week
+type TimesheetIssue struct {
+	ID           string `json:"id"`
+	Summary      string `json:"summary"`
+	Minutes      []int  `json:"minutes"` // one entry per day, Monday first
+	TotalMinutes int    `json:"totalMinutes"`
+}
+
+// worklogsCmd represents the worklogs command
+var worklogsCmd = &cobra.Command{
+	Use:   "worklogs",
+	Short: "Weekly timesheet of logged work",
+	Long:  `Shows the work logged in a week as a timesheet and fills in the missing time.`,
+}
+
+// weekWorklogsCmd represents the worklogs week command
+var weekWorklogsCmd = &cobra.Command{
+	Use:   "week",
+	Short: "Shows a timesheet of the week",
+	Long: `Shows the work logged in a week as a grid of issues by days, with daily and issue totals.
+The week is given as an ISO week (e.g. 2025-W07) and defaults to the current one.`,
+	Args: cobra.NoArgs,
+	RunE: showWeekTimesheet,
+}
+
+// fillWorklogsCmd represents the worklogs fill command
+var fillWorklogsCmd = &cobra.Command{
+	Use:   "fill",
+	Short: "Interactively logs the missing time of the week",
+	Long: `Goes through the workdays (Monday to Friday, up to today) of a week on which less than the
+daily target was logged, and prompts for a ticket, a duration and a description to log
+the missing time. The target is worklogs.daily_target_minutes from the config (8 hours by default).`,
+	Args: cobra.NoArgs,
+	RunE: fillWeekTimesheet,
+}
+
+func init() {
+	worklogsCmd.AddCommand(weekWorklogsCmd)
+	worklogsCmd.AddCommand(fillWorklogsCmd)
+
+	weekWorklogsCmd.Flags().StringVarP(&timesheetUser, "user", "u", "me", "User to show the timesheet for")
+	weekWorklogsCmd.Flags().StringVarP(&timesheetWeek, "week", "w", "", "ISO week, e.g. 2025-W07 (defaults to the current week)")
+	weekWorklogsCmd.Flags().StringVarP(&timesheetProject, "project", "p", "", "Only include work logged in this project")
+
+	fillWorklogsCmd.Flags().StringVarP(&timesheetWeek, "week", "w", "", "ISO week, e.g. 2025-W07 (defaults to the current week)")
+	fillWorklogsCmd.Flags().StringVar(&fillTarget, "target", "", "Daily target overriding the config (e.g. 7h 30m)")
+}
+
+func showWeekTimesheet(cmd *cobra.Command, args []string) error {
+	monday, err := parseISOWeek(timesheetWeek, time.Now())
+	if err != nil {
+		return err
+	}
+
+	// Load configuration
+	cfg, err := config.Load(cfgFile, cmd.Flags())
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+
+	// Validate configuration
+	if err := cfg.Validate(); err != nil {
+		return fmt.Errorf("invalid configuration: %w", err)
+	}
+
+	// Create client and context
+	client := cfg.NewClient()
+	ctx := youtrack.NewYouTrackContext(context.Background(), cfg.Server.Token)
+
+	// Resolve the project filter by short name or name
+	projectFilter := timesheetProject
+	if projectFilter != "" {
+		project, err := projects.Resolve(cache.Open(cfg), client, ctx, projectFilter)
+		if err != nil {
+			return err
+		}
+		projectFilter = project.ShortName
+	}
+
+	user, err := timesheetOwner(client, ctx, timesheetUser, projectFilter, cfg.Defaults.Project)
+	if err != nil {
+		return err
+	}
+
+	sheet, err := fetchTimesheet(client, ctx, user, monday, projectFilter)
+	if err != nil {
+		return err
+	}
+
+	return outputResult(sheet, func(data interface{}) error {
+		return formatTimesheet(data.(*Timesheet))
+	})
+}
+
+func fillWeekTimesheet(cmd *cobra.Command, args []string) error {
+	now := time.Now()
+	monday, err := parseISOWeek(timesheetWeek, now)
+	if err != nil {
+		return err
+	}
+
+	// Load configuration
+	cfg, err := config.Load(cfgFile, cmd.Flags())
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+
+	// Validate configuration
+	if err := cfg.Validate(); err != nil {
+		return fmt.Errorf("invalid configuration: %w", err)
+	}
+
+	target := cfg.Worklogs.DailyTargetMinutes
+	if target <= 0 {
+		target = defaultDailyTarget
+	}
+	if fillTarget != "" {
+		if target, err = youtrack.ParseDuration(fillTarget); err != nil {
+			return fmt.Errorf("invalid target format: %w", err)
+		}
+	}
+
+	// Create client and context
+	client := cfg.NewClient()
+	ctx := youtrack.NewYouTrackContext(context.Background(), cfg.Server.Token)
+
+	// Worklogs are added as the token owner, so the timesheet is always the own one
+	user, err := client.GetUser(ctx, "me")
+	if err != nil {
+		log.Error("Failed to get current user", "error", err)
+		return fmt.Errorf("failed to get current user: %w", err)
+	}
+
+	sheet, err := fetchTimesheet(client, ctx, user, monday, "")
+	if err != nil {
+		return err
+	}
+
+	today := now.Format("2006-01-02")
+	var missing []*TimesheetDay
+	for i, day := range sheet.Days {
+		// Workdays only, and nothing in the future
+		if i < 5 && day.Date <= today && day.Minutes < target {
+			missing = append(missing, day)
+		}
+	}
+	if len(missing) == 0 {
+		fmt.Printf("Nothing to fill: every workday of %s up to today has at least %s logged.\n", sheet.Week, formatDuration(target))
+		return nil
+	}
+
+	if len(sheet.Issues) > 0 {
+		ids := make([]string, 0, len(sheet.Issues))
+		for _, issue := range sheet.Issues {
+			ids = append(ids, issue.ID)
+		}
+		fmt.Printf("Worked on this week: %s\n", strings.Join(ids, ", "))
+	}
+	fmt.Println("Enter a ticket ID to log the missing time, leave it empty to skip the day, or 'q' to stop.")
+
+	reader := bufio.NewReader(os.Stdin)
+	added := 0
+	for _, day := range missing {
+		date, _ := time.Parse("2006-01-02", day.Date)
+		gap := target - day.Minutes
+		fmt.Printf("\n%s: %s logged, %s missing\n", date.Format("Mon 2006-01-02"), formatDuration(day.Minutes), formatDuration(gap))
+
+		ticketID, err := prompt(reader, "Ticket: ")
+		if err != nil {
+			return err
+		}
+		if ticketID == "" {
+			continue
+		}
+		if strings.EqualFold(ticketID, "q") {
+			break
+		}
+
+		minutes := gap
+		if answer, err := prompt(reader, fmt.Sprintf("Duration [%s]: ", formatDuration(gap))); err != nil {
+			return err
+		} else if answer != "" {
+			if minutes, err = youtrack.ParseDuration(answer); err != nil {
+				fmt.Printf("Invalid duration '%s', skipping the day: %v\n", answer, err)
+				continue
+			}
+		}
+
+		description, err := prompt(reader, "Description (optional): ")
+		if err != nil {
+			return err
+		}
+
+		dateMs := date.UnixMilli()
+		worklog, err := client.AddIssueWorklog(ctx, ticketID, &youtrack.CreateWorklogRequest{
+			Duration:    youtrack.DurationValue{Minutes: minutes},
+			Description: description,
+			Date:        &dateMs,
+		})
+		if err != nil {
+			log.Error("Failed to add worklog", "ticketID", ticketID, "error", err)
+			fmt.Printf("Failed to add worklog to %s: %v\n", ticketID, err)
+			continue
+		}
+		added++
+		fmt.Printf("Logged %s on %s.\n", formatDuration(worklog.Duration.Minutes), ticketID)
+	}
+
+	fmt.Printf("\nAdded %d worklog(s).\n", added)
+	return nil
+}
+
+// prompt prints a question and reads a trimmed answer; the end of input answers "q"
+func prompt(reader *bufio.Reader, question string) (string, error) {
+	fmt.Print(question)
+	answer, err := reader.ReadString('\n')
+	if err == io.EOF && answer == "" {
+		fmt.Println()
+		return "q", nil
+	}
+	if err != nil && err != io.EOF {
+		return "", fmt.Errorf("failed to read input: %w", err)
+	}
+	return strings.TrimSpace(answer), nil
+}
+
+// timesheetOwner finds the user a timesheet is shown for, "me" is the current user
+func timesheetOwner(client *youtrack.Client, ctx *youtrack.YouTrackContext, username, projectID, defaultProject string) (*youtrack.User, error) {
+	if username == "" || username == "me" {
+		user, err := client.GetUser(ctx, "me")
+		if err != nil {
+			log.Error("Failed to get current user", "error", err)
+			return nil, fmt.Errorf("failed to get current user: %w", err)
+		}
+		return user, nil
+	}
+
+	user, err := findUser(client, ctx, username, projectID, defaultProject)
+	if err != nil {
+		log.Error("Failed to find user", "error", err)
+		return nil, fmt.Errorf("failed to find user: %w", err)
+	}
+	return user, nil
+}
+
+// fetchTimesheet fetches the user's work items of the week starting on monday and groups them
+func fetchTimesheet(client *youtrack.Client, ctx *youtrack.YouTrackContext, user *youtrack.User, monday time.Time, projectID string) (*Timesheet, error) {
+	sunday := monday.AddDate(0, 0, 6)
+
+	log.Info("Fetching worklogs for timesheet", "user", user.Login, "start", monday.Format("2006-01-02"))
+
+	workItems, err := fetchAllUserWorklogs(client, ctx, user.ID, projectID, monday.Format("2006-01-02"), sunday.Format("2006-01-02"))
+	if err != nil {
+		log.Error("Failed to fetch user worklogs", "error", err)
+		return nil, fmt.Errorf("failed to fetch user worklogs: %w", err)
+	}
+
+	return buildTimesheet(user, monday, workItems), nil
+}
+
+// buildTimesheet groups work items into a grid of issues by days of the week starting on monday
+func buildTimesheet(user *youtrack.User, monday time.Time, workItems []*youtrack.WorkItem) *Timesheet {
+	year, week := monday.ISOWeek()
+	sheet := &Timesheet{
+		User: user,
+		Week: fmt.Sprintf("%d-W%02d", year, week),
+	}
+
+	dayIndex := make(map[string]int, 7)
+	for i := 0; i < 7; i++ {
+		date := monday.AddDate(0, 0, i).Format("2006-01-02")
+		dayIndex[date] = i
+		sheet.Days = append(sheet.Days, &TimesheetDay{Date: date})
+	}
+
+	issues := make(map[string]*TimesheetIssue)
+	for _, item := range workItems {
+		// Work item dates are day timestamps at midnight UTC
+		i, ok := dayIndex[item.Date.UTC().Format("2006-01-02")]
+		if !ok {
+			continue
+		}
+
+		id, summary := "", ""
+		if item.Issue != nil {
+			id, summary = item.Issue.ID, item.Issue.Summary
+		}
+		issue, ok := issues[id]
+		if !ok {
+			issue = &TimesheetIssue{ID: id, Summary: summary, Minutes: make([]int, 7)}
+			issues[id] = issue
+			sheet.Issues = append(sheet.Issues, issue)
+		}
+
+		minutes := item.Duration.Minutes
+		issue.Minutes[i] += minutes
+		issue.TotalMinutes += minutes
+		sheet.Days[i].Minutes += minutes
+		sheet.TotalMinutes += minutes
+	}
+
+	sort.Slice(sheet.Issues, func(i, j int) bool {
+		return sheet.Issues[i].ID < sheet.Issues[j].ID
+	})
+	return sheet
+}
+
+// parseISOWeek returns the Monday of an ISO week like "2025-W07", or of the week of now if empty
+func parseISOWeek(value string, now time.Time) (time.Time, error) {
+	if value == "" {
+		year, week := now.ISOWeek()
+		value = fmt.Sprintf("%d-W%02d", year, week)
+	}
+
+	yearPart, weekPart, ok := strings.Cut(strings.ToUpper(value), "-W")
+	year, yearErr := strconv.Atoi(yearPart)
+	week, weekErr := strconv.Atoi(weekPart)
+	if !ok || yearErr != nil || weekErr != nil || week < 1 || week > 53 {
+		return time.Time{}, fmt.Errorf("invalid week '%s' (use YYYY-Www, e.g. 2025-W07)", value)
+	}
+
+	// January 4th is always in week 1
+	jan4 := time.Date(year, time.January, 4, 0, 0, 0, 0, time.UTC)
+	monday := jan4.AddDate(0, 0, -((int(jan4.Weekday())+6)%7)+(week-1)*7)
+	if _, w := monday.ISOWeek(); w != week {
+		return time.Time{}, fmt.Errorf("invalid week '%s': %d has no week %d", value, year, week)
+	}
+	return monday, nil
+}
+
+// formatTimesheet renders the timesheet as a table of issues by days
+func formatTimesheet(sheet *Timesheet) error {
+	th := theme.Current()
+
+	name := sheet.User.FullName
+	if name == "" {
+		name = sheet.User.Login
+	}
+	fmt.Printf("%s\n\n", th.Header.Render(fmt.Sprintf("Timesheet of %s, week %s", name, sheet.Week)))
+
+	if len(sheet.Issues) == 0 {
+		fmt.Println("No worklogs found")
+		return nil
+	}
+
+	headers := []string{"ISSUE"}
+	for _, day := range sheet.Days {
+		date, _ := time.Parse("2006-01-02", day.Date)
+		headers = append(headers, date.Format("Mon 02"))
+	}
+	headers = append(headers, "TOTAL")
+
+	lastRow := len(sheet.Issues)
+	t := th.Table(headers...).
+		Flex(0).
+		Style(func(row, col int) lipgloss.Style {
+			if row == lastRow {
+				return th.Header
+			}
+			return th.Cell
+		})
+
+	for _, issue := range sheet.Issues {
+		label := issue.ID
+		if label == "" {
+			label = "(no issue)"
+		}
+		if issue.Summary != "" {
+			label += " " + issue.Summary
+		}
+
+		row := []string{label}
+		for _, minutes := range issue.Minutes {
+			row = append(row, timesheetCell(minutes))
+		}
+		row = append(row, formatDuration(issue.TotalMinutes))
+		t.Row(row...)
+	}
+
+	totals := []string{"TOTAL"}
+	for _, day := range sheet.Days {
+		totals = append(totals, timesheetCell(day.Minutes))
+	}
+	totals = append(totals, formatDuration(sheet.TotalMinutes))
+	t.Row(totals...)
+
+	fmt.Println(t)
+	return nil
+}
+
+// timesheetCell formats a grid cell, leaving days without work empty
+func timesheetCell(minutes int) string {
+	if minutes == 0 {
+		return ""
+	}
+	return formatDuration(minutes)
+}
@@ -0,0 +1,494 @@
+package commands
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/log"
+	"github.com/spf13/cobra"
+
+	"github.com/mkozhukh/youtrack/internal/yt/cache"
+	"github.com/mkozhukh/youtrack/internal/yt/config"
+	"github.com/mkozhukh/youtrack/internal/yt/projects"
+	"github.com/mkozhukh/youtrack/internal/yt/theme"
+	"github.com/mkozhukh/youtrack/pkg/youtrack"
+)
+
+// Formats of imported timesheets
+const (
+	importFormatToggl   = "toggl"
+	importFormatGeneric = "generic"
+)
+
+// Statuses of imported rows
+const (
+	importNew       = "new"
+	importCreated   = "created"
+	importDuplicate = "duplicate"
+	importUnmapped  = "unmapped"
+	importInvalid   = "invalid"
+	importNotFound  = "not found"
+	importFailed    = "failed"
+)
+
+var (
+	importFile    string
+	importFormat  string
+	importMapping string
+	importDryRun  bool
+)
+
+// ImportRow is a timesheet row with the issue it maps to and what the import did with it
+type ImportRow struct {
+	Line        int    `json:"line"`
+	Date        string `json:"date,omitempty"`
+	Minutes     int    `json:"minutes"`
+	Issue       string `json:"issue,omitempty"`
+	Description string `json:"description,omitempty"`
+	Type        string `json:"type,omitempty"`
+	Status      string `json:"status"`
+	Reason      string `json:"reason,omitempty"`
+	WorkItemID  string `json:"workItemId,omitempty"`
+
+	// keys are the texts matched against the mapping file: task, project, description
+	keys []string
+}
+
+// ImportReport is the reconciliation of a timesheet with the work logged in YouTrack
+type ImportReport struct {
+	File   string         `json:"file"`
+	Format string         `json:"format"`
+	DryRun bool           `json:"dryRun"`
+	Rows   []*ImportRow   `json:"rows"`
+	Counts map[string]int `json:"counts"`
+	// Minutes is the time of the rows created, or to be created on a dry run
+	Minutes int `json:"minutes"`
+}
+
+// IssueIDs returns the issues work was logged on, or would be on a dry run, for --output ids
+func (r *ImportReport) IssueIDs() []string {
+	var ids []string
+	seen := make(map[string]bool)
+	for _, row := range r.Rows {
+		if (row.Status == importCreated || row.Status == importNew) && !seen[row.Issue] {
+			seen[row.Issue] = true
+			ids = append(ids, row.Issue)
+		}
+	}
+	return ids
+}
+
+// importWorklogsCmd represents the worklogs import command
+var importWorklogsCmd = &cobra.Command{
+	Use:   "import",
+	Short: "Imports work items from a Toggl or CSV timesheet",
+	Long: `Creates work items from the rows of a CSV timesheet, as exported by Toggl (--format toggl)
+or with the columns date, duration, issue, description, type and project (--format generic,
+the header names them, in any order; only date and duration are required).
+
+A row is logged on the issue of its issue column, else on the first issue ID found in its
+description, else on the issue the mapping file gives for its task, project or description.
+The mapping file is a CSV of "name,issue" lines, names are compared case-insensitively.
+
+Rows already logged by you on the same issue, day and duration are reported as duplicates
+and skipped, so a timesheet can be imported again after it was extended. With --dry-run,
+the reconciliation report is printed without creating anything.`,
+	Example: `  yt worklogs import --file timesheet.csv --format toggl --dry-run
+  yt worklogs import --file timesheet.csv --format toggl --mapping projects.csv
+  yt worklogs import --file hours.csv`,
+	Args: cobra.NoArgs,
+	RunE: importWorklogs,
+}
+
+func init() {
+	worklogsCmd.AddCommand(importWorklogsCmd)
+
+	importWorklogsCmd.Flags().StringVarP(&importFile, "file", "f", "", "CSV file to import, - for standard input (required)")
+	importWorklogsCmd.Flags().StringVar(&importFormat, "format", importFormatGeneric, "Format of the file: toggl or generic")
+	importWorklogsCmd.Flags().StringVarP(&importMapping, "mapping", "m", "", "CSV file mapping task, project or description names to issues")
+	importWorklogsCmd.Flags().BoolVar(&importDryRun, "dry-run", false, "Print the reconciliation report without creating work items")
+	importWorklogsCmd.MarkFlagRequired("file")
+}
+
+func importWorklogs(cmd *cobra.Command, args []string) error {
+	if importFormat != importFormatToggl && importFormat != importFormatGeneric {
+		return fmt.Errorf("invalid format '%s' (use toggl or generic)", importFormat)
+	}
+
+	rows, err := readTimesheet(importFile, importFormat)
+	if err != nil {
+		return err
+	}
+
+	var mapping map[string]string
+	if importMapping != "" {
+		if mapping, err = readImportMapping(importMapping); err != nil {
+			return err
+		}
+	}
+
+	// Load configuration
+	cfg, err := config.Load(cfgFile, cmd.Flags())
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+
+	// Validate configuration
+	if err := cfg.Validate(); err != nil {
+		return fmt.Errorf("invalid configuration: %w", err)
+	}
+
+	// Create client and context
+	client := cfg.NewClient()
+	ctx := youtrack.NewYouTrackContext(context.Background(), cfg.Server.Token)
+
+	// Issue IDs in descriptions are matched against the project short names
+	var prefixes []string
+	if list, err := projects.List(cache.Open(cfg), client, ctx); err != nil {
+		log.Warn("Failed to list projects, only upper-case issue IDs are picked", "error", err)
+	} else {
+		for _, project := range list {
+			prefixes = append(prefixes, project.ShortName)
+		}
+	}
+
+	for _, row := range rows {
+		if row.Status == "" {
+			mapImportRow(row, cfg, mapping, prefixes)
+		}
+	}
+
+	me, err := client.GetCurrentUser(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get current user: %w", err)
+	}
+	if err := reconcileImport(client, ctx, me, rows); err != nil {
+		return err
+	}
+
+	report := &ImportReport{File: importFile, Format: importFormat, DryRun: importDryRun, Rows: rows}
+	if !importDryRun {
+		for _, row := range rows {
+			if row.Status != importNew {
+				continue
+			}
+			date, _ := time.Parse("2006-01-02", row.Date)
+			dateMs := date.UnixMilli()
+			req := &youtrack.CreateWorklogRequest{
+				Duration:    youtrack.DurationValue{Minutes: row.Minutes},
+				Description: row.Description,
+				Date:        &dateMs,
+			}
+			if row.Type != "" {
+				req.Type = &youtrack.WorkTypeRequest{Name: row.Type}
+			}
+
+			log.Info("Adding worklog", "line", row.Line, "issue", row.Issue, "date", row.Date, "minutes", row.Minutes)
+			worklog, err := client.AddIssueWorklog(ctx, row.Issue, req)
+			if err != nil {
+				row.Status, row.Reason = importFailed, youtrack.TranslateError(err).Error()
+				continue
+			}
+			row.Status, row.WorkItemID = importCreated, worklog.ID
+		}
+	}
+
+	report.Counts = make(map[string]int)
+	for _, row := range rows {
+		report.Counts[row.Status]++
+		if row.Status == importCreated || row.Status == importNew {
+			report.Minutes += row.Minutes
+		}
+	}
+
+	if err := outputResult(report, formatImportReport); err != nil {
+		return err
+	}
+	if report.Counts[importFailed] > 0 {
+		return fmt.Errorf("%d work item(s) failed to be created", report.Counts[importFailed])
+	}
+	return nil
+}
+
+// readTimesheet reads the rows of a timesheet; rows that cannot be parsed are marked invalid
+func readTimesheet(path, format string) ([]*ImportRow, error) {
+	var r io.Reader = os.Stdin
+	if path != "-" {
+		f, err := os.Open(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open the timesheet: %w", err)
+		}
+		defer f.Close()
+		r = f
+	}
+
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the timesheet header: %w", err)
+	}
+
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		// Toggl writes a byte order mark before the first column
+		name = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))
+		columns[name] = i
+	}
+
+	var required []string
+	if format == importFormatToggl {
+		required = []string{"start date", "duration"}
+	} else {
+		required = []string{"date", "duration"}
+	}
+	for _, name := range required {
+		if _, ok := columns[name]; !ok {
+			return nil, fmt.Errorf("the timesheet has no '%s' column, is it a %s file?", name, format)
+		}
+	}
+
+	var rows []*ImportRow
+	line := 1
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		line++
+		if err != nil {
+			return nil, fmt.Errorf("failed to read the timesheet: %w", err)
+		}
+		field := func(name string) string {
+			if i, ok := columns[name]; ok && i < len(record) {
+				return strings.TrimSpace(record[i])
+			}
+			return ""
+		}
+
+		row := &ImportRow{Line: line, Description: field("description")}
+		var date, duration string
+		if format == importFormatToggl {
+			date, duration = field("start date"), field("duration")
+			row.keys = []string{field("task"), field("project"), row.Description}
+		} else {
+			date, duration = field("date"), field("duration")
+			row.Issue, row.Type = field("issue"), field("type")
+			row.keys = []string{field("project"), row.Description}
+		}
+
+		if parsed, err := time.Parse("2006-01-02", date); err == nil {
+			row.Date = parsed.Format("2006-01-02")
+		}
+		if minutes, err := parseImportDuration(duration); err != nil {
+			row.Status, row.Reason = importInvalid, err.Error()
+		} else if row.Minutes = minutes; minutes == 0 {
+			row.Status, row.Reason = importInvalid, "shorter than a minute"
+		}
+		if row.Date == "" {
+			row.Status, row.Reason = importInvalid, fmt.Sprintf("invalid date '%s'", date)
+		}
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+// parseImportDuration parses a clock duration like "1:30" or "01:30:00", rounded to the
+// nearest minute, or a duration like "1h 30m"
+func parseImportDuration(value string) (int, error) {
+	if !strings.Contains(value, ":") {
+		minutes, err := youtrack.ParseDuration(value)
+		if err != nil {
+			return 0, fmt.Errorf("invalid duration '%s'", value)
+		}
+		return minutes, nil
+	}
+
+	parts := strings.Split(value, ":")
+	if len(parts) > 3 {
+		return 0, fmt.Errorf("invalid duration '%s'", value)
+	}
+	seconds := 0
+	for i, part := range parts {
+		n, err := strconv.Atoi(part)
+		if err != nil || n < 0 {
+			return 0, fmt.Errorf("invalid duration '%s'", value)
+		}
+		seconds += n * []int{3600, 60, 1}[i]
+	}
+	return int(math.Round(float64(seconds) / 60)), nil
+}
+
+// readImportMapping reads the "name,issue" lines of a mapping file, keyed by lower-case name
+func readImportMapping(path string) (map[string]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open the mapping file: %w", err)
+	}
+	defer f.Close()
+
+	reader := csv.NewReader(f)
+	reader.FieldsPerRecord = 2
+	reader.TrimLeadingSpace = true
+	reader.Comment = '#'
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the mapping file: %w", err)
+	}
+
+	mapping := make(map[string]string, len(records))
+	for _, record := range records {
+		mapping[strings.ToLower(strings.TrimSpace(record[0]))] = strings.TrimSpace(record[1])
+	}
+	return mapping, nil
+}
+
+// mapImportRow sets the issue of a row: its issue column, the first issue ID of its description,
+// or the issue the mapping gives for one of its keys
+func mapImportRow(row *ImportRow, cfg *config.Config, mapping map[string]string, prefixes []string) {
+	if row.Issue == "" {
+		if ids := youtrack.ExtractIssueIDs(row.Description, prefixes); len(ids) > 0 {
+			row.Issue = ids[0]
+		}
+	}
+	if row.Issue == "" {
+		for _, key := range row.keys {
+			if issue, ok := mapping[strings.ToLower(key)]; ok && key != "" {
+				row.Issue = issue
+				break
+			}
+		}
+	}
+	if row.Issue == "" {
+		row.Status, row.Reason = importUnmapped, "no issue ID in the row and no mapping"
+		return
+	}
+
+	issue, err := projects.IssueID(cfg, row.Issue)
+	if err != nil {
+		row.Status, row.Reason = importInvalid, err.Error()
+		return
+	}
+	row.Issue = strings.ToUpper(issue)
+}
+
+// reconcileImport marks the mapped rows as new, duplicate (the user already logged the same
+// time on the issue that day) or not found, fetching the work items of each issue once
+func reconcileImport(client *youtrack.Client, ctx *youtrack.YouTrackContext, me *youtrack.User, rows []*ImportRow) error {
+	logged := make(map[string]map[string]int)
+	for _, row := range rows {
+		if row.Status != "" {
+			continue
+		}
+
+		existing, ok := logged[row.Issue]
+		if !ok {
+			log.Info("Fetching worklogs", "issue", row.Issue)
+			worklogs, err := client.GetIssueWorklogs(ctx, row.Issue)
+			var apiErr *youtrack.APIError
+			if errors.As(err, &apiErr) && apiErr.StatusCode == 404 {
+				existing = nil
+			} else if err != nil {
+				return fmt.Errorf("failed to fetch the worklogs of %s: %w", row.Issue, err)
+			} else {
+				// Work items keyed by day and duration, counted to match repeated rows one to one
+				existing = make(map[string]int)
+				for _, worklog := range worklogs {
+					if worklog.Author == nil || worklog.Author.ID != me.ID {
+						continue
+					}
+					existing[importKey(worklog.Date.UTC().Format("2006-01-02"), worklog.Duration.Minutes)]++
+				}
+			}
+			logged[row.Issue] = existing
+		}
+
+		key := importKey(row.Date, row.Minutes)
+		switch {
+		case existing == nil:
+			row.Status = importNotFound
+		case existing[key] > 0:
+			existing[key]--
+			row.Status, row.Reason = importDuplicate, "already logged"
+		default:
+			row.Status = importNew
+		}
+	}
+	return nil
+}
+
+// importKey identifies work items of the same day and duration
+func importKey(date string, minutes int) string {
+	return fmt.Sprintf("%s/%d", date, minutes)
+}
+
+func formatImportReport(data interface{}) error {
+	report := data.(*ImportReport)
+	if len(report.Rows) == 0 {
+		fmt.Println("The timesheet has no rows.")
+		return nil
+	}
+
+	th := theme.Current()
+	statusStyles := map[string]lipgloss.Style{
+		importNew:       th.OK,
+		importCreated:   th.OK,
+		importDuplicate: th.Cell,
+		importUnmapped:  th.Warn,
+		importInvalid:   th.Warn,
+		importNotFound:  th.Warn,
+		importFailed:    th.Error,
+	}
+
+	t := th.Table("LINE", "DATE", "DURATION", "ISSUE", "DESCRIPTION", "STATUS").
+		Flex(4).
+		Style(func(row, col int) lipgloss.Style {
+			if col == 5 {
+				return statusStyles[report.Rows[row].Status]
+			}
+			return th.Cell
+		})
+
+	for _, row := range report.Rows {
+		status := row.Status
+		if row.Reason != "" && row.Status != importDuplicate {
+			status += ": " + row.Reason
+		}
+		issue := row.Issue
+		if issue == "" {
+			issue = "-"
+		}
+		t.Row(strconv.Itoa(row.Line), row.Date, formatDuration(row.Minutes), issue, row.Description, status)
+	}
+	fmt.Println(t)
+
+	var parts []string
+	for _, status := range []string{importCreated, importNew, importDuplicate, importUnmapped, importNotFound, importInvalid, importFailed} {
+		if n := report.Counts[status]; n > 0 {
+			label := status
+			if status == importNew {
+				label = "to create"
+			}
+			parts = append(parts, fmt.Sprintf("%d %s", n, label))
+		}
+	}
+	logged := "logged"
+	if report.DryRun {
+		logged = "to log"
+	}
+	fmt.Printf("\n%d row(s): %s; %s %s\n", len(report.Rows), strings.Join(parts, ", "), formatDuration(report.Minutes), logged)
+	if report.DryRun && report.Counts[importNew] > 0 {
+		fmt.Println("Dry run, nothing was created. Run without --dry-run to create the work items.")
+	}
+	return nil
+}
@@ -0,0 +1,29 @@
+package config
+
+import (
+	"time"
+
+	"github.com/charmbracelet/log"
+
+	"github.com/mkozhukh/youtrack/pkg/youtrack"
+)
+
+// NewClient creates a client for the configured server, with the hub URL when set.
+// Each HTTP request is logged at debug level, shown with yt --trace.
+func (c *Config) NewClient() *youtrack.Client {
+	client := youtrack.NewClient(c.Server.URL)
+	if c.Server.HubURL != "" {
+		client.SetHubURL(c.Server.HubURL)
+	}
+	client.SetTraceHook(traceRequest)
+	return client
+}
+
+// traceRequest logs an HTTP request line with the response status
+func traceRequest(method, url string, status int, duration time.Duration, err error) {
+	if err != nil {
+		log.Debug("HTTP "+method+" "+url, "error", err, "duration", duration)
+		return
+	}
+	log.Debug("HTTP "+method+" "+url, "status", status, "duration", duration)
+}
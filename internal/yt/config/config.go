@@ -18,6 +18,19 @@ import (
 type Config struct {
 	Server   ServerConfig   `koanf:"server"`
 	Defaults DefaultsConfig `koanf:"defaults"`
+	Cache    CacheConfig    `koanf:"cache"`
+	Workflow WorkflowConfig `koanf:"workflow"`
+	Timer    TimerConfig    `koanf:"timer"`
+	Worklogs WorklogsConfig `koanf:"worklogs"`
+	SLA      SLAConfig      `koanf:"sla"`
+	Offline  OfflineConfig  `koanf:"offline"`
+	Git      GitConfig      `koanf:"git"`
+	Budget   BudgetConfig   `koanf:"budget"`
+	Output   OutputConfig   `koanf:"output"`
+	// Projects holds per-project settings by project short name, [project.PRJ] sections
+	Projects map[string]ProjectConfig `koanf:"project"`
+	// Aliases maps shortcut names to yt command lines, e.g. mine = "tickets list -u me"
+	Aliases map[string]string `koanf:"aliases"`
 }
 
 // ServerConfig holds server-related configuration
@@ -33,6 +46,186 @@ type DefaultsConfig struct {
 	UserID  string `koanf:"user_id"`
 }
 
+// ProjectConfig holds the settings of one project
+type ProjectConfig struct {
+	Defaults ProjectDefaults `koanf:"defaults"`
+	Budget   ProjectBudget   `koanf:"budget"`
+}
+
+// ProjectDefaults holds the values `yt tickets create` sets on new tickets of a project,
+// unless given on the command line
+type ProjectDefaults struct {
+	Type     string   `koanf:"type"`
+	Priority string   `koanf:"priority"`
+	Assignee string   `koanf:"assignee"`
+	Tags     []string `koanf:"tags"`
+}
+
+// IsEmpty reports whether no default is set
+func (d ProjectDefaults) IsEmpty() bool {
+	return d.Type == "" && d.Priority == "" && d.Assignee == "" && len(d.Tags) == 0
+}
+
+// ProjectBudget holds the budget `yt report budget` compares the cost of a project with
+type ProjectBudget struct {
+	// Amount is the budget, in the currency of the [budget] section
+	Amount float64 `koanf:"amount"`
+	// Start is the first day the budget covers, YYYY-MM-DD, the default of --since
+	Start string `koanf:"start"`
+	// Rate is the hourly rate of the project, overriding the global one
+	Rate float64 `koanf:"rate"`
+	// Rates are hourly rates by user login in the project, overriding all others
+	Rates map[string]float64 `koanf:"rates"`
+	// WarnAt overrides the global warning thresholds
+	WarnAt []float64 `koanf:"warn_at"`
+}
+
+// IsEmpty reports whether no budget setting is set
+func (b ProjectBudget) IsEmpty() bool {
+	return b.Amount == 0 && b.Start == "" && b.Rate == 0 && len(b.Rates) == 0 && len(b.WarnAt) == 0
+}
+
+// CacheConfig holds local metadata cache settings
+type CacheConfig struct {
+	Dir        string `koanf:"dir"`
+	TTLSeconds int    `koanf:"ttl_seconds"`
+	// Encrypt stores cache entries, issue indexes and the offline journal encrypted with AES-GCM
+	Encrypt bool `koanf:"encrypt"`
+	// KeyFile holds the encryption key, generated on first use (defaults to ~/.config/yt/cache.key)
+	KeyFile string `koanf:"key_file"`
+	// Key is the encryption secret, set through YT_CACHE_KEY (e.g. from the OS keyring); it
+	// takes precedence over the key file and is never saved
+	Key string `koanf:"key"`
+}
+
+// WorkflowConfig holds issue states used by workflow helpers
+type WorkflowConfig struct {
+	// ReviewState is the state set when a pull request is linked (e.g. "In Review")
+	ReviewState string `koanf:"review_state"`
+	// ReviewStates overrides ReviewState per project short name
+	ReviewStates map[string]string `koanf:"review_states"`
+}
+
+// TimerConfig holds the rules `yt timer stop` applies to the elapsed time
+type TimerConfig struct {
+	// MaxSessionMinutes caps the time logged by one session; 0 uses 8 hours, -1 disables the cap
+	MaxSessionMinutes int `koanf:"max_session_minutes"`
+	// RoundMinutes rounds the logged time to the nearest multiple (e.g. 5 or 15); 0 logs whole minutes
+	RoundMinutes int `koanf:"round_minutes"`
+}
+
+// WorklogsConfig holds the timesheet settings of `yt worklogs`
+type WorklogsConfig struct {
+	// DailyTargetMinutes is the time expected to be logged per workday; 0 uses 8 hours
+	DailyTargetMinutes int `koanf:"daily_target_minutes"`
+}
+
+// OfflineConfig holds the settings of the offline queue replayed by `yt sync`
+type OfflineConfig struct {
+	// Enabled queues creates, updates, comments and worklogs when YouTrack is unreachable
+	Enabled bool `koanf:"enabled"`
+	// Journal is the file holding the queued changes; empty uses ~/.config/yt/journal.json
+	Journal string `koanf:"journal"`
+}
+
+// GitConfig holds the naming rules of `yt git branch` and the checks of the commit-msg hook
+type GitConfig struct {
+	// BranchTemplate is the Go template of branch names; empty uses "feature/{{.ID}}-{{.Slug}}"
+	BranchTemplate string `koanf:"branch_template"`
+	// SlugLength caps the length of the slugified summary; 0 uses 40
+	SlugLength int `koanf:"slug_length"`
+	// CommitTemplate is the Go template of a subject line the ticket ID is injected into;
+	// empty uses "{{.ID}} {{.Subject}}"
+	CommitTemplate string `koanf:"commit_template"`
+	// RequireTicket rejects commits without a ticket ID in the message or the branch name
+	RequireTicket bool `koanf:"require_ticket"`
+}
+
+// BudgetConfig holds the hourly rates `yt report budget` prices logged time with
+type BudgetConfig struct {
+	// Currency labels the amounts, e.g. "EUR"
+	Currency string `koanf:"currency"`
+	// Rate is the hourly rate of users without a rate of their own
+	Rate float64 `koanf:"rate"`
+	// Rates are hourly rates by user login
+	Rates map[string]float64 `koanf:"rates"`
+	// WarnAt are the percentages of a budget past which the report warns; empty uses 80
+	WarnAt []float64 `koanf:"warn_at"`
+}
+
+// OutputConfig holds the settings of the text output
+type OutputConfig struct {
+	// Theme names the color theme: dark, light or plain; empty uses dark, or plain when
+	// NO_COLOR is set
+	Theme string `koanf:"theme"`
+}
+
+// SLAConfig holds the SLA policy checked by `yt report sla`: default targets, overridden per priority
+type SLAConfig struct {
+	// PriorityField is the custom field holding the priority; empty uses "Priority"
+	PriorityField string `koanf:"priority_field"`
+	SLATarget     `koanf:",squash"`
+	// Priorities overrides the default targets by priority name (e.g. "Critical")
+	Priorities map[string]SLATarget `koanf:"priorities"`
+}
+
+// SLATarget holds the hours allowed to respond to and to resolve an issue; 0 means no target
+type SLATarget struct {
+	FirstResponseHours float64 `koanf:"first_response_hours"`
+	ResolutionHours    float64 `koanf:"resolution_hours"`
+}
+
+// ReviewStateFor returns the review state for a project, or "" when none is configured
+func (w WorkflowConfig) ReviewStateFor(projectID string) string {
+	for project, state := range w.ReviewStates {
+		if strings.EqualFold(project, projectID) {
+			return state
+		}
+	}
+	return w.ReviewState
+}
+
+// ProjectDefaults returns the ticket defaults of a project, matched case-insensitively by short name
+func (c *Config) ProjectDefaults(projectID string) ProjectDefaults {
+	for project, settings := range c.Projects {
+		if strings.EqualFold(project, projectID) {
+			return settings.Defaults
+		}
+	}
+	return ProjectDefaults{}
+}
+
+// ProjectBudget returns the budget settings of a project, matched case-insensitively by short name
+func (c *Config) ProjectBudget(projectID string) ProjectBudget {
+	for project, settings := range c.Projects {
+		if strings.EqualFold(project, projectID) {
+			return settings.Budget
+		}
+	}
+	return ProjectBudget{}
+}
+
+// HourlyRate returns the rate of a user's time in a project: the user's rate in the project,
+// the user's global rate, the project rate, then the global rate. It reports false when
+// none is configured.
+func (c *Config) HourlyRate(projectID, login string) (float64, bool) {
+	project := c.ProjectBudget(projectID)
+	for _, rates := range []map[string]float64{project.Rates, c.Budget.Rates} {
+		for name, rate := range rates {
+			if strings.EqualFold(name, login) {
+				return rate, true
+			}
+		}
+	}
+	if project.Rate != 0 {
+		return project.Rate, true
+	}
+	if c.Budget.Rate != 0 {
+		return c.Budget.Rate, true
+	}
+	return 0, false
+}
+
 // Global instance for the configuration
 var k = koanf.New(".")
 
@@ -124,7 +317,7 @@ func Save(cfg *Config, configPath string) error {
 	}
 
 	// Marshal the config to TOML
-	data, err := toml.Parser().Marshal(map[string]interface{}{
+	values := map[string]interface{}{
 		"server": map[string]interface{}{
 			"url":     cfg.Server.URL,
 			"token":   cfg.Server.Token,
@@ -134,7 +327,105 @@ func Save(cfg *Config, configPath string) error {
 			"project": cfg.Defaults.Project,
 			"user_id": cfg.Defaults.UserID,
 		},
-	})
+	}
+	if cfg.Workflow.ReviewState != "" || len(cfg.Workflow.ReviewStates) > 0 {
+		values["workflow"] = map[string]interface{}{
+			"review_state":  cfg.Workflow.ReviewState,
+			"review_states": cfg.Workflow.ReviewStates,
+		}
+	}
+	if cfg.Cache.Dir != "" || cfg.Cache.TTLSeconds != 0 || cfg.Cache.Encrypt || cfg.Cache.KeyFile != "" {
+		values["cache"] = map[string]interface{}{
+			"dir":         cfg.Cache.Dir,
+			"ttl_seconds": cfg.Cache.TTLSeconds,
+			"encrypt":     cfg.Cache.Encrypt,
+			"key_file":    cfg.Cache.KeyFile,
+		}
+	}
+	if cfg.Timer.MaxSessionMinutes != 0 || cfg.Timer.RoundMinutes != 0 {
+		values["timer"] = map[string]interface{}{
+			"max_session_minutes": cfg.Timer.MaxSessionMinutes,
+			"round_minutes":       cfg.Timer.RoundMinutes,
+		}
+	}
+	if cfg.Worklogs.DailyTargetMinutes != 0 {
+		values["worklogs"] = map[string]interface{}{
+			"daily_target_minutes": cfg.Worklogs.DailyTargetMinutes,
+		}
+	}
+	if cfg.SLA.PriorityField != "" || cfg.SLA.SLATarget != (SLATarget{}) || len(cfg.SLA.Priorities) > 0 {
+		priorities := make(map[string]interface{}, len(cfg.SLA.Priorities))
+		for name, target := range cfg.SLA.Priorities {
+			priorities[name] = map[string]interface{}{
+				"first_response_hours": target.FirstResponseHours,
+				"resolution_hours":     target.ResolutionHours,
+			}
+		}
+		values["sla"] = map[string]interface{}{
+			"priority_field":       cfg.SLA.PriorityField,
+			"first_response_hours": cfg.SLA.FirstResponseHours,
+			"resolution_hours":     cfg.SLA.ResolutionHours,
+			"priorities":           priorities,
+		}
+	}
+	if cfg.Offline.Enabled || cfg.Offline.Journal != "" {
+		values["offline"] = map[string]interface{}{
+			"enabled": cfg.Offline.Enabled,
+			"journal": cfg.Offline.Journal,
+		}
+	}
+	if cfg.Git != (GitConfig{}) {
+		values["git"] = map[string]interface{}{
+			"branch_template": cfg.Git.BranchTemplate,
+			"slug_length":     cfg.Git.SlugLength,
+			"commit_template": cfg.Git.CommitTemplate,
+			"require_ticket":  cfg.Git.RequireTicket,
+		}
+	}
+	if cfg.Budget.Currency != "" || cfg.Budget.Rate != 0 || len(cfg.Budget.Rates) > 0 || len(cfg.Budget.WarnAt) > 0 {
+		values["budget"] = map[string]interface{}{
+			"currency": cfg.Budget.Currency,
+			"rate":     cfg.Budget.Rate,
+			"rates":    cfg.Budget.Rates,
+			"warn_at":  cfg.Budget.WarnAt,
+		}
+	}
+	if cfg.Output.Theme != "" {
+		values["output"] = map[string]interface{}{
+			"theme": cfg.Output.Theme,
+		}
+	}
+	if len(cfg.Projects) > 0 {
+		projects := make(map[string]interface{}, len(cfg.Projects))
+		for name, project := range cfg.Projects {
+			settings := map[string]interface{}{}
+			if !project.Defaults.IsEmpty() {
+				settings["defaults"] = map[string]interface{}{
+					"type":     project.Defaults.Type,
+					"priority": project.Defaults.Priority,
+					"assignee": project.Defaults.Assignee,
+					"tags":     project.Defaults.Tags,
+				}
+			}
+			if !project.Budget.IsEmpty() {
+				settings["budget"] = map[string]interface{}{
+					"amount":  project.Budget.Amount,
+					"start":   project.Budget.Start,
+					"rate":    project.Budget.Rate,
+					"rates":   project.Budget.Rates,
+					"warn_at": project.Budget.WarnAt,
+				}
+			}
+			if len(settings) > 0 {
+				projects[name] = settings
+			}
+		}
+		values["project"] = projects
+	}
+	if len(cfg.Aliases) > 0 {
+		values["aliases"] = cfg.Aliases
+	}
+	data, err := toml.Parser().Marshal(values)
 	if err != nil {
 		return fmt.Errorf("failed to marshal config: %w", err)
 	}
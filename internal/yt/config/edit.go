@@ -0,0 +1,204 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/knadh/koanf/parsers/toml"
+	"github.com/knadh/koanf/providers/file"
+	"github.com/knadh/koanf/v2"
+)
+
+// secretKeys are the keys whose values are masked when shown
+var secretKeys = map[string]bool{"server.token": true, "cache.key": true}
+
+// IsSecret reports whether the value of a key is masked when shown
+func IsSecret(key string) bool {
+	return secretKeys[key]
+}
+
+// Get returns the effective value of a key after Load: from the file, the environment or flags
+func Get(key string) (interface{}, bool) {
+	if !k.Exists(key) {
+		return nil, false
+	}
+	return k.Get(key), true
+}
+
+// All returns the effective values after Load, by flattened key
+func All() map[string]interface{} {
+	return k.All()
+}
+
+// Keys returns the configuration keys, with <name> standing for the entries of map sections
+func Keys() []string {
+	var keys []string
+	collectKeys(reflect.TypeOf(Config{}), "", &keys)
+	sort.Strings(keys)
+	return keys
+}
+
+// collectKeys appends the keys of a config struct type, prefixed
+func collectKeys(t reflect.Type, prefix string, keys *[]string) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name, squash := koanfTag(field)
+		switch {
+		case squash:
+			collectKeys(field.Type, prefix, keys)
+		case field.Type.Kind() == reflect.Struct:
+			collectKeys(field.Type, prefix+name+".", keys)
+		case field.Type.Kind() == reflect.Map && field.Type.Elem().Kind() == reflect.Struct:
+			collectKeys(field.Type.Elem(), prefix+name+".<name>.", keys)
+		case field.Type.Kind() == reflect.Map:
+			*keys = append(*keys, prefix+name+".<name>")
+		default:
+			*keys = append(*keys, prefix+name)
+		}
+	}
+}
+
+// koanfTag returns the key of a struct field and whether its fields are squashed into the parent
+func koanfTag(field reflect.StructField) (string, bool) {
+	tag := field.Tag.Get("koanf")
+	name, opts, _ := strings.Cut(tag, ",")
+	return name, opts == "squash"
+}
+
+// keyKind returns the kind of the value of a key, or an error when the key is unknown
+func keyKind(key string) (reflect.Kind, error) {
+	t := reflect.TypeOf(Config{})
+	parts := strings.Split(key, ".")
+	for i := 0; i < len(parts); i++ {
+		switch t.Kind() {
+		case reflect.Struct:
+			field, ok := findField(t, parts[i])
+			if !ok {
+				return reflect.Invalid, fmt.Errorf("unknown configuration key '%s'", key)
+			}
+			t = field.Type
+		case reflect.Map:
+			// Any name selects a map entry
+			t = t.Elem()
+		default:
+			return reflect.Invalid, fmt.Errorf("unknown configuration key '%s'", key)
+		}
+	}
+	if t.Kind() == reflect.Struct || t.Kind() == reflect.Map {
+		return reflect.Invalid, fmt.Errorf("'%s' is a section, set one of its keys (see 'yt config get --keys')", key)
+	}
+	return t.Kind(), nil
+}
+
+// findField finds the field of a struct type by key, looking into squashed fields
+func findField(t reflect.Type, name string) (reflect.StructField, bool) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag, squash := koanfTag(field)
+		if squash {
+			if f, ok := findField(field.Type, name); ok {
+				return f, true
+			}
+			continue
+		}
+		if tag == name {
+			return field, true
+		}
+	}
+	return reflect.StructField{}, false
+}
+
+// parseValue converts a raw value to the kind of the key
+func parseValue(kind reflect.Kind, raw string) (interface{}, error) {
+	switch kind {
+	case reflect.Int, reflect.Int64:
+		return strconv.Atoi(raw)
+	case reflect.Float64:
+		return strconv.ParseFloat(raw, 64)
+	case reflect.Bool:
+		return strconv.ParseBool(raw)
+	case reflect.Slice:
+		// Lists are given comma-separated, e.g. project.PRJ.defaults.tags
+		var items []string
+		for _, item := range strings.Split(raw, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				items = append(items, item)
+			}
+		}
+		return items, nil
+	default:
+		return raw, nil
+	}
+}
+
+// SetValue sets a key in the configuration file, keeping the other settings.
+// The value is converted to the type of the key, e.g. a number for timer.round_minutes.
+func SetValue(configPath, key, raw string) error {
+	return SetValues(configPath, map[string]string{key: raw})
+}
+
+// SetValues sets several keys in the configuration file at once, like SetValue
+func SetValues(configPath string, values map[string]string) error {
+	if configPath == "" {
+		configPath = GetConfigPath()
+	}
+
+	// Only the file is edited, values from the environment must not end up in it
+	fk := koanf.New(".")
+	if _, err := os.Stat(configPath); err == nil {
+		if err := fk.Load(file.Provider(configPath), toml.Parser()); err != nil {
+			return fmt.Errorf("failed to load config file: %w", err)
+		}
+	}
+
+	for key, raw := range values {
+		kind, err := keyKind(key)
+		if err != nil {
+			return err
+		}
+		value, err := parseValue(kind, raw)
+		if err != nil {
+			return fmt.Errorf("invalid value for '%s': %w", key, err)
+		}
+		if err := fk.Set(key, value); err != nil {
+			return fmt.Errorf("failed to set '%s': %w", key, err)
+		}
+	}
+
+	data, err := fk.Marshal(toml.Parser())
+	if err != nil {
+		return fmt.Errorf("failed to marshal config: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(configPath), 0755); err != nil {
+		return fmt.Errorf("failed to create config directory: %w", err)
+	}
+	if err := os.WriteFile(configPath, data, 0600); err != nil {
+		return fmt.Errorf("failed to write config file: %w", err)
+	}
+	return nil
+}
+
+// LoadFile loads the configuration file alone, without the environment and flags.
+// A missing file gives an empty configuration.
+func LoadFile(configPath string) (*Config, error) {
+	if configPath == "" {
+		configPath = GetConfigPath()
+	}
+
+	var cfg Config
+	fk := koanf.New(".")
+	if _, err := os.Stat(configPath); err == nil {
+		if err := fk.Load(file.Provider(configPath), toml.Parser()); err != nil {
+			return nil, fmt.Errorf("failed to load config file: %w", err)
+		}
+	}
+	if err := fk.Unmarshal("", &cfg); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal config: %w", err)
+	}
+	return &cfg, nil
+}
@@ -0,0 +1,126 @@
+// Package extract prints selected fields of command results for scripts, with a Go template
+// (--template) or a JSONPath expression (--jsonpath), as an alternative to piping JSON to jq
+package extract
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/mkozhukh/youtrack/pkg/youtrack"
+)
+
+// Print prints data with a Go template or a JSONPath expression, whichever is set
+func Print(w io.Writer, tmpl, path string, data interface{}) error {
+	switch {
+	case tmpl != "" && path != "":
+		return fmt.Errorf("--template and --jsonpath cannot be used together")
+	case tmpl != "":
+		return Template(w, tmpl, data)
+	default:
+		return JSONPath(w, path, data)
+	}
+}
+
+// templateFuncs are the functions available in templates besides the built-in ones
+var templateFuncs = template.FuncMap{
+	"json": func(v interface{}) (string, error) {
+		data, err := json.Marshal(v)
+		return string(data), err
+	},
+	"join":  strings.Join,
+	"upper": strings.ToUpper,
+	"lower": strings.ToLower,
+	"date": func(layout string, v interface{}) string {
+		switch t := v.(type) {
+		case time.Time:
+			return t.Local().Format(layout)
+		case youtrack.YouTrackTime:
+			return t.Local().Format(layout)
+		case *youtrack.YouTrackTime:
+			if t == nil {
+				return ""
+			}
+			return t.Local().Format(layout)
+		default:
+			return fmt.Sprint(v)
+		}
+	},
+}
+
+// Template executes a Go template on the result, once per element for a list, e.g.
+// '{{.ID}} {{.Summary}}' on the tickets of `yt tickets list`. Fields are the Go field names
+// of the result. Each execution ends with a newline unless the template prints one.
+func Template(w io.Writer, text string, data interface{}) error {
+	t, err := template.New("output").Funcs(templateFuncs).Option("missingkey=zero").Parse(text)
+	if err != nil {
+		return fmt.Errorf("invalid --template: %w", err)
+	}
+
+	items := []interface{}{data}
+	if v := reflect.ValueOf(data); v.Kind() == reflect.Slice {
+		items = make([]interface{}, v.Len())
+		for i := range items {
+			items[i] = v.Index(i).Interface()
+		}
+	}
+
+	for _, item := range items {
+		var sb strings.Builder
+		if err := t.Execute(&sb, item); err != nil {
+			return fmt.Errorf("failed to execute --template: %w", err)
+		}
+		out := sb.String()
+		if !strings.HasSuffix(out, "\n") {
+			out += "\n"
+		}
+		if _, err := io.WriteString(w, out); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// JSONPath prints the values an expression selects in the JSON output of the result, one per
+// line: strings as they are, other values as compact JSON. The expression uses the JSON field
+// names, e.g. '$[*].idReadable' on the tickets of `yt tickets list`.
+func JSONPath(w io.Writer, expr string, data interface{}) error {
+	steps, err := parsePath(expr)
+	if err != nil {
+		return fmt.Errorf("invalid --jsonpath: %w", err)
+	}
+
+	// Select on the JSON form, so field names match the json output
+	raw, err := json.Marshal(data)
+	if err != nil {
+		return fmt.Errorf("failed to marshal the result: %w", err)
+	}
+	var root interface{}
+	if err := json.Unmarshal(raw, &root); err != nil {
+		return fmt.Errorf("failed to unmarshal the result: %w", err)
+	}
+
+	nodes := []interface{}{root}
+	for _, s := range steps {
+		nodes = s.apply(nodes)
+	}
+
+	for _, node := range nodes {
+		line, ok := node.(string)
+		if !ok {
+			data, err := json.Marshal(node)
+			if err != nil {
+				return err
+			}
+			line = string(data)
+		}
+		if _, err := fmt.Fprintln(w, line); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,449 @@
+package extract
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// step is one selector of a JSONPath expression, mapping the current nodes to the next ones
+type step interface {
+	apply(nodes []interface{}) []interface{}
+}
+
+// childStep selects object members by name
+type childStep struct {
+	names []string
+}
+
+// indexStep selects array elements by index, negative indexes count from the end
+type indexStep struct {
+	indexes []int
+}
+
+// sliceStep selects the array elements in [start:end)
+type sliceStep struct {
+	start, end       int
+	hasStart, hasEnd bool
+}
+
+// wildcardStep selects every member or element
+type wildcardStep struct{}
+
+// descendantStep applies a step to the nodes and all their descendants (..)
+type descendantStep struct {
+	inner step
+}
+
+// filterStep selects the members or elements for which a condition holds: [?(@.state == 'Open')]
+type filterStep struct {
+	path  []step
+	op    string // empty to test that the path exists
+	value interface{}
+}
+
+// parsePath parses the supported JSONPath subset: $, .name, ['name'], [n], [a:b], [*], .*, ..name
+// and [?(@.path op value)] filters with ==, !=, <, <=, >, >= and literal strings, numbers,
+// booleans or null. "$" may be omitted, and "$.[*]" is read as "$[*]".
+func parsePath(expr string) ([]step, error) {
+	expr = strings.TrimSpace(expr)
+	if expr == "" {
+		return nil, fmt.Errorf("empty expression")
+	}
+	rest := strings.TrimPrefix(expr, "$")
+	if rest != "" && rest[0] != '.' && rest[0] != '[' {
+		rest = "." + rest
+	}
+	steps, rest, err := parseSteps(rest, false)
+	if err != nil {
+		return nil, err
+	}
+	if rest != "" {
+		return nil, fmt.Errorf("unexpected %q", rest)
+	}
+	return steps, nil
+}
+
+// parseSteps parses selectors until the end of the expression, or a comparison operator or a
+// closing parenthesis inside a filter
+func parseSteps(rest string, inFilter bool) ([]step, string, error) {
+	var steps []step
+	for rest != "" {
+		if inFilter && (rest[0] == ' ' || rest[0] == ')' || strings.ContainsRune("=!<>", rune(rest[0]))) {
+			break
+		}
+
+		descendant := false
+		switch {
+		case strings.HasPrefix(rest, ".."):
+			descendant = true
+			rest = rest[2:]
+		case rest[0] == '.':
+			rest = rest[1:]
+		case rest[0] != '[':
+			return nil, rest, fmt.Errorf("expected '.' or '[' at %q", rest)
+		}
+
+		var s step
+		var err error
+		switch {
+		case rest == "":
+			return nil, rest, fmt.Errorf("expression ends with '.'")
+		case rest[0] == '[':
+			s, rest, err = parseBracket(rest)
+		case rest[0] == '*':
+			s, rest = wildcardStep{}, rest[1:]
+		default:
+			end := strings.IndexAny(rest, ".[ )=!<>")
+			if end < 0 {
+				end = len(rest)
+			}
+			if end == 0 {
+				return nil, rest, fmt.Errorf("expected a name at %q", rest)
+			}
+			s, rest = childStep{names: []string{rest[:end]}}, rest[end:]
+		}
+		if err != nil {
+			return nil, rest, err
+		}
+		if descendant {
+			s = descendantStep{inner: s}
+		}
+		steps = append(steps, s)
+	}
+	return steps, rest, nil
+}
+
+// parseBracket parses a [...] selector
+func parseBracket(rest string) (step, string, error) {
+	if strings.HasPrefix(rest, "[?(") {
+		return parseFilter(rest)
+	}
+
+	end := closingBracket(rest)
+	if end < 0 {
+		return nil, rest, fmt.Errorf("missing ']' in %q", rest)
+	}
+	body, rest := strings.TrimSpace(rest[1:end]), rest[end+1:]
+
+	switch {
+	case body == "*":
+		return wildcardStep{}, rest, nil
+	case strings.HasPrefix(body, "'") || strings.HasPrefix(body, `"`):
+		var names []string
+		for _, part := range splitList(body) {
+			name, err := unquote(part)
+			if err != nil {
+				return nil, rest, err
+			}
+			names = append(names, name)
+		}
+		return childStep{names: names}, rest, nil
+	case strings.Contains(body, ":"):
+		s := sliceStep{}
+		bounds := strings.SplitN(body, ":", 2)
+		for i, bound := range bounds {
+			bound = strings.TrimSpace(bound)
+			if bound == "" {
+				continue
+			}
+			n, err := strconv.Atoi(bound)
+			if err != nil {
+				return nil, rest, fmt.Errorf("invalid slice bound %q", bound)
+			}
+			if i == 0 {
+				s.start, s.hasStart = n, true
+			} else {
+				s.end, s.hasEnd = n, true
+			}
+		}
+		return s, rest, nil
+	default:
+		var indexes []int
+		for _, part := range splitList(body) {
+			n, err := strconv.Atoi(part)
+			if err != nil {
+				return nil, rest, fmt.Errorf("invalid index %q", part)
+			}
+			indexes = append(indexes, n)
+		}
+		return indexStep{indexes: indexes}, rest, nil
+	}
+}
+
+// parseFilter parses a [?(@.path op value)] selector
+func parseFilter(rest string) (step, string, error) {
+	rest = strings.TrimPrefix(rest, "[?(")
+	rest = strings.TrimLeft(rest, " ")
+	if !strings.HasPrefix(rest, "@") {
+		return nil, rest, fmt.Errorf("a filter must start with '@' at %q", rest)
+	}
+
+	path, rest, err := parseSteps(rest[1:], true)
+	if err != nil {
+		return nil, rest, err
+	}
+	f := filterStep{path: path}
+
+	rest = strings.TrimLeft(rest, " ")
+	for _, op := range []string{"==", "!=", "<=", ">=", "<", ">"} {
+		if strings.HasPrefix(rest, op) {
+			f.op = op
+			rest = strings.TrimLeft(rest[len(op):], " ")
+			break
+		}
+	}
+	if f.op != "" {
+		end := strings.Index(rest, ")]")
+		if end < 0 {
+			return nil, rest, fmt.Errorf("missing ')]' in filter")
+		}
+		literal := strings.TrimSpace(rest[:end])
+		rest = rest[end:]
+		if strings.HasPrefix(literal, "'") {
+			if f.value, err = unquote(literal); err != nil {
+				return nil, rest, err
+			}
+		} else if err := json.Unmarshal([]byte(literal), &f.value); err != nil {
+			return nil, rest, fmt.Errorf("invalid filter value %q", literal)
+		}
+	}
+
+	if !strings.HasPrefix(rest, ")]") {
+		return nil, rest, fmt.Errorf("missing ')]' in filter")
+	}
+	return f, rest[2:], nil
+}
+
+// closingBracket returns the index of the ']' closing the bracket at the start, skipping quotes
+func closingBracket(s string) int {
+	var quote byte
+	for i := 1; i < len(s); i++ {
+		switch {
+		case quote != 0:
+			if s[i] == quote {
+				quote = 0
+			}
+		case s[i] == '\'' || s[i] == '"':
+			quote = s[i]
+		case s[i] == ']':
+			return i
+		}
+	}
+	return -1
+}
+
+// splitList splits a comma-separated list of a bracket, keeping commas inside quotes
+func splitList(body string) []string {
+	var parts []string
+	var quote byte
+	start := 0
+	for i := 0; i < len(body); i++ {
+		switch {
+		case quote != 0:
+			if body[i] == quote {
+				quote = 0
+			}
+		case body[i] == '\'' || body[i] == '"':
+			quote = body[i]
+		case body[i] == ',':
+			parts = append(parts, strings.TrimSpace(body[start:i]))
+			start = i + 1
+		}
+	}
+	return append(parts, strings.TrimSpace(body[start:]))
+}
+
+// unquote removes the single or double quotes around a name or a string literal
+func unquote(s string) (string, error) {
+	if len(s) < 2 || (s[0] != '\'' && s[0] != '"') || s[len(s)-1] != s[0] {
+		return "", fmt.Errorf("invalid quoted string %s", s)
+	}
+	return s[1 : len(s)-1], nil
+}
+
+func (s childStep) apply(nodes []interface{}) []interface{} {
+	var out []interface{}
+	for _, node := range nodes {
+		if obj, ok := node.(map[string]interface{}); ok {
+			for _, name := range s.names {
+				if v, ok := obj[name]; ok {
+					out = append(out, v)
+				}
+			}
+		}
+	}
+	return out
+}
+
+func (s indexStep) apply(nodes []interface{}) []interface{} {
+	var out []interface{}
+	for _, node := range nodes {
+		if arr, ok := node.([]interface{}); ok {
+			for _, i := range s.indexes {
+				if i < 0 {
+					i += len(arr)
+				}
+				if i >= 0 && i < len(arr) {
+					out = append(out, arr[i])
+				}
+			}
+		}
+	}
+	return out
+}
+
+func (s sliceStep) apply(nodes []interface{}) []interface{} {
+	var out []interface{}
+	for _, node := range nodes {
+		arr, ok := node.([]interface{})
+		if !ok {
+			continue
+		}
+		start, end := 0, len(arr)
+		if s.hasStart {
+			start = s.start
+		}
+		if s.hasEnd {
+			end = s.end
+		}
+		if start < 0 {
+			start += len(arr)
+		}
+		if end < 0 {
+			end += len(arr)
+		}
+		start, end = max(0, start), min(len(arr), end)
+		if start < end {
+			out = append(out, arr[start:end]...)
+		}
+	}
+	return out
+}
+
+func (wildcardStep) apply(nodes []interface{}) []interface{} {
+	var out []interface{}
+	for _, node := range nodes {
+		out = append(out, children(node)...)
+	}
+	return out
+}
+
+func (s descendantStep) apply(nodes []interface{}) []interface{} {
+	var all []interface{}
+	var walk func(node interface{})
+	walk = func(node interface{}) {
+		all = append(all, node)
+		for _, child := range children(node) {
+			walk(child)
+		}
+	}
+	for _, node := range nodes {
+		walk(node)
+	}
+	return s.inner.apply(all)
+}
+
+func (s filterStep) apply(nodes []interface{}) []interface{} {
+	var out []interface{}
+	for _, node := range nodes {
+		for _, child := range children(node) {
+			if s.matches(child) {
+				out = append(out, child)
+			}
+		}
+	}
+	return out
+}
+
+// matches reports whether the filter holds for a node
+func (s filterStep) matches(node interface{}) bool {
+	values := []interface{}{node}
+	for _, p := range s.path {
+		values = p.apply(values)
+	}
+	if s.op == "" {
+		return len(values) > 0
+	}
+	for _, v := range values {
+		if compare(v, s.op, s.value) {
+			return true
+		}
+	}
+	return false
+}
+
+// compare compares a JSON value with a filter literal; numbers and strings are ordered
+func compare(a interface{}, op string, b interface{}) bool {
+	switch op {
+	case "==":
+		return equal(a, b)
+	case "!=":
+		return !equal(a, b)
+	}
+
+	var c int
+	switch av := a.(type) {
+	case float64:
+		bv, ok := b.(float64)
+		if !ok {
+			return false
+		}
+		switch {
+		case av < bv:
+			c = -1
+		case av > bv:
+			c = 1
+		}
+	case string:
+		bv, ok := b.(string)
+		if !ok {
+			return false
+		}
+		c = strings.Compare(av, bv)
+	default:
+		return false
+	}
+
+	switch op {
+	case "<":
+		return c < 0
+	case "<=":
+		return c <= 0
+	case ">":
+		return c > 0
+	default:
+		return c >= 0
+	}
+}
+
+// equal compares scalar JSON values
+func equal(a, b interface{}) bool {
+	switch a.(type) {
+	case map[string]interface{}, []interface{}:
+		return false
+	}
+	return a == b
+}
+
+// children returns the members of an object, sorted by name, or the elements of an array
+func children(node interface{}) []interface{} {
+	switch v := node.(type) {
+	case []interface{}:
+		return v
+	case map[string]interface{}:
+		names := make([]string, 0, len(v))
+		for name := range v {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		out := make([]interface{}, len(names))
+		for i, name := range names {
+			out[i] = v[name]
+		}
+		return out
+	}
+	return nil
+}
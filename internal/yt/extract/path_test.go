@@ -0,0 +1,114 @@
+package extract
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const pathDocument = `{
+	"total": 3,
+	"name with space": "x",
+	"issues": [
+		{"id": "PRJ-1", "state": "Open", "votes": 3, "tags": ["a", "b"], "assignee": {"login": "alice"}},
+		{"id": "PRJ-2", "state": "Fixed", "votes": 10, "tags": [], "assignee": null},
+		{"id": "PRJ-3", "state": "Open", "votes": 0, "tags": ["b"]}
+	]
+}`
+
+func TestJSONPath(t *testing.T) {
+	var data interface{}
+	if err := json.Unmarshal([]byte(pathDocument), &data); err != nil {
+		t.Fatalf("Failed to parse the document: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		expr     string
+		expected []string
+	}{
+		{name: "Child", expr: "$.total", expected: []string{"3"}},
+		{name: "Without root", expr: "total", expected: []string{"3"}},
+		{name: "Quoted name", expr: "$['name with space']", expected: []string{"x"}},
+		{name: "Quoted names", expr: `$.issues[0]['id', "state"]`, expected: []string{"PRJ-1", "Open"}},
+		{name: "Missing member", expr: "$.issues[0].summary", expected: nil},
+		{name: "Index", expr: "$.issues[1].id", expected: []string{"PRJ-2"}},
+		{name: "Negative index", expr: "$.issues[-1].id", expected: []string{"PRJ-3"}},
+		{name: "Index list", expr: "$.issues[0, 2].id", expected: []string{"PRJ-1", "PRJ-3"}},
+		{name: "Index out of range", expr: "$.issues[5].id", expected: nil},
+		{name: "Slice", expr: "$.issues[1:].id", expected: []string{"PRJ-2", "PRJ-3"}},
+		{name: "Slice end", expr: "$.issues[:2].id", expected: []string{"PRJ-1", "PRJ-2"}},
+		{name: "Negative slice", expr: "$.issues[-2:-1].id", expected: []string{"PRJ-2"}},
+		{name: "Empty slice", expr: "$.issues[2:1].id", expected: nil},
+		{name: "Wildcard", expr: "$.issues[*].id", expected: []string{"PRJ-1", "PRJ-2", "PRJ-3"}},
+		{name: "Dot wildcard", expr: "$.issues[0].assignee.*", expected: []string{"alice"}},
+		{name: "Dot before bracket", expr: "$.issues.[*].state", expected: []string{"Open", "Fixed", "Open"}},
+		{name: "Descendant", expr: "$..id", expected: []string{"PRJ-1", "PRJ-2", "PRJ-3"}},
+		{name: "Nested descendant", expr: "$.issues..login", expected: []string{"alice"}},
+		{name: "Filter equal", expr: "$.issues[?(@.state == 'Open')].id", expected: []string{"PRJ-1", "PRJ-3"}},
+		{name: "Filter not equal", expr: "$.issues[?(@.state != 'Open')].id", expected: []string{"PRJ-2"}},
+		{name: "Filter greater", expr: "$.issues[?(@.votes > 2)].id", expected: []string{"PRJ-1", "PRJ-2"}},
+		{name: "Filter less or equal", expr: "$.issues[?(@.votes<=3)].id", expected: []string{"PRJ-1", "PRJ-3"}},
+		{name: "Filter string order", expr: "$.issues[?(@.state < 'G')].id", expected: []string{"PRJ-2"}},
+		{name: "Filter type mismatch", expr: "$.issues[?(@.votes > '2')].id", expected: nil},
+		{name: "Filter exists", expr: "$.issues[?(@.assignee)].id", expected: []string{"PRJ-1", "PRJ-2"}},
+		{name: "Filter null", expr: "$.issues[?(@.assignee == null)].id", expected: []string{"PRJ-2"}},
+		{name: "Filter nested path", expr: `$.issues[?(@.assignee.login == "alice")].id`, expected: []string{"PRJ-1"}},
+		{name: "Filter any element", expr: "$.issues[?(@.tags[*] == 'b')].id", expected: []string{"PRJ-1", "PRJ-3"}},
+		{name: "Array as JSON", expr: "$.issues[0].tags", expected: []string{`["a","b"]`}},
+		{name: "Object as JSON", expr: "$.issues[0].assignee", expected: []string{`{"login":"alice"}`}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := JSONPath(&buf, tt.expr, data); err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			var lines []string
+			if out := strings.TrimSuffix(buf.String(), "\n"); out != "" {
+				lines = strings.Split(out, "\n")
+			}
+			if !reflect.DeepEqual(lines, tt.expected) {
+				t.Errorf("Expected %q, got %q", tt.expected, lines)
+			}
+		})
+	}
+}
+
+func TestJSONPath_Errors(t *testing.T) {
+	tests := []struct {
+		name     string
+		expr     string
+		expected string
+	}{
+		{name: "Empty", expr: " ", expected: "empty expression"},
+		{name: "Trailing dot", expr: "$.issues.", expected: "expression ends with '.'"},
+		{name: "Trailing descendant", expr: "$..", expected: "expression ends with '.'"},
+		{name: "Missing name", expr: "$.=", expected: "expected a name"},
+		{name: "Unclosed bracket", expr: "$.issues[0", expected: "missing ']'"},
+		{name: "Unclosed quote", expr: "$['id]", expected: "missing ']'"},
+		{name: "Unquoted name in list", expr: "$['id', state]", expected: "invalid quoted string"},
+		{name: "Invalid index", expr: "$.issues[first]", expected: "invalid index"},
+		{name: "Invalid slice bound", expr: "$.issues[a:2]", expected: "invalid slice bound"},
+		{name: "Filter without @", expr: "$.issues[?(state == 'Open')]", expected: "must start with '@'"},
+		{name: "Unclosed filter", expr: "$.issues[?(@.state == 'Open'", expected: "missing ')]'"},
+		{name: "Invalid filter value", expr: "$.issues[?(@.state == Open)]", expected: "invalid filter value"},
+		{name: "Text after the path", expr: "$.issues[?(@.votes)] )", expected: "expected '.' or '['"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			err := JSONPath(&buf, tt.expr, map[string]interface{}{})
+			if err == nil {
+				t.Fatalf("Expected error, got output %q", buf.String())
+			}
+			if !strings.Contains(err.Error(), tt.expected) {
+				t.Errorf("Expected error containing %q, got %v", tt.expected, err)
+			}
+		})
+	}
+}
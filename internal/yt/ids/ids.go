@@ -0,0 +1,39 @@
+// Package ids prints the ticket IDs of command results, for the ids output format
+package ids
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/mkozhukh/youtrack/pkg/youtrack"
+)
+
+// Format is the output format printing the ticket IDs of a result, one per line
+const Format = "ids"
+
+// Lister is implemented by the results of commands that list tickets
+type Lister interface {
+	IssueIDs() []string
+}
+
+// Print prints the ticket IDs of a result one per line, to be piped to other commands
+func Print(w io.Writer, data interface{}) error {
+	var list []string
+	switch v := data.(type) {
+	case []*youtrack.Issue:
+		for _, issue := range v {
+			list = append(list, issue.ID)
+		}
+	case Lister:
+		list = v.IssueIDs()
+	default:
+		return fmt.Errorf("--output ids is only supported by commands listing tickets")
+	}
+
+	for _, id := range list {
+		if id != "" {
+			fmt.Fprintln(w, id)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,318 @@
+// Package index keeps a local full-text index of the issues of a project, so `yt find` searches
+// them without contacting YouTrack. The index is a JSON snapshot of the issue texts stored
+// in the cache directory, encrypted like the cache; the term postings are built in memory
+// when it is loaded.
+package index
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/mkozhukh/youtrack/internal/yt/cache"
+)
+
+// fileName is the name of the index file in a project's cache directory
+const fileName = "index.json"
+
+// Weights of the fields a term is found in
+const (
+	weightSummary     = 3.0
+	weightTags        = 2.0
+	weightDescription = 1.0
+)
+
+// Document is the indexed text of an issue
+type Document struct {
+	ID          string    `json:"id"`
+	Summary     string    `json:"summary"`
+	Description string    `json:"description,omitempty"`
+	State       string    `json:"state,omitempty"`
+	Assignee    string    `json:"assignee,omitempty"`
+	Tags        []string  `json:"tags,omitempty"`
+	Updated     time.Time `json:"updated"`
+	Resolved    bool      `json:"resolved,omitempty"`
+}
+
+// Index is the snapshot of a project's issues
+type Index struct {
+	Project   string               `json:"project"`
+	Refreshed time.Time            `json:"refreshed"`
+	Documents map[string]*Document `json:"issues"`
+
+	// postings maps a term to the weight it has in each document, built on first search
+	postings map[string]map[string]float64
+}
+
+// New creates an empty index of a project
+func New(project string) *Index {
+	return &Index{Project: strings.ToUpper(project), Documents: make(map[string]*Document)}
+}
+
+// Put adds or replaces a document
+func (idx *Index) Put(doc *Document) {
+	idx.Documents[doc.ID] = doc
+	idx.postings = nil
+}
+
+// LastUpdated returns the latest update time of the indexed issues, zero when empty
+func (idx *Index) LastUpdated() time.Time {
+	var last time.Time
+	for _, doc := range idx.Documents {
+		if doc.Updated.After(last) {
+			last = doc.Updated
+		}
+	}
+	return last
+}
+
+// Hit is a document matching a search
+type Hit struct {
+	Document *Document `json:"issue"`
+	Score    float64   `json:"score"`
+	// Snippet is the description line matching the terms, when the summary does not match them all
+	Snippet string `json:"snippet,omitempty"`
+}
+
+// Search returns the documents containing all the terms of the query, best matches first. A term
+// matches the words it is a prefix of, e.g. "auth" matches "authentication", with a lower weight
+// than a whole word.
+func (idx *Index) Search(query string) []*Hit {
+	terms := Tokenize(query)
+	if len(terms) == 0 {
+		return nil
+	}
+	idx.build()
+
+	var scores map[string]float64
+	for _, term := range terms {
+		matches := idx.match(term)
+		if scores == nil {
+			scores = matches
+			continue
+		}
+		// Keep the documents matching the previous terms as well
+		for id := range scores {
+			if score, ok := matches[id]; ok {
+				scores[id] += score
+			} else {
+				delete(scores, id)
+			}
+		}
+	}
+
+	hits := make([]*Hit, 0, len(scores))
+	for id, score := range scores {
+		doc := idx.Documents[id]
+		hits = append(hits, &Hit{Document: doc, Score: score, Snippet: snippet(doc, terms)})
+	}
+	SortHits(hits)
+	return hits
+}
+
+// SortHits orders hits by score, then by the most recently updated
+func SortHits(hits []*Hit) {
+	sort.Slice(hits, func(i, j int) bool {
+		if hits[i].Score != hits[j].Score {
+			return hits[i].Score > hits[j].Score
+		}
+		return hits[i].Document.Updated.After(hits[j].Document.Updated)
+	})
+}
+
+// match scores the documents containing a term, weighting rare terms higher
+func (idx *Index) match(term string) map[string]float64 {
+	matches := make(map[string]float64)
+	for token, docs := range idx.postings {
+		if !strings.HasPrefix(token, term) {
+			continue
+		}
+		factor := 1.0
+		if token != term {
+			factor = 0.5
+		}
+		for id, weight := range docs {
+			if weight*factor > matches[id] {
+				matches[id] = weight * factor
+			}
+		}
+	}
+
+	idf := math.Log(1 + float64(len(idx.Documents))/float64(max(1, len(matches))))
+	for id := range matches {
+		matches[id] *= idf
+	}
+	return matches
+}
+
+// build computes the term postings of the documents
+func (idx *Index) build() {
+	if idx.postings != nil {
+		return
+	}
+	idx.postings = make(map[string]map[string]float64)
+	add := func(id, text string, weight float64) {
+		for _, token := range Tokenize(text) {
+			docs := idx.postings[token]
+			if docs == nil {
+				docs = make(map[string]float64)
+				idx.postings[token] = docs
+			}
+			docs[id] += weight
+		}
+	}
+	for id, doc := range idx.Documents {
+		add(id, doc.ID+" "+doc.Summary, weightSummary)
+		add(id, strings.Join(doc.Tags, " "), weightTags)
+		add(id, doc.Description, weightDescription)
+		add(id, doc.State+" "+doc.Assignee, weightDescription)
+	}
+}
+
+// Tokenize splits a text into lower-case words of letters and digits
+func Tokenize(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+// snippet returns the first description line containing a term, when the summary lacks one
+func snippet(doc *Document, terms []string) string {
+	if containsAll(Tokenize(doc.Summary), terms) {
+		return ""
+	}
+	for _, line := range strings.Split(doc.Description, "\n") {
+		tokens := Tokenize(line)
+		for _, term := range terms {
+			if containsAll(tokens, []string{term}) {
+				line = strings.Join(strings.Fields(line), " ")
+				if runes := []rune(line); len(runes) > 80 {
+					line = string(runes[:77]) + "..."
+				}
+				return line
+			}
+		}
+	}
+	return ""
+}
+
+// containsAll reports whether every term is a prefix of one of the tokens
+func containsAll(tokens, terms []string) bool {
+	for _, term := range terms {
+		found := false
+		for _, token := range tokens {
+			if strings.HasPrefix(token, term) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// Store keeps the indexes under the cache directory, as <dir>/<project>/index.json
+type Store struct {
+	dir   string
+	cache *cache.Store
+}
+
+// NewStore creates a store of the indexes in the directory of a cache, sharing its encryption
+func NewStore(metadata *cache.Store) *Store {
+	return &Store{dir: metadata.Dir(), cache: metadata}
+}
+
+// Path returns the index file of a project
+func (s *Store) Path(project string) string {
+	return filepath.Join(s.dir, strings.ToUpper(project), fileName)
+}
+
+// Load reads the index of a project, nil when the project is not indexed
+func (s *Store) Load(project string) (*Index, error) {
+	data, err := os.ReadFile(s.Path(project))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the index of %s: %w", project, err)
+	}
+	if data, err = s.cache.Unseal(data); err != nil {
+		return nil, fmt.Errorf("failed to read the index of %s: %w", project, err)
+	}
+
+	var idx Index
+	if err := json.Unmarshal(data, &idx); err != nil {
+		return nil, fmt.Errorf("failed to parse the index of %s (rebuild it with 'yt index build --full'): %w", project, err)
+	}
+	if idx.Documents == nil {
+		idx.Documents = make(map[string]*Document)
+	}
+	return &idx, nil
+}
+
+// Projects returns the indexed projects, sorted by short name
+func (s *Store) Projects() ([]string, error) {
+	entries, err := os.ReadDir(s.dir)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to list the indexes: %w", err)
+	}
+
+	var list []string
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		if _, err := os.Stat(filepath.Join(s.dir, entry.Name(), fileName)); err == nil {
+			list = append(list, entry.Name())
+		}
+	}
+	sort.Strings(list)
+	return list, nil
+}
+
+// Save writes the index through a temporary file, so an interrupted write keeps the previous one
+func (s *Store) Save(idx *Index) error {
+	data, err := json.Marshal(idx)
+	if err != nil {
+		return fmt.Errorf("failed to marshal the index: %w", err)
+	}
+	if data, err = s.cache.Seal(data); err != nil {
+		return err
+	}
+
+	path := s.Path(idx.Project)
+	dir := filepath.Dir(path)
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return fmt.Errorf("failed to create the index directory: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(dir, ".index-*.json")
+	if err != nil {
+		return fmt.Errorf("failed to write the index: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write the index: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write the index: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("failed to write the index: %w", err)
+	}
+	return nil
+}
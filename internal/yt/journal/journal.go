@@ -0,0 +1,228 @@
+// Package journal keeps the changes the yt CLI queued while YouTrack was unreachable, to be
+// replayed by `yt sync`. The journal is a small JSON file holding the operations in the order
+// they were queued, encrypted like the cache when cache.encrypt is set.
+package journal
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+)
+
+// Kinds of queued operations
+const (
+	KindCreate  = "create"
+	KindUpdate  = "update"
+	KindComment = "comment"
+	KindWorklog = "worklog"
+)
+
+// Operation is a change queued while offline. It keeps the command input, not the API
+// request, so names are resolved against the server when the change is replayed.
+type Operation struct {
+	ID     string    `json:"id"`
+	Kind   string    `json:"kind"`
+	Queued time.Time `json:"queued"`
+
+	// IssueID is the changed ticket; empty for a create
+	IssueID string `json:"issueId,omitempty"`
+
+	// Create input
+	Project    string   `json:"project,omitempty"`
+	Summary    string   `json:"summary,omitempty"`
+	Assignee   string   `json:"assignee,omitempty"`
+	Tags       []string `json:"tags,omitempty"`
+	NoDefaults bool     `json:"noDefaults,omitempty"`
+	Visibility string   `json:"visibility,omitempty"`
+
+	// Description of a created ticket or of a worklog
+	Description string `json:"description,omitempty"`
+	// Fields are the name=value assignments of a create or update
+	Fields []string `json:"fields,omitempty"`
+
+	// Text of a comment
+	Text string `json:"text,omitempty"`
+	// ReplyTo is the ID of the comment a comment answers
+	ReplyTo string `json:"replyTo,omitempty"`
+
+	// Minutes of a worklog, dated with the time it was queued unless Date or Spread is set
+	Minutes int `json:"minutes,omitempty"`
+	// Date of a backdated worklog, YYYY-MM-DD
+	Date string `json:"date,omitempty"`
+	// Spread is the "from..to" date range a worklog is split across
+	Spread string `json:"spread,omitempty"`
+	// Author is the login of the user a worklog is logged for, empty for the token owner
+	Author string `json:"author,omitempty"`
+}
+
+// Target describes what the operation changes, e.g. "PRJ-12" or "new ticket in PRJ"
+func (op *Operation) Target() string {
+	if op.Kind == KindCreate {
+		return "new ticket in " + op.Project
+	}
+	return op.IssueID
+}
+
+// Unreachable reports whether a request failed before reaching the server: the host could
+// not be resolved or connected to. Such a change was not applied and can be queued; a timeout
+// is not, as the server may have applied the change.
+func Unreachable(err error) bool {
+	var dnsErr *net.DNSError
+	if errors.As(err, &dnsErr) {
+		return true
+	}
+	var opErr *net.OpError
+	return errors.As(err, &opErr) && opErr.Op == "dial"
+}
+
+// Sealer encrypts the journal at rest; the metadata cache is one, sealing only when
+// cache.encrypt is set
+type Sealer interface {
+	Seal(data []byte) ([]byte, error)
+	Unseal(data []byte) ([]byte, error)
+}
+
+// Store persists the queued operations in a JSON file
+type Store struct {
+	path   string
+	sealer Sealer
+}
+
+// file is the content of the journal
+type file struct {
+	// NextID is the ID of the next queued operation, IDs are not reused
+	NextID     int          `json:"nextId"`
+	Operations []*Operation `json:"operations"`
+}
+
+// NewStore creates a store for the journal at path, or the default path if empty, sealed with
+// sealer, or written as plain JSON when it is nil
+func NewStore(path string, sealer Sealer) *Store {
+	if path == "" {
+		path = DefaultPath()
+	}
+	return &Store{path: path, sealer: sealer}
+}
+
+// DefaultPath returns the default journal, next to the yt configuration (~/.config/yt/journal.json)
+func DefaultPath() string {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return filepath.Join(os.TempDir(), "yt-journal.json")
+	}
+	return filepath.Join(homeDir, ".config", "yt", "journal.json")
+}
+
+// Path returns the journal path
+func (s *Store) Path() string {
+	return s.path
+}
+
+// List returns the queued operations, oldest first
+func (s *Store) List() ([]*Operation, error) {
+	f, err := s.load()
+	if err != nil {
+		return nil, err
+	}
+	return f.Operations, nil
+}
+
+// Add queues an operation, setting its ID and queue time, and returns the number of queued operations
+func (s *Store) Add(op *Operation, now time.Time) (int, error) {
+	f, err := s.load()
+	if err != nil {
+		return 0, err
+	}
+
+	if f.NextID < 1 {
+		f.NextID = 1
+	}
+	op.ID = strconv.Itoa(f.NextID)
+	op.Queued = now
+	f.NextID++
+
+	f.Operations = append(f.Operations, op)
+	if err := s.save(f); err != nil {
+		return 0, err
+	}
+	return len(f.Operations), nil
+}
+
+// Remove removes an operation by ID, reporting whether it was queued
+func (s *Store) Remove(id string) (bool, error) {
+	f, err := s.load()
+	if err != nil {
+		return false, err
+	}
+	for i, op := range f.Operations {
+		if op.ID == id {
+			f.Operations = append(f.Operations[:i], f.Operations[i+1:]...)
+			return true, s.save(f)
+		}
+	}
+	return false, nil
+}
+
+// load reads the journal, a missing file is an empty journal
+func (s *Store) load() (*file, error) {
+	data, err := os.ReadFile(s.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return &file{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the offline journal: %w", err)
+	}
+	if s.sealer != nil {
+		if data, err = s.sealer.Unseal(data); err != nil {
+			return nil, fmt.Errorf("failed to read the offline journal: %w", err)
+		}
+	}
+
+	var f file
+	if err := json.Unmarshal(data, &f); err != nil {
+		return nil, fmt.Errorf("failed to parse the offline journal %s: %w", s.path, err)
+	}
+	return &f, nil
+}
+
+// save writes the journal through a temporary file, so an interrupted write keeps the previous
+// state. The file is kept once empty, for the next ID.
+func (s *Store) save(f *file) error {
+	data, err := json.MarshalIndent(f, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal the offline journal: %w", err)
+	}
+	if s.sealer != nil {
+		if data, err = s.sealer.Seal(data); err != nil {
+			return err
+		}
+	}
+
+	dir := filepath.Dir(s.path)
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return fmt.Errorf("failed to create the offline journal directory: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(dir, ".journal-*.json")
+	if err != nil {
+		return fmt.Errorf("failed to write the offline journal: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write the offline journal: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write the offline journal: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), s.path); err != nil {
+		return fmt.Errorf("failed to write the offline journal: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,68 @@
+package journal
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/mkozhukh/youtrack/internal/yt/cache"
+	"github.com/mkozhukh/youtrack/internal/yt/config"
+)
+
+func TestStore_Sealed(t *testing.T) {
+	dir := t.TempDir()
+	cfg := &config.Config{}
+	cfg.Cache.Dir = dir
+	cfg.Cache.Encrypt = true
+	cfg.Cache.Key = "secret"
+
+	path := filepath.Join(dir, "journal.json")
+	store := NewStore(path, cache.Open(cfg))
+	if _, err := store.Add(&Operation{Kind: KindComment, IssueID: "PRJ-1", Text: "secret comment"}, time.Now()); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	for _, plain := range []string{"secret comment", "PRJ-1", KindComment} {
+		if bytes.Contains(data, []byte(plain)) {
+			t.Errorf("Expected the journal to be encrypted, found %q in it", plain)
+		}
+	}
+
+	ops, err := NewStore(path, cache.Open(cfg)).List()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(ops) != 1 || ops[0].Text != "secret comment" {
+		t.Errorf("Expected the queued comment, got %+v", ops)
+	}
+
+	if _, err := NewStore(path, nil).List(); err == nil {
+		t.Error("Expected an error reading the encrypted journal without the key")
+	}
+}
+
+func TestStore_Plain(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "journal.json")
+	store := NewStore(path, nil)
+	if _, err := store.Add(&Operation{Kind: KindComment, IssueID: "PRJ-1", Text: "plain comment"}, time.Now()); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	cfg := &config.Config{}
+	cfg.Cache.Dir = t.TempDir()
+	cfg.Cache.Encrypt = true
+	cfg.Cache.Key = "secret"
+	ops, err := NewStore(path, cache.Open(cfg)).List()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(ops) != 1 || ops[0].Text != "plain comment" {
+		t.Errorf("Expected the journal written before encryption to stay readable, got %+v", ops)
+	}
+}
@@ -0,0 +1,26 @@
+package projects
+
+import (
+	"context"
+
+	"github.com/mkozhukh/youtrack/internal/yt/cache"
+	"github.com/mkozhukh/youtrack/internal/yt/config"
+	"github.com/mkozhukh/youtrack/pkg/youtrack"
+)
+
+// IssueID normalizes a ticket ID given on the command line: "PRJ-123" is kept and a bare
+// number like "123" is completed with the default project, which may be configured by
+// name and is resolved to its short name first
+func IssueID(cfg *config.Config, id string) (string, error) {
+	normalized, err := youtrack.NormalizeIssueID(id, "")
+	if err == nil || cfg.Defaults.Project == "" {
+		return normalized, err
+	}
+
+	ctx := youtrack.NewYouTrackContext(context.Background(), cfg.Server.Token)
+	project, err := Resolve(cache.Open(cfg), cfg.NewClient(), ctx, cfg.Defaults.Project)
+	if err != nil {
+		return "", err
+	}
+	return youtrack.NormalizeIssueID(id, project.ShortName)
+}
@@ -0,0 +1,92 @@
+package projects
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/charmbracelet/log"
+
+	"github.com/mkozhukh/youtrack/internal/resolver"
+	"github.com/mkozhukh/youtrack/internal/yt/cache"
+	"github.com/mkozhukh/youtrack/pkg/youtrack"
+)
+
+// cacheKind is the cache entry kind that holds the project list
+const cacheKind = "projects"
+
+// Resolve resolves a --project flag value (short name, ID or human-readable name)
+// to a project, using the project list from the local metadata cache.
+// If the project list can't be fetched, the value is passed through as is
+// and left for the API to validate.
+func Resolve(store *cache.Store, client *youtrack.Client, ytCtx *youtrack.YouTrackContext, query string) (*youtrack.Project, error) {
+	lister := &cachedLister{store: store, client: client, ytCtx: ytCtx}
+	project, err := resolver.ResolveProject(ytCtx.Context(), lister, query)
+	if err != nil {
+		var resolveErr *resolver.ResolveError
+		if errors.As(err, &resolveErr) {
+			return nil, err
+		}
+		log.Warn("Project resolution skipped", "project", query, "error", err)
+		return &youtrack.Project{ID: query, ShortName: query}, nil
+	}
+
+	if project.ShortName != query {
+		log.Info("Project resolved", "query", query, "project", project.ShortName)
+	}
+	return project, nil
+}
+
+// List returns all projects visible to the user, from the local metadata cache when fresh
+func List(store *cache.Store, client *youtrack.Client, ytCtx *youtrack.YouTrackContext) ([]*youtrack.Project, error) {
+	lister := &cachedLister{store: store, client: client, ytCtx: ytCtx}
+	return lister.ListAllProjects(ytCtx.Context())
+}
+
+// fetchAll retrieves all projects visible to the user
+func fetchAll(client *youtrack.Client, ytCtx *youtrack.YouTrackContext) ([]*youtrack.Project, error) {
+	var allProjects []*youtrack.Project
+	skip := 0
+	top := 100
+
+	for {
+		projects, err := client.ListProjects(ytCtx, skip, top)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list projects: %w", err)
+		}
+
+		allProjects = append(allProjects, projects...)
+
+		if len(projects) < top {
+			break
+		}
+		skip += top
+	}
+
+	return allProjects, nil
+}
+
+// cachedLister lists projects through the local metadata cache
+type cachedLister struct {
+	store  *cache.Store
+	client *youtrack.Client
+	ytCtx  *youtrack.YouTrackContext
+}
+
+// ListAllProjects returns the cached project list, fetching it when missing or stale
+func (l *cachedLister) ListAllProjects(ctx context.Context) ([]*youtrack.Project, error) {
+	var projects []*youtrack.Project
+	if l.store.Get("", cacheKind, &projects) {
+		return projects, nil
+	}
+
+	projects, err := fetchAll(l.client, l.ytCtx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := l.store.Set("", cacheKind, projects); err != nil {
+		log.Warn("Failed to cache projects", "error", err)
+	}
+	return projects, nil
+}
@@ -0,0 +1,100 @@
+package theme
+
+import (
+	"fmt"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/lipgloss/table"
+)
+
+// Table is a table styled by a theme: with borders and colors, or as tab-aligned columns
+// for the plain theme
+type Table struct {
+	theme   *Theme
+	headers []string
+	rows    [][]string
+	style   func(row, col int) lipgloss.Style
+	flex    int
+}
+
+// Table starts a table with the given column headers
+func (t *Theme) Table(headers ...string) *Table {
+	return &Table{theme: t, headers: headers, flex: -1}
+}
+
+// Row appends a row of cells
+func (t *Table) Row(cells ...string) *Table {
+	t.rows = append(t.rows, cells)
+	return t
+}
+
+// Style sets the style of the cells, by row (counted from 0, without the header) and column;
+// the cells use the Cell style otherwise. The plain theme ignores it.
+func (t *Table) Style(fn func(row, col int) lipgloss.Style) *Table {
+	t.style = fn
+	return t
+}
+
+// Flex makes a column, e.g. a summary, take the width the other columns leave in the
+// terminal: longer lines are cut with an ellipsis. SetWidth(0) keeps them whole.
+func (t *Table) Flex(col int) *Table {
+	t.flex = col
+	return t
+}
+
+// String renders the table, without a trailing newline
+func (t *Table) String() string {
+	if t.theme.Plain {
+		return t.plain()
+	}
+
+	lt := table.New().
+		Border(lipgloss.NormalBorder()).
+		BorderStyle(t.theme.Border).
+		StyleFunc(func(row, col int) lipgloss.Style {
+			style := t.theme.Cell
+			switch {
+			case row == table.HeaderRow:
+				style = t.theme.Header
+			case t.style != nil:
+				style = t.style(row, col)
+			}
+			return style.Padding(0, 1)
+		}).
+		Headers(t.headers...)
+	// Each column is padded by a space on both sides and followed by a border, the
+	// first one also preceded by one
+	for _, row := range fitFlex(t.headers, t.rows, t.flex, 3, 1) {
+		lt.Row(row...)
+	}
+	return lt.String()
+}
+
+// plain renders the table as columns aligned with spaces, one line per row without
+// trailing spaces
+func (t *Table) plain() string {
+	var sb strings.Builder
+	w := tabwriter.NewWriter(&sb, 0, 0, 2, ' ', 0)
+	rows := make([][]string, len(t.rows))
+	for i, row := range t.rows {
+		rows[i] = make([]string, len(row))
+		for col, cell := range row {
+			rows[i][col] = strings.Join(strings.Fields(cell), " ")
+		}
+	}
+
+	// Columns are separated by two spaces, the last one is not followed by any
+	fmt.Fprintln(w, strings.Join(t.headers, "\t"))
+	for _, row := range fitFlex(t.headers, rows, t.flex, 2, -2) {
+		fmt.Fprintln(w, strings.Join(row, "\t"))
+	}
+	w.Flush()
+
+	lines := strings.Split(strings.TrimSuffix(sb.String(), "\n"), "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimRight(line, " ")
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,118 @@
+// Package theme holds the styles of the text output of yt, with presets for dark and light
+// terminals and a plain one for terminals without colors and machine-friendly output
+package theme
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Theme is a set of styles for the text output
+type Theme struct {
+	Name string
+	// Plain renders tables as tab-aligned columns, without borders or colors
+	Plain bool
+
+	Border    lipgloss.Style // Table and box borders
+	Header    lipgloss.Style // Headings and table headers
+	Cell      lipgloss.Style // Table cells and regular text
+	Accent    lipgloss.Style // Ticket IDs and highlighted values
+	Muted     lipgloss.Style // Secondary details, e.g. assignees on cards
+	Disabled  lipgloss.Style // Inactive entries, e.g. banned users
+	Separator lipgloss.Style // Rules between items, e.g. board cards
+	Code      lipgloss.Style // Code, commit hashes
+	Strong    lipgloss.Style // Bold text
+	Link      lipgloss.Style // Link texts
+	OK        lipgloss.Style // Success statuses
+	Warn      lipgloss.Style // Warnings
+	Error     lipgloss.Style // Failures and breaches
+}
+
+// presets are the themes by name
+var presets = map[string]*Theme{
+	"dark":  newColorTheme("dark", "99", "212", "246", "240", "238", "214", "42", "214", "196"),
+	"light": newColorTheme("light", "61", "125", "238", "245", "250", "130", "28", "166", "160"),
+	"plain": {Name: "plain", Plain: true},
+}
+
+// newColorTheme returns a theme with the given colors: the border and accent color, then the
+// colors of headers, cells, muted text, separators, code, success, warnings and errors
+func newColorTheme(name, accent, header, cell, muted, separator, code, ok, warn, fail string) *Theme {
+	color := func(c string) lipgloss.Style {
+		return lipgloss.NewStyle().Foreground(lipgloss.Color(c))
+	}
+	return &Theme{
+		Name:      name,
+		Border:    color(accent),
+		Header:    color(header).Bold(true),
+		Cell:      color(cell),
+		Accent:    color(accent).Bold(true),
+		Muted:     color(muted),
+		Disabled:  color(muted).Strikethrough(true),
+		Separator: color(separator),
+		Code:      color(code),
+		Strong:    lipgloss.NewStyle().Bold(true),
+		Link:      lipgloss.NewStyle().Underline(true),
+		OK:        color(ok),
+		Warn:      color(warn),
+		Error:     color(fail).Bold(true),
+	}
+}
+
+// current is the theme of the output, set by Select
+var current = presets["dark"]
+
+// Current returns the theme of the output
+func Current() *Theme {
+	return current
+}
+
+// Names returns the names of the presets, sorted
+func Names() []string {
+	names := make([]string, 0, len(presets))
+	for name := range presets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Lookup returns a preset by name, matched case-insensitively
+func Lookup(name string) (*Theme, error) {
+	if t, ok := presets[strings.ToLower(strings.TrimSpace(name))]; ok {
+		return t, nil
+	}
+	return nil, fmt.Errorf("unknown theme '%s', expected one of: %s", name, strings.Join(Names(), ", "))
+}
+
+// Select sets the current theme: the requested one (the --theme flag) when given, plain
+// when NO_COLOR is set or TERM is dumb, then the configured one, and dark by default
+func Select(requested, configured string) error {
+	name := requested
+	switch {
+	case name != "":
+	case NoColor():
+		name = "plain"
+	case configured != "":
+		name = configured
+	default:
+		name = "dark"
+	}
+
+	t, err := Lookup(name)
+	if err != nil {
+		return err
+	}
+	current = t
+	return nil
+}
+
+// NoColor reports whether the environment asks for output without colors, through
+// NO_COLOR (https://no-color.org) or a dumb terminal
+func NoColor() bool {
+	return os.Getenv("NO_COLOR") != "" || os.Getenv("TERM") == "dumb"
+}
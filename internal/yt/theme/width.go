@@ -0,0 +1,100 @@
+package theme
+
+import (
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"golang.org/x/term"
+)
+
+// minFlexWidth is the narrowest a flexible column is shrunk to, even when the table
+// then overflows the terminal
+const minFlexWidth = 20
+
+// width is the width tables fit in by shrinking their flexible column, 0 for no limit
+var width int
+
+// SetWidth sets the width tables fit in, 0 to never shrink their flexible column
+func SetWidth(w int) {
+	width = max(w, 0)
+}
+
+// TerminalWidth returns the width of the terminal stdout is connected to, the COLUMNS
+// variable when it is not a terminal, or 0 when neither is known
+func TerminalWidth() int {
+	if w, _, err := term.GetSize(int(os.Stdout.Fd())); err == nil && w > 0 {
+		return w
+	}
+	if w, err := strconv.Atoi(os.Getenv("COLUMNS")); err == nil && w > 0 {
+		return w
+	}
+	return 0
+}
+
+// fitFlex shortens the cells of the flexible column so that the table fits the width, given
+// the space taken by each column besides its content and by the table itself
+func fitFlex(headers []string, rows [][]string, flex, columnGap, tableGap int) [][]string {
+	if width == 0 || flex < 0 || flex >= len(headers) {
+		return rows
+	}
+
+	used := tableGap + columnGap*len(headers)
+	for col := range headers {
+		if col != flex {
+			used += columnWidth(headers, rows, col)
+		}
+	}
+	available := max(width-used, minFlexWidth, lipgloss.Width(headers[flex]))
+
+	fitted := make([][]string, len(rows))
+	for i, row := range rows {
+		fitted[i] = row
+		if flex < len(row) && cellWidth(row[flex]) > available {
+			fitted[i] = append([]string(nil), row...)
+			fitted[i][flex] = truncateLines(row[flex], available)
+		}
+	}
+	return fitted
+}
+
+// columnWidth returns the width of the widest cell of a column, its header included
+func columnWidth(headers []string, rows [][]string, col int) int {
+	w := lipgloss.Width(headers[col])
+	for _, row := range rows {
+		if col < len(row) {
+			w = max(w, cellWidth(row[col]))
+		}
+	}
+	return w
+}
+
+// cellWidth returns the width of the widest line of a cell
+func cellWidth(cell string) int {
+	w := 0
+	for _, line := range strings.Split(cell, "\n") {
+		w = max(w, lipgloss.Width(line))
+	}
+	return w
+}
+
+// truncateLines shortens each line of a cell to at most n columns, marking the cut with
+// an ellipsis
+func truncateLines(cell string, n int) string {
+	lines := strings.Split(cell, "\n")
+	for i, line := range lines {
+		if lipgloss.Width(line) <= n {
+			continue
+		}
+		runes := []rune(line)
+		if len(runes) > n {
+			runes = runes[:n]
+		}
+		for len(runes) > 0 && lipgloss.Width(string(runes))+3 > n {
+			runes = runes[:len(runes)-1]
+		}
+		lines[i] = strings.TrimRight(string(runes), " ") + "..."
+	}
+	return strings.Join(lines, "\n")
+}
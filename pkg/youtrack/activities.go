@@ -0,0 +1,109 @@
+package youtrack
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// ActivityQuery selects the activities of the issues matching a query
+type ActivityQuery struct {
+	// IssueQuery is the search query selecting the issues, e.g. "project: PRJ"
+	IssueQuery string
+	// Since leaves out the activities before this time, when set
+	Since time.Time
+	// PageSize is the number of activities fetched per request, DefaultPageSize when 0
+	PageSize int
+}
+
+// activitiesPage is a page of the activity stream, with the cursor of the next page
+type activitiesPage struct {
+	Activities  []*ActivityItem `json:"activities"`
+	AfterCursor string          `json:"afterCursor"`
+	HasAfter    bool            `json:"hasAfter"`
+}
+
+// IssueID returns the readable ID of the issue an activity belongs to, set for the
+// activities of ForEachActivity
+func (a *ActivityItem) IssueID() string {
+	if a.Target == nil {
+		return ""
+	}
+	if a.Target.Issue != nil {
+		return a.Target.Issue.ID
+	}
+	return a.Target.IDReadable
+}
+
+// ForEachActivity calls fn for each activity of the issues matching the query, oldest first.
+// Activities are fetched page by page with the cursor of the activity stream, so exports of any
+// size run in constant memory. Returning ErrStopIteration from fn stops the iteration without error.
+func (c *Client) ForEachActivity(ctx *YouTrackContext, query ActivityQuery, fn func(activity *ActivityItem) error) error {
+	pageSize := query.PageSize
+	if pageSize <= 0 {
+		pageSize = DefaultPageSize
+	}
+
+	params := url.Values{}
+	params.Add("fields", "activities("+activityFields+",target(id,idReadable,issue(idReadable))),afterCursor,hasAfter")
+	// The activity stream of several issues lists only the named categories
+	params.Add("categories", strings.Join(activityCategories, ","))
+	params.Add("$top", fmt.Sprintf("%d", pageSize))
+	if query.IssueQuery != "" {
+		params.Add("issueQuery", query.IssueQuery)
+	}
+	if !query.Since.IsZero() {
+		params.Add("start", fmt.Sprintf("%d", query.Since.UnixMilli()))
+	}
+
+	for {
+		if err := ctx.Context().Err(); err != nil {
+			return err
+		}
+
+		page, err := c.getActivitiesPage(ctx, params)
+		if err != nil {
+			return err
+		}
+		for _, activity := range page.Activities {
+			if err := fn(activity); err != nil {
+				if errors.Is(err, ErrStopIteration) {
+					return nil
+				}
+				return err
+			}
+		}
+		if !page.HasAfter || page.AfterCursor == "" || len(page.Activities) == 0 {
+			return nil
+		}
+		params.Set("cursor", page.AfterCursor)
+	}
+}
+
+// getActivitiesPage fetches one page of the activity stream
+func (c *Client) getActivitiesPage(ctx *YouTrackContext, params url.Values) (*activitiesPage, error) {
+	resp, err := c.Get(ctx, "/api/activitiesPage", params)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var page activitiesPage
+	if err := json.NewDecoder(resp.Body).Decode(&page); err != nil {
+		return nil, fmt.Errorf("failed to decode activities: %w", err)
+	}
+	return &page, nil
+}
+
+// AddedText returns the display values the activity added
+func (a *ActivityItem) AddedText() []string {
+	return displayValues(a.Added, a.AddedValues)
+}
+
+// RemovedText returns the display values the activity removed
+func (a *ActivityItem) RemovedText() []string {
+	return displayValues(a.Removed, a.RemovedValues)
+}
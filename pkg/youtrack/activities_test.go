@@ -0,0 +1,157 @@
+package youtrack
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestClient_ForEachActivity(t *testing.T) {
+	var queries []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/activitiesPage" {
+			t.Errorf("Unexpected path %s", r.URL.Path)
+		}
+		params := r.URL.Query()
+		queries = append(queries, params.Get("issueQuery")+"|"+params.Get("start"))
+
+		// The cursor is the index of the first activity of the page
+		first, _ := strconv.Atoi(params.Get("cursor"))
+		top, _ := strconv.Atoi(params.Get("$top"))
+		var activities []string
+		for i := first; i < first+top && i < 5; i++ {
+			activities = append(activities, fmt.Sprintf(`{"id":"a%d","timestamp":%d,"category":{"id":"CustomFieldCategory"},"field":{"name":"State"},"added":[{"name":"Open"}],"target":{"id":"2-%d","idReadable":"PRJ-%d"}}`, i, i*1000, i, i+1))
+		}
+		next := first + top
+		fmt.Fprintf(w, `{"activities":[%s],"afterCursor":"%d","hasAfter":%t}`, strings.Join(activities, ","), next, next < 5)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL)
+	ctx := NewYouTrackContext(context.Background(), "token")
+	since := time.UnixMilli(1700000000000)
+
+	tests := []struct {
+		name     string
+		stopAt   int
+		expected []string
+	}{
+		{
+			name:     "Follows the cursor through all pages",
+			expected: []string{"PRJ-1", "PRJ-2", "PRJ-3", "PRJ-4", "PRJ-5"},
+		},
+		{
+			name:     "Stops early",
+			stopAt:   3,
+			expected: []string{"PRJ-1", "PRJ-2", "PRJ-3"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			queries = nil
+			var ids []string
+			query := ActivityQuery{IssueQuery: "project: PRJ", Since: since, PageSize: 2}
+			err := client.ForEachActivity(ctx, query, func(activity *ActivityItem) error {
+				ids = append(ids, activity.IssueID())
+				if len(activity.AddedText()) != 1 || activity.FieldName() != "State" {
+					t.Errorf("Unexpected activity %+v", activity)
+				}
+				if tt.stopAt > 0 && len(ids) == tt.stopAt {
+					return ErrStopIteration
+				}
+				return nil
+			})
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if strings.Join(ids, ",") != strings.Join(tt.expected, ",") {
+				t.Errorf("Expected %v, got %v", tt.expected, ids)
+			}
+			for _, q := range queries {
+				if q != "project: PRJ|1700000000000" {
+					t.Errorf("Unexpected query parameters %q", q)
+				}
+			}
+		})
+	}
+}
+
+func TestActivityItem_IssueID(t *testing.T) {
+	tests := []struct {
+		name     string
+		target   *ActivityTarget
+		expected string
+	}{
+		{name: "Issue target", target: &ActivityTarget{ID: "2-1", IDReadable: "PRJ-1"}, expected: "PRJ-1"},
+		{name: "Comment target", target: &ActivityTarget{ID: "4-1", Issue: &IssueRef{ID: "PRJ-2"}}, expected: "PRJ-2"},
+		{name: "No target", expected: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			item := &ActivityItem{Target: tt.target}
+			if got := item.IssueID(); got != tt.expected {
+				t.Errorf("Expected %q, got %q", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestActivityItem_UnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		name            string
+		input           string
+		expectedAdded   string
+		expectedRemoved string
+		addedCount      int
+		removedCount    int
+		addedLogin      string
+	}{
+		{
+			name:         "Multi-value field change",
+			input:        `{"id":"1","timestamp":0,"added":[{"name":"Fixed"}],"removed":[{"name":"Open"}]}`,
+			addedCount:   1,
+			removedCount: 1,
+		},
+		{
+			name:       "Single entity value",
+			input:      `{"id":"2","timestamp":0,"added":{"login":"john"}}`,
+			addedLogin: "john",
+		},
+		{
+			name:            "Text value",
+			input:           `{"id":"3","timestamp":0,"added":"New summary","removed":"Old summary"}`,
+			expectedAdded:   "New summary",
+			expectedRemoved: "Old summary",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var item ActivityItem
+			if err := json.Unmarshal([]byte(tt.input), &item); err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			if len(item.AddedValues) != tt.addedCount || len(item.RemovedValues) != tt.removedCount {
+				t.Errorf("Expected %d/%d values, got %d/%d", tt.addedCount, tt.removedCount, len(item.AddedValues), len(item.RemovedValues))
+			}
+			if tt.expectedAdded != "" && (item.Added == nil || item.Added.Text != tt.expectedAdded) {
+				t.Errorf("Expected added text %q, got %+v", tt.expectedAdded, item.Added)
+			}
+			if tt.addedLogin != "" && (item.Added == nil || item.Added.Login != tt.addedLogin) {
+				t.Errorf("Expected added login %q, got %+v", tt.addedLogin, item.Added)
+			}
+			if tt.expectedRemoved != "" && (item.Removed == nil || item.Removed.Text != tt.expectedRemoved) {
+				t.Errorf("Expected removed text %q, got %+v", tt.expectedRemoved, item.Removed)
+			}
+		})
+	}
+}
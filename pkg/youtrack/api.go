@@ -0,0 +1,164 @@
+package youtrack
+
+import "io"
+
+// API is the set of YouTrack operations of Client, without its configuration and raw
+// request methods. Depend on it, or on one of the narrower interfaces it is made of,
+// to substitute the client in tests.
+type API interface {
+	IssueAPI
+	CommentAPI
+	ReactionAPI
+	LinkAPI
+	TagAPI
+	VoteAPI
+	AttachmentAPI
+	WorklogAPI
+	SearchAPI
+	StatsAPI
+	ProjectAPI
+	UserAPI
+	GroupAPI
+	NotificationAPI
+}
+
+var _ API = (*Client)(nil)
+
+// IssueAPI reads and changes issues
+type IssueAPI interface {
+	GetIssue(ctx *YouTrackContext, issueID string) (*Issue, error)
+	GetIssueProjected(ctx *YouTrackContext, issueID string, projection *IssueProjection) (*Issue, error)
+	GetIssuesByIDs(ctx *YouTrackContext, ids []string) ([]*Issue, error)
+	CreateIssue(ctx *YouTrackContext, req *CreateIssueRequest) (*Issue, error)
+	CreateIssueTree(ctx *YouTrackContext, parent *CreateIssueRequest, subtasks []*CreateIssueRequest) (*IssueTree, error)
+	UpdateIssue(ctx *YouTrackContext, issueID string, req *UpdateIssueRequest) (*Issue, error)
+	UpdateIssueAssignee(ctx *YouTrackContext, issueID string, assigneeLogin string) (*Issue, error)
+	UpdateIssueAssigneeByProject(ctx *YouTrackContext, issueID string, projectID string, username string) (*Issue, error)
+	DeleteIssue(ctx *YouTrackContext, issueID string) error
+	ApplyCommand(ctx *YouTrackContext, issueID string, command string) error
+	GetIssueCustomFields(ctx *YouTrackContext, issueID string) ([]*CustomFieldValue, error)
+	NewCustomFieldPatch(ctx *YouTrackContext, projectID string) (*CustomFieldPatch, error)
+	ResolveVisibility(ctx *YouTrackContext, groupNames, userLogins []string) (*Visibility, error)
+	GetIssueActivities(ctx *YouTrackContext, issueID string) ([]*ActivityItem, error)
+	ForEachActivity(ctx *YouTrackContext, query ActivityQuery, fn func(activity *ActivityItem) error) error
+	GetIssueVcsChanges(ctx *YouTrackContext, issueID string) ([]*VcsChange, error)
+}
+
+// CommentAPI reads and changes issue comments
+type CommentAPI interface {
+	GetIssueComments(ctx *YouTrackContext, issueID string) ([]*IssueComment, error)
+	AddIssueComment(ctx *YouTrackContext, issueID string, text string) (*IssueComment, error)
+	ReplyToIssueComment(ctx *YouTrackContext, issueID, commentID string, text string) (*IssueComment, error)
+	UpdateIssueComment(ctx *YouTrackContext, issueID, commentID string, text string) (*IssueComment, error)
+	DeleteIssueComment(ctx *YouTrackContext, issueID, commentID string) error
+}
+
+// ReactionAPI reads and changes comment reactions
+type ReactionAPI interface {
+	GetCommentReactions(ctx *YouTrackContext, issueID, commentID string) ([]*Reaction, error)
+	AddCommentReaction(ctx *YouTrackContext, issueID, commentID, reaction string) (*Reaction, error)
+	RemoveCommentReaction(ctx *YouTrackContext, issueID, commentID, reactionID string) error
+}
+
+// LinkAPI reads and creates issue links
+type LinkAPI interface {
+	GetIssueLinks(ctx *YouTrackContext, issueID string) ([]*IssueLink, error)
+	CreateIssueLink(ctx *YouTrackContext, sourceIssueID, targetIssueID, linkType string) error
+	GetAvailableLinkTypes(ctx *YouTrackContext) ([]*LinkType, error)
+}
+
+// TagAPI reads and changes tags and issue tags
+type TagAPI interface {
+	GetIssueTags(ctx *YouTrackContext, issueID string) ([]*IssueTag, error)
+	AddIssueTag(ctx *YouTrackContext, issueID string, tagID string) error
+	RemoveIssueTag(ctx *YouTrackContext, issueID string, tagID string) error
+	ListTags(ctx *YouTrackContext, skip, top int) ([]*Tag, error)
+	CreateTag(ctx *YouTrackContext, name string, color string) (*Tag, error)
+	GetTagByName(ctx *YouTrackContext, name string) (*Tag, error)
+	EnsureTag(ctx *YouTrackContext, name string, color string) (string, error)
+}
+
+// VoteAPI reads and changes issue votes
+type VoteAPI interface {
+	GetIssueVoters(ctx *YouTrackContext, issueID string) (*IssueVoters, error)
+	VoteIssue(ctx *YouTrackContext, issueID string) error
+	UnvoteIssue(ctx *YouTrackContext, issueID string) error
+}
+
+// AttachmentAPI reads and changes issue attachments
+type AttachmentAPI interface {
+	GetIssueAttachments(ctx *YouTrackContext, issueID string) ([]*Attachment, error)
+	AddIssueAttachment(ctx *YouTrackContext, issueID string, filePath string) (*Attachment, error)
+	AddIssueAttachmentFromReader(ctx *YouTrackContext, issueID string, content io.Reader, size int64, filename string, progress UploadProgress) (*Attachment, error)
+	AddIssueAttachmentFromBytes(ctx *YouTrackContext, issueID string, content []byte, filename string) (*Attachment, error)
+	DeleteIssueAttachment(ctx *YouTrackContext, issueID string, attachmentID string) error
+	GetIssueAttachmentContent(ctx *YouTrackContext, issueID string, attachmentID string) ([]byte, error)
+	DownloadByURL(ctx *YouTrackContext, rawURL string) ([]byte, error)
+}
+
+// WorklogAPI reads and adds work items
+type WorklogAPI interface {
+	GetIssueWorklogs(ctx *YouTrackContext, issueID string) ([]*WorkItem, error)
+	AddIssueWorklog(ctx *YouTrackContext, issueID string, req *CreateWorklogRequest) (*WorkItem, error)
+	GetUserWorklogs(ctx *YouTrackContext, userID string, projectID string, startDate, endDate string, skip, top int) ([]*WorkItem, error)
+	GetTimeTrackingSettings(ctx *YouTrackContext, projectID string) (*TimeTrackingSettings, error)
+}
+
+// SearchAPI searches issues
+type SearchAPI interface {
+	SearchIssues(ctx *YouTrackContext, query string, skip, top int) ([]*Issue, error)
+	SearchIssuesSorted(ctx *YouTrackContext, query string, skip, top int, sortBy, sortOrder string) ([]*Issue, error)
+	SearchIssuesProjected(ctx *YouTrackContext, query string, skip, top int, sortBy, sortOrder string, projection *IssueProjection) ([]*Issue, error)
+	ForEachIssue(ctx *YouTrackContext, query string, pageSize int, fn func(issue *Issue) error) error
+	GetSearchSuggestions(ctx *YouTrackContext, query string, caret int) (*SearchAssist, error)
+	FindSimilarIssues(ctx *YouTrackContext, text string, opts SimilarIssuesOptions) ([]*SimilarIssue, error)
+}
+
+// StatsAPI counts issues and aggregates project statistics
+type StatsAPI interface {
+	CountIssues(ctx *YouTrackContext, query string) (int, error)
+	GetProjectStats(ctx *YouTrackContext, projectID string, opts ProjectStatsOptions) (*ProjectStats, error)
+	GetFieldDistribution(ctx *YouTrackContext, projectID, fieldName, query string) (*FieldDistribution, error)
+	GetAssigneeLoad(ctx *YouTrackContext, projectID string, opts AssigneeLoadOptions) ([]*AssigneeLoad, error)
+}
+
+// ProjectAPI reads projects and their custom fields
+type ProjectAPI interface {
+	GetProject(ctx *YouTrackContext, projectID string) (*Project, error)
+	GetProjectByName(ctx *YouTrackContext, name string) (*Project, error)
+	ListProjects(ctx *YouTrackContext, skip, top int) ([]*Project, error)
+	GetProjectIssues(ctx *YouTrackContext, projectID string, skip, top int) ([]*Issue, error)
+	GetProjectCustomFields(ctx *YouTrackContext, projectID string) ([]*CustomField, error)
+	ListProjectCustomFields(ctx *YouTrackContext, projectID string) ([]*ProjectCustomField, error)
+	GetProjectCustomField(ctx *YouTrackContext, projectID string, fieldName string) (*ProjectCustomField, error)
+	GetCustomFieldAllowedValues(ctx *YouTrackContext, projectID string, fieldName string) ([]AllowedValue, error)
+	AddCustomFieldEnumValue(ctx *YouTrackContext, projectID string, fieldName string, valueName string, color string) error
+}
+
+// UserAPI reads users
+type UserAPI interface {
+	GetCurrentUser(ctx *YouTrackContext) (*User, error)
+	GetUser(ctx *YouTrackContext, userID string) (*User, error)
+	SearchUsers(ctx *YouTrackContext, query string, skip, top int) ([]*User, error)
+	GetUserByLogin(ctx *YouTrackContext, login string) (*User, error)
+	GetProjectUsers(ctx *YouTrackContext, projectID string, skip, top int) ([]*User, error)
+	GetProjectTeam(ctx *YouTrackContext, projectID string, skip, top int) ([]*User, error)
+	GetProjectAssignees(ctx *YouTrackContext, projectID string, skip, top int) ([]*User, error)
+	SuggestUserByProject(ctx *YouTrackContext, projectID string, username string) (*User, error)
+	GetPermissions(ctx *YouTrackContext) (*Permissions, error)
+	CheckPermission(ctx *YouTrackContext, permission Permission, projectID string) error
+}
+
+// GroupAPI reads user groups
+type GroupAPI interface {
+	ListGroups(ctx *YouTrackContext) ([]*UserGroup, error)
+	FindGroup(ctx *YouTrackContext, name string) (*UserGroup, error)
+	GetGroupMembers(ctx *YouTrackContext, ringID string, skip, top int) ([]*User, error)
+}
+
+// NotificationAPI reads and marks the current user's notifications
+type NotificationAPI interface {
+	GetNotifications(ctx *YouTrackContext, skip, top int, unreadOnly bool) ([]*Notification, error)
+	CountUnreadNotifications(ctx *YouTrackContext) (int, error)
+	MarkNotificationRead(ctx *YouTrackContext, notificationID string, read bool) error
+}
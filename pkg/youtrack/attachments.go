@@ -18,7 +18,7 @@ func (c *Client) GetIssueAttachments(ctx *YouTrackContext, issueID string) ([]*A
 	path := fmt.Sprintf("/api/issues/%s/attachments", issueID)
 
 	query := url.Values{}
-	query.Add("fields", "id,name,size,created,mimeType,url,author(id,login,fullName)")
+	query.Add("fields", "id,name,size,created,mimeType,extension,url,thumbnailURL,imageDimensions(width,height),author(id,login,fullName)")
 
 	resp, err := c.Get(ctx, path, query)
 	if err != nil {
@@ -34,7 +34,12 @@ func (c *Client) GetIssueAttachments(ctx *YouTrackContext, issueID string) ([]*A
 	return attachments, nil
 }
 
-// AddIssueAttachment uploads a file as an attachment to an issue
+// UploadProgress is called while an attachment is uploaded, with the number of content
+// bytes sent so far and the total size (-1 if unknown)
+type UploadProgress func(sent, total int64)
+
+// AddIssueAttachment uploads a file as an attachment to an issue.
+// The file is streamed, so its size is not limited by available memory.
 func (c *Client) AddIssueAttachment(ctx *YouTrackContext, issueID string, filePath string) (*Attachment, error) {
 	// Open the file
 	file, err := os.Open(filePath)
@@ -49,32 +54,39 @@ func (c *Client) AddIssueAttachment(ctx *YouTrackContext, issueID string, filePa
 		return nil, fmt.Errorf("failed to get file info: %w", err)
 	}
 
-	// Create multipart form data
-	var requestBody bytes.Buffer
-	writer := multipart.NewWriter(&requestBody)
+	return c.AddIssueAttachmentFromReader(ctx, issueID, file, fileInfo.Size(), filepath.Base(filePath), nil)
+}
 
-	// Add the file field
-	fileName := filepath.Base(filePath)
-	fileWriter, err := writer.CreateFormFile("file", fileName)
-	if err != nil {
+// AddIssueAttachmentFromReader streams content as an attachment to an issue without buffering it.
+// size is the content length in bytes, or -1 if unknown (the request is then sent chunked);
+// progress, if not nil, is called as the content is sent.
+func (c *Client) AddIssueAttachmentFromReader(ctx *YouTrackContext, issueID string, content io.Reader, size int64, filename string, progress UploadProgress) (*Attachment, error) {
+	// Build the multipart envelope around the file content
+	var envelope bytes.Buffer
+	writer := multipart.NewWriter(&envelope)
+	if _, err := writer.CreateFormFile("file", filename); err != nil {
 		return nil, fmt.Errorf("failed to create form file: %w", err)
 	}
+	header := bytes.Clone(envelope.Bytes())
+	envelope.Reset()
+	if err := writer.Close(); err != nil {
+		return nil, fmt.Errorf("failed to close form writer: %w", err)
+	}
+	footer := envelope.Bytes()
 
-	// Copy file content to form
-	_, err = io.Copy(fileWriter, file)
-	if err != nil {
-		return nil, fmt.Errorf("failed to copy file content: %w", err)
+	if progress != nil {
+		content = &progressReader{Reader: content, total: size, progress: progress}
 	}
+	body := io.MultiReader(bytes.NewReader(header), content, bytes.NewReader(footer))
 
-	// Close the writer to finalize the form
-	err = writer.Close()
-	if err != nil {
-		return nil, fmt.Errorf("failed to close form writer: %w", err)
+	contentLength := int64(-1)
+	if size >= 0 {
+		contentLength = int64(len(header)) + size + int64(len(footer))
 	}
 
 	// Make the API request
 	path := fmt.Sprintf("/api/issues/%s/attachments", issueID)
-	resp, err := c.doMultipartRequest(ctx, http.MethodPost, path, &requestBody, writer.FormDataContentType())
+	resp, err := c.doMultipartRequest(ctx, http.MethodPost, path, body, contentLength, writer.FormDataContentType())
 	if err != nil {
 		return nil, fmt.Errorf("failed to upload attachment: %w", err)
 	}
@@ -88,7 +100,7 @@ func (c *Client) AddIssueAttachment(ctx *YouTrackContext, issueID string, filePa
 
 	// Find the newly created attachment (it should be the one with matching name and size)
 	for _, attachment := range attachments {
-		if attachment.Name == fileName && attachment.Size == fileInfo.Size() {
+		if attachment.Name == filename && (size < 0 || attachment.Size == size) {
 			return attachment, nil
 		}
 	}
@@ -101,6 +113,36 @@ func (c *Client) AddIssueAttachment(ctx *YouTrackContext, issueID string, filePa
 	return nil, fmt.Errorf("uploaded attachment not found in response")
 }
 
+// progressReader reports the bytes read from the wrapped reader
+type progressReader struct {
+	io.Reader
+	sent     int64
+	total    int64
+	progress UploadProgress
+}
+
+func (r *progressReader) Read(p []byte) (int, error) {
+	n, err := r.Reader.Read(p)
+	if n > 0 {
+		r.sent += int64(n)
+		r.progress(r.sent, r.total)
+	}
+	return n, err
+}
+
+// DeleteIssueAttachment deletes an attachment from an issue
+func (c *Client) DeleteIssueAttachment(ctx *YouTrackContext, issueID string, attachmentID string) error {
+	path := fmt.Sprintf("/api/issues/%s/attachments/%s", issueID, attachmentID)
+
+	resp, err := c.Delete(ctx, path)
+	if err != nil {
+		return fmt.Errorf("failed to delete attachment: %w", err)
+	}
+	defer resp.Body.Close()
+
+	return nil
+}
+
 // GetIssueAttachmentContent downloads the raw content of an attachment.
 // It first fetches the attachment metadata to get the download URL, then downloads from that URL.
 func (c *Client) GetIssueAttachmentContent(ctx *YouTrackContext, issueID string, attachmentID string) ([]byte, error) {
@@ -131,7 +173,10 @@ func (c *Client) DownloadByURL(ctx *YouTrackContext, rawURL string) ([]byte, err
 		fullURL = c.baseURL + rawURL
 	}
 
-	req, err := http.NewRequestWithContext(ctx.Context(), http.MethodGet, fullURL, nil)
+	reqCtx, cancel := ctx.requestContext(c.timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(reqCtx, http.MethodGet, fullURL, nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
@@ -149,6 +194,8 @@ func (c *Client) DownloadByURL(ctx *YouTrackContext, rawURL string) ([]byte, err
 		return nil, &APIError{
 			StatusCode: resp.StatusCode,
 			Message:    string(body),
+			Method:     http.MethodGet,
+			Path:       req.URL.Path,
 		}
 	}
 
@@ -162,74 +209,36 @@ func (c *Client) DownloadByURL(ctx *YouTrackContext, rawURL string) ([]byte, err
 
 // AddIssueAttachmentFromBytes uploads content as an attachment to an issue
 func (c *Client) AddIssueAttachmentFromBytes(ctx *YouTrackContext, issueID string, content []byte, filename string) (*Attachment, error) {
-	// Create multipart form data
-	var requestBody bytes.Buffer
-	writer := multipart.NewWriter(&requestBody)
-
-	// Add the file field
-	fileWriter, err := writer.CreateFormFile("file", filename)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create form file: %w", err)
-	}
-
-	// Write content to form
-	_, err = fileWriter.Write(content)
-	if err != nil {
-		return nil, fmt.Errorf("failed to write content: %w", err)
-	}
-
-	// Close the writer to finalize the form
-	err = writer.Close()
-	if err != nil {
-		return nil, fmt.Errorf("failed to close form writer: %w", err)
-	}
-
-	// Make the API request
-	path := fmt.Sprintf("/api/issues/%s/attachments", issueID)
-	resp, err := c.doMultipartRequest(ctx, http.MethodPost, path, &requestBody, writer.FormDataContentType())
-	if err != nil {
-		return nil, fmt.Errorf("failed to upload attachment: %w", err)
-	}
-	defer resp.Body.Close()
-
-	// Parse response
-	var attachments []*Attachment
-	if err := json.NewDecoder(resp.Body).Decode(&attachments); err != nil {
-		return nil, fmt.Errorf("failed to decode attachment response: %w", err)
-	}
-
-	// Find the newly created attachment
-	for _, attachment := range attachments {
-		if attachment.Name == filename {
-			return attachment, nil
-		}
-	}
-
-	// Fallback to first attachment
-	if len(attachments) > 0 {
-		return attachments[0], nil
-	}
-
-	return nil, fmt.Errorf("uploaded attachment not found in response")
+	return c.AddIssueAttachmentFromReader(ctx, issueID, bytes.NewReader(content), int64(len(content)), filename, nil)
 }
 
-// doMultipartRequest makes an HTTP request with multipart form data
-func (c *Client) doMultipartRequest(ctx *YouTrackContext, method, path string, body io.Reader, contentType string) (*http.Response, error) {
+// doMultipartRequest makes an HTTP request with multipart form data;
+// contentLength is the body size, or -1 to send it chunked.
+// Large uploads can outlast the client timeout, so only the context timeout applies.
+func (c *Client) doMultipartRequest(ctx *YouTrackContext, method, path string, body io.Reader, contentLength int64, contentType string) (*http.Response, error) {
 	fullURL := c.baseURL + path
 
-	req, err := http.NewRequestWithContext(ctx.Context(), method, fullURL, body)
+	reqCtx, cancel := ctx.requestContext(0)
+	req, err := http.NewRequestWithContext(reqCtx, method, fullURL, body)
 	if err != nil {
+		cancel()
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
 
 	req.Header.Set("Authorization", "Bearer "+ctx.APIKey)
 	req.Header.Set("Accept", "application/json")
 	req.Header.Set("Content-Type", contentType)
+	req.ContentLength = contentLength
 
-	resp, err := c.httpClient.Do(req)
+	uploadClient := *c.httpClient
+	uploadClient.Timeout = 0
+
+	resp, err := uploadClient.Do(req)
 	if err != nil {
+		cancel()
 		return nil, fmt.Errorf("request failed: %w", err)
 	}
+	resp.Body = &cancelOnClose{ReadCloser: resp.Body, cancel: cancel}
 
 	if resp.StatusCode >= 400 {
 		defer resp.Body.Close()
@@ -237,6 +246,8 @@ func (c *Client) doMultipartRequest(ctx *YouTrackContext, method, path string, b
 		return nil, &APIError{
 			StatusCode: resp.StatusCode,
 			Message:    string(bodyBytes),
+			Method:     method,
+			Path:       path,
 		}
 	}
 
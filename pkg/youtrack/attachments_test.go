@@ -0,0 +1,144 @@
+package youtrack
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestClient_GetIssueAttachments(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/issues/PROJ-1/attachments" {
+			http.NotFound(w, r)
+			return
+		}
+		if !strings.Contains(r.URL.Query().Get("fields"), "imageDimensions(width,height)") {
+			t.Errorf("Expected image dimensions in fields, got %s", r.URL.Query().Get("fields"))
+		}
+		fmt.Fprint(w, `[
+			{"id":"1-1","name":"screen.png","size":2048,"mimeType":"image/png","extension":"png",
+			 "url":"/api/files/1-1?sign=x","thumbnailURL":"/api/files/1-1?sign=x&thumbnail=true",
+			 "imageDimensions":{"width":800,"height":600}},
+			{"id":"1-2","name":"log.txt","size":100,"mimeType":"text/plain","extension":"txt","url":"/api/files/1-2?sign=y"}
+		]`)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL)
+	ctx := NewYouTrackContext(context.Background(), "token")
+
+	attachments, err := client.GetIssueAttachments(ctx, "PROJ-1")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(attachments) != 2 {
+		t.Fatalf("Expected 2 attachments, got %d", len(attachments))
+	}
+
+	tests := []struct {
+		name      string
+		isImage   bool
+		extension string
+		width     int
+	}{
+		{name: "screen.png", isImage: true, extension: "png", width: 800},
+		{name: "log.txt", isImage: false, extension: "txt"},
+	}
+
+	for i, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			att := attachments[i]
+			if att.IsImage() != tt.isImage {
+				t.Errorf("Expected IsImage %v, got %v", tt.isImage, att.IsImage())
+			}
+			if att.Extension != tt.extension {
+				t.Errorf("Expected extension %s, got %s", tt.extension, att.Extension)
+			}
+			width := 0
+			if att.ImageDimensions != nil {
+				width = att.ImageDimensions.Width
+			}
+			if width != tt.width {
+				t.Errorf("Expected width %d, got %d", tt.width, width)
+			}
+			if tt.isImage && att.ThumbnailURL == "" {
+				t.Error("Expected a thumbnail URL")
+			}
+		})
+	}
+}
+
+func TestClient_DeleteIssueAttachment(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodDelete || r.URL.Path != "/api/issues/PROJ-1/attachments/1-1" {
+			http.NotFound(w, r)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL)
+	ctx := NewYouTrackContext(context.Background(), "token")
+
+	if err := client.DeleteIssueAttachment(ctx, "PROJ-1", "1-1"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if err := client.DeleteIssueAttachment(ctx, "PROJ-1", "1-9"); err == nil {
+		t.Fatal("Expected error for a missing attachment")
+	}
+}
+
+func TestClient_AddIssueAttachmentFromReader(t *testing.T) {
+	content := strings.Repeat("x", 100000)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/api/issues/PROJ-1/attachments" {
+			http.NotFound(w, r)
+			return
+		}
+		file, header, err := r.FormFile("file")
+		if err != nil {
+			t.Fatalf("Failed to read form file: %v", err)
+		}
+		defer file.Close()
+		data, _ := io.ReadAll(file)
+		if string(data) != content {
+			t.Errorf("Expected %d bytes of content, got %d", len(content), len(data))
+		}
+		fmt.Fprintf(w, `[{"id":"1-3","name":"%s","size":%d}]`, header.Filename, len(data))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL)
+	ctx := NewYouTrackContext(context.Background(), "token")
+
+	tests := []struct {
+		name string
+		size int64
+	}{
+		{name: "known size", size: int64(len(content))},
+		{name: "unknown size", size: -1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var sent, total int64
+			attachment, err := client.AddIssueAttachmentFromReader(ctx, "PROJ-1", strings.NewReader(content), tt.size, "big.log",
+				func(s, t int64) { sent, total = s, t })
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if attachment.ID != "1-3" || attachment.Name != "big.log" {
+				t.Errorf("Unexpected attachment: %+v", attachment)
+			}
+			if sent != int64(len(content)) || total != tt.size {
+				t.Errorf("Expected progress %d/%d, got %d/%d", len(content), tt.size, sent, total)
+			}
+		})
+	}
+}
@@ -0,0 +1,243 @@
+package youtrack
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+)
+
+const (
+	// defaultBatchConcurrency is the number of operations of a batch run at once when none is given
+	defaultBatchConcurrency = 4
+	// defaultBatchRetries is how many times a failed operation is retried when none is given
+	defaultBatchRetries = 2
+	// defaultBatchRetryDelay is the wait before the first retry, doubled for each next one
+	defaultBatchRetryDelay = 500 * time.Millisecond
+)
+
+// BatchOperationType is the kind of a batch operation
+type BatchOperationType string
+
+// Batch operation types
+const (
+	BatchCreate  BatchOperationType = "create"
+	BatchUpdate  BatchOperationType = "update"
+	BatchComment BatchOperationType = "comment"
+)
+
+// BatchOperation is one change of a batch: an issue to create, an issue to update, or a
+// comment to add. Apply replaces the built-in operations with a custom one, Type then labels
+// it in the report and tells whether it may be repeated (see BatchOptions.Retries).
+type BatchOperation struct {
+	Type BatchOperationType `json:"type"`
+	// IssueID is the issue updated or commented on
+	IssueID string              `json:"issueId,omitempty"`
+	Create  *CreateIssueRequest `json:"create,omitempty"`
+	Update  *UpdateIssueRequest `json:"update,omitempty"`
+	Comment string              `json:"comment,omitempty"`
+	// Apply is a custom operation on the issue
+	Apply func(ctx *YouTrackContext) error `json:"-"`
+}
+
+// BatchClient is the part of the API the built-in batch operations use
+type BatchClient interface {
+	CreateIssue(ctx *YouTrackContext, req *CreateIssueRequest) (*Issue, error)
+	UpdateIssue(ctx *YouTrackContext, issueID string, req *UpdateIssueRequest) (*Issue, error)
+	AddIssueComment(ctx *YouTrackContext, issueID string, text string) (*IssueComment, error)
+}
+
+// BatchOptions configures a BatchExecutor
+type BatchOptions struct {
+	Concurrency int // operations run at once, defaults to 4
+	// Retries is how many times an operation failing with a transient error (429, 502, 503,
+	// 504 or no response) is retried, defaults to 2; a negative value disables retries.
+	// Creates and comments are only retried on 429 and 503, as after the other errors they
+	// may have been applied and a retry would duplicate them.
+	Retries    int
+	RetryDelay time.Duration // wait before the first retry, doubled for each next one, defaults to 500ms
+}
+
+// BatchResult is the outcome of one operation of a batch
+type BatchResult struct {
+	// Index is the position of the operation in the batch
+	Index int                `json:"index"`
+	Type  BatchOperationType `json:"type"`
+	// IssueID is the issue of the operation, the new one for a create
+	IssueID  string `json:"issueId,omitempty"`
+	Success  bool   `json:"success"`
+	Attempts int    `json:"attempts"`
+	Error    string `json:"error,omitempty"`
+	// Err is the error of the last attempt
+	Err error `json:"-"`
+}
+
+// BatchReport is the aggregated outcome of a batch, the results in the order of the operations
+type BatchReport struct {
+	Succeeded int           `json:"succeeded"`
+	Failed    int           `json:"failed"`
+	Results   []BatchResult `json:"results"`
+}
+
+// BatchExecutor runs the operations of a batch with bounded concurrency and retries
+type BatchExecutor struct {
+	client BatchClient
+	opts   BatchOptions
+}
+
+// NewBatchExecutor creates an executor running the operations with the client; zero options
+// take the defaults
+func NewBatchExecutor(client BatchClient, opts BatchOptions) *BatchExecutor {
+	if opts.Concurrency < 1 {
+		opts.Concurrency = defaultBatchConcurrency
+	}
+	if opts.Retries == 0 {
+		opts.Retries = defaultBatchRetries
+	} else if opts.Retries < 0 {
+		opts.Retries = 0
+	}
+	if opts.RetryDelay <= 0 {
+		opts.RetryDelay = defaultBatchRetryDelay
+	}
+	return &BatchExecutor{client: client, opts: opts}
+}
+
+// Execute runs all the operations and reports each of them. A failed operation does not stop
+// the others; once the context is cancelled, the operations not started yet fail with its error.
+func (e *BatchExecutor) Execute(ctx *YouTrackContext, ops []BatchOperation) *BatchReport {
+	report := &BatchReport{Results: make([]BatchResult, len(ops))}
+
+	sem := make(chan struct{}, e.opts.Concurrency)
+	var wg sync.WaitGroup
+	for i := range ops {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(i int) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			report.Results[i] = e.run(ctx, i, &ops[i])
+		}(i)
+	}
+	wg.Wait()
+
+	for _, result := range report.Results {
+		if result.Success {
+			report.Succeeded++
+		} else {
+			report.Failed++
+		}
+	}
+	return report
+}
+
+// run applies an operation, retrying it while it fails with a transient error
+func (e *BatchExecutor) run(ctx *YouTrackContext, index int, op *BatchOperation) BatchResult {
+	result := BatchResult{Index: index, Type: op.Type, IssueID: op.IssueID}
+
+	err := validateBatchOperation(op)
+	delay := e.opts.RetryDelay
+	for err == nil {
+		if err = ctx.Context().Err(); err != nil {
+			break
+		}
+
+		result.Attempts++
+		var issueID string
+		issueID, err = e.apply(ctx, op)
+		if err == nil {
+			if issueID != "" {
+				result.IssueID = issueID
+			}
+			result.Success = true
+			return result
+		}
+		if result.Attempts > e.opts.Retries || !isRetryable(ctx, op, err) {
+			break
+		}
+
+		select {
+		case <-ctx.Context().Done():
+		case <-time.After(delay):
+		}
+		delay *= 2
+		err = nil
+	}
+
+	result.Err = TranslateError(err)
+	result.Error = result.Err.Error()
+	return result
+}
+
+// apply runs an operation once and returns the ID of the issue it created
+func (e *BatchExecutor) apply(ctx *YouTrackContext, op *BatchOperation) (string, error) {
+	if op.Apply != nil {
+		return "", op.Apply(ctx)
+	}
+
+	switch op.Type {
+	case BatchCreate:
+		issue, err := e.client.CreateIssue(ctx, op.Create)
+		if err != nil {
+			return "", err
+		}
+		return issue.ID, nil
+	case BatchUpdate:
+		_, err := e.client.UpdateIssue(ctx, op.IssueID, op.Update)
+		return "", err
+	default:
+		_, err := e.client.AddIssueComment(ctx, op.IssueID, op.Comment)
+		return "", err
+	}
+}
+
+// validateBatchOperation checks that an operation has what its type needs
+func validateBatchOperation(op *BatchOperation) error {
+	if op.Apply != nil {
+		return nil
+	}
+
+	switch op.Type {
+	case BatchCreate:
+		if op.Create == nil {
+			return fmt.Errorf("create operation without an issue")
+		}
+	case BatchUpdate:
+		if op.IssueID == "" || op.Update == nil {
+			return fmt.Errorf("update operation needs an issue ID and the changes")
+		}
+	case BatchComment:
+		if op.IssueID == "" || op.Comment == "" {
+			return fmt.Errorf("comment operation needs an issue ID and a text")
+		}
+	default:
+		return fmt.Errorf("unknown operation type '%s' (use create, update or comment)", op.Type)
+	}
+	return nil
+}
+
+// isRetryable reports whether a failed operation may succeed when repeated: the server was
+// overloaded or unavailable, or no response was received while the caller still waits.
+// Creates and comments are not idempotent, so they are only repeated when the server
+// refused the request (429, 503).
+func isRetryable(ctx *YouTrackContext, op *BatchOperation, err error) bool {
+	if ctx.Context().Err() != nil {
+		return false
+	}
+	idempotent := op.Type != BatchCreate && op.Type != BatchComment
+
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		switch apiErr.StatusCode {
+		case http.StatusTooManyRequests, http.StatusServiceUnavailable:
+			return true
+		case http.StatusBadGateway, http.StatusGatewayTimeout:
+			return idempotent
+		}
+		return false
+	}
+
+	var netErr net.Error
+	return idempotent && errors.As(err, &netErr)
+}
@@ -0,0 +1,158 @@
+package youtrack
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestBatchExecutor_Execute(t *testing.T) {
+	var mu sync.Mutex
+	calls := make(map[string]int)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		calls[r.URL.Path]++
+		count := calls[r.URL.Path]
+		mu.Unlock()
+
+		switch {
+		case r.URL.Path == "/api/issues":
+			w.Write([]byte(`{"id":"2-9","idReadable":"PRJ-9","summary":"New"}`))
+		case strings.HasPrefix(r.URL.Path, "/api/issues/PRJ-404"):
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"error":"Not Found"}`))
+		case strings.HasPrefix(r.URL.Path, "/api/issues/PRJ-503"):
+			// Unavailable on the first call only
+			if count == 1 {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+			w.Write([]byte(`{"id":"c-1","text":"Hi"}`))
+		case strings.HasPrefix(r.URL.Path, "/api/issues/PRJ-DOWN"):
+			w.WriteHeader(http.StatusBadGateway)
+		default:
+			w.Write([]byte(`{"id":"2-1","idReadable":"PRJ-1","summary":"Updated"}`))
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL)
+	ctx := NewYouTrackContext(context.Background(), "token")
+	summary := "Updated"
+
+	var applied []string
+	ops := []BatchOperation{
+		{Type: BatchCreate, Create: &CreateIssueRequest{Project: ProjectRef{ID: "0-1"}, Summary: "New"}},
+		{Type: BatchUpdate, IssueID: "PRJ-1", Update: &UpdateIssueRequest{Summary: &summary}},
+		{Type: BatchComment, IssueID: "PRJ-503", Comment: "Hi"},
+		{Type: BatchComment, IssueID: "PRJ-404", Comment: "Hi"},
+		{Type: BatchUpdate, IssueID: "PRJ-DOWN", Update: &UpdateIssueRequest{Summary: &summary}},
+		{Type: BatchComment, IssueID: "PRJ-DOWN", Comment: "Hi"},
+		{Type: BatchUpdate, IssueID: "PRJ-1"},
+		{Type: "delete", IssueID: "PRJ-1"},
+		{Type: "tag", IssueID: "PRJ-7", Apply: func(ctx *YouTrackContext) error {
+			applied = append(applied, "PRJ-7")
+			return nil
+		}},
+	}
+
+	executor := NewBatchExecutor(client, BatchOptions{Concurrency: 1, Retries: 2, RetryDelay: time.Millisecond})
+	report := executor.Execute(ctx, ops)
+
+	tests := []struct {
+		name     string
+		issueID  string
+		success  bool
+		attempts int
+		err      string
+	}{
+		{name: "Create returns the new issue", issueID: "PRJ-9", success: true, attempts: 1},
+		{name: "Update", issueID: "PRJ-1", success: true, attempts: 1},
+		{name: "Transient error is retried", issueID: "PRJ-503", success: true, attempts: 2},
+		{name: "Not found is not retried", issueID: "PRJ-404", attempts: 1, err: "status 404"},
+		{name: "Retries are bounded", issueID: "PRJ-DOWN", attempts: 3, err: "status 502"},
+		{name: "Comment is not retried on 502", issueID: "PRJ-DOWN", attempts: 1, err: "status 502"},
+		{name: "Update without changes", issueID: "PRJ-1", err: "needs an issue ID and the changes"},
+		{name: "Unknown type", issueID: "PRJ-1", err: "unknown operation type 'delete'"},
+		{name: "Custom operation", issueID: "PRJ-7", success: true, attempts: 1},
+	}
+
+	if len(report.Results) != len(tests) {
+		t.Fatalf("Expected %d results, got %d", len(tests), len(report.Results))
+	}
+	for i, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := report.Results[i]
+			if result.Index != i {
+				t.Errorf("Expected index %d, got %d", i, result.Index)
+			}
+			if result.IssueID != tt.issueID {
+				t.Errorf("Expected issue %s, got %s", tt.issueID, result.IssueID)
+			}
+			if result.Success != tt.success {
+				t.Errorf("Expected success %v, got %v (%s)", tt.success, result.Success, result.Error)
+			}
+			if result.Attempts != tt.attempts {
+				t.Errorf("Expected %d attempts, got %d", tt.attempts, result.Attempts)
+			}
+			if !strings.Contains(result.Error, tt.err) || (tt.err == "") != (result.Error == "") {
+				t.Errorf("Expected error containing %q, got %q", tt.err, result.Error)
+			}
+		})
+	}
+
+	if report.Succeeded != 4 || report.Failed != 5 {
+		t.Errorf("Expected 4 succeeded and 5 failed, got %d and %d", report.Succeeded, report.Failed)
+	}
+	if len(applied) != 1 {
+		t.Errorf("Expected the custom operation to run once, got %v", applied)
+	}
+}
+
+func TestBatchExecutor_Cancelled(t *testing.T) {
+	parent, cancel := context.WithCancel(context.Background())
+	cancel()
+	ctx := NewYouTrackContext(parent, "token")
+
+	ran := false
+	executor := NewBatchExecutor(NewClient("http://127.0.0.1:1"), BatchOptions{})
+	report := executor.Execute(ctx, []BatchOperation{{Type: "tag", Apply: func(ctx *YouTrackContext) error {
+		ran = true
+		return nil
+	}}})
+
+	if ran {
+		t.Error("Expected the operation not to run after cancellation")
+	}
+	if report.Failed != 1 || !errors.Is(report.Results[0].Err, context.Canceled) {
+		t.Errorf("Expected the operation to fail with the context error, got %+v", report.Results[0])
+	}
+}
+
+func TestBatchExecutor_NoResponse(t *testing.T) {
+	ctx := NewYouTrackContext(context.Background(), "token")
+	summary := "Updated"
+
+	// Nothing listens on the port, the requests get no response
+	executor := NewBatchExecutor(NewClient("http://127.0.0.1:1"), BatchOptions{Retries: 2, RetryDelay: time.Millisecond})
+	report := executor.Execute(ctx, []BatchOperation{
+		{Type: BatchUpdate, IssueID: "PRJ-1", Update: &UpdateIssueRequest{Summary: &summary}},
+		{Type: BatchCreate, Create: &CreateIssueRequest{Project: ProjectRef{ID: "0-1"}, Summary: "New"}},
+		{Type: BatchComment, IssueID: "PRJ-1", Comment: "Hi"},
+	})
+
+	for i, attempts := range []int{3, 1, 1} {
+		result := report.Results[i]
+		if result.Success {
+			t.Errorf("Expected operation %d to fail", i)
+		}
+		if result.Attempts != attempts {
+			t.Errorf("Expected %d attempts for operation %d, got %d", attempts, i, result.Attempts)
+		}
+	}
+}
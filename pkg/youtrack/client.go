@@ -2,11 +2,14 @@ package youtrack
 
 import (
 	"bytes"
+	"context"
+	"crypto/tls"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
 	"net/url"
+	"sync"
 	"time"
 )
 
@@ -16,11 +19,55 @@ type RESTLogger interface {
 	LogRESTError(method, path string, body interface{}, statusCode int, errMsg string)
 }
 
+// RESTBodyLogger is implemented by REST loggers that also capture request and response
+// bodies, e.g. for debugging. Capture is decided per request, so it can be toggled at runtime.
+// The Authorization header and any occurrence of the API key in the bodies are redacted.
+type RESTBodyLogger interface {
+	CaptureBodies() bool
+	LogRESTBodies(exchange *RESTExchange)
+}
+
+// RESTExchange is a captured REST request and its response
+type RESTExchange struct {
+	Method         string
+	URL            string
+	RequestHeader  http.Header
+	RequestBody    []byte
+	StatusCode     int
+	ResponseHeader http.Header
+	ResponseBody   []byte
+	Duration       time.Duration
+	// Err is the transport error, when no response was received
+	Err error
+}
+
+// TraceHook is called after each HTTP request with its method, URL and outcome;
+// status is 0 and err is set when no response was received
+type TraceHook func(method, url string, status int, duration time.Duration, err error)
+
+// redacted replaces the API key in captured data
+const redacted = "[REDACTED]"
+
 type Client struct {
 	baseURL    string
 	hubURL     string
 	httpClient *http.Client
-	logger     RESTLogger
+	// timeout is the default request timeout, applied as a context deadline so that a
+	// longer one can be set per call
+	timeout   time.Duration
+	logger    RESTLogger
+	trace     TraceHook
+	userAgent string
+
+	// Transport settings kept so that SetTransportConfig rebuilds the same transport
+	tlsConfig  *tls.Config
+	proxy      func(*http.Request) (*url.URL, error)
+	middleware []Middleware
+	base       *http.Transport
+
+	// Server capabilities, detected on first use
+	versionMu    sync.Mutex
+	capabilities *Capabilities
 }
 
 // SetLogger sets the REST logger for the client
@@ -28,25 +75,39 @@ func (c *Client) SetLogger(logger RESTLogger) {
 	c.logger = logger
 }
 
+// SetTraceHook sets a hook called after each HTTP request, e.g. to print the request line
+// and the response status when debugging
+func (c *Client) SetTraceHook(hook TraceHook) {
+	c.trace = hook
+}
+
 // SetHubURL sets the Hub instance URL for Hub REST API calls (e.g. project team users).
 func (c *Client) SetHubURL(hubURL string) {
 	c.hubURL = hubURL
 }
 
+// SetTimeout sets the default timeout applied to every HTTP request made by the client,
+// zero for none. Use YouTrackContext.WithTimeout to override it, shorter or longer, for
+// individual calls.
+func (c *Client) SetTimeout(timeout time.Duration) {
+	c.timeout = timeout
+}
+
 func NewClient(baseURL string) *Client {
-	return &Client{
-		baseURL: baseURL,
-		httpClient: &http.Client{
-			Timeout: 30 * time.Second,
-		},
+	c := &Client{
+		baseURL:    baseURL,
+		httpClient: &http.Client{},
+		timeout:    30 * time.Second,
 	}
+	c.buildTransport(DefaultTransportConfig())
+	return c
 }
 
 func (c *Client) doRequest(ctx *YouTrackContext, method, path string, query url.Values, body interface{}) (*http.Response, error) {
-	return c.doRequestWithBase(c.baseURL, ctx, method, path, query, body)
+	return c.doRequestWithBase(c.baseURL, ctx, method, path, query, nil, body)
 }
 
-func (c *Client) doRequestWithBase(baseURL string, ctx *YouTrackContext, method, path string, query url.Values, body interface{}) (*http.Response, error) {
+func (c *Client) doRequestWithBase(baseURL string, ctx *YouTrackContext, method, path string, query url.Values, header http.Header, body interface{}) (*http.Response, error) {
 	start := time.Now()
 
 	u, err := url.Parse(baseURL)
@@ -60,31 +121,63 @@ func (c *Client) doRequestWithBase(baseURL string, ctx *YouTrackContext, method,
 	}
 
 	var reqBody io.Reader
+	var jsonBody []byte
 	if body != nil {
-		jsonBody, err := json.Marshal(body)
+		jsonBody, err = json.Marshal(body)
 		if err != nil {
 			return nil, fmt.Errorf("failed to marshal request body: %w", err)
 		}
 		reqBody = bytes.NewReader(jsonBody)
 	}
 
-	req, err := http.NewRequestWithContext(ctx.Context(), method, u.String(), reqBody)
+	reqCtx, cancel := ctx.requestContext(c.timeout)
+	req, err := http.NewRequestWithContext(reqCtx, method, u.String(), reqBody)
 	if err != nil {
+		cancel()
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
 
-	req.Header.Set("Authorization", "Bearer "+ctx.APIKey)
 	req.Header.Set("Accept", "application/json")
+	if c.userAgent != "" {
+		req.Header.Set("User-Agent", c.userAgent)
+	}
 	if body != nil {
 		req.Header.Set("Content-Type", "application/json")
 	}
+	for name, values := range header {
+		req.Header[http.CanonicalHeaderKey(name)] = values
+	}
+	req.Header.Set("Authorization", "Bearer "+ctx.APIKey)
 
 	resp, err := c.httpClient.Do(req)
 	duration := time.Since(start)
 
+	if c.trace != nil {
+		status := 0
+		if resp != nil {
+			status = resp.StatusCode
+		}
+		c.trace(method, u.String(), status, duration, err)
+	}
+
+	bodyLogger, capture := c.logger.(RESTBodyLogger)
+	capture = capture && bodyLogger.CaptureBodies()
+
 	if err != nil {
+		cancel()
+		if capture {
+			bodyLogger.LogRESTBodies(newRESTExchange(req, jsonBody, nil, nil, duration, err, ctx.APIKey))
+		}
 		return nil, fmt.Errorf("request failed: %w", err)
 	}
+	if capture {
+		// The whole body is read to log it, then served from memory
+		respBody, readErr := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		bodyLogger.LogRESTBodies(newRESTExchange(req, jsonBody, resp, respBody, time.Since(start), readErr, ctx.APIKey))
+		resp.Body = io.NopCloser(bytes.NewReader(respBody))
+	}
+	resp.Body = &cancelOnClose{ReadCloser: resp.Body, cancel: cancel}
 
 	// Log successful REST call
 	if c.logger != nil {
@@ -104,12 +197,88 @@ func (c *Client) doRequestWithBase(baseURL string, ctx *YouTrackContext, method,
 		return nil, &APIError{
 			StatusCode: resp.StatusCode,
 			Message:    errMsg,
+			Method:     method,
+			Path:       path,
 		}
 	}
 
 	return resp, nil
 }
 
+// newRESTExchange builds a captured exchange with the API key redacted
+func newRESTExchange(req *http.Request, reqBody []byte, resp *http.Response, respBody []byte, duration time.Duration, err error, apiKey string) *RESTExchange {
+	redact := func(data []byte) []byte {
+		if apiKey == "" || len(data) == 0 {
+			return data
+		}
+		return bytes.ReplaceAll(data, []byte(apiKey), []byte(redacted))
+	}
+
+	exchange := &RESTExchange{
+		Method:        req.Method,
+		URL:           req.URL.String(),
+		RequestHeader: req.Header.Clone(),
+		RequestBody:   redact(reqBody),
+		Duration:      duration,
+		Err:           err,
+	}
+	if exchange.RequestHeader.Get("Authorization") != "" {
+		exchange.RequestHeader.Set("Authorization", "Bearer "+redacted)
+	}
+	if resp != nil {
+		exchange.StatusCode = resp.StatusCode
+		exchange.ResponseHeader = resp.Header.Clone()
+		exchange.ResponseBody = redact(respBody)
+	}
+	return exchange
+}
+
+// cancelOnClose releases the request context once the response body is closed
+type cancelOnClose struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (b *cancelOnClose) Close() error {
+	err := b.ReadCloser.Close()
+	b.cancel()
+	return err
+}
+
+// RequestOptions are the optional parts of a low-level request
+type RequestOptions struct {
+	// Query holds the query parameters
+	Query url.Values
+	// Fields sets the fields parameter, the attributes of the entities the response returns,
+	// e.g. "id,idReadable,summary"; it replaces a fields value of Query
+	Fields string
+	// Header holds extra request headers. They may replace Accept and Content-Type, never the
+	// Authorization header of the context's API key
+	Header http.Header
+}
+
+// values returns the query parameters with the fields, without changing the options
+func (o *RequestOptions) values() url.Values {
+	if o == nil || (o.Query == nil && o.Fields == "") {
+		return nil
+	}
+	query := make(url.Values, len(o.Query)+1)
+	for key, values := range o.Query {
+		query[key] = append([]string(nil), values...)
+	}
+	if o.Fields != "" {
+		query.Set("fields", o.Fields)
+	}
+	return query
+}
+
+// The low-level methods below send a request to a REST path of the server, e.g.
+// "/api/issues/PRJ-1", with the client's token, transport, logger and trace hook, for the
+// endpoints the client has no method for. Their signatures are stable within a major version.
+// A body is encoded as JSON, a json.RawMessage is sent as is. A status of 400 or more is
+// returned as an *APIError; otherwise the caller closes the response body.
+
+// Get sends a GET request with the query parameters
 func (c *Client) Get(ctx *YouTrackContext, path string, query url.Values) (*http.Response, error) {
 	return c.doRequest(ctx, http.MethodGet, path, query, nil)
 }
@@ -118,21 +287,34 @@ func (c *Client) hubGet(ctx *YouTrackContext, path string, query url.Values) (*h
 	if c.hubURL == "" {
 		return nil, fmt.Errorf("hub URL is not configured; set hub_url in config to use project team features")
 	}
-	return c.doRequestWithBase(c.hubURL, ctx, http.MethodGet, path, query, nil)
+	return c.doRequestWithBase(c.hubURL, ctx, http.MethodGet, path, query, nil, nil)
 }
 
+// Post sends a POST request with the body
 func (c *Client) Post(ctx *YouTrackContext, path string, body interface{}) (*http.Response, error) {
 	return c.doRequest(ctx, http.MethodPost, path, nil, body)
 }
 
+// PostWithQuery sends a POST request with the query parameters and the body
 func (c *Client) PostWithQuery(ctx *YouTrackContext, path string, query url.Values, body interface{}) (*http.Response, error) {
 	return c.doRequest(ctx, http.MethodPost, path, query, body)
 }
 
+// Put sends a PUT request with the body
 func (c *Client) Put(ctx *YouTrackContext, path string, body interface{}) (*http.Response, error) {
 	return c.doRequest(ctx, http.MethodPut, path, nil, body)
 }
 
+// Delete sends a DELETE request
 func (c *Client) Delete(ctx *YouTrackContext, path string) (*http.Response, error) {
 	return c.doRequest(ctx, http.MethodDelete, path, nil, nil)
 }
+
+// Do sends a request with any method, the body and the options, which may be nil
+func (c *Client) Do(ctx *YouTrackContext, method, path string, body interface{}, opts *RequestOptions) (*http.Response, error) {
+	var header http.Header
+	if opts != nil {
+		header = opts.Header
+	}
+	return c.doRequestWithBase(c.baseURL, ctx, method, path, opts.values(), header, body)
+}
@@ -0,0 +1,345 @@
+package youtrack
+
+import (
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestClient_RequestTimeout(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-time.After(2 * time.Second):
+		}
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL)
+
+	t.Run("Per-request timeout", func(t *testing.T) {
+		ctx := NewYouTrackContext(context.Background(), "token").WithTimeout(50 * time.Millisecond)
+		_, err := client.GetCurrentUser(ctx)
+		if !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("Expected deadline exceeded error, got %v", err)
+		}
+	})
+
+	t.Run("Caller cancellation", func(t *testing.T) {
+		parent, cancel := context.WithCancel(context.Background())
+		time.AfterFunc(50*time.Millisecond, cancel)
+		_, err := client.GetCurrentUser(NewYouTrackContext(parent, "token"))
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("Expected canceled error, got %v", err)
+		}
+	})
+}
+
+func TestClient_PerCallTimeoutLongerThanClient(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-time.After(200 * time.Millisecond):
+		}
+		w.Write([]byte(`{"id":"1-1","login":"john"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL)
+	client.SetTimeout(50 * time.Millisecond)
+	ctx := NewYouTrackContext(context.Background(), "token")
+
+	if _, err := client.GetCurrentUser(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected the client timeout to apply, got %v", err)
+	}
+
+	user, err := client.GetCurrentUser(ctx.WithTimeout(2 * time.Second))
+	if err != nil {
+		t.Fatalf("Expected the per-call timeout to replace the client timeout, got %v", err)
+	}
+	if user.Login != "john" {
+		t.Errorf("Expected login john, got %s", user.Login)
+	}
+}
+
+func TestClient_TimeoutKeepsBodyReadable(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"id":"1-1","login":"john"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL)
+	ctx := NewYouTrackContext(context.Background(), "token").WithTimeout(time.Second)
+
+	user, err := client.GetCurrentUser(ctx)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if user.Login != "john" {
+		t.Errorf("Expected login john, got %s", user.Login)
+	}
+}
+
+func TestClient_GzipResponse(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.Contains(r.Header.Get("Accept-Encoding"), "gzip") {
+			w.Write([]byte(`{"login":"plain"}`))
+			return
+		}
+		w.Header().Set("Content-Encoding", "gzip")
+		gz := gzip.NewWriter(w)
+		gz.Write([]byte(`{"login":"gzipped"}`))
+		gz.Close()
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		name     string
+		config   TransportConfig
+		expected string
+	}{
+		{name: "Compression enabled", config: DefaultTransportConfig(), expected: "gzipped"},
+		{name: "Compression disabled", config: TransportConfig{DisableCompression: true}, expected: "plain"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := NewClient(server.URL)
+			client.SetTransportConfig(tt.config)
+
+			user, err := client.GetCurrentUser(NewYouTrackContext(context.Background(), "token"))
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if user.Login != tt.expected {
+				t.Errorf("Expected login %s, got %s", tt.expected, user.Login)
+			}
+		})
+	}
+}
+
+func TestClient_HTTP2(t *testing.T) {
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{"login":%q}`, r.Proto)
+	}))
+	server.EnableHTTP2 = true
+	server.StartTLS()
+	defer server.Close()
+
+	tests := []struct {
+		name     string
+		config   TransportConfig
+		expected string
+	}{
+		{name: "Default", config: DefaultTransportConfig(), expected: "HTTP/2.0"},
+		{name: "HTTP/2 disabled", config: TransportConfig{}, expected: "HTTP/1.1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tlsConfig := server.Client().Transport.(*http.Transport).TLSClientConfig
+			client, err := NewClientWithOptions(server.URL, WithTLSConfig(tlsConfig), WithTransportConfig(tt.config))
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			user, err := client.GetCurrentUser(NewYouTrackContext(context.Background(), "token"))
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if user.Login != tt.expected {
+				t.Errorf("Expected protocol %s, got %s", tt.expected, user.Login)
+			}
+		})
+	}
+}
+
+// captureLogger records the exchanges passed to LogRESTBodies
+type captureLogger struct {
+	capture   bool
+	exchanges []*RESTExchange
+}
+
+func (l *captureLogger) LogRESTCall(method, path string, duration time.Duration) {}
+
+func (l *captureLogger) LogRESTError(method, path string, body interface{}, statusCode int, errMsg string) {
+}
+
+func (l *captureLogger) CaptureBodies() bool { return l.capture }
+
+func (l *captureLogger) LogRESTBodies(exchange *RESTExchange) {
+	l.exchanges = append(l.exchanges, exchange)
+}
+
+func TestClient_CaptureBodies(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"id":"2-1","text":"secret-token-123 was pasted"}`))
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		name     string
+		capture  bool
+		expected int
+	}{
+		{name: "Capture enabled", capture: true, expected: 1},
+		{name: "Capture disabled", capture: false, expected: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			logger := &captureLogger{capture: tt.capture}
+			client := NewClient(server.URL)
+			client.SetLogger(logger)
+			ctx := NewYouTrackContext(context.Background(), "secret-token-123")
+
+			comment, err := client.AddIssueComment(ctx, "PRJ-1", "uses secret-token-123")
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if comment.ID != "2-1" {
+				t.Errorf("Expected the response to stay readable, got comment %q", comment.ID)
+			}
+			if len(logger.exchanges) != tt.expected {
+				t.Fatalf("Expected %d captured exchanges, got %d", tt.expected, len(logger.exchanges))
+			}
+			if tt.expected == 0 {
+				return
+			}
+
+			exchange := logger.exchanges[0]
+			if exchange.Method != http.MethodPost || exchange.StatusCode != http.StatusOK {
+				t.Errorf("Unexpected exchange %s %d", exchange.Method, exchange.StatusCode)
+			}
+			if got := exchange.RequestHeader.Get("Authorization"); got != "Bearer [REDACTED]" {
+				t.Errorf("Expected the Authorization header to be redacted, got %q", got)
+			}
+			for _, body := range []string{string(exchange.RequestBody), string(exchange.ResponseBody)} {
+				if strings.Contains(body, "secret-token-123") || !strings.Contains(body, "[REDACTED]") {
+					t.Errorf("Expected the API key to be redacted in %q", body)
+				}
+			}
+		})
+	}
+}
+
+func TestClient_TraceHook(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.Contains(r.URL.Path, "missing") {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Write([]byte(`{"login":"john"}`))
+	}))
+	defer server.Close()
+
+	var traced []string
+	client := NewClient(server.URL)
+	client.SetTraceHook(func(method, url string, status int, duration time.Duration, err error) {
+		traced = append(traced, fmt.Sprintf("%s %s %d", method, strings.TrimPrefix(url, server.URL), status))
+	})
+	ctx := NewYouTrackContext(context.Background(), "token")
+
+	client.GetCurrentUser(ctx)
+	client.GetIssue(ctx, "missing")
+
+	expected := []string{
+		"GET /api/users/me?fields=id%2Clogin%2CfullName%2Cemail%2CavatarUrl%2Cbanned%2Conline 200",
+		"GET /api/issues/missing",
+	}
+	if len(traced) != len(expected) {
+		t.Fatalf("Expected %d traced requests, got %v", len(expected), traced)
+	}
+	if traced[0] != expected[0] {
+		t.Errorf("Expected %q, got %q", expected[0], traced[0])
+	}
+	if !strings.HasPrefix(traced[1], expected[1]) || !strings.HasSuffix(traced[1], " 404") {
+		t.Errorf("Expected %q with status 404, got %q", expected[1], traced[1])
+	}
+}
+
+func TestClient_Do(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		body, _ := io.ReadAll(r.Body)
+		fmt.Fprintf(w, `{"method":%q,"query":%q,"accept":%q,"body":%q}`, r.Method, r.URL.RawQuery, r.Header.Get("Accept"), body)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL)
+	ctx := NewYouTrackContext(context.Background(), "token")
+
+	tests := []struct {
+		name     string
+		method   string
+		body     interface{}
+		opts     *RequestOptions
+		expected string
+	}{
+		{
+			name:     "No options",
+			method:   http.MethodGet,
+			expected: `{"method":"GET","query":"","accept":"application/json","body":""}`,
+		},
+		{
+			name:     "Fields replace the query value",
+			method:   http.MethodGet,
+			opts:     &RequestOptions{Query: url.Values{"fields": {"id"}, "top": {"5"}}, Fields: "id,name"},
+			expected: `{"method":"GET","query":"fields=id%2Cname&top=5","accept":"application/json","body":""}`,
+		},
+		{
+			name:     "Raw JSON body",
+			method:   http.MethodPatch,
+			body:     json.RawMessage(`{"name":"x"}`),
+			expected: `{"method":"PATCH","query":"","accept":"application/json","body":"{\"name\":\"x\"}"}`,
+		},
+		{
+			name:   "Headers keep the token",
+			method: http.MethodGet,
+			opts: &RequestOptions{Header: http.Header{
+				"accept":        {"text/plain"},
+				"Authorization": {"Bearer other"},
+			}},
+			expected: `{"method":"GET","query":"","accept":"text/plain","body":""}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := client.Do(ctx, tt.method, "/api/admin/projects", tt.body, tt.opts)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			defer resp.Body.Close()
+			got, _ := io.ReadAll(resp.Body)
+			if string(got) != tt.expected {
+				t.Errorf("Expected %s, got %s", tt.expected, got)
+			}
+		})
+	}
+
+	t.Run("Options are not changed", func(t *testing.T) {
+		opts := &RequestOptions{Query: url.Values{"top": {"5"}}, Fields: "id"}
+		resp, err := client.Do(ctx, http.MethodGet, "/api/admin/projects", nil, opts)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		resp.Body.Close()
+		if _, ok := opts.Query["fields"]; ok {
+			t.Errorf("Expected the query of the options to be left as is, got %v", opts.Query)
+		}
+	})
+}
@@ -6,11 +6,14 @@ import (
 	"net/url"
 )
 
+// commentFields are the fields requested for issue comments
+const commentFields = "id,text,created,updated,author(id,login,fullName,email),replyTo(id)," + reactionFields
+
 func (c *Client) GetIssueComments(ctx *YouTrackContext, issueID string) ([]*IssueComment, error) {
 	path := fmt.Sprintf("/api/issues/%s/comments", issueID)
 
 	query := url.Values{}
-	query.Add("fields", "id,text,created,updated,author(id,login,fullName,email)")
+	query.Add("fields", commentFields)
 
 	resp, err := c.Get(ctx, path, query)
 	if err != nil {
@@ -27,13 +30,28 @@ func (c *Client) GetIssueComments(ctx *YouTrackContext, issueID string) ([]*Issu
 }
 
 func (c *Client) AddIssueComment(ctx *YouTrackContext, issueID string, text string) (*IssueComment, error) {
+	return c.addIssueComment(ctx, issueID, text, "")
+}
+
+// ReplyToIssueComment adds a comment answering another comment of the issue, so that
+// servers with comment threads show it in the thread of that comment
+func (c *Client) ReplyToIssueComment(ctx *YouTrackContext, issueID, commentID string, text string) (*IssueComment, error) {
+	return c.addIssueComment(ctx, issueID, text, commentID)
+}
+
+// addIssueComment adds a comment, a reply when replyTo is set
+func (c *Client) addIssueComment(ctx *YouTrackContext, issueID, text, replyTo string) (*IssueComment, error) {
 	path := fmt.Sprintf("/api/issues/%s/comments", issueID)
 
 	query := url.Values{}
-	query.Add("fields", "id,text,created,updated,author(id,login,fullName,email)")
-
-	req := map[string]string{
-		"text": text,
+	query.Add("fields", commentFields)
+
+	req := struct {
+		Text    string      `json:"text"`
+		ReplyTo *CommentRef `json:"replyTo,omitempty"`
+	}{Text: text}
+	if replyTo != "" {
+		req.ReplyTo = &CommentRef{ID: replyTo}
 	}
 
 	resp, err := c.PostWithQuery(ctx, path, query, req)
@@ -54,7 +72,7 @@ func (c *Client) UpdateIssueComment(ctx *YouTrackContext, issueID, commentID str
 	path := fmt.Sprintf("/api/issues/%s/comments/%s", issueID, commentID)
 
 	query := url.Values{}
-	query.Add("fields", "id,text,created,updated,author(id,login,fullName,email)")
+	query.Add("fields", commentFields)
 
 	req := map[string]string{
 		"text": text,
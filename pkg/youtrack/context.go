@@ -2,11 +2,13 @@ package youtrack
 
 import (
 	"context"
+	"time"
 )
 
 type YouTrackContext struct {
-	ctx    context.Context
-	APIKey string
+	ctx     context.Context
+	APIKey  string
+	timeout time.Duration
 }
 
 func NewYouTrackContext(ctx context.Context, apiKey string) *YouTrackContext {
@@ -17,5 +19,33 @@ func NewYouTrackContext(ctx context.Context, apiKey string) *YouTrackContext {
 }
 
 func (y *YouTrackContext) Context() context.Context {
+	if y.ctx == nil {
+		return context.Background()
+	}
 	return y.ctx
 }
+
+// WithTimeout returns a copy of the context that limits every request made with it to the given duration,
+// in place of the client timeout, even when longer. A zero or negative duration restores the client timeout.
+func (y *YouTrackContext) WithTimeout(timeout time.Duration) *YouTrackContext {
+	c := *y
+	c.timeout = timeout
+	return &c
+}
+
+// Timeout returns the per-request timeout, or zero if none is set
+func (y *YouTrackContext) Timeout() time.Duration {
+	return y.timeout
+}
+
+// requestContext derives the context for a single HTTP request, applying the per-request timeout if set
+// and the client default otherwise (none when zero)
+func (y *YouTrackContext) requestContext(defaultTimeout time.Duration) (context.Context, context.CancelFunc) {
+	if y.timeout > 0 {
+		return context.WithTimeout(y.Context(), y.timeout)
+	}
+	if defaultTimeout > 0 {
+		return context.WithTimeout(y.Context(), defaultTimeout)
+	}
+	return context.WithCancel(y.Context())
+}
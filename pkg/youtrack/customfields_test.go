@@ -0,0 +1,37 @@
+package youtrack
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestIssue_DateField(t *testing.T) {
+	var issue Issue
+	if err := json.Unmarshal([]byte(`{"idReadable":"P-1","customFields":[
+		{"name":"Due Date","$type":"DateIssueCustomField","value":1705276800000},
+		{"name":"Start","$type":"DateIssueCustomField","value":null},
+		{"name":"Deadline","$type":"SimpleIssueCustomField","value":1705314600000,"projectCustomField":{"field":{"fieldType":{"id":"date and time"}}}},
+		{"name":"Points","$type":"SimpleIssueCustomField","value":5}
+	]}`), &issue); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	due, ok := issue.DateField("due date")
+	if !ok || due.Format("2006-01-02") != "2024-01-15" {
+		t.Errorf("Expected 2024-01-15, got %v %v", due, ok)
+	}
+	if _, ok := issue.DateField("Start"); ok {
+		t.Error("Expected empty date for Start")
+	}
+	deadline, ok := issue.DateField("Deadline")
+	if !ok || !deadline.Equal(time.UnixMilli(1705314600000)) {
+		t.Errorf("Expected deadline timestamp, got %v %v", deadline, ok)
+	}
+	if _, ok := issue.DateField("Points"); ok {
+		t.Error("Expected no date for a number field")
+	}
+	if _, ok := issue.DateField("Missing"); ok {
+		t.Error("Expected no date for a missing field")
+	}
+}
@@ -0,0 +1,79 @@
+package youtrack
+
+import (
+	"math"
+	"sort"
+	"time"
+)
+
+// TimeInState returns the time an issue spent in each value of its state field, from the
+// activity stream. The issue is in the state it was created in until the first change, and
+// the time in the last state is counted until the resolution, or until now while unresolved.
+// Time in an unknown state, before the history starts, is left out.
+func TimeInState(issue *Issue, activities []*ActivityItem, stateField string, now time.Time) map[string]time.Duration {
+	changes := FieldHistory(activities, stateField)
+
+	// The state the issue was created in is the one the first change removed
+	state := issue.FieldValue(stateField)
+	if len(changes) > 0 {
+		state = firstValue(changes[0].From)
+	}
+
+	durations := make(map[string]time.Duration)
+	add := func(state string, from, to time.Time) {
+		if state != "" && to.After(from) {
+			durations[state] += to.Sub(from)
+		}
+	}
+
+	start := issue.Created.Time
+	for _, change := range changes {
+		add(state, start, change.Timestamp.Time)
+		state, start = firstValue(change.To), change.Timestamp.Time
+	}
+
+	end := now
+	if issue.Resolved != nil && !issue.Resolved.IsZero() {
+		end = issue.Resolved.Time
+	}
+	add(state, start, end)
+	return durations
+}
+
+// LeadTime returns the time from the creation to the resolution of an issue, false when unresolved
+func LeadTime(issue *Issue) (time.Duration, bool) {
+	if issue.Resolved == nil || issue.Resolved.IsZero() {
+		return 0, false
+	}
+	return issue.Resolved.Sub(issue.Created.Time), true
+}
+
+// DurationPercentile returns the p-th percentile (0-100) of durations, interpolating between
+// the nearest ranks; 0 without durations
+func DurationPercentile(durations []time.Duration, p float64) time.Duration {
+	if len(durations) == 0 {
+		return 0
+	}
+	sorted := append([]time.Duration(nil), durations...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+
+	rank := p / 100 * float64(len(sorted)-1)
+	lower := int(math.Floor(rank))
+	upper := int(math.Ceil(rank))
+	if lower < 0 {
+		return sorted[0]
+	}
+	if upper >= len(sorted) {
+		return sorted[len(sorted)-1]
+	}
+	weight := rank - float64(lower)
+	return sorted[lower] + time.Duration(weight*float64(sorted[upper]-sorted[lower]))
+}
+
+// firstValue returns the first of the values of a change, empty when there is none
+func firstValue(values []string) string {
+	if len(values) == 0 {
+		return ""
+	}
+	return values[0]
+}
@@ -0,0 +1,90 @@
+package youtrack
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTimeInState(t *testing.T) {
+	created := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	at := func(hours int) YouTrackTime { return YouTrackTime{Time: created.Add(time.Duration(hours) * time.Hour)} }
+	stateChange := func(hours int, from, to string) *ActivityItem {
+		item := &ActivityItem{Timestamp: at(hours), Field: &Field{Name: "State"}}
+		if from != "" {
+			item.RemovedValues = []*FieldValue{{Name: from}}
+		}
+		item.AddedValues = []*FieldValue{{Name: to}}
+		return item
+	}
+	resolved := at(30)
+
+	tests := []struct {
+		name       string
+		issue      *Issue
+		activities []*ActivityItem
+		expected   map[string]time.Duration
+	}{
+		{
+			name:  "Resolved issue",
+			issue: &Issue{Created: at(0), Resolved: &resolved},
+			activities: []*ActivityItem{
+				stateChange(2, "Open", "In Progress"),
+				stateChange(10, "In Progress", "Review"),
+				stateChange(12, "Review", "In Progress"),
+				stateChange(20, "In Progress", "Fixed"),
+			},
+			expected: map[string]time.Duration{"Open": 2 * time.Hour, "In Progress": 16 * time.Hour, "Review": 2 * time.Hour, "Fixed": 10 * time.Hour},
+		},
+		{
+			name:       "Unresolved issue counts until now",
+			issue:      &Issue{Created: at(0)},
+			activities: []*ActivityItem{stateChange(4, "Open", "In Progress")},
+			expected:   map[string]time.Duration{"Open": 4 * time.Hour, "In Progress": 44 * time.Hour},
+		},
+		{
+			name:     "Issue without state changes",
+			issue:    &Issue{Created: at(0), CustomFields: []*CustomFieldValue{{Name: "State", Value: map[string]interface{}{"name": "Open"}}}},
+			expected: map[string]time.Duration{"Open": 48 * time.Hour},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := TimeInState(tt.issue, tt.activities, "State", at(48).Time)
+			if len(got) != len(tt.expected) {
+				t.Fatalf("Expected %v, got %v", tt.expected, got)
+			}
+			for state, duration := range tt.expected {
+				if got[state] != duration {
+					t.Errorf("Expected %s in %s, got %s", duration, state, got[state])
+				}
+			}
+		})
+	}
+}
+
+func TestDurationPercentile(t *testing.T) {
+	durations := []time.Duration{4 * time.Hour, time.Hour, 3 * time.Hour, 2 * time.Hour}
+
+	tests := []struct {
+		name      string
+		durations []time.Duration
+		p         float64
+		expected  time.Duration
+	}{
+		{name: "Median interpolates", durations: durations, p: 50, expected: 150 * time.Minute},
+		{name: "Minimum", durations: durations, p: 0, expected: time.Hour},
+		{name: "Maximum", durations: durations, p: 100, expected: 4 * time.Hour},
+		{name: "90th percentile", durations: durations, p: 90, expected: 222 * time.Minute},
+		{name: "Single value", durations: []time.Duration{time.Hour}, p: 75, expected: time.Hour},
+		{name: "No values", p: 50},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := DurationPercentile(tt.durations, tt.p); got != tt.expected {
+				t.Errorf("Expected %s, got %s", tt.expected, got)
+			}
+		})
+	}
+}
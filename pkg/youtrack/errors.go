@@ -1,12 +1,21 @@
 package youtrack
 
-import "fmt"
+import (
+	"errors"
+	"fmt"
+)
 
 type APIError struct {
 	StatusCode int
 	Message    string
+	// Method and Path identify the refused request, TranslateError uses them
+	Method string
+	Path   string
 }
 
 func (e *APIError) Error() string {
 	return fmt.Sprintf("YouTrack API error (status %d): %s", e.StatusCode, e.Message)
 }
+
+// ErrStopIteration can be returned from an iteration callback to stop early without an error
+var ErrStopIteration = errors.New("stop iteration")
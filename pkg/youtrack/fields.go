@@ -0,0 +1,187 @@
+package youtrack
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// CustomFieldPatch builds the customFields payload of CreateIssueRequest and UpdateIssueRequest.
+// Field types are taken from the project, so each value is sent with the $type and
+// shape the API expects, e.g. {"name":"State","$type":"StateIssueCustomField","value":{"name":"Fixed"}}.
+type CustomFieldPatch struct {
+	projectFields map[string]*ProjectCustomField
+	fields        []CustomField
+	err           error
+}
+
+// NewCustomFieldPatch creates a patch builder for the given project fields
+func NewCustomFieldPatch(projectFields []*ProjectCustomField) *CustomFieldPatch {
+	p := &CustomFieldPatch{
+		projectFields: make(map[string]*ProjectCustomField, len(projectFields)),
+	}
+	for _, field := range projectFields {
+		if field.Field != nil {
+			p.projectFields[strings.ToLower(field.Field.Name)] = field
+		}
+	}
+	return p
+}
+
+// NewCustomFieldPatch creates a patch builder for the custom fields of a project
+func (c *Client) NewCustomFieldPatch(ctx *YouTrackContext, projectID string) (*CustomFieldPatch, error) {
+	fields, err := c.ListProjectCustomFields(ctx, projectID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get project custom fields: %w", err)
+	}
+	return NewCustomFieldPatch(fields), nil
+}
+
+// ProjectField returns the project field with the given name (case-insensitive), or nil
+func (p *CustomFieldPatch) ProjectField(name string) *ProjectCustomField {
+	return p.projectFields[strings.ToLower(name)]
+}
+
+// Set sets a project field to one or more values: value names for bundle fields
+// (enum, state, version, ...), logins for user fields, plain values for simple fields.
+// Unknown fields and several values for a single-value field are reported by Fields.
+func (p *CustomFieldPatch) Set(name string, values ...string) *CustomFieldPatch {
+	field := p.ProjectField(name)
+	if field == nil {
+		p.fail(fmt.Errorf("custom field '%s' not found in project", name))
+		return p
+	}
+	valueType := ""
+	if field.Field.FieldType != nil {
+		valueType = field.Field.FieldType.ID
+	}
+	return p.set(field.Field.Name, field.IssueFieldType(), valueType, values)
+}
+
+// SetTyped sets a field with an explicit issue field $type (e.g. "SingleEnumIssueCustomField"),
+// without looking it up in the project. Values of simple fields are sent as strings.
+func (p *CustomFieldPatch) SetTyped(name, fieldType string, values ...string) *CustomFieldPatch {
+	return p.set(name, fieldType, "", values)
+}
+
+// set adds a field value; valueType is the project field type ID ("integer", "float", "date and time", ...)
+func (p *CustomFieldPatch) set(name, fieldType, valueType string, values []string) *CustomFieldPatch {
+	multi := strings.HasPrefix(fieldType, "Multi")
+	if !multi && len(values) > 1 {
+		p.fail(fmt.Errorf("field '%s' accepts a single value, got %d", name, len(values)))
+		return p
+	}
+
+	value, err := customFieldValue(fieldType, valueType, values)
+	if err != nil {
+		p.fail(fmt.Errorf("invalid value for field '%s': %w", name, err))
+		return p
+	}
+
+	p.fields = append(p.fields, CustomField{Name: name, Type: fieldType, Value: value})
+	return p
+}
+
+// Fields returns the built custom fields, or the first error recorded while setting them
+func (p *CustomFieldPatch) Fields() ([]CustomField, error) {
+	if p.err != nil {
+		return nil, p.err
+	}
+	return p.fields, nil
+}
+
+// fail records the first error
+func (p *CustomFieldPatch) fail(err error) {
+	if p.err == nil {
+		p.err = err
+	}
+}
+
+// customFieldValue builds the value JSON shape for an issue custom field type:
+// bundle elements are {"name": ...}, users are {"login": ...}, multi-value fields are arrays.
+// An empty value list clears the field.
+func customFieldValue(fieldType, valueType string, values []string) (interface{}, error) {
+	multi := strings.HasPrefix(fieldType, "Multi")
+	kind := strings.TrimSuffix(strings.TrimPrefix(strings.TrimPrefix(fieldType, "Multi"), "Single"), "IssueCustomField")
+
+	var element func(string) (interface{}, error)
+	switch kind {
+	case "Enum", "Version", "Build", "Owned", "Group", "State":
+		element = func(v string) (interface{}, error) { return SingleValue{Value: v}, nil }
+	case "User":
+		element = func(v string) (interface{}, error) { return SingleUserValue{ID: v}, nil }
+	case "Text":
+		element = func(v string) (interface{}, error) { return map[string]string{"text": v}, nil }
+	case "Period":
+		element = func(v string) (interface{}, error) { return map[string]string{"presentation": v}, nil }
+	case "Date":
+		element = parseDateValue
+	default:
+		element = func(v string) (interface{}, error) { return parseSimpleValue(valueType, v) }
+	}
+
+	if multi {
+		elements := make([]interface{}, 0, len(values))
+		for _, v := range values {
+			e, err := element(v)
+			if err != nil {
+				return nil, err
+			}
+			elements = append(elements, e)
+		}
+		return elements, nil
+	}
+
+	if len(values) == 0 {
+		return nil, nil
+	}
+	return element(values[0])
+}
+
+// parseDateValue converts a YYYY-MM-DD or RFC 3339 date to epoch milliseconds
+func parseDateValue(v string) (interface{}, error) {
+	if t, err := time.Parse("2006-01-02", v); err == nil {
+		return t.UnixMilli(), nil
+	}
+	if t, err := time.Parse(time.RFC3339, v); err == nil {
+		return t.UnixMilli(), nil
+	}
+	return nil, fmt.Errorf("invalid date '%s' (use YYYY-MM-DD)", v)
+}
+
+// parseDateTimeValue converts a local "YYYY-MM-DD HH:MM" (or just a date) or an RFC 3339
+// timestamp to epoch milliseconds
+func parseDateTimeValue(v string) (interface{}, error) {
+	for _, layout := range []string{"2006-01-02 15:04", "2006-01-02T15:04", "2006-01-02"} {
+		if t, err := time.ParseInLocation(layout, v, time.Local); err == nil {
+			return t.UnixMilli(), nil
+		}
+	}
+	if t, err := time.Parse(time.RFC3339, v); err == nil {
+		return t.UnixMilli(), nil
+	}
+	return nil, fmt.Errorf("invalid date and time '%s' (use YYYY-MM-DD HH:MM)", v)
+}
+
+// parseSimpleValue converts a simple field value according to the project field type
+func parseSimpleValue(valueType, v string) (interface{}, error) {
+	switch valueType {
+	case "integer":
+		i, err := strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid integer '%s'", v)
+		}
+		return i, nil
+	case "float":
+		f, err := strconv.ParseFloat(v, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid number '%s'", v)
+		}
+		return f, nil
+	case "date and time":
+		return parseDateTimeValue(v)
+	default:
+		return v, nil
+	}
+}
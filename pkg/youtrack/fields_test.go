@@ -0,0 +1,98 @@
+package youtrack
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestCustomFieldPatch(t *testing.T) {
+	projectFields := []*ProjectCustomField{
+		{Type: "StateProjectCustomField", Field: &CustomFieldDefinition{Name: "State", FieldType: &FieldType{ID: "state[1]"}}},
+		{Type: "VersionProjectCustomField", Field: &CustomFieldDefinition{Name: "Fix versions", FieldType: &FieldType{ID: "version[*]", IsMultiValue: true}}},
+		{Type: "UserProjectCustomField", Field: &CustomFieldDefinition{Name: "Assignee", FieldType: &FieldType{ID: "user[1]"}}},
+		{Type: "SimpleProjectCustomField", Field: &CustomFieldDefinition{Name: "Estimate points", FieldType: &FieldType{ID: "integer"}}},
+		{Type: "SimpleProjectCustomField", Field: &CustomFieldDefinition{Name: "Due Date", FieldType: &FieldType{ID: "date"}}},
+		{Type: "SimpleProjectCustomField", Field: &CustomFieldDefinition{Name: "Deadline", FieldType: &FieldType{ID: "date and time"}}},
+	}
+
+	tests := []struct {
+		name     string
+		build    func(p *CustomFieldPatch)
+		expected string
+		wantErr  bool
+	}{
+		{
+			name:     "State by name",
+			build:    func(p *CustomFieldPatch) { p.Set("state", "Fixed") },
+			expected: `[{"name":"State","$type":"StateIssueCustomField","value":{"name":"Fixed"}}]`,
+		},
+		{
+			name:     "Multi-value version",
+			build:    func(p *CustomFieldPatch) { p.Set("Fix versions", "2024.1", "2024.2") },
+			expected: `[{"name":"Fix versions","$type":"MultiVersionIssueCustomField","value":[{"name":"2024.1"},{"name":"2024.2"}]}]`,
+		},
+		{
+			name:     "User and integer",
+			build:    func(p *CustomFieldPatch) { p.Set("Assignee", "john").Set("Estimate points", "5") },
+			expected: `[{"name":"Assignee","$type":"SingleUserIssueCustomField","value":{"login":"john"}},{"name":"Estimate points","$type":"SimpleIssueCustomField","value":5}]`,
+		},
+		{
+			name:     "Date",
+			build:    func(p *CustomFieldPatch) { p.Set("Due Date", "2025-03-01") },
+			expected: `[{"name":"Due Date","$type":"DateIssueCustomField","value":1740787200000}]`,
+		},
+		{
+			name:     "Date and time",
+			build:    func(p *CustomFieldPatch) { p.Set("Deadline", "2025-03-01T10:00:00Z") },
+			expected: `[{"name":"Deadline","$type":"SimpleIssueCustomField","value":1740823200000}]`,
+		},
+		{
+			name:     "Explicit type",
+			build:    func(p *CustomFieldPatch) { p.SetTyped("Priority", "SingleEnumIssueCustomField", "Critical") },
+			expected: `[{"name":"Priority","$type":"SingleEnumIssueCustomField","value":{"name":"Critical"}}]`,
+		},
+		{
+			name:    "Unknown field",
+			build:   func(p *CustomFieldPatch) { p.Set("Severity", "Major") },
+			wantErr: true,
+		},
+		{
+			name:    "Several values for single-value field",
+			build:   func(p *CustomFieldPatch) { p.Set("State", "Open", "Fixed") },
+			wantErr: true,
+		},
+		{
+			name:    "Invalid date",
+			build:   func(p *CustomFieldPatch) { p.Set("Deadline", "next week") },
+			wantErr: true,
+		},
+		{
+			name:    "Invalid integer",
+			build:   func(p *CustomFieldPatch) { p.Set("Estimate points", "five") },
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			patch := NewCustomFieldPatch(projectFields)
+			tt.build(patch)
+
+			fields, err := patch.Fields()
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("Expected error, got fields %+v", fields)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			data, _ := json.Marshal(fields)
+			if string(data) != tt.expected {
+				t.Errorf("Expected %s, got %s", tt.expected, data)
+			}
+		})
+	}
+}
@@ -0,0 +1,75 @@
+package youtrack
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// ListGroups returns all user groups
+func (c *Client) ListGroups(ctx *YouTrackContext) ([]*UserGroup, error) {
+	query := url.Values{}
+	query.Add("fields", "id,name,ringId,usersCount")
+
+	resp, err := c.Get(ctx, "/api/groups", query)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var groups []*UserGroup
+	if err := json.NewDecoder(resp.Body).Decode(&groups); err != nil {
+		return nil, fmt.Errorf("failed to decode groups: %w", err)
+	}
+
+	return groups, nil
+}
+
+// FindGroup finds a group by ID or case-insensitive name
+func (c *Client) FindGroup(ctx *YouTrackContext, name string) (*UserGroup, error) {
+	groups, err := c.ListGroups(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list groups: %w", err)
+	}
+
+	group := findGroup(groups, name)
+	if group == nil {
+		return nil, fmt.Errorf("group '%s' not found", name)
+	}
+	return group, nil
+}
+
+// GetGroupMembers returns the members of a group, including members of its subgroups.
+// The group is identified by its Hub ID (UserGroup.RingID).
+func (c *Client) GetGroupMembers(ctx *YouTrackContext, ringID string, skip, top int) ([]*User, error) {
+	path := fmt.Sprintf("/hub/api/rest/usergroups/%s/users", url.PathEscape(ringID))
+
+	params := url.Values{}
+	params.Add("$skip", fmt.Sprintf("%d", skip))
+	params.Add("$top", fmt.Sprintf("%d", top))
+	params.Add("fields", "id,login,name,profile(email(email))")
+
+	resp, err := c.hubGet(ctx, path, params)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var hubResp hubUserPage
+	if err := json.NewDecoder(resp.Body).Decode(&hubResp); err != nil {
+		return nil, fmt.Errorf("failed to decode group members: %w", err)
+	}
+
+	return hubResp.toUsers(), nil
+}
+
+// findGroup finds a group by ID or case-insensitive name
+func findGroup(groups []*UserGroup, name string) *UserGroup {
+	for _, group := range groups {
+		if group.ID == name || group.RingID == name || strings.EqualFold(group.Name, name) {
+			return group
+		}
+	}
+	return nil
+}
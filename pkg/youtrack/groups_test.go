@@ -0,0 +1,50 @@
+package youtrack
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestClient_GetGroupMembers(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/groups":
+			fmt.Fprint(w, `[{"id":"3-2","name":"Developers","ringId":"a1b2","usersCount":2}]`)
+		case "/hub/api/rest/usergroups/a1b2/users":
+			if r.URL.Query().Get("$top") != "50" {
+				t.Errorf("Expected $top=50, got %s", r.URL.Query().Get("$top"))
+			}
+			fmt.Fprint(w, `{"users":[{"id":"u1","login":"john","name":"John Smith","profile":{"email":{"email":"john@example.com"}}}]}`)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL)
+	client.SetHubURL(server.URL)
+	ctx := NewYouTrackContext(context.Background(), "token")
+
+	group, err := client.FindGroup(ctx, "developers")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if group.RingID != "a1b2" || group.UsersCount != 2 {
+		t.Errorf("Unexpected group: %+v", group)
+	}
+
+	members, err := client.GetGroupMembers(ctx, group.RingID, 0, 50)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(members) != 1 || members[0].Login != "john" || members[0].FullName != "John Smith" || members[0].Email != "john@example.com" {
+		t.Errorf("Unexpected members: %+v", members[0])
+	}
+
+	if _, err := client.FindGroup(ctx, "Managers"); err == nil {
+		t.Error("Expected error for unknown group")
+	}
+}
@@ -0,0 +1,74 @@
+package youtrack
+
+import (
+	"sort"
+	"strings"
+)
+
+// FieldChange is a change of one field in the history of an issue
+type FieldChange struct {
+	Timestamp YouTrackTime `json:"timestamp"`
+	Author    *User        `json:"author,omitempty"`
+	Field     string       `json:"field"`
+	From      []string     `json:"from,omitempty"`
+	To        []string     `json:"to,omitempty"`
+}
+
+// DisplayValue returns the readable form of an activity value: the name, text, user name or login
+func (v *FieldValue) DisplayValue() string {
+	for _, text := range []string{v.Name, v.Text, v.FullName, v.Login, v.Markdown} {
+		if text != "" {
+			return text
+		}
+	}
+	return v.ID
+}
+
+// FieldName returns the name of the field an activity changed, empty for activities that
+// change no field, such as comments
+func (a *ActivityItem) FieldName() string {
+	if a.Field != nil {
+		if a.Field.Name != "" {
+			return a.Field.Name
+		}
+		return a.Field.ID
+	}
+	return a.TargetMember
+}
+
+// FieldHistory extracts the changes of a field from an activity stream, oldest first. The
+// field is matched by name, case-insensitively.
+func FieldHistory(activities []*ActivityItem, fieldName string) []*FieldChange {
+	var changes []*FieldChange
+	for _, activity := range activities {
+		name := activity.FieldName()
+		if name == "" || !strings.EqualFold(name, fieldName) {
+			continue
+		}
+		changes = append(changes, &FieldChange{
+			Timestamp: activity.Timestamp,
+			Author:    activity.Author,
+			Field:     name,
+			From:      displayValues(activity.Removed, activity.RemovedValues),
+			To:        displayValues(activity.Added, activity.AddedValues),
+		})
+	}
+	sort.SliceStable(changes, func(i, j int) bool {
+		return changes[i].Timestamp.Before(changes[j].Timestamp.Time)
+	})
+	return changes
+}
+
+// displayValues returns the readable forms of the added or removed values of an activity
+func displayValues(single *FieldValue, values []*FieldValue) []string {
+	if single != nil {
+		values = append([]*FieldValue{single}, values...)
+	}
+	var list []string
+	for _, v := range values {
+		if text := v.DisplayValue(); text != "" {
+			list = append(list, text)
+		}
+	}
+	return list
+}
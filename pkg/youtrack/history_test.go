@@ -0,0 +1,65 @@
+package youtrack
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestFieldHistory(t *testing.T) {
+	const activities = `[
+		{"id":"1","timestamp":3000,"author":{"login":"jane"},"field":{"id":"f-1","name":"State"},"added":[{"name":"Fixed"}],"removed":[{"name":"In Progress"}]},
+		{"id":"2","timestamp":1000,"author":{"login":"john"},"field":{"id":"f-1","name":"State"},"added":[{"name":"In Progress"}],"removed":[{"name":"Open"}]},
+		{"id":"3","timestamp":2000,"author":{"login":"john"},"field":{"id":"f-2","name":"Assignee"},"added":[{"login":"jane"}]},
+		{"id":"4","timestamp":2500,"category":{"id":"CommentCategory"}},
+		{"id":"5","timestamp":4000,"targetMember":"summary","added":"New summary","removed":"Old summary"}
+	]`
+
+	var items []*ActivityItem
+	if err := json.Unmarshal([]byte(activities), &items); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		field    string
+		expected []string
+	}{
+		{
+			name:     "Field changes oldest first",
+			field:    "state",
+			expected: []string{"john: Open -> In Progress", "jane: In Progress -> Fixed"},
+		},
+		{
+			name:     "Value without a previous one",
+			field:    "Assignee",
+			expected: []string{"john:  -> jane"},
+		},
+		{
+			name:     "Issue attribute",
+			field:    "Summary",
+			expected: []string{": Old summary -> New summary"},
+		},
+		{
+			name:  "Unknown field",
+			field: "Priority",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			changes := FieldHistory(items, tt.field)
+			var got []string
+			for _, change := range changes {
+				author := ""
+				if change.Author != nil {
+					author = change.Author.Login
+				}
+				got = append(got, author+": "+strings.Join(change.From, ",")+" -> "+strings.Join(change.To, ","))
+			}
+			if strings.Join(got, "|") != strings.Join(tt.expected, "|") {
+				t.Errorf("Expected %q, got %q", tt.expected, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,119 @@
+package youtrack
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// issueIDPattern matches candidate issue IDs such as "PROJ-123"
+var issueIDPattern = regexp.MustCompile(`\b([A-Za-z][A-Za-z0-9_]*)-(\d+)\b`)
+
+var (
+	// fullIssueIDPattern matches a whole readable ("PROJ-123") or database ("2-123") issue ID
+	fullIssueIDPattern = regexp.MustCompile(`^[A-Za-z0-9_]+-\d+$`)
+	// issueNumberPattern matches a bare issue number, optionally written "#123"
+	issueNumberPattern = regexp.MustCompile(`^#?(\d+)$`)
+	// internalIssueIDPattern matches a database issue ID such as "2-123"
+	internalIssueIDPattern = regexp.MustCompile(`^\d+-\d+$`)
+)
+
+// IsInternalIssueID reports whether id is a database issue ID ("2-123") rather than a
+// readable one ("PROJ-123"). The API accepts both wherever an issue ID is expected.
+func IsInternalIssueID(id string) bool {
+	return internalIssueIDPattern.MatchString(id)
+}
+
+// NormalizeIssueID accepts an issue ID like "PROJ-123", or a bare number like "123" (or "#123")
+// completed with the default project to "PROJ-123". It returns an error for anything else,
+// or for a number without a default project.
+func NormalizeIssueID(id, defaultProject string) (string, error) {
+	id = strings.TrimSpace(id)
+	if fullIssueIDPattern.MatchString(id) {
+		return id, nil
+	}
+	if match := issueNumberPattern.FindStringSubmatch(id); match != nil {
+		if defaultProject == "" {
+			return "", fmt.Errorf("issue number %s needs a project: use the full ID (e.g. PRJ-%s) or set a default project", id, match[1])
+		}
+		return defaultProject + "-" + match[1], nil
+	}
+	return "", fmt.Errorf("invalid issue ID format: %s (expected format: PRJ-123, or 123 with a default project)", id)
+}
+
+// ExtractIssueIDs scans free text (commit messages, PR descriptions, ...) for issue IDs.
+// Only IDs whose project matches one of the prefixes (case-insensitive) are returned,
+// spelled with the prefix as given; with no prefixes, any uppercase ID like "PROJ-12" matches.
+// IDs are returned once, in order of first appearance.
+func ExtractIssueIDs(text string, prefixes []string) []string {
+	known := make(map[string]string, len(prefixes))
+	for _, prefix := range prefixes {
+		known[strings.ToUpper(prefix)] = prefix
+	}
+
+	var ids []string
+	seen := make(map[string]bool)
+	for _, match := range issueIDPattern.FindAllStringSubmatch(text, -1) {
+		project, number := match[1], match[2]
+		if len(known) > 0 {
+			prefix, ok := known[strings.ToUpper(project)]
+			if !ok {
+				continue
+			}
+			project = prefix
+		} else if project != strings.ToUpper(project) {
+			continue
+		}
+
+		id := project + "-" + number
+		if !seen[id] {
+			seen[id] = true
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+// GetIssuesByIDs fetches several issues by readable or database ID. Readable IDs are fetched
+// with one search, database IDs one by one. Issues that do not exist or are not visible are
+// omitted; the result keeps the order of ids.
+func (c *Client) GetIssuesByIDs(ctx *YouTrackContext, ids []string) ([]*Issue, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	var readable []string
+	byID := make(map[string]*Issue, len(ids))
+	for _, id := range ids {
+		if !IsInternalIssueID(id) {
+			readable = append(readable, id)
+			continue
+		}
+		issue, err := c.GetIssue(ctx, id)
+		if err != nil {
+			if apiErr, ok := err.(*APIError); ok && apiErr.StatusCode == 404 {
+				continue
+			}
+			return nil, err
+		}
+		byID[id] = issue
+	}
+
+	if len(readable) > 0 {
+		issues, err := c.SearchIssuesSorted(ctx, "issue id: "+strings.Join(readable, ", "), 0, len(readable), "", "")
+		if err != nil {
+			return nil, err
+		}
+		for _, issue := range issues {
+			byID[strings.ToUpper(issue.ID)] = issue
+		}
+	}
+
+	result := make([]*Issue, 0, len(ids))
+	for _, id := range ids {
+		if issue, ok := byID[strings.ToUpper(id)]; ok {
+			result = append(result, issue)
+		}
+	}
+	return result, nil
+}
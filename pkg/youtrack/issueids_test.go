@@ -0,0 +1,146 @@
+package youtrack
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+func TestExtractIssueIDs(t *testing.T) {
+	tests := []struct {
+		name     string
+		text     string
+		prefixes []string
+		expected []string
+	}{
+		{name: "Commit message", text: "PROJ-12: fix login (see also PROJ-7, PROJ-12)", expected: []string{"PROJ-12", "PROJ-7"}},
+		{name: "Prefixes filter", text: "Fixes mob-3 and OPS-4, uses SHA-256", prefixes: []string{"MOB"}, expected: []string{"MOB-3"}},
+		{name: "Lowercase ignored without prefixes", text: "branch feature-12 for WEB-9", expected: []string{"WEB-9"}},
+		{name: "Inside words", text: "fooPROJ-1 PROJ-2x (PROJ-3)", prefixes: []string{"PROJ"}, expected: []string{"PROJ-3"}},
+		{name: "None", text: "no references here", expected: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ids := ExtractIssueIDs(tt.text, tt.prefixes)
+			if !reflect.DeepEqual(ids, tt.expected) {
+				t.Errorf("Expected %v, got %v", tt.expected, ids)
+			}
+		})
+	}
+}
+
+func TestNormalizeIssueID(t *testing.T) {
+	tests := []struct {
+		name           string
+		id             string
+		defaultProject string
+		expected       string
+		wantErr        bool
+	}{
+		{name: "Full ID", id: "PROJ-123", defaultProject: "OPS", expected: "PROJ-123"},
+		{name: "Full ID without default", id: " proj-7 ", expected: "proj-7"},
+		{name: "Database ID", id: "2-123", expected: "2-123"},
+		{name: "Number", id: "123", defaultProject: "PROJ", expected: "PROJ-123"},
+		{name: "Hash number", id: "#45", defaultProject: "PROJ", expected: "PROJ-45"},
+		{name: "Number without default", id: "123", wantErr: true},
+		{name: "Invalid", id: "PROJ 123", defaultProject: "PROJ", wantErr: true},
+		{name: "Empty", id: "", defaultProject: "PROJ", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			id, err := NormalizeIssueID(tt.id, tt.defaultProject)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Expected error %v, got %v", tt.wantErr, err)
+			}
+			if id != tt.expected {
+				t.Errorf("Expected %q, got %q", tt.expected, id)
+			}
+		})
+	}
+}
+
+func TestClient_GetIssuesByIDs(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if q := r.URL.Query().Get("query"); q != "issue id: PROJ-2, PROJ-1, PROJ-9" {
+			t.Errorf("Unexpected query: %s", q)
+		}
+		fmt.Fprint(w, `[
+			{"idReadable":"PROJ-1","summary":"First","customFields":[{"name":"State","$type":"StateIssueCustomField","value":{"name":"Open"}}]},
+			{"idReadable":"PROJ-2","summary":"Second","customFields":[{"name":"Assignee","$type":"SingleUserIssueCustomField","value":{"login":"john"}},{"name":"Stage","$type":"StateIssueCustomField","value":{"name":"Fixed"}}]}
+		]`)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL)
+	ctx := NewYouTrackContext(context.Background(), "token")
+
+	issues, err := client.GetIssuesByIDs(ctx, []string{"PROJ-2", "PROJ-1", "PROJ-9"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(issues) != 2 || issues[0].ID != "PROJ-2" || issues[1].ID != "PROJ-1" {
+		t.Fatalf("Unexpected issues: %+v", issues)
+	}
+	if issues[0].State != "Fixed" || issues[0].Assignee == nil || issues[0].Assignee.Login != "john" || issues[1].State != "Open" {
+		t.Errorf("Unexpected fields: %+v %+v", issues[0], issues[1])
+	}
+}
+
+func TestClient_GetIssuesByIDs_InternalIDs(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/issues":
+			if q := r.URL.Query().Get("query"); q != "issue id: PROJ-1" {
+				t.Errorf("Unexpected query: %s", q)
+			}
+			fmt.Fprint(w, `[{"id":"2-1","idReadable":"PROJ-1","summary":"First"}]`)
+		case "/api/issues/2-7":
+			fmt.Fprint(w, `{"id":"2-7","idReadable":"PROJ-7","summary":"Seventh"}`)
+		default:
+			http.Error(w, `{"error":"Not Found"}`, http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL)
+	ctx := NewYouTrackContext(context.Background(), "token")
+
+	issues, err := client.GetIssuesByIDs(ctx, []string{"2-7", "PROJ-1", "2-9"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(issues) != 2 || issues[0].ID != "PROJ-7" || issues[1].ID != "PROJ-1" {
+		t.Fatalf("Unexpected issues: %+v", issues)
+	}
+	if issues[0].InternalID != "2-7" || issues[1].InternalID != "2-1" {
+		t.Errorf("Expected both ID forms, got %+v %+v", issues[0], issues[1])
+	}
+}
+
+func TestIssueRef_MarshalJSON(t *testing.T) {
+	tests := []struct {
+		id       string
+		expected string
+	}{
+		{id: "PROJ-12", expected: `{"idReadable":"PROJ-12"}`},
+		{id: "2-12", expected: `{"id":"2-12"}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.id, func(t *testing.T) {
+			data, err := json.Marshal(&IssueRef{ID: tt.id})
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if string(data) != tt.expected {
+				t.Errorf("Expected %s, got %s", tt.expected, data)
+			}
+		})
+	}
+}
@@ -2,15 +2,20 @@ package youtrack
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
+	"net/http"
 	"net/url"
+	"strings"
 )
 
 func (c *Client) GetIssue(ctx *YouTrackContext, issueID string) (*Issue, error) {
-	path := fmt.Sprintf("/api/issues/%s", issueID)
+	return c.GetIssueProjected(ctx, issueID, nil)
+}
 
-	query := url.Values{}
-	query.Add("fields", "idReadable,summary,description,created,updated,resolved,reporter(id,login,fullName,email),updater(id,login,fullName,email),customFields(name,$type,value(id,name,login,fullName)),tags(id,name,color)")
+// getIssue fetches an issue with the fields of query
+func (c *Client) getIssue(ctx *YouTrackContext, issueID string, query url.Values) (*Issue, error) {
+	path := fmt.Sprintf("/api/issues/%s", issueID)
 
 	resp, err := c.Get(ctx, path, query)
 	if err != nil {
@@ -29,7 +34,7 @@ func (c *Client) GetIssue(ctx *YouTrackContext, issueID string) (*Issue, error)
 func (c *Client) CreateIssue(ctx *YouTrackContext, req *CreateIssueRequest) (*Issue, error) {
 	// Add fields parameter to get the full issue details in response
 	query := url.Values{}
-	query.Add("fields", "idReadable,summary,description,created,updated,resolved,reporter(id,login,fullName,email),updater(id,login,fullName,email),customFields(name,$type,value(id,name,login,fullName)),tags(id,name,color)")
+	query.Add("fields", "id,idReadable,summary,description,created,updated,resolved,votes,reporter(id,login,fullName,email),updater(id,login,fullName,email),customFields(name,$type,value(id,name,login,fullName),projectCustomField(field(fieldType(id)))),tags(id,name,color),visibility($type,permittedGroups(id,name),permittedUsers(id,login,fullName))")
 
 	resp, err := c.PostWithQuery(ctx, "/api/issues", query, req)
 	if err != nil {
@@ -117,7 +122,7 @@ func (c *Client) SearchIssues(ctx *YouTrackContext, query string, skip, top int)
 	params.Add("query", query)
 	params.Add("$skip", fmt.Sprintf("%d", skip))
 	params.Add("$top", fmt.Sprintf("%d", top))
-	params.Add("fields", "idReadable,summary,description,created,updated,resolved,reporter(id,login,fullName,email),updater(id,login,fullName,email),customFields(name,$type,value(id,name,login,fullName)),tags(id,name,color)")
+	params.Add("fields", "id,idReadable,summary,description,created,updated,resolved,votes,reporter(id,login,fullName,email),updater(id,login,fullName,email),customFields(name,$type,value(id,name,login,fullName),projectCustomField(field(fieldType(id)))),tags(id,name,color)")
 
 	resp, err := c.Get(ctx, "/api/issues", params)
 	if err != nil {
@@ -161,6 +166,12 @@ func (c *Client) AddIssueWorklog(ctx *YouTrackContext, issueID string, req *Crea
 
 	resp, err := c.PostWithQuery(ctx, path, query, req)
 	if err != nil {
+		// Any user may log own work, logging it for another one needs more than 'Add Work Item'
+		var apiErr *APIError
+		if req.Author != nil && errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusForbidden {
+			_, project, _ := RequiredPermission(apiErr.Method, apiErr.Path)
+			return nil, &PermissionError{Permission: PermissionUpdateWorkItem, Project: project, Err: apiErr}
+		}
 		return nil, err
 	}
 	defer resp.Body.Close()
@@ -174,6 +185,15 @@ func (c *Client) AddIssueWorklog(ctx *YouTrackContext, issueID string, req *Crea
 }
 
 func (c *Client) CreateIssueLink(ctx *YouTrackContext, sourceIssueID, targetIssueID, linkType string) error {
+	// The command names the target, which takes a readable ID
+	if IsInternalIssueID(targetIssueID) {
+		target, err := c.GetIssue(ctx, targetIssueID)
+		if err != nil {
+			return err
+		}
+		targetIssueID = target.ID
+	}
+
 	req := &CreateIssueLinkRequest{
 		Query: fmt.Sprintf("%s %s", linkType, targetIssueID),
 		Issues: []*IssueRef{
@@ -194,7 +214,7 @@ func (c *Client) GetIssueLinks(ctx *YouTrackContext, issueID string) ([]*IssueLi
 	path := fmt.Sprintf("/api/issues/%s/links", issueID)
 
 	query := url.Values{}
-	query.Add("fields", "id,direction,linkType(id,name),issues(idReadable,summary)")
+	query.Add("fields", "id,direction,linkType(id,name),issues(id,idReadable,summary)")
 
 	resp, err := c.Get(ctx, path, query)
 	if err != nil {
@@ -231,7 +251,7 @@ func (c *Client) GetIssueCustomFields(ctx *YouTrackContext, issueID string) ([]*
 	path := fmt.Sprintf("/api/issues/%s/customFields", issueID)
 
 	query := url.Values{}
-	query.Add("fields", "name,$type,value(name,id,$type)")
+	query.Add("fields", "name,$type,value(name,id,$type),projectCustomField(field(fieldType(id)))")
 
 	resp, err := c.Get(ctx, path, query)
 	if err != nil {
@@ -265,11 +285,18 @@ func (c *Client) GetAvailableLinkTypes(ctx *YouTrackContext) ([]*LinkType, error
 	return linkTypes, nil
 }
 
+// activityFields are the fields requested for activity items
+const activityFields = "id,category(id),author(id,login,fullName,email),timestamp,targetMember,field(id,name),removed(id,name,text,fullName,login,markdown),added(id,name,text,fullName,login,markdown)"
+
 func (c *Client) GetIssueActivities(ctx *YouTrackContext, issueID string) ([]*ActivityItem, error) {
 	path := fmt.Sprintf("/api/issues/%s/activities", issueID)
 
 	query := url.Values{}
-	query.Add("fields", "id,category(id),author(id,login,fullName,email),timestamp,targetMember,field(id,name),removed(id,name,text,fullName,login,markdown),added(id,name,text,fullName,login,markdown)")
+	query.Add("fields", activityFields)
+	if !c.Capabilities(ctx).ActivityDefaults {
+		// Older versions list only the activities of the named categories
+		query.Add("categories", strings.Join(activityCategories, ","))
+	}
 
 	resp, err := c.Get(ctx, path, query)
 	if err != nil {
@@ -0,0 +1,55 @@
+package youtrack
+
+import (
+	"fmt"
+)
+
+// subtaskOfLinkType is the link that makes an issue a subtask of another
+const subtaskOfLinkType = "subtask of"
+
+// IssueTree is a parent issue with its subtasks
+type IssueTree struct {
+	Parent   *Issue   `json:"parent"`
+	Subtasks []*Issue `json:"subtasks"`
+}
+
+// CreateIssueTree creates a parent issue and its subtasks, each linked as a subtask of the
+// parent. Subtasks without a project are created in the project of the parent. When a step
+// fails, the issues already created are deleted so that no partial tree is left; the error
+// notes the rollback, or carries a *RollbackError naming the issues that could not be deleted.
+func (c *Client) CreateIssueTree(ctx *YouTrackContext, parent *CreateIssueRequest, subtasks []*CreateIssueRequest) (*IssueTree, error) {
+	var tx Compensation
+
+	root, err := c.CreateIssue(ctx, parent)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create the parent issue: %w", err)
+	}
+	tx.Record("delete "+root.ID, c.deleteIssueUndo(root.ID))
+
+	tree := &IssueTree{Parent: root, Subtasks: []*Issue{}}
+	for i, req := range subtasks {
+		sub := *req
+		if sub.Project.ID == "" {
+			sub.Project = parent.Project
+		}
+
+		issue, err := c.CreateIssue(ctx, &sub)
+		if err != nil {
+			return nil, tx.Fail(ctx, fmt.Errorf("failed to create subtask %d '%s': %w", i+1, sub.Summary, err))
+		}
+		tx.Record("delete "+issue.ID, c.deleteIssueUndo(issue.ID))
+
+		if err := c.CreateIssueLink(ctx, issue.ID, root.ID, subtaskOfLinkType); err != nil {
+			return nil, tx.Fail(ctx, fmt.Errorf("failed to link %s as a subtask of %s: %w", issue.ID, root.ID, err))
+		}
+		tree.Subtasks = append(tree.Subtasks, issue)
+	}
+	return tree, nil
+}
+
+// deleteIssueUndo returns the undo of creating an issue
+func (c *Client) deleteIssueUndo(issueID string) func(ctx *YouTrackContext) error {
+	return func(ctx *YouTrackContext) error {
+		return c.DeleteIssue(ctx, issueID)
+	}
+}
@@ -0,0 +1,162 @@
+package youtrack
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestClient_CreateIssueTree(t *testing.T) {
+	tests := []struct {
+		name      string
+		failOn    string // summary of the issue whose creation fails, or "link"
+		denyOn    string // issue whose deletion is refused
+		subtasks  []string
+		expected  []string
+		errSubstr string
+		rollback  bool
+	}{
+		{
+			name:     "Creates and links the subtasks",
+			subtasks: []string{"One", "Two"},
+			expected: []string{
+				"POST /api/issues Epic in 0-1",
+				"POST /api/issues One in 0-1",
+				"POST /api/commands subtask of PRJ-1 on PRJ-2",
+				"POST /api/issues Two in 0-2",
+				"POST /api/commands subtask of PRJ-1 on PRJ-3",
+			},
+		},
+		{
+			name:     "Failed subtask deletes the created issues",
+			failOn:   "Two",
+			subtasks: []string{"One", "Two"},
+			expected: []string{
+				"POST /api/issues Epic in 0-1",
+				"POST /api/issues One in 0-1",
+				"POST /api/commands subtask of PRJ-1 on PRJ-2",
+				"POST /api/issues Two in 0-2",
+				"DELETE /api/issues/PRJ-2",
+				"DELETE /api/issues/PRJ-1",
+			},
+			errSubstr: "failed to create subtask 2 'Two'",
+		},
+		{
+			name:     "Failed link deletes the subtask too",
+			failOn:   "link",
+			subtasks: []string{"One"},
+			expected: []string{
+				"POST /api/issues Epic in 0-1",
+				"POST /api/issues One in 0-1",
+				"POST /api/commands subtask of PRJ-1 on PRJ-2",
+				"DELETE /api/issues/PRJ-2",
+				"DELETE /api/issues/PRJ-1",
+			},
+			errSubstr: "failed to link PRJ-2 as a subtask of PRJ-1",
+		},
+		{
+			name:     "Undeletable issue is reported",
+			failOn:   "link",
+			denyOn:   "PRJ-1",
+			subtasks: []string{"One"},
+			expected: []string{
+				"POST /api/issues Epic in 0-1",
+				"POST /api/issues One in 0-1",
+				"POST /api/commands subtask of PRJ-1 on PRJ-2",
+				"DELETE /api/issues/PRJ-2",
+				"DELETE /api/issues/PRJ-1",
+			},
+			errSubstr: "delete PRJ-1",
+			rollback:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var mu sync.Mutex
+			var requests []string
+			created := 0
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				mu.Lock()
+				defer mu.Unlock()
+
+				var body struct {
+					Summary string      `json:"summary"`
+					Project ProjectRef  `json:"project"`
+					Query   string      `json:"query"`
+					Issues  []*IssueRef `json:"issues"`
+				}
+				json.NewDecoder(r.Body).Decode(&body)
+
+				switch {
+				case r.Method == http.MethodPost && r.URL.Path == "/api/issues":
+					requests = append(requests, fmt.Sprintf("POST %s %s in %s", r.URL.Path, body.Summary, body.Project.ID))
+					if body.Summary == tt.failOn {
+						w.WriteHeader(http.StatusBadRequest)
+						return
+					}
+					created++
+					fmt.Fprintf(w, `{"id":"2-%d","idReadable":"PRJ-%d","summary":%q}`, created, created, body.Summary)
+				case r.URL.Path == "/api/commands":
+					requests = append(requests, fmt.Sprintf("POST %s %s on %s", r.URL.Path, body.Query, body.Issues[0].ID))
+					if tt.failOn == "link" {
+						w.WriteHeader(http.StatusBadRequest)
+						return
+					}
+					w.Write([]byte(`{}`))
+				case r.Method == http.MethodDelete:
+					requests = append(requests, "DELETE "+r.URL.Path)
+					if strings.HasSuffix(r.URL.Path, "/"+tt.denyOn) {
+						w.WriteHeader(http.StatusForbidden)
+						return
+					}
+				}
+			}))
+			defer server.Close()
+
+			client := NewClient(server.URL)
+			ctx := NewYouTrackContext(context.Background(), "token")
+
+			var subtasks []*CreateIssueRequest
+			for i, summary := range tt.subtasks {
+				req := &CreateIssueRequest{Summary: summary}
+				if i == 1 {
+					req.Project = ProjectRef{ID: "0-2"}
+				}
+				subtasks = append(subtasks, req)
+			}
+
+			tree, err := client.CreateIssueTree(ctx, &CreateIssueRequest{Project: ProjectRef{ID: "0-1"}, Summary: "Epic"}, subtasks)
+			if !reflect.DeepEqual(requests, tt.expected) {
+				t.Errorf("Expected requests:\n%s\ngot:\n%s", strings.Join(tt.expected, "\n"), strings.Join(requests, "\n"))
+			}
+
+			if tt.errSubstr == "" {
+				if err != nil {
+					t.Fatalf("Unexpected error: %v", err)
+				}
+				if tree.Parent.ID != "PRJ-1" || len(tree.Subtasks) != len(tt.subtasks) {
+					t.Errorf("Expected PRJ-1 with %d subtasks, got %+v", len(tt.subtasks), tree)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.errSubstr) {
+				t.Fatalf("Expected error containing %q, got %v", tt.errSubstr, err)
+			}
+			var rollbackErr *RollbackError
+			if errors.As(err, &rollbackErr) != tt.rollback {
+				t.Errorf("Expected rollback error %v, got %v", tt.rollback, err)
+			}
+			if subtasks[0].Project.ID != "" {
+				t.Errorf("Expected the subtask requests to be left as they are, got %+v", subtasks[0])
+			}
+		})
+	}
+}
@@ -0,0 +1,442 @@
+package youtrack
+
+import (
+	"fmt"
+	"html"
+	"regexp"
+	"strings"
+)
+
+// MarkupFormat is the markup language of an issue text
+type MarkupFormat string
+
+const (
+	// MarkupMarkdown is the markup of current YouTrack texts
+	MarkupMarkdown MarkupFormat = "markdown"
+	// MarkupHTML is HTML, found in texts imported from other trackers
+	MarkupHTML MarkupFormat = "html"
+	// MarkupWiki is the YouTrack wiki markup of texts written before Markdown became the default
+	MarkupWiki MarkupFormat = "wiki"
+)
+
+var (
+	htmlBlockPattern  = regexp.MustCompile(`(?i)<(p|br|div|b|strong|i|em|u|ul|ol|li|h[1-6]|pre|code|a|span|table|tr|td|font|blockquote|img)\b[^>]*/?>`)
+	htmlTagPattern    = regexp.MustCompile(`<(/?)([a-zA-Z][a-zA-Z0-9]*)([^>]*)>`)
+	htmlAttrPattern   = regexp.MustCompile(`(?i)\b(href|src|alt)\s*=\s*(?:"([^"]*)"|'([^']*)'|([^\s>]+))`)
+	htmlSpacePattern  = regexp.MustCompile(`[ \t\r\n]+`)
+	blankLinesPattern = regexp.MustCompile(`\n[ \t]*\n(?:[ \t]*\n)+`)
+
+	wikiBlockPattern     = regexp.MustCompile(`\{(code|quote|noformat|monospace|html)(:[^}]*)?\}`)
+	wikiHeadingPattern   = regexp.MustCompile(`^(={1,6})\s*(.+?)\s*=+\s*$`)
+	wikiLinkPattern      = regexp.MustCompile(`\[((?:https?|ftp)://[^\s\]]+)(?:\s+([^\]]+))?\]`)
+	wikiMonospacePattern = regexp.MustCompile(`\{monospace\}(.*?)\{monospace\}|\{\{(.*?)\}\}`)
+	wikiBoldPattern      = regexp.MustCompile(`(^|[\s(])\*([^*\s](?:[^*]*[^*\s])?)\*($|[\s.,;:!?)])`)
+	wikiOrderedPattern   = regexp.MustCompile(`^(\s*)#\s+`)
+)
+
+// DetectMarkup guesses the markup of a text: HTML when it has HTML tags, wiki when it has
+// YouTrack wiki blocks, headings or links, else Markdown
+func DetectMarkup(text string) MarkupFormat {
+	if htmlBlockPattern.MatchString(text) {
+		return MarkupHTML
+	}
+	if wikiBlockPattern.MatchString(text) || wikiLinkPattern.MatchString(text) {
+		return MarkupWiki
+	}
+	for _, line := range strings.Split(text, "\n") {
+		if wikiHeadingPattern.MatchString(line) {
+			return MarkupWiki
+		}
+	}
+	return MarkupMarkdown
+}
+
+// ToMarkdown converts an HTML or wiki text to Markdown. Markdown texts are returned as they are.
+func ToMarkdown(text string) string {
+	return ConvertMarkup(text, DetectMarkup(text))
+}
+
+// ConvertMarkup converts a text of the given markup to Markdown
+func ConvertMarkup(text string, format MarkupFormat) string {
+	switch format {
+	case MarkupHTML:
+		return htmlToMarkdown(text)
+	case MarkupWiki:
+		return wikiToMarkdown(text)
+	default:
+		return text
+	}
+}
+
+// MarkdownDescription returns the description as Markdown. Descriptions YouTrack flags as not
+// Markdown, and descriptions that look like HTML or wiki markup, are converted.
+func (i *Issue) MarkdownDescription() string {
+	format := DetectMarkup(i.Description)
+	if format == MarkupMarkdown && i.UsesMarkdown != nil && !*i.UsesMarkdown {
+		format = MarkupWiki
+	}
+	return ConvertMarkup(i.Description, format)
+}
+
+// htmlConverter renders HTML elements as Markdown. Links and quotes buffer their content,
+// so the frames keep the output of each open element.
+type htmlConverter struct {
+	frames []*htmlFrame
+	lists  []*htmlList
+	pre    int
+}
+
+type htmlFrame struct {
+	tag  string
+	href string
+	sb   strings.Builder
+}
+
+type htmlList struct {
+	ordered bool
+	count   int
+}
+
+// htmlToMarkdown converts HTML to Markdown. Unknown tags are dropped, their text is kept.
+func htmlToMarkdown(text string) string {
+	c := &htmlConverter{frames: []*htmlFrame{{}}}
+	last := 0
+	for _, match := range htmlTagPattern.FindAllStringSubmatchIndex(text, -1) {
+		c.text(text[last:match[0]])
+		last = match[1]
+		closing := text[match[2]:match[3]] == "/"
+		tag := strings.ToLower(text[match[4]:match[5]])
+		attrs := text[match[6]:match[7]]
+		if closing {
+			c.close(tag)
+		} else {
+			c.open(tag, attrs)
+		}
+	}
+	c.text(text[last:])
+
+	// Close the elements left open
+	for len(c.frames) > 1 {
+		c.close(c.frames[len(c.frames)-1].tag)
+	}
+	return tidyMarkdown(c.frames[0].sb.String())
+}
+
+func (c *htmlConverter) out() *strings.Builder {
+	return &c.frames[len(c.frames)-1].sb
+}
+
+func (c *htmlConverter) text(s string) {
+	s = html.UnescapeString(s)
+	out := c.out()
+	if c.pre == 0 {
+		s = htmlSpacePattern.ReplaceAllString(s, " ")
+		// No leading space on a new line
+		if out.Len() == 0 || strings.HasSuffix(out.String(), "\n") {
+			s = strings.TrimLeft(s, " ")
+		}
+	}
+	out.WriteString(s)
+}
+
+func (c *htmlConverter) open(tag, attrs string) {
+	out := c.out()
+	switch tag {
+	case "p", "div", "table":
+		out.WriteString("\n\n")
+	case "br":
+		out.WriteString("\n")
+	case "tr":
+		out.WriteString("\n")
+	case "td", "th":
+		out.WriteString(" ")
+	case "h1", "h2", "h3", "h4", "h5", "h6":
+		out.WriteString("\n\n" + strings.Repeat("#", int(tag[1]-'0')) + " ")
+	case "b", "strong":
+		out.WriteString("**")
+	case "i", "em":
+		out.WriteString("_")
+	case "s", "strike", "del":
+		out.WriteString("~~")
+	case "code":
+		if c.pre == 0 {
+			out.WriteString("`")
+		}
+	case "pre":
+		out.WriteString("\n\n```\n")
+		c.pre++
+	case "img":
+		out.WriteString(fmt.Sprintf("![%s](%s)", htmlAttr(attrs, "alt"), htmlAttr(attrs, "src")))
+	case "ul", "ol":
+		c.lists = append(c.lists, &htmlList{ordered: tag == "ol"})
+	case "li":
+		out.WriteString("\n")
+		if len(c.lists) == 0 {
+			out.WriteString("- ")
+			break
+		}
+		list := c.lists[len(c.lists)-1]
+		out.WriteString(strings.Repeat("  ", len(c.lists)-1))
+		if list.ordered {
+			list.count++
+			out.WriteString(fmt.Sprintf("%d. ", list.count))
+		} else {
+			out.WriteString("- ")
+		}
+	case "a", "blockquote":
+		c.frames = append(c.frames, &htmlFrame{tag: tag, href: htmlAttr(attrs, "href")})
+	}
+}
+
+func (c *htmlConverter) close(tag string) {
+	out := c.out()
+	switch tag {
+	case "p", "div", "table":
+		out.WriteString("\n\n")
+	case "h1", "h2", "h3", "h4", "h5", "h6":
+		out.WriteString("\n\n")
+	case "b", "strong":
+		out.WriteString("**")
+	case "i", "em":
+		out.WriteString("_")
+	case "s", "strike", "del":
+		out.WriteString("~~")
+	case "code":
+		if c.pre == 0 {
+			out.WriteString("`")
+		}
+	case "pre":
+		if c.pre > 0 {
+			out.WriteString("\n```\n\n")
+			c.pre--
+		}
+	case "ul", "ol":
+		if len(c.lists) > 0 {
+			c.lists = c.lists[:len(c.lists)-1]
+		}
+		out.WriteString("\n\n")
+	case "a", "blockquote":
+		// Close the innermost frame of the tag, and the frames opened after it
+		for len(c.frames) > 1 {
+			frame := c.frames[len(c.frames)-1]
+			c.frames = c.frames[:len(c.frames)-1]
+			c.out().WriteString(frame.render())
+			if frame.tag == tag {
+				break
+			}
+		}
+	}
+}
+
+// render returns the Markdown of a buffered element
+func (f *htmlFrame) render() string {
+	content := f.sb.String()
+	switch f.tag {
+	case "a":
+		text := strings.TrimSpace(content)
+		if f.href == "" {
+			return content
+		}
+		if text == "" || text == f.href {
+			return "<" + f.href + ">"
+		}
+		return "[" + text + "](" + f.href + ")"
+	case "blockquote":
+		return "\n\n" + quoteLines(tidyMarkdown(content)) + "\n\n"
+	}
+	return content
+}
+
+// htmlAttr returns the value of an attribute of an HTML tag
+func htmlAttr(attrs, name string) string {
+	for _, match := range htmlAttrPattern.FindAllStringSubmatch(attrs, -1) {
+		if strings.EqualFold(match[1], name) {
+			return html.UnescapeString(match[2] + match[3] + match[4])
+		}
+	}
+	return ""
+}
+
+// wikiToMarkdown converts YouTrack wiki markup to Markdown: code and quote blocks, headings,
+// links, monospace, bold and numbered lists. Italics (_text_) are the same in both.
+func wikiToMarkdown(text string) string {
+	var lines []string
+	inCode, inQuote := false, false
+	for _, line := range strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n") {
+		// Block markers, alone on their line or around text
+		if match := wikiBlockPattern.FindStringSubmatchIndex(line); match != nil && !wikiMonospacePattern.MatchString(line) {
+			block := line[match[2]:match[3]]
+			lang := ""
+			if match[4] >= 0 {
+				lang = strings.TrimPrefix(line[match[4]:match[5]], ":")
+			}
+			before, after := strings.TrimSpace(line[:match[0]]), strings.TrimSpace(line[match[1]:])
+			switch {
+			case block == "code" || block == "noformat" || block == "html":
+				if before != "" {
+					lines = append(lines, before)
+				}
+				if inCode {
+					lines = append(lines, "```")
+				} else {
+					lines = append(lines, "```"+lang)
+				}
+				inCode = !inCode
+				if after != "" {
+					lines = append(lines, after)
+				}
+				continue
+			case block == "quote" && !inCode:
+				inQuote = !inQuote
+				if before != "" {
+					lines = append(lines, quoteLines(before))
+				}
+				if after != "" {
+					lines = append(lines, quoteLines(after))
+				}
+				continue
+			}
+		}
+
+		if inCode {
+			lines = append(lines, line)
+			continue
+		}
+
+		if match := wikiHeadingPattern.FindStringSubmatch(line); match != nil {
+			line = strings.Repeat("#", len(match[1])) + " " + match[2]
+		} else {
+			line = wikiOrderedPattern.ReplaceAllString(line, "${1}1. ")
+		}
+		line = wikiMonospacePattern.ReplaceAllString(line, "`$1$2`")
+		line = wikiLinkPattern.ReplaceAllStringFunc(line, func(link string) string {
+			match := wikiLinkPattern.FindStringSubmatch(link)
+			if match[2] == "" {
+				return "<" + match[1] + ">"
+			}
+			return "[" + match[2] + "](" + match[1] + ")"
+		})
+		line = wikiBoldPattern.ReplaceAllString(line, "$1**$2**$3")
+
+		if inQuote {
+			line = quoteLines(line)
+		}
+		lines = append(lines, line)
+	}
+	if inCode {
+		lines = append(lines, "```")
+	}
+	return strings.Join(lines, "\n")
+}
+
+// quoteLines prefixes each line of a text with the Markdown quote marker
+func quoteLines(text string) string {
+	lines := strings.Split(text, "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimRight("> "+line, " ")
+	}
+	return strings.Join(lines, "\n")
+}
+
+// tidyMarkdown trims the lines and blank lines left by the conversion
+func tidyMarkdown(text string) string {
+	lines := strings.Split(text, "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimRight(line, " \t")
+	}
+	text = blankLinesPattern.ReplaceAllString(strings.Join(lines, "\n"), "\n\n")
+	return strings.Trim(text, "\n ")
+}
+
+var (
+	wordPattern       = regexp.MustCompile(`[\pL']+`)
+	emptyLinkPattern  = regexp.MustCompile(`\[[^\]]*\]\(\s*\)`)
+	openLinkPattern   = regexp.MustCompile(`\[[^\]]*\]\([^)\s]*$`)
+	inlineCodePattern = regexp.MustCompile("`[^`]*`")
+)
+
+// MarkdownWarnings checks a Markdown text for the mistakes that make it render badly: unclosed
+// code blocks, code spans and bold markers, broken links, repeated words, and HTML or wiki
+// markup that YouTrack shows as typed. It returns one message per problem found.
+func MarkdownWarnings(text string) []string {
+	var warnings []string
+	switch DetectMarkup(text) {
+	case MarkupHTML:
+		warnings = append(warnings, "the text contains HTML tags, YouTrack comments are Markdown")
+	case MarkupWiki:
+		warnings = append(warnings, "the text looks like YouTrack wiki markup ({code}, =heading=, [url text]), use Markdown instead")
+	}
+
+	inFence, fenceLine := false, 0
+	var paragraph []string
+	checkParagraph := func() {
+		if strings.Count(strings.Join(paragraph, "\n"), "**")%2 != 0 {
+			warnings = append(warnings, fmt.Sprintf("unclosed bold marker ** in the paragraph starting %q", shorten(paragraph[0], 30)))
+		}
+		paragraph = nil
+	}
+
+	for i, line := range strings.Split(text, "\n") {
+		number := i + 1
+		if strings.HasPrefix(strings.TrimSpace(line), "```") {
+			if !inFence {
+				fenceLine = number
+			}
+			inFence = !inFence
+			continue
+		}
+		if inFence {
+			continue
+		}
+		if strings.TrimSpace(line) == "" {
+			if len(paragraph) > 0 {
+				checkParagraph()
+			}
+			continue
+		}
+		paragraph = append(paragraph, line)
+
+		if strings.Count(line, "`")%2 != 0 {
+			warnings = append(warnings, fmt.Sprintf("line %d: unclosed code span `", number))
+		}
+		prose := inlineCodePattern.ReplaceAllString(line, "")
+		if emptyLinkPattern.MatchString(prose) {
+			warnings = append(warnings, fmt.Sprintf("line %d: link without a target", number))
+		}
+		if openLinkPattern.MatchString(prose) {
+			warnings = append(warnings, fmt.Sprintf("line %d: unclosed link target", number))
+		}
+		if word := repeatedWord(prose); word != "" {
+			warnings = append(warnings, fmt.Sprintf("line %d: repeated word %q", number, word))
+		}
+	}
+	if len(paragraph) > 0 {
+		checkParagraph()
+	}
+	if inFence {
+		warnings = append(warnings, fmt.Sprintf("line %d: code block ``` is never closed", fenceLine))
+	}
+	return warnings
+}
+
+// repeatedWord returns the first word of a line typed twice in a row, e.g. "the the"
+func repeatedWord(line string) string {
+	words := wordPattern.FindAllStringIndex(line, -1)
+	for i := 1; i < len(words); i++ {
+		prev, word := line[words[i-1][0]:words[i-1][1]], line[words[i][0]:words[i][1]]
+		between := line[words[i-1][1]:words[i][0]]
+		if len([]rune(word)) > 1 && strings.TrimSpace(between) == "" && strings.EqualFold(prev, word) {
+			return word
+		}
+	}
+	return ""
+}
+
+// shorten cuts a text to at most n runes
+func shorten(text string, n int) string {
+	runes := []rune(strings.TrimSpace(text))
+	if len(runes) <= n {
+		return string(runes)
+	}
+	return string(runes[:n]) + "..."
+}
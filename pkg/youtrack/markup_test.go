@@ -0,0 +1,137 @@
+package youtrack
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestDetectMarkup(t *testing.T) {
+	tests := []struct {
+		name     string
+		text     string
+		expected MarkupFormat
+	}{
+		{name: "Markdown", text: "# Title\n\nSome **bold** text and a [link](http://example.com)", expected: MarkupMarkdown},
+		{name: "Plain text", text: "Steps: open the page, click save", expected: MarkupMarkdown},
+		{name: "HTML paragraphs", text: "<p>First</p><p>Second</p>", expected: MarkupHTML},
+		{name: "HTML line breaks", text: "line one<br/>line two", expected: MarkupHTML},
+		{name: "Wiki code block", text: "Run:\n{code}\nmake\n{code}", expected: MarkupWiki},
+		{name: "Wiki heading", text: "== Steps ==\nopen the page", expected: MarkupWiki},
+		{name: "Wiki link", text: "See [http://example.com the docs]", expected: MarkupWiki},
+		{name: "Markdown comparison is not a tag", text: "a < b and b > c", expected: MarkupMarkdown},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := DetectMarkup(tt.text); got != tt.expected {
+				t.Errorf("Expected %s, got %s", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestToMarkdown(t *testing.T) {
+	tests := []struct {
+		name     string
+		text     string
+		expected string
+	}{
+		{
+			name:     "Markdown unchanged",
+			text:     "Some *text*\n\n- item",
+			expected: "Some *text*\n\n- item",
+		},
+		{
+			name:     "HTML inline formatting",
+			text:     "<p>A <b>bold</b>, <i>italic</i> and <code>code</code> &amp; more</p>",
+			expected: "A **bold**, _italic_ and `code` & more",
+		},
+		{
+			name:     "HTML headings and links",
+			text:     "<h2>Steps</h2><p>See <a href=\"http://example.com/a\">the docs</a> or <a href='http://example.com/b'>http://example.com/b</a></p>",
+			expected: "## Steps\n\nSee [the docs](http://example.com/a) or <http://example.com/b>",
+		},
+		{
+			name:     "HTML lists",
+			text:     "<ul><li>one</li><li>two<ol><li>first</li><li>second</li></ol></li></ul>",
+			expected: "- one\n- two\n  1. first\n  2. second",
+		},
+		{
+			name:     "HTML preformatted block and quote",
+			text:     "<pre>if a &lt; b {\n  return\n}</pre><blockquote><p>quoted</p><p>text</p></blockquote>",
+			expected: "```\nif a < b {\n  return\n}\n```\n\n> quoted\n>\n> text",
+		},
+		{
+			name:     "HTML image",
+			text:     "<div><img src=\"shot.png\" alt=\"Screenshot\"/></div>",
+			expected: "![Screenshot](shot.png)",
+		},
+		{
+			name:     "Wiki blocks",
+			text:     "= Title =\nRun {monospace}make{monospace} *now*:\n{code:go}\nfmt.Println(\"*x*\")\n{code}\n{quote}\nsaid so\n{quote}",
+			expected: "# Title\nRun `make` **now**:\n```go\nfmt.Println(\"*x*\")\n```\n> said so",
+		},
+		{
+			name:     "Wiki links and numbered lists",
+			text:     "# first [http://example.com docs]\n# second [https://example.com]",
+			expected: "1. first [docs](http://example.com)\n1. second <https://example.com>",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ToMarkdown(tt.text); got != tt.expected {
+				t.Errorf("Expected:\n%s\ngot:\n%s", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestIssue_MarkdownDescription(t *testing.T) {
+	legacy, current := false, true
+	tests := []struct {
+		name         string
+		usesMarkdown *bool
+		description  string
+		expected     string
+	}{
+		{name: "Legacy flag converts wiki bold", usesMarkdown: &legacy, description: "a *bold* word", expected: "a **bold** word"},
+		{name: "Markdown flag keeps the text", usesMarkdown: &current, description: "a *bold* word", expected: "a *bold* word"},
+		{name: "HTML is converted whatever the flag", usesMarkdown: &current, description: "<p>a <b>bold</b> word</p>", expected: "a **bold** word"},
+		{name: "Not fetched flag keeps Markdown", description: "a *bold* word", expected: "a *bold* word"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			issue := &Issue{Description: tt.description, UsesMarkdown: tt.usesMarkdown}
+			if got := issue.MarkdownDescription(); got != tt.expected {
+				t.Errorf("Expected %q, got %q", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestMarkdownWarnings(t *testing.T) {
+	tests := []struct {
+		name     string
+		text     string
+		expected []string
+	}{
+		{name: "Clean text", text: "Fixed in `main`, see [PR](http://example.com/1).\n\n```\nx **y\n```", expected: nil},
+		{name: "Unclosed code block", text: "Run:\n```\nmake", expected: []string{"line 2: code block ``` is never closed"}},
+		{name: "Unclosed code span", text: "call `foo()", expected: []string{"line 1: unclosed code span `"}},
+		{name: "Unclosed bold", text: "a **bold\nword\n\nnext", expected: []string{`unclosed bold marker ** in the paragraph starting "a **bold"`}},
+		{name: "Broken links", text: "see [docs]()\nand [more](http://example", expected: []string{"line 1: link without a target", "line 2: unclosed link target"}},
+		{name: "Repeated word", text: "fixed in the the next build", expected: []string{`line 1: repeated word "the"`}},
+		{name: "Wiki markup", text: "{code}\nmake\n{code}", expected: []string{"the text looks like YouTrack wiki markup ({code}, =heading=, [url text]), use Markdown instead"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := MarkdownWarnings(tt.text)
+			if strings.Join(got, "\n") != strings.Join(tt.expected, "\n") {
+				t.Errorf("Expected %q, got %q", tt.expected, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,204 @@
+package youtrack
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/url"
+	"strings"
+)
+
+// notificationFields are the fields requested for notifications
+const notificationFields = "id,read,updated,content,metadata"
+
+// maxUnreadScan is the number of recent notifications CountUnreadNotifications looks at
+const maxUnreadScan = 1000
+
+// Notification is an entry of the current user's notification inbox: a change to an issue
+// the user is mentioned in, subscribed to by a tag or a saved search, or otherwise watches
+type Notification struct {
+	ID      string       `json:"id"`
+	Read    bool         `json:"read"`
+	Updated YouTrackTime `json:"updated"`
+
+	// IssueID and IssueSummary identify the changed issue
+	IssueID      string `json:"issueId,omitempty"`
+	IssueSummary string `json:"issueSummary,omitempty"`
+	// Reasons tell why the user was notified, e.g. "mention", "tag Star", "saved search Assigned to me"
+	Reasons []string `json:"reasons,omitempty"`
+	// Changes are the names of the changed fields or the kinds of change, e.g. "State", "comment"
+	Changes []string `json:"changes,omitempty"`
+	// Content is the rendered notification, as sent by email
+	Content string `json:"content,omitempty"`
+}
+
+// IsMention reports whether the user was notified for being mentioned
+func (n *Notification) IsMention() bool {
+	for _, reason := range n.Reasons {
+		if reason == "mention" {
+			return true
+		}
+	}
+	return false
+}
+
+// rawNotification is a notification as returned by the API, with compressed content and metadata
+type rawNotification struct {
+	ID       string       `json:"id"`
+	Read     bool         `json:"read"`
+	Updated  YouTrackTime `json:"updated"`
+	Content  string       `json:"content"`
+	Metadata string       `json:"metadata"`
+}
+
+// notificationMetadata is the part of the notification metadata that is read
+type notificationMetadata struct {
+	Issue *struct {
+		ID      string `json:"id"`
+		Summary string `json:"summary"`
+	} `json:"issue"`
+	Reason *struct {
+		Mentions      []notificationReason `json:"mentionReasons"`
+		Tags          []notificationReason `json:"tagReasons"`
+		SavedSearches []notificationReason `json:"savedSearchReasons"`
+	} `json:"reason"`
+	Change *struct {
+		Events []struct {
+			Category string `json:"category"`
+			Name     string `json:"name"`
+		} `json:"events"`
+	} `json:"change"`
+}
+
+type notificationReason struct {
+	Name string `json:"name"`
+}
+
+// GetNotifications returns the current user's notifications, most recent first. Paginated;
+// with unreadOnly, read notifications are left out of the page.
+func (c *Client) GetNotifications(ctx *YouTrackContext, skip, top int, unreadOnly bool) ([]*Notification, error) {
+	params := url.Values{}
+	params.Add("fields", notificationFields)
+	params.Add("$skip", fmt.Sprintf("%d", skip))
+	params.Add("$top", fmt.Sprintf("%d", top))
+
+	resp, err := c.Get(ctx, "/api/users/notifications", params)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var raw []*rawNotification
+	if err := json.NewDecoder(resp.Body).Decode(&raw); err != nil {
+		return nil, fmt.Errorf("failed to decode notifications: %w", err)
+	}
+
+	notifications := make([]*Notification, 0, len(raw))
+	for _, r := range raw {
+		if unreadOnly && r.Read {
+			continue
+		}
+		notifications = append(notifications, r.decode())
+	}
+	return notifications, nil
+}
+
+// CountUnreadNotifications counts the unread notifications among the 1000 most recent ones
+func (c *Client) CountUnreadNotifications(ctx *YouTrackContext) (int, error) {
+	count := 0
+	for skip := 0; skip < maxUnreadScan; skip += DefaultPageSize {
+		page, err := c.GetNotifications(ctx, skip, DefaultPageSize, false)
+		if err != nil {
+			return 0, err
+		}
+		for _, n := range page {
+			if !n.Read {
+				count++
+			}
+		}
+		if len(page) < DefaultPageSize {
+			break
+		}
+	}
+	return count, nil
+}
+
+// MarkNotificationRead marks a notification read, or unread with read false
+func (c *Client) MarkNotificationRead(ctx *YouTrackContext, notificationID string, read bool) error {
+	path := fmt.Sprintf("/api/users/notifications/%s", notificationID)
+
+	resp, err := c.Post(ctx, path, map[string]bool{"read": read})
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	return nil
+}
+
+// decode unpacks the content and metadata of a notification
+func (r *rawNotification) decode() *Notification {
+	n := &Notification{ID: r.ID, Read: r.Read, Updated: r.Updated}
+	if content, err := unpackNotificationField(r.Content); err == nil {
+		n.Content = string(content)
+	}
+
+	data, err := unpackNotificationField(r.Metadata)
+	if err != nil {
+		return n
+	}
+	var meta notificationMetadata
+	if err := json.Unmarshal(data, &meta); err != nil {
+		return n
+	}
+
+	if meta.Issue != nil {
+		n.IssueID, n.IssueSummary = meta.Issue.ID, meta.Issue.Summary
+	}
+	if meta.Reason != nil {
+		if len(meta.Reason.Mentions) > 0 {
+			n.Reasons = append(n.Reasons, "mention")
+		}
+		for _, tag := range meta.Reason.Tags {
+			n.Reasons = append(n.Reasons, "tag "+tag.Name)
+		}
+		for _, search := range meta.Reason.SavedSearches {
+			n.Reasons = append(n.Reasons, "saved search "+search.Name)
+		}
+	}
+	if meta.Change != nil {
+		seen := make(map[string]bool)
+		for _, event := range meta.Change.Events {
+			name := event.Name
+			if name == "" {
+				name = strings.ToLower(strings.TrimSuffix(event.Category, "Category"))
+			}
+			if name != "" && !seen[name] {
+				seen[name] = true
+				n.Changes = append(n.Changes, name)
+			}
+		}
+	}
+	return n
+}
+
+// unpackNotificationField decodes a notification field, sent base64-encoded and gzip-compressed.
+// Fields that are not compressed are returned as they are.
+func unpackNotificationField(value string) ([]byte, error) {
+	if value == "" {
+		return nil, nil
+	}
+	data, err := base64.StdEncoding.DecodeString(value)
+	if err != nil {
+		return []byte(value), nil
+	}
+	reader, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return data, nil
+	}
+	defer reader.Close()
+	return io.ReadAll(reader)
+}
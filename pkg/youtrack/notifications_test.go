@@ -0,0 +1,143 @@
+package youtrack
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// packNotificationField compresses a value the way YouTrack sends notification fields
+func packNotificationField(t *testing.T, value string) string {
+	var buf bytes.Buffer
+	w := gzip.NewWriter(&buf)
+	if _, err := w.Write([]byte(value)); err != nil {
+		t.Fatal(err)
+	}
+	w.Close()
+	return base64.StdEncoding.EncodeToString(buf.Bytes())
+}
+
+func TestClient_GetNotifications(t *testing.T) {
+	mention := packNotificationField(t, `{"issue":{"id":"PROJ-1","summary":"Login fails"},
+		"reason":{"mentionReasons":[{"name":"Mention"}],"tagReasons":[{"name":"Star"}]},
+		"change":{"events":[{"category":"COMMENT","name":""},{"category":"CUSTOM_FIELD","name":"State"},{"category":"CUSTOM_FIELD","name":"State"}]}}`)
+	saved := packNotificationField(t, `{"issue":{"id":"PROJ-2","summary":"Slow search"},
+		"reason":{"savedSearchReasons":[{"name":"Assigned to me"}]}}`)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/users/notifications" {
+			http.NotFound(w, r)
+			return
+		}
+		fmt.Fprintf(w, `[
+			{"id":"n1","read":false,"updated":1700000000000,"content":%q,"metadata":%q},
+			{"id":"n2","read":true,"updated":1690000000000,"metadata":%q},
+			{"id":"n3","read":false,"updated":1680000000000,"metadata":"not packed"}]`,
+			packNotificationField(t, "<p>John mentioned you</p>"), mention, saved)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL)
+	ctx := NewYouTrackContext(context.Background(), "token")
+
+	tests := []struct {
+		name       string
+		unreadOnly bool
+		expected   []string
+	}{
+		{name: "All", unreadOnly: false, expected: []string{"n1", "n2", "n3"}},
+		{name: "Unread only", unreadOnly: true, expected: []string{"n1", "n3"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			notifications, err := client.GetNotifications(ctx, 0, 10, tt.unreadOnly)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if len(notifications) != len(tt.expected) {
+				t.Fatalf("Expected %d notifications, got %d", len(tt.expected), len(notifications))
+			}
+			for i, id := range tt.expected {
+				if notifications[i].ID != id {
+					t.Errorf("Expected notification %d to be %s, got %s", i, id, notifications[i].ID)
+				}
+			}
+		})
+	}
+
+	notifications, err := client.GetNotifications(ctx, 0, 10, false)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	first := notifications[0]
+	if first.IssueID != "PROJ-1" || first.IssueSummary != "Login fails" || first.Content != "<p>John mentioned you</p>" {
+		t.Errorf("Unexpected notification: %+v", first)
+	}
+	if !first.IsMention() || fmt.Sprint(first.Reasons) != "[mention tag Star]" {
+		t.Errorf("Unexpected reasons: %v", first.Reasons)
+	}
+	if fmt.Sprint(first.Changes) != "[comment State]" {
+		t.Errorf("Unexpected changes: %v", first.Changes)
+	}
+	if second := notifications[1]; second.IsMention() || fmt.Sprint(second.Reasons) != "[saved search Assigned to me]" {
+		t.Errorf("Unexpected reasons: %v", second.Reasons)
+	}
+	if third := notifications[2]; third.IssueID != "" || third.Reasons != nil {
+		t.Errorf("Expected an undecodable notification without details, got %+v", third)
+	}
+}
+
+func TestClient_CountUnreadNotifications(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var page []map[string]interface{}
+		if r.URL.Query().Get("$skip") == "0" {
+			for i := 0; i < DefaultPageSize; i++ {
+				page = append(page, map[string]interface{}{"id": fmt.Sprintf("n%d", i), "read": i%2 == 0})
+			}
+		} else {
+			page = append(page, map[string]interface{}{"id": "last", "read": false})
+		}
+		json.NewEncoder(w).Encode(page)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL)
+	ctx := NewYouTrackContext(context.Background(), "token")
+
+	count, err := client.CountUnreadNotifications(ctx)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if count != DefaultPageSize/2+1 {
+		t.Errorf("Expected %d unread notifications, got %d", DefaultPageSize/2+1, count)
+	}
+}
+
+func TestClient_MarkNotificationRead(t *testing.T) {
+	var body map[string]bool
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/api/users/notifications/n1" {
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		json.NewDecoder(r.Body).Decode(&body)
+		fmt.Fprint(w, `{}`)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL)
+	ctx := NewYouTrackContext(context.Background(), "token")
+
+	if err := client.MarkNotificationRead(ctx, "n1", true); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !body["read"] {
+		t.Errorf("Expected read true, got %v", body)
+	}
+}
@@ -0,0 +1,215 @@
+package youtrack
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"time"
+)
+
+// Middleware wraps the round tripper that sends the client requests,
+// e.g. to add headers, retry, record or rate-limit them
+type Middleware func(next http.RoundTripper) http.RoundTripper
+
+// RoundTripperFunc adapts a function to http.RoundTripper, for writing middleware
+type RoundTripperFunc func(*http.Request) (*http.Response, error)
+
+// RoundTrip calls f(req)
+func (f RoundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+// ClientOption configures a client created by NewClientWithOptions
+type ClientOption func(*clientOptions) error
+
+// clientOptions collects the options of NewClientWithOptions
+type clientOptions struct {
+	httpClient *http.Client
+	transport  TransportConfig
+	middleware []Middleware
+	userAgent  string
+	tlsConfig  *tls.Config
+	proxy      func(*http.Request) (*url.URL, error)
+	hubURL     string
+	timeout    time.Duration
+	logger     RESTLogger
+}
+
+// tls returns the TLS config the options change, creating it on first use
+func (o *clientOptions) tls() *tls.Config {
+	if o.tlsConfig == nil {
+		o.tlsConfig = &tls.Config{MinVersion: tls.VersionTLS12}
+	}
+	return o.tlsConfig
+}
+
+// NewClientWithOptions creates a client like NewClient, configured by options for environments
+// the defaults do not fit: corporate proxies, private certificate authorities, self-signed
+// certificates, request middleware or a custom http.Client.
+//
+//	client, err := youtrack.NewClientWithOptions("https://youtrack.corp",
+//		youtrack.WithCACertFile("/etc/ssl/corp-ca.pem"),
+//		youtrack.WithProxy("http://proxy.corp:3128"),
+//		youtrack.WithUserAgent("release-bot/1.0"),
+//	)
+func NewClientWithOptions(baseURL string, opts ...ClientOption) (*Client, error) {
+	o := &clientOptions{transport: DefaultTransportConfig(), timeout: 30 * time.Second}
+	for _, opt := range opts {
+		if err := opt(o); err != nil {
+			return nil, err
+		}
+	}
+
+	c := &Client{
+		baseURL:    baseURL,
+		hubURL:     o.hubURL,
+		logger:     o.logger,
+		userAgent:  o.userAgent,
+		tlsConfig:  o.tlsConfig,
+		proxy:      o.proxy,
+		middleware: o.middleware,
+	}
+
+	if o.httpClient == nil {
+		c.httpClient = &http.Client{}
+		c.timeout = o.timeout
+		c.buildTransport(o.transport)
+		return c, nil
+	}
+
+	// A custom client is used as is, only wrapped with the middleware
+	httpClient := *o.httpClient
+	if len(o.middleware) > 0 {
+		transport := httpClient.Transport
+		if transport == nil {
+			transport = http.DefaultTransport
+		}
+		httpClient.Transport = wrapMiddleware(transport, o.middleware)
+	}
+	c.httpClient = &httpClient
+	return c, nil
+}
+
+// WithHTTPClient sends the requests with a custom http.Client, e.g. one with instrumentation.
+// The transport, TLS, proxy and timeout options do not apply to it, nor does a later
+// SetTransportConfig; the middleware wraps its transport.
+func WithHTTPClient(client *http.Client) ClientOption {
+	return func(o *clientOptions) error {
+		if client == nil {
+			return fmt.Errorf("http client is nil")
+		}
+		o.httpClient = client
+		return nil
+	}
+}
+
+// WithTransportConfig sets the connection settings, DefaultTransportConfig otherwise
+func WithTransportConfig(cfg TransportConfig) ClientOption {
+	return func(o *clientOptions) error {
+		o.transport = cfg
+		return nil
+	}
+}
+
+// WithMiddleware wraps the transport with middleware; the first one sees the requests first
+func WithMiddleware(middleware ...Middleware) ClientOption {
+	return func(o *clientOptions) error {
+		o.middleware = append(o.middleware, middleware...)
+		return nil
+	}
+}
+
+// WithUserAgent sets the User-Agent header of the requests
+func WithUserAgent(userAgent string) ClientOption {
+	return func(o *clientOptions) error {
+		o.userAgent = userAgent
+		return nil
+	}
+}
+
+// WithTLSConfig sets the TLS configuration; later TLS options change a copy of it
+func WithTLSConfig(config *tls.Config) ClientOption {
+	return func(o *clientOptions) error {
+		if config == nil {
+			return fmt.Errorf("TLS config is nil")
+		}
+		o.tlsConfig = config.Clone()
+		return nil
+	}
+}
+
+// WithCACertFile trusts the certificate authorities of a PEM file in addition to the system ones,
+// for instances with certificates issued by a private CA
+func WithCACertFile(path string) ClientOption {
+	return func(o *clientOptions) error {
+		pem, err := os.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("failed to read CA certificates: %w", err)
+		}
+
+		pool, err := x509.SystemCertPool()
+		if err != nil || pool == nil {
+			pool = x509.NewCertPool()
+		}
+		if !pool.AppendCertsFromPEM(pem) {
+			return fmt.Errorf("no PEM certificates found in %s", path)
+		}
+		o.tls().RootCAs = pool
+		return nil
+	}
+}
+
+// WithInsecureSkipVerify disables the verification of the server certificate, for self-hosted
+// instances with self-signed certificates. It exposes the API key to anyone able to intercept
+// the connection, prefer WithCACertFile.
+func WithInsecureSkipVerify() ClientOption {
+	return func(o *clientOptions) error {
+		o.tls().InsecureSkipVerify = true
+		return nil
+	}
+}
+
+// WithProxy sends the requests through a proxy (http, https or socks5 URL, with credentials
+// if needed) instead of the one of the HTTP_PROXY/HTTPS_PROXY/NO_PROXY environment variables.
+// An empty URL connects directly, ignoring the environment.
+func WithProxy(proxyURL string) ClientOption {
+	return func(o *clientOptions) error {
+		if proxyURL == "" {
+			o.proxy = func(*http.Request) (*url.URL, error) { return nil, nil }
+			return nil
+		}
+		u, err := url.Parse(proxyURL)
+		if err != nil || u.Scheme == "" || u.Host == "" {
+			return fmt.Errorf("invalid proxy URL '%s'", proxyURL)
+		}
+		o.proxy = http.ProxyURL(u)
+		return nil
+	}
+}
+
+// WithHubURL sets the Hub instance URL, like SetHubURL
+func WithHubURL(hubURL string) ClientOption {
+	return func(o *clientOptions) error {
+		o.hubURL = hubURL
+		return nil
+	}
+}
+
+// WithTimeout sets the default request timeout, like SetTimeout
+func WithTimeout(timeout time.Duration) ClientOption {
+	return func(o *clientOptions) error {
+		o.timeout = timeout
+		return nil
+	}
+}
+
+// WithLogger sets the REST logger, like SetLogger
+func WithLogger(logger RESTLogger) ClientOption {
+	return func(o *clientOptions) error {
+		o.logger = logger
+		return nil
+	}
+}
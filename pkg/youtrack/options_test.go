@@ -0,0 +1,167 @@
+package youtrack
+
+import (
+	"context"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestNewClientWithOptions_Middleware(t *testing.T) {
+	var headers http.Header
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		headers = r.Header.Clone()
+		w.Write([]byte(`{"login":"john"}`))
+	}))
+	defer server.Close()
+
+	var order []string
+	middleware := func(name string) Middleware {
+		return func(next http.RoundTripper) http.RoundTripper {
+			return RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+				order = append(order, name)
+				req.Header.Add("X-Middleware", name)
+				return next.RoundTrip(req)
+			})
+		}
+	}
+
+	client, err := NewClientWithOptions(server.URL,
+		WithMiddleware(middleware("first"), middleware("second")),
+		WithUserAgent("release-bot/1.0"),
+	)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if _, err := client.GetCurrentUser(NewYouTrackContext(context.Background(), "token")); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if strings.Join(order, ",") != "first,second" {
+		t.Errorf("Expected middleware order first,second, got %v", order)
+	}
+	if got := headers.Values("X-Middleware"); len(got) != 2 {
+		t.Errorf("Expected both middleware headers, got %v", got)
+	}
+	if got := headers.Get("User-Agent"); got != "release-bot/1.0" {
+		t.Errorf("Expected User-Agent release-bot/1.0, got %q", got)
+	}
+
+	// Rebuilding the transport keeps the middleware
+	order = nil
+	client.SetTransportConfig(DefaultTransportConfig())
+	client.GetCurrentUser(NewYouTrackContext(context.Background(), "token"))
+	if len(order) != 2 {
+		t.Errorf("Expected the middleware to survive SetTransportConfig, got %v", order)
+	}
+}
+
+func TestNewClientWithOptions_TLS(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"login":"john"}`))
+	}))
+	defer server.Close()
+
+	caFile := filepath.Join(t.TempDir(), "ca.pem")
+	certPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+	if err := os.WriteFile(caFile, certPEM, 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		opts    []ClientOption
+		wantErr bool
+	}{
+		{name: "Untrusted certificate", opts: nil, wantErr: true},
+		{name: "Custom CA", opts: []ClientOption{WithCACertFile(caFile)}},
+		{name: "Insecure skip verify", opts: []ClientOption{WithInsecureSkipVerify()}},
+		{name: "Custom HTTP client", opts: []ClientOption{WithHTTPClient(server.Client())}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, err := NewClientWithOptions(server.URL, tt.opts...)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			_, err = client.GetCurrentUser(NewYouTrackContext(context.Background(), "token"))
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Expected error %v, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestNewClientWithOptions_CustomClientTransport(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"login":"john"}`))
+	}))
+	defer server.Close()
+
+	var calls int
+	client, err := NewClientWithOptions(server.URL,
+		WithHTTPClient(server.Client()),
+		WithMiddleware(func(next http.RoundTripper) http.RoundTripper {
+			return RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+				calls++
+				return next.RoundTrip(req)
+			})
+		}),
+	)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	// The custom client trusts the server certificate; a rebuilt transport would not
+	client.SetTransportConfig(DefaultTransportConfig())
+	if _, err := client.GetCurrentUser(NewYouTrackContext(context.Background(), "token")); err != nil {
+		t.Fatalf("Expected the custom client transport to be kept, got %v", err)
+	}
+	if calls != 1 {
+		t.Errorf("Expected the middleware to be called once, got %d", calls)
+	}
+}
+
+func TestNewClientWithOptions_InvalidOptions(t *testing.T) {
+	tests := []struct {
+		name string
+		opt  ClientOption
+	}{
+		{name: "Invalid proxy URL", opt: WithProxy("proxy.corp")},
+		{name: "Missing CA file", opt: WithCACertFile(filepath.Join(t.TempDir(), "missing.pem"))},
+		{name: "Nil HTTP client", opt: WithHTTPClient(nil)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := NewClientWithOptions("https://youtrack.example.com", tt.opt); err == nil {
+				t.Error("Expected an error")
+			}
+		})
+	}
+}
+
+func TestNewClientWithOptions_Proxy(t *testing.T) {
+	var proxied string
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		proxied = r.URL.String()
+		w.Write([]byte(`{"login":"john"}`))
+	}))
+	defer proxy.Close()
+
+	client, err := NewClientWithOptions("http://youtrack.invalid", WithProxy(proxy.URL))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if _, err := client.GetCurrentUser(NewYouTrackContext(context.Background(), "token")); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !strings.HasPrefix(proxied, "http://youtrack.invalid/api/users/me") {
+		t.Errorf("Expected the request to go through the proxy, got %q", proxied)
+	}
+}
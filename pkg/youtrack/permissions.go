@@ -0,0 +1,256 @@
+package youtrack
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+// Permission is a YouTrack permission, with the key used by the API and the name shown in
+// the YouTrack role settings
+type Permission struct {
+	Key  string `json:"key"`
+	Name string `json:"name"`
+}
+
+// The permissions checked by the client
+var (
+	PermissionReadIssue      = Permission{Key: "JetBrains.YouTrack.READ_ISSUE", Name: "Read Issue"}
+	PermissionCreateIssue    = Permission{Key: "JetBrains.YouTrack.CREATE_ISSUE", Name: "Create Issue"}
+	PermissionUpdateIssue    = Permission{Key: "JetBrains.YouTrack.UPDATE_ISSUE", Name: "Update Issue"}
+	PermissionDeleteIssue    = Permission{Key: "JetBrains.YouTrack.DELETE_ISSUE", Name: "Delete Issue"}
+	PermissionLinkIssue      = Permission{Key: "JetBrains.YouTrack.LINK_ISSUE", Name: "Link Issue"}
+	PermissionCreateComment  = Permission{Key: "JetBrains.YouTrack.CREATE_COMMENT", Name: "Create Comment"}
+	PermissionUpdateComment  = Permission{Key: "JetBrains.YouTrack.UPDATE_COMMENT", Name: "Update Comment"}
+	PermissionDeleteComment  = Permission{Key: "JetBrains.YouTrack.DELETE_COMMENT", Name: "Delete Comment"}
+	PermissionCreateWorkItem = Permission{Key: "JetBrains.YouTrack.CREATE_WORK_ITEM", Name: "Add Work Item"}
+	PermissionUpdateWorkItem = Permission{Key: "JetBrains.YouTrack.UPDATE_WORK_ITEM", Name: "Update Work Item"}
+	PermissionReadProject    = Permission{Key: "jetbrains.jetpass.project-read", Name: "Read Project"}
+	PermissionUpdateProject  = Permission{Key: "jetbrains.jetpass.project-update", Name: "Update Project"}
+)
+
+// PermissionError tells which permission a refused request needs, and in which project when
+// the request names one
+type PermissionError struct {
+	Permission Permission
+	Project    string
+	// Err is the API error, nil when a pre-flight check found the permission missing
+	Err error
+}
+
+func (e *PermissionError) Error() string {
+	if e.Project == "" {
+		return fmt.Sprintf("your token lacks '%s'", e.Permission.Name)
+	}
+	return fmt.Sprintf("your token lacks '%s' in project %s", e.Permission.Name, e.Project)
+}
+
+func (e *PermissionError) Unwrap() error {
+	return e.Err
+}
+
+// Permissions are the permissions of the current user, globally or per project
+type Permissions struct {
+	entries map[string]*permissionEntry
+}
+
+type permissionEntry struct {
+	Global     bool `json:"global"`
+	Permission struct {
+		Key string `json:"key"`
+	} `json:"permission"`
+	Projects []struct {
+		ID        string `json:"id"`
+		ShortName string `json:"shortName"`
+	} `json:"projects"`
+}
+
+// GetPermissions returns the permissions of the current user, from the permissions cache the
+// YouTrack UI uses
+func (c *Client) GetPermissions(ctx *YouTrackContext) (*Permissions, error) {
+	params := url.Values{}
+	params.Add("fields", "global,permission(key),projects(id,shortName)")
+
+	resp, err := c.Get(ctx, "/api/permissions/cache", params)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var entries []*permissionEntry
+	if err := json.NewDecoder(resp.Body).Decode(&entries); err != nil {
+		return nil, fmt.Errorf("failed to decode permissions: %w", err)
+	}
+
+	perms := &Permissions{entries: make(map[string]*permissionEntry, len(entries))}
+	for _, entry := range entries {
+		perms.entries[entry.Permission.Key] = entry
+	}
+	return perms, nil
+}
+
+// Has reports whether the user has a permission in a project, given by short name or ID.
+// With an empty project, it reports whether the user has it anywhere.
+func (p *Permissions) Has(permission Permission, projectID string) bool {
+	entry, ok := p.entries[permission.Key]
+	if !ok {
+		return false
+	}
+	if entry.Global || projectID == "" && len(entry.Projects) > 0 {
+		return true
+	}
+	for _, project := range entry.Projects {
+		if strings.EqualFold(project.ShortName, projectID) || project.ID == projectID {
+			return true
+		}
+	}
+	return false
+}
+
+// Missing returns the permissions the user lacks in a project, in the given order
+func (p *Permissions) Missing(projectID string, permissions ...Permission) []Permission {
+	var missing []Permission
+	for _, permission := range permissions {
+		if !p.Has(permission, projectID) {
+			missing = append(missing, permission)
+		}
+	}
+	return missing
+}
+
+// WorkPermissions are the permissions the day to day work on issues needs
+var WorkPermissions = []Permission{
+	PermissionReadIssue,
+	PermissionCreateIssue,
+	PermissionUpdateIssue,
+	PermissionCreateComment,
+	PermissionCreateWorkItem,
+}
+
+// PermissionNames formats permissions for messages, e.g. "'Update Issue', 'Add Work Item'"
+func PermissionNames(permissions []Permission) string {
+	names := make([]string, len(permissions))
+	for i, permission := range permissions {
+		names[i] = "'" + permission.Name + "'"
+	}
+	return strings.Join(names, ", ")
+}
+
+// Check returns a PermissionError when the user lacks a permission in a project
+func (p *Permissions) Check(permission Permission, projectID string) error {
+	if p.Has(permission, projectID) {
+		return nil
+	}
+	return &PermissionError{Permission: permission, Project: projectID}
+}
+
+// CheckPermission checks before a change that the current user has a permission in a project,
+// so the change is not attempted in vain
+func (c *Client) CheckPermission(ctx *YouTrackContext, permission Permission, projectID string) error {
+	perms, err := c.GetPermissions(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to check permissions: %w", err)
+	}
+	return perms.Check(permission, projectID)
+}
+
+// permissionRule maps requests to the permission they need
+type permissionRule struct {
+	method     string // empty for any method
+	pattern    *regexp.Regexp
+	permission Permission
+}
+
+// permissionRules are matched in order, the first matching rule wins. The first group of a
+// pattern is the issue or project ID.
+var permissionRules = []permissionRule{
+	{http.MethodPost, regexp.MustCompile(`^/api/issues$`), PermissionCreateIssue},
+	{http.MethodPost, regexp.MustCompile(`^/api/issues/([^/]+)/comments$`), PermissionCreateComment},
+	{http.MethodDelete, regexp.MustCompile(`^/api/issues/([^/]+)/comments/[^/]+$`), PermissionDeleteComment},
+	{http.MethodPost, regexp.MustCompile(`^/api/issues/([^/]+)/comments/[^/]+$`), PermissionUpdateComment},
+	{http.MethodPost, regexp.MustCompile(`^/api/issues/([^/]+)/timeTracking/workItems$`), PermissionCreateWorkItem},
+	{"", regexp.MustCompile(`^/api/issues/([^/]+)/timeTracking/workItems/[^/]+$`), PermissionUpdateWorkItem},
+	{"", regexp.MustCompile(`^/api/issues/([^/]+)/links(/.*)?$`), PermissionLinkIssue},
+	{http.MethodGet, regexp.MustCompile(`^/api/issues(?:/([^/]+))?(/.*)?$`), PermissionReadIssue},
+	{http.MethodDelete, regexp.MustCompile(`^/api/issues/([^/]+)$`), PermissionDeleteIssue},
+	{"", regexp.MustCompile(`^/api/issues/([^/]+)(/.*)?$`), PermissionUpdateIssue},
+	{http.MethodPost, regexp.MustCompile(`^/api/commands$`), PermissionUpdateIssue},
+	{http.MethodGet, regexp.MustCompile(`^/api/admin/projects/([^/]+)(/.*)?$`), PermissionReadProject},
+	{"", regexp.MustCompile(`^/api/admin/projects/([^/]+)(/.*)?$`), PermissionUpdateProject},
+}
+
+// issueProjectPattern matches a readable issue ID, the project is its prefix
+var issueProjectPattern = regexp.MustCompile(`^([A-Za-z][A-Za-z0-9_]*)-\d+$`)
+
+// RequiredPermission returns the permission a request needs, with the project the request
+// names: the project of a readable issue ID or the project of an admin path
+func RequiredPermission(method, path string) (Permission, string, bool) {
+	for _, rule := range permissionRules {
+		if rule.method != "" && rule.method != method {
+			continue
+		}
+		match := rule.pattern.FindStringSubmatch(path)
+		if match == nil {
+			continue
+		}
+
+		project := ""
+		if len(match) > 1 && match[1] != "" {
+			project = match[1]
+			if strings.HasPrefix(path, "/api/issues/") {
+				project = ""
+				if m := issueProjectPattern.FindStringSubmatch(match[1]); m != nil {
+					project = m[1]
+				}
+			}
+		}
+		return rule.permission, project, true
+	}
+	return Permission{}, "", false
+}
+
+// TranslateError replaces a 403 API error with a PermissionError naming the permission the
+// request needs, e.g. "your token lacks 'Update Issue' in project PRJ". The message of a
+// wrapped error keeps its context. Other errors, and errors already naming a permission, are
+// returned as they are.
+func TranslateError(err error) error {
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusForbidden {
+		return err
+	}
+	var permErr *PermissionError
+	if errors.As(err, &permErr) {
+		return err
+	}
+	permission, project, ok := RequiredPermission(apiErr.Method, apiErr.Path)
+	if !ok {
+		return err
+	}
+
+	permErr = &PermissionError{Permission: permission, Project: project, Err: apiErr}
+	if err == error(apiErr) {
+		return permErr
+	}
+	return &translatedError{
+		message: strings.Replace(err.Error(), apiErr.Error(), permErr.Error(), 1),
+		err:     permErr,
+	}
+}
+
+// translatedError is a wrapped error with the API error replaced in its message
+type translatedError struct {
+	message string
+	err     error
+}
+
+func (e *translatedError) Error() string {
+	return e.message
+}
+
+func (e *translatedError) Unwrap() error {
+	return e.err
+}
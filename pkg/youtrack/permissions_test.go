@@ -0,0 +1,167 @@
+package youtrack
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestRequiredPermission(t *testing.T) {
+	tests := []struct {
+		name       string
+		method     string
+		path       string
+		permission Permission
+		project    string
+		ok         bool
+	}{
+		{name: "create issue", method: "POST", path: "/api/issues", permission: PermissionCreateIssue, ok: true},
+		{name: "update issue", method: "POST", path: "/api/issues/PRJ-12", permission: PermissionUpdateIssue, project: "PRJ", ok: true},
+		{name: "read issue", method: "GET", path: "/api/issues/PRJ-12/comments", permission: PermissionReadIssue, project: "PRJ", ok: true},
+		{name: "search issues", method: "GET", path: "/api/issues", permission: PermissionReadIssue, ok: true},
+		{name: "delete issue", method: "DELETE", path: "/api/issues/PRJ-12", permission: PermissionDeleteIssue, project: "PRJ", ok: true},
+		{name: "add comment", method: "POST", path: "/api/issues/PRJ-12/comments", permission: PermissionCreateComment, project: "PRJ", ok: true},
+		{name: "update comment", method: "POST", path: "/api/issues/PRJ-12/comments/4-5", permission: PermissionUpdateComment, project: "PRJ", ok: true},
+		{name: "delete comment", method: "DELETE", path: "/api/issues/PRJ-12/comments/4-5", permission: PermissionDeleteComment, project: "PRJ", ok: true},
+		{name: "add worklog", method: "POST", path: "/api/issues/PRJ-12/timeTracking/workItems", permission: PermissionCreateWorkItem, project: "PRJ", ok: true},
+		{name: "delete worklog", method: "DELETE", path: "/api/issues/PRJ-12/timeTracking/workItems/8-1", permission: PermissionUpdateWorkItem, project: "PRJ", ok: true},
+		{name: "link issues", method: "POST", path: "/api/issues/PRJ-12/links/81-0s/issues", permission: PermissionLinkIssue, project: "PRJ", ok: true},
+		{name: "upload attachment", method: "POST", path: "/api/issues/PRJ-12/attachments", permission: PermissionUpdateIssue, project: "PRJ", ok: true},
+		{name: "internal issue ID", method: "POST", path: "/api/issues/2-15", permission: PermissionUpdateIssue, ok: true},
+		{name: "apply command", method: "POST", path: "/api/commands", permission: PermissionUpdateIssue, ok: true},
+		{name: "read project", method: "GET", path: "/api/admin/projects/PRJ/customFields", permission: PermissionReadProject, project: "PRJ", ok: true},
+		{name: "update project", method: "POST", path: "/api/admin/projects/PRJ", permission: PermissionUpdateProject, project: "PRJ", ok: true},
+		{name: "unknown request", method: "GET", path: "/api/users/me", ok: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			permission, project, ok := RequiredPermission(tt.method, tt.path)
+			if ok != tt.ok {
+				t.Fatalf("Expected ok %v, got %v", tt.ok, ok)
+			}
+			if permission != tt.permission {
+				t.Errorf("Expected %q, got %q", tt.permission.Name, permission.Name)
+			}
+			if project != tt.project {
+				t.Errorf("Expected project %q, got %q", tt.project, project)
+			}
+		})
+	}
+}
+
+func TestTranslateError(t *testing.T) {
+	forbidden := &APIError{StatusCode: 403, Message: `{"error":"Forbidden"}`, Method: "POST", Path: "/api/issues/PRJ-12"}
+
+	tests := []struct {
+		name       string
+		err        error
+		expected   string
+		permission bool
+	}{
+		{name: "forbidden", err: forbidden, expected: "your token lacks 'Update Issue' in project PRJ", permission: true},
+		{name: "wrapped", err: fmt.Errorf("failed to update ticket: %w", forbidden), expected: "failed to update ticket: your token lacks 'Update Issue' in project PRJ", permission: true},
+		{name: "not found", err: &APIError{StatusCode: 404, Message: "missing", Method: "GET", Path: "/api/issues/PRJ-12"}, expected: "YouTrack API error (status 404): missing"},
+		{name: "unknown request", err: &APIError{StatusCode: 403, Message: "no", Method: "GET", Path: "/api/users/me"}, expected: "YouTrack API error (status 403): no"},
+		{name: "other error", err: errors.New("boom"), expected: "boom"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := TranslateError(tt.err)
+			if err.Error() != tt.expected {
+				t.Errorf("Expected %q, got %q", tt.expected, err.Error())
+			}
+			var permErr *PermissionError
+			if errors.As(err, &permErr) != tt.permission {
+				t.Errorf("Expected PermissionError %v, got %v", tt.permission, !tt.permission)
+			}
+			if tt.permission && !errors.Is(err, forbidden) {
+				t.Errorf("Expected the API error to stay reachable")
+			}
+		})
+	}
+
+	if TranslateError(nil) != nil {
+		t.Errorf("Expected nil for nil")
+	}
+}
+
+func TestClient_Permissions(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/permissions/cache":
+			fmt.Fprint(w, `[
+				{"global":true,"permission":{"key":"JetBrains.YouTrack.READ_ISSUE"},"projects":[]},
+				{"global":false,"permission":{"key":"JetBrains.YouTrack.UPDATE_ISSUE"},"projects":[{"id":"0-1","shortName":"PRJ"}]},
+				{"global":false,"permission":{"key":"JetBrains.YouTrack.CREATE_COMMENT"},"projects":[{"id":"0-1","shortName":"PRJ"},{"id":"0-2","shortName":"OPS"}]}
+			]`)
+		case "/api/issues/OPS-3", "/api/issues/OPS-3/timeTracking/workItems":
+			w.WriteHeader(http.StatusForbidden)
+			fmt.Fprint(w, `{"error":"Forbidden"}`)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL)
+	ctx := NewYouTrackContext(context.Background(), "token")
+
+	perms, err := client.GetPermissions(ctx)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	tests := []struct {
+		name       string
+		permission Permission
+		project    string
+		expected   bool
+	}{
+		{name: "global", permission: PermissionReadIssue, project: "OPS", expected: true},
+		{name: "in project", permission: PermissionUpdateIssue, project: "PRJ", expected: true},
+		{name: "project ID", permission: PermissionUpdateIssue, project: "0-1", expected: true},
+		{name: "short name case", permission: PermissionUpdateIssue, project: "prj", expected: true},
+		{name: "other project", permission: PermissionUpdateIssue, project: "OPS", expected: false},
+		{name: "anywhere", permission: PermissionUpdateIssue, project: "", expected: true},
+		{name: "not granted", permission: PermissionDeleteIssue, project: "PRJ", expected: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := perms.Has(tt.permission, tt.project); got != tt.expected {
+				t.Errorf("Expected %v, got %v", tt.expected, got)
+			}
+		})
+	}
+
+	missing := perms.Missing("OPS", WorkPermissions...)
+	if names := PermissionNames(missing); names != "'Create Issue', 'Update Issue', 'Add Work Item'" {
+		t.Errorf("Unexpected missing permissions: %s", names)
+	}
+
+	if err := client.CheckPermission(ctx, PermissionUpdateIssue, "OPS"); err == nil || err.Error() != "your token lacks 'Update Issue' in project OPS" {
+		t.Errorf("Unexpected pre-flight error: %v", err)
+	}
+	if err := client.CheckPermission(ctx, PermissionUpdateIssue, "PRJ"); err != nil {
+		t.Errorf("Unexpected pre-flight error: %v", err)
+	}
+
+	_, err = client.UpdateIssue(ctx, "OPS-3", &UpdateIssueRequest{})
+	if err := TranslateError(err); err == nil || err.Error() != "your token lacks 'Update Issue' in project OPS" {
+		t.Errorf("Unexpected translated error: %v", err)
+	}
+
+	// Own work needs 'Add Work Item', work of another user 'Update Work Item'
+	_, err = client.AddIssueWorklog(ctx, "OPS-3", &CreateWorklogRequest{Duration: DurationValue{Minutes: 30}})
+	if err := TranslateError(err); err == nil || err.Error() != "your token lacks 'Add Work Item' in project OPS" {
+		t.Errorf("Unexpected worklog error: %v", err)
+	}
+	_, err = client.AddIssueWorklog(ctx, "OPS-3", &CreateWorklogRequest{Duration: DurationValue{Minutes: 30}, Author: &UserRef{ID: "1-5"}})
+	if err := TranslateError(err); err == nil || err.Error() != "your token lacks 'Update Work Item' in project OPS" {
+		t.Errorf("Unexpected worklog error on behalf of another user: %v", err)
+	}
+}
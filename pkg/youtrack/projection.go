@@ -0,0 +1,229 @@
+package youtrack
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// issueDetailFields are the fields requested for a single issue
+const issueDetailFields = issueFields + ",visibility($type,permittedGroups(id,name),permittedUsers(id,login,fullName))"
+
+// customFieldsFields are the fields requested for the custom field values of an issue
+const customFieldsFields = "customFields(name,$type,value(id,name,login,fullName),projectCustomField(field(fieldType(id))))"
+
+// projectionRESTFields maps the projection paths of issue attributes to the REST fields they need
+var projectionRESTFields = map[string]string{
+	"id":          "id,idReadable",
+	"internal_id": "id",
+	"summary":     "summary",
+	"description": "description,usesMarkdown",
+	"created":     "created",
+	"updated":     "updated",
+	"resolved":    "resolved",
+	"votes":       "votes",
+	"tags":        "tags(id,name,color)",
+	"visibility":  "visibility($type,permittedGroups(id,name),permittedUsers(id,login,fullName))",
+}
+
+// projectionUserFields maps the sub-paths of the issue users to the REST user fields
+var projectionUserFields = map[string]string{
+	"login":     "login",
+	"full_name": "fullName",
+}
+
+// IssueProjection selects the issue attributes to fetch, GraphQL style: paths such as "summary",
+// "state", "assignee.login" or "reporter.full_name". Paths that are not issue attributes name
+// custom fields, e.g. "Priority"; "custom_fields" selects all of them. The readable ID is always
+// fetched. A nil projection selects the default fields.
+type IssueProjection struct {
+	paths        []string
+	selected     map[string]bool
+	rest         []string
+	reporter     []string
+	customFields []string
+	allCustom    bool
+	state        bool
+	assignee     bool
+}
+
+// NewIssueProjection parses projection paths. Attribute names are matched case-insensitively.
+func NewIssueProjection(paths []string) (*IssueProjection, error) {
+	p := &IssueProjection{selected: make(map[string]bool)}
+	p.addREST("id,idReadable")
+
+	for _, raw := range paths {
+		path := strings.TrimSpace(raw)
+		if path == "" {
+			continue
+		}
+		name, fieldName, hasSub := strings.Cut(path, ".")
+		key, sub := strings.ToLower(name), strings.ToLower(fieldName)
+
+		switch key {
+		case "assignee", "reporter":
+			if !hasSub {
+				sub = "login"
+			}
+			if _, ok := projectionUserFields[sub]; !ok {
+				return nil, fmt.Errorf("unknown field %q: %s has login and full_name", path, key)
+			}
+			if key == "assignee" {
+				// The assignee is a custom field
+				p.assignee = true
+			} else {
+				p.reporter = appendUnique(p.reporter, projectionUserFields[sub])
+			}
+			p.selected[key] = true
+			p.selected[key+"."+sub] = true
+		case "state":
+			if hasSub {
+				return nil, fmt.Errorf("unknown field %q: state has no sub-fields", path)
+			}
+			// State fields are found by type, their name varies by project
+			p.state = true
+			p.selected[key] = true
+		case "custom_fields":
+			if hasSub {
+				p.addCustomField(fieldName)
+			} else {
+				p.allCustom = true
+			}
+			p.selected[key] = true
+		case "visible_to":
+			key = "visibility"
+			fallthrough
+		default:
+			fields, ok := projectionRESTFields[key]
+			if !ok && hasSub {
+				return nil, fmt.Errorf("unknown field %q", path)
+			}
+			if !ok {
+				// Not an issue attribute, a custom field name
+				p.addCustomField(name)
+				p.selected["custom_fields"] = true
+				break
+			}
+			if hasSub && (key != "tags" || sub != "name") {
+				return nil, fmt.Errorf("unknown field %q: %s has no sub-fields", path, key)
+			}
+			p.addREST(fields)
+			p.selected[key] = true
+		}
+		p.paths = append(p.paths, path)
+	}
+	return p, nil
+}
+
+// Paths returns the paths of the projection as given
+func (p *IssueProjection) Paths() []string {
+	if p == nil {
+		return nil
+	}
+	return p.paths
+}
+
+// Includes reports whether the projection selects an attribute, e.g. "summary", "assignee" or
+// "assignee.full_name". An attribute is selected by any of its sub-paths; the login of a user is
+// selected by the bare attribute too. A nil projection includes every attribute.
+func (p *IssueProjection) Includes(path string) bool {
+	if p == nil {
+		return true
+	}
+	path = strings.ToLower(path)
+	return path == "id" || p.selected[path]
+}
+
+// IncludesCustomField reports whether the projection selects a custom field by name. The
+// assignee and state fields are selected as attributes, not as custom fields.
+func (p *IssueProjection) IncludesCustomField(name string) bool {
+	if p == nil || p.allCustom {
+		return true
+	}
+	for _, field := range p.customFields {
+		if strings.EqualFold(field, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// Fields returns the REST fields parameter of the projection
+func (p *IssueProjection) Fields() string {
+	if p == nil {
+		return issueFields
+	}
+	fields := append([]string{}, p.rest...)
+	if len(p.reporter) > 0 {
+		fields = append(fields, "reporter(id,"+strings.Join(p.reporter, ",")+")")
+	}
+	if p.allCustom || p.state || p.assignee || len(p.customFields) > 0 {
+		fields = append(fields, customFieldsFields)
+	}
+	return strings.Join(fields, ",")
+}
+
+// apply sets the fields parameters of a request for the projection. Named custom fields are
+// filtered by the server unless all custom fields are needed.
+func (p *IssueProjection) apply(params url.Values, defaultFields string) {
+	if p == nil {
+		params.Set("fields", defaultFields)
+		return
+	}
+	params.Set("fields", p.Fields())
+	if !p.allCustom && !p.state {
+		if p.assignee {
+			params.Add("customFields", "Assignee")
+		}
+		for _, name := range p.customFields {
+			params.Add("customFields", name)
+		}
+	}
+}
+
+func (p *IssueProjection) addREST(fields string) {
+	p.rest = appendUnique(p.rest, fields)
+}
+
+func (p *IssueProjection) addCustomField(name string) {
+	for _, field := range p.customFields {
+		if strings.EqualFold(field, name) {
+			return
+		}
+	}
+	p.customFields = append(p.customFields, name)
+}
+
+// appendUnique appends value to list unless already there
+func appendUnique(list []string, value string) []string {
+	for _, v := range list {
+		if v == value {
+			return list
+		}
+	}
+	return append(list, value)
+}
+
+// SearchIssuesProjected searches issues like SearchIssuesSorted, fetching only the attributes
+// of the projection
+func (c *Client) SearchIssuesProjected(ctx *YouTrackContext, query string, skip, top int, sortBy, sortOrder string, projection *IssueProjection) ([]*Issue, error) {
+	fullQuery := query
+	if sortBy != "" {
+		fullQuery = fmt.Sprintf("%s sort by: %s %s", query, sortBy, sortOrder)
+	}
+
+	params := url.Values{}
+	params.Add("query", fullQuery)
+	params.Add("$skip", fmt.Sprintf("%d", skip))
+	params.Add("$top", fmt.Sprintf("%d", top))
+	projection.apply(params, issueFields)
+
+	return c.getIssues(ctx, params)
+}
+
+// GetIssueProjected returns an issue like GetIssue, fetching only the attributes of the projection
+func (c *Client) GetIssueProjected(ctx *YouTrackContext, issueID string, projection *IssueProjection) (*Issue, error) {
+	params := url.Values{}
+	projection.apply(params, issueDetailFields)
+	return c.getIssue(ctx, issueID, params)
+}
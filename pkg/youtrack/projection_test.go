@@ -0,0 +1,140 @@
+package youtrack
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestNewIssueProjection(t *testing.T) {
+	tests := []struct {
+		name           string
+		paths          []string
+		expectedFields string
+		includes       []string
+		excludes       []string
+		expectError    bool
+	}{
+		{
+			name:           "Plain attributes",
+			paths:          []string{"id", "summary", "Created"},
+			expectedFields: "id,idReadable,summary,created",
+			includes:       []string{"id", "summary", "created"},
+			excludes:       []string{"description", "assignee", "custom_fields"},
+		},
+		{
+			name:           "User sub-fields",
+			paths:          []string{"assignee.login", "reporter.full_name", "reporter.login"},
+			expectedFields: "id,idReadable,reporter(id,fullName,login)," + customFieldsFields,
+			includes:       []string{"assignee", "assignee.login", "reporter.full_name", "reporter.login"},
+			excludes:       []string{"assignee.full_name", "custom_fields"},
+		},
+		{
+			name:     "Bare user selects the login",
+			paths:    []string{"reporter"},
+			includes: []string{"reporter", "reporter.login"},
+			excludes: []string{"reporter.full_name"},
+		},
+		{
+			name:           "Custom field names",
+			paths:          []string{"Priority", "custom_fields.Type", "tags.name", "visible_to"},
+			expectedFields: "id,idReadable,tags(id,name,color),visibility($type,permittedGroups(id,name),permittedUsers(id,login,fullName))," + customFieldsFields,
+			includes:       []string{"custom_fields", "tags", "visibility"},
+		},
+		{
+			name:        "Unknown user sub-field",
+			paths:       []string{"assignee.password"},
+			expectError: true,
+		},
+		{
+			name:        "Sub-field of a plain attribute",
+			paths:       []string{"summary.text"},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			projection, err := NewIssueProjection(tt.paths)
+			if tt.expectError {
+				if err == nil {
+					t.Fatalf("Expected error, got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if tt.expectedFields != "" && projection.Fields() != tt.expectedFields {
+				t.Errorf("Expected fields %q, got %q", tt.expectedFields, projection.Fields())
+			}
+			for _, path := range tt.includes {
+				if !projection.Includes(path) {
+					t.Errorf("Expected %q to be included", path)
+				}
+			}
+			for _, path := range tt.excludes {
+				if projection.Includes(path) {
+					t.Errorf("Expected %q to be excluded", path)
+				}
+			}
+		})
+	}
+}
+
+func TestClient_SearchIssuesProjected(t *testing.T) {
+	var fields string
+	var customFields []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fields = r.URL.Query().Get("fields")
+		customFields = r.URL.Query()["customFields"]
+		w.Write([]byte(`[{"idReadable":"PRJ-1","summary":"One","customFields":[{"name":"Priority","$type":"SingleEnumIssueCustomField","value":{"name":"Major"}}]}]`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL)
+	ctx := NewYouTrackContext(context.Background(), "token")
+
+	tests := []struct {
+		name                 string
+		paths                []string
+		expectedCustomFields []string
+	}{
+		{name: "Named custom fields are filtered", paths: []string{"summary", "Priority"}, expectedCustomFields: []string{"Priority"}},
+		{name: "State needs all custom fields", paths: []string{"state", "Priority"}},
+		{name: "Assignee is fetched as a custom field", paths: []string{"assignee.login", "Priority"}, expectedCustomFields: []string{"Assignee", "Priority"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			projection, err := NewIssueProjection(tt.paths)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			issues, err := client.SearchIssuesProjected(ctx, "project: PRJ", 0, 10, "", "", projection)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if fields != projection.Fields() {
+				t.Errorf("Expected fields %q, got %q", projection.Fields(), fields)
+			}
+			if strings.Join(customFields, ",") != strings.Join(tt.expectedCustomFields, ",") {
+				t.Errorf("Expected customFields %v, got %v", tt.expectedCustomFields, customFields)
+			}
+			if len(issues) != 1 || len(issues[0].CustomFields) != 1 {
+				t.Fatalf("Expected one issue with its custom field, got %+v", issues)
+			}
+		})
+	}
+
+	t.Run("Nil projection fetches the default fields", func(t *testing.T) {
+		if _, err := client.SearchIssuesProjected(ctx, "project: PRJ", 0, 10, "", "", nil); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if fields != issueFields || len(customFields) != 0 {
+			t.Errorf("Expected the default fields, got %q %v", fields, customFields)
+		}
+	})
+}
@@ -84,7 +84,7 @@ func (c *Client) GetProjectIssues(ctx *YouTrackContext, projectID string, skip,
 	params.Add("query", fmt.Sprintf("project:{%s}", projectID))
 	params.Add("$skip", fmt.Sprintf("%d", skip))
 	params.Add("$top", fmt.Sprintf("%d", top))
-	params.Add("fields", "idReadable,summary,description,created,updated,resolved,reporter(id,login,fullName,email),updater(id,login,fullName,email),customFields(name,$type,value(id,name,login,fullName)),tags(id,name,color)")
+	params.Add("fields", "id,idReadable,summary,description,created,updated,resolved,votes,reporter(id,login,fullName,email),updater(id,login,fullName,email),customFields(name,$type,value(id,name,login,fullName),projectCustomField(field(fieldType(id)))),tags(id,name,color)")
 
 	resp, err := c.Get(ctx, "/api/issues", params)
 	if err != nil {
@@ -130,11 +130,12 @@ func (c *Client) GetProjectCustomFields(ctx *YouTrackContext, projectID string)
 	return fields, nil
 }
 
-func (c *Client) GetCustomFieldAllowedValues(ctx *YouTrackContext, projectID string, fieldName string) ([]AllowedValue, error) {
+// ListProjectCustomFields returns the custom fields attached to a project, with their types and bundles
+func (c *Client) ListProjectCustomFields(ctx *YouTrackContext, projectID string) ([]*ProjectCustomField, error) {
 	path := fmt.Sprintf("/api/admin/projects/%s/customFields", projectID)
 
 	query := url.Values{}
-	query.Add("fields", "field(id,name,$type),bundle(id)")
+	query.Add("fields", "id,$type,field(id,name,fieldType(id,isMultiValue)),bundle(id)")
 
 	resp, err := c.Get(ctx, path, query)
 	if err != nil {
@@ -142,113 +143,121 @@ func (c *Client) GetCustomFieldAllowedValues(ctx *YouTrackContext, projectID str
 	}
 	defer resp.Body.Close()
 
-	var rawFields []struct {
-		Field  *CustomField `json:"field"`
-		Bundle *struct {
-			ID string `json:"id"`
-		} `json:"bundle"`
-	}
-	if err := json.NewDecoder(resp.Body).Decode(&rawFields); err != nil {
+	var fields []*ProjectCustomField
+	if err := json.NewDecoder(resp.Body).Decode(&fields); err != nil {
 		return nil, fmt.Errorf("failed to decode project custom fields: %w", err)
 	}
 
-	// Find the matching field
-	for _, rf := range rawFields {
-		if rf.Field == nil || !strings.EqualFold(rf.Field.Name, fieldName) {
-			continue
-		}
+	return fields, nil
+}
 
-		if rf.Bundle == nil {
-			return nil, fmt.Errorf("field '%s' has no associated bundle", fieldName)
-		}
+// GetProjectCustomField returns a project custom field by name (case-insensitive), with its type and bundle
+func (c *Client) GetProjectCustomField(ctx *YouTrackContext, projectID string, fieldName string) (*ProjectCustomField, error) {
+	fields, err := c.ListProjectCustomFields(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
 
-		// Determine bundle type from field type
-		bundleType := "enum"
-		fieldType := rf.Field.Type
-		switch {
-		case strings.Contains(fieldType, "State"):
-			bundleType = "state"
-		case strings.Contains(fieldType, "Owned"):
-			bundleType = "ownedField"
-		case strings.Contains(fieldType, "Enum"):
-			bundleType = "enum"
-		case strings.Contains(fieldType, "Version"):
-			bundleType = "version"
-		case strings.Contains(fieldType, "Build"):
-			bundleType = "build"
+	for _, field := range fields {
+		if field.Field != nil && strings.EqualFold(field.Field.Name, fieldName) {
+			return field, nil
 		}
+	}
 
-		// GET bundle values
-		bundlePath := fmt.Sprintf("/api/admin/customFieldSettings/bundles/%s/%s/values", bundleType, rf.Bundle.ID)
+	return nil, fmt.Errorf("custom field '%s' not found in project '%s'", fieldName, projectID)
+}
 
-		bundleQuery := url.Values{}
-		bundleQuery.Add("fields", "id,name")
+func (c *Client) GetCustomFieldAllowedValues(ctx *YouTrackContext, projectID string, fieldName string) ([]AllowedValue, error) {
+	field, err := c.GetProjectCustomField(ctx, projectID, fieldName)
+	if err != nil {
+		return nil, err
+	}
 
-		bundleResp, err := c.Get(ctx, bundlePath, bundleQuery)
-		if err != nil {
-			return nil, fmt.Errorf("failed to get bundle values: %w", err)
-		}
-		defer bundleResp.Body.Close()
+	if field.Bundle == nil {
+		return nil, fmt.Errorf("field '%s' has no associated bundle", fieldName)
+	}
 
-		var values []AllowedValue
-		if err := json.NewDecoder(bundleResp.Body).Decode(&values); err != nil {
-			return nil, fmt.Errorf("failed to decode allowed values: %w", err)
-		}
+	// GET bundle values
+	bundleKind := field.BundleKind()
+	bundlePath := fmt.Sprintf("/api/admin/customFieldSettings/bundles/%s/%s/values", bundleKind, field.Bundle.ID)
 
-		return values, nil
+	bundleQuery := url.Values{}
+	if bundleKind == "version" {
+		bundleQuery.Add("fields", "id,name,archived,released,releaseDate")
+	} else {
+		bundleQuery.Add("fields", "id,name")
 	}
 
-	return nil, fmt.Errorf("custom field '%s' not found in project '%s'", fieldName, projectID)
-}
+	bundleResp, err := c.Get(ctx, bundlePath, bundleQuery)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get bundle values: %w", err)
+	}
+	defer bundleResp.Body.Close()
 
-func (c *Client) AddCustomFieldEnumValue(ctx *YouTrackContext, projectID string, fieldName string, valueName string, color string) error {
-	path := fmt.Sprintf("/api/admin/projects/%s/customFields", projectID)
+	var values []AllowedValue
+	if err := json.NewDecoder(bundleResp.Body).Decode(&values); err != nil {
+		return nil, fmt.Errorf("failed to decode allowed values: %w", err)
+	}
 
-	query := url.Values{}
-	query.Add("fields", "field(id,name,$type),bundle(id)")
+	return values, nil
+}
 
-	resp, err := c.Get(ctx, path, query)
+func (c *Client) AddCustomFieldEnumValue(ctx *YouTrackContext, projectID string, fieldName string, valueName string, color string) error {
+	field, err := c.GetProjectCustomField(ctx, projectID, fieldName)
 	if err != nil {
 		return err
 	}
-	defer resp.Body.Close()
 
-	var rawFields []struct {
-		Field  *CustomField `json:"field"`
-		Bundle *struct {
-			ID string `json:"id"`
-		} `json:"bundle"`
+	if field.Bundle == nil {
+		return fmt.Errorf("field '%s' has no associated bundle", fieldName)
 	}
-	if err := json.NewDecoder(resp.Body).Decode(&rawFields); err != nil {
-		return fmt.Errorf("failed to decode project custom fields: %w", err)
+
+	postPath := fmt.Sprintf("/api/admin/customFieldSettings/bundles/enum/%s/values", field.Bundle.ID)
+
+	body := map[string]interface{}{
+		"name": valueName,
+	}
+	if color != "" {
+		body["color"] = map[string]string{"id": color}
 	}
 
-	for _, rf := range rawFields {
-		if rf.Field == nil || !strings.EqualFold(rf.Field.Name, fieldName) {
-			continue
-		}
+	postResp, err := c.Post(ctx, postPath, body)
+	if err != nil {
+		return fmt.Errorf("failed to add enum value: %w", err)
+	}
+	defer postResp.Body.Close()
 
-		if rf.Bundle == nil {
-			return fmt.Errorf("field '%s' has no associated bundle", fieldName)
-		}
+	return nil
+}
 
-		postPath := fmt.Sprintf("/api/admin/customFieldSettings/bundles/enum/%s/values", rf.Bundle.ID)
+// TimeTrackingSettings is the time tracking configuration of a project
+type TimeTrackingSettings struct {
+	Enabled bool `json:"enabled"`
+	// Estimate and TimeSpent name the period fields bound to estimation and spent time, if any
+	Estimate  *ProjectCustomField `json:"estimate,omitempty"`
+	TimeSpent *ProjectCustomField `json:"timeSpent,omitempty"`
+	// WorkItemTypes are the work types available in the project
+	WorkItemTypes []*WorkType `json:"workItemTypes,omitempty"`
+}
 
-		body := map[string]interface{}{
-			"name": valueName,
-		}
-		if color != "" {
-			body["color"] = map[string]string{"id": color}
-		}
+// GetTimeTrackingSettings returns whether time tracking is enabled in a project, with its
+// estimation and spent time fields and the available work types
+func (c *Client) GetTimeTrackingSettings(ctx *YouTrackContext, projectID string) (*TimeTrackingSettings, error) {
+	path := fmt.Sprintf("/api/admin/projects/%s/timeTrackingSettings", projectID)
 
-		postResp, err := c.Post(ctx, postPath, body)
-		if err != nil {
-			return fmt.Errorf("failed to add enum value: %w", err)
-		}
-		defer postResp.Body.Close()
+	query := url.Values{}
+	query.Add("fields", "enabled,estimate(field(name)),timeSpent(field(name)),workItemTypes(id,name)")
+
+	resp, err := c.Get(ctx, path, query)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
 
-		return nil
+	var settings TimeTrackingSettings
+	if err := json.NewDecoder(resp.Body).Decode(&settings); err != nil {
+		return nil, fmt.Errorf("failed to decode time tracking settings: %w", err)
 	}
 
-	return fmt.Errorf("custom field '%s' not found in project '%s'", fieldName, projectID)
+	return &settings, nil
 }
@@ -0,0 +1,106 @@
+package youtrack
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestProjectCustomField_IssueFieldType(t *testing.T) {
+	tests := []struct {
+		name     string
+		field    ProjectCustomField
+		expected string
+	}{
+		{
+			name:     "Single enum",
+			field:    ProjectCustomField{Type: "EnumProjectCustomField", Field: &CustomFieldDefinition{FieldType: &FieldType{ID: "enum[1]"}}},
+			expected: "SingleEnumIssueCustomField",
+		},
+		{
+			name:     "Multi version",
+			field:    ProjectCustomField{Type: "VersionProjectCustomField", Field: &CustomFieldDefinition{FieldType: &FieldType{ID: "version[*]", IsMultiValue: true}}},
+			expected: "MultiVersionIssueCustomField",
+		},
+		{
+			name:     "State",
+			field:    ProjectCustomField{Type: "StateProjectCustomField"},
+			expected: "StateIssueCustomField",
+		},
+		{
+			name:     "Simple",
+			field:    ProjectCustomField{Type: "SimpleProjectCustomField"},
+			expected: "SimpleIssueCustomField",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := tt.field.IssueFieldType(); result != tt.expected {
+				t.Errorf("Expected %q, got %q", tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestClient_GetCustomFieldAllowedValues_Version(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/admin/projects/PRJ/customFields":
+			fmt.Fprint(w, `[
+				{"id":"1","$type":"StateProjectCustomField","field":{"id":"f1","name":"State"},"bundle":{"id":"b1"}},
+				{"id":"2","$type":"VersionProjectCustomField","field":{"id":"f2","name":"Fix versions","fieldType":{"id":"version[*]","isMultiValue":true}},"bundle":{"id":"b2"}}
+			]`)
+		case "/api/admin/customFieldSettings/bundles/version/b2/values":
+			fmt.Fprint(w, `[
+				{"id":"v1","name":"2024.1","released":true,"archived":true},
+				{"id":"v2","name":"2024.2","released":false,"archived":false}
+			]`)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL)
+	ctx := NewYouTrackContext(context.Background(), "token")
+
+	values, err := client.GetCustomFieldAllowedValues(ctx, "PRJ", "fix versions")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(values) != 2 {
+		t.Fatalf("Expected 2 values, got %d", len(values))
+	}
+	if !values[0].Released || !values[0].Archived || values[1].Released {
+		t.Errorf("Unexpected version flags: %+v", values)
+	}
+}
+
+func TestClient_GetTimeTrackingSettings(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/admin/projects/PRJ/timeTrackingSettings" {
+			http.NotFound(w, r)
+			return
+		}
+		fmt.Fprint(w, `{"enabled":true,"estimate":{"field":{"name":"Estimation"}},"timeSpent":null,
+			"workItemTypes":[{"id":"t1","name":"Development"},{"id":"t2","name":"Testing"}]}`)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL)
+	ctx := NewYouTrackContext(context.Background(), "token")
+
+	settings, err := client.GetTimeTrackingSettings(ctx, "PRJ")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !settings.Enabled || settings.Estimate == nil || settings.Estimate.Field.Name != "Estimation" || settings.TimeSpent != nil {
+		t.Errorf("Unexpected settings: %+v", settings)
+	}
+	if len(settings.WorkItemTypes) != 2 || settings.WorkItemTypes[1].Name != "Testing" {
+		t.Errorf("Unexpected work types: %+v", settings.WorkItemTypes)
+	}
+}
@@ -0,0 +1,90 @@
+package youtrack
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// PullRequest is a pull request (or merge request) referenced by URL
+type PullRequest struct {
+	URL        string `json:"url"`
+	Provider   string `json:"provider"`   // "GitHub", "GitLab" or "Bitbucket"
+	Repository string `json:"repository"` // e.g. "owner/repo" or "group/subgroup/project"
+	Number     string `json:"number"`
+}
+
+// ParsePullRequestURL parses a GitHub pull request, GitLab merge request or
+// Bitbucket pull request URL. Self-hosted instances are recognized by the path:
+//
+//	https://github.com/owner/repo/pull/12
+//	https://gitlab.example.com/group/project/-/merge_requests/34
+//	https://bitbucket.org/workspace/repo/pull-requests/56
+func ParsePullRequestURL(rawURL string) (*PullRequest, error) {
+	u, err := url.Parse(strings.TrimSpace(rawURL))
+	if err != nil || u.Host == "" || (u.Scheme != "http" && u.Scheme != "https") {
+		return nil, fmt.Errorf("invalid pull request URL '%s'", rawURL)
+	}
+
+	parts := strings.Split(strings.Trim(u.Path, "/"), "/")
+	for i := 1; i+1 < len(parts); i++ {
+		var provider string
+		repoEnd := i
+		switch parts[i] {
+		case "pull":
+			provider = "GitHub"
+		case "pull-requests":
+			provider = "Bitbucket"
+		case "merge_requests":
+			provider = "GitLab"
+			if parts[i-1] == "-" {
+				repoEnd = i - 1
+			}
+		default:
+			continue
+		}
+
+		number := parts[i+1]
+		if repoEnd < 2 || !isDigits(number) {
+			break
+		}
+		return &PullRequest{
+			URL:        u.String(),
+			Provider:   provider,
+			Repository: strings.Join(parts[:repoEnd], "/"),
+			Number:     number,
+		}, nil
+	}
+
+	return nil, fmt.Errorf("'%s' is not a GitHub, GitLab or Bitbucket pull request URL", rawURL)
+}
+
+// Reference returns the short reference, e.g. "owner/repo#12" or "group/project!34" for GitLab
+func (p *PullRequest) Reference() string {
+	if p.Provider == "GitLab" {
+		return fmt.Sprintf("%s!%s", p.Repository, p.Number)
+	}
+	return fmt.Sprintf("%s#%s", p.Repository, p.Number)
+}
+
+// Comment returns the Markdown comment text announcing the pull request on an issue
+func (p *PullRequest) Comment() string {
+	kind := "Pull request"
+	if p.Provider == "GitLab" {
+		kind = "Merge request"
+	}
+	return fmt.Sprintf("%s: [%s](%s)", kind, p.Reference(), p.URL)
+}
+
+// isDigits reports whether s is a non-empty string of ASCII digits
+func isDigits(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
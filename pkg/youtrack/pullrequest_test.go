@@ -0,0 +1,39 @@
+package youtrack
+
+import "testing"
+
+func TestParsePullRequestURL(t *testing.T) {
+	tests := []struct {
+		name      string
+		url       string
+		provider  string
+		reference string
+		wantErr   bool
+	}{
+		{name: "GitHub", url: "https://github.com/owner/repo/pull/12", provider: "GitHub", reference: "owner/repo#12"},
+		{name: "GitHub files tab", url: "https://github.com/owner/repo/pull/12/files", provider: "GitHub", reference: "owner/repo#12"},
+		{name: "GitLab subgroup", url: "https://gitlab.example.com/group/sub/project/-/merge_requests/34", provider: "GitLab", reference: "group/sub/project!34"},
+		{name: "Bitbucket", url: "https://bitbucket.org/workspace/repo/pull-requests/56", provider: "Bitbucket", reference: "workspace/repo#56"},
+		{name: "Issue URL", url: "https://github.com/owner/repo/issues/12", wantErr: true},
+		{name: "No number", url: "https://github.com/owner/repo/pull/new", wantErr: true},
+		{name: "Not a URL", url: "owner/repo#12", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pr, err := ParsePullRequestURL(tt.url)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("Expected error, got %+v", pr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if pr.Provider != tt.provider || pr.Reference() != tt.reference {
+				t.Errorf("Expected %s %s, got %s %s", tt.provider, tt.reference, pr.Provider, pr.Reference())
+			}
+		})
+	}
+}
@@ -0,0 +1,101 @@
+package youtrack
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"sort"
+)
+
+// reactionFields are the fields requested for comment reactions
+const reactionFields = "reactions(id,reaction,author(id,login,fullName))"
+
+// GetCommentReactions returns the reactions to an issue comment
+func (c *Client) GetCommentReactions(ctx *YouTrackContext, issueID, commentID string) ([]*Reaction, error) {
+	if err := c.require(ctx, FeatureReactions); err != nil {
+		return nil, err
+	}
+
+	path := fmt.Sprintf("/api/issues/%s/comments/%s/reactions", issueID, commentID)
+
+	query := url.Values{}
+	query.Add("fields", "id,reaction,author(id,login,fullName)")
+
+	resp, err := c.Get(ctx, path, query)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var reactions []*Reaction
+	if err := json.NewDecoder(resp.Body).Decode(&reactions); err != nil {
+		return nil, fmt.Errorf("failed to decode reactions: %w", err)
+	}
+
+	return reactions, nil
+}
+
+// AddCommentReaction adds a reaction of the current user to an issue comment.
+// The reaction is a YouTrack reaction name, such as "thumbs-up" or "heart".
+func (c *Client) AddCommentReaction(ctx *YouTrackContext, issueID, commentID, reaction string) (*Reaction, error) {
+	if err := c.require(ctx, FeatureReactions); err != nil {
+		return nil, err
+	}
+
+	path := fmt.Sprintf("/api/issues/%s/comments/%s/reactions", issueID, commentID)
+
+	query := url.Values{}
+	query.Add("fields", "id,reaction,author(id,login,fullName)")
+
+	resp, err := c.PostWithQuery(ctx, path, query, map[string]string{"reaction": reaction})
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var result Reaction
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("failed to decode reaction: %w", err)
+	}
+
+	return &result, nil
+}
+
+// RemoveCommentReaction removes a reaction from an issue comment
+func (c *Client) RemoveCommentReaction(ctx *YouTrackContext, issueID, commentID, reactionID string) error {
+	if err := c.require(ctx, FeatureReactions); err != nil {
+		return err
+	}
+
+	path := fmt.Sprintf("/api/issues/%s/comments/%s/reactions/%s", issueID, commentID, reactionID)
+
+	resp, err := c.Delete(ctx, path)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	return nil
+}
+
+// ReactionCount is the number of reactions of one kind to a comment
+type ReactionCount struct {
+	Reaction string `json:"reaction"`
+	Count    int    `json:"count"`
+}
+
+// CountReactions groups reactions by kind, most frequent first
+func CountReactions(reactions []*Reaction) []ReactionCount {
+	var counts []ReactionCount
+	index := make(map[string]int)
+	for _, r := range reactions {
+		if i, ok := index[r.Reaction]; ok {
+			counts[i].Count++
+			continue
+		}
+		index[r.Reaction] = len(counts)
+		counts = append(counts, ReactionCount{Reaction: r.Reaction, Count: 1})
+	}
+	sort.SliceStable(counts, func(i, j int) bool { return counts[i].Count > counts[j].Count })
+	return counts
+}
@@ -0,0 +1,131 @@
+package youtrack
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestClient_GetIssueComments_Reactions(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.Contains(r.URL.Query().Get("fields"), "reactions(") {
+			t.Errorf("Expected reactions in fields, got %s", r.URL.Query().Get("fields"))
+		}
+		fmt.Fprint(w, `[{"id":"4-1","text":"Ship it","reactions":[
+			{"id":"r-1","reaction":"thumbs-up","author":{"login":"john"}},
+			{"id":"r-2","reaction":"thumbs-up","author":{"login":"jane"}}]}]`)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL)
+	ctx := NewYouTrackContext(context.Background(), "token")
+
+	comments, err := client.GetIssueComments(ctx, "PROJ-1")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(comments) != 1 || len(comments[0].Reactions) != 2 {
+		t.Fatalf("Expected one comment with 2 reactions, got %+v", comments)
+	}
+	if r := comments[0].Reactions[1]; r.Reaction != "thumbs-up" || r.Author.Login != "jane" {
+		t.Errorf("Unexpected reaction: %+v", r)
+	}
+}
+
+func TestClient_CommentReactions(t *testing.T) {
+	const path = "/api/issues/PROJ-1/comments/4-1/reactions"
+
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		call   func(c *Client, ctx *YouTrackContext) error
+	}{
+		{
+			name:   "List",
+			method: http.MethodGet,
+			path:   path,
+			call: func(c *Client, ctx *YouTrackContext) error {
+				reactions, err := c.GetCommentReactions(ctx, "PROJ-1", "4-1")
+				if err == nil && (len(reactions) != 1 || reactions[0].Reaction != "heart") {
+					return fmt.Errorf("unexpected reactions: %+v", reactions)
+				}
+				return err
+			},
+		},
+		{
+			name:   "Add",
+			method: http.MethodPost,
+			path:   path,
+			call: func(c *Client, ctx *YouTrackContext) error {
+				reaction, err := c.AddCommentReaction(ctx, "PROJ-1", "4-1", "heart")
+				if err == nil && reaction.ID != "r-1" {
+					return fmt.Errorf("unexpected reaction: %+v", reaction)
+				}
+				return err
+			},
+		},
+		{
+			name:   "Remove",
+			method: http.MethodDelete,
+			path:   path + "/r-1",
+			call: func(c *Client, ctx *YouTrackContext) error {
+				return c.RemoveCommentReaction(ctx, "PROJ-1", "4-1", "r-1")
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Method != tt.method || r.URL.Path != tt.path {
+					t.Errorf("Unexpected request %s %s", r.Method, r.URL.Path)
+				}
+				switch r.Method {
+				case http.MethodGet:
+					fmt.Fprint(w, `[{"id":"r-1","reaction":"heart","author":{"login":"john"}}]`)
+				case http.MethodPost:
+					var body map[string]string
+					json.NewDecoder(r.Body).Decode(&body)
+					if body["reaction"] != "heart" {
+						t.Errorf("Expected reaction heart, got %v", body)
+					}
+					fmt.Fprint(w, `{"id":"r-1","reaction":"heart"}`)
+				}
+			}))
+			defer server.Close()
+
+			client := NewClient(server.URL)
+			client.SetServerVersion("2024.2")
+			ctx := NewYouTrackContext(context.Background(), "token")
+
+			if err := tt.call(client, ctx); err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func TestCountReactions(t *testing.T) {
+	reactions := []*Reaction{
+		{Reaction: "heart"},
+		{Reaction: "thumbs-up"},
+		{Reaction: "thumbs-up"},
+		{Reaction: "eyes"},
+	}
+
+	counts := CountReactions(reactions)
+	expected := []ReactionCount{{"thumbs-up", 2}, {"heart", 1}, {"eyes", 1}}
+	if len(counts) != len(expected) {
+		t.Fatalf("Expected %d counts, got %+v", len(expected), counts)
+	}
+	for i, c := range expected {
+		if counts[i] != c {
+			t.Errorf("Expected %+v at %d, got %+v", c, i, counts[i])
+		}
+	}
+}
@@ -0,0 +1,100 @@
+package youtrack
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// ReleaseNotes are issues grouped by the value of a custom field, e.g. Type
+type ReleaseNotes struct {
+	Title   string               `json:"title,omitempty"`
+	GroupBy string               `json:"groupBy"`
+	Groups  []*ReleaseNotesGroup `json:"groups"`
+}
+
+// ReleaseNotesGroup is the set of issues sharing a field value
+type ReleaseNotesGroup struct {
+	Name   string   `json:"name"`
+	Issues []*Issue `json:"issues"`
+}
+
+// BuildReleaseNotes groups issues by the value of the groupBy field (default "Type").
+// Groups listed in order come first, in that order (case-insensitive); the others
+// follow alphabetically, and issues without a value are put last under "Other".
+// Issues keep their order within a group.
+func BuildReleaseNotes(title string, issues []*Issue, groupBy string, order []string) *ReleaseNotes {
+	if groupBy == "" {
+		groupBy = "Type"
+	}
+
+	notes := &ReleaseNotes{Title: title, GroupBy: groupBy}
+	index := make(map[string]*ReleaseNotesGroup)
+	for _, issue := range issues {
+		name := issue.FieldValue(groupBy)
+		if name == "" {
+			name = "Other"
+		}
+		group, ok := index[name]
+		if !ok {
+			group = &ReleaseNotesGroup{Name: name}
+			index[name] = group
+			notes.Groups = append(notes.Groups, group)
+		}
+		group.Issues = append(group.Issues, issue)
+	}
+
+	rank := func(name string) int {
+		for i, o := range order {
+			if strings.EqualFold(o, name) {
+				return i
+			}
+		}
+		if name == "Other" {
+			return len(order) + 1
+		}
+		return len(order)
+	}
+	sort.SliceStable(notes.Groups, func(i, j int) bool {
+		ri, rj := rank(notes.Groups[i].Name), rank(notes.Groups[j].Name)
+		if ri != rj {
+			return ri < rj
+		}
+		return notes.Groups[i].Name < notes.Groups[j].Name
+	})
+
+	return notes
+}
+
+// Markdown renders the release notes as Markdown, one section per group
+func (r *ReleaseNotes) Markdown() string {
+	var sb strings.Builder
+	if r.Title != "" {
+		sb.WriteString(fmt.Sprintf("# %s\n\n", r.Title))
+	}
+
+	if len(r.Groups) == 0 {
+		sb.WriteString("No issues.\n")
+		return sb.String()
+	}
+
+	for i, group := range r.Groups {
+		if i > 0 {
+			sb.WriteString("\n")
+		}
+		sb.WriteString(fmt.Sprintf("## %s\n\n", group.Name))
+		for _, issue := range group.Issues {
+			sb.WriteString(fmt.Sprintf("- %s %s\n", issue.ID, issue.Summary))
+		}
+	}
+	return sb.String()
+}
+
+// Count returns the number of issues in the release notes
+func (r *ReleaseNotes) Count() int {
+	count := 0
+	for _, group := range r.Groups {
+		count += len(group.Issues)
+	}
+	return count
+}
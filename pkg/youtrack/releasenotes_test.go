@@ -0,0 +1,80 @@
+package youtrack
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestBuildReleaseNotes(t *testing.T) {
+	var issues []*Issue
+	if err := json.Unmarshal([]byte(`[
+		{"idReadable":"P-1","summary":"Crash on start","customFields":[{"name":"Type","value":{"name":"Bug"}}]},
+		{"idReadable":"P-2","summary":"Dark mode","customFields":[{"name":"Type","value":{"name":"Feature"}}]},
+		{"idReadable":"P-3","summary":"Cleanup","customFields":[{"name":"Type","value":null}]},
+		{"idReadable":"P-4","summary":"Wrong total","customFields":[{"name":"Type","value":{"name":"Bug"}}]},
+		{"idReadable":"P-5","summary":"Docs","customFields":[{"name":"Type","value":{"name":"Documentation"}}]}
+	]`), &issues); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	notes := BuildReleaseNotes("Release 2024.2", issues, "", []string{"feature", "Bug"})
+
+	expected := `# Release 2024.2
+
+## Feature
+
+- P-2 Dark mode
+
+## Bug
+
+- P-1 Crash on start
+- P-4 Wrong total
+
+## Documentation
+
+- P-5 Docs
+
+## Other
+
+- P-3 Cleanup
+`
+	if got := notes.Markdown(); got != expected {
+		t.Errorf("Expected:\n%s\nGot:\n%s", expected, got)
+	}
+	if notes.Count() != 5 {
+		t.Errorf("Expected 5 issues, got %d", notes.Count())
+	}
+}
+
+func TestCustomFieldValue_String(t *testing.T) {
+	tests := []struct {
+		name      string
+		fieldType string
+		valueType string
+		value     interface{}
+		expected  string
+	}{
+		{name: "Empty", value: nil, expected: ""},
+		{name: "Enum", value: map[string]interface{}{"name": "Bug"}, expected: "Bug"},
+		{name: "User", value: map[string]interface{}{"login": "john", "fullName": "John Smith"}, expected: "John Smith"},
+		{name: "Multi", value: []interface{}{map[string]interface{}{"name": "2024.1"}, map[string]interface{}{"name": "2024.2"}}, expected: "2024.1, 2024.2"},
+		{name: "Number", value: float64(5), expected: "5"},
+		{name: "Date", fieldType: "DateIssueCustomField", value: float64(1705276800000), expected: "2024-01-15"},
+		{name: "Date and time", fieldType: "SimpleIssueCustomField", valueType: "date and time", value: float64(1705314600000),
+			expected: time.UnixMilli(1705314600000).Format("2006-01-02 15:04")},
+		{name: "Integer", fieldType: "SimpleIssueCustomField", valueType: "integer", value: float64(1705314600000), expected: "1705314600000"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			field := &CustomFieldValue{Type: tt.fieldType, Value: tt.value}
+			if tt.valueType != "" {
+				field.ProjectField = &ProjectCustomField{Field: &CustomFieldDefinition{FieldType: &FieldType{ID: tt.valueType}}}
+			}
+			if got := field.String(); got != tt.expected {
+				t.Errorf("Expected %q, got %q", tt.expected, got)
+			}
+		})
+	}
+}
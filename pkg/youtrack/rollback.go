@@ -0,0 +1,120 @@
+package youtrack
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// Compensation records the applied steps of a multi-step change with the way to undo each of
+// them, so that a failure of a later step can leave YouTrack as it was before the change.
+// The zero value is ready to use and safe for concurrent use.
+type Compensation struct {
+	mu    sync.Mutex
+	steps []compensationStep
+}
+
+// compensationStep is an applied step and its undo
+type compensationStep struct {
+	name string
+	undo func(ctx *YouTrackContext) error
+}
+
+// StepError is a step that could not be undone
+type StepError struct {
+	Step string
+	Err  error
+}
+
+// RollbackError lists the steps a rollback could not undo, last applied first; what they
+// changed is left in YouTrack
+type RollbackError struct {
+	Failed []StepError
+}
+
+func (e *RollbackError) Error() string {
+	parts := make([]string, 0, len(e.Failed))
+	for _, failed := range e.Failed {
+		parts = append(parts, fmt.Sprintf("%s: %v", failed.Step, failed.Err))
+	}
+	return fmt.Sprintf("rollback incomplete, %d step(s) could not be undone: %s", len(e.Failed), strings.Join(parts, "; "))
+}
+
+// Unwrap returns the errors of the steps, so that errors.As finds an *APIError among them
+func (e *RollbackError) Unwrap() []error {
+	errs := make([]error, 0, len(e.Failed))
+	for _, failed := range e.Failed {
+		errs = append(errs, failed.Err)
+	}
+	return errs
+}
+
+// Record adds the undo of a step that was applied, e.g. deleting an issue just created
+func (c *Compensation) Record(name string, undo func(ctx *YouTrackContext) error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.steps = append(c.steps, compensationStep{name: name, undo: undo})
+}
+
+// Step applies a step and records its undo when it succeeds
+func (c *Compensation) Step(ctx *YouTrackContext, name string, apply, undo func(ctx *YouTrackContext) error) error {
+	if err := apply(ctx); err != nil {
+		return err
+	}
+	c.Record(name, undo)
+	return nil
+}
+
+// Steps returns the names of the recorded steps in the order they were applied
+func (c *Compensation) Steps() []string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	names := make([]string, 0, len(c.steps))
+	for _, step := range c.steps {
+		names = append(names, step.name)
+	}
+	return names
+}
+
+// Rollback undoes the recorded steps, last applied first, and forgets them. Every undo is
+// attempted even after one fails; the failed ones are returned as a *RollbackError. The undos
+// run even when ctx is cancelled, as a cancelled request is a common reason to roll back.
+func (c *Compensation) Rollback(ctx *YouTrackContext) error {
+	c.mu.Lock()
+	steps := c.steps
+	c.steps = nil
+	c.mu.Unlock()
+
+	undoCtx := *ctx
+	undoCtx.ctx = context.WithoutCancel(ctx.Context())
+
+	var rollbackErr *RollbackError
+	for i := len(steps) - 1; i >= 0; i-- {
+		if err := steps[i].undo(&undoCtx); err != nil {
+			if rollbackErr == nil {
+				rollbackErr = &RollbackError{}
+			}
+			rollbackErr.Failed = append(rollbackErr.Failed, StepError{Step: steps[i].name, Err: err})
+		}
+	}
+	if rollbackErr != nil {
+		return rollbackErr
+	}
+	return nil
+}
+
+// Fail rolls back after a step failed with err and returns err, noting the undone steps, or
+// joined with the *RollbackError when some could not be undone
+func (c *Compensation) Fail(ctx *YouTrackContext, err error) error {
+	undone := len(c.Steps())
+	if rollbackErr := c.Rollback(ctx); rollbackErr != nil {
+		return errors.Join(err, rollbackErr)
+	}
+	if undone == 0 {
+		return err
+	}
+	return fmt.Errorf("%w (rolled back %d step(s))", err, undone)
+}
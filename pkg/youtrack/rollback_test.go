@@ -0,0 +1,131 @@
+package youtrack
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestCompensation_Rollback(t *testing.T) {
+	var undone []string
+	undo := func(name string, err error) func(ctx *YouTrackContext) error {
+		return func(ctx *YouTrackContext) error {
+			if ctx.Context().Err() != nil {
+				t.Errorf("Expected undo of %s to run with a live context", name)
+			}
+			undone = append(undone, name)
+			return err
+		}
+	}
+
+	tests := []struct {
+		name     string
+		steps    map[string]error
+		order    []string
+		expected []string
+		failed   []string
+	}{
+		{
+			name:     "Undoes in reverse order",
+			order:    []string{"create", "link", "tag"},
+			expected: []string{"tag", "link", "create"},
+		},
+		{
+			name:     "Failed undo does not stop the others",
+			order:    []string{"create", "link", "tag"},
+			steps:    map[string]error{"link": &APIError{StatusCode: 403, Message: "forbidden"}},
+			expected: []string{"tag", "link", "create"},
+			failed:   []string{"link"},
+		},
+		{
+			name: "Nothing to undo",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			undone = nil
+			parent, cancel := context.WithCancel(context.Background())
+			cancel()
+			ctx := NewYouTrackContext(parent, "token")
+
+			var tx Compensation
+			for _, name := range tt.order {
+				err := tx.Step(ctx, name, func(ctx *YouTrackContext) error { return nil }, undo(name, tt.steps[name]))
+				if err != nil {
+					t.Fatalf("Unexpected error: %v", err)
+				}
+			}
+			if !reflect.DeepEqual(tx.Steps(), tt.order) && len(tt.order) > 0 {
+				t.Errorf("Expected steps %v, got %v", tt.order, tx.Steps())
+			}
+
+			err := tx.Rollback(ctx)
+			if !reflect.DeepEqual(undone, tt.expected) {
+				t.Errorf("Expected undo order %v, got %v", tt.expected, undone)
+			}
+			if len(tx.Steps()) != 0 {
+				t.Errorf("Expected the steps to be forgotten, got %v", tx.Steps())
+			}
+
+			var rollbackErr *RollbackError
+			if len(tt.failed) == 0 {
+				if err != nil {
+					t.Errorf("Unexpected error: %v", err)
+				}
+				return
+			}
+			if !errors.As(err, &rollbackErr) {
+				t.Fatalf("Expected a rollback error, got %v", err)
+			}
+			var names []string
+			for _, failed := range rollbackErr.Failed {
+				names = append(names, failed.Step)
+			}
+			if !reflect.DeepEqual(names, tt.failed) {
+				t.Errorf("Expected failed steps %v, got %v", tt.failed, names)
+			}
+			var apiErr *APIError
+			if !errors.As(err, &apiErr) {
+				t.Errorf("Expected the API error to be reachable, got %v", err)
+			}
+		})
+	}
+}
+
+func TestCompensation_Fail(t *testing.T) {
+	ctx := NewYouTrackContext(context.Background(), "token")
+	cause := errors.New("link failed")
+
+	t.Run("Notes the undone steps", func(t *testing.T) {
+		var tx Compensation
+		tx.Record("delete PRJ-1", func(ctx *YouTrackContext) error { return nil })
+		err := tx.Fail(ctx, cause)
+		if !errors.Is(err, cause) || !strings.Contains(err.Error(), "rolled back 1 step(s)") {
+			t.Errorf("Expected the cause with the rollback note, got %v", err)
+		}
+	})
+
+	t.Run("Step failure is not recorded", func(t *testing.T) {
+		var tx Compensation
+		err := tx.Step(ctx, "create", func(ctx *YouTrackContext) error { return cause }, func(ctx *YouTrackContext) error { return nil })
+		if !errors.Is(err, cause) || len(tx.Steps()) != 0 {
+			t.Errorf("Expected the step error and no recorded step, got %v and %v", err, tx.Steps())
+		}
+		if err := tx.Fail(ctx, cause); err != cause {
+			t.Errorf("Expected the cause unchanged, got %v", err)
+		}
+	})
+
+	t.Run("Joins the rollback error", func(t *testing.T) {
+		var tx Compensation
+		tx.Record("delete PRJ-1", func(ctx *YouTrackContext) error { return errors.New("forbidden") })
+		err := tx.Fail(ctx, cause)
+		var rollbackErr *RollbackError
+		if !errors.Is(err, cause) || !errors.As(err, &rollbackErr) {
+			t.Errorf("Expected the cause and the rollback error, got %v", err)
+		}
+	})
+}
@@ -2,22 +2,23 @@ package youtrack
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/url"
+	"unicode/utf8"
 )
 
-func (c *Client) SearchIssuesSorted(ctx *YouTrackContext, query string, skip, top int, sortBy, sortOrder string) ([]*Issue, error) {
-	fullQuery := query
-	if sortBy != "" {
-		fullQuery = fmt.Sprintf("%s sort by: %s %s", query, sortBy, sortOrder)
-	}
+// DefaultPageSize is the page size used by iterators when none is given
+const DefaultPageSize = 100
 
-	params := url.Values{}
-	params.Add("query", fullQuery)
-	params.Add("$skip", fmt.Sprintf("%d", skip))
-	params.Add("$top", fmt.Sprintf("%d", top))
-	params.Add("fields", "idReadable,summary,description,created,updated,resolved,reporter(id,login,fullName,email),updater(id,login,fullName,email),customFields(name,$type,value(id,name,login,fullName)),tags(id,name,color)")
+const issueFields = "id,idReadable,summary,description,usesMarkdown,created,updated,resolved,votes,reporter(id,login,fullName,email),updater(id,login,fullName,email),customFields(name,$type,value(id,name,login,fullName),projectCustomField(field(fieldType(id)))),tags(id,name,color)"
 
+func (c *Client) SearchIssuesSorted(ctx *YouTrackContext, query string, skip, top int, sortBy, sortOrder string) ([]*Issue, error) {
+	return c.SearchIssuesProjected(ctx, query, skip, top, sortBy, sortOrder, nil)
+}
+
+// getIssues fetches the issues matching the query parameters
+func (c *Client) getIssues(ctx *YouTrackContext, params url.Values) ([]*Issue, error) {
 	resp, err := c.Get(ctx, "/api/issues", params)
 	if err != nil {
 		return nil, err
@@ -31,3 +32,92 @@ func (c *Client) SearchIssuesSorted(ctx *YouTrackContext, query string, skip, to
 
 	return issues, nil
 }
+
+// GetSearchSuggestions returns query completion suggestions for the given query and caret
+// position, in characters
+func (c *Client) GetSearchSuggestions(ctx *YouTrackContext, query string, caret int) (*SearchAssist, error) {
+	if length := utf8.RuneCountInString(query); caret < 0 || caret > length {
+		caret = length
+	}
+
+	params := url.Values{}
+	params.Add("fields", "query,caret,suggestions(option,description,prefix,suffix,group,caret,completionStart,completionEnd)")
+
+	req := &SearchAssistRequest{
+		Query: query,
+		Caret: caret,
+	}
+
+	resp, err := c.PostWithQuery(ctx, "/api/search/assist", params, req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var assist SearchAssist
+	if err := json.NewDecoder(resp.Body).Decode(&assist); err != nil {
+		return nil, fmt.Errorf("failed to decode search suggestions: %w", err)
+	}
+
+	return &assist, nil
+}
+
+// ForEachIssue iterates over all issues matching the query page by page, calling fn for each issue.
+// Issues are decoded one at a time, so memory use stays flat regardless of the result size.
+// Returning ErrStopIteration from fn stops the iteration without an error.
+func (c *Client) ForEachIssue(ctx *YouTrackContext, query string, pageSize int, fn func(issue *Issue) error) error {
+	if pageSize <= 0 {
+		pageSize = DefaultPageSize
+	}
+
+	for skip := 0; ; skip += pageSize {
+		if err := ctx.Context().Err(); err != nil {
+			return err
+		}
+
+		count, err := c.streamIssuesPage(ctx, query, skip, pageSize, fn)
+		if errors.Is(err, ErrStopIteration) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if count < pageSize {
+			return nil
+		}
+	}
+}
+
+// streamIssuesPage fetches a single page of issues and decodes it element by element
+func (c *Client) streamIssuesPage(ctx *YouTrackContext, query string, skip, top int, fn func(issue *Issue) error) (int, error) {
+	params := url.Values{}
+	params.Add("query", query)
+	params.Add("$skip", fmt.Sprintf("%d", skip))
+	params.Add("$top", fmt.Sprintf("%d", top))
+	params.Add("fields", issueFields)
+
+	resp, err := c.Get(ctx, "/api/issues", params)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	decoder := json.NewDecoder(resp.Body)
+	if _, err := decoder.Token(); err != nil {
+		return 0, fmt.Errorf("failed to decode issues: %w", err)
+	}
+
+	count := 0
+	for decoder.More() {
+		var issue Issue
+		if err := decoder.Decode(&issue); err != nil {
+			return count, fmt.Errorf("failed to decode issue: %w", err)
+		}
+		count++
+		if err := fn(&issue); err != nil {
+			return count, err
+		}
+	}
+
+	return count, nil
+}
@@ -0,0 +1,101 @@
+package youtrack
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestSearchSuggestion_Apply(t *testing.T) {
+	tests := []struct {
+		name       string
+		query      string
+		suggestion SearchSuggestion
+		expected   string
+	}{
+		{
+			name:       "Complete field value",
+			query:      "State: In",
+			suggestion: SearchSuggestion{Option: "In Progress", Prefix: "{", Suffix: "} ", CompletionStart: 7, CompletionEnd: 9},
+			expected:   "State: {In Progress} ",
+		},
+		{
+			name:       "Empty query",
+			query:      "",
+			suggestion: SearchSuggestion{Option: "State", Suffix: ": ", CompletionStart: 0, CompletionEnd: 0},
+			expected:   "State: ",
+		},
+		{
+			name:       "Positions in characters",
+			query:      "Assignee: Jürgen Sta",
+			suggestion: SearchSuggestion{Option: "Stage", Suffix: " ", CompletionStart: 17, CompletionEnd: 20},
+			expected:   "Assignee: Jürgen Stage ",
+		},
+		{
+			name:       "Out of range positions append",
+			query:      "for: me ",
+			suggestion: SearchSuggestion{Option: "#Unresolved", CompletionStart: 100, CompletionEnd: 120},
+			expected:   "for: me #Unresolved",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := tt.suggestion.Apply(tt.query)
+			if result != tt.expected {
+				t.Errorf("Expected %q, got %q", tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestClient_ForEachIssue(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		skip, _ := strconv.Atoi(r.URL.Query().Get("$skip"))
+		top, _ := strconv.Atoi(r.URL.Query().Get("$top"))
+		var issues []string
+		for i := skip; i < skip+top && i < 5; i++ {
+			issues = append(issues, fmt.Sprintf(`{"idReadable":"PRJ-%d","created":0,"updated":0}`, i+1))
+		}
+		fmt.Fprintf(w, "[%s]", strings.Join(issues, ","))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL)
+	ctx := NewYouTrackContext(context.Background(), "token")
+
+	t.Run("Iterates all pages", func(t *testing.T) {
+		var ids []string
+		err := client.ForEachIssue(ctx, "project: PRJ", 2, func(issue *Issue) error {
+			ids = append(ids, issue.ID)
+			return nil
+		})
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if len(ids) != 5 || ids[4] != "PRJ-5" {
+			t.Errorf("Expected 5 issues ending with PRJ-5, got %v", ids)
+		}
+	})
+
+	t.Run("Stops early", func(t *testing.T) {
+		count := 0
+		err := client.ForEachIssue(ctx, "project: PRJ", 2, func(issue *Issue) error {
+			count++
+			if count == 3 {
+				return ErrStopIteration
+			}
+			return nil
+		})
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if count != 3 {
+			t.Errorf("Expected 3 issues, got %d", count)
+		}
+	})
+}
@@ -0,0 +1,179 @@
+package youtrack
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+)
+
+const (
+	// maxSimilarKeywords limits the keywords searched for, the longest summaries add little
+	maxSimilarKeywords = 8
+	// similarCandidates is the number of search results ranked locally
+	similarCandidates = 50
+	// defaultSimilarLimit is the number of candidates returned when no limit is given
+	defaultSimilarLimit = 10
+)
+
+// SimilarIssue is a possible duplicate found by FindSimilarIssues
+type SimilarIssue struct {
+	Issue *Issue `json:"issue"`
+	// Score is the keyword overlap with the searched text, from 0 to 1
+	Score float64 `json:"score"`
+	// Keywords are the searched keywords found in the issue summary
+	Keywords []string `json:"keywords"`
+}
+
+// SimilarIssuesOptions narrows down FindSimilarIssues
+type SimilarIssuesOptions struct {
+	Project        string    // project short name to search in, empty for all projects
+	ExcludeID      string    // issue to leave out, usually the one the text was taken from
+	UnresolvedOnly bool      // skip resolved issues
+	CreatedSince   time.Time // skip issues created before this day, zero for no limit
+	Limit          int       // maximum number of candidates, defaults to 10
+}
+
+// FindSimilarIssues searches for issues whose summary shares keywords with text, e.g. the
+// summary of a new or existing issue, and ranks them by keyword overlap. It finds likely
+// duplicates, not exact ones: check the candidates before linking or closing anything.
+func (c *Client) FindSimilarIssues(ctx *YouTrackContext, text string, opts SimilarIssuesOptions) ([]*SimilarIssue, error) {
+	keywords := SummaryKeywords(text)
+	if len(keywords) == 0 {
+		return nil, fmt.Errorf("no keywords to search for in '%s'", text)
+	}
+
+	issues, err := c.SearchIssues(ctx, similarIssuesQuery(keywords, opts), 0, similarCandidates)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search for similar issues: %w", err)
+	}
+
+	limit := opts.Limit
+	if limit <= 0 {
+		limit = defaultSimilarLimit
+	}
+	return rankSimilarIssues(keywords, issues, opts.ExcludeID, limit), nil
+}
+
+// similarIssuesQuery builds a query matching any of the keywords in the summary
+func similarIssuesQuery(keywords []string, opts SimilarIssuesOptions) string {
+	terms := make([]string, 0, len(keywords))
+	for _, kw := range keywords {
+		terms = append(terms, "summary: "+kw)
+	}
+
+	var parts []string
+	if opts.Project != "" {
+		parts = append(parts, "project: "+opts.Project)
+	}
+	if opts.UnresolvedOnly {
+		parts = append(parts, "#Unresolved")
+	}
+	if !opts.CreatedSince.IsZero() {
+		parts = append(parts, "created: "+opts.CreatedSince.Format("2006-01-02")+" .. Today")
+	}
+	parts = append(parts, "("+strings.Join(terms, " or ")+")")
+	return strings.Join(parts, " ")
+}
+
+// rankSimilarIssues scores issues by the Jaccard similarity of their summary keywords
+// to the searched keywords, best first; ties go to the most recently updated issue
+func rankSimilarIssues(keywords []string, issues []*Issue, excludeID string, limit int) []*SimilarIssue {
+	source := make(map[string]bool, len(keywords))
+	for _, kw := range keywords {
+		source[keywordStem(kw)] = true
+	}
+
+	var result []*SimilarIssue
+	for _, issue := range issues {
+		if excludeID != "" && strings.EqualFold(issue.ID, excludeID) {
+			continue
+		}
+
+		candidate := make(map[string]bool)
+		var matched []string
+		for _, kw := range SummaryKeywords(issue.Summary) {
+			stem := keywordStem(kw)
+			if candidate[stem] {
+				continue
+			}
+			candidate[stem] = true
+			if source[stem] {
+				matched = append(matched, kw)
+			}
+		}
+		if len(matched) == 0 {
+			continue
+		}
+
+		union := len(source) + len(candidate) - len(matched)
+		result = append(result, &SimilarIssue{
+			Issue:    issue,
+			Score:    float64(len(matched)) / float64(union),
+			Keywords: matched,
+		})
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		if result[i].Score != result[j].Score {
+			return result[i].Score > result[j].Score
+		}
+		return result[i].Issue.Updated.After(result[j].Issue.Updated.Time)
+	})
+	if len(result) > limit {
+		result = result[:limit]
+	}
+	return result
+}
+
+// SummaryKeywords extracts the distinct lower-case words of a summary that are worth
+// searching for: at least three characters long and not a common English stop word
+func SummaryKeywords(text string) []string {
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	seen := make(map[string]bool)
+	var keywords []string
+	for _, word := range words {
+		if len([]rune(word)) < 3 || stopWords[word] || seen[word] {
+			continue
+		}
+		seen[word] = true
+		keywords = append(keywords, word)
+		if len(keywords) == maxSimilarKeywords {
+			break
+		}
+	}
+	return keywords
+}
+
+// keywordStem folds simple plural forms, so "crashes" matches "crash" and "releases" "release"
+func keywordStem(word string) string {
+	if base, ok := strings.CutSuffix(word, "es"); ok {
+		for _, suffix := range []string{"s", "x", "z", "ch", "sh"} {
+			if strings.HasSuffix(base, suffix) {
+				return base
+			}
+		}
+	}
+	if len(word) > 3 && strings.HasSuffix(word, "s") && !strings.HasSuffix(word, "ss") {
+		return strings.TrimSuffix(word, "s")
+	}
+	return word
+}
+
+var stopWords = map[string]bool{
+	"the": true, "and": true, "for": true, "with": true, "not": true, "but": true,
+	"are": true, "was": true, "were": true, "has": true, "have": true, "had": true,
+	"this": true, "that": true, "these": true, "those": true, "from": true, "into": true,
+	"when": true, "then": true, "than": true, "after": true, "before": true, "while": true,
+	"can": true, "cannot": true, "does": true, "doesn": true, "don": true, "should": true,
+	"would": true, "could": true, "will": true, "all": true, "any": true, "some": true,
+	"its": true, "our": true, "your": true, "their": true, "there": true, "here": true,
+	"via": true, "about": true, "over": true, "under": true, "out": true, "off": true,
+	"only": true, "also": true, "more": true, "most": true, "very": true, "too": true,
+	"how": true, "what": true, "why": true, "which": true, "who": true, "where": true,
+	"add": true, "fix": true, "issue": true, "bug": true,
+}
@@ -0,0 +1,109 @@
+package youtrack
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestSummaryKeywords(t *testing.T) {
+	tests := []struct {
+		text     string
+		expected []string
+	}{
+		{text: "Fix the login crash on Android", expected: []string{"login", "crash", "android"}},
+		{text: "Export to CSV: export fails for large reports", expected: []string{"export", "csv", "fails", "large", "reports"}},
+		{text: "UI is ok", expected: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.text, func(t *testing.T) {
+			keywords := SummaryKeywords(tt.text)
+			if !reflect.DeepEqual(keywords, tt.expected) {
+				t.Errorf("Expected %v, got %v", tt.expected, keywords)
+			}
+		})
+	}
+}
+
+func TestClient_FindSimilarIssues(t *testing.T) {
+	var query string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query = r.URL.Query().Get("query")
+		fmt.Fprint(w, `[
+			{"idReadable":"MOB-1","summary":"Login crash on Android","updated":1700000000000},
+			{"idReadable":"MOB-7","summary":"App crashes on start","updated":1700000000000},
+			{"idReadable":"WEB-3","summary":"Login crashes on Android tablets","updated":1710000000000},
+			{"idReadable":"WEB-4","summary":"Unrelated result","updated":1710000000000}
+		]`)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL)
+	ctx := NewYouTrackContext(context.Background(), "token")
+
+	similar, err := client.FindSimilarIssues(ctx, "Login crash on Android", SimilarIssuesOptions{
+		Project:        "MOB",
+		ExcludeID:      "MOB-1",
+		UnresolvedOnly: true,
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	expectedQuery := "project: MOB #Unresolved (summary: login or summary: crash or summary: android)"
+	if query != expectedQuery {
+		t.Errorf("Expected query %q, got %q", expectedQuery, query)
+	}
+
+	var ids []string
+	for _, s := range similar {
+		ids = append(ids, s.Issue.ID)
+	}
+	if !reflect.DeepEqual(ids, []string{"WEB-3", "MOB-7"}) {
+		t.Fatalf("Expected WEB-3, MOB-7, got %v", ids)
+	}
+	if similar[0].Score != 0.75 {
+		t.Errorf("Expected score 0.75 for WEB-3, got %v", similar[0].Score)
+	}
+	if !reflect.DeepEqual(similar[0].Keywords, []string{"login", "crashes", "android"}) {
+		t.Errorf("Unexpected matched keywords: %v", similar[0].Keywords)
+	}
+}
+
+func TestClient_FindSimilarIssues_NoKeywords(t *testing.T) {
+	client := NewClient("http://localhost")
+	ctx := NewYouTrackContext(context.Background(), "token")
+
+	if _, err := client.FindSimilarIssues(ctx, "it is on", SimilarIssuesOptions{}); err == nil {
+		t.Fatal("Expected error for text without keywords")
+	}
+}
+
+func TestSimilarIssuesQuery(t *testing.T) {
+	keywords := []string{"login", "crash"}
+	tests := []struct {
+		name     string
+		opts     SimilarIssuesOptions
+		expected string
+	}{
+		{name: "No options", expected: "(summary: login or summary: crash)"},
+		{
+			name:     "Created since",
+			opts:     SimilarIssuesOptions{Project: "MOB", UnresolvedOnly: true, CreatedSince: time.Date(2025, 3, 9, 15, 0, 0, 0, time.UTC)},
+			expected: "project: MOB #Unresolved created: 2025-03-09 .. Today (summary: login or summary: crash)",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if query := similarIssuesQuery(keywords, tt.opts); query != tt.expected {
+				t.Errorf("Expected %q, got %q", tt.expected, query)
+			}
+		})
+	}
+}
@@ -0,0 +1,159 @@
+package youtrack
+
+import (
+	"strings"
+	"time"
+)
+
+// SLATarget is the time allowed to respond to and to resolve an issue; zero means no target
+type SLATarget struct {
+	FirstResponse time.Duration
+	Resolution    time.Duration
+}
+
+// SLAPolicy holds the SLA targets of issues per priority
+type SLAPolicy struct {
+	// PriorityField is the custom field holding the priority, defaults to "Priority"
+	PriorityField string
+	// Default applies to priorities not listed in Priorities and to issues without one
+	Default SLATarget
+	// Priorities are the targets by priority name, matched case-insensitively
+	Priorities map[string]SLATarget
+}
+
+// IsEmpty reports whether the policy has no target at all
+func (p SLAPolicy) IsEmpty() bool {
+	if p.Default != (SLATarget{}) {
+		return false
+	}
+	for _, target := range p.Priorities {
+		if target != (SLATarget{}) {
+			return false
+		}
+	}
+	return true
+}
+
+// Field returns the custom field holding the priority
+func (p SLAPolicy) Field() string {
+	if p.PriorityField == "" {
+		return "Priority"
+	}
+	return p.PriorityField
+}
+
+// TargetFor returns the targets of a priority, or the default ones when it is not listed
+func (p SLAPolicy) TargetFor(priority string) SLATarget {
+	for name, target := range p.Priorities {
+		if strings.EqualFold(name, priority) {
+			return target
+		}
+	}
+	return p.Default
+}
+
+// SLAStatus is the outcome of an SLA check
+type SLAStatus string
+
+const (
+	// SLAMet means the step was done within the target
+	SLAMet SLAStatus = "met"
+	// SLABreached means the step was done late, or is not done and the target passed
+	SLABreached SLAStatus = "breached"
+	// SLAPending means the step is not done yet and the target has not passed
+	SLAPending SLAStatus = "pending"
+	// SLANoTarget means the policy has no target for the step
+	SLANoTarget SLAStatus = "none"
+)
+
+// SLACheck is the outcome of one SLA target of an issue
+type SLACheck struct {
+	Status        SLAStatus `json:"status"`
+	TargetMinutes int       `json:"targetMinutes,omitempty"`
+	// ElapsedMinutes is the time from the issue creation to the step, or to now when not done
+	ElapsedMinutes int `json:"elapsedMinutes"`
+	// At is when the step was done, nil when it is not done yet
+	At *time.Time `json:"at,omitempty"`
+}
+
+// SLAResult is the SLA evaluation of an issue
+type SLAResult struct {
+	IssueID       string    `json:"issueId"`
+	Summary       string    `json:"summary"`
+	Priority      string    `json:"priority,omitempty"`
+	Created       time.Time `json:"created"`
+	FirstResponse SLACheck  `json:"firstResponse"`
+	Resolution    SLACheck  `json:"resolution"`
+}
+
+// Breached reports whether any target of the issue is breached
+func (r *SLAResult) Breached() bool {
+	return r.FirstResponse.Status == SLABreached || r.Resolution.Status == SLABreached
+}
+
+// FirstResponseTime returns when someone other than the reporter first commented on the issue,
+// or nil when nobody did
+func FirstResponseTime(issue *Issue, comments []*IssueComment) *time.Time {
+	var first *time.Time
+	for _, comment := range comments {
+		if comment.Author != nil && issue.Reporter != nil &&
+			(comment.Author.ID != "" && comment.Author.ID == issue.Reporter.ID ||
+				comment.Author.Login != "" && comment.Author.Login == issue.Reporter.Login) {
+			continue
+		}
+		if first == nil || comment.Created.Before(*first) {
+			created := comment.Created.Time
+			first = &created
+		}
+	}
+	return first
+}
+
+// EvaluateSLA checks an issue against the policy at now: the first response is the first
+// comment by someone other than the reporter, the resolution is the resolved date.
+// Times are calendar time from the issue creation, working hours are not taken into account.
+func EvaluateSLA(issue *Issue, comments []*IssueComment, policy SLAPolicy, now time.Time) *SLAResult {
+	priority := issue.FieldValue(policy.Field())
+	target := policy.TargetFor(priority)
+
+	var resolved *time.Time
+	if issue.Resolved != nil && !issue.Resolved.IsZero() {
+		t := issue.Resolved.Time
+		resolved = &t
+	}
+
+	return &SLAResult{
+		IssueID:       issue.ID,
+		Summary:       issue.Summary,
+		Priority:      priority,
+		Created:       issue.Created.Time,
+		FirstResponse: checkSLA(issue.Created.Time, FirstResponseTime(issue, comments), target.FirstResponse, now),
+		Resolution:    checkSLA(issue.Created.Time, resolved, target.Resolution, now),
+	}
+}
+
+// checkSLA compares the time from created to done, or to now when not done, with the target
+func checkSLA(created time.Time, done *time.Time, target time.Duration, now time.Time) SLACheck {
+	end := now
+	if done != nil {
+		end = *done
+	}
+	elapsed := max(end.Sub(created), 0)
+
+	check := SLACheck{
+		TargetMinutes:  int(target / time.Minute),
+		ElapsedMinutes: int(elapsed / time.Minute),
+		At:             done,
+	}
+	switch {
+	case target <= 0:
+		check.Status = SLANoTarget
+	case elapsed > target:
+		check.Status = SLABreached
+	case done != nil:
+		check.Status = SLAMet
+	default:
+		check.Status = SLAPending
+	}
+	return check
+}
@@ -0,0 +1,88 @@
+package youtrack
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestEvaluateSLA(t *testing.T) {
+	var issues []*Issue
+	if err := json.Unmarshal([]byte(`[
+		{"idReadable":"P-1","created":1704067200000,"reporter":{"id":"1-1","login":"alice"},
+		 "customFields":[{"name":"Priority","value":{"name":"Critical"}}]},
+		{"idReadable":"P-2","created":1704067200000,"resolved":1704088800000,"reporter":{"id":"1-1","login":"alice"},
+		 "customFields":[{"name":"Priority","value":{"name":"Normal"}}]},
+		{"idReadable":"P-3","created":1704067200000,"reporter":{"id":"1-1","login":"alice"},
+		 "customFields":[{"name":"Priority","value":null}]}
+	]`), &issues); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	created := time.UnixMilli(1704067200000)
+	comment := func(login string, after time.Duration) *IssueComment {
+		return &IssueComment{Author: &User{Login: login}, Created: YouTrackTime{Time: created.Add(after)}}
+	}
+
+	policy := SLAPolicy{
+		Default: SLATarget{FirstResponse: 24 * time.Hour},
+		Priorities: map[string]SLATarget{
+			"critical": {FirstResponse: time.Hour, Resolution: 8 * time.Hour},
+			"Normal":   {FirstResponse: 4 * time.Hour, Resolution: 48 * time.Hour},
+		},
+	}
+	now := created.Add(10 * time.Hour)
+
+	tests := []struct {
+		name           string
+		issue          *Issue
+		comments       []*IssueComment
+		firstResponse  SLAStatus
+		resolution     SLAStatus
+		responseMinute int
+		breached       bool
+	}{
+		{
+			name:           "reporter comments do not count as a response",
+			issue:          issues[0],
+			comments:       []*IssueComment{comment("alice", 10*time.Minute), comment("bob", 2*time.Hour)},
+			firstResponse:  SLABreached,
+			resolution:     SLABreached,
+			responseMinute: 120,
+			breached:       true,
+		},
+		{
+			name:           "resolved within target",
+			issue:          issues[1],
+			comments:       []*IssueComment{comment("bob", 3*time.Hour), comment("carol", 30*time.Minute)},
+			firstResponse:  SLAMet,
+			resolution:     SLAMet,
+			responseMinute: 30,
+		},
+		{
+			name:           "default target and no response yet",
+			issue:          issues[2],
+			firstResponse:  SLAPending,
+			resolution:     SLANoTarget,
+			responseMinute: 600,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := EvaluateSLA(tt.issue, tt.comments, policy, now)
+			if result.FirstResponse.Status != tt.firstResponse {
+				t.Errorf("Expected first response %s, got %s", tt.firstResponse, result.FirstResponse.Status)
+			}
+			if result.Resolution.Status != tt.resolution {
+				t.Errorf("Expected resolution %s, got %s", tt.resolution, result.Resolution.Status)
+			}
+			if result.FirstResponse.ElapsedMinutes != tt.responseMinute {
+				t.Errorf("Expected first response after %d minutes, got %d", tt.responseMinute, result.FirstResponse.ElapsedMinutes)
+			}
+			if result.Breached() != tt.breached {
+				t.Errorf("Expected breached %v, got %v", tt.breached, result.Breached())
+			}
+		})
+	}
+}
@@ -0,0 +1,419 @@
+package youtrack
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// countRetries is how many times a count still being computed is asked again
+	countRetries = 10
+	// statsConcurrency limits the count queries of GetProjectStats in flight
+	statsConcurrency = 8
+	// defaultStatsDays is the period of the created/resolved counts when none is given
+	defaultStatsDays = 30
+	// defaultTopAssignees is the number of assignees listed when none is given
+	defaultTopAssignees = 5
+)
+
+// countRetryDelay is the wait before asking again for a count still being computed
+var countRetryDelay = 250 * time.Millisecond
+
+// issueCount is the response of the issue count endpoint
+type issueCount struct {
+	Count int `json:"count"`
+}
+
+// CountIssues returns the number of issues matching the query without fetching them.
+// YouTrack answers -1 while it is still counting, the request is then repeated a few times.
+func (c *Client) CountIssues(ctx *YouTrackContext, query string) (int, error) {
+	params := url.Values{}
+	params.Add("fields", "count")
+
+	for attempt := 0; ; attempt++ {
+		resp, err := c.PostWithQuery(ctx, "/api/issuesGetter/count", params, map[string]string{"query": query})
+		if err != nil {
+			return 0, err
+		}
+
+		var result issueCount
+		err = json.NewDecoder(resp.Body).Decode(&result)
+		resp.Body.Close()
+		if err != nil {
+			return 0, fmt.Errorf("failed to decode issue count: %w", err)
+		}
+		if result.Count >= 0 {
+			return result.Count, nil
+		}
+		if attempt == countRetries {
+			return 0, fmt.Errorf("issue count for '%s' is not ready, try again later", query)
+		}
+
+		select {
+		case <-ctx.Context().Done():
+			return 0, ctx.Context().Err()
+		case <-time.After(countRetryDelay):
+		}
+	}
+}
+
+// ProjectStatsOptions configures GetProjectStats
+type ProjectStatsOptions struct {
+	Days         int // period of the created/resolved counts, defaults to 30
+	TopAssignees int // number of assignees listed, defaults to 5
+}
+
+// ValueCount is the number of issues with a field value
+type ValueCount struct {
+	Value string `json:"value"`
+	Count int    `json:"count"`
+}
+
+// ProjectStats summarizes the issues of a project
+type ProjectStats struct {
+	Project  string `json:"project"`
+	Open     int    `json:"open"`
+	Resolved int    `json:"resolved"`
+	// ByState counts all issues per state, ByPriority the open ones per priority
+	ByState    []ValueCount `json:"byState,omitempty"`
+	ByPriority []ValueCount `json:"byPriority,omitempty"`
+	// Created and ResolvedRecently count the issues created and resolved in the last Days days
+	Days             int `json:"days"`
+	Created          int `json:"created"`
+	ResolvedRecently int `json:"resolvedRecently"`
+	// TopAssignees are the users with the most open issues, "Unassigned" included
+	TopAssignees []ValueCount `json:"topAssignees,omitempty"`
+	// Missing lists the parts that could not be computed, e.g. a project without a Priority field,
+	// by their JSON names: open, resolved, created, resolvedRecently, states, priorities, assignees
+	Missing []string `json:"missing,omitempty"`
+}
+
+// GetProjectStats computes issue statistics of a project with count-only queries, run
+// concurrently: open and resolved issues, issues per state and open issues per priority,
+// issues created and resolved recently, and the assignees with the most open issues
+// (among the first 100 members of the project team).
+// Parts that fail, e.g. a field the project doesn't have or a count that times out, are listed
+// in Missing; it fails only when no count succeeds.
+func (c *Client) GetProjectStats(ctx *YouTrackContext, projectID string, opts ProjectStatsOptions) (*ProjectStats, error) {
+	if opts.Days <= 0 {
+		opts.Days = defaultStatsDays
+	}
+	if opts.TopAssignees <= 0 {
+		opts.TopAssignees = defaultTopAssignees
+	}
+
+	stats := &ProjectStats{Project: projectID, Days: opts.Days}
+	project := fmt.Sprintf("project: {%s}", projectID)
+	since := time.Now().AddDate(0, 0, -opts.Days).Format("2006-01-02")
+
+	counter := newStatsCounter(c, ctx)
+	counter.countPart("open", project+" #Unresolved", &stats.Open)
+	counter.countPart("resolved", project+" #Resolved", &stats.Resolved)
+	counter.countPart("created", fmt.Sprintf("%s created: %s .. Today", project, since), &stats.Created)
+	counter.countPart("resolvedRecently", fmt.Sprintf("%s resolved date: %s .. Today", project, since), &stats.ResolvedRecently)
+
+	states, stateErr := c.GetCustomFieldAllowedValues(ctx, projectID, "State")
+	stateCounts := make([]int, len(states))
+	for i, state := range states {
+		counter.countPart("states", fmt.Sprintf("%s State: {%s}", project, state.Name), &stateCounts[i])
+	}
+
+	priorities, priorityErr := c.GetCustomFieldAllowedValues(ctx, projectID, "Priority")
+	priorityCounts := make([]int, len(priorities))
+	for i, priority := range priorities {
+		counter.countPart("priorities", fmt.Sprintf("%s #Unresolved Priority: {%s}", project, priority.Name), &priorityCounts[i])
+	}
+
+	users, userErr := c.GetProjectUsers(ctx, projectID, 0, DefaultPageSize)
+	assigneeNames := []string{"Unassigned"}
+	assigneeQueries := []string{"Unassigned"}
+	for _, user := range users {
+		assigneeNames = append(assigneeNames, user.Login)
+		assigneeQueries = append(assigneeQueries, "{"+user.Login+"}")
+	}
+	assigneeCounts := make([]int, len(assigneeNames))
+	if userErr == nil {
+		for i, query := range assigneeQueries {
+			counter.countPart("assignees", fmt.Sprintf("%s #Unresolved Assignee: %s", project, query), &assigneeCounts[i])
+		}
+	}
+
+	if err := counter.wait(); err != nil {
+		return nil, fmt.Errorf("failed to count issues: %w", err)
+	}
+
+	for _, part := range []string{"open", "resolved", "created", "resolvedRecently"} {
+		if counter.failed(part) {
+			stats.Missing = append(stats.Missing, part)
+		}
+	}
+	if stateErr != nil || counter.failed("states") {
+		stats.Missing = append(stats.Missing, "states")
+	} else {
+		stats.ByState = valueCounts(states, stateCounts)
+	}
+	if priorityErr != nil || counter.failed("priorities") {
+		stats.Missing = append(stats.Missing, "priorities")
+	} else {
+		stats.ByPriority = valueCounts(priorities, priorityCounts)
+	}
+	if userErr != nil || counter.failed("assignees") {
+		stats.Missing = append(stats.Missing, "assignees")
+	} else {
+		for i, name := range assigneeNames {
+			if assigneeCounts[i] > 0 {
+				stats.TopAssignees = append(stats.TopAssignees, ValueCount{Value: name, Count: assigneeCounts[i]})
+			}
+		}
+		sort.SliceStable(stats.TopAssignees, func(i, j int) bool {
+			return stats.TopAssignees[i].Count > stats.TopAssignees[j].Count
+		})
+		if len(stats.TopAssignees) > opts.TopAssignees {
+			stats.TopAssignees = stats.TopAssignees[:opts.TopAssignees]
+		}
+	}
+
+	return stats, nil
+}
+
+// FieldDistribution is the number of issues per value of a custom field
+type FieldDistribution struct {
+	Field string `json:"field"`
+	// Values are in bundle order, followed by an entry with an empty Value for issues without a value
+	Values []ValueCount `json:"values"`
+	Total  int          `json:"total"`
+}
+
+// GetFieldDistribution counts the project issues matching query (e.g. "#Unresolved") per value
+// of a bundle-backed custom field (state, enum, version, ...), with count-only queries.
+// Issues of multi-value fields are counted once per value, so Total can exceed the issue count.
+func (c *Client) GetFieldDistribution(ctx *YouTrackContext, projectID, fieldName, query string) (*FieldDistribution, error) {
+	field, err := c.GetProjectCustomField(ctx, projectID, fieldName)
+	if err != nil {
+		return nil, err
+	}
+	values, err := c.GetCustomFieldAllowedValues(ctx, projectID, field.Field.Name)
+	if err != nil {
+		return nil, fmt.Errorf("field '%s' has no values to count: %w", field.Field.Name, err)
+	}
+
+	base := strings.TrimSpace(fmt.Sprintf("project: {%s} %s", projectID, query))
+	counts := make([]int, len(values)+1)
+
+	counter := newStatsCounter(c, ctx)
+	for i, v := range values {
+		counter.count(fmt.Sprintf("%s {%s}: {%s}", base, field.Field.Name, v.Name), &counts[i])
+	}
+	counter.count(fmt.Sprintf("%s has: -{%s}", base, field.Field.Name), &counts[len(values)])
+	if err := counter.wait(); err != nil {
+		return nil, fmt.Errorf("failed to count issues: %w", err)
+	}
+
+	dist := &FieldDistribution{
+		Field:  field.Field.Name,
+		Values: append(valueCounts(values, counts), ValueCount{Count: counts[len(values)]}),
+	}
+	for _, n := range counts {
+		dist.Total += n
+	}
+	return dist, nil
+}
+
+// AssigneeLoadOptions configures GetAssigneeLoad
+type AssigneeLoadOptions struct {
+	// IssueType also counts the open issues of this type per member, e.g. "Bug"
+	IssueType string
+	// TypeField is the custom field holding the issue type, defaults to "Type"
+	TypeField string
+	// WorklogDays also sums the time each member logged in the project in the last WorklogDays days
+	WorklogDays int
+}
+
+// AssigneeLoad is the current workload of a project member
+type AssigneeLoad struct {
+	User       *User `json:"user"`
+	OpenIssues int   `json:"openIssues"`
+	// OpenOfType counts the open issues of AssigneeLoadOptions.IssueType
+	OpenOfType int `json:"openOfType,omitempty"`
+	// LoggedMinutes is the time logged in the last AssigneeLoadOptions.WorklogDays days
+	LoggedMinutes int `json:"loggedMinutes,omitempty"`
+}
+
+// GetAssigneeLoad returns the members of the project team (the first 100) with their open
+// issue counts, least loaded first, to spread new issues evenly. Members are ordered by open
+// issues of the given type, then all open issues, then recently logged time.
+func (c *Client) GetAssigneeLoad(ctx *YouTrackContext, projectID string, opts AssigneeLoadOptions) ([]*AssigneeLoad, error) {
+	if opts.TypeField == "" {
+		opts.TypeField = "Type"
+	}
+
+	users, err := c.GetProjectUsers(ctx, projectID, 0, DefaultPageSize)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get project users: %w", err)
+	}
+	// Banned users cannot take new issues
+	users = ActiveUsers(users)
+
+	project := fmt.Sprintf("project: {%s} #Unresolved", projectID)
+	since := time.Now().AddDate(0, 0, -opts.WorklogDays).Format("2006-01-02")
+	until := time.Now().Format("2006-01-02")
+
+	loads := make([]*AssigneeLoad, len(users))
+	counter := newStatsCounter(c, ctx)
+	for i, user := range users {
+		load := &AssigneeLoad{User: user}
+		loads[i] = load
+
+		counter.count(fmt.Sprintf("%s Assignee: {%s}", project, user.Login), &load.OpenIssues)
+		if opts.IssueType != "" {
+			counter.count(fmt.Sprintf("%s Assignee: {%s} {%s}: {%s}", project, user.Login, opts.TypeField, opts.IssueType), &load.OpenOfType)
+		}
+		if opts.WorklogDays > 0 {
+			counter.run(func() error {
+				for skip := 0; ; skip += DefaultPageSize {
+					items, err := c.GetUserWorklogs(ctx, user.ID, projectID, since, until, skip, DefaultPageSize)
+					if err != nil {
+						return fmt.Errorf("failed to get worklogs of %s: %w", user.Login, err)
+					}
+					for _, item := range items {
+						load.LoggedMinutes += item.Duration.Minutes
+					}
+					if len(items) < DefaultPageSize {
+						return nil
+					}
+				}
+			})
+		}
+	}
+	if err := counter.wait(); err != nil {
+		return nil, fmt.Errorf("failed to compute assignee load: %w", err)
+	}
+
+	sort.SliceStable(loads, func(i, j int) bool {
+		a, b := loads[i], loads[j]
+		if a.OpenOfType != b.OpenOfType {
+			return a.OpenOfType < b.OpenOfType
+		}
+		if a.OpenIssues != b.OpenIssues {
+			return a.OpenIssues < b.OpenIssues
+		}
+		if a.LoggedMinutes != b.LoggedMinutes {
+			return a.LoggedMinutes < b.LoggedMinutes
+		}
+		return a.User.Login < b.User.Login
+	})
+	return loads, nil
+}
+
+// valueCounts pairs bundle values with their counts, in bundle order
+func valueCounts(values []AllowedValue, counts []int) []ValueCount {
+	result := make([]ValueCount, len(values))
+	for i, v := range values {
+		result[i] = ValueCount{Value: v.Name, Count: counts[i]}
+	}
+	return result
+}
+
+// statsCounter runs count queries concurrently, keeping the first error. The queries of a
+// part of the result that can be missing record their failure in the part instead.
+type statsCounter struct {
+	client *Client
+	ctx    *YouTrackContext
+	sem    chan struct{}
+	wg     sync.WaitGroup
+	mu     sync.Mutex
+	err    error
+
+	// parts maps the parts to the first error of their queries, nil while none failed
+	parts map[string]error
+}
+
+func newStatsCounter(client *Client, ctx *YouTrackContext) *statsCounter {
+	return &statsCounter{
+		client: client,
+		ctx:    ctx,
+		sem:    make(chan struct{}, statsConcurrency),
+		parts:  make(map[string]error),
+	}
+}
+
+// count stores the number of issues matching the query in target
+func (s *statsCounter) count(query string, target *int) {
+	s.countPart("", query, target)
+}
+
+// countPart is count for a query of a part of the result, see failed
+func (s *statsCounter) countPart(part, query string, target *int) {
+	s.runPart(part, func() error {
+		n, err := s.client.CountIssues(s.ctx, query)
+		if err != nil {
+			return err
+		}
+		*target = n
+		return nil
+	})
+}
+
+// run runs fn along the count queries, within the same concurrency limit
+func (s *statsCounter) run(fn func() error) {
+	s.runPart("", fn)
+}
+
+// runPart is run for a part of the result, all parts but "" record their failures apart
+func (s *statsCounter) runPart(part string, fn func() error) {
+	if part != "" {
+		s.mu.Lock()
+		if _, ok := s.parts[part]; !ok {
+			s.parts[part] = nil
+		}
+		s.mu.Unlock()
+	}
+
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		s.sem <- struct{}{}
+		defer func() { <-s.sem }()
+
+		if err := fn(); err != nil {
+			s.mu.Lock()
+			switch {
+			case part != "" && s.parts[part] == nil:
+				s.parts[part] = err
+			case part == "" && s.err == nil:
+				s.err = err
+			}
+			s.mu.Unlock()
+		}
+	}()
+}
+
+// wait waits for all queries and returns the first error outside the parts, or the first
+// error of a part when all parts failed
+func (s *statsCounter) wait() error {
+	s.wg.Wait()
+	if s.err != nil || len(s.parts) == 0 {
+		return s.err
+	}
+
+	var first error
+	for _, err := range s.parts {
+		if err == nil {
+			return nil
+		}
+		if first == nil {
+			first = err
+		}
+	}
+	return first
+}
+
+// failed reports whether a query of the part failed, after wait
+func (s *statsCounter) failed(part string) bool {
+	return s.parts[part] != nil
+}
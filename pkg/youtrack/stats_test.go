@@ -0,0 +1,291 @@
+package youtrack
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestClient_CountIssues(t *testing.T) {
+	countRetryDelay = time.Millisecond
+	defer func() { countRetryDelay = 250 * time.Millisecond }()
+
+	tests := []struct {
+		name      string
+		responses []int
+		expected  int
+		wantErr   bool
+		requests  int
+	}{
+		{name: "Ready", responses: []int{12}, expected: 12, requests: 1},
+		{name: "Still counting", responses: []int{-1, -1, 7}, expected: 7, requests: 3},
+		{name: "Never ready", responses: []int{-1}, wantErr: true, requests: countRetries + 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			requests := 0
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				var body map[string]string
+				json.NewDecoder(r.Body).Decode(&body)
+				if r.Method != http.MethodPost || r.URL.Path != "/api/issuesGetter/count" || body["query"] != "project: {PRJ}" {
+					t.Errorf("Unexpected request %s %s %v", r.Method, r.URL.Path, body)
+				}
+				count := tt.responses[min(requests, len(tt.responses)-1)]
+				requests++
+				fmt.Fprintf(w, `{"count":%d,"$type":"IssueCountResponse"}`, count)
+			}))
+			defer server.Close()
+
+			client := NewClient(server.URL)
+			ctx := NewYouTrackContext(context.Background(), "token")
+
+			count, err := client.CountIssues(ctx, "project: {PRJ}")
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if count != tt.expected {
+				t.Errorf("Expected %d, got %d", tt.expected, count)
+			}
+			if requests != tt.requests {
+				t.Errorf("Expected %d requests, got %d", tt.requests, requests)
+			}
+		})
+	}
+}
+
+func TestClient_GetProjectStats(t *testing.T) {
+	counts := map[string]int{
+		"project: {PRJ} #Unresolved":          10,
+		"project: {PRJ} #Resolved":            25,
+		"project: {PRJ} State: {Open}":        6,
+		"project: {PRJ} State: {In Progress}": 4,
+		"project: {PRJ} State: {Fixed}":       25,
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/issuesGetter/count":
+			var body map[string]string
+			json.NewDecoder(r.Body).Decode(&body)
+			count := counts[body["query"]]
+			if strings.Contains(body["query"], "created:") {
+				count = 3
+			}
+			fmt.Fprintf(w, `{"count":%d}`, count)
+		case "/api/admin/projects/PRJ/customFields":
+			fmt.Fprint(w, `[{"id":"f1","$type":"StateProjectCustomField","field":{"name":"State"},"bundle":{"id":"b1"}}]`)
+		case "/api/admin/customFieldSettings/bundles/state/b1/values":
+			fmt.Fprint(w, `[{"id":"v1","name":"Open"},{"id":"v2","name":"In Progress"},{"id":"v3","name":"Fixed"}]`)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL)
+	ctx := NewYouTrackContext(context.Background(), "token")
+
+	stats, err := client.GetProjectStats(ctx, "PRJ", ProjectStatsOptions{Days: 7})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if stats.Open != 10 || stats.Resolved != 25 || stats.Created != 3 || stats.Days != 7 {
+		t.Errorf("Unexpected totals: %+v", stats)
+	}
+	expectedStates := []ValueCount{{"Open", 6}, {"In Progress", 4}, {"Fixed", 25}}
+	if len(stats.ByState) != len(expectedStates) {
+		t.Fatalf("Expected %d states, got %+v", len(expectedStates), stats.ByState)
+	}
+	for i, state := range expectedStates {
+		if stats.ByState[i] != state {
+			t.Errorf("Expected state %+v, got %+v", state, stats.ByState[i])
+		}
+	}
+	// No Priority field and no Hub access: both parts are reported missing
+	if strings.Join(stats.Missing, ",") != "priorities,assignees" {
+		t.Errorf("Expected priorities and assignees missing, got %v", stats.Missing)
+	}
+}
+
+func TestClient_GetProjectStats_PartialFailure(t *testing.T) {
+	counts := map[string]int{
+		"project: {PRJ} #Unresolved":                      10,
+		"project: {PRJ} #Resolved":                        25,
+		"project: {PRJ} #Unresolved Assignee: Unassigned": 4,
+		"project: {PRJ} #Unresolved Assignee: {alice}":    6,
+	}
+
+	tests := []struct {
+		name     string
+		failing  string // count queries containing it fail
+		missing  string
+		assigned int
+		wantErr  bool
+	}{
+		{name: "All counts", missing: "states,priorities", assigned: 2},
+		{name: "Created count fails", failing: "created:", missing: "created,states,priorities", assigned: 2},
+		{name: "Assignee count fails", failing: "{alice}", missing: "states,priorities,assignees"},
+		{name: "All counts fail", failing: "project:", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				switch r.URL.Path {
+				case "/api/issuesGetter/count":
+					var body map[string]string
+					json.NewDecoder(r.Body).Decode(&body)
+					if tt.failing != "" && strings.Contains(body["query"], tt.failing) {
+						http.Error(w, `{"error":"bad query"}`, http.StatusBadRequest)
+						return
+					}
+					if strings.Contains(body["query"], "Assignee") {
+						if _, ok := counts[body["query"]]; !ok {
+							t.Errorf("Unexpected query %q", body["query"])
+						}
+					}
+					fmt.Fprintf(w, `{"count":%d}`, counts[body["query"]])
+				case "/api/admin/projects/PRJ":
+					fmt.Fprint(w, `{"ringId":"ring-1"}`)
+				case "/hub/api/rest/projects/ring-1/team/users":
+					fmt.Fprint(w, `{"users":[{"id":"1","login":"alice","name":"Alice Smith"}]}`)
+				default:
+					http.NotFound(w, r)
+				}
+			}))
+			defer server.Close()
+
+			client := NewClient(server.URL)
+			client.SetHubURL(server.URL + "/hub")
+			ctx := NewYouTrackContext(context.Background(), "token")
+
+			stats, err := client.GetProjectStats(ctx, "PRJ", ProjectStatsOptions{})
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("Expected an error, got %+v", stats)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if stats.Open != 10 || stats.Resolved != 25 {
+				t.Errorf("Unexpected totals: %+v", stats)
+			}
+			if strings.Join(stats.Missing, ",") != tt.missing {
+				t.Errorf("Expected %q missing, got %v", tt.missing, stats.Missing)
+			}
+			if len(stats.TopAssignees) != tt.assigned {
+				t.Errorf("Expected %d assignees, got %+v", tt.assigned, stats.TopAssignees)
+			}
+		})
+	}
+}
+
+func TestClient_GetFieldDistribution(t *testing.T) {
+	counts := map[string]int{
+		"project: {PRJ} #Unresolved {Priority}: {Critical}": 2,
+		"project: {PRJ} #Unresolved {Priority}: {Normal}":   9,
+		"project: {PRJ} #Unresolved has: -{Priority}":       1,
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/issuesGetter/count":
+			var body map[string]string
+			json.NewDecoder(r.Body).Decode(&body)
+			count, ok := counts[body["query"]]
+			if !ok {
+				t.Errorf("Unexpected query %q", body["query"])
+			}
+			fmt.Fprintf(w, `{"count":%d}`, count)
+		case "/api/admin/projects/PRJ/customFields":
+			fmt.Fprint(w, `[{"id":"f1","$type":"EnumProjectCustomField","field":{"name":"Priority"},"bundle":{"id":"b1"}}]`)
+		case "/api/admin/customFieldSettings/bundles/enum/b1/values":
+			fmt.Fprint(w, `[{"id":"v1","name":"Critical"},{"id":"v2","name":"Normal"}]`)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL)
+	ctx := NewYouTrackContext(context.Background(), "token")
+
+	dist, err := client.GetFieldDistribution(ctx, "PRJ", "priority", "#Unresolved")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	expected := []ValueCount{{"Critical", 2}, {"Normal", 9}, {"", 1}}
+	if dist.Field != "Priority" || dist.Total != 12 || len(dist.Values) != len(expected) {
+		t.Fatalf("Unexpected distribution: %+v", dist)
+	}
+	for i, v := range expected {
+		if dist.Values[i] != v {
+			t.Errorf("Expected %+v, got %+v", v, dist.Values[i])
+		}
+	}
+}
+
+func TestClient_GetAssigneeLoad(t *testing.T) {
+	counts := map[string]int{
+		"project: {PRJ} #Unresolved Assignee: {alice}":               3,
+		"project: {PRJ} #Unresolved Assignee: {bob}":                 5,
+		"project: {PRJ} #Unresolved Assignee: {carol}":               3,
+		"project: {PRJ} #Unresolved Assignee: {alice} {Type}: {Bug}": 2,
+		"project: {PRJ} #Unresolved Assignee: {bob} {Type}: {Bug}":   0,
+		"project: {PRJ} #Unresolved Assignee: {carol} {Type}: {Bug}": 2,
+	}
+	logged := map[string]int{"1": 240, "2": 60, "3": 30}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/issuesGetter/count":
+			var body map[string]string
+			json.NewDecoder(r.Body).Decode(&body)
+			count, ok := counts[body["query"]]
+			if !ok {
+				t.Errorf("Unexpected query %q", body["query"])
+			}
+			fmt.Fprintf(w, `{"count":%d}`, count)
+		case "/api/admin/projects/PRJ":
+			fmt.Fprint(w, `{"ringId":"ring-1"}`)
+		case "/hub/api/rest/projects/ring-1/team/users":
+			fmt.Fprint(w, `{"users":[{"id":"1","login":"alice"},{"id":"2","login":"bob"},{"id":"3","login":"carol"}]}`)
+		case "/api/workItems":
+			fmt.Fprintf(w, `[{"id":"w1","duration":{"minutes":%d}}]`, logged[r.URL.Query().Get("author")])
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL)
+	client.SetHubURL(server.URL + "/hub")
+	ctx := NewYouTrackContext(context.Background(), "token")
+
+	loads, err := client.GetAssigneeLoad(ctx, "PRJ", AssigneeLoadOptions{IssueType: "Bug", WorklogDays: 14})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	// bob has no bugs; alice and carol tie on bugs and open issues, carol logged less
+	expected := []string{"bob", "carol", "alice"}
+	if len(loads) != len(expected) {
+		t.Fatalf("Expected %d members, got %d", len(expected), len(loads))
+	}
+	for i, login := range expected {
+		if loads[i].User.Login != login {
+			t.Errorf("Expected %s at %d, got %s", login, i, loads[i].User.Login)
+		}
+	}
+	if loads[2].OpenIssues != 3 || loads[2].OpenOfType != 2 || loads[2].LoggedMinutes != 240 {
+		t.Errorf("Unexpected load of alice: %+v", loads[2])
+	}
+}
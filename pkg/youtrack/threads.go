@@ -0,0 +1,66 @@
+package youtrack
+
+// CommentThread is a comment with the replies to it, each a thread of its own
+type CommentThread struct {
+	Comment *IssueComment    `json:"comment"`
+	Replies []*CommentThread `json:"replies,omitempty"`
+}
+
+// BuildCommentThreads arranges comments into threads, keeping their order within each level.
+// A reply to a comment that is not among the comments, e.g. a deleted one, starts a thread,
+// as does a comment whose replies lead back to itself.
+func BuildCommentThreads(comments []*IssueComment) []*CommentThread {
+	threads := make(map[string]*CommentThread, len(comments))
+	for _, comment := range comments {
+		threads[comment.ID] = &CommentThread{Comment: comment}
+	}
+
+	var roots []*CommentThread
+	for _, comment := range comments {
+		thread := threads[comment.ID]
+		if parent := replyParent(threads, comment); parent != nil {
+			parent.Replies = append(parent.Replies, thread)
+			continue
+		}
+		roots = append(roots, thread)
+	}
+	return roots
+}
+
+// replyParent returns the thread of the comment a comment replies to, nil when it is missing
+// or the replies form a cycle
+func replyParent(threads map[string]*CommentThread, comment *IssueComment) *CommentThread {
+	if comment.ReplyTo == nil {
+		return nil
+	}
+	parent, ok := threads[comment.ReplyTo.ID]
+	if !ok {
+		return nil
+	}
+
+	// Follow the replies up; reaching the comment again, or more steps than comments, is a cycle
+	for ancestor, steps := parent.Comment, 0; ancestor.ReplyTo != nil && steps <= len(threads); steps++ {
+		if ancestor.ID == comment.ID {
+			return nil
+		}
+		next, ok := threads[ancestor.ReplyTo.ID]
+		if !ok {
+			break
+		}
+		ancestor = next.Comment
+	}
+	return parent
+}
+
+// WalkCommentThreads calls fn for every comment of the threads, each before its replies,
+// with its depth: 0 for the comments starting a thread
+func WalkCommentThreads(threads []*CommentThread, fn func(comment *IssueComment, depth int)) {
+	walkCommentThreads(threads, 0, fn)
+}
+
+func walkCommentThreads(threads []*CommentThread, depth int, fn func(comment *IssueComment, depth int)) {
+	for _, thread := range threads {
+		fn(thread.Comment, depth)
+		walkCommentThreads(thread.Replies, depth+1, fn)
+	}
+}
@@ -0,0 +1,114 @@
+package youtrack
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestClient_AddIssueComment_ReplyTo(t *testing.T) {
+	tests := []struct {
+		name     string
+		replyTo  string
+		expected string
+	}{
+		{name: "Top-level comment", expected: `{"text":"Done"}`},
+		{name: "Reply", replyTo: "4-1", expected: `{"text":"Done","replyTo":{"id":"4-1"}}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if !strings.Contains(r.URL.Query().Get("fields"), "replyTo(id)") {
+					t.Errorf("Expected replyTo in fields, got %s", r.URL.Query().Get("fields"))
+				}
+				var body json.RawMessage
+				if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+					t.Fatalf("Failed to decode the request: %v", err)
+				}
+				if string(body) != tt.expected {
+					t.Errorf("Expected body %s, got %s", tt.expected, body)
+				}
+				reply := ""
+				if tt.replyTo != "" {
+					reply = fmt.Sprintf(`,"replyTo":{"id":"%s"}`, tt.replyTo)
+				}
+				fmt.Fprintf(w, `{"id":"4-2","text":"Done"%s}`, reply)
+			}))
+			defer server.Close()
+
+			client := NewClient(server.URL)
+			ctx := NewYouTrackContext(context.Background(), "token")
+
+			var comment *IssueComment
+			var err error
+			if tt.replyTo == "" {
+				comment, err = client.AddIssueComment(ctx, "PROJ-1", "Done")
+			} else {
+				comment, err = client.ReplyToIssueComment(ctx, "PROJ-1", tt.replyTo, "Done")
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if tt.replyTo == "" && comment.ReplyTo != nil {
+				t.Errorf("Expected no reply, got %+v", comment.ReplyTo)
+			}
+			if tt.replyTo != "" && (comment.ReplyTo == nil || comment.ReplyTo.ID != tt.replyTo) {
+				t.Errorf("Expected a reply to %s, got %+v", tt.replyTo, comment.ReplyTo)
+			}
+		})
+	}
+}
+
+func TestBuildCommentThreads(t *testing.T) {
+	comment := func(id, replyTo string) *IssueComment {
+		c := &IssueComment{ID: id}
+		if replyTo != "" {
+			c.ReplyTo = &CommentRef{ID: replyTo}
+		}
+		return c
+	}
+
+	tests := []struct {
+		name     string
+		comments []*IssueComment
+		expected string
+	}{
+		{
+			name:     "No threads",
+			comments: []*IssueComment{comment("1", ""), comment("2", "")},
+			expected: "1:0 2:0",
+		},
+		{
+			name:     "Nested replies keep their order",
+			comments: []*IssueComment{comment("1", ""), comment("2", ""), comment("3", "1"), comment("4", "3"), comment("5", "1")},
+			expected: "1:0 3:1 4:2 5:1 2:0",
+		},
+		{
+			name:     "Reply to a missing comment starts a thread",
+			comments: []*IssueComment{comment("1", ""), comment("2", "9")},
+			expected: "1:0 2:0",
+		},
+		{
+			name:     "Reply cycle",
+			comments: []*IssueComment{comment("1", "2"), comment("2", "1"), comment("3", "3"), comment("4", "1")},
+			expected: "1:0 4:1 2:0 3:0",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var walked []string
+			WalkCommentThreads(BuildCommentThreads(tt.comments), func(c *IssueComment, depth int) {
+				walked = append(walked, fmt.Sprintf("%s:%d", c.ID, depth))
+			})
+			if got := strings.Join(walked, " "); got != tt.expected {
+				t.Errorf("Expected %s, got %s", tt.expected, got)
+			}
+		})
+	}
+}
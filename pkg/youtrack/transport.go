@@ -0,0 +1,88 @@
+package youtrack
+
+import (
+	"net"
+	"net/http"
+	"time"
+)
+
+// TransportConfig holds connection settings for the underlying HTTP transport
+type TransportConfig struct {
+	MaxIdleConns        int           // Maximum idle connections across all hosts
+	MaxIdleConnsPerHost int           // Maximum idle keep-alive connections per host
+	IdleConnTimeout     time.Duration // How long an idle connection is kept in the pool
+	DisableKeepAlives   bool          // Open a new connection for every request
+	DisableCompression  bool          // Do not request gzip-compressed responses
+	EnableHTTP2         bool          // Attempt HTTP/2 when the server supports it
+}
+
+// DefaultTransportConfig returns transport settings tuned for many small API calls to a single host
+func DefaultTransportConfig() TransportConfig {
+	return TransportConfig{
+		MaxIdleConns:        100,
+		MaxIdleConnsPerHost: 20,
+		IdleConnTimeout:     90 * time.Second,
+		EnableHTTP2:         true,
+	}
+}
+
+// newTransport builds an HTTP transport from the config.
+// HTTP/2 has to be forced since the transport has its own dialer; it is negotiated over TLS
+// and falls back to HTTP/1.1 when the server does not offer it.
+// Compression is handled transparently: the transport sends Accept-Encoding: gzip
+// and decompresses the response body unless DisableCompression is set.
+func newTransport(cfg TransportConfig) *http.Transport {
+	dialer := &net.Dialer{
+		Timeout:   30 * time.Second,
+		KeepAlive: 30 * time.Second,
+	}
+
+	return &http.Transport{
+		Proxy:                 http.ProxyFromEnvironment,
+		DialContext:           dialer.DialContext,
+		MaxIdleConns:          cfg.MaxIdleConns,
+		MaxIdleConnsPerHost:   cfg.MaxIdleConnsPerHost,
+		IdleConnTimeout:       cfg.IdleConnTimeout,
+		DisableKeepAlives:     cfg.DisableKeepAlives,
+		DisableCompression:    cfg.DisableCompression,
+		ForceAttemptHTTP2:     cfg.EnableHTTP2,
+		TLSHandshakeTimeout:   10 * time.Second,
+		ExpectContinueTimeout: 1 * time.Second,
+	}
+}
+
+// SetTransportConfig replaces the client's HTTP transport with one built from the config,
+// keeping the TLS, proxy and middleware options the client was created with. A client created
+// with WithHTTPClient keeps the transport of that client, which takes precedence.
+func (c *Client) SetTransportConfig(cfg TransportConfig) {
+	if c.base == nil {
+		// The transport belongs to the custom client
+		return
+	}
+	c.buildTransport(cfg)
+}
+
+// buildTransport sets the client transport built from the config and the client options
+func (c *Client) buildTransport(cfg TransportConfig) {
+	base := newTransport(cfg)
+	if c.tlsConfig != nil {
+		base.TLSClientConfig = c.tlsConfig.Clone()
+	}
+	if c.proxy != nil {
+		base.Proxy = c.proxy
+	}
+
+	if c.base != nil {
+		c.base.CloseIdleConnections()
+	}
+	c.base = base
+	c.httpClient.Transport = wrapMiddleware(base, c.middleware)
+}
+
+// wrapMiddleware wraps a round tripper with middleware, the first one being the outermost
+func wrapMiddleware(rt http.RoundTripper, middleware []Middleware) http.RoundTripper {
+	for i := len(middleware) - 1; i >= 0; i-- {
+		rt = middleware[i](rt)
+	}
+	return rt
+}
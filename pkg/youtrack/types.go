@@ -34,6 +34,7 @@ func (yt YouTrackTime) MarshalJSON() ([]byte, error) {
 
 type Issue struct {
 	ID          string        `json:"idReadable"`
+	InternalID  string        `json:"id,omitempty"` // database ID, e.g. "2-123"
 	Summary     string        `json:"summary"`
 	Description string        `json:"description,omitempty"`
 	Created     YouTrackTime  `json:"created"`
@@ -42,10 +43,18 @@ type Issue struct {
 	Reporter    *User         `json:"reporter,omitempty"`
 	UpdatedBy   *User         `json:"updater,omitempty"`
 	Assignee    *User         `json:"-"` // extracted from customFields
+	State       string        `json:"-"` // extracted from customFields
 	Tags        []*IssueTag   `json:"tags,omitempty"`
+	Visibility  *Visibility   `json:"visibility,omitempty"`
+	Votes       int           `json:"votes,omitempty"` // including votes for duplicates
+	// UsesMarkdown is false for descriptions in the legacy wiki markup, nil when not fetched
+	UsesMarkdown *bool `json:"usesMarkdown,omitempty"`
+
+	// CustomFields holds the raw custom field values returned with the issue
+	CustomFields []*CustomFieldValue `json:"-"`
 }
 
-// UnmarshalJSON custom unmarshals Issue, extracting Assignee from customFields
+// UnmarshalJSON custom unmarshals Issue, extracting Assignee and State from customFields
 func (i *Issue) UnmarshalJSON(data []byte) error {
 	type IssueAlias Issue
 	aux := &struct {
@@ -60,17 +69,28 @@ func (i *Issue) UnmarshalJSON(data []byte) error {
 	}
 
 	for _, raw := range aux.CustomFields {
+		var value CustomFieldValue
+		if err := json.Unmarshal(raw, &value); err == nil {
+			i.CustomFields = append(i.CustomFields, &value)
+		}
+
 		var field struct {
 			Name  string `json:"name"`
 			Type  string `json:"$type"`
-			Value *User  `json:"value"`
+			Value *struct {
+				User
+				Name string `json:"name"`
+			} `json:"value"`
 		}
-		if err := json.Unmarshal(raw, &field); err != nil {
+		if err := json.Unmarshal(raw, &field); err != nil || field.Value == nil {
 			continue
 		}
-		if field.Name == "Assignee" && field.Value != nil && field.Value.Login != "" {
-			i.Assignee = field.Value
-			break
+		switch {
+		case field.Name == "Assignee" && field.Value.Login != "":
+			user := field.Value.User
+			i.Assignee = &user
+		case field.Type == "StateIssueCustomField" || (field.Name == "State" && i.State == ""):
+			i.State = field.Value.Name
 		}
 	}
 
@@ -78,10 +98,26 @@ func (i *Issue) UnmarshalJSON(data []byte) error {
 }
 
 type User struct {
-	ID       string `json:"id"`
-	Login    string `json:"login"`
-	FullName string `json:"fullName,omitempty"`
-	Email    string `json:"email,omitempty"`
+	ID        string `json:"id"`
+	Login     string `json:"login"`
+	FullName  string `json:"fullName,omitempty"`
+	Email     string `json:"email,omitempty"`
+	AvatarURL string `json:"avatarUrl,omitempty"`
+	// Banned users cannot log in, nor be assigned
+	Banned bool `json:"banned,omitempty"`
+	// Online is set for users with an active session, when the endpoint reports it
+	Online bool `json:"online,omitempty"`
+}
+
+// ActiveUsers returns the users that are not banned
+func ActiveUsers(users []*User) []*User {
+	active := make([]*User, 0, len(users))
+	for _, user := range users {
+		if !user.Banned {
+			active = append(active, user)
+		}
+	}
+	return active
 }
 
 type Project struct {
@@ -92,11 +128,27 @@ type Project struct {
 }
 
 type IssueComment struct {
-	ID      string       `json:"id"`
-	Author  *User        `json:"author,omitempty"`
-	Text    string       `json:"text"`
-	Created YouTrackTime `json:"created"`
-	Updated YouTrackTime `json:"updated"`
+	ID        string       `json:"id"`
+	Author    *User        `json:"author,omitempty"`
+	Text      string       `json:"text"`
+	Created   YouTrackTime `json:"created"`
+	Updated   YouTrackTime `json:"updated"`
+	Reactions []*Reaction  `json:"reactions,omitempty"`
+	// ReplyTo is the comment this one answers, nil for a top-level comment or on servers
+	// without comment threads
+	ReplyTo *CommentRef `json:"replyTo,omitempty"`
+}
+
+// CommentRef refers to a comment by its ID
+type CommentRef struct {
+	ID string `json:"id"`
+}
+
+// Reaction is an emoji reaction of a user to a comment, e.g. "thumbs-up"
+type Reaction struct {
+	ID       string `json:"id"`
+	Reaction string `json:"reaction"`
+	Author   *User  `json:"author,omitempty"`
 }
 
 type ProjectRef struct {
@@ -122,12 +174,59 @@ type CreateIssueRequest struct {
 	Summary     string        `json:"summary"`
 	Description string        `json:"description,omitempty"`
 	Fields      []CustomField `json:"customFields,omitempty"`
+	Visibility  *Visibility   `json:"visibility,omitempty"`
 }
 
 type UpdateIssueRequest struct {
 	Summary     *string       `json:"summary,omitempty"`
 	Description *string       `json:"description,omitempty"`
 	Fields      []CustomField `json:"customFields,omitempty"`
+	Visibility  *Visibility   `json:"visibility,omitempty"`
+}
+
+// Visibility restricts who can see an issue to the listed groups and users
+type Visibility struct {
+	Type            string       `json:"$type"` // "LimitedVisibility" or "UnlimitedVisibility"
+	PermittedGroups []*UserGroup `json:"permittedGroups,omitempty"`
+	PermittedUsers  []*User      `json:"permittedUsers,omitempty"`
+}
+
+// UserGroup is a group of users
+type UserGroup struct {
+	ID         string `json:"id"`
+	Name       string `json:"name"`
+	RingID     string `json:"ringId,omitempty"` // Hub entity ID
+	UsersCount int    `json:"usersCount,omitempty"`
+}
+
+// NewLimitedVisibility creates a visibility restricted to the given groups and users
+func NewLimitedVisibility(groups []*UserGroup, users []*User) *Visibility {
+	return &Visibility{Type: "LimitedVisibility", PermittedGroups: groups, PermittedUsers: users}
+}
+
+// NewUnlimitedVisibility creates a visibility that removes all restrictions
+func NewUnlimitedVisibility() *Visibility {
+	return &Visibility{Type: "UnlimitedVisibility"}
+}
+
+// IsLimited reports whether the visibility restricts access
+func (v *Visibility) IsLimited() bool {
+	return v != nil && v.Type == "LimitedVisibility" && (len(v.PermittedGroups) > 0 || len(v.PermittedUsers) > 0)
+}
+
+// String returns a readable list of the permitted groups and users
+func (v *Visibility) String() string {
+	if !v.IsLimited() {
+		return "everyone"
+	}
+	var parts []string
+	for _, g := range v.PermittedGroups {
+		parts = append(parts, g.Name)
+	}
+	for _, u := range v.PermittedUsers {
+		parts = append(parts, u.Login)
+	}
+	return strings.Join(parts, ", ")
 }
 
 type SearchIssuesRequest struct {
@@ -286,13 +385,27 @@ type WorkType struct {
 }
 
 type Attachment struct {
-	ID       string       `json:"id"`
-	Name     string       `json:"name"`
-	Size     int64        `json:"size"`
-	Created  YouTrackTime `json:"created"`
-	Author   *User        `json:"author,omitempty"`
-	MimeType string       `json:"mimeType,omitempty"`
-	URL      string       `json:"url,omitempty"`
+	ID              string           `json:"id"`
+	Name            string           `json:"name"`
+	Size            int64            `json:"size"`
+	Created         YouTrackTime     `json:"created"`
+	Author          *User            `json:"author,omitempty"`
+	MimeType        string           `json:"mimeType,omitempty"`
+	Extension       string           `json:"extension,omitempty"`
+	URL             string           `json:"url,omitempty"`
+	ThumbnailURL    string           `json:"thumbnailURL,omitempty"`    // set for images
+	ImageDimensions *ImageDimensions `json:"imageDimensions,omitempty"` // set for images
+}
+
+// ImageDimensions is the size of an image attachment in pixels
+type ImageDimensions struct {
+	Width  int `json:"width"`
+	Height int `json:"height"`
+}
+
+// IsImage reports whether the attachment is an image
+func (a *Attachment) IsImage() bool {
+	return strings.HasPrefix(a.MimeType, "image/")
 }
 
 type CreateWorklogRequest struct {
@@ -300,12 +413,19 @@ type CreateWorklogRequest struct {
 	Description string           `json:"text,omitempty"`
 	Date        *int64           `json:"date,omitempty"` // Unix epoch milliseconds
 	Type        *WorkTypeRequest `json:"type,omitempty"`
+	// Author logs the work on behalf of another user, which needs 'Update Work Item'
+	Author *UserRef `json:"author,omitempty"`
 }
 
 type WorkTypeRequest struct {
 	Name string `json:"name"`
 }
 
+// UserRef refers to a user by database ID
+type UserRef struct {
+	ID string `json:"id"`
+}
+
 type IssueLink struct {
 	ID        string    `json:"id"`
 	Direction string    `json:"direction"`
@@ -318,6 +438,37 @@ type LinkType struct {
 	Name string `json:"name"`
 }
 
+// VcsChange is a commit linked to an issue by a VCS integration
+type VcsChange struct {
+	ID       string       `json:"id"`
+	Version  string       `json:"version"` // commit hash
+	Text     string       `json:"text"`    // commit message
+	Date     YouTrackTime `json:"date"`
+	UserName string       `json:"userName,omitempty"` // author name in the VCS
+	Author   *User        `json:"author,omitempty"`   // matching YouTrack user, if any
+	URLs     []string     `json:"urls,omitempty"`
+	Files    int          `json:"files,omitempty"`
+}
+
+// ShortVersion returns the abbreviated commit hash
+func (v *VcsChange) ShortVersion() string {
+	if len(v.Version) > 8 {
+		return v.Version[:8]
+	}
+	return v.Version
+}
+
+// AuthorName returns the YouTrack user name of the author, or the VCS user name
+func (v *VcsChange) AuthorName() string {
+	if v.Author != nil {
+		if v.Author.FullName != "" {
+			return v.Author.FullName
+		}
+		return v.Author.Login
+	}
+	return v.UserName
+}
+
 type CreateIssueLinkRequest struct {
 	Query  string      `json:"query"`
 	Issues []*IssueRef `json:"issues"`
@@ -327,15 +478,194 @@ type IssueRef struct {
 	ID string `json:"idReadable"`
 }
 
+// MarshalJSON refers to the issue by database ID when ID is one, by readable ID otherwise
+func (r *IssueRef) MarshalJSON() ([]byte, error) {
+	if IsInternalIssueID(r.ID) {
+		return json.Marshal(map[string]string{"id": r.ID})
+	}
+	return json.Marshal(map[string]string{"idReadable": r.ID})
+}
+
+// IssueVoters holds the votes of an issue
+type IssueVoters struct {
+	Votes          int              `json:"votes"`   // total, including votes for duplicates
+	HasVote        bool             `json:"hasVote"` // the current user voted
+	Voters         []*User          `json:"voters"`
+	DuplicateVotes []*DuplicateVote `json:"duplicateVotes,omitempty"`
+}
+
+// DuplicateVote is a vote given to a duplicate of the issue
+type DuplicateVote struct {
+	IssueID string `json:"issueId"`
+	User    *User  `json:"user"`
+}
+
 type CustomFieldValue struct {
 	Name  string      `json:"name"`
 	Type  string      `json:"$type"`
 	Value interface{} `json:"value"`
+
+	// ProjectField is the field definition, used to tell date-time fields from plain numbers
+	ProjectField *ProjectCustomField `json:"projectCustomField,omitempty"`
+}
+
+// IsDate reports whether the field holds a date ("date") or a timestamp ("date and time")
+func (f *CustomFieldValue) IsDate() bool {
+	return f.Type == "DateIssueCustomField" || f.HasTime()
+}
+
+// HasTime reports whether the field is a "date and time" field
+func (f *CustomFieldValue) HasTime() bool {
+	return f.ProjectField != nil && f.ProjectField.Field != nil && f.ProjectField.Field.FieldType != nil &&
+		f.ProjectField.Field.FieldType.ID == "date and time"
+}
+
+// String returns the field value for display: value names (or logins) joined
+// with ", " for multi-value fields, "" when the field is empty
+func (f *CustomFieldValue) String() string {
+	switch v := f.Value.(type) {
+	case nil:
+		return ""
+	case map[string]interface{}:
+		for _, key := range []string{"name", "fullName", "login", "text", "presentation"} {
+			if s, ok := v[key].(string); ok && s != "" {
+				return s
+			}
+		}
+		return fmt.Sprintf("%v", v)
+	case []interface{}:
+		names := make([]string, 0, len(v))
+		for _, item := range v {
+			names = append(names, (&CustomFieldValue{Value: item}).String())
+		}
+		return strings.Join(names, ", ")
+	case float64:
+		if t, ok := f.DateValue(); ok {
+			if f.HasTime() {
+				return t.Format("2006-01-02 15:04")
+			}
+			return t.Format("2006-01-02")
+		}
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	default:
+		return fmt.Sprintf("%v", v)
+	}
+}
+
+// DateValue returns the value of a date or date-time field (epoch milliseconds in the API).
+// Dates are calendar days and are returned in UTC, date-time values in local time.
+func (f *CustomFieldValue) DateValue() (time.Time, bool) {
+	ms, ok := f.Value.(float64)
+	if !ok || !f.IsDate() {
+		return time.Time{}, false
+	}
+	t := time.UnixMilli(int64(ms))
+	if !f.HasTime() {
+		t = t.UTC()
+	}
+	return t, true
+}
+
+// DateField returns the value of a date custom field (case-insensitive name),
+// or false when the issue has no such field or it is empty
+func (i *Issue) DateField(name string) (time.Time, bool) {
+	for _, field := range i.CustomFields {
+		if strings.EqualFold(field.Name, name) {
+			return field.DateValue()
+		}
+	}
+	return time.Time{}, false
+}
+
+// FieldValue returns the display value of a custom field (case-insensitive name),
+// or "" when the issue has no such field or it is empty
+func (i *Issue) FieldValue(name string) string {
+	for _, field := range i.CustomFields {
+		if strings.EqualFold(field.Name, name) {
+			return field.String()
+		}
+	}
+	return ""
 }
 
 type AllowedValue struct {
-	ID   string `json:"id"`
-	Name string `json:"name"`
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	Archived    bool   `json:"archived,omitempty"`    // version bundles only
+	Released    bool   `json:"released,omitempty"`    // version bundles only
+	ReleaseDate int64  `json:"releaseDate,omitempty"` // version bundles only, epoch milliseconds
+}
+
+// ProjectCustomField is a custom field attached to a project, with its value bundle
+type ProjectCustomField struct {
+	ID     string                 `json:"id"`
+	Type   string                 `json:"$type"` // e.g. "EnumProjectCustomField", "VersionProjectCustomField"
+	Field  *CustomFieldDefinition `json:"field"`
+	Bundle *BundleRef             `json:"bundle,omitempty"`
+}
+
+// CustomFieldDefinition is the instance-wide definition of a custom field
+type CustomFieldDefinition struct {
+	ID        string     `json:"id"`
+	Name      string     `json:"name"`
+	FieldType *FieldType `json:"fieldType,omitempty"`
+}
+
+// FieldType describes the value type of a custom field, e.g. "enum[1]" or "version[*]"
+type FieldType struct {
+	ID           string `json:"id"`
+	IsMultiValue bool   `json:"isMultiValue"`
+}
+
+// BundleRef references the set of values of a bundle-backed field
+type BundleRef struct {
+	ID string `json:"id"`
+}
+
+// bundleKinds maps project custom field types to bundle kinds used in the admin API
+var bundleKinds = []struct {
+	marker string
+	kind   string
+}{
+	{"State", "state"},
+	{"Owned", "ownedField"},
+	{"Enum", "enum"},
+	{"Version", "version"},
+	{"Build", "build"},
+}
+
+// BundleKind returns the bundle kind of the field as used in
+// /api/admin/customFieldSettings/bundles/{kind} paths, defaulting to "enum"
+func (f *ProjectCustomField) BundleKind() string {
+	for _, bk := range bundleKinds {
+		if strings.Contains(f.Type, bk.marker) {
+			return bk.kind
+		}
+	}
+	return "enum"
+}
+
+// IsMultiValue reports whether the field holds a list of values
+func (f *ProjectCustomField) IsMultiValue() bool {
+	return f.Field != nil && f.Field.FieldType != nil && f.Field.FieldType.IsMultiValue
+}
+
+// IssueFieldType returns the $type of this field on an issue,
+// e.g. "VersionProjectCustomField" with multiple values becomes "MultiVersionIssueCustomField"
+func (f *ProjectCustomField) IssueFieldType() string {
+	kind := strings.TrimSuffix(f.Type, "ProjectCustomField")
+	if kind == "Simple" && f.Field != nil && f.Field.FieldType != nil && f.Field.FieldType.ID == "date" {
+		return "DateIssueCustomField"
+	}
+	switch kind {
+	case "Enum", "Version", "Build", "User", "Owned", "Group":
+		if f.IsMultiValue() {
+			return "Multi" + kind + "IssueCustomField"
+		}
+		return "Single" + kind + "IssueCustomField"
+	default:
+		return kind + "IssueCustomField"
+	}
 }
 
 type CommandRequest struct {
@@ -343,6 +673,45 @@ type CommandRequest struct {
 	Issues []*IssueRef `json:"issues"`
 }
 
+// SearchAssistRequest is the payload for the search assist endpoint
+type SearchAssistRequest struct {
+	Query string `json:"query"`
+	Caret int    `json:"caret"`
+}
+
+// SearchAssist holds query completion suggestions returned by YouTrack
+type SearchAssist struct {
+	Query       string              `json:"query"`
+	Caret       int                 `json:"caret"`
+	Suggestions []*SearchSuggestion `json:"suggestions"`
+}
+
+// SearchSuggestion represents a single query completion option
+type SearchSuggestion struct {
+	Option          string `json:"option"`
+	Description     string `json:"description,omitempty"`
+	Prefix          string `json:"prefix,omitempty"`
+	Suffix          string `json:"suffix,omitempty"`
+	Group           string `json:"group,omitempty"`
+	Caret           int    `json:"caret"`
+	CompletionStart int    `json:"completionStart"`
+	CompletionEnd   int    `json:"completionEnd"`
+}
+
+// Apply returns the query with the suggestion inserted in place of the completed range.
+// The range is in characters, not bytes.
+func (s *SearchSuggestion) Apply(query string) string {
+	runes := []rune(query)
+	start, end := s.CompletionStart, s.CompletionEnd
+	if start < 0 || start > len(runes) {
+		start = len(runes)
+	}
+	if end < start || end > len(runes) {
+		end = start
+	}
+	return string(runes[:start]) + s.Prefix + s.Option + s.Suffix + string(runes[end:])
+}
+
 // ActivityItem represents an activity item in the issue history
 type ActivityItem struct {
 	ID            string        `json:"id"`
@@ -353,8 +722,67 @@ type ActivityItem struct {
 	Field         *Field        `json:"field,omitempty"`
 	RemovedValues []*FieldValue `json:"removed,omitempty"`
 	AddedValues   []*FieldValue `json:"added,omitempty"`
-	Added         *FieldValue   `json:"added,omitempty"`
-	Removed       *FieldValue   `json:"removed,omitempty"`
+	Added         *FieldValue   `json:"addedValue,omitempty"`   // set when YouTrack returns a single value
+	Removed       *FieldValue   `json:"removedValue,omitempty"` // set when YouTrack returns a single value
+	// Target is the changed entity, set by the activity stream of several issues
+	Target *ActivityTarget `json:"target,omitempty"`
+}
+
+// ActivityTarget is the entity an activity changed: an issue, or a comment or work item with its issue
+type ActivityTarget struct {
+	ID         string    `json:"id"`
+	IDReadable string    `json:"idReadable,omitempty"` // set when the target is an issue
+	Issue      *IssueRef `json:"issue,omitempty"`      // set when the target belongs to an issue
+}
+
+// UnmarshalJSON custom unmarshals ActivityItem, since "added" and "removed"
+// can be either a list of values, a single entity, or a plain scalar
+func (a *ActivityItem) UnmarshalJSON(data []byte) error {
+	type ActivityItemAlias ActivityItem
+	aux := &struct {
+		*ActivityItemAlias
+		Added   json.RawMessage `json:"added,omitempty"`
+		Removed json.RawMessage `json:"removed,omitempty"`
+	}{
+		ActivityItemAlias: (*ActivityItemAlias)(a),
+	}
+
+	if err := json.Unmarshal(data, aux); err != nil {
+		return err
+	}
+
+	a.AddedValues, a.Added = decodeActivityValues(aux.Added)
+	a.RemovedValues, a.Removed = decodeActivityValues(aux.Removed)
+	return nil
+}
+
+// decodeActivityValues decodes the raw "added"/"removed" payload of an activity item
+func decodeActivityValues(raw json.RawMessage) ([]*FieldValue, *FieldValue) {
+	if len(raw) == 0 || string(raw) == "null" {
+		return nil, nil
+	}
+
+	switch raw[0] {
+	case '[':
+		var values []*FieldValue
+		if err := json.Unmarshal(raw, &values); err == nil {
+			return values, nil
+		}
+	case '{':
+		var value FieldValue
+		if err := json.Unmarshal(raw, &value); err == nil {
+			return nil, &value
+		}
+	case '"':
+		var text string
+		if err := json.Unmarshal(raw, &text); err == nil {
+			return nil, &FieldValue{Text: text}
+		}
+	default:
+		return nil, &FieldValue{Text: string(raw)}
+	}
+
+	return nil, nil
 }
 
 // Category represents the category of an activity item
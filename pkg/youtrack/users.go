@@ -2,14 +2,18 @@ package youtrack
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/url"
 	"strings"
 )
 
+// userFields are the fields requested for users
+const userFields = "id,login,fullName,email,avatarUrl,banned,online"
+
 func (c *Client) GetCurrentUser(ctx *YouTrackContext) (*User, error) {
 	query := url.Values{}
-	query.Add("fields", "id,login,fullName,email")
+	query.Add("fields", userFields)
 
 	resp, err := c.Get(ctx, "/api/users/me", query)
 	if err != nil {
@@ -29,7 +33,7 @@ func (c *Client) GetUser(ctx *YouTrackContext, userID string) (*User, error) {
 	path := fmt.Sprintf("/api/users/%s", userID)
 
 	query := url.Values{}
-	query.Add("fields", "id,login,fullName,email")
+	query.Add("fields", userFields)
 
 	resp, err := c.Get(ctx, path, query)
 	if err != nil {
@@ -50,7 +54,7 @@ func (c *Client) SearchUsers(ctx *YouTrackContext, query string, skip, top int)
 	params.Add("query", query)
 	params.Add("$skip", fmt.Sprintf("%d", skip))
 	params.Add("$top", fmt.Sprintf("%d", top))
-	params.Add("fields", "id,login,fullName,email")
+	params.Add("fields", userFields)
 
 	resp, err := c.Get(ctx, "/api/users", params)
 	if err != nil {
@@ -79,7 +83,21 @@ func (c *Client) GetUserByLogin(ctx *YouTrackContext, login string) (*User, erro
 	return users[0], nil
 }
 
+// GetProjectUsers returns the project team from Hub. Without a Hub URL, or when the token may
+// not read the team (it needs admin rights), it falls back to the assignable users.
 func (c *Client) GetProjectUsers(ctx *YouTrackContext, projectID string, skip, top int) ([]*User, error) {
+	if c.hubURL == "" {
+		return c.GetProjectAssignees(ctx, projectID, skip, top)
+	}
+	users, err := c.GetProjectTeam(ctx, projectID, skip, top)
+	if isPermissionError(err) {
+		return c.GetProjectAssignees(ctx, projectID, skip, top)
+	}
+	return users, err
+}
+
+// GetProjectTeam returns the project team users via the Hub REST API. Paginated.
+func (c *Client) GetProjectTeam(ctx *YouTrackContext, projectID string, skip, top int) ([]*User, error) {
 	// Step 1: Get the project's ringId (Hub entity ID)
 	ringID, err := c.getProjectRingID(ctx, projectID)
 	if err != nil {
@@ -92,7 +110,7 @@ func (c *Client) GetProjectUsers(ctx *YouTrackContext, projectID string, skip, t
 	params := url.Values{}
 	params.Add("$skip", fmt.Sprintf("%d", skip))
 	params.Add("$top", fmt.Sprintf("%d", top))
-	params.Add("fields", "id,login,name,profile(email(email))")
+	params.Add("fields", "id,login,name,banned,profile(email(email),avatar(url))")
 
 	resp, err := c.hubGet(ctx, path, params)
 	if err != nil {
@@ -100,35 +118,110 @@ func (c *Client) GetProjectUsers(ctx *YouTrackContext, projectID string, skip, t
 	}
 	defer resp.Body.Close()
 
-	var hubResp struct {
-		Users []struct {
-			ID      string `json:"id"`
-			Login   string `json:"login"`
-			Name    string `json:"name"`
-			Profile struct {
-				Email struct {
-					Email string `json:"email"`
-				} `json:"email"`
-			} `json:"profile"`
-		} `json:"users"`
-	}
+	var hubResp hubUserPage
 	if err := json.NewDecoder(resp.Body).Decode(&hubResp); err != nil {
 		return nil, fmt.Errorf("failed to decode project users: %w", err)
 	}
 
-	users := make([]*User, len(hubResp.Users))
-	for i, hu := range hubResp.Users {
-		users[i] = &User{
-			ID:       hu.ID,
-			Login:    hu.Login,
-			FullName: hu.Name,
-			Email:    hu.Profile.Email.Email,
+	return hubResp.toUsers(), nil
+}
+
+// GetProjectAssignees returns the users that can be assigned in a project: the users and group
+// members of the Assignee field bundle, or of the first user field when there is no Assignee.
+// Unlike the project team, it is readable without admin rights. Paginated.
+func (c *Client) GetProjectAssignees(ctx *YouTrackContext, projectID string, skip, top int) ([]*User, error) {
+	path := fmt.Sprintf("/api/admin/projects/%s/customFields", projectID)
+
+	params := url.Values{}
+	params.Add("fields", "$type,field(name),bundle(aggregatedUsers("+userFields+"))")
+
+	resp, err := c.Get(ctx, path, params)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var fields []struct {
+		Type  string `json:"$type"`
+		Field *struct {
+			Name string `json:"name"`
+		} `json:"field"`
+		Bundle *struct {
+			AggregatedUsers []*User `json:"aggregatedUsers"`
+		} `json:"bundle"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&fields); err != nil {
+		return nil, fmt.Errorf("failed to decode project user fields: %w", err)
+	}
+
+	var users []*User
+	found := false
+	for _, field := range fields {
+		if field.Type != "UserProjectCustomField" || field.Bundle == nil {
+			continue
+		}
+		if field.Field != nil && strings.EqualFold(field.Field.Name, "Assignee") {
+			users, found = field.Bundle.AggregatedUsers, true
+			break
 		}
+		if !found {
+			users, found = field.Bundle.AggregatedUsers, true
+		}
+	}
+	if !found {
+		return nil, fmt.Errorf("project '%s' has no user field to list assignees from", projectID)
 	}
 
+	if skip >= len(users) {
+		return []*User{}, nil
+	}
+	users = users[skip:]
+	if top > 0 && top < len(users) {
+		users = users[:top]
+	}
 	return users, nil
 }
 
+// isPermissionError reports whether the server refused a request for lack of rights
+func isPermissionError(err error) bool {
+	var apiErr *APIError
+	return errors.As(err, &apiErr) && (apiErr.StatusCode == 401 || apiErr.StatusCode == 403)
+}
+
+// hubUserPage is a page of users returned by the Hub REST API
+type hubUserPage struct {
+	Users []struct {
+		ID      string `json:"id"`
+		Login   string `json:"login"`
+		Name    string `json:"name"`
+		Banned  bool   `json:"banned"`
+		Profile struct {
+			Email struct {
+				Email string `json:"email"`
+			} `json:"email"`
+			Avatar struct {
+				URL string `json:"url"`
+			} `json:"avatar"`
+		} `json:"profile"`
+	} `json:"users"`
+}
+
+// toUsers converts Hub users to YouTrack users
+func (p *hubUserPage) toUsers() []*User {
+	users := make([]*User, len(p.Users))
+	for i, hu := range p.Users {
+		users[i] = &User{
+			ID:        hu.ID,
+			Login:     hu.Login,
+			FullName:  hu.Name,
+			Email:     hu.Profile.Email.Email,
+			AvatarURL: hu.Profile.Avatar.URL,
+			Banned:    hu.Banned,
+		}
+	}
+	return users
+}
+
 // getProjectRingID retrieves the Hub entity ID (ringId) for a YouTrack project.
 func (c *Client) getProjectRingID(ctx *YouTrackContext, projectID string) (string, error) {
 	path := fmt.Sprintf("/api/admin/projects/%s", projectID)
@@ -177,8 +270,8 @@ func (c *Client) SuggestUserByProject(ctx *YouTrackContext, projectID string, us
 			break
 		}
 
-		// Search for matching user
-		for _, user := range users {
+		// Search for matching user, banned users cannot be assigned
+		for _, user := range ActiveUsers(users) {
 			// Check if username matches any of the user fields (case-insensitive)
 			if strings.Contains(strings.ToLower(user.Login), lowercaseUsername) ||
 				strings.Contains(strings.ToLower(user.FullName), lowercaseUsername) ||
@@ -202,7 +295,7 @@ func (c *Client) GetUserWorklogs(ctx *YouTrackContext, userID string, projectID
 	params := url.Values{}
 	params.Add("$skip", fmt.Sprintf("%d", skip))
 	params.Add("$top", fmt.Sprintf("%d", top))
-	params.Add("fields", "id,date,duration(minutes,presentation),text,author(id,login,fullName,email),type(id,name),issue(idReadable,summary)")
+	params.Add("fields", "id,date,duration(minutes,presentation),text,author(id,login,fullName,email),type(id,name),issue(id,idReadable,summary)")
 	params.Add("author", userID)
 
 	if projectID != "" {
@@ -0,0 +1,148 @@
+package youtrack
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestClient_GetProjectUsers_Status(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/admin/projects/PRJ":
+			fmt.Fprint(w, `{"ringId":"ring-1"}`)
+		case "/hub/api/rest/projects/ring-1/team/users":
+			fmt.Fprint(w, `{"users":[
+				{"id":"1","login":"alice","name":"Alice Smith","profile":{"avatar":{"url":"https://yt.example.com/avatar/1"}}},
+				{"id":"2","login":"alex","name":"Alex Smith","banned":true}
+			]}`)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL)
+	client.SetHubURL(server.URL + "/hub")
+	ctx := NewYouTrackContext(context.Background(), "token")
+
+	users, err := client.GetProjectUsers(ctx, "PRJ", 0, 10)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(users) != 2 {
+		t.Fatalf("Expected 2 users, got %d", len(users))
+	}
+	if users[0].AvatarURL != "https://yt.example.com/avatar/1" || users[0].Banned {
+		t.Errorf("Unexpected alice: %+v", users[0])
+	}
+	if !users[1].Banned {
+		t.Errorf("Expected alex to be banned")
+	}
+	if active := ActiveUsers(users); len(active) != 1 || active[0].Login != "alice" {
+		t.Errorf("Expected only alice to be active, got %v", active)
+	}
+
+	tests := []struct {
+		name     string
+		username string
+		expected string
+	}{
+		{name: "active user", username: "alice", expected: "alice"},
+		{name: "banned user skipped", username: "alex", expected: ""},
+		{name: "shared name skips banned", username: "Smith", expected: "alice"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			user, err := client.SuggestUserByProject(ctx, "PRJ", tt.username)
+			if tt.expected == "" {
+				if err == nil {
+					t.Errorf("Expected error, got %+v", user)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if user.Login != tt.expected {
+				t.Errorf("Expected %s, got %s", tt.expected, user.Login)
+			}
+		})
+	}
+}
+
+func TestClient_GetProjectUsers_AssigneeFallback(t *testing.T) {
+	fields := `[
+		{"$type":"EnumProjectCustomField","field":{"name":"Priority"},"bundle":{}},
+		{"$type":"UserProjectCustomField","field":{"name":"Reviewer"},"bundle":{"aggregatedUsers":[{"id":"9","login":"zoe"}]}},
+		{"$type":"UserProjectCustomField","field":{"name":"Assignee"},"bundle":{"aggregatedUsers":[
+			{"id":"1","login":"alice"},{"id":"2","login":"bob"},{"id":"3","login":"carol"}
+		]}}
+	]`
+
+	tests := []struct {
+		name      string
+		hub       bool
+		teamCode  int
+		skip, top int
+		expected  []string
+		expectErr bool
+	}{
+		{name: "no hub URL", skip: 0, top: 10, expected: []string{"alice", "bob", "carol"}},
+		{name: "team forbidden", hub: true, teamCode: http.StatusForbidden, skip: 0, top: 10, expected: []string{"alice", "bob", "carol"}},
+		{name: "paginated", skip: 1, top: 1, expected: []string{"bob"}},
+		{name: "past the end", skip: 5, top: 10, expected: []string{}},
+		{name: "team available", hub: true, teamCode: http.StatusOK, skip: 0, top: 10, expected: []string{"dave"}},
+		{name: "other team error", hub: true, teamCode: http.StatusInternalServerError, skip: 0, top: 10, expectErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				switch r.URL.Path {
+				case "/api/admin/projects/PRJ":
+					fmt.Fprint(w, `{"ringId":"ring-1"}`)
+				case "/api/admin/projects/PRJ/customFields":
+					if !strings.Contains(r.URL.Query().Get("fields"), "aggregatedUsers") {
+						t.Errorf("Expected the bundle users to be requested, got %q", r.URL.Query().Get("fields"))
+					}
+					fmt.Fprint(w, fields)
+				case "/hub/api/rest/projects/ring-1/team/users":
+					w.WriteHeader(tt.teamCode)
+					fmt.Fprint(w, `{"users":[{"id":"4","login":"dave"}]}`)
+				default:
+					http.NotFound(w, r)
+				}
+			}))
+			defer server.Close()
+
+			client := NewClient(server.URL)
+			if tt.hub {
+				client.SetHubURL(server.URL + "/hub")
+			}
+			ctx := NewYouTrackContext(context.Background(), "token")
+
+			users, err := client.GetProjectUsers(ctx, "PRJ", tt.skip, tt.top)
+			if tt.expectErr {
+				if err == nil {
+					t.Errorf("Expected error, got %d users", len(users))
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if len(users) != len(tt.expected) {
+				t.Fatalf("Expected %v, got %d users", tt.expected, len(users))
+			}
+			for i, login := range tt.expected {
+				if users[i].Login != login {
+					t.Errorf("Expected %s at %d, got %s", login, i, users[i].Login)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,53 @@
+package youtrack
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+)
+
+// GetIssueVcsChanges returns the commits linked to an issue, oldest first
+func (c *Client) GetIssueVcsChanges(ctx *YouTrackContext, issueID string) ([]*VcsChange, error) {
+	path := fmt.Sprintf("/api/issues/%s/activities", issueID)
+
+	query := url.Values{}
+	query.Add("categories", "VcsChangeCategory")
+	query.Add("fields", "added(id,version,text,date,userName,urls,files,author(id,login,fullName))")
+
+	resp, err := c.Get(ctx, path, query)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var activities []struct {
+		Added json.RawMessage `json:"added"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&activities); err != nil {
+		return nil, fmt.Errorf("failed to decode VCS changes: %w", err)
+	}
+
+	var changes []*VcsChange
+	for _, activity := range activities {
+		if len(activity.Added) == 0 || string(activity.Added) == "null" {
+			continue
+		}
+
+		// "added" is a list of changes, or a single change for some versions
+		if activity.Added[0] == '[' {
+			var added []*VcsChange
+			if err := json.Unmarshal(activity.Added, &added); err != nil {
+				return nil, fmt.Errorf("failed to decode VCS changes: %w", err)
+			}
+			changes = append(changes, added...)
+		} else {
+			var added VcsChange
+			if err := json.Unmarshal(activity.Added, &added); err != nil {
+				return nil, fmt.Errorf("failed to decode VCS changes: %w", err)
+			}
+			changes = append(changes, &added)
+		}
+	}
+
+	return changes, nil
+}
@@ -0,0 +1,54 @@
+package youtrack
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestClient_GetIssueVcsChanges(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/issues/PROJ-1/activities" {
+			http.NotFound(w, r)
+			return
+		}
+		if r.URL.Query().Get("categories") != "VcsChangeCategory" {
+			t.Errorf("Expected VcsChangeCategory, got %s", r.URL.Query().Get("categories"))
+		}
+		fmt.Fprint(w, `[
+			{"added":[{"id":"1","version":"0123456789abcdef","text":"Fix login","date":1700000000000,"userName":"jdoe","urls":["https://git.example.com/c/0123456"]}]},
+			{"added":{"id":"2","version":"fedcba","text":"Add test","date":1700000001000,"author":{"login":"john","fullName":"John Smith"}}},
+			{"added":null}
+		]`)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL)
+	ctx := NewYouTrackContext(context.Background(), "token")
+
+	changes, err := client.GetIssueVcsChanges(ctx, "PROJ-1")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(changes) != 2 {
+		t.Fatalf("Expected 2 changes, got %d", len(changes))
+	}
+
+	tests := []struct {
+		shortVersion string
+		author       string
+	}{
+		{shortVersion: "01234567", author: "jdoe"},
+		{shortVersion: "fedcba", author: "John Smith"},
+	}
+	for i, tt := range tests {
+		if changes[i].ShortVersion() != tt.shortVersion || changes[i].AuthorName() != tt.author {
+			t.Errorf("Change %d: expected %s by %s, got %s by %s", i, tt.shortVersion, tt.author, changes[i].ShortVersion(), changes[i].AuthorName())
+		}
+	}
+	if len(changes[0].URLs) != 1 || changes[0].Date.Time.IsZero() {
+		t.Errorf("Unexpected change: %+v", changes[0])
+	}
+}
@@ -0,0 +1,185 @@
+package youtrack
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// Features gated by the server version
+const (
+	FeatureReactions = "comment reactions"
+	// FeatureActivityDefaults is listing all issue activities without naming their categories
+	FeatureActivityDefaults = "activities without categories"
+)
+
+// featureVersions are the first YouTrack versions supporting each feature
+var featureVersions = map[string]string{
+	FeatureReactions:        "2022.2",
+	FeatureActivityDefaults: "2023.1",
+}
+
+// RequiredVersion returns the first YouTrack version supporting a feature, empty for unknown features
+func RequiredVersion(feature string) string {
+	return featureVersions[feature]
+}
+
+// activityCategories are the activity categories requested from versions that need them named
+var activityCategories = []string{
+	"IssueCreatedCategory", "IssueResolvedCategory", "SummaryCategory", "DescriptionCategory",
+	"CustomFieldCategory", "CommentsCategory", "LinksCategory", "TagsCategory", "AttachmentsCategory",
+	"ProjectCategory", "VisibilityCategory",
+}
+
+// ServerVersion is the version of a YouTrack instance, as reported by /api/config
+type ServerVersion struct {
+	Version string `json:"version"` // e.g. "2024.2"
+	Build   string `json:"build"`
+}
+
+// Capabilities tell which version-dependent features a YouTrack instance supports.
+// An unknown version (Version empty) supports everything.
+type Capabilities struct {
+	Version           string
+	Reactions         bool
+	ActivityDefaults  bool
+	versionComponents []int
+}
+
+// Supports reports whether a feature, one of the Feature constants, is supported
+func (c *Capabilities) Supports(feature string) bool {
+	required, ok := featureVersions[feature]
+	if !ok || c.versionComponents == nil {
+		return true
+	}
+	return compareVersions(c.versionComponents, parseVersion(required)) >= 0
+}
+
+// UnsupportedError is returned for a feature the YouTrack instance is too old for
+type UnsupportedError struct {
+	Feature  string
+	Version  string
+	Required string
+}
+
+func (e *UnsupportedError) Error() string {
+	return fmt.Sprintf("%s not supported by your YouTrack version (%s, needs %s or later)", e.Feature, e.Version, e.Required)
+}
+
+// IsUnsupported reports whether an error is an UnsupportedError
+func IsUnsupported(err error) bool {
+	var unsupported *UnsupportedError
+	return errors.As(err, &unsupported)
+}
+
+// GetServerVersion returns the version of the YouTrack instance
+func (c *Client) GetServerVersion(ctx *YouTrackContext) (*ServerVersion, error) {
+	query := url.Values{}
+	query.Add("fields", "version,build")
+
+	resp, err := c.Get(ctx, "/api/config", query)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var version ServerVersion
+	if err := json.NewDecoder(resp.Body).Decode(&version); err != nil {
+		return nil, fmt.Errorf("failed to decode server version: %w", err)
+	}
+	return &version, nil
+}
+
+// SetServerVersion sets the server version instead of detecting it, e.g. "2022.3".
+// An empty version makes the client detect it again.
+func (c *Client) SetServerVersion(version string) {
+	c.versionMu.Lock()
+	defer c.versionMu.Unlock()
+
+	if version == "" {
+		c.capabilities = nil
+		return
+	}
+	c.capabilities = newCapabilities(version)
+}
+
+// Capabilities returns the features the YouTrack instance supports. The version is detected
+// on the first call and kept; when it cannot be read, every feature counts as supported.
+func (c *Client) Capabilities(ctx *YouTrackContext) *Capabilities {
+	c.versionMu.Lock()
+	defer c.versionMu.Unlock()
+
+	if c.capabilities != nil {
+		return c.capabilities
+	}
+
+	version, err := c.GetServerVersion(ctx)
+	if err != nil {
+		var apiErr *APIError
+		if errors.As(err, &apiErr) && apiErr.StatusCode != 401 {
+			// The instance answered but does not tell its version, it will not later either
+			c.capabilities = newCapabilities("")
+			return c.capabilities
+		}
+		// Auth and connection problems may pass, try again on the next call
+		return newCapabilities("")
+	}
+	c.capabilities = newCapabilities(version.Version)
+	return c.capabilities
+}
+
+// require returns an UnsupportedError when the instance is too old for a feature
+func (c *Client) require(ctx *YouTrackContext, feature string) error {
+	caps := c.Capabilities(ctx)
+	if caps.Supports(feature) {
+		return nil
+	}
+	return &UnsupportedError{Feature: feature, Version: caps.Version, Required: RequiredVersion(feature)}
+}
+
+// newCapabilities computes the capabilities of a version, an empty one supports everything
+func newCapabilities(version string) *Capabilities {
+	caps := &Capabilities{Version: version, versionComponents: parseVersion(version)}
+	caps.Reactions = caps.Supports(FeatureReactions)
+	caps.ActivityDefaults = caps.Supports(FeatureActivityDefaults)
+	return caps
+}
+
+// parseVersion splits a version like "2024.2.34646" into its numbers, nil when it is not one
+func parseVersion(version string) []int {
+	if version == "" {
+		return nil
+	}
+	var components []int
+	for _, part := range strings.Split(version, ".") {
+		n, err := strconv.Atoi(part)
+		if err != nil {
+			return nil
+		}
+		components = append(components, n)
+	}
+	return components
+}
+
+// compareVersions compares two parsed versions, missing components count as 0
+func compareVersions(a, b []int) int {
+	for i := 0; i < len(a) || i < len(b); i++ {
+		var x, y int
+		if i < len(a) {
+			x = a[i]
+		}
+		if i < len(b) {
+			y = b[i]
+		}
+		if x != y {
+			if x < y {
+				return -1
+			}
+			return 1
+		}
+	}
+	return 0
+}
@@ -0,0 +1,96 @@
+package youtrack
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestClient_Capabilities(t *testing.T) {
+	tests := []struct {
+		name        string
+		config      func(w http.ResponseWriter)
+		version     string
+		reactions   bool
+		categories  bool
+		configCalls int
+	}{
+		{
+			name:        "recent version",
+			config:      func(w http.ResponseWriter) { fmt.Fprint(w, `{"version":"2024.2","build":"34646"}`) },
+			version:     "2024.2",
+			reactions:   true,
+			configCalls: 1,
+		},
+		{
+			name:        "old version",
+			config:      func(w http.ResponseWriter) { fmt.Fprint(w, `{"version":"2021.3","build":"23307"}`) },
+			version:     "2021.3",
+			reactions:   false,
+			categories:  true,
+			configCalls: 1,
+		},
+		{
+			name:        "no config endpoint",
+			config:      func(w http.ResponseWriter) { http.Error(w, "not found", http.StatusNotFound) },
+			reactions:   true,
+			configCalls: 1,
+		},
+		{
+			// Lookups rejected for the key are retried on each use
+			name:        "unauthorized",
+			config:      func(w http.ResponseWriter) { http.Error(w, "unauthorized", http.StatusUnauthorized) },
+			reactions:   true,
+			configCalls: 3,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			configCalls := 0
+			var categories string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				switch r.URL.Path {
+				case "/api/config":
+					configCalls++
+					tt.config(w)
+				case "/api/issues/PROJ-1/activities":
+					categories = r.URL.Query().Get("categories")
+					fmt.Fprint(w, `[]`)
+				default:
+					fmt.Fprint(w, `[]`)
+				}
+			}))
+			defer server.Close()
+
+			client := NewClient(server.URL)
+			ctx := NewYouTrackContext(context.Background(), "token")
+
+			caps := client.Capabilities(ctx)
+			if caps.Version != tt.version || caps.Reactions != tt.reactions {
+				t.Errorf("Expected version %q with reactions %v, got %+v", tt.version, tt.reactions, caps)
+			}
+
+			_, err := client.GetCommentReactions(ctx, "PROJ-1", "4-1")
+			if tt.reactions && err != nil {
+				t.Errorf("Unexpected error: %v", err)
+			}
+			if !tt.reactions && !IsUnsupported(err) {
+				t.Errorf("Expected an unsupported error, got %v", err)
+			}
+
+			if _, err := client.GetIssueActivities(ctx, "PROJ-1"); err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if (categories != "") != tt.categories {
+				t.Errorf("Expected categories %v, got %q", tt.categories, categories)
+			}
+
+			if configCalls != tt.configCalls {
+				t.Errorf("Unexpected number of version lookups: %d", configCalls)
+			}
+		})
+	}
+}
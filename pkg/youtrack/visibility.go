@@ -0,0 +1,65 @@
+package youtrack
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ResolveVisibility builds a limited visibility from group names (or IDs) and user logins.
+// Returns nil when both lists are empty.
+func (c *Client) ResolveVisibility(ctx *YouTrackContext, groupNames, userLogins []string) (*Visibility, error) {
+	if len(groupNames) == 0 && len(userLogins) == 0 {
+		return nil, nil
+	}
+
+	var groups []*UserGroup
+	if len(groupNames) > 0 {
+		allGroups, err := c.ListGroups(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list groups: %w", err)
+		}
+
+		for _, name := range groupNames {
+			group := findGroup(allGroups, name)
+			if group == nil {
+				return nil, fmt.Errorf("group '%s' not found", name)
+			}
+			// Only the reference is sent, not the Hub details
+			groups = append(groups, &UserGroup{ID: group.ID, Name: group.Name})
+		}
+	}
+
+	var users []*User
+	for _, login := range userLogins {
+		user, err := c.GetUserByLogin(ctx, login)
+		if err != nil {
+			return nil, err
+		}
+		users = append(users, user)
+	}
+
+	return NewLimitedVisibility(groups, users), nil
+}
+
+// ParseVisibility parses a comma-separated visibility spec such as "Developers, user:john".
+// Entries are group names unless prefixed with "user:" (or "group:" for clarity).
+// The spec "public" (or "everyone") requests unlimited visibility.
+func ParseVisibility(spec string) (groups, users []string, public bool) {
+	spec = strings.TrimSpace(spec)
+	if strings.EqualFold(spec, "public") || strings.EqualFold(spec, "everyone") {
+		return nil, nil, true
+	}
+
+	for _, entry := range strings.Split(spec, ",") {
+		entry = strings.TrimSpace(entry)
+		switch {
+		case entry == "":
+			continue
+		case strings.HasPrefix(entry, "user:"):
+			users = append(users, strings.TrimSpace(strings.TrimPrefix(entry, "user:")))
+		default:
+			groups = append(groups, strings.TrimSpace(strings.TrimPrefix(entry, "group:")))
+		}
+	}
+	return groups, users, false
+}
@@ -0,0 +1,66 @@
+package youtrack
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+func TestParseVisibility(t *testing.T) {
+	tests := []struct {
+		name   string
+		spec   string
+		groups []string
+		users  []string
+		public bool
+	}{
+		{name: "Groups", spec: "Developers, QA", groups: []string{"Developers", "QA"}},
+		{name: "Groups and users", spec: "group:Developers,user:john", groups: []string{"Developers"}, users: []string{"john"}},
+		{name: "Public", spec: "Public", public: true},
+		{name: "Empty", spec: " , "},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			groups, users, public := ParseVisibility(tt.spec)
+			if !reflect.DeepEqual(groups, tt.groups) || !reflect.DeepEqual(users, tt.users) || public != tt.public {
+				t.Errorf("Expected %v %v %v, got %v %v %v", tt.groups, tt.users, tt.public, groups, users, public)
+			}
+		})
+	}
+}
+
+func TestClient_ResolveVisibility(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/groups":
+			fmt.Fprint(w, `[{"id":"3-1","name":"All Users"},{"id":"3-2","name":"Developers"}]`)
+		case "/api/users":
+			fmt.Fprint(w, `[{"id":"1-5","login":"john","fullName":"John Smith"}]`)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL)
+	ctx := NewYouTrackContext(context.Background(), "token")
+
+	visibility, err := client.ResolveVisibility(ctx, []string{"developers"}, []string{"john"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !visibility.IsLimited() || visibility.PermittedGroups[0].ID != "3-2" || visibility.PermittedUsers[0].ID != "1-5" {
+		t.Errorf("Unexpected visibility: %+v", visibility)
+	}
+	if visibility.String() != "Developers, john" {
+		t.Errorf("Expected 'Developers, john', got %q", visibility.String())
+	}
+
+	if _, err := client.ResolveVisibility(ctx, []string{"Managers"}, nil); err == nil {
+		t.Error("Expected error for unknown group")
+	}
+}
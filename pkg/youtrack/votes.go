@@ -0,0 +1,72 @@
+package youtrack
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+)
+
+// GetIssueVoters returns the vote count of an issue and the users who voted for it
+// or for one of its duplicates
+func (c *Client) GetIssueVoters(ctx *YouTrackContext, issueID string) (*IssueVoters, error) {
+	path := fmt.Sprintf("/api/issues/%s", issueID)
+
+	query := url.Values{}
+	query.Add("fields", "votes,voters(hasVote,original(id,login,fullName),duplicate(user(id,login,fullName),issue(idReadable)))")
+
+	resp, err := c.Get(ctx, path, query)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var result struct {
+		Votes  int `json:"votes"`
+		Voters struct {
+			HasVote   bool    `json:"hasVote"`
+			Original  []*User `json:"original"`
+			Duplicate []struct {
+				User  *User `json:"user"`
+				Issue struct {
+					ID string `json:"idReadable"`
+				} `json:"issue"`
+			} `json:"duplicate"`
+		} `json:"voters"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("failed to decode voters: %w", err)
+	}
+
+	voters := &IssueVoters{
+		Votes:   result.Votes,
+		HasVote: result.Voters.HasVote,
+		Voters:  result.Voters.Original,
+	}
+	for _, vote := range result.Voters.Duplicate {
+		voters.DuplicateVotes = append(voters.DuplicateVotes, &DuplicateVote{IssueID: vote.Issue.ID, User: vote.User})
+	}
+
+	return voters, nil
+}
+
+// VoteIssue adds the vote of the current user to an issue
+func (c *Client) VoteIssue(ctx *YouTrackContext, issueID string) error {
+	return c.setVote(ctx, issueID, true)
+}
+
+// UnvoteIssue removes the vote of the current user from an issue
+func (c *Client) UnvoteIssue(ctx *YouTrackContext, issueID string) error {
+	return c.setVote(ctx, issueID, false)
+}
+
+func (c *Client) setVote(ctx *YouTrackContext, issueID string, hasVote bool) error {
+	path := fmt.Sprintf("/api/issues/%s/voters", issueID)
+
+	resp, err := c.Post(ctx, path, map[string]bool{"hasVote": hasVote})
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	return nil
+}
@@ -0,0 +1,75 @@
+package youtrack
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestClient_GetIssueVoters(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/issues/PROJ-1" {
+			http.NotFound(w, r)
+			return
+		}
+		fmt.Fprint(w, `{"votes":3,"voters":{"hasVote":true,
+			"original":[{"id":"1-1","login":"john","fullName":"John Smith"},{"id":"1-2","login":"jane"}],
+			"duplicate":[{"user":{"id":"1-3","login":"bob"},"issue":{"idReadable":"PROJ-7"}}]}}`)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL)
+	ctx := NewYouTrackContext(context.Background(), "token")
+
+	voters, err := client.GetIssueVoters(ctx, "PROJ-1")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if voters.Votes != 3 || !voters.HasVote {
+		t.Errorf("Expected 3 votes including mine, got %d (hasVote %v)", voters.Votes, voters.HasVote)
+	}
+	if len(voters.Voters) != 2 || voters.Voters[0].Login != "john" {
+		t.Errorf("Unexpected voters: %+v", voters.Voters)
+	}
+	if len(voters.DuplicateVotes) != 1 || voters.DuplicateVotes[0].IssueID != "PROJ-7" || voters.DuplicateVotes[0].User.Login != "bob" {
+		t.Errorf("Unexpected duplicate votes: %+v", voters.DuplicateVotes)
+	}
+}
+
+func TestClient_VoteIssue(t *testing.T) {
+	tests := []struct {
+		name     string
+		vote     func(c *Client, ctx *YouTrackContext) error
+		expected bool
+	}{
+		{name: "Vote", vote: func(c *Client, ctx *YouTrackContext) error { return c.VoteIssue(ctx, "PROJ-1") }, expected: true},
+		{name: "Unvote", vote: func(c *Client, ctx *YouTrackContext) error { return c.UnvoteIssue(ctx, "PROJ-1") }, expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var body map[string]bool
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Method != http.MethodPost || r.URL.Path != "/api/issues/PROJ-1/voters" {
+					t.Errorf("Unexpected request %s %s", r.Method, r.URL.Path)
+				}
+				json.NewDecoder(r.Body).Decode(&body)
+				fmt.Fprint(w, `{}`)
+			}))
+			defer server.Close()
+
+			client := NewClient(server.URL)
+			ctx := NewYouTrackContext(context.Background(), "token")
+
+			if err := tt.vote(client, ctx); err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if hasVote, ok := body["hasVote"]; !ok || hasVote != tt.expected {
+				t.Errorf("Expected hasVote %v, got %v", tt.expected, body)
+			}
+		})
+	}
+}
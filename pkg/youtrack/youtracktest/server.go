@@ -0,0 +1,822 @@
+// Package youtracktest provides an in-memory fake YouTrack server for tests.
+//
+// The fake serves the REST endpoints pkg/youtrack uses for issues, comments, users,
+// projects and worklogs, so code built on the client can be tested without a live
+// instance:
+//
+//	srv := youtracktest.NewServer()
+//	defer srv.Close()
+//	srv.AddProject("PRJ", "Project")
+//	id := srv.AddIssue("PRJ", "Login fails", "")
+//
+//	client := srv.Client()
+//	issue, err := client.GetIssue(srv.Context(context.Background()), id)
+//
+// Search supports a small query subset: "project: X" (or "project:{X}"), #Unresolved,
+// #Resolved and free text matched against summaries and descriptions; "sort by:" is
+// ignored. Other endpoints answer 404.
+package youtracktest
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/mkozhukh/youtrack/pkg/youtrack"
+)
+
+// Token is the API key accepted by the fake unless RequireToken sets another one
+const Token = "perm:youtracktest"
+
+// Version is the YouTrack version the fake reports
+const Version = "2024.3"
+
+// ResolvedStates are the State values that mark an issue resolved
+var ResolvedStates = []string{"Fixed", "Done", "Verified", "Won't fix", "Duplicate", "Obsolete"}
+
+// Server is an in-memory fake YouTrack instance
+type Server struct {
+	*httptest.Server
+
+	// Now returns the time recorded on created and updated entities, time.Now by default
+	Now func() time.Time
+
+	mu       sync.Mutex
+	token    string
+	me       *youtrack.User
+	users    []*youtrack.User
+	projects []*youtrack.Project
+	issues   []*issue
+	lastID   int
+}
+
+// issue is the stored state of an issue
+type issue struct {
+	id          string // database ID, e.g. "2-1"
+	readableID  string // e.g. "PRJ-1"
+	project     *youtrack.Project
+	summary     string
+	description string
+	created     time.Time
+	updated     time.Time
+	resolved    *time.Time
+	reporter    *youtrack.User
+	assignee    *youtrack.User
+	state       string
+	comments    []*youtrack.IssueComment
+	worklogs    []*youtrack.WorkItem
+}
+
+// NewServer starts a fake server with a current user "admin" and no projects
+func NewServer() *Server {
+	s := &Server{Now: time.Now, token: Token}
+	s.me = s.AddUser("admin", "Admin")
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/config", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, youtrack.ServerVersion{Version: Version, Build: "1"})
+	})
+	mux.HandleFunc("GET /api/users/me", s.getMe)
+	mux.HandleFunc("GET /api/users/{id}", s.getUser)
+	mux.HandleFunc("GET /api/users", s.searchUsers)
+	mux.HandleFunc("GET /api/admin/projects", s.listProjects)
+	mux.HandleFunc("GET /api/admin/projects/{id}", s.getProject)
+	mux.HandleFunc("GET /api/issues", s.searchIssues)
+	mux.HandleFunc("POST /api/issues", s.createIssue)
+	mux.HandleFunc("GET /api/issues/{id}", s.getIssue)
+	mux.HandleFunc("POST /api/issues/{id}", s.updateIssue)
+	mux.HandleFunc("DELETE /api/issues/{id}", s.deleteIssue)
+	mux.HandleFunc("GET /api/issues/{id}/comments", s.listComments)
+	mux.HandleFunc("POST /api/issues/{id}/comments", s.addComment)
+	mux.HandleFunc("POST /api/issues/{id}/comments/{commentID}", s.updateComment)
+	mux.HandleFunc("DELETE /api/issues/{id}/comments/{commentID}", s.deleteComment)
+	mux.HandleFunc("GET /api/issues/{id}/timeTracking/workItems", s.listWorklogs)
+	mux.HandleFunc("POST /api/issues/{id}/timeTracking/workItems", s.addWorklog)
+	mux.HandleFunc("GET /api/workItems", s.searchWorklogs)
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		writeError(w, http.StatusNotFound, "youtracktest: "+r.Method+" "+r.URL.Path+" is not implemented")
+	})
+
+	s.Server = httptest.NewServer(s.authenticate(mux))
+	return s
+}
+
+// Client returns a client for the fake server
+func (s *Server) Client() *youtrack.Client {
+	return youtrack.NewClient(s.URL)
+}
+
+// Context returns a context carrying the accepted API key
+func (s *Server) Context(ctx context.Context) *youtrack.YouTrackContext {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return youtrack.NewYouTrackContext(ctx, s.token)
+}
+
+// RequireToken changes the accepted API key, requests with another one get 401
+func (s *Server) RequireToken(token string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.token = token
+}
+
+// CurrentUser returns the user the API key belongs to
+func (s *Server) CurrentUser() *youtrack.User {
+	return s.me
+}
+
+// AddUser adds a user and returns it
+func (s *Server) AddUser(login, fullName string) *youtrack.User {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	user := &youtrack.User{ID: s.nextID("1"), Login: login, FullName: fullName, Email: login + "@example.com"}
+	s.users = append(s.users, user)
+	return user
+}
+
+// AddProject adds a project and returns it
+func (s *Server) AddProject(shortName, name string) *youtrack.Project {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	project := &youtrack.Project{ID: s.nextID("0"), ShortName: shortName, Name: name}
+	s.projects = append(s.projects, project)
+	return project
+}
+
+// AddIssue adds an issue reported by the current user to a project and returns its readable ID.
+// It panics when the project does not exist, as a test setup error.
+func (s *Server) AddIssue(projectID, summary, description string) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	project := s.findProject(projectID)
+	if project == nil {
+		panic("youtracktest: unknown project " + projectID)
+	}
+	return s.newIssue(project, summary, description).readableID
+}
+
+// SetState sets the State of an issue, resolving it for one of the ResolvedStates
+func (s *Server) SetState(issueID, state string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if is := s.findIssue(issueID); is != nil {
+		s.setState(is, state)
+	}
+}
+
+// SetAssignee assigns an issue to a user by login, or unassigns it with an empty login
+func (s *Server) SetAssignee(issueID, login string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if is := s.findIssue(issueID); is != nil {
+		is.assignee = s.findUser(login)
+	}
+}
+
+// AddComment adds a comment to an issue by a user login, the current user when empty
+func (s *Server) AddComment(issueID, login, text string) *youtrack.IssueComment {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	is := s.findIssue(issueID)
+	if is == nil {
+		return nil
+	}
+	author := s.me
+	if login != "" {
+		author = s.findUser(login)
+	}
+	return s.newComment(is, author, text)
+}
+
+// Issue returns the current state of an issue, as the client would get it
+func (s *Server) Issue(issueID string) (*youtrack.Issue, bool) {
+	s.mu.Lock()
+	is := s.findIssue(issueID)
+	if is == nil {
+		s.mu.Unlock()
+		return nil, false
+	}
+	data, _ := json.Marshal(issueJSON(is))
+	s.mu.Unlock()
+
+	var result youtrack.Issue
+	if err := json.Unmarshal(data, &result); err != nil {
+		return nil, false
+	}
+	return &result, true
+}
+
+// Comments returns the comments of an issue
+func (s *Server) Comments(issueID string) []*youtrack.IssueComment {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if is := s.findIssue(issueID); is != nil {
+		return append([]*youtrack.IssueComment(nil), is.comments...)
+	}
+	return nil
+}
+
+// Worklogs returns the work items of an issue
+func (s *Server) Worklogs(issueID string) []*youtrack.WorkItem {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if is := s.findIssue(issueID); is != nil {
+		return append([]*youtrack.WorkItem(nil), is.worklogs...)
+	}
+	return nil
+}
+
+// nextID returns a new database ID with the type prefix, e.g. "2-7"; s.mu must be held
+func (s *Server) nextID(prefix string) string {
+	s.lastID++
+	return fmt.Sprintf("%s-%d", prefix, s.lastID)
+}
+
+// findUser finds a user by ID or login; s.mu must be held
+func (s *Server) findUser(id string) *youtrack.User {
+	for _, user := range s.users {
+		if user.ID == id || user.Login == id {
+			return user
+		}
+	}
+	return nil
+}
+
+// findProject finds a project by ID or short name; s.mu must be held
+func (s *Server) findProject(id string) *youtrack.Project {
+	for _, project := range s.projects {
+		if project.ID == id || strings.EqualFold(project.ShortName, id) {
+			return project
+		}
+	}
+	return nil
+}
+
+// findIssue finds an issue by database or readable ID; s.mu must be held
+func (s *Server) findIssue(id string) *issue {
+	for _, is := range s.issues {
+		if is.id == id || strings.EqualFold(is.readableID, id) {
+			return is
+		}
+	}
+	return nil
+}
+
+// newIssue stores a new issue; s.mu must be held
+func (s *Server) newIssue(project *youtrack.Project, summary, description string) *issue {
+	number := 1
+	for _, is := range s.issues {
+		if is.project == project {
+			number++
+		}
+	}
+
+	now := s.Now()
+	is := &issue{
+		id:          s.nextID("2"),
+		readableID:  fmt.Sprintf("%s-%d", project.ShortName, number),
+		project:     project,
+		summary:     summary,
+		description: description,
+		created:     now,
+		updated:     now,
+		reporter:    s.me,
+		state:       "Open",
+	}
+	s.issues = append(s.issues, is)
+	return is
+}
+
+// setState sets the state of an issue and its resolved time; s.mu must be held
+func (s *Server) setState(is *issue, state string) {
+	is.state = state
+	is.resolved = nil
+	for _, resolved := range ResolvedStates {
+		if strings.EqualFold(resolved, state) {
+			now := s.Now()
+			is.resolved = &now
+		}
+	}
+}
+
+// newComment stores a new comment; s.mu must be held
+func (s *Server) newComment(is *issue, author *youtrack.User, text string) *youtrack.IssueComment {
+	now := s.Now()
+	comment := &youtrack.IssueComment{
+		ID:      s.nextID("4"),
+		Author:  author,
+		Text:    text,
+		Created: youtrack.YouTrackTime{Time: now},
+		Updated: youtrack.YouTrackTime{Time: now},
+	}
+	is.comments = append(is.comments, comment)
+	is.updated = now
+	return comment
+}
+
+// authenticate rejects requests without the accepted API key
+func (s *Server) authenticate(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		token := s.token
+		s.mu.Unlock()
+
+		if r.Header.Get("Authorization") != "Bearer "+token {
+			writeError(w, http.StatusUnauthorized, "Unauthorized")
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+func (s *Server) getMe(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, s.me)
+}
+
+func (s *Server) getUser(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	user := s.findUser(r.PathValue("id"))
+	if user == nil {
+		writeError(w, http.StatusNotFound, "Entity with id "+r.PathValue("id")+" not found")
+		return
+	}
+	writeJSON(w, user)
+}
+
+func (s *Server) searchUsers(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	query := strings.ToLower(r.URL.Query().Get("query"))
+	var users []*youtrack.User
+	for _, user := range s.users {
+		if login, ok := strings.CutPrefix(query, "login:"); ok {
+			if strings.EqualFold(user.Login, login) {
+				users = append(users, user)
+			}
+			continue
+		}
+		if strings.Contains(strings.ToLower(user.Login+" "+user.FullName+" "+user.Email), query) {
+			users = append(users, user)
+		}
+	}
+	writeJSON(w, page(users, r))
+}
+
+func (s *Server) listProjects(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	writeJSON(w, page(s.projects, r))
+}
+
+func (s *Server) getProject(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	project := s.findProject(r.PathValue("id"))
+	if project == nil {
+		writeError(w, http.StatusNotFound, "Entity with id "+r.PathValue("id")+" not found")
+		return
+	}
+	writeJSON(w, project)
+}
+
+func (s *Server) searchIssues(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	match := parseQuery(r.URL.Query().Get("query"))
+	var issues []map[string]interface{}
+	for _, is := range s.issues {
+		if match(is) {
+			issues = append(issues, issueJSON(is))
+		}
+	}
+	writeJSON(w, page(issues, r))
+}
+
+func (s *Server) createIssue(w http.ResponseWriter, r *http.Request) {
+	var req youtrack.CreateIssueRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	project := s.findProject(req.Project.ID)
+	if project == nil {
+		writeError(w, http.StatusBadRequest, "Project "+req.Project.ID+" not found")
+		return
+	}
+	if strings.TrimSpace(req.Summary) == "" {
+		writeError(w, http.StatusBadRequest, "Summary is required")
+		return
+	}
+
+	is := s.newIssue(project, req.Summary, req.Description)
+	if err := s.applyFields(is, req.Fields); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	writeJSON(w, issueJSON(is))
+}
+
+func (s *Server) getIssue(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	is := s.issueOr404(w, r)
+	if is == nil {
+		return
+	}
+	writeJSON(w, issueJSON(is))
+}
+
+func (s *Server) updateIssue(w http.ResponseWriter, r *http.Request) {
+	var req youtrack.UpdateIssueRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	is := s.issueOr404(w, r)
+	if is == nil {
+		return
+	}
+	if req.Summary != nil {
+		is.summary = *req.Summary
+	}
+	if req.Description != nil {
+		is.description = *req.Description
+	}
+	if err := s.applyFields(is, req.Fields); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	is.updated = s.Now()
+	writeJSON(w, issueJSON(is))
+}
+
+func (s *Server) deleteIssue(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	is := s.issueOr404(w, r)
+	if is == nil {
+		return
+	}
+	for i, stored := range s.issues {
+		if stored == is {
+			s.issues = append(s.issues[:i], s.issues[i+1:]...)
+			break
+		}
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+func (s *Server) listComments(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	is := s.issueOr404(w, r)
+	if is == nil {
+		return
+	}
+	writeJSON(w, is.comments)
+}
+
+func (s *Server) addComment(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		Text    string               `json:"text"`
+		ReplyTo *youtrack.CommentRef `json:"replyTo"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	is := s.issueOr404(w, r)
+	if is == nil {
+		return
+	}
+	if req.ReplyTo != nil && !hasComment(is, req.ReplyTo.ID) {
+		writeError(w, http.StatusBadRequest, "Comment "+req.ReplyTo.ID+" not found")
+		return
+	}
+	comment := s.newComment(is, s.me, req.Text)
+	comment.ReplyTo = req.ReplyTo
+	writeJSON(w, comment)
+}
+
+func (s *Server) updateComment(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		Text string `json:"text"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	is := s.issueOr404(w, r)
+	if is == nil {
+		return
+	}
+	for _, comment := range is.comments {
+		if comment.ID == r.PathValue("commentID") {
+			comment.Text = req.Text
+			comment.Updated = youtrack.YouTrackTime{Time: s.Now()}
+			writeJSON(w, comment)
+			return
+		}
+	}
+	writeError(w, http.StatusNotFound, "Comment "+r.PathValue("commentID")+" not found")
+}
+
+func (s *Server) deleteComment(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	is := s.issueOr404(w, r)
+	if is == nil {
+		return
+	}
+	for i, comment := range is.comments {
+		if comment.ID == r.PathValue("commentID") {
+			is.comments = append(is.comments[:i], is.comments[i+1:]...)
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+	}
+	writeError(w, http.StatusNotFound, "Comment "+r.PathValue("commentID")+" not found")
+}
+
+func (s *Server) listWorklogs(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	is := s.issueOr404(w, r)
+	if is == nil {
+		return
+	}
+	writeJSON(w, is.worklogs)
+}
+
+func (s *Server) addWorklog(w http.ResponseWriter, r *http.Request) {
+	var req youtrack.CreateWorklogRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	is := s.issueOr404(w, r)
+	if is == nil {
+		return
+	}
+	if req.Duration.Minutes <= 0 {
+		writeError(w, http.StatusBadRequest, "Duration must be positive")
+		return
+	}
+
+	date := s.Now()
+	if req.Date != nil {
+		date = time.UnixMilli(*req.Date)
+	}
+	item := &youtrack.WorkItem{
+		ID:          s.nextID("5"),
+		Author:      s.me,
+		Date:        youtrack.YouTrackTime{Time: date},
+		Duration:    youtrack.DurationValue{Minutes: req.Duration.Minutes, Presentation: presentation(req.Duration.Minutes)},
+		Description: req.Description,
+		Issue:       &youtrack.Issue{ID: is.readableID, Summary: is.summary},
+	}
+	if req.Type != nil {
+		item.Type = &youtrack.WorkType{ID: req.Type.Name, Name: req.Type.Name}
+	}
+	is.worklogs = append(is.worklogs, item)
+	writeJSON(w, item)
+}
+
+func (s *Server) searchWorklogs(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	params := r.URL.Query()
+	match := parseQuery(params.Get("query"))
+	start, _ := time.Parse("2006-01-02", params.Get("startDate"))
+	end, endErr := time.Parse("2006-01-02", params.Get("endDate"))
+
+	var items []*youtrack.WorkItem
+	for _, is := range s.issues {
+		if !match(is) {
+			continue
+		}
+		for _, item := range is.worklogs {
+			if author := params.Get("author"); author != "" && item.Author.ID != author && item.Author.Login != author {
+				continue
+			}
+			if item.Date.Before(start) || endErr == nil && !item.Date.Before(end.AddDate(0, 0, 1)) {
+				continue
+			}
+			items = append(items, item)
+		}
+	}
+	sort.SliceStable(items, func(i, j int) bool { return items[i].Date.Before(items[j].Date.Time) })
+	writeJSON(w, page(items, r))
+}
+
+// issueOr404 finds the issue of the request path or answers 404; s.mu must be held
+// hasComment reports whether an issue has a comment; s.mu must be held
+func hasComment(is *issue, commentID string) bool {
+	for _, comment := range is.comments {
+		if comment.ID == commentID {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *Server) issueOr404(w http.ResponseWriter, r *http.Request) *issue {
+	is := s.findIssue(r.PathValue("id"))
+	if is == nil {
+		writeError(w, http.StatusNotFound, "Issue "+r.PathValue("id")+" not found")
+	}
+	return is
+}
+
+// applyFields applies the Assignee and State custom fields of a request; s.mu must be held
+func (s *Server) applyFields(is *issue, fields []youtrack.CustomField) error {
+	for _, field := range fields {
+		data, _ := json.Marshal(field.Value)
+		var value struct {
+			ID    string `json:"id"`
+			Login string `json:"login"`
+			Name  string `json:"name"`
+		}
+		json.Unmarshal(data, &value)
+
+		switch field.Name {
+		case "Assignee":
+			if field.Value == nil {
+				is.assignee = nil
+				continue
+			}
+			user := s.findUser(value.Login)
+			if user == nil {
+				user = s.findUser(value.ID)
+			}
+			if user == nil {
+				return fmt.Errorf("user %s%s not found", value.Login, value.ID)
+			}
+			is.assignee = user
+		case "State":
+			s.setState(is, value.Name)
+		}
+	}
+	return nil
+}
+
+// issueJSON renders an issue the way YouTrack does, with Assignee and State as custom fields
+func issueJSON(is *issue) map[string]interface{} {
+	var assignee interface{}
+	if is.assignee != nil {
+		assignee = is.assignee
+	}
+	data := map[string]interface{}{
+		"id":          is.id,
+		"idReadable":  is.readableID,
+		"summary":     is.summary,
+		"description": is.description,
+		"created":     is.created.UnixMilli(),
+		"updated":     is.updated.UnixMilli(),
+		"reporter":    is.reporter,
+		"project":     is.project,
+		"customFields": []map[string]interface{}{
+			{"name": "Assignee", "$type": "SingleUserIssueCustomField", "value": assignee},
+			{"name": "State", "$type": "StateIssueCustomField", "value": map[string]string{"name": is.state}},
+		},
+	}
+	if is.resolved != nil {
+		data["resolved"] = is.resolved.UnixMilli()
+	}
+	return data
+}
+
+// parseQuery returns a matcher for the supported query subset
+func parseQuery(query string) func(*issue) bool {
+	if i := strings.Index(strings.ToLower(query), "sort by:"); i >= 0 {
+		query = query[:i]
+	}
+
+	var project, text string
+	var resolved *bool
+	fields := strings.Fields(query)
+	for i := 0; i < len(fields); i++ {
+		field := fields[i]
+		switch lower := strings.ToLower(field); {
+		case strings.HasPrefix(lower, "project:"):
+			project = field[len("project:"):]
+			if project == "" && i+1 < len(fields) {
+				i++
+				project = fields[i]
+			}
+			project = strings.Trim(project, "{}")
+		case lower == "#unresolved":
+			value := false
+			resolved = &value
+		case lower == "#resolved":
+			value := true
+			resolved = &value
+		default:
+			text = strings.TrimSpace(text + " " + field)
+		}
+	}
+
+	return func(is *issue) bool {
+		if project != "" && !strings.EqualFold(is.project.ShortName, project) && !strings.EqualFold(is.project.Name, project) {
+			return false
+		}
+		if resolved != nil && (is.resolved != nil) != *resolved {
+			return false
+		}
+		if text != "" && !strings.Contains(strings.ToLower(is.summary+" "+is.description), strings.ToLower(text)) {
+			return false
+		}
+		return true
+	}
+}
+
+// page applies the $skip and $top parameters of a request to a list
+func page[T any](items []T, r *http.Request) []T {
+	skip, _ := strconv.Atoi(r.URL.Query().Get("$skip"))
+	top, err := strconv.Atoi(r.URL.Query().Get("$top"))
+	if skip > len(items) {
+		skip = len(items)
+	}
+	items = items[skip:]
+	if err == nil && top >= 0 && top < len(items) {
+		items = items[:top]
+	}
+	if items == nil {
+		items = []T{}
+	}
+	return items
+}
+
+// presentation formats minutes the way YouTrack presents durations, e.g. "1h 30m"
+func presentation(minutes int) string {
+	switch {
+	case minutes < 60:
+		return fmt.Sprintf("%dm", minutes)
+	case minutes%60 == 0:
+		return fmt.Sprintf("%dh", minutes/60)
+	default:
+		return fmt.Sprintf("%dh %dm", minutes/60, minutes%60)
+	}
+}
+
+// writeJSON writes a JSON response
+func writeJSON(w http.ResponseWriter, data interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(data)
+}
+
+// writeError writes an error response in the YouTrack format
+func writeError(w http.ResponseWriter, status int, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(map[string]string{
+		"error":             http.StatusText(status),
+		"error_description": message,
+	})
+}
@@ -0,0 +1,187 @@
+package youtracktest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/mkozhukh/youtrack/pkg/youtrack"
+)
+
+func newTestServer(t *testing.T) (*Server, *youtrack.Client, *youtrack.YouTrackContext) {
+	t.Helper()
+	srv := NewServer()
+	t.Cleanup(srv.Close)
+	srv.Now = func() time.Time { return time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC) }
+	srv.AddProject("PRJ", "Project")
+	srv.AddUser("jdoe", "John Doe")
+	return srv, srv.Client(), srv.Context(context.Background())
+}
+
+func TestServer_Issues(t *testing.T) {
+	srv, client, ctx := newTestServer(t)
+
+	created, err := client.CreateIssue(ctx, &youtrack.CreateIssueRequest{
+		Project: youtrack.ProjectRef{ID: "PRJ"},
+		Summary: "Login fails",
+	})
+	if err != nil {
+		t.Fatalf("CreateIssue failed: %v", err)
+	}
+	if created.ID != "PRJ-1" {
+		t.Fatalf("expected PRJ-1, got %s", created.ID)
+	}
+	srv.AddIssue("PRJ", "Export is slow", "takes minutes")
+
+	if _, err := client.UpdateIssueAssignee(ctx, "PRJ-1", "jdoe"); err != nil {
+		t.Fatalf("UpdateIssueAssignee failed: %v", err)
+	}
+	srv.SetState("PRJ-2", "Fixed")
+
+	tests := []struct {
+		name     string
+		query    string
+		expected []string
+	}{
+		{name: "project", query: "project: PRJ", expected: []string{"PRJ-1", "PRJ-2"}},
+		{name: "braced project", query: "project:{PRJ} sort by: updated desc", expected: []string{"PRJ-1", "PRJ-2"}},
+		{name: "unresolved", query: "project: PRJ #Unresolved", expected: []string{"PRJ-1"}},
+		{name: "resolved", query: "#Resolved", expected: []string{"PRJ-2"}},
+		{name: "text", query: "minutes", expected: []string{"PRJ-2"}},
+		{name: "other project", query: "project: OTHER", expected: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			issues, err := client.SearchIssues(ctx, tt.query, 0, 10)
+			if err != nil {
+				t.Fatalf("SearchIssues failed: %v", err)
+			}
+			if len(issues) != len(tt.expected) {
+				t.Fatalf("expected %d issues, got %d", len(tt.expected), len(issues))
+			}
+			for i, id := range tt.expected {
+				if issues[i].ID != id {
+					t.Errorf("expected issue %d to be %s, got %s", i, id, issues[i].ID)
+				}
+			}
+		})
+	}
+
+	issue, err := client.GetIssue(ctx, "PRJ-1")
+	if err != nil {
+		t.Fatalf("GetIssue failed: %v", err)
+	}
+	if issue.Assignee == nil || issue.Assignee.Login != "jdoe" || issue.State != "Open" {
+		t.Errorf("unexpected issue: assignee %v, state %s", issue.Assignee, issue.State)
+	}
+	if resolved, _ := srv.Issue("PRJ-2"); resolved.Resolved == nil || resolved.State != "Fixed" {
+		t.Errorf("expected PRJ-2 to be resolved as Fixed")
+	}
+
+	if err := client.DeleteIssue(ctx, "PRJ-1"); err != nil {
+		t.Fatalf("DeleteIssue failed: %v", err)
+	}
+	_, err = client.GetIssue(ctx, "PRJ-1")
+	if apiErr, ok := err.(*youtrack.APIError); !ok || apiErr.StatusCode != 404 {
+		t.Errorf("expected 404 for a deleted issue, got %v", err)
+	}
+}
+
+func TestServer_CommentsAndWorklogs(t *testing.T) {
+	srv, client, ctx := newTestServer(t)
+	id := srv.AddIssue("PRJ", "Login fails", "")
+	srv.AddComment(id, "jdoe", "Reproduced")
+
+	comment, err := client.AddIssueComment(ctx, id, "Looking into it")
+	if err != nil {
+		t.Fatalf("AddIssueComment failed: %v", err)
+	}
+	if _, err := client.UpdateIssueComment(ctx, id, comment.ID, "Fixed in main"); err != nil {
+		t.Fatalf("UpdateIssueComment failed: %v", err)
+	}
+	comments, err := client.GetIssueComments(ctx, id)
+	if err != nil {
+		t.Fatalf("GetIssueComments failed: %v", err)
+	}
+	if len(comments) != 2 || comments[0].Author.Login != "jdoe" || comments[1].Text != "Fixed in main" {
+		t.Errorf("unexpected comments: %+v", comments)
+	}
+
+	reply, err := client.ReplyToIssueComment(ctx, id, comments[0].ID, "Same here")
+	if err != nil {
+		t.Fatalf("ReplyToIssueComment failed: %v", err)
+	}
+	if reply.ReplyTo == nil || reply.ReplyTo.ID != comments[0].ID {
+		t.Errorf("expected a reply to %s, got %+v", comments[0].ID, reply.ReplyTo)
+	}
+	if _, err := client.ReplyToIssueComment(ctx, id, "4-999", "Lost"); err == nil {
+		t.Error("expected an error replying to a missing comment")
+	}
+
+	date := time.Date(2024, 2, 28, 0, 0, 0, 0, time.UTC).UnixMilli()
+	item, err := client.AddIssueWorklog(ctx, id, &youtrack.CreateWorklogRequest{
+		Duration:    youtrack.DurationValue{Minutes: 90},
+		Description: "debugging",
+		Date:        &date,
+	})
+	if err != nil {
+		t.Fatalf("AddIssueWorklog failed: %v", err)
+	}
+	if item.Duration.Presentation != "1h 30m" {
+		t.Errorf("expected 1h 30m, got %s", item.Duration.Presentation)
+	}
+
+	me := srv.CurrentUser()
+	tests := []struct {
+		name      string
+		startDate string
+		endDate   string
+		expected  int
+	}{
+		{name: "in range", startDate: "2024-02-28", endDate: "2024-02-28", expected: 1},
+		{name: "before", startDate: "2024-02-01", endDate: "2024-02-27", expected: 0},
+		{name: "after", startDate: "2024-02-29", endDate: "", expected: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			items, err := client.GetUserWorklogs(ctx, me.ID, "PRJ", tt.startDate, tt.endDate, 0, 10)
+			if err != nil {
+				t.Fatalf("GetUserWorklogs failed: %v", err)
+			}
+			if len(items) != tt.expected {
+				t.Errorf("expected %d work items, got %d", tt.expected, len(items))
+			}
+		})
+	}
+}
+
+func TestServer_UsersAndProjects(t *testing.T) {
+	srv, client, ctx := newTestServer(t)
+
+	me, err := client.GetCurrentUser(ctx)
+	if err != nil || me.Login != "admin" {
+		t.Fatalf("expected the current user admin, got %v, %v", me, err)
+	}
+	user, err := client.GetUserByLogin(ctx, "jdoe")
+	if err != nil || user.FullName != "John Doe" {
+		t.Fatalf("expected jdoe, got %v, %v", user, err)
+	}
+	project, err := client.GetProject(ctx, "PRJ")
+	if err != nil || project.Name != "Project" {
+		t.Fatalf("expected project PRJ, got %v, %v", project, err)
+	}
+
+	srv.AddProject("OPS", "Operations")
+	projects, err := client.ListProjects(ctx, 1, 10)
+	if err != nil || len(projects) != 1 || projects[0].ShortName != "OPS" {
+		t.Fatalf("expected the second page to hold OPS, got %v, %v", projects, err)
+	}
+
+	srv.RequireToken("perm:other")
+	_, err = client.GetCurrentUser(ctx)
+	if apiErr, ok := err.(*youtrack.APIError); !ok || apiErr.StatusCode != 401 {
+		t.Errorf("expected 401 for a rejected token, got %v", err)
+	}
+}